@@ -30,6 +30,7 @@ type Stock struct {
 	Total         int32                  `protobuf:"varint,4,opt,name=total,proto3" json:"total,omitempty"`         // Total physical stock
 	WarehouseId   string                 `protobuf:"bytes,5,opt,name=warehouse_id,json=warehouseId,proto3" json:"warehouse_id,omitempty"`
 	UpdatedAt     string                 `protobuf:"bytes,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	VariantId     string                 `protobuf:"bytes,7,opt,name=variant_id,json=variantId,proto3" json:"variant_id,omitempty"` // Set when the stock belongs to a product variant
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -106,11 +107,18 @@ func (x *Stock) GetUpdatedAt() string {
 	return ""
 }
 
+func (x *Stock) GetVariantId() string {
+	if x != nil {
+		return x.VariantId
+	}
+	return ""
+}
+
 // StockMovement represents a stock transaction
 type StockMovement struct {
 	state          protoimpl.MessageState `protogen:"open.v1"`
 	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	ProductId      string                 `protobuf:"bytes,2,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	ProductId      string                 `protobuf:"bytes,2,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`          // Product ID, or the variant ID for a variant's stock
 	MovementType   string                 `protobuf:"bytes,3,opt,name=movement_type,json=movementType,proto3" json:"movement_type,omitempty"` // INBOUND, OUTBOUND, RESERVED, RELEASED, COMMITTED, ADJUSTMENT
 	Quantity       int32                  `protobuf:"varint,4,opt,name=quantity,proto3" json:"quantity,omitempty"`
 	BeforeQuantity int32                  `protobuf:"varint,5,opt,name=before_quantity,json=beforeQuantity,proto3" json:"before_quantity,omitempty"`
@@ -223,18 +231,104 @@ func (x *StockMovement) GetCreatedAt() string {
 	return ""
 }
 
+// Reservation is one reserved line; lines reserved together share a reservation_id
+type Reservation struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReservationId string                 `protobuf:"bytes,1,opt,name=reservation_id,json=reservationId,proto3" json:"reservation_id,omitempty"`
+	OrderId       string                 `protobuf:"bytes,2,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	ProductId     string                 `protobuf:"bytes,3,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"` // Product ID, or the variant ID for a variant's stock
+	Quantity      int32                  `protobuf:"varint,4,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	Status        string                 `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`                        // PENDING, COMMITTED, RELEASED, EXPIRED
+	ExpiresAt     string                 `protobuf:"bytes,6,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"` // Released back to available stock if not confirmed by then
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Reservation) Reset() {
+	*x = Reservation{}
+	mi := &file_inventory_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Reservation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Reservation) ProtoMessage() {}
+
+func (x *Reservation) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Reservation.ProtoReflect.Descriptor instead.
+func (*Reservation) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Reservation) GetReservationId() string {
+	if x != nil {
+		return x.ReservationId
+	}
+	return ""
+}
+
+func (x *Reservation) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *Reservation) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *Reservation) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *Reservation) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Reservation) GetExpiresAt() string {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return ""
+}
+
 // GetStock
 type GetStockRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
 	WarehouseId   string                 `protobuf:"bytes,2,opt,name=warehouse_id,json=warehouseId,proto3" json:"warehouse_id,omitempty"` // Optional
+	VariantId     string                 `protobuf:"bytes,3,opt,name=variant_id,json=variantId,proto3" json:"variant_id,omitempty"`       // Optional, stock of this variant of the product
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *GetStockRequest) Reset() {
 	*x = GetStockRequest{}
-	mi := &file_inventory_proto_msgTypes[2]
+	mi := &file_inventory_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -246,7 +340,7 @@ func (x *GetStockRequest) String() string {
 func (*GetStockRequest) ProtoMessage() {}
 
 func (x *GetStockRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_inventory_proto_msgTypes[2]
+	mi := &file_inventory_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -259,7 +353,7 @@ func (x *GetStockRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetStockRequest.ProtoReflect.Descriptor instead.
 func (*GetStockRequest) Descriptor() ([]byte, []int) {
-	return file_inventory_proto_rawDescGZIP(), []int{2}
+	return file_inventory_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *GetStockRequest) GetProductId() string {
@@ -276,6 +370,13 @@ func (x *GetStockRequest) GetWarehouseId() string {
 	return ""
 }
 
+func (x *GetStockRequest) GetVariantId() string {
+	if x != nil {
+		return x.VariantId
+	}
+	return ""
+}
+
 type GetStockResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Stock         *Stock                 `protobuf:"bytes,1,opt,name=stock,proto3" json:"stock,omitempty"`
@@ -285,7 +386,7 @@ type GetStockResponse struct {
 
 func (x *GetStockResponse) Reset() {
 	*x = GetStockResponse{}
-	mi := &file_inventory_proto_msgTypes[3]
+	mi := &file_inventory_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -297,7 +398,7 @@ func (x *GetStockResponse) String() string {
 func (*GetStockResponse) ProtoMessage() {}
 
 func (x *GetStockResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_inventory_proto_msgTypes[3]
+	mi := &file_inventory_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -310,7 +411,7 @@ func (x *GetStockResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetStockResponse.ProtoReflect.Descriptor instead.
 func (*GetStockResponse) Descriptor() ([]byte, []int) {
-	return file_inventory_proto_rawDescGZIP(), []int{3}
+	return file_inventory_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *GetStockResponse) GetStock() *Stock {
@@ -320,6 +421,95 @@ func (x *GetStockResponse) GetStock() *Stock {
 	return nil
 }
 
+// GetStocks
+type GetStocksRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductIds    []string               `protobuf:"bytes,1,rep,name=product_ids,json=productIds,proto3" json:"product_ids,omitempty"` // Product or variant IDs
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetStocksRequest) Reset() {
+	*x = GetStocksRequest{}
+	mi := &file_inventory_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStocksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStocksRequest) ProtoMessage() {}
+
+func (x *GetStocksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStocksRequest.ProtoReflect.Descriptor instead.
+func (*GetStocksRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetStocksRequest) GetProductIds() []string {
+	if x != nil {
+		return x.ProductIds
+	}
+	return nil
+}
+
+type GetStocksResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Stocks        []*Stock               `protobuf:"bytes,1,rep,name=stocks,proto3" json:"stocks,omitempty"` // Products without a stock record are omitted
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetStocksResponse) Reset() {
+	*x = GetStocksResponse{}
+	mi := &file_inventory_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStocksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStocksResponse) ProtoMessage() {}
+
+func (x *GetStocksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStocksResponse.ProtoReflect.Descriptor instead.
+func (*GetStocksResponse) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetStocksResponse) GetStocks() []*Stock {
+	if x != nil {
+		return x.Stocks
+	}
+	return nil
+}
+
 // UpdateStock
 type UpdateStockRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -327,13 +517,14 @@ type UpdateStockRequest struct {
 	Quantity      int32                  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"` // Can be positive (add) or negative (remove)
 	WarehouseId   string                 `protobuf:"bytes,3,opt,name=warehouse_id,json=warehouseId,proto3" json:"warehouse_id,omitempty"`
 	Reason        string                 `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
+	VariantId     string                 `protobuf:"bytes,5,opt,name=variant_id,json=variantId,proto3" json:"variant_id,omitempty"` // Optional, updates the stock of this variant of the product
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *UpdateStockRequest) Reset() {
 	*x = UpdateStockRequest{}
-	mi := &file_inventory_proto_msgTypes[4]
+	mi := &file_inventory_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -345,7 +536,7 @@ func (x *UpdateStockRequest) String() string {
 func (*UpdateStockRequest) ProtoMessage() {}
 
 func (x *UpdateStockRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_inventory_proto_msgTypes[4]
+	mi := &file_inventory_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -358,7 +549,7 @@ func (x *UpdateStockRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateStockRequest.ProtoReflect.Descriptor instead.
 func (*UpdateStockRequest) Descriptor() ([]byte, []int) {
-	return file_inventory_proto_rawDescGZIP(), []int{4}
+	return file_inventory_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *UpdateStockRequest) GetProductId() string {
@@ -389,6 +580,13 @@ func (x *UpdateStockRequest) GetReason() string {
 	return ""
 }
 
+func (x *UpdateStockRequest) GetVariantId() string {
+	if x != nil {
+		return x.VariantId
+	}
+	return ""
+}
+
 type UpdateStockResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Stock         *Stock                 `protobuf:"bytes,1,opt,name=stock,proto3" json:"stock,omitempty"`
@@ -399,7 +597,7 @@ type UpdateStockResponse struct {
 
 func (x *UpdateStockResponse) Reset() {
 	*x = UpdateStockResponse{}
-	mi := &file_inventory_proto_msgTypes[5]
+	mi := &file_inventory_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -411,7 +609,7 @@ func (x *UpdateStockResponse) String() string {
 func (*UpdateStockResponse) ProtoMessage() {}
 
 func (x *UpdateStockResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_inventory_proto_msgTypes[5]
+	mi := &file_inventory_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -424,7 +622,7 @@ func (x *UpdateStockResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateStockResponse.ProtoReflect.Descriptor instead.
 func (*UpdateStockResponse) Descriptor() ([]byte, []int) {
-	return file_inventory_proto_rawDescGZIP(), []int{5}
+	return file_inventory_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *UpdateStockResponse) GetStock() *Stock {
@@ -453,7 +651,7 @@ type ReserveStockRequest struct {
 
 func (x *ReserveStockRequest) Reset() {
 	*x = ReserveStockRequest{}
-	mi := &file_inventory_proto_msgTypes[6]
+	mi := &file_inventory_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -465,7 +663,7 @@ func (x *ReserveStockRequest) String() string {
 func (*ReserveStockRequest) ProtoMessage() {}
 
 func (x *ReserveStockRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_inventory_proto_msgTypes[6]
+	mi := &file_inventory_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -478,7 +676,7 @@ func (x *ReserveStockRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ReserveStockRequest.ProtoReflect.Descriptor instead.
 func (*ReserveStockRequest) Descriptor() ([]byte, []int) {
-	return file_inventory_proto_rawDescGZIP(), []int{6}
+	return file_inventory_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *ReserveStockRequest) GetOrderId() string {
@@ -506,13 +704,14 @@ type StockItem struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
 	Quantity      int32                  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	VariantId     string                 `protobuf:"bytes,3,opt,name=variant_id,json=variantId,proto3" json:"variant_id,omitempty"` // Optional, the variant's stock is used instead of the product's
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *StockItem) Reset() {
 	*x = StockItem{}
-	mi := &file_inventory_proto_msgTypes[7]
+	mi := &file_inventory_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -524,7 +723,7 @@ func (x *StockItem) String() string {
 func (*StockItem) ProtoMessage() {}
 
 func (x *StockItem) ProtoReflect() protoreflect.Message {
-	mi := &file_inventory_proto_msgTypes[7]
+	mi := &file_inventory_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -537,7 +736,7 @@ func (x *StockItem) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StockItem.ProtoReflect.Descriptor instead.
 func (*StockItem) Descriptor() ([]byte, []int) {
-	return file_inventory_proto_rawDescGZIP(), []int{7}
+	return file_inventory_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *StockItem) GetProductId() string {
@@ -554,19 +753,28 @@ func (x *StockItem) GetQuantity() int32 {
 	return 0
 }
 
+func (x *StockItem) GetVariantId() string {
+	if x != nil {
+		return x.VariantId
+	}
+	return ""
+}
+
 type ReserveStockResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	ReservationId string                 `protobuf:"bytes,1,opt,name=reservation_id,json=reservationId,proto3" json:"reservation_id,omitempty"`
 	Success       bool                   `protobuf:"varint,2,opt,name=success,proto3" json:"success,omitempty"`
 	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
 	Stocks        []*Stock               `protobuf:"bytes,4,rep,name=stocks,proto3" json:"stocks,omitempty"`
+	ExpiresAt     string                 `protobuf:"bytes,5,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	Reservations  []*Reservation         `protobuf:"bytes,6,rep,name=reservations,proto3" json:"reservations,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ReserveStockResponse) Reset() {
 	*x = ReserveStockResponse{}
-	mi := &file_inventory_proto_msgTypes[8]
+	mi := &file_inventory_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -578,7 +786,7 @@ func (x *ReserveStockResponse) String() string {
 func (*ReserveStockResponse) ProtoMessage() {}
 
 func (x *ReserveStockResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_inventory_proto_msgTypes[8]
+	mi := &file_inventory_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -591,7 +799,7 @@ func (x *ReserveStockResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ReserveStockResponse.ProtoReflect.Descriptor instead.
 func (*ReserveStockResponse) Descriptor() ([]byte, []int) {
-	return file_inventory_proto_rawDescGZIP(), []int{8}
+	return file_inventory_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *ReserveStockResponse) GetReservationId() string {
@@ -622,10 +830,24 @@ func (x *ReserveStockResponse) GetStocks() []*Stock {
 	return nil
 }
 
+func (x *ReserveStockResponse) GetExpiresAt() string {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return ""
+}
+
+func (x *ReserveStockResponse) GetReservations() []*Reservation {
+	if x != nil {
+		return x.Reservations
+	}
+	return nil
+}
+
 // ReleaseStock
 type ReleaseStockRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	ReservationId string                 `protobuf:"bytes,1,opt,name=reservation_id,json=reservationId,proto3" json:"reservation_id,omitempty"`
+	ReservationId string                 `protobuf:"bytes,1,opt,name=reservation_id,json=reservationId,proto3" json:"reservation_id,omitempty"` // Used when order_id is empty
 	OrderId       string                 `protobuf:"bytes,2,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
 	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
 	unknownFields protoimpl.UnknownFields
@@ -634,7 +856,7 @@ type ReleaseStockRequest struct {
 
 func (x *ReleaseStockRequest) Reset() {
 	*x = ReleaseStockRequest{}
-	mi := &file_inventory_proto_msgTypes[9]
+	mi := &file_inventory_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -646,7 +868,7 @@ func (x *ReleaseStockRequest) String() string {
 func (*ReleaseStockRequest) ProtoMessage() {}
 
 func (x *ReleaseStockRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_inventory_proto_msgTypes[9]
+	mi := &file_inventory_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -659,7 +881,7 @@ func (x *ReleaseStockRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ReleaseStockRequest.ProtoReflect.Descriptor instead.
 func (*ReleaseStockRequest) Descriptor() ([]byte, []int) {
-	return file_inventory_proto_rawDescGZIP(), []int{9}
+	return file_inventory_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *ReleaseStockRequest) GetReservationId() string {
@@ -693,7 +915,7 @@ type ReleaseStockResponse struct {
 
 func (x *ReleaseStockResponse) Reset() {
 	*x = ReleaseStockResponse{}
-	mi := &file_inventory_proto_msgTypes[10]
+	mi := &file_inventory_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -705,7 +927,7 @@ func (x *ReleaseStockResponse) String() string {
 func (*ReleaseStockResponse) ProtoMessage() {}
 
 func (x *ReleaseStockResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_inventory_proto_msgTypes[10]
+	mi := &file_inventory_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -718,7 +940,7 @@ func (x *ReleaseStockResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ReleaseStockResponse.ProtoReflect.Descriptor instead.
 func (*ReleaseStockResponse) Descriptor() ([]byte, []int) {
-	return file_inventory_proto_rawDescGZIP(), []int{10}
+	return file_inventory_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *ReleaseStockResponse) GetSuccess() bool {
@@ -738,7 +960,7 @@ func (x *ReleaseStockResponse) GetMessage() string {
 // CommitStock
 type CommitStockRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	ReservationId string                 `protobuf:"bytes,1,opt,name=reservation_id,json=reservationId,proto3" json:"reservation_id,omitempty"`
+	ReservationId string                 `protobuf:"bytes,1,opt,name=reservation_id,json=reservationId,proto3" json:"reservation_id,omitempty"` // Used when order_id is empty
 	OrderId       string                 `protobuf:"bytes,2,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
@@ -746,7 +968,7 @@ type CommitStockRequest struct {
 
 func (x *CommitStockRequest) Reset() {
 	*x = CommitStockRequest{}
-	mi := &file_inventory_proto_msgTypes[11]
+	mi := &file_inventory_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -758,7 +980,7 @@ func (x *CommitStockRequest) String() string {
 func (*CommitStockRequest) ProtoMessage() {}
 
 func (x *CommitStockRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_inventory_proto_msgTypes[11]
+	mi := &file_inventory_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -771,7 +993,7 @@ func (x *CommitStockRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CommitStockRequest.ProtoReflect.Descriptor instead.
 func (*CommitStockRequest) Descriptor() ([]byte, []int) {
-	return file_inventory_proto_rawDescGZIP(), []int{11}
+	return file_inventory_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *CommitStockRequest) GetReservationId() string {
@@ -799,7 +1021,7 @@ type CommitStockResponse struct {
 
 func (x *CommitStockResponse) Reset() {
 	*x = CommitStockResponse{}
-	mi := &file_inventory_proto_msgTypes[12]
+	mi := &file_inventory_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -811,7 +1033,7 @@ func (x *CommitStockResponse) String() string {
 func (*CommitStockResponse) ProtoMessage() {}
 
 func (x *CommitStockResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_inventory_proto_msgTypes[12]
+	mi := &file_inventory_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -824,7 +1046,7 @@ func (x *CommitStockResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CommitStockResponse.ProtoReflect.Descriptor instead.
 func (*CommitStockResponse) Descriptor() ([]byte, []int) {
-	return file_inventory_proto_rawDescGZIP(), []int{12}
+	return file_inventory_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *CommitStockResponse) GetSuccess() bool {
@@ -848,6 +1070,224 @@ func (x *CommitStockResponse) GetMovements() []*StockMovement {
 	return nil
 }
 
+// ConfirmReservation
+type ConfirmReservationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReservationId string                 `protobuf:"bytes,1,opt,name=reservation_id,json=reservationId,proto3" json:"reservation_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfirmReservationRequest) Reset() {
+	*x = ConfirmReservationRequest{}
+	mi := &file_inventory_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfirmReservationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfirmReservationRequest) ProtoMessage() {}
+
+func (x *ConfirmReservationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfirmReservationRequest.ProtoReflect.Descriptor instead.
+func (*ConfirmReservationRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ConfirmReservationRequest) GetReservationId() string {
+	if x != nil {
+		return x.ReservationId
+	}
+	return ""
+}
+
+type ConfirmReservationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Reservations  []*Reservation         `protobuf:"bytes,3,rep,name=reservations,proto3" json:"reservations,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfirmReservationResponse) Reset() {
+	*x = ConfirmReservationResponse{}
+	mi := &file_inventory_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfirmReservationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfirmReservationResponse) ProtoMessage() {}
+
+func (x *ConfirmReservationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfirmReservationResponse.ProtoReflect.Descriptor instead.
+func (*ConfirmReservationResponse) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ConfirmReservationResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ConfirmReservationResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ConfirmReservationResponse) GetReservations() []*Reservation {
+	if x != nil {
+		return x.Reservations
+	}
+	return nil
+}
+
+// ReleaseReservation
+type ReleaseReservationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReservationId string                 `protobuf:"bytes,1,opt,name=reservation_id,json=reservationId,proto3" json:"reservation_id,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReleaseReservationRequest) Reset() {
+	*x = ReleaseReservationRequest{}
+	mi := &file_inventory_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReleaseReservationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReleaseReservationRequest) ProtoMessage() {}
+
+func (x *ReleaseReservationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReleaseReservationRequest.ProtoReflect.Descriptor instead.
+func (*ReleaseReservationRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ReleaseReservationRequest) GetReservationId() string {
+	if x != nil {
+		return x.ReservationId
+	}
+	return ""
+}
+
+func (x *ReleaseReservationRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type ReleaseReservationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Reservations  []*Reservation         `protobuf:"bytes,3,rep,name=reservations,proto3" json:"reservations,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReleaseReservationResponse) Reset() {
+	*x = ReleaseReservationResponse{}
+	mi := &file_inventory_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReleaseReservationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReleaseReservationResponse) ProtoMessage() {}
+
+func (x *ReleaseReservationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReleaseReservationResponse.ProtoReflect.Descriptor instead.
+func (*ReleaseReservationResponse) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ReleaseReservationResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ReleaseReservationResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ReleaseReservationResponse) GetReservations() []*Reservation {
+	if x != nil {
+		return x.Reservations
+	}
+	return nil
+}
+
 // CheckAvailability
 type CheckAvailabilityRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -859,7 +1299,7 @@ type CheckAvailabilityRequest struct {
 
 func (x *CheckAvailabilityRequest) Reset() {
 	*x = CheckAvailabilityRequest{}
-	mi := &file_inventory_proto_msgTypes[13]
+	mi := &file_inventory_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -871,7 +1311,7 @@ func (x *CheckAvailabilityRequest) String() string {
 func (*CheckAvailabilityRequest) ProtoMessage() {}
 
 func (x *CheckAvailabilityRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_inventory_proto_msgTypes[13]
+	mi := &file_inventory_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -884,7 +1324,7 @@ func (x *CheckAvailabilityRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CheckAvailabilityRequest.ProtoReflect.Descriptor instead.
 func (*CheckAvailabilityRequest) Descriptor() ([]byte, []int) {
-	return file_inventory_proto_rawDescGZIP(), []int{13}
+	return file_inventory_proto_rawDescGZIP(), []int{20}
 }
 
 func (x *CheckAvailabilityRequest) GetItems() []*StockItem {
@@ -911,7 +1351,7 @@ type CheckAvailabilityResponse struct {
 
 func (x *CheckAvailabilityResponse) Reset() {
 	*x = CheckAvailabilityResponse{}
-	mi := &file_inventory_proto_msgTypes[14]
+	mi := &file_inventory_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -923,7 +1363,7 @@ func (x *CheckAvailabilityResponse) String() string {
 func (*CheckAvailabilityResponse) ProtoMessage() {}
 
 func (x *CheckAvailabilityResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_inventory_proto_msgTypes[14]
+	mi := &file_inventory_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -936,7 +1376,7 @@ func (x *CheckAvailabilityResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CheckAvailabilityResponse.ProtoReflect.Descriptor instead.
 func (*CheckAvailabilityResponse) Descriptor() ([]byte, []int) {
-	return file_inventory_proto_rawDescGZIP(), []int{14}
+	return file_inventory_proto_rawDescGZIP(), []int{21}
 }
 
 func (x *CheckAvailabilityResponse) GetAvailable() bool {
@@ -958,13 +1398,14 @@ type UnavailableItem struct {
 	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
 	Requested     int32                  `protobuf:"varint,2,opt,name=requested,proto3" json:"requested,omitempty"`
 	Available     int32                  `protobuf:"varint,3,opt,name=available,proto3" json:"available,omitempty"`
+	VariantId     string                 `protobuf:"bytes,4,opt,name=variant_id,json=variantId,proto3" json:"variant_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *UnavailableItem) Reset() {
 	*x = UnavailableItem{}
-	mi := &file_inventory_proto_msgTypes[15]
+	mi := &file_inventory_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -976,7 +1417,7 @@ func (x *UnavailableItem) String() string {
 func (*UnavailableItem) ProtoMessage() {}
 
 func (x *UnavailableItem) ProtoReflect() protoreflect.Message {
-	mi := &file_inventory_proto_msgTypes[15]
+	mi := &file_inventory_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -989,7 +1430,7 @@ func (x *UnavailableItem) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UnavailableItem.ProtoReflect.Descriptor instead.
 func (*UnavailableItem) Descriptor() ([]byte, []int) {
-	return file_inventory_proto_rawDescGZIP(), []int{15}
+	return file_inventory_proto_rawDescGZIP(), []int{22}
 }
 
 func (x *UnavailableItem) GetProductId() string {
@@ -1013,19 +1454,30 @@ func (x *UnavailableItem) GetAvailable() int32 {
 	return 0
 }
 
+func (x *UnavailableItem) GetVariantId() string {
+	if x != nil {
+		return x.VariantId
+	}
+	return ""
+}
+
 // GetStockHistory
 type GetStockHistoryRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
 	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
 	Offset        int32                  `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
+	MovementType  string                 `protobuf:"bytes,4,opt,name=movement_type,json=movementType,proto3" json:"movement_type,omitempty"` // Lọc theo loại: INBOUND, OUTBOUND, ADJUSTMENT, RESERVED, RELEASED, COMMITTED
+	StartDate     string                 `protobuf:"bytes,5,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"`          // RFC3339, bao gồm
+	EndDate       string                 `protobuf:"bytes,6,opt,name=end_date,json=endDate,proto3" json:"end_date,omitempty"`                // RFC3339, bao gồm
+	VariantId     string                 `protobuf:"bytes,7,opt,name=variant_id,json=variantId,proto3" json:"variant_id,omitempty"`          // Lịch sử tồn kho của biến thể này (tùy chọn)
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *GetStockHistoryRequest) Reset() {
 	*x = GetStockHistoryRequest{}
-	mi := &file_inventory_proto_msgTypes[16]
+	mi := &file_inventory_proto_msgTypes[23]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1037,7 +1489,7 @@ func (x *GetStockHistoryRequest) String() string {
 func (*GetStockHistoryRequest) ProtoMessage() {}
 
 func (x *GetStockHistoryRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_inventory_proto_msgTypes[16]
+	mi := &file_inventory_proto_msgTypes[23]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1050,7 +1502,7 @@ func (x *GetStockHistoryRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetStockHistoryRequest.ProtoReflect.Descriptor instead.
 func (*GetStockHistoryRequest) Descriptor() ([]byte, []int) {
-	return file_inventory_proto_rawDescGZIP(), []int{16}
+	return file_inventory_proto_rawDescGZIP(), []int{23}
 }
 
 func (x *GetStockHistoryRequest) GetProductId() string {
@@ -1074,6 +1526,34 @@ func (x *GetStockHistoryRequest) GetOffset() int32 {
 	return 0
 }
 
+func (x *GetStockHistoryRequest) GetMovementType() string {
+	if x != nil {
+		return x.MovementType
+	}
+	return ""
+}
+
+func (x *GetStockHistoryRequest) GetStartDate() string {
+	if x != nil {
+		return x.StartDate
+	}
+	return ""
+}
+
+func (x *GetStockHistoryRequest) GetEndDate() string {
+	if x != nil {
+		return x.EndDate
+	}
+	return ""
+}
+
+func (x *GetStockHistoryRequest) GetVariantId() string {
+	if x != nil {
+		return x.VariantId
+	}
+	return ""
+}
+
 type GetStockHistoryResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Movements     []*StockMovement       `protobuf:"bytes,1,rep,name=movements,proto3" json:"movements,omitempty"`
@@ -1084,7 +1564,7 @@ type GetStockHistoryResponse struct {
 
 func (x *GetStockHistoryResponse) Reset() {
 	*x = GetStockHistoryResponse{}
-	mi := &file_inventory_proto_msgTypes[17]
+	mi := &file_inventory_proto_msgTypes[24]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1096,7 +1576,7 @@ func (x *GetStockHistoryResponse) String() string {
 func (*GetStockHistoryResponse) ProtoMessage() {}
 
 func (x *GetStockHistoryResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_inventory_proto_msgTypes[17]
+	mi := &file_inventory_proto_msgTypes[24]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1109,7 +1589,7 @@ func (x *GetStockHistoryResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetStockHistoryResponse.ProtoReflect.Descriptor instead.
 func (*GetStockHistoryResponse) Descriptor() ([]byte, []int) {
-	return file_inventory_proto_rawDescGZIP(), []int{17}
+	return file_inventory_proto_rawDescGZIP(), []int{24}
 }
 
 func (x *GetStockHistoryResponse) GetMovements() []*StockMovement {
@@ -1130,7 +1610,7 @@ var File_inventory_proto protoreflect.FileDescriptor
 
 const file_inventory_proto_rawDesc = "" +
 	"\n" +
-	"\x0finventory.proto\x12\x11inventory_service\"\xb8\x01\n" +
+	"\x0finventory.proto\x12\x11inventory_service\"\xd7\x01\n" +
 	"\x05Stock\x12\x1d\n" +
 	"\n" +
 	"product_id\x18\x01 \x01(\tR\tproductId\x12\x1c\n" +
@@ -1139,7 +1619,9 @@ const file_inventory_proto_rawDesc = "" +
 	"\x05total\x18\x04 \x01(\x05R\x05total\x12!\n" +
 	"\fwarehouse_id\x18\x05 \x01(\tR\vwarehouseId\x12\x1d\n" +
 	"\n" +
-	"updated_at\x18\x06 \x01(\tR\tupdatedAt\"\xd0\x02\n" +
+	"updated_at\x18\x06 \x01(\tR\tupdatedAt\x12\x1d\n" +
+	"\n" +
+	"variant_id\x18\a \x01(\tR\tvariantId\"\xd0\x02\n" +
 	"\rStockMovement\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
 	"\n" +
@@ -1153,35 +1635,58 @@ const file_inventory_proto_rawDesc = "" +
 	"\x06reason\x18\t \x01(\tR\x06reason\x12\x1d\n" +
 	"\n" +
 	"created_at\x18\n" +
-	" \x01(\tR\tcreatedAt\"S\n" +
+	" \x01(\tR\tcreatedAt\"\xc1\x01\n" +
+	"\vReservation\x12%\n" +
+	"\x0ereservation_id\x18\x01 \x01(\tR\rreservationId\x12\x19\n" +
+	"\border_id\x18\x02 \x01(\tR\aorderId\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x03 \x01(\tR\tproductId\x12\x1a\n" +
+	"\bquantity\x18\x04 \x01(\x05R\bquantity\x12\x16\n" +
+	"\x06status\x18\x05 \x01(\tR\x06status\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\x06 \x01(\tR\texpiresAt\"r\n" +
 	"\x0fGetStockRequest\x12\x1d\n" +
 	"\n" +
 	"product_id\x18\x01 \x01(\tR\tproductId\x12!\n" +
-	"\fwarehouse_id\x18\x02 \x01(\tR\vwarehouseId\"B\n" +
+	"\fwarehouse_id\x18\x02 \x01(\tR\vwarehouseId\x12\x1d\n" +
+	"\n" +
+	"variant_id\x18\x03 \x01(\tR\tvariantId\"B\n" +
 	"\x10GetStockResponse\x12.\n" +
-	"\x05stock\x18\x01 \x01(\v2\x18.inventory_service.StockR\x05stock\"\x8a\x01\n" +
+	"\x05stock\x18\x01 \x01(\v2\x18.inventory_service.StockR\x05stock\"3\n" +
+	"\x10GetStocksRequest\x12\x1f\n" +
+	"\vproduct_ids\x18\x01 \x03(\tR\n" +
+	"productIds\"E\n" +
+	"\x11GetStocksResponse\x120\n" +
+	"\x06stocks\x18\x01 \x03(\v2\x18.inventory_service.StockR\x06stocks\"\xa9\x01\n" +
 	"\x12UpdateStockRequest\x12\x1d\n" +
 	"\n" +
 	"product_id\x18\x01 \x01(\tR\tproductId\x12\x1a\n" +
 	"\bquantity\x18\x02 \x01(\x05R\bquantity\x12!\n" +
 	"\fwarehouse_id\x18\x03 \x01(\tR\vwarehouseId\x12\x16\n" +
-	"\x06reason\x18\x04 \x01(\tR\x06reason\"\x83\x01\n" +
+	"\x06reason\x18\x04 \x01(\tR\x06reason\x12\x1d\n" +
+	"\n" +
+	"variant_id\x18\x05 \x01(\tR\tvariantId\"\x83\x01\n" +
 	"\x13UpdateStockResponse\x12.\n" +
 	"\x05stock\x18\x01 \x01(\v2\x18.inventory_service.StockR\x05stock\x12<\n" +
 	"\bmovement\x18\x02 \x01(\v2 .inventory_service.StockMovementR\bmovement\"\x87\x01\n" +
 	"\x13ReserveStockRequest\x12\x19\n" +
 	"\border_id\x18\x01 \x01(\tR\aorderId\x122\n" +
 	"\x05items\x18\x02 \x03(\v2\x1c.inventory_service.StockItemR\x05items\x12!\n" +
-	"\fwarehouse_id\x18\x03 \x01(\tR\vwarehouseId\"F\n" +
+	"\fwarehouse_id\x18\x03 \x01(\tR\vwarehouseId\"e\n" +
 	"\tStockItem\x12\x1d\n" +
 	"\n" +
 	"product_id\x18\x01 \x01(\tR\tproductId\x12\x1a\n" +
-	"\bquantity\x18\x02 \x01(\x05R\bquantity\"\xa3\x01\n" +
+	"\bquantity\x18\x02 \x01(\x05R\bquantity\x12\x1d\n" +
+	"\n" +
+	"variant_id\x18\x03 \x01(\tR\tvariantId\"\x86\x02\n" +
 	"\x14ReserveStockResponse\x12%\n" +
 	"\x0ereservation_id\x18\x01 \x01(\tR\rreservationId\x12\x18\n" +
 	"\asuccess\x18\x02 \x01(\bR\asuccess\x12\x18\n" +
 	"\amessage\x18\x03 \x01(\tR\amessage\x120\n" +
-	"\x06stocks\x18\x04 \x03(\v2\x18.inventory_service.StockR\x06stocks\"o\n" +
+	"\x06stocks\x18\x04 \x03(\v2\x18.inventory_service.StockR\x06stocks\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\x05 \x01(\tR\texpiresAt\x12B\n" +
+	"\freservations\x18\x06 \x03(\v2\x1e.inventory_service.ReservationR\freservations\"o\n" +
 	"\x13ReleaseStockRequest\x12%\n" +
 	"\x0ereservation_id\x18\x01 \x01(\tR\rreservationId\x12\x19\n" +
 	"\border_id\x18\x02 \x01(\tR\aorderId\x12\x16\n" +
@@ -1195,32 +1700,56 @@ const file_inventory_proto_rawDesc = "" +
 	"\x13CommitStockResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
 	"\amessage\x18\x02 \x01(\tR\amessage\x12>\n" +
-	"\tmovements\x18\x03 \x03(\v2 .inventory_service.StockMovementR\tmovements\"q\n" +
+	"\tmovements\x18\x03 \x03(\v2 .inventory_service.StockMovementR\tmovements\"B\n" +
+	"\x19ConfirmReservationRequest\x12%\n" +
+	"\x0ereservation_id\x18\x01 \x01(\tR\rreservationId\"\x94\x01\n" +
+	"\x1aConfirmReservationResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12B\n" +
+	"\freservations\x18\x03 \x03(\v2\x1e.inventory_service.ReservationR\freservations\"Z\n" +
+	"\x19ReleaseReservationRequest\x12%\n" +
+	"\x0ereservation_id\x18\x01 \x01(\tR\rreservationId\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\"\x94\x01\n" +
+	"\x1aReleaseReservationResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12B\n" +
+	"\freservations\x18\x03 \x03(\v2\x1e.inventory_service.ReservationR\freservations\"q\n" +
 	"\x18CheckAvailabilityRequest\x122\n" +
 	"\x05items\x18\x01 \x03(\v2\x1c.inventory_service.StockItemR\x05items\x12!\n" +
 	"\fwarehouse_id\x18\x02 \x01(\tR\vwarehouseId\"\x8a\x01\n" +
 	"\x19CheckAvailabilityResponse\x12\x1c\n" +
 	"\tavailable\x18\x01 \x01(\bR\tavailable\x12O\n" +
-	"\x11unavailable_items\x18\x02 \x03(\v2\".inventory_service.UnavailableItemR\x10unavailableItems\"l\n" +
+	"\x11unavailable_items\x18\x02 \x03(\v2\".inventory_service.UnavailableItemR\x10unavailableItems\"\x8b\x01\n" +
 	"\x0fUnavailableItem\x12\x1d\n" +
 	"\n" +
 	"product_id\x18\x01 \x01(\tR\tproductId\x12\x1c\n" +
 	"\trequested\x18\x02 \x01(\x05R\trequested\x12\x1c\n" +
-	"\tavailable\x18\x03 \x01(\x05R\tavailable\"e\n" +
+	"\tavailable\x18\x03 \x01(\x05R\tavailable\x12\x1d\n" +
+	"\n" +
+	"variant_id\x18\x04 \x01(\tR\tvariantId\"\xe3\x01\n" +
 	"\x16GetStockHistoryRequest\x12\x1d\n" +
 	"\n" +
 	"product_id\x18\x01 \x01(\tR\tproductId\x12\x14\n" +
 	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x16\n" +
-	"\x06offset\x18\x03 \x01(\x05R\x06offset\"o\n" +
+	"\x06offset\x18\x03 \x01(\x05R\x06offset\x12#\n" +
+	"\rmovement_type\x18\x04 \x01(\tR\fmovementType\x12\x1d\n" +
+	"\n" +
+	"start_date\x18\x05 \x01(\tR\tstartDate\x12\x19\n" +
+	"\bend_date\x18\x06 \x01(\tR\aendDate\x12\x1d\n" +
+	"\n" +
+	"variant_id\x18\a \x01(\tR\tvariantId\"o\n" +
 	"\x17GetStockHistoryResponse\x12>\n" +
 	"\tmovements\x18\x01 \x03(\v2 .inventory_service.StockMovementR\tmovements\x12\x14\n" +
-	"\x05total\x18\x02 \x01(\x05R\x05total2\xbf\x05\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total2\xfd\a\n" +
 	"\x10InventoryService\x12S\n" +
-	"\bGetStock\x12\".inventory_service.GetStockRequest\x1a#.inventory_service.GetStockResponse\x12\\\n" +
+	"\bGetStock\x12\".inventory_service.GetStockRequest\x1a#.inventory_service.GetStockResponse\x12V\n" +
+	"\tGetStocks\x12#.inventory_service.GetStocksRequest\x1a$.inventory_service.GetStocksResponse\x12\\\n" +
 	"\vUpdateStock\x12%.inventory_service.UpdateStockRequest\x1a&.inventory_service.UpdateStockResponse\x12_\n" +
 	"\fReserveStock\x12&.inventory_service.ReserveStockRequest\x1a'.inventory_service.ReserveStockResponse\x12_\n" +
 	"\fReleaseStock\x12&.inventory_service.ReleaseStockRequest\x1a'.inventory_service.ReleaseStockResponse\x12\\\n" +
-	"\vCommitStock\x12%.inventory_service.CommitStockRequest\x1a&.inventory_service.CommitStockResponse\x12n\n" +
+	"\vCommitStock\x12%.inventory_service.CommitStockRequest\x1a&.inventory_service.CommitStockResponse\x12q\n" +
+	"\x12ConfirmReservation\x12,.inventory_service.ConfirmReservationRequest\x1a-.inventory_service.ConfirmReservationResponse\x12q\n" +
+	"\x12ReleaseReservation\x12,.inventory_service.ReleaseReservationRequest\x1a-.inventory_service.ReleaseReservationResponse\x12n\n" +
 	"\x11CheckAvailability\x12+.inventory_service.CheckAvailabilityRequest\x1a,.inventory_service.CheckAvailabilityResponse\x12h\n" +
 	"\x0fGetStockHistory\x12).inventory_service.GetStockHistoryRequest\x1a*.inventory_service.GetStockHistoryResponseB?Z=github.com/datngth03/ecommerce-go-app/proto/inventory_serviceb\x06proto3"
 
@@ -1236,56 +1765,73 @@ func file_inventory_proto_rawDescGZIP() []byte {
 	return file_inventory_proto_rawDescData
 }
 
-var file_inventory_proto_msgTypes = make([]protoimpl.MessageInfo, 18)
+var file_inventory_proto_msgTypes = make([]protoimpl.MessageInfo, 25)
 var file_inventory_proto_goTypes = []any{
-	(*Stock)(nil),                     // 0: inventory_service.Stock
-	(*StockMovement)(nil),             // 1: inventory_service.StockMovement
-	(*GetStockRequest)(nil),           // 2: inventory_service.GetStockRequest
-	(*GetStockResponse)(nil),          // 3: inventory_service.GetStockResponse
-	(*UpdateStockRequest)(nil),        // 4: inventory_service.UpdateStockRequest
-	(*UpdateStockResponse)(nil),       // 5: inventory_service.UpdateStockResponse
-	(*ReserveStockRequest)(nil),       // 6: inventory_service.ReserveStockRequest
-	(*StockItem)(nil),                 // 7: inventory_service.StockItem
-	(*ReserveStockResponse)(nil),      // 8: inventory_service.ReserveStockResponse
-	(*ReleaseStockRequest)(nil),       // 9: inventory_service.ReleaseStockRequest
-	(*ReleaseStockResponse)(nil),      // 10: inventory_service.ReleaseStockResponse
-	(*CommitStockRequest)(nil),        // 11: inventory_service.CommitStockRequest
-	(*CommitStockResponse)(nil),       // 12: inventory_service.CommitStockResponse
-	(*CheckAvailabilityRequest)(nil),  // 13: inventory_service.CheckAvailabilityRequest
-	(*CheckAvailabilityResponse)(nil), // 14: inventory_service.CheckAvailabilityResponse
-	(*UnavailableItem)(nil),           // 15: inventory_service.UnavailableItem
-	(*GetStockHistoryRequest)(nil),    // 16: inventory_service.GetStockHistoryRequest
-	(*GetStockHistoryResponse)(nil),   // 17: inventory_service.GetStockHistoryResponse
+	(*Stock)(nil),                      // 0: inventory_service.Stock
+	(*StockMovement)(nil),              // 1: inventory_service.StockMovement
+	(*Reservation)(nil),                // 2: inventory_service.Reservation
+	(*GetStockRequest)(nil),            // 3: inventory_service.GetStockRequest
+	(*GetStockResponse)(nil),           // 4: inventory_service.GetStockResponse
+	(*GetStocksRequest)(nil),           // 5: inventory_service.GetStocksRequest
+	(*GetStocksResponse)(nil),          // 6: inventory_service.GetStocksResponse
+	(*UpdateStockRequest)(nil),         // 7: inventory_service.UpdateStockRequest
+	(*UpdateStockResponse)(nil),        // 8: inventory_service.UpdateStockResponse
+	(*ReserveStockRequest)(nil),        // 9: inventory_service.ReserveStockRequest
+	(*StockItem)(nil),                  // 10: inventory_service.StockItem
+	(*ReserveStockResponse)(nil),       // 11: inventory_service.ReserveStockResponse
+	(*ReleaseStockRequest)(nil),        // 12: inventory_service.ReleaseStockRequest
+	(*ReleaseStockResponse)(nil),       // 13: inventory_service.ReleaseStockResponse
+	(*CommitStockRequest)(nil),         // 14: inventory_service.CommitStockRequest
+	(*CommitStockResponse)(nil),        // 15: inventory_service.CommitStockResponse
+	(*ConfirmReservationRequest)(nil),  // 16: inventory_service.ConfirmReservationRequest
+	(*ConfirmReservationResponse)(nil), // 17: inventory_service.ConfirmReservationResponse
+	(*ReleaseReservationRequest)(nil),  // 18: inventory_service.ReleaseReservationRequest
+	(*ReleaseReservationResponse)(nil), // 19: inventory_service.ReleaseReservationResponse
+	(*CheckAvailabilityRequest)(nil),   // 20: inventory_service.CheckAvailabilityRequest
+	(*CheckAvailabilityResponse)(nil),  // 21: inventory_service.CheckAvailabilityResponse
+	(*UnavailableItem)(nil),            // 22: inventory_service.UnavailableItem
+	(*GetStockHistoryRequest)(nil),     // 23: inventory_service.GetStockHistoryRequest
+	(*GetStockHistoryResponse)(nil),    // 24: inventory_service.GetStockHistoryResponse
 }
 var file_inventory_proto_depIdxs = []int32{
 	0,  // 0: inventory_service.GetStockResponse.stock:type_name -> inventory_service.Stock
-	0,  // 1: inventory_service.UpdateStockResponse.stock:type_name -> inventory_service.Stock
-	1,  // 2: inventory_service.UpdateStockResponse.movement:type_name -> inventory_service.StockMovement
-	7,  // 3: inventory_service.ReserveStockRequest.items:type_name -> inventory_service.StockItem
-	0,  // 4: inventory_service.ReserveStockResponse.stocks:type_name -> inventory_service.Stock
-	1,  // 5: inventory_service.CommitStockResponse.movements:type_name -> inventory_service.StockMovement
-	7,  // 6: inventory_service.CheckAvailabilityRequest.items:type_name -> inventory_service.StockItem
-	15, // 7: inventory_service.CheckAvailabilityResponse.unavailable_items:type_name -> inventory_service.UnavailableItem
-	1,  // 8: inventory_service.GetStockHistoryResponse.movements:type_name -> inventory_service.StockMovement
-	2,  // 9: inventory_service.InventoryService.GetStock:input_type -> inventory_service.GetStockRequest
-	4,  // 10: inventory_service.InventoryService.UpdateStock:input_type -> inventory_service.UpdateStockRequest
-	6,  // 11: inventory_service.InventoryService.ReserveStock:input_type -> inventory_service.ReserveStockRequest
-	9,  // 12: inventory_service.InventoryService.ReleaseStock:input_type -> inventory_service.ReleaseStockRequest
-	11, // 13: inventory_service.InventoryService.CommitStock:input_type -> inventory_service.CommitStockRequest
-	13, // 14: inventory_service.InventoryService.CheckAvailability:input_type -> inventory_service.CheckAvailabilityRequest
-	16, // 15: inventory_service.InventoryService.GetStockHistory:input_type -> inventory_service.GetStockHistoryRequest
-	3,  // 16: inventory_service.InventoryService.GetStock:output_type -> inventory_service.GetStockResponse
-	5,  // 17: inventory_service.InventoryService.UpdateStock:output_type -> inventory_service.UpdateStockResponse
-	8,  // 18: inventory_service.InventoryService.ReserveStock:output_type -> inventory_service.ReserveStockResponse
-	10, // 19: inventory_service.InventoryService.ReleaseStock:output_type -> inventory_service.ReleaseStockResponse
-	12, // 20: inventory_service.InventoryService.CommitStock:output_type -> inventory_service.CommitStockResponse
-	14, // 21: inventory_service.InventoryService.CheckAvailability:output_type -> inventory_service.CheckAvailabilityResponse
-	17, // 22: inventory_service.InventoryService.GetStockHistory:output_type -> inventory_service.GetStockHistoryResponse
-	16, // [16:23] is the sub-list for method output_type
-	9,  // [9:16] is the sub-list for method input_type
-	9,  // [9:9] is the sub-list for extension type_name
-	9,  // [9:9] is the sub-list for extension extendee
-	0,  // [0:9] is the sub-list for field type_name
+	0,  // 1: inventory_service.GetStocksResponse.stocks:type_name -> inventory_service.Stock
+	0,  // 2: inventory_service.UpdateStockResponse.stock:type_name -> inventory_service.Stock
+	1,  // 3: inventory_service.UpdateStockResponse.movement:type_name -> inventory_service.StockMovement
+	10, // 4: inventory_service.ReserveStockRequest.items:type_name -> inventory_service.StockItem
+	0,  // 5: inventory_service.ReserveStockResponse.stocks:type_name -> inventory_service.Stock
+	2,  // 6: inventory_service.ReserveStockResponse.reservations:type_name -> inventory_service.Reservation
+	1,  // 7: inventory_service.CommitStockResponse.movements:type_name -> inventory_service.StockMovement
+	2,  // 8: inventory_service.ConfirmReservationResponse.reservations:type_name -> inventory_service.Reservation
+	2,  // 9: inventory_service.ReleaseReservationResponse.reservations:type_name -> inventory_service.Reservation
+	10, // 10: inventory_service.CheckAvailabilityRequest.items:type_name -> inventory_service.StockItem
+	22, // 11: inventory_service.CheckAvailabilityResponse.unavailable_items:type_name -> inventory_service.UnavailableItem
+	1,  // 12: inventory_service.GetStockHistoryResponse.movements:type_name -> inventory_service.StockMovement
+	3,  // 13: inventory_service.InventoryService.GetStock:input_type -> inventory_service.GetStockRequest
+	5,  // 14: inventory_service.InventoryService.GetStocks:input_type -> inventory_service.GetStocksRequest
+	7,  // 15: inventory_service.InventoryService.UpdateStock:input_type -> inventory_service.UpdateStockRequest
+	9,  // 16: inventory_service.InventoryService.ReserveStock:input_type -> inventory_service.ReserveStockRequest
+	12, // 17: inventory_service.InventoryService.ReleaseStock:input_type -> inventory_service.ReleaseStockRequest
+	14, // 18: inventory_service.InventoryService.CommitStock:input_type -> inventory_service.CommitStockRequest
+	16, // 19: inventory_service.InventoryService.ConfirmReservation:input_type -> inventory_service.ConfirmReservationRequest
+	18, // 20: inventory_service.InventoryService.ReleaseReservation:input_type -> inventory_service.ReleaseReservationRequest
+	20, // 21: inventory_service.InventoryService.CheckAvailability:input_type -> inventory_service.CheckAvailabilityRequest
+	23, // 22: inventory_service.InventoryService.GetStockHistory:input_type -> inventory_service.GetStockHistoryRequest
+	4,  // 23: inventory_service.InventoryService.GetStock:output_type -> inventory_service.GetStockResponse
+	6,  // 24: inventory_service.InventoryService.GetStocks:output_type -> inventory_service.GetStocksResponse
+	8,  // 25: inventory_service.InventoryService.UpdateStock:output_type -> inventory_service.UpdateStockResponse
+	11, // 26: inventory_service.InventoryService.ReserveStock:output_type -> inventory_service.ReserveStockResponse
+	13, // 27: inventory_service.InventoryService.ReleaseStock:output_type -> inventory_service.ReleaseStockResponse
+	15, // 28: inventory_service.InventoryService.CommitStock:output_type -> inventory_service.CommitStockResponse
+	17, // 29: inventory_service.InventoryService.ConfirmReservation:output_type -> inventory_service.ConfirmReservationResponse
+	19, // 30: inventory_service.InventoryService.ReleaseReservation:output_type -> inventory_service.ReleaseReservationResponse
+	21, // 31: inventory_service.InventoryService.CheckAvailability:output_type -> inventory_service.CheckAvailabilityResponse
+	24, // 32: inventory_service.InventoryService.GetStockHistory:output_type -> inventory_service.GetStockHistoryResponse
+	23, // [23:33] is the sub-list for method output_type
+	13, // [13:23] is the sub-list for method input_type
+	13, // [13:13] is the sub-list for extension type_name
+	13, // [13:13] is the sub-list for extension extendee
+	0,  // [0:13] is the sub-list for field type_name
 }
 
 func init() { file_inventory_proto_init() }
@@ -1299,7 +1845,7 @@ func file_inventory_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_inventory_proto_rawDesc), len(file_inventory_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   18,
+			NumMessages:   25,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -19,13 +19,16 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	InventoryService_GetStock_FullMethodName          = "/inventory_service.InventoryService/GetStock"
-	InventoryService_UpdateStock_FullMethodName       = "/inventory_service.InventoryService/UpdateStock"
-	InventoryService_ReserveStock_FullMethodName      = "/inventory_service.InventoryService/ReserveStock"
-	InventoryService_ReleaseStock_FullMethodName      = "/inventory_service.InventoryService/ReleaseStock"
-	InventoryService_CommitStock_FullMethodName       = "/inventory_service.InventoryService/CommitStock"
-	InventoryService_CheckAvailability_FullMethodName = "/inventory_service.InventoryService/CheckAvailability"
-	InventoryService_GetStockHistory_FullMethodName   = "/inventory_service.InventoryService/GetStockHistory"
+	InventoryService_GetStock_FullMethodName           = "/inventory_service.InventoryService/GetStock"
+	InventoryService_GetStocks_FullMethodName          = "/inventory_service.InventoryService/GetStocks"
+	InventoryService_UpdateStock_FullMethodName        = "/inventory_service.InventoryService/UpdateStock"
+	InventoryService_ReserveStock_FullMethodName       = "/inventory_service.InventoryService/ReserveStock"
+	InventoryService_ReleaseStock_FullMethodName       = "/inventory_service.InventoryService/ReleaseStock"
+	InventoryService_CommitStock_FullMethodName        = "/inventory_service.InventoryService/CommitStock"
+	InventoryService_ConfirmReservation_FullMethodName = "/inventory_service.InventoryService/ConfirmReservation"
+	InventoryService_ReleaseReservation_FullMethodName = "/inventory_service.InventoryService/ReleaseReservation"
+	InventoryService_CheckAvailability_FullMethodName  = "/inventory_service.InventoryService/CheckAvailability"
+	InventoryService_GetStockHistory_FullMethodName    = "/inventory_service.InventoryService/GetStockHistory"
 )
 
 // InventoryServiceClient is the client API for InventoryService service.
@@ -36,6 +39,8 @@ const (
 type InventoryServiceClient interface {
 	// GetStock retrieves current stock for a product
 	GetStock(ctx context.Context, in *GetStockRequest, opts ...grpc.CallOption) (*GetStockResponse, error)
+	// GetStocks retrieves current stock for multiple products in one call
+	GetStocks(ctx context.Context, in *GetStocksRequest, opts ...grpc.CallOption) (*GetStocksResponse, error)
 	// UpdateStock updates stock quantity for a product
 	UpdateStock(ctx context.Context, in *UpdateStockRequest, opts ...grpc.CallOption) (*UpdateStockResponse, error)
 	// ReserveStock reserves stock for an order (pending payment)
@@ -44,6 +49,10 @@ type InventoryServiceClient interface {
 	ReleaseStock(ctx context.Context, in *ReleaseStockRequest, opts ...grpc.CallOption) (*ReleaseStockResponse, error)
 	// CommitStock commits reserved stock (payment completed)
 	CommitStock(ctx context.Context, in *CommitStockRequest, opts ...grpc.CallOption) (*CommitStockResponse, error)
+	// ConfirmReservation commits a reservation by its ID (payment completed)
+	ConfirmReservation(ctx context.Context, in *ConfirmReservationRequest, opts ...grpc.CallOption) (*ConfirmReservationResponse, error)
+	// ReleaseReservation returns a reservation to available stock by its ID
+	ReleaseReservation(ctx context.Context, in *ReleaseReservationRequest, opts ...grpc.CallOption) (*ReleaseReservationResponse, error)
 	// CheckAvailability checks if products are available
 	CheckAvailability(ctx context.Context, in *CheckAvailabilityRequest, opts ...grpc.CallOption) (*CheckAvailabilityResponse, error)
 	// GetStockHistory retrieves stock movement history
@@ -68,6 +77,16 @@ func (c *inventoryServiceClient) GetStock(ctx context.Context, in *GetStockReque
 	return out, nil
 }
 
+func (c *inventoryServiceClient) GetStocks(ctx context.Context, in *GetStocksRequest, opts ...grpc.CallOption) (*GetStocksResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetStocksResponse)
+	err := c.cc.Invoke(ctx, InventoryService_GetStocks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *inventoryServiceClient) UpdateStock(ctx context.Context, in *UpdateStockRequest, opts ...grpc.CallOption) (*UpdateStockResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(UpdateStockResponse)
@@ -108,6 +127,26 @@ func (c *inventoryServiceClient) CommitStock(ctx context.Context, in *CommitStoc
 	return out, nil
 }
 
+func (c *inventoryServiceClient) ConfirmReservation(ctx context.Context, in *ConfirmReservationRequest, opts ...grpc.CallOption) (*ConfirmReservationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ConfirmReservationResponse)
+	err := c.cc.Invoke(ctx, InventoryService_ConfirmReservation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) ReleaseReservation(ctx context.Context, in *ReleaseReservationRequest, opts ...grpc.CallOption) (*ReleaseReservationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReleaseReservationResponse)
+	err := c.cc.Invoke(ctx, InventoryService_ReleaseReservation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *inventoryServiceClient) CheckAvailability(ctx context.Context, in *CheckAvailabilityRequest, opts ...grpc.CallOption) (*CheckAvailabilityResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(CheckAvailabilityResponse)
@@ -136,6 +175,8 @@ func (c *inventoryServiceClient) GetStockHistory(ctx context.Context, in *GetSto
 type InventoryServiceServer interface {
 	// GetStock retrieves current stock for a product
 	GetStock(context.Context, *GetStockRequest) (*GetStockResponse, error)
+	// GetStocks retrieves current stock for multiple products in one call
+	GetStocks(context.Context, *GetStocksRequest) (*GetStocksResponse, error)
 	// UpdateStock updates stock quantity for a product
 	UpdateStock(context.Context, *UpdateStockRequest) (*UpdateStockResponse, error)
 	// ReserveStock reserves stock for an order (pending payment)
@@ -144,6 +185,10 @@ type InventoryServiceServer interface {
 	ReleaseStock(context.Context, *ReleaseStockRequest) (*ReleaseStockResponse, error)
 	// CommitStock commits reserved stock (payment completed)
 	CommitStock(context.Context, *CommitStockRequest) (*CommitStockResponse, error)
+	// ConfirmReservation commits a reservation by its ID (payment completed)
+	ConfirmReservation(context.Context, *ConfirmReservationRequest) (*ConfirmReservationResponse, error)
+	// ReleaseReservation returns a reservation to available stock by its ID
+	ReleaseReservation(context.Context, *ReleaseReservationRequest) (*ReleaseReservationResponse, error)
 	// CheckAvailability checks if products are available
 	CheckAvailability(context.Context, *CheckAvailabilityRequest) (*CheckAvailabilityResponse, error)
 	// GetStockHistory retrieves stock movement history
@@ -161,6 +206,9 @@ type UnimplementedInventoryServiceServer struct{}
 func (UnimplementedInventoryServiceServer) GetStock(context.Context, *GetStockRequest) (*GetStockResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetStock not implemented")
 }
+func (UnimplementedInventoryServiceServer) GetStocks(context.Context, *GetStocksRequest) (*GetStocksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStocks not implemented")
+}
 func (UnimplementedInventoryServiceServer) UpdateStock(context.Context, *UpdateStockRequest) (*UpdateStockResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateStock not implemented")
 }
@@ -173,6 +221,12 @@ func (UnimplementedInventoryServiceServer) ReleaseStock(context.Context, *Releas
 func (UnimplementedInventoryServiceServer) CommitStock(context.Context, *CommitStockRequest) (*CommitStockResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CommitStock not implemented")
 }
+func (UnimplementedInventoryServiceServer) ConfirmReservation(context.Context, *ConfirmReservationRequest) (*ConfirmReservationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ConfirmReservation not implemented")
+}
+func (UnimplementedInventoryServiceServer) ReleaseReservation(context.Context, *ReleaseReservationRequest) (*ReleaseReservationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReleaseReservation not implemented")
+}
 func (UnimplementedInventoryServiceServer) CheckAvailability(context.Context, *CheckAvailabilityRequest) (*CheckAvailabilityResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CheckAvailability not implemented")
 }
@@ -218,6 +272,24 @@ func _InventoryService_GetStock_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _InventoryService_GetStocks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStocksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).GetStocks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_GetStocks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).GetStocks(ctx, req.(*GetStocksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _InventoryService_UpdateStock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(UpdateStockRequest)
 	if err := dec(in); err != nil {
@@ -290,6 +362,42 @@ func _InventoryService_CommitStock_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _InventoryService_ConfirmReservation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConfirmReservationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).ConfirmReservation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_ConfirmReservation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).ConfirmReservation(ctx, req.(*ConfirmReservationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_ReleaseReservation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReleaseReservationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).ReleaseReservation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_ReleaseReservation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).ReleaseReservation(ctx, req.(*ReleaseReservationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _InventoryService_CheckAvailability_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(CheckAvailabilityRequest)
 	if err := dec(in); err != nil {
@@ -337,6 +445,10 @@ var InventoryService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetStock",
 			Handler:    _InventoryService_GetStock_Handler,
 		},
+		{
+			MethodName: "GetStocks",
+			Handler:    _InventoryService_GetStocks_Handler,
+		},
 		{
 			MethodName: "UpdateStock",
 			Handler:    _InventoryService_UpdateStock_Handler,
@@ -353,6 +465,14 @@ var InventoryService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CommitStock",
 			Handler:    _InventoryService_CommitStock_Handler,
 		},
+		{
+			MethodName: "ConfirmReservation",
+			Handler:    _InventoryService_ConfirmReservation_Handler,
+		},
+		{
+			MethodName: "ReleaseReservation",
+			Handler:    _InventoryService_ReleaseReservation_Handler,
+		},
 		{
 			MethodName: "CheckAvailability",
 			Handler:    _InventoryService_CheckAvailability_Handler,
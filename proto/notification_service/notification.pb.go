@@ -22,22 +22,23 @@ const (
 )
 
 type Notification struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Type          string                 `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
-	Channel       string                 `protobuf:"bytes,4,opt,name=channel,proto3" json:"channel,omitempty"`
-	Recipient     string                 `protobuf:"bytes,5,opt,name=recipient,proto3" json:"recipient,omitempty"`
-	Subject       string                 `protobuf:"bytes,6,opt,name=subject,proto3" json:"subject,omitempty"`
-	Content       string                 `protobuf:"bytes,7,opt,name=content,proto3" json:"content,omitempty"`
-	Status        string                 `protobuf:"bytes,8,opt,name=status,proto3" json:"status,omitempty"`
-	ErrorMessage  string                 `protobuf:"bytes,9,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
-	TemplateId    string                 `protobuf:"bytes,10,opt,name=template_id,json=templateId,proto3" json:"template_id,omitempty"`
-	Metadata      string                 `protobuf:"bytes,11,opt,name=metadata,proto3" json:"metadata,omitempty"`
-	CreatedAt     string                 `protobuf:"bytes,12,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	SentAt        string                 `protobuf:"bytes,13,opt,name=sent_at,json=sentAt,proto3" json:"sent_at,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Id                string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId            string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Type              string                 `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	Channel           string                 `protobuf:"bytes,4,opt,name=channel,proto3" json:"channel,omitempty"`
+	Recipient         string                 `protobuf:"bytes,5,opt,name=recipient,proto3" json:"recipient,omitempty"`
+	Subject           string                 `protobuf:"bytes,6,opt,name=subject,proto3" json:"subject,omitempty"`
+	Content           string                 `protobuf:"bytes,7,opt,name=content,proto3" json:"content,omitempty"`
+	Status            string                 `protobuf:"bytes,8,opt,name=status,proto3" json:"status,omitempty"`
+	ErrorMessage      string                 `protobuf:"bytes,9,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	TemplateId        string                 `protobuf:"bytes,10,opt,name=template_id,json=templateId,proto3" json:"template_id,omitempty"`
+	Metadata          string                 `protobuf:"bytes,11,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	CreatedAt         string                 `protobuf:"bytes,12,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	SentAt            string                 `protobuf:"bytes,13,opt,name=sent_at,json=sentAt,proto3" json:"sent_at,omitempty"`
+	ProviderMessageId string                 `protobuf:"bytes,14,opt,name=provider_message_id,json=providerMessageId,proto3" json:"provider_message_id,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
 }
 
 func (x *Notification) Reset() {
@@ -161,6 +162,13 @@ func (x *Notification) GetSentAt() string {
 	return ""
 }
 
+func (x *Notification) GetProviderMessageId() string {
+	if x != nil {
+		return x.ProviderMessageId
+	}
+	return ""
+}
+
 type Template struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -549,27 +557,31 @@ func (x *SendSMSResponse) GetMessage() string {
 	return ""
 }
 
-type GetNotificationRequest struct {
-	state          protoimpl.MessageState `protogen:"open.v1"`
-	NotificationId string                 `protobuf:"bytes,1,opt,name=notification_id,json=notificationId,proto3" json:"notification_id,omitempty"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+type SendPushNotificationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	DeviceToken   string                 `protobuf:"bytes,2,opt,name=device_token,json=deviceToken,proto3" json:"device_token,omitempty"` // Optional, when empty the push is sent to all registered devices of user_id
+	Title         string                 `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
+	Body          string                 `protobuf:"bytes,4,opt,name=body,proto3" json:"body,omitempty"`
+	Data          map[string]string      `protobuf:"bytes,5,rep,name=data,proto3" json:"data,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetNotificationRequest) Reset() {
-	*x = GetNotificationRequest{}
+func (x *SendPushNotificationRequest) Reset() {
+	*x = SendPushNotificationRequest{}
 	mi := &file_notification_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetNotificationRequest) String() string {
+func (x *SendPushNotificationRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetNotificationRequest) ProtoMessage() {}
+func (*SendPushNotificationRequest) ProtoMessage() {}
 
-func (x *GetNotificationRequest) ProtoReflect() protoreflect.Message {
+func (x *SendPushNotificationRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_notification_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -581,39 +593,73 @@ func (x *GetNotificationRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetNotificationRequest.ProtoReflect.Descriptor instead.
-func (*GetNotificationRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use SendPushNotificationRequest.ProtoReflect.Descriptor instead.
+func (*SendPushNotificationRequest) Descriptor() ([]byte, []int) {
 	return file_notification_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *GetNotificationRequest) GetNotificationId() string {
+func (x *SendPushNotificationRequest) GetUserId() string {
 	if x != nil {
-		return x.NotificationId
+		return x.UserId
 	}
 	return ""
 }
 
-type GetNotificationResponse struct {
+func (x *SendPushNotificationRequest) GetDeviceToken() string {
+	if x != nil {
+		return x.DeviceToken
+	}
+	return ""
+}
+
+func (x *SendPushNotificationRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *SendPushNotificationRequest) GetBody() string {
+	if x != nil {
+		return x.Body
+	}
+	return ""
+}
+
+func (x *SendPushNotificationRequest) GetData() map[string]string {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type SendPushNotificationResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Notification  *Notification          `protobuf:"bytes,1,opt,name=notification,proto3" json:"notification,omitempty"`
+	Success       bool                   `protobuf:"varint,2,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	TokenInvalid  bool                   `protobuf:"varint,4,opt,name=token_invalid,json=tokenInvalid,proto3" json:"token_invalid,omitempty"` // The device token was rejected and marked stale
+	Notifications []*Notification        `protobuf:"bytes,5,rep,name=notifications,proto3" json:"notifications,omitempty"`                    // One per device when sent to a user
+	Sent          int32                  `protobuf:"varint,6,opt,name=sent,proto3" json:"sent,omitempty"`
+	Failed        int32                  `protobuf:"varint,7,opt,name=failed,proto3" json:"failed,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetNotificationResponse) Reset() {
-	*x = GetNotificationResponse{}
+func (x *SendPushNotificationResponse) Reset() {
+	*x = SendPushNotificationResponse{}
 	mi := &file_notification_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetNotificationResponse) String() string {
+func (x *SendPushNotificationResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetNotificationResponse) ProtoMessage() {}
+func (*SendPushNotificationResponse) ProtoMessage() {}
 
-func (x *GetNotificationResponse) ProtoReflect() protoreflect.Message {
+func (x *SendPushNotificationResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_notification_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -625,42 +671,85 @@ func (x *GetNotificationResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetNotificationResponse.ProtoReflect.Descriptor instead.
-func (*GetNotificationResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use SendPushNotificationResponse.ProtoReflect.Descriptor instead.
+func (*SendPushNotificationResponse) Descriptor() ([]byte, []int) {
 	return file_notification_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *GetNotificationResponse) GetNotification() *Notification {
+func (x *SendPushNotificationResponse) GetNotification() *Notification {
 	if x != nil {
 		return x.Notification
 	}
 	return nil
 }
 
-type GetNotificationHistoryRequest struct {
+func (x *SendPushNotificationResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *SendPushNotificationResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *SendPushNotificationResponse) GetTokenInvalid() bool {
+	if x != nil {
+		return x.TokenInvalid
+	}
+	return false
+}
+
+func (x *SendPushNotificationResponse) GetNotifications() []*Notification {
+	if x != nil {
+		return x.Notifications
+	}
+	return nil
+}
+
+func (x *SendPushNotificationResponse) GetSent() int32 {
+	if x != nil {
+		return x.Sent
+	}
+	return 0
+}
+
+func (x *SendPushNotificationResponse) GetFailed() int32 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
+}
+
+type DeviceToken struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Type          string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
-	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
-	Offset        int32                  `protobuf:"varint,4,opt,name=offset,proto3" json:"offset,omitempty"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Platform      string                 `protobuf:"bytes,3,opt,name=platform,proto3" json:"platform,omitempty"`
+	CreatedAt     string                 `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     string                 `protobuf:"bytes,5,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetNotificationHistoryRequest) Reset() {
-	*x = GetNotificationHistoryRequest{}
+func (x *DeviceToken) Reset() {
+	*x = DeviceToken{}
 	mi := &file_notification_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetNotificationHistoryRequest) String() string {
+func (x *DeviceToken) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetNotificationHistoryRequest) ProtoMessage() {}
+func (*DeviceToken) ProtoMessage() {}
 
-func (x *GetNotificationHistoryRequest) ProtoReflect() protoreflect.Message {
+func (x *DeviceToken) ProtoReflect() protoreflect.Message {
 	mi := &file_notification_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -672,61 +761,69 @@ func (x *GetNotificationHistoryRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetNotificationHistoryRequest.ProtoReflect.Descriptor instead.
-func (*GetNotificationHistoryRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use DeviceToken.ProtoReflect.Descriptor instead.
+func (*DeviceToken) Descriptor() ([]byte, []int) {
 	return file_notification_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *GetNotificationHistoryRequest) GetUserId() string {
+func (x *DeviceToken) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *DeviceToken) GetUserId() string {
 	if x != nil {
 		return x.UserId
 	}
 	return ""
 }
 
-func (x *GetNotificationHistoryRequest) GetType() string {
+func (x *DeviceToken) GetPlatform() string {
 	if x != nil {
-		return x.Type
+		return x.Platform
 	}
 	return ""
 }
 
-func (x *GetNotificationHistoryRequest) GetLimit() int32 {
+func (x *DeviceToken) GetCreatedAt() string {
 	if x != nil {
-		return x.Limit
+		return x.CreatedAt
 	}
-	return 0
+	return ""
 }
 
-func (x *GetNotificationHistoryRequest) GetOffset() int32 {
+func (x *DeviceToken) GetUpdatedAt() string {
 	if x != nil {
-		return x.Offset
+		return x.UpdatedAt
 	}
-	return 0
+	return ""
 }
 
-type GetNotificationHistoryResponse struct {
+type RegisterDeviceTokenRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Notifications []*Notification        `protobuf:"bytes,1,rep,name=notifications,proto3" json:"notifications,omitempty"`
-	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	DeviceToken   string                 `protobuf:"bytes,2,opt,name=device_token,json=deviceToken,proto3" json:"device_token,omitempty"`
+	Platform      string                 `protobuf:"bytes,3,opt,name=platform,proto3" json:"platform,omitempty"` // ios, android, web
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetNotificationHistoryResponse) Reset() {
-	*x = GetNotificationHistoryResponse{}
+func (x *RegisterDeviceTokenRequest) Reset() {
+	*x = RegisterDeviceTokenRequest{}
 	mi := &file_notification_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetNotificationHistoryResponse) String() string {
+func (x *RegisterDeviceTokenRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetNotificationHistoryResponse) ProtoMessage() {}
+func (*RegisterDeviceTokenRequest) ProtoMessage() {}
 
-func (x *GetNotificationHistoryResponse) ProtoReflect() protoreflect.Message {
+func (x *RegisterDeviceTokenRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_notification_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -738,79 +835,1159 @@ func (x *GetNotificationHistoryResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetNotificationHistoryResponse.ProtoReflect.Descriptor instead.
-func (*GetNotificationHistoryResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use RegisterDeviceTokenRequest.ProtoReflect.Descriptor instead.
+func (*RegisterDeviceTokenRequest) Descriptor() ([]byte, []int) {
 	return file_notification_proto_rawDescGZIP(), []int{9}
 }
 
-func (x *GetNotificationHistoryResponse) GetNotifications() []*Notification {
+func (x *RegisterDeviceTokenRequest) GetUserId() string {
 	if x != nil {
-		return x.Notifications
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *RegisterDeviceTokenRequest) GetDeviceToken() string {
+	if x != nil {
+		return x.DeviceToken
+	}
+	return ""
+}
+
+func (x *RegisterDeviceTokenRequest) GetPlatform() string {
+	if x != nil {
+		return x.Platform
+	}
+	return ""
+}
+
+type RegisterDeviceTokenResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Device        *DeviceToken           `protobuf:"bytes,1,opt,name=device,proto3" json:"device,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RegisterDeviceTokenResponse) Reset() {
+	*x = RegisterDeviceTokenResponse{}
+	mi := &file_notification_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegisterDeviceTokenResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegisterDeviceTokenResponse) ProtoMessage() {}
+
+func (x *RegisterDeviceTokenResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_notification_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegisterDeviceTokenResponse.ProtoReflect.Descriptor instead.
+func (*RegisterDeviceTokenResponse) Descriptor() ([]byte, []int) {
+	return file_notification_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *RegisterDeviceTokenResponse) GetDevice() *DeviceToken {
+	if x != nil {
+		return x.Device
 	}
 	return nil
 }
 
-func (x *GetNotificationHistoryResponse) GetTotal() int32 {
+type UnregisterDeviceTokenRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	DeviceToken   string                 `protobuf:"bytes,2,opt,name=device_token,json=deviceToken,proto3" json:"device_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnregisterDeviceTokenRequest) Reset() {
+	*x = UnregisterDeviceTokenRequest{}
+	mi := &file_notification_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnregisterDeviceTokenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnregisterDeviceTokenRequest) ProtoMessage() {}
+
+func (x *UnregisterDeviceTokenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_notification_proto_msgTypes[11]
 	if x != nil {
-		return x.Total
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return 0
+	return mi.MessageOf(x)
 }
 
-var File_notification_proto protoreflect.FileDescriptor
+// Deprecated: Use UnregisterDeviceTokenRequest.ProtoReflect.Descriptor instead.
+func (*UnregisterDeviceTokenRequest) Descriptor() ([]byte, []int) {
+	return file_notification_proto_rawDescGZIP(), []int{11}
+}
 
-const file_notification_proto_rawDesc = "" +
-	"\n" +
-	"\x12notification.proto\x12\x14notification_service\"\xe9\x02\n" +
-	"\fNotification\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
-	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x12\n" +
-	"\x04type\x18\x03 \x01(\tR\x04type\x12\x18\n" +
-	"\achannel\x18\x04 \x01(\tR\achannel\x12\x1c\n" +
-	"\trecipient\x18\x05 \x01(\tR\trecipient\x12\x18\n" +
-	"\asubject\x18\x06 \x01(\tR\asubject\x12\x18\n" +
-	"\acontent\x18\a \x01(\tR\acontent\x12\x16\n" +
-	"\x06status\x18\b \x01(\tR\x06status\x12#\n" +
-	"\rerror_message\x18\t \x01(\tR\ferrorMessage\x12\x1f\n" +
-	"\vtemplate_id\x18\n" +
-	" \x01(\tR\n" +
-	"templateId\x12\x1a\n" +
-	"\bmetadata\x18\v \x01(\tR\bmetadata\x12\x1d\n" +
-	"\n" +
-	"created_at\x18\f \x01(\tR\tcreatedAt\x12\x17\n" +
-	"\asent_at\x18\r \x01(\tR\x06sentAt\"\xd6\x02\n" +
-	"\bTemplate\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
-	"\x04name\x18\x02 \x01(\tR\x04name\x12\x12\n" +
-	"\x04type\x18\x03 \x01(\tR\x04type\x12\x18\n" +
-	"\asubject\x18\x04 \x01(\tR\asubject\x12\x12\n" +
-	"\x04body\x18\x05 \x01(\tR\x04body\x12K\n" +
-	"\tvariables\x18\x06 \x03(\v2-.notification_service.Template.VariablesEntryR\tvariables\x12\x1b\n" +
-	"\tis_active\x18\a \x01(\bR\bisActive\x12\x1d\n" +
-	"\n" +
-	"created_at\x18\b \x01(\tR\tcreatedAt\x12\x1d\n" +
-	"\n" +
-	"updated_at\x18\t \x01(\tR\tupdatedAt\x1a<\n" +
-	"\x0eVariablesEntry\x12\x10\n" +
-	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xab\x02\n" +
-	"\x10SendEmailRequest\x12\x17\n" +
-	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1c\n" +
-	"\trecipient\x18\x02 \x01(\tR\trecipient\x12\x18\n" +
-	"\asubject\x18\x03 \x01(\tR\asubject\x12\x12\n" +
-	"\x04body\x18\x04 \x01(\tR\x04body\x12\x1f\n" +
-	"\vtemplate_id\x18\x05 \x01(\tR\n" +
-	"templateId\x12S\n" +
-	"\tvariables\x18\x06 \x03(\v25.notification_service.SendEmailRequest.VariablesEntryR\tvariables\x1a<\n" +
-	"\x0eVariablesEntry\x12\x10\n" +
-	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x8f\x01\n" +
-	"\x11SendEmailResponse\x12F\n" +
-	"\fnotification\x18\x01 \x01(\v2\".notification_service.NotificationR\fnotification\x12\x18\n" +
-	"\asuccess\x18\x02 \x01(\bR\asuccess\x12\x18\n" +
-	"\amessage\x18\x03 \x01(\tR\amessage\"\x93\x02\n" +
-	"\x0eSendSMSRequest\x12\x17\n" +
-	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1c\n" +
+func (x *UnregisterDeviceTokenRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *UnregisterDeviceTokenRequest) GetDeviceToken() string {
+	if x != nil {
+		return x.DeviceToken
+	}
+	return ""
+}
+
+type UnregisterDeviceTokenResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnregisterDeviceTokenResponse) Reset() {
+	*x = UnregisterDeviceTokenResponse{}
+	mi := &file_notification_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnregisterDeviceTokenResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnregisterDeviceTokenResponse) ProtoMessage() {}
+
+func (x *UnregisterDeviceTokenResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_notification_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnregisterDeviceTokenResponse.ProtoReflect.Descriptor instead.
+func (*UnregisterDeviceTokenResponse) Descriptor() ([]byte, []int) {
+	return file_notification_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *UnregisterDeviceTokenResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type GetNotificationRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	NotificationId string                 `protobuf:"bytes,1,opt,name=notification_id,json=notificationId,proto3" json:"notification_id,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *GetNotificationRequest) Reset() {
+	*x = GetNotificationRequest{}
+	mi := &file_notification_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetNotificationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetNotificationRequest) ProtoMessage() {}
+
+func (x *GetNotificationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_notification_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetNotificationRequest.ProtoReflect.Descriptor instead.
+func (*GetNotificationRequest) Descriptor() ([]byte, []int) {
+	return file_notification_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *GetNotificationRequest) GetNotificationId() string {
+	if x != nil {
+		return x.NotificationId
+	}
+	return ""
+}
+
+type GetNotificationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Notification  *Notification          `protobuf:"bytes,1,opt,name=notification,proto3" json:"notification,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetNotificationResponse) Reset() {
+	*x = GetNotificationResponse{}
+	mi := &file_notification_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetNotificationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetNotificationResponse) ProtoMessage() {}
+
+func (x *GetNotificationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_notification_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetNotificationResponse.ProtoReflect.Descriptor instead.
+func (*GetNotificationResponse) Descriptor() ([]byte, []int) {
+	return file_notification_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *GetNotificationResponse) GetNotification() *Notification {
+	if x != nil {
+		return x.Notification
+	}
+	return nil
+}
+
+type GetNotificationHistoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Type          string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset        int32                  `protobuf:"varint,4,opt,name=offset,proto3" json:"offset,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetNotificationHistoryRequest) Reset() {
+	*x = GetNotificationHistoryRequest{}
+	mi := &file_notification_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetNotificationHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetNotificationHistoryRequest) ProtoMessage() {}
+
+func (x *GetNotificationHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_notification_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetNotificationHistoryRequest.ProtoReflect.Descriptor instead.
+func (*GetNotificationHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_notification_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *GetNotificationHistoryRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GetNotificationHistoryRequest) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *GetNotificationHistoryRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *GetNotificationHistoryRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+type GetNotificationHistoryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Notifications []*Notification        `protobuf:"bytes,1,rep,name=notifications,proto3" json:"notifications,omitempty"`
+	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetNotificationHistoryResponse) Reset() {
+	*x = GetNotificationHistoryResponse{}
+	mi := &file_notification_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetNotificationHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetNotificationHistoryResponse) ProtoMessage() {}
+
+func (x *GetNotificationHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_notification_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetNotificationHistoryResponse.ProtoReflect.Descriptor instead.
+func (*GetNotificationHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_notification_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *GetNotificationHistoryResponse) GetNotifications() []*Notification {
+	if x != nil {
+		return x.Notifications
+	}
+	return nil
+}
+
+func (x *GetNotificationHistoryResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+type BulkEmailRecipient struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // Optional, used to check notification preferences
+	Email         string                 `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
+	Variables     map[string]string      `protobuf:"bytes,3,rep,name=variables,proto3" json:"variables,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // Override the shared variables for this recipient
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BulkEmailRecipient) Reset() {
+	*x = BulkEmailRecipient{}
+	mi := &file_notification_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BulkEmailRecipient) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BulkEmailRecipient) ProtoMessage() {}
+
+func (x *BulkEmailRecipient) ProtoReflect() protoreflect.Message {
+	mi := &file_notification_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BulkEmailRecipient.ProtoReflect.Descriptor instead.
+func (*BulkEmailRecipient) Descriptor() ([]byte, []int) {
+	return file_notification_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *BulkEmailRecipient) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *BulkEmailRecipient) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *BulkEmailRecipient) GetVariables() map[string]string {
+	if x != nil {
+		return x.Variables
+	}
+	return nil
+}
+
+type SendBulkEmailRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Recipients    []*BulkEmailRecipient  `protobuf:"bytes,1,rep,name=recipients,proto3" json:"recipients,omitempty"`
+	Subject       string                 `protobuf:"bytes,2,opt,name=subject,proto3" json:"subject,omitempty"`
+	Body          string                 `protobuf:"bytes,3,opt,name=body,proto3" json:"body,omitempty"`
+	TemplateId    string                 `protobuf:"bytes,4,opt,name=template_id,json=templateId,proto3" json:"template_id,omitempty"`
+	Variables     map[string]string      `protobuf:"bytes,5,rep,name=variables,proto3" json:"variables,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SendBulkEmailRequest) Reset() {
+	*x = SendBulkEmailRequest{}
+	mi := &file_notification_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SendBulkEmailRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SendBulkEmailRequest) ProtoMessage() {}
+
+func (x *SendBulkEmailRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_notification_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SendBulkEmailRequest.ProtoReflect.Descriptor instead.
+func (*SendBulkEmailRequest) Descriptor() ([]byte, []int) {
+	return file_notification_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *SendBulkEmailRequest) GetRecipients() []*BulkEmailRecipient {
+	if x != nil {
+		return x.Recipients
+	}
+	return nil
+}
+
+func (x *SendBulkEmailRequest) GetSubject() string {
+	if x != nil {
+		return x.Subject
+	}
+	return ""
+}
+
+func (x *SendBulkEmailRequest) GetBody() string {
+	if x != nil {
+		return x.Body
+	}
+	return ""
+}
+
+func (x *SendBulkEmailRequest) GetTemplateId() string {
+	if x != nil {
+		return x.TemplateId
+	}
+	return ""
+}
+
+func (x *SendBulkEmailRequest) GetVariables() map[string]string {
+	if x != nil {
+		return x.Variables
+	}
+	return nil
+}
+
+type BulkEmailJob struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	Total         int32                  `protobuf:"varint,3,opt,name=total,proto3" json:"total,omitempty"`
+	Pending       int32                  `protobuf:"varint,4,opt,name=pending,proto3" json:"pending,omitempty"`
+	Sent          int32                  `protobuf:"varint,5,opt,name=sent,proto3" json:"sent,omitempty"`
+	Failed        int32                  `protobuf:"varint,6,opt,name=failed,proto3" json:"failed,omitempty"`
+	Skipped       int32                  `protobuf:"varint,7,opt,name=skipped,proto3" json:"skipped,omitempty"`
+	CreatedAt     string                 `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	StartedAt     string                 `protobuf:"bytes,9,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	CompletedAt   string                 `protobuf:"bytes,10,opt,name=completed_at,json=completedAt,proto3" json:"completed_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BulkEmailJob) Reset() {
+	*x = BulkEmailJob{}
+	mi := &file_notification_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BulkEmailJob) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BulkEmailJob) ProtoMessage() {}
+
+func (x *BulkEmailJob) ProtoReflect() protoreflect.Message {
+	mi := &file_notification_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BulkEmailJob.ProtoReflect.Descriptor instead.
+func (*BulkEmailJob) Descriptor() ([]byte, []int) {
+	return file_notification_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *BulkEmailJob) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *BulkEmailJob) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *BulkEmailJob) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *BulkEmailJob) GetPending() int32 {
+	if x != nil {
+		return x.Pending
+	}
+	return 0
+}
+
+func (x *BulkEmailJob) GetSent() int32 {
+	if x != nil {
+		return x.Sent
+	}
+	return 0
+}
+
+func (x *BulkEmailJob) GetFailed() int32 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
+}
+
+func (x *BulkEmailJob) GetSkipped() int32 {
+	if x != nil {
+		return x.Skipped
+	}
+	return 0
+}
+
+func (x *BulkEmailJob) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *BulkEmailJob) GetStartedAt() string {
+	if x != nil {
+		return x.StartedAt
+	}
+	return ""
+}
+
+func (x *BulkEmailJob) GetCompletedAt() string {
+	if x != nil {
+		return x.CompletedAt
+	}
+	return ""
+}
+
+type SendBulkEmailResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Job           *BulkEmailJob          `protobuf:"bytes,1,opt,name=job,proto3" json:"job,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SendBulkEmailResponse) Reset() {
+	*x = SendBulkEmailResponse{}
+	mi := &file_notification_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SendBulkEmailResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SendBulkEmailResponse) ProtoMessage() {}
+
+func (x *SendBulkEmailResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_notification_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SendBulkEmailResponse.ProtoReflect.Descriptor instead.
+func (*SendBulkEmailResponse) Descriptor() ([]byte, []int) {
+	return file_notification_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *SendBulkEmailResponse) GetJob() *BulkEmailJob {
+	if x != nil {
+		return x.Job
+	}
+	return nil
+}
+
+type GetBulkJobStatusRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	JobId           string                 `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	RecipientStatus string                 `protobuf:"bytes,2,opt,name=recipient_status,json=recipientStatus,proto3" json:"recipient_status,omitempty"` // Filter results: PENDING, SENT, FAILED, SKIPPED
+	Limit           int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset          int32                  `protobuf:"varint,4,opt,name=offset,proto3" json:"offset,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *GetBulkJobStatusRequest) Reset() {
+	*x = GetBulkJobStatusRequest{}
+	mi := &file_notification_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBulkJobStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBulkJobStatusRequest) ProtoMessage() {}
+
+func (x *GetBulkJobStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_notification_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBulkJobStatusRequest.ProtoReflect.Descriptor instead.
+func (*GetBulkJobStatusRequest) Descriptor() ([]byte, []int) {
+	return file_notification_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *GetBulkJobStatusRequest) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+func (x *GetBulkJobStatusRequest) GetRecipientStatus() string {
+	if x != nil {
+		return x.RecipientStatus
+	}
+	return ""
+}
+
+func (x *GetBulkJobStatusRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *GetBulkJobStatusRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+type BulkEmailResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Recipient     string                 `protobuf:"bytes,2,opt,name=recipient,proto3" json:"recipient,omitempty"`
+	Status        string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	ErrorMessage  string                 `protobuf:"bytes,4,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	SentAt        string                 `protobuf:"bytes,5,opt,name=sent_at,json=sentAt,proto3" json:"sent_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BulkEmailResult) Reset() {
+	*x = BulkEmailResult{}
+	mi := &file_notification_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BulkEmailResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BulkEmailResult) ProtoMessage() {}
+
+func (x *BulkEmailResult) ProtoReflect() protoreflect.Message {
+	mi := &file_notification_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BulkEmailResult.ProtoReflect.Descriptor instead.
+func (*BulkEmailResult) Descriptor() ([]byte, []int) {
+	return file_notification_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *BulkEmailResult) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *BulkEmailResult) GetRecipient() string {
+	if x != nil {
+		return x.Recipient
+	}
+	return ""
+}
+
+func (x *BulkEmailResult) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *BulkEmailResult) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+func (x *BulkEmailResult) GetSentAt() string {
+	if x != nil {
+		return x.SentAt
+	}
+	return ""
+}
+
+type GetBulkJobStatusResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Job           *BulkEmailJob          `protobuf:"bytes,1,opt,name=job,proto3" json:"job,omitempty"`
+	Results       []*BulkEmailResult     `protobuf:"bytes,2,rep,name=results,proto3" json:"results,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBulkJobStatusResponse) Reset() {
+	*x = GetBulkJobStatusResponse{}
+	mi := &file_notification_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBulkJobStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBulkJobStatusResponse) ProtoMessage() {}
+
+func (x *GetBulkJobStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_notification_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBulkJobStatusResponse.ProtoReflect.Descriptor instead.
+func (*GetBulkJobStatusResponse) Descriptor() ([]byte, []int) {
+	return file_notification_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *GetBulkJobStatusResponse) GetJob() *BulkEmailJob {
+	if x != nil {
+		return x.Job
+	}
+	return nil
+}
+
+func (x *GetBulkJobStatusResponse) GetResults() []*BulkEmailResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+type NotificationPreferences struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	EmailEnabled  bool                   `protobuf:"varint,2,opt,name=email_enabled,json=emailEnabled,proto3" json:"email_enabled,omitempty"`
+	SmsEnabled    bool                   `protobuf:"varint,3,opt,name=sms_enabled,json=smsEnabled,proto3" json:"sms_enabled,omitempty"`
+	PushEnabled   bool                   `protobuf:"varint,4,opt,name=push_enabled,json=pushEnabled,proto3" json:"push_enabled,omitempty"`
+	UpdatedAt     string                 `protobuf:"bytes,5,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NotificationPreferences) Reset() {
+	*x = NotificationPreferences{}
+	mi := &file_notification_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NotificationPreferences) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NotificationPreferences) ProtoMessage() {}
+
+func (x *NotificationPreferences) ProtoReflect() protoreflect.Message {
+	mi := &file_notification_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NotificationPreferences.ProtoReflect.Descriptor instead.
+func (*NotificationPreferences) Descriptor() ([]byte, []int) {
+	return file_notification_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *NotificationPreferences) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *NotificationPreferences) GetEmailEnabled() bool {
+	if x != nil {
+		return x.EmailEnabled
+	}
+	return false
+}
+
+func (x *NotificationPreferences) GetSmsEnabled() bool {
+	if x != nil {
+		return x.SmsEnabled
+	}
+	return false
+}
+
+func (x *NotificationPreferences) GetPushEnabled() bool {
+	if x != nil {
+		return x.PushEnabled
+	}
+	return false
+}
+
+func (x *NotificationPreferences) GetUpdatedAt() string {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return ""
+}
+
+type GetNotificationPreferencesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetNotificationPreferencesRequest) Reset() {
+	*x = GetNotificationPreferencesRequest{}
+	mi := &file_notification_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetNotificationPreferencesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetNotificationPreferencesRequest) ProtoMessage() {}
+
+func (x *GetNotificationPreferencesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_notification_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetNotificationPreferencesRequest.ProtoReflect.Descriptor instead.
+func (*GetNotificationPreferencesRequest) Descriptor() ([]byte, []int) {
+	return file_notification_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *GetNotificationPreferencesRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type UpdateNotificationPreferencesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	EmailEnabled  bool                   `protobuf:"varint,2,opt,name=email_enabled,json=emailEnabled,proto3" json:"email_enabled,omitempty"`
+	SmsEnabled    bool                   `protobuf:"varint,3,opt,name=sms_enabled,json=smsEnabled,proto3" json:"sms_enabled,omitempty"`
+	PushEnabled   bool                   `protobuf:"varint,4,opt,name=push_enabled,json=pushEnabled,proto3" json:"push_enabled,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateNotificationPreferencesRequest) Reset() {
+	*x = UpdateNotificationPreferencesRequest{}
+	mi := &file_notification_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateNotificationPreferencesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateNotificationPreferencesRequest) ProtoMessage() {}
+
+func (x *UpdateNotificationPreferencesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_notification_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateNotificationPreferencesRequest.ProtoReflect.Descriptor instead.
+func (*UpdateNotificationPreferencesRequest) Descriptor() ([]byte, []int) {
+	return file_notification_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *UpdateNotificationPreferencesRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *UpdateNotificationPreferencesRequest) GetEmailEnabled() bool {
+	if x != nil {
+		return x.EmailEnabled
+	}
+	return false
+}
+
+func (x *UpdateNotificationPreferencesRequest) GetSmsEnabled() bool {
+	if x != nil {
+		return x.SmsEnabled
+	}
+	return false
+}
+
+func (x *UpdateNotificationPreferencesRequest) GetPushEnabled() bool {
+	if x != nil {
+		return x.PushEnabled
+	}
+	return false
+}
+
+type NotificationPreferencesResponse struct {
+	state         protoimpl.MessageState   `protogen:"open.v1"`
+	Preferences   *NotificationPreferences `protobuf:"bytes,1,opt,name=preferences,proto3" json:"preferences,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NotificationPreferencesResponse) Reset() {
+	*x = NotificationPreferencesResponse{}
+	mi := &file_notification_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NotificationPreferencesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NotificationPreferencesResponse) ProtoMessage() {}
+
+func (x *NotificationPreferencesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_notification_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NotificationPreferencesResponse.ProtoReflect.Descriptor instead.
+func (*NotificationPreferencesResponse) Descriptor() ([]byte, []int) {
+	return file_notification_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *NotificationPreferencesResponse) GetPreferences() *NotificationPreferences {
+	if x != nil {
+		return x.Preferences
+	}
+	return nil
+}
+
+var File_notification_proto protoreflect.FileDescriptor
+
+const file_notification_proto_rawDesc = "" +
+	"\n" +
+	"\x12notification.proto\x12\x14notification_service\"\x99\x03\n" +
+	"\fNotification\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x12\n" +
+	"\x04type\x18\x03 \x01(\tR\x04type\x12\x18\n" +
+	"\achannel\x18\x04 \x01(\tR\achannel\x12\x1c\n" +
+	"\trecipient\x18\x05 \x01(\tR\trecipient\x12\x18\n" +
+	"\asubject\x18\x06 \x01(\tR\asubject\x12\x18\n" +
+	"\acontent\x18\a \x01(\tR\acontent\x12\x16\n" +
+	"\x06status\x18\b \x01(\tR\x06status\x12#\n" +
+	"\rerror_message\x18\t \x01(\tR\ferrorMessage\x12\x1f\n" +
+	"\vtemplate_id\x18\n" +
+	" \x01(\tR\n" +
+	"templateId\x12\x1a\n" +
+	"\bmetadata\x18\v \x01(\tR\bmetadata\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\f \x01(\tR\tcreatedAt\x12\x17\n" +
+	"\asent_at\x18\r \x01(\tR\x06sentAt\x12.\n" +
+	"\x13provider_message_id\x18\x0e \x01(\tR\x11providerMessageId\"\xd6\x02\n" +
+	"\bTemplate\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x12\n" +
+	"\x04type\x18\x03 \x01(\tR\x04type\x12\x18\n" +
+	"\asubject\x18\x04 \x01(\tR\asubject\x12\x12\n" +
+	"\x04body\x18\x05 \x01(\tR\x04body\x12K\n" +
+	"\tvariables\x18\x06 \x03(\v2-.notification_service.Template.VariablesEntryR\tvariables\x12\x1b\n" +
+	"\tis_active\x18\a \x01(\bR\bisActive\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\b \x01(\tR\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\t \x01(\tR\tupdatedAt\x1a<\n" +
+	"\x0eVariablesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xab\x02\n" +
+	"\x10SendEmailRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1c\n" +
+	"\trecipient\x18\x02 \x01(\tR\trecipient\x12\x18\n" +
+	"\asubject\x18\x03 \x01(\tR\asubject\x12\x12\n" +
+	"\x04body\x18\x04 \x01(\tR\x04body\x12\x1f\n" +
+	"\vtemplate_id\x18\x05 \x01(\tR\n" +
+	"templateId\x12S\n" +
+	"\tvariables\x18\x06 \x03(\v25.notification_service.SendEmailRequest.VariablesEntryR\tvariables\x1a<\n" +
+	"\x0eVariablesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x8f\x01\n" +
+	"\x11SendEmailResponse\x12F\n" +
+	"\fnotification\x18\x01 \x01(\v2\".notification_service.NotificationR\fnotification\x12\x18\n" +
+	"\asuccess\x18\x02 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\"\x93\x02\n" +
+	"\x0eSendSMSRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1c\n" +
 	"\trecipient\x18\x02 \x01(\tR\trecipient\x12\x18\n" +
 	"\amessage\x18\x03 \x01(\tR\amessage\x12\x1f\n" +
 	"\vtemplate_id\x18\x04 \x01(\tR\n" +
@@ -822,7 +1999,43 @@ const file_notification_proto_rawDesc = "" +
 	"\x0fSendSMSResponse\x12F\n" +
 	"\fnotification\x18\x01 \x01(\v2\".notification_service.NotificationR\fnotification\x12\x18\n" +
 	"\asuccess\x18\x02 \x01(\bR\asuccess\x12\x18\n" +
-	"\amessage\x18\x03 \x01(\tR\amessage\"A\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\"\x8d\x02\n" +
+	"\x1bSendPushNotificationRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12!\n" +
+	"\fdevice_token\x18\x02 \x01(\tR\vdeviceToken\x12\x14\n" +
+	"\x05title\x18\x03 \x01(\tR\x05title\x12\x12\n" +
+	"\x04body\x18\x04 \x01(\tR\x04body\x12O\n" +
+	"\x04data\x18\x05 \x03(\v2;.notification_service.SendPushNotificationRequest.DataEntryR\x04data\x1a7\n" +
+	"\tDataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xb5\x02\n" +
+	"\x1cSendPushNotificationResponse\x12F\n" +
+	"\fnotification\x18\x01 \x01(\v2\".notification_service.NotificationR\fnotification\x12\x18\n" +
+	"\asuccess\x18\x02 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\x12#\n" +
+	"\rtoken_invalid\x18\x04 \x01(\bR\ftokenInvalid\x12H\n" +
+	"\rnotifications\x18\x05 \x03(\v2\".notification_service.NotificationR\rnotifications\x12\x12\n" +
+	"\x04sent\x18\x06 \x01(\x05R\x04sent\x12\x16\n" +
+	"\x06failed\x18\a \x01(\x05R\x06failed\"\x96\x01\n" +
+	"\vDeviceToken\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x1a\n" +
+	"\bplatform\x18\x03 \x01(\tR\bplatform\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\tR\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\x05 \x01(\tR\tupdatedAt\"t\n" +
+	"\x1aRegisterDeviceTokenRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12!\n" +
+	"\fdevice_token\x18\x02 \x01(\tR\vdeviceToken\x12\x1a\n" +
+	"\bplatform\x18\x03 \x01(\tR\bplatform\"X\n" +
+	"\x1bRegisterDeviceTokenResponse\x129\n" +
+	"\x06device\x18\x01 \x01(\v2!.notification_service.DeviceTokenR\x06device\"Z\n" +
+	"\x1cUnregisterDeviceTokenRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12!\n" +
+	"\fdevice_token\x18\x02 \x01(\tR\vdeviceToken\"9\n" +
+	"\x1dUnregisterDeviceTokenResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"A\n" +
 	"\x16GetNotificationRequest\x12'\n" +
 	"\x0fnotification_id\x18\x01 \x01(\tR\x0enotificationId\"a\n" +
 	"\x17GetNotificationResponse\x12F\n" +
@@ -834,12 +2047,87 @@ const file_notification_proto_rawDesc = "" +
 	"\x06offset\x18\x04 \x01(\x05R\x06offset\"\x80\x01\n" +
 	"\x1eGetNotificationHistoryResponse\x12H\n" +
 	"\rnotifications\x18\x01 \x03(\v2\".notification_service.NotificationR\rnotifications\x12\x14\n" +
-	"\x05total\x18\x02 \x01(\x05R\x05total2\xc1\x03\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\"\xd8\x01\n" +
+	"\x12BulkEmailRecipient\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x14\n" +
+	"\x05email\x18\x02 \x01(\tR\x05email\x12U\n" +
+	"\tvariables\x18\x03 \x03(\v27.notification_service.BulkEmailRecipient.VariablesEntryR\tvariables\x1a<\n" +
+	"\x0eVariablesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xc6\x02\n" +
+	"\x14SendBulkEmailRequest\x12H\n" +
+	"\n" +
+	"recipients\x18\x01 \x03(\v2(.notification_service.BulkEmailRecipientR\n" +
+	"recipients\x12\x18\n" +
+	"\asubject\x18\x02 \x01(\tR\asubject\x12\x12\n" +
+	"\x04body\x18\x03 \x01(\tR\x04body\x12\x1f\n" +
+	"\vtemplate_id\x18\x04 \x01(\tR\n" +
+	"templateId\x12W\n" +
+	"\tvariables\x18\x05 \x03(\v29.notification_service.SendBulkEmailRequest.VariablesEntryR\tvariables\x1a<\n" +
+	"\x0eVariablesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x8d\x02\n" +
+	"\fBulkEmailJob\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x14\n" +
+	"\x05total\x18\x03 \x01(\x05R\x05total\x12\x18\n" +
+	"\apending\x18\x04 \x01(\x05R\apending\x12\x12\n" +
+	"\x04sent\x18\x05 \x01(\x05R\x04sent\x12\x16\n" +
+	"\x06failed\x18\x06 \x01(\x05R\x06failed\x12\x18\n" +
+	"\askipped\x18\a \x01(\x05R\askipped\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\b \x01(\tR\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"started_at\x18\t \x01(\tR\tstartedAt\x12!\n" +
+	"\fcompleted_at\x18\n" +
+	" \x01(\tR\vcompletedAt\"M\n" +
+	"\x15SendBulkEmailResponse\x124\n" +
+	"\x03job\x18\x01 \x01(\v2\".notification_service.BulkEmailJobR\x03job\"\x89\x01\n" +
+	"\x17GetBulkJobStatusRequest\x12\x15\n" +
+	"\x06job_id\x18\x01 \x01(\tR\x05jobId\x12)\n" +
+	"\x10recipient_status\x18\x02 \x01(\tR\x0frecipientStatus\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\x04 \x01(\x05R\x06offset\"\x9e\x01\n" +
+	"\x0fBulkEmailResult\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1c\n" +
+	"\trecipient\x18\x02 \x01(\tR\trecipient\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x12#\n" +
+	"\rerror_message\x18\x04 \x01(\tR\ferrorMessage\x12\x17\n" +
+	"\asent_at\x18\x05 \x01(\tR\x06sentAt\"\x91\x01\n" +
+	"\x18GetBulkJobStatusResponse\x124\n" +
+	"\x03job\x18\x01 \x01(\v2\".notification_service.BulkEmailJobR\x03job\x12?\n" +
+	"\aresults\x18\x02 \x03(\v2%.notification_service.BulkEmailResultR\aresults\"\xba\x01\n" +
+	"\x17NotificationPreferences\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12#\n" +
+	"\remail_enabled\x18\x02 \x01(\bR\femailEnabled\x12\x1f\n" +
+	"\vsms_enabled\x18\x03 \x01(\bR\n" +
+	"smsEnabled\x12!\n" +
+	"\fpush_enabled\x18\x04 \x01(\bR\vpushEnabled\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\x05 \x01(\tR\tupdatedAt\"<\n" +
+	"!GetNotificationPreferencesRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"\xa8\x01\n" +
+	"$UpdateNotificationPreferencesRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12#\n" +
+	"\remail_enabled\x18\x02 \x01(\bR\femailEnabled\x12\x1f\n" +
+	"\vsms_enabled\x18\x03 \x01(\bR\n" +
+	"smsEnabled\x12!\n" +
+	"\fpush_enabled\x18\x04 \x01(\bR\vpushEnabled\"r\n" +
+	"\x1fNotificationPreferencesResponse\x12O\n" +
+	"\vpreferences\x18\x01 \x01(\v2-.notification_service.NotificationPreferencesR\vpreferences2\xc0\n" +
+	"\n" +
 	"\x13NotificationService\x12\\\n" +
 	"\tSendEmail\x12&.notification_service.SendEmailRequest\x1a'.notification_service.SendEmailResponse\x12V\n" +
-	"\aSendSMS\x12$.notification_service.SendSMSRequest\x1a%.notification_service.SendSMSResponse\x12n\n" +
+	"\aSendSMS\x12$.notification_service.SendSMSRequest\x1a%.notification_service.SendSMSResponse\x12}\n" +
+	"\x14SendPushNotification\x121.notification_service.SendPushNotificationRequest\x1a2.notification_service.SendPushNotificationResponse\x12z\n" +
+	"\x13RegisterDeviceToken\x120.notification_service.RegisterDeviceTokenRequest\x1a1.notification_service.RegisterDeviceTokenResponse\x12\x80\x01\n" +
+	"\x15UnregisterDeviceToken\x122.notification_service.UnregisterDeviceTokenRequest\x1a3.notification_service.UnregisterDeviceTokenResponse\x12n\n" +
 	"\x0fGetNotification\x12,.notification_service.GetNotificationRequest\x1a-.notification_service.GetNotificationResponse\x12\x83\x01\n" +
-	"\x16GetNotificationHistory\x123.notification_service.GetNotificationHistoryRequest\x1a4.notification_service.GetNotificationHistoryResponseBBZ@github.com/datngth03/ecommerce-go-app/proto/notification_serviceb\x06proto3"
+	"\x16GetNotificationHistory\x123.notification_service.GetNotificationHistoryRequest\x1a4.notification_service.GetNotificationHistoryResponse\x12h\n" +
+	"\rSendBulkEmail\x12*.notification_service.SendBulkEmailRequest\x1a+.notification_service.SendBulkEmailResponse\x12q\n" +
+	"\x10GetBulkJobStatus\x12-.notification_service.GetBulkJobStatusRequest\x1a..notification_service.GetBulkJobStatusResponse\x12\x8c\x01\n" +
+	"\x1aGetNotificationPreferences\x127.notification_service.GetNotificationPreferencesRequest\x1a5.notification_service.NotificationPreferencesResponse\x12\x92\x01\n" +
+	"\x1dUpdateNotificationPreferences\x12:.notification_service.UpdateNotificationPreferencesRequest\x1a5.notification_service.NotificationPreferencesResponseBBZ@github.com/datngth03/ecommerce-go-app/proto/notification_serviceb\x06proto3"
 
 var (
 	file_notification_proto_rawDescOnce sync.Once
@@ -853,43 +2141,89 @@ func file_notification_proto_rawDescGZIP() []byte {
 	return file_notification_proto_rawDescData
 }
 
-var file_notification_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
+var file_notification_proto_msgTypes = make([]protoimpl.MessageInfo, 34)
 var file_notification_proto_goTypes = []any{
-	(*Notification)(nil),                   // 0: notification_service.Notification
-	(*Template)(nil),                       // 1: notification_service.Template
-	(*SendEmailRequest)(nil),               // 2: notification_service.SendEmailRequest
-	(*SendEmailResponse)(nil),              // 3: notification_service.SendEmailResponse
-	(*SendSMSRequest)(nil),                 // 4: notification_service.SendSMSRequest
-	(*SendSMSResponse)(nil),                // 5: notification_service.SendSMSResponse
-	(*GetNotificationRequest)(nil),         // 6: notification_service.GetNotificationRequest
-	(*GetNotificationResponse)(nil),        // 7: notification_service.GetNotificationResponse
-	(*GetNotificationHistoryRequest)(nil),  // 8: notification_service.GetNotificationHistoryRequest
-	(*GetNotificationHistoryResponse)(nil), // 9: notification_service.GetNotificationHistoryResponse
-	nil,                                    // 10: notification_service.Template.VariablesEntry
-	nil,                                    // 11: notification_service.SendEmailRequest.VariablesEntry
-	nil,                                    // 12: notification_service.SendSMSRequest.VariablesEntry
+	(*Notification)(nil),                         // 0: notification_service.Notification
+	(*Template)(nil),                             // 1: notification_service.Template
+	(*SendEmailRequest)(nil),                     // 2: notification_service.SendEmailRequest
+	(*SendEmailResponse)(nil),                    // 3: notification_service.SendEmailResponse
+	(*SendSMSRequest)(nil),                       // 4: notification_service.SendSMSRequest
+	(*SendSMSResponse)(nil),                      // 5: notification_service.SendSMSResponse
+	(*SendPushNotificationRequest)(nil),          // 6: notification_service.SendPushNotificationRequest
+	(*SendPushNotificationResponse)(nil),         // 7: notification_service.SendPushNotificationResponse
+	(*DeviceToken)(nil),                          // 8: notification_service.DeviceToken
+	(*RegisterDeviceTokenRequest)(nil),           // 9: notification_service.RegisterDeviceTokenRequest
+	(*RegisterDeviceTokenResponse)(nil),          // 10: notification_service.RegisterDeviceTokenResponse
+	(*UnregisterDeviceTokenRequest)(nil),         // 11: notification_service.UnregisterDeviceTokenRequest
+	(*UnregisterDeviceTokenResponse)(nil),        // 12: notification_service.UnregisterDeviceTokenResponse
+	(*GetNotificationRequest)(nil),               // 13: notification_service.GetNotificationRequest
+	(*GetNotificationResponse)(nil),              // 14: notification_service.GetNotificationResponse
+	(*GetNotificationHistoryRequest)(nil),        // 15: notification_service.GetNotificationHistoryRequest
+	(*GetNotificationHistoryResponse)(nil),       // 16: notification_service.GetNotificationHistoryResponse
+	(*BulkEmailRecipient)(nil),                   // 17: notification_service.BulkEmailRecipient
+	(*SendBulkEmailRequest)(nil),                 // 18: notification_service.SendBulkEmailRequest
+	(*BulkEmailJob)(nil),                         // 19: notification_service.BulkEmailJob
+	(*SendBulkEmailResponse)(nil),                // 20: notification_service.SendBulkEmailResponse
+	(*GetBulkJobStatusRequest)(nil),              // 21: notification_service.GetBulkJobStatusRequest
+	(*BulkEmailResult)(nil),                      // 22: notification_service.BulkEmailResult
+	(*GetBulkJobStatusResponse)(nil),             // 23: notification_service.GetBulkJobStatusResponse
+	(*NotificationPreferences)(nil),              // 24: notification_service.NotificationPreferences
+	(*GetNotificationPreferencesRequest)(nil),    // 25: notification_service.GetNotificationPreferencesRequest
+	(*UpdateNotificationPreferencesRequest)(nil), // 26: notification_service.UpdateNotificationPreferencesRequest
+	(*NotificationPreferencesResponse)(nil),      // 27: notification_service.NotificationPreferencesResponse
+	nil,                                          // 28: notification_service.Template.VariablesEntry
+	nil,                                          // 29: notification_service.SendEmailRequest.VariablesEntry
+	nil,                                          // 30: notification_service.SendSMSRequest.VariablesEntry
+	nil,                                          // 31: notification_service.SendPushNotificationRequest.DataEntry
+	nil,                                          // 32: notification_service.BulkEmailRecipient.VariablesEntry
+	nil,                                          // 33: notification_service.SendBulkEmailRequest.VariablesEntry
 }
 var file_notification_proto_depIdxs = []int32{
-	10, // 0: notification_service.Template.variables:type_name -> notification_service.Template.VariablesEntry
-	11, // 1: notification_service.SendEmailRequest.variables:type_name -> notification_service.SendEmailRequest.VariablesEntry
+	28, // 0: notification_service.Template.variables:type_name -> notification_service.Template.VariablesEntry
+	29, // 1: notification_service.SendEmailRequest.variables:type_name -> notification_service.SendEmailRequest.VariablesEntry
 	0,  // 2: notification_service.SendEmailResponse.notification:type_name -> notification_service.Notification
-	12, // 3: notification_service.SendSMSRequest.variables:type_name -> notification_service.SendSMSRequest.VariablesEntry
+	30, // 3: notification_service.SendSMSRequest.variables:type_name -> notification_service.SendSMSRequest.VariablesEntry
 	0,  // 4: notification_service.SendSMSResponse.notification:type_name -> notification_service.Notification
-	0,  // 5: notification_service.GetNotificationResponse.notification:type_name -> notification_service.Notification
-	0,  // 6: notification_service.GetNotificationHistoryResponse.notifications:type_name -> notification_service.Notification
-	2,  // 7: notification_service.NotificationService.SendEmail:input_type -> notification_service.SendEmailRequest
-	4,  // 8: notification_service.NotificationService.SendSMS:input_type -> notification_service.SendSMSRequest
-	6,  // 9: notification_service.NotificationService.GetNotification:input_type -> notification_service.GetNotificationRequest
-	8,  // 10: notification_service.NotificationService.GetNotificationHistory:input_type -> notification_service.GetNotificationHistoryRequest
-	3,  // 11: notification_service.NotificationService.SendEmail:output_type -> notification_service.SendEmailResponse
-	5,  // 12: notification_service.NotificationService.SendSMS:output_type -> notification_service.SendSMSResponse
-	7,  // 13: notification_service.NotificationService.GetNotification:output_type -> notification_service.GetNotificationResponse
-	9,  // 14: notification_service.NotificationService.GetNotificationHistory:output_type -> notification_service.GetNotificationHistoryResponse
-	11, // [11:15] is the sub-list for method output_type
-	7,  // [7:11] is the sub-list for method input_type
-	7,  // [7:7] is the sub-list for extension type_name
-	7,  // [7:7] is the sub-list for extension extendee
-	0,  // [0:7] is the sub-list for field type_name
+	31, // 5: notification_service.SendPushNotificationRequest.data:type_name -> notification_service.SendPushNotificationRequest.DataEntry
+	0,  // 6: notification_service.SendPushNotificationResponse.notification:type_name -> notification_service.Notification
+	0,  // 7: notification_service.SendPushNotificationResponse.notifications:type_name -> notification_service.Notification
+	8,  // 8: notification_service.RegisterDeviceTokenResponse.device:type_name -> notification_service.DeviceToken
+	0,  // 9: notification_service.GetNotificationResponse.notification:type_name -> notification_service.Notification
+	0,  // 10: notification_service.GetNotificationHistoryResponse.notifications:type_name -> notification_service.Notification
+	32, // 11: notification_service.BulkEmailRecipient.variables:type_name -> notification_service.BulkEmailRecipient.VariablesEntry
+	17, // 12: notification_service.SendBulkEmailRequest.recipients:type_name -> notification_service.BulkEmailRecipient
+	33, // 13: notification_service.SendBulkEmailRequest.variables:type_name -> notification_service.SendBulkEmailRequest.VariablesEntry
+	19, // 14: notification_service.SendBulkEmailResponse.job:type_name -> notification_service.BulkEmailJob
+	19, // 15: notification_service.GetBulkJobStatusResponse.job:type_name -> notification_service.BulkEmailJob
+	22, // 16: notification_service.GetBulkJobStatusResponse.results:type_name -> notification_service.BulkEmailResult
+	24, // 17: notification_service.NotificationPreferencesResponse.preferences:type_name -> notification_service.NotificationPreferences
+	2,  // 18: notification_service.NotificationService.SendEmail:input_type -> notification_service.SendEmailRequest
+	4,  // 19: notification_service.NotificationService.SendSMS:input_type -> notification_service.SendSMSRequest
+	6,  // 20: notification_service.NotificationService.SendPushNotification:input_type -> notification_service.SendPushNotificationRequest
+	9,  // 21: notification_service.NotificationService.RegisterDeviceToken:input_type -> notification_service.RegisterDeviceTokenRequest
+	11, // 22: notification_service.NotificationService.UnregisterDeviceToken:input_type -> notification_service.UnregisterDeviceTokenRequest
+	13, // 23: notification_service.NotificationService.GetNotification:input_type -> notification_service.GetNotificationRequest
+	15, // 24: notification_service.NotificationService.GetNotificationHistory:input_type -> notification_service.GetNotificationHistoryRequest
+	18, // 25: notification_service.NotificationService.SendBulkEmail:input_type -> notification_service.SendBulkEmailRequest
+	21, // 26: notification_service.NotificationService.GetBulkJobStatus:input_type -> notification_service.GetBulkJobStatusRequest
+	25, // 27: notification_service.NotificationService.GetNotificationPreferences:input_type -> notification_service.GetNotificationPreferencesRequest
+	26, // 28: notification_service.NotificationService.UpdateNotificationPreferences:input_type -> notification_service.UpdateNotificationPreferencesRequest
+	3,  // 29: notification_service.NotificationService.SendEmail:output_type -> notification_service.SendEmailResponse
+	5,  // 30: notification_service.NotificationService.SendSMS:output_type -> notification_service.SendSMSResponse
+	7,  // 31: notification_service.NotificationService.SendPushNotification:output_type -> notification_service.SendPushNotificationResponse
+	10, // 32: notification_service.NotificationService.RegisterDeviceToken:output_type -> notification_service.RegisterDeviceTokenResponse
+	12, // 33: notification_service.NotificationService.UnregisterDeviceToken:output_type -> notification_service.UnregisterDeviceTokenResponse
+	14, // 34: notification_service.NotificationService.GetNotification:output_type -> notification_service.GetNotificationResponse
+	16, // 35: notification_service.NotificationService.GetNotificationHistory:output_type -> notification_service.GetNotificationHistoryResponse
+	20, // 36: notification_service.NotificationService.SendBulkEmail:output_type -> notification_service.SendBulkEmailResponse
+	23, // 37: notification_service.NotificationService.GetBulkJobStatus:output_type -> notification_service.GetBulkJobStatusResponse
+	27, // 38: notification_service.NotificationService.GetNotificationPreferences:output_type -> notification_service.NotificationPreferencesResponse
+	27, // 39: notification_service.NotificationService.UpdateNotificationPreferences:output_type -> notification_service.NotificationPreferencesResponse
+	29, // [29:40] is the sub-list for method output_type
+	18, // [18:29] is the sub-list for method input_type
+	18, // [18:18] is the sub-list for extension type_name
+	18, // [18:18] is the sub-list for extension extendee
+	0,  // [0:18] is the sub-list for field type_name
 }
 
 func init() { file_notification_proto_init() }
@@ -903,7 +2237,7 @@ func file_notification_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_notification_proto_rawDesc), len(file_notification_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   13,
+			NumMessages:   34,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -29,7 +29,7 @@ type Payment struct {
 	UserId            string                 `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
 	Amount            float64                `protobuf:"fixed64,4,opt,name=amount,proto3" json:"amount,omitempty"`
 	Currency          string                 `protobuf:"bytes,5,opt,name=currency,proto3" json:"currency,omitempty"`                                              // USD, VND, EUR, etc.
-	Status            string                 `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`                                                  // PENDING, PROCESSING, COMPLETED, FAILED, REFUNDED
+	Status            string                 `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`                                                  // PENDING, PROCESSING, COMPLETED, FAILED, PARTIALLY_REFUNDED, REFUNDED
 	Method            string                 `protobuf:"bytes,7,opt,name=method,proto3" json:"method,omitempty"`                                                  // STRIPE, PAYPAL, CREDIT_CARD, BANK_TRANSFER
 	GatewayPaymentId  string                 `protobuf:"bytes,8,opt,name=gateway_payment_id,json=gatewayPaymentId,proto3" json:"gateway_payment_id,omitempty"`    // Stripe/PayPal transaction ID
 	GatewayCustomerId string                 `protobuf:"bytes,9,opt,name=gateway_customer_id,json=gatewayCustomerId,proto3" json:"gateway_customer_id,omitempty"` // Stripe/PayPal customer ID
@@ -37,6 +37,8 @@ type Payment struct {
 	Metadata          string                 `protobuf:"bytes,11,opt,name=metadata,proto3" json:"metadata,omitempty"` // JSON metadata
 	CreatedAt         string                 `protobuf:"bytes,12,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 	UpdatedAt         string                 `protobuf:"bytes,13,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	PaymentMethodId   string                 `protobuf:"bytes,14,opt,name=payment_method_id,json=paymentMethodId,proto3" json:"payment_method_id,omitempty"` // Saved payment method charged, if any
+	RefundedAmount    float64                `protobuf:"fixed64,15,opt,name=refunded_amount,json=refundedAmount,proto3" json:"refunded_amount,omitempty"`    // Sum of completed refunds
 	unknownFields     protoimpl.UnknownFields
 	sizeCache         protoimpl.SizeCache
 }
@@ -162,6 +164,20 @@ func (x *Payment) GetUpdatedAt() string {
 	return ""
 }
 
+func (x *Payment) GetPaymentMethodId() string {
+	if x != nil {
+		return x.PaymentMethodId
+	}
+	return ""
+}
+
+func (x *Payment) GetRefundedAmount() float64 {
+	if x != nil {
+		return x.RefundedAmount
+	}
+	return 0
+}
+
 // Refund represents a payment refund
 type Refund struct {
 	state           protoimpl.MessageState `protogen:"open.v1"`
@@ -361,12 +377,14 @@ type PaymentMethod struct {
 	state           protoimpl.MessageState `protogen:"open.v1"`
 	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	UserId          string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	MethodType      string                 `protobuf:"bytes,3,opt,name=method_type,json=methodType,proto3" json:"method_type,omitempty"` // CARD, BANK_ACCOUNT
-	Last4           string                 `protobuf:"bytes,4,opt,name=last4,proto3" json:"last4,omitempty"`                             // Last 4 digits
-	Brand           string                 `protobuf:"bytes,5,opt,name=brand,proto3" json:"brand,omitempty"`                             // VISA, MASTERCARD, etc.
-	GatewayMethodId string                 `protobuf:"bytes,6,opt,name=gateway_method_id,json=gatewayMethodId,proto3" json:"gateway_method_id,omitempty"`
+	MethodType      string                 `protobuf:"bytes,3,opt,name=method_type,json=methodType,proto3" json:"method_type,omitempty"`                  // CARD, BANK_ACCOUNT
+	Last4           string                 `protobuf:"bytes,4,opt,name=last4,proto3" json:"last4,omitempty"`                                              // Last 4 digits
+	Brand           string                 `protobuf:"bytes,5,opt,name=brand,proto3" json:"brand,omitempty"`                                              // VISA, MASTERCARD, etc.
+	GatewayMethodId string                 `protobuf:"bytes,6,opt,name=gateway_method_id,json=gatewayMethodId,proto3" json:"gateway_method_id,omitempty"` // Provider token (e.g. Stripe pm_...), never a card number
 	IsDefault       bool                   `protobuf:"varint,7,opt,name=is_default,json=isDefault,proto3" json:"is_default,omitempty"`
 	CreatedAt       string                 `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	ExpMonth        int32                  `protobuf:"varint,9,opt,name=exp_month,json=expMonth,proto3" json:"exp_month,omitempty"`
+	ExpYear         int32                  `protobuf:"varint,10,opt,name=exp_year,json=expYear,proto3" json:"exp_year,omitempty"`
 	unknownFields   protoimpl.UnknownFields
 	sizeCache       protoimpl.SizeCache
 }
@@ -457,6 +475,177 @@ func (x *PaymentMethod) GetCreatedAt() string {
 	return ""
 }
 
+func (x *PaymentMethod) GetExpMonth() int32 {
+	if x != nil {
+		return x.ExpMonth
+	}
+	return 0
+}
+
+func (x *PaymentMethod) GetExpYear() int32 {
+	if x != nil {
+		return x.ExpYear
+	}
+	return 0
+}
+
+// Subscription represents a recurring charge on a saved payment method
+type Subscription struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Id                string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId            string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	PlanId            string                 `protobuf:"bytes,3,opt,name=plan_id,json=planId,proto3" json:"plan_id,omitempty"`
+	Amount            float64                `protobuf:"fixed64,4,opt,name=amount,proto3" json:"amount,omitempty"`
+	Currency          string                 `protobuf:"bytes,5,opt,name=currency,proto3" json:"currency,omitempty"`
+	Interval          string                 `protobuf:"bytes,6,opt,name=interval,proto3" json:"interval,omitempty"` // DAY, WEEK, MONTH, YEAR
+	IntervalCount     int32                  `protobuf:"varint,7,opt,name=interval_count,json=intervalCount,proto3" json:"interval_count,omitempty"`
+	PaymentMethodId   string                 `protobuf:"bytes,8,opt,name=payment_method_id,json=paymentMethodId,proto3" json:"payment_method_id,omitempty"`
+	Status            string                 `protobuf:"bytes,9,opt,name=status,proto3" json:"status,omitempty"`                                                // ACTIVE, PAST_DUE, CANCELLED
+	CurrentPeriodEnd  string                 `protobuf:"bytes,10,opt,name=current_period_end,json=currentPeriodEnd,proto3" json:"current_period_end,omitempty"` // When the next period starts and is charged
+	NextChargeAt      string                 `protobuf:"bytes,11,opt,name=next_charge_at,json=nextChargeAt,proto3" json:"next_charge_at,omitempty"`             // Next charge attempt (later while retrying a failed charge)
+	FailedAttempts    int32                  `protobuf:"varint,12,opt,name=failed_attempts,json=failedAttempts,proto3" json:"failed_attempts,omitempty"`
+	LastFailureReason string                 `protobuf:"bytes,13,opt,name=last_failure_reason,json=lastFailureReason,proto3" json:"last_failure_reason,omitempty"`
+	CancelledAt       string                 `protobuf:"bytes,14,opt,name=cancelled_at,json=cancelledAt,proto3" json:"cancelled_at,omitempty"`
+	CreatedAt         string                 `protobuf:"bytes,15,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *Subscription) Reset() {
+	*x = Subscription{}
+	mi := &file_payment_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Subscription) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Subscription) ProtoMessage() {}
+
+func (x *Subscription) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Subscription.ProtoReflect.Descriptor instead.
+func (*Subscription) Descriptor() ([]byte, []int) {
+	return file_payment_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Subscription) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Subscription) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *Subscription) GetPlanId() string {
+	if x != nil {
+		return x.PlanId
+	}
+	return ""
+}
+
+func (x *Subscription) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *Subscription) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *Subscription) GetInterval() string {
+	if x != nil {
+		return x.Interval
+	}
+	return ""
+}
+
+func (x *Subscription) GetIntervalCount() int32 {
+	if x != nil {
+		return x.IntervalCount
+	}
+	return 0
+}
+
+func (x *Subscription) GetPaymentMethodId() string {
+	if x != nil {
+		return x.PaymentMethodId
+	}
+	return ""
+}
+
+func (x *Subscription) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Subscription) GetCurrentPeriodEnd() string {
+	if x != nil {
+		return x.CurrentPeriodEnd
+	}
+	return ""
+}
+
+func (x *Subscription) GetNextChargeAt() string {
+	if x != nil {
+		return x.NextChargeAt
+	}
+	return ""
+}
+
+func (x *Subscription) GetFailedAttempts() int32 {
+	if x != nil {
+		return x.FailedAttempts
+	}
+	return 0
+}
+
+func (x *Subscription) GetLastFailureReason() string {
+	if x != nil {
+		return x.LastFailureReason
+	}
+	return ""
+}
+
+func (x *Subscription) GetCancelledAt() string {
+	if x != nil {
+		return x.CancelledAt
+	}
+	return ""
+}
+
+func (x *Subscription) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
 // =================================
 // ProcessPayment - Main payment processing
 // =================================
@@ -475,7 +664,7 @@ type ProcessPaymentRequest struct {
 
 func (x *ProcessPaymentRequest) Reset() {
 	*x = ProcessPaymentRequest{}
-	mi := &file_payment_proto_msgTypes[4]
+	mi := &file_payment_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -487,7 +676,7 @@ func (x *ProcessPaymentRequest) String() string {
 func (*ProcessPaymentRequest) ProtoMessage() {}
 
 func (x *ProcessPaymentRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_payment_proto_msgTypes[4]
+	mi := &file_payment_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -500,7 +689,7 @@ func (x *ProcessPaymentRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ProcessPaymentRequest.ProtoReflect.Descriptor instead.
 func (*ProcessPaymentRequest) Descriptor() ([]byte, []int) {
-	return file_payment_proto_rawDescGZIP(), []int{4}
+	return file_payment_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *ProcessPaymentRequest) GetOrderId() string {
@@ -564,7 +753,7 @@ type ProcessPaymentResponse struct {
 
 func (x *ProcessPaymentResponse) Reset() {
 	*x = ProcessPaymentResponse{}
-	mi := &file_payment_proto_msgTypes[5]
+	mi := &file_payment_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -576,7 +765,7 @@ func (x *ProcessPaymentResponse) String() string {
 func (*ProcessPaymentResponse) ProtoMessage() {}
 
 func (x *ProcessPaymentResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_payment_proto_msgTypes[5]
+	mi := &file_payment_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -589,7 +778,7 @@ func (x *ProcessPaymentResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ProcessPaymentResponse.ProtoReflect.Descriptor instead.
 func (*ProcessPaymentResponse) Descriptor() ([]byte, []int) {
-	return file_payment_proto_rawDescGZIP(), []int{5}
+	return file_payment_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *ProcessPaymentResponse) GetPayment() *Payment {
@@ -626,15 +815,17 @@ func (x *ProcessPaymentResponse) GetClientSecret() string {
 type RefundPaymentRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	PaymentId     string                 `protobuf:"bytes,1,opt,name=payment_id,json=paymentId,proto3" json:"payment_id,omitempty"`
-	Amount        float64                `protobuf:"fixed64,2,opt,name=amount,proto3" json:"amount,omitempty"` // Partial or full refund
+	Amount        float64                `protobuf:"fixed64,2,opt,name=amount,proto3" json:"amount,omitempty"` // Partial or full refund; 0 refunds whatever is still refundable
 	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	Items         []*RefundItem          `protobuf:"bytes,4,rep,name=items,proto3" json:"items,omitempty"`                                 // Items returned to stock; a full refund without items returns the whole order
+	SkipRestock   bool                   `protobuf:"varint,5,opt,name=skip_restock,json=skipRestock,proto3" json:"skip_restock,omitempty"` // Nothing goes back to stock, e.g. a return restocked on receipt; no items allowed
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *RefundPaymentRequest) Reset() {
 	*x = RefundPaymentRequest{}
-	mi := &file_payment_proto_msgTypes[6]
+	mi := &file_payment_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -646,7 +837,7 @@ func (x *RefundPaymentRequest) String() string {
 func (*RefundPaymentRequest) ProtoMessage() {}
 
 func (x *RefundPaymentRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_payment_proto_msgTypes[6]
+	mi := &file_payment_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -659,7 +850,7 @@ func (x *RefundPaymentRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RefundPaymentRequest.ProtoReflect.Descriptor instead.
 func (*RefundPaymentRequest) Descriptor() ([]byte, []int) {
-	return file_payment_proto_rawDescGZIP(), []int{6}
+	return file_payment_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *RefundPaymentRequest) GetPaymentId() string {
@@ -683,6 +874,72 @@ func (x *RefundPaymentRequest) GetReason() string {
 	return ""
 }
 
+func (x *RefundPaymentRequest) GetItems() []*RefundItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *RefundPaymentRequest) GetSkipRestock() bool {
+	if x != nil {
+		return x.SkipRestock
+	}
+	return false
+}
+
+type RefundItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Quantity      int32                  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RefundItem) Reset() {
+	*x = RefundItem{}
+	mi := &file_payment_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RefundItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefundItem) ProtoMessage() {}
+
+func (x *RefundItem) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefundItem.ProtoReflect.Descriptor instead.
+func (*RefundItem) Descriptor() ([]byte, []int) {
+	return file_payment_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *RefundItem) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *RefundItem) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
 type RefundPaymentResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Refund        *Refund                `protobuf:"bytes,1,opt,name=refund,proto3" json:"refund,omitempty"`
@@ -694,7 +951,7 @@ type RefundPaymentResponse struct {
 
 func (x *RefundPaymentResponse) Reset() {
 	*x = RefundPaymentResponse{}
-	mi := &file_payment_proto_msgTypes[7]
+	mi := &file_payment_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -706,7 +963,7 @@ func (x *RefundPaymentResponse) String() string {
 func (*RefundPaymentResponse) ProtoMessage() {}
 
 func (x *RefundPaymentResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_payment_proto_msgTypes[7]
+	mi := &file_payment_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -719,7 +976,7 @@ func (x *RefundPaymentResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RefundPaymentResponse.ProtoReflect.Descriptor instead.
 func (*RefundPaymentResponse) Descriptor() ([]byte, []int) {
-	return file_payment_proto_rawDescGZIP(), []int{7}
+	return file_payment_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *RefundPaymentResponse) GetRefund() *Refund {
@@ -755,7 +1012,7 @@ type GetPaymentRequest struct {
 
 func (x *GetPaymentRequest) Reset() {
 	*x = GetPaymentRequest{}
-	mi := &file_payment_proto_msgTypes[8]
+	mi := &file_payment_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -767,7 +1024,7 @@ func (x *GetPaymentRequest) String() string {
 func (*GetPaymentRequest) ProtoMessage() {}
 
 func (x *GetPaymentRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_payment_proto_msgTypes[8]
+	mi := &file_payment_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -780,7 +1037,7 @@ func (x *GetPaymentRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetPaymentRequest.ProtoReflect.Descriptor instead.
 func (*GetPaymentRequest) Descriptor() ([]byte, []int) {
-	return file_payment_proto_rawDescGZIP(), []int{8}
+	return file_payment_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *GetPaymentRequest) GetPaymentId() string {
@@ -801,7 +1058,7 @@ type GetPaymentResponse struct {
 
 func (x *GetPaymentResponse) Reset() {
 	*x = GetPaymentResponse{}
-	mi := &file_payment_proto_msgTypes[9]
+	mi := &file_payment_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -813,7 +1070,7 @@ func (x *GetPaymentResponse) String() string {
 func (*GetPaymentResponse) ProtoMessage() {}
 
 func (x *GetPaymentResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_payment_proto_msgTypes[9]
+	mi := &file_payment_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -826,7 +1083,7 @@ func (x *GetPaymentResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetPaymentResponse.ProtoReflect.Descriptor instead.
 func (*GetPaymentResponse) Descriptor() ([]byte, []int) {
-	return file_payment_proto_rawDescGZIP(), []int{9}
+	return file_payment_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *GetPaymentResponse) GetPayment() *Payment {
@@ -862,7 +1119,7 @@ type GetPaymentByOrderRequest struct {
 
 func (x *GetPaymentByOrderRequest) Reset() {
 	*x = GetPaymentByOrderRequest{}
-	mi := &file_payment_proto_msgTypes[10]
+	mi := &file_payment_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -874,7 +1131,7 @@ func (x *GetPaymentByOrderRequest) String() string {
 func (*GetPaymentByOrderRequest) ProtoMessage() {}
 
 func (x *GetPaymentByOrderRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_payment_proto_msgTypes[10]
+	mi := &file_payment_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -887,7 +1144,7 @@ func (x *GetPaymentByOrderRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetPaymentByOrderRequest.ProtoReflect.Descriptor instead.
 func (*GetPaymentByOrderRequest) Descriptor() ([]byte, []int) {
-	return file_payment_proto_rawDescGZIP(), []int{10}
+	return file_payment_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *GetPaymentByOrderRequest) GetOrderId() string {
@@ -908,7 +1165,7 @@ type GetPaymentByOrderResponse struct {
 
 func (x *GetPaymentByOrderResponse) Reset() {
 	*x = GetPaymentByOrderResponse{}
-	mi := &file_payment_proto_msgTypes[11]
+	mi := &file_payment_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -920,7 +1177,7 @@ func (x *GetPaymentByOrderResponse) String() string {
 func (*GetPaymentByOrderResponse) ProtoMessage() {}
 
 func (x *GetPaymentByOrderResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_payment_proto_msgTypes[11]
+	mi := &file_payment_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -933,7 +1190,7 @@ func (x *GetPaymentByOrderResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetPaymentByOrderResponse.ProtoReflect.Descriptor instead.
 func (*GetPaymentByOrderResponse) Descriptor() ([]byte, []int) {
-	return file_payment_proto_rawDescGZIP(), []int{11}
+	return file_payment_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *GetPaymentByOrderResponse) GetPayment() *Payment {
@@ -971,7 +1228,7 @@ type GetPaymentHistoryRequest struct {
 
 func (x *GetPaymentHistoryRequest) Reset() {
 	*x = GetPaymentHistoryRequest{}
-	mi := &file_payment_proto_msgTypes[12]
+	mi := &file_payment_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -983,7 +1240,7 @@ func (x *GetPaymentHistoryRequest) String() string {
 func (*GetPaymentHistoryRequest) ProtoMessage() {}
 
 func (x *GetPaymentHistoryRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_payment_proto_msgTypes[12]
+	mi := &file_payment_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -996,7 +1253,7 @@ func (x *GetPaymentHistoryRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetPaymentHistoryRequest.ProtoReflect.Descriptor instead.
 func (*GetPaymentHistoryRequest) Descriptor() ([]byte, []int) {
-	return file_payment_proto_rawDescGZIP(), []int{12}
+	return file_payment_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *GetPaymentHistoryRequest) GetUserId() string {
@@ -1024,13 +1281,15 @@ type GetPaymentHistoryResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Payments      []*Payment             `protobuf:"bytes,1,rep,name=payments,proto3" json:"payments,omitempty"`
 	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	TotalPages    int32                  `protobuf:"varint,3,opt,name=total_pages,json=totalPages,proto3" json:"total_pages,omitempty"` // at the requested limit
+	HasMore       bool                   `protobuf:"varint,4,opt,name=has_more,json=hasMore,proto3" json:"has_more,omitempty"`          // payments remain after this page
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *GetPaymentHistoryResponse) Reset() {
 	*x = GetPaymentHistoryResponse{}
-	mi := &file_payment_proto_msgTypes[13]
+	mi := &file_payment_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1042,7 +1301,7 @@ func (x *GetPaymentHistoryResponse) String() string {
 func (*GetPaymentHistoryResponse) ProtoMessage() {}
 
 func (x *GetPaymentHistoryResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_payment_proto_msgTypes[13]
+	mi := &file_payment_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1055,7 +1314,7 @@ func (x *GetPaymentHistoryResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetPaymentHistoryResponse.ProtoReflect.Descriptor instead.
 func (*GetPaymentHistoryResponse) Descriptor() ([]byte, []int) {
-	return file_payment_proto_rawDescGZIP(), []int{13}
+	return file_payment_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *GetPaymentHistoryResponse) GetPayments() []*Payment {
@@ -1072,6 +1331,20 @@ func (x *GetPaymentHistoryResponse) GetTotal() int32 {
 	return 0
 }
 
+func (x *GetPaymentHistoryResponse) GetTotalPages() int32 {
+	if x != nil {
+		return x.TotalPages
+	}
+	return 0
+}
+
+func (x *GetPaymentHistoryResponse) GetHasMore() bool {
+	if x != nil {
+		return x.HasMore
+	}
+	return false
+}
+
 // =================================
 // ConfirmPayment - Confirm pending payment (for 3D Secure)
 // =================================
@@ -1085,7 +1358,7 @@ type ConfirmPaymentRequest struct {
 
 func (x *ConfirmPaymentRequest) Reset() {
 	*x = ConfirmPaymentRequest{}
-	mi := &file_payment_proto_msgTypes[14]
+	mi := &file_payment_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1097,7 +1370,7 @@ func (x *ConfirmPaymentRequest) String() string {
 func (*ConfirmPaymentRequest) ProtoMessage() {}
 
 func (x *ConfirmPaymentRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_payment_proto_msgTypes[14]
+	mi := &file_payment_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1110,7 +1383,7 @@ func (x *ConfirmPaymentRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ConfirmPaymentRequest.ProtoReflect.Descriptor instead.
 func (*ConfirmPaymentRequest) Descriptor() ([]byte, []int) {
-	return file_payment_proto_rawDescGZIP(), []int{14}
+	return file_payment_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *ConfirmPaymentRequest) GetPaymentId() string {
@@ -1138,7 +1411,7 @@ type ConfirmPaymentResponse struct {
 
 func (x *ConfirmPaymentResponse) Reset() {
 	*x = ConfirmPaymentResponse{}
-	mi := &file_payment_proto_msgTypes[15]
+	mi := &file_payment_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1150,7 +1423,7 @@ func (x *ConfirmPaymentResponse) String() string {
 func (*ConfirmPaymentResponse) ProtoMessage() {}
 
 func (x *ConfirmPaymentResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_payment_proto_msgTypes[15]
+	mi := &file_payment_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1163,7 +1436,7 @@ func (x *ConfirmPaymentResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ConfirmPaymentResponse.ProtoReflect.Descriptor instead.
 func (*ConfirmPaymentResponse) Descriptor() ([]byte, []int) {
-	return file_payment_proto_rawDescGZIP(), []int{15}
+	return file_payment_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *ConfirmPaymentResponse) GetPayment() *Payment {
@@ -1193,16 +1466,21 @@ func (x *ConfirmPaymentResponse) GetMessage() string {
 type SavePaymentMethodRequest struct {
 	state           protoimpl.MessageState `protogen:"open.v1"`
 	UserId          string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	MethodType      string                 `protobuf:"bytes,2,opt,name=method_type,json=methodType,proto3" json:"method_type,omitempty"`
-	GatewayMethodId string                 `protobuf:"bytes,3,opt,name=gateway_method_id,json=gatewayMethodId,proto3" json:"gateway_method_id,omitempty"`
+	MethodType      string                 `protobuf:"bytes,2,opt,name=method_type,json=methodType,proto3" json:"method_type,omitempty"`                  // CARD, BANK_ACCOUNT
+	GatewayMethodId string                 `protobuf:"bytes,3,opt,name=gateway_method_id,json=gatewayMethodId,proto3" json:"gateway_method_id,omitempty"` // Token from the provider's client-side tokenization
 	IsDefault       bool                   `protobuf:"varint,4,opt,name=is_default,json=isDefault,proto3" json:"is_default,omitempty"`
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+	// Display metadata returned by the provider with the token; required for CARD
+	Last4         string `protobuf:"bytes,5,opt,name=last4,proto3" json:"last4,omitempty"`
+	Brand         string `protobuf:"bytes,6,opt,name=brand,proto3" json:"brand,omitempty"`
+	ExpMonth      int32  `protobuf:"varint,7,opt,name=exp_month,json=expMonth,proto3" json:"exp_month,omitempty"`
+	ExpYear       int32  `protobuf:"varint,8,opt,name=exp_year,json=expYear,proto3" json:"exp_year,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *SavePaymentMethodRequest) Reset() {
 	*x = SavePaymentMethodRequest{}
-	mi := &file_payment_proto_msgTypes[16]
+	mi := &file_payment_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1214,7 +1492,7 @@ func (x *SavePaymentMethodRequest) String() string {
 func (*SavePaymentMethodRequest) ProtoMessage() {}
 
 func (x *SavePaymentMethodRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_payment_proto_msgTypes[16]
+	mi := &file_payment_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1227,7 +1505,7 @@ func (x *SavePaymentMethodRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SavePaymentMethodRequest.ProtoReflect.Descriptor instead.
 func (*SavePaymentMethodRequest) Descriptor() ([]byte, []int) {
-	return file_payment_proto_rawDescGZIP(), []int{16}
+	return file_payment_proto_rawDescGZIP(), []int{18}
 }
 
 func (x *SavePaymentMethodRequest) GetUserId() string {
@@ -1258,6 +1536,34 @@ func (x *SavePaymentMethodRequest) GetIsDefault() bool {
 	return false
 }
 
+func (x *SavePaymentMethodRequest) GetLast4() string {
+	if x != nil {
+		return x.Last4
+	}
+	return ""
+}
+
+func (x *SavePaymentMethodRequest) GetBrand() string {
+	if x != nil {
+		return x.Brand
+	}
+	return ""
+}
+
+func (x *SavePaymentMethodRequest) GetExpMonth() int32 {
+	if x != nil {
+		return x.ExpMonth
+	}
+	return 0
+}
+
+func (x *SavePaymentMethodRequest) GetExpYear() int32 {
+	if x != nil {
+		return x.ExpYear
+	}
+	return 0
+}
+
 type SavePaymentMethodResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	PaymentMethod *PaymentMethod         `protobuf:"bytes,1,opt,name=payment_method,json=paymentMethod,proto3" json:"payment_method,omitempty"`
@@ -1269,7 +1575,7 @@ type SavePaymentMethodResponse struct {
 
 func (x *SavePaymentMethodResponse) Reset() {
 	*x = SavePaymentMethodResponse{}
-	mi := &file_payment_proto_msgTypes[17]
+	mi := &file_payment_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1281,7 +1587,7 @@ func (x *SavePaymentMethodResponse) String() string {
 func (*SavePaymentMethodResponse) ProtoMessage() {}
 
 func (x *SavePaymentMethodResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_payment_proto_msgTypes[17]
+	mi := &file_payment_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1294,7 +1600,7 @@ func (x *SavePaymentMethodResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SavePaymentMethodResponse.ProtoReflect.Descriptor instead.
 func (*SavePaymentMethodResponse) Descriptor() ([]byte, []int) {
-	return file_payment_proto_rawDescGZIP(), []int{17}
+	return file_payment_proto_rawDescGZIP(), []int{19}
 }
 
 func (x *SavePaymentMethodResponse) GetPaymentMethod() *PaymentMethod {
@@ -1330,7 +1636,7 @@ type GetPaymentMethodsRequest struct {
 
 func (x *GetPaymentMethodsRequest) Reset() {
 	*x = GetPaymentMethodsRequest{}
-	mi := &file_payment_proto_msgTypes[18]
+	mi := &file_payment_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1342,7 +1648,7 @@ func (x *GetPaymentMethodsRequest) String() string {
 func (*GetPaymentMethodsRequest) ProtoMessage() {}
 
 func (x *GetPaymentMethodsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_payment_proto_msgTypes[18]
+	mi := &file_payment_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1355,7 +1661,7 @@ func (x *GetPaymentMethodsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetPaymentMethodsRequest.ProtoReflect.Descriptor instead.
 func (*GetPaymentMethodsRequest) Descriptor() ([]byte, []int) {
-	return file_payment_proto_rawDescGZIP(), []int{18}
+	return file_payment_proto_rawDescGZIP(), []int{20}
 }
 
 func (x *GetPaymentMethodsRequest) GetUserId() string {
@@ -1374,7 +1680,7 @@ type GetPaymentMethodsResponse struct {
 
 func (x *GetPaymentMethodsResponse) Reset() {
 	*x = GetPaymentMethodsResponse{}
-	mi := &file_payment_proto_msgTypes[19]
+	mi := &file_payment_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1386,7 +1692,7 @@ func (x *GetPaymentMethodsResponse) String() string {
 func (*GetPaymentMethodsResponse) ProtoMessage() {}
 
 func (x *GetPaymentMethodsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_payment_proto_msgTypes[19]
+	mi := &file_payment_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1399,7 +1705,7 @@ func (x *GetPaymentMethodsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetPaymentMethodsResponse.ProtoReflect.Descriptor instead.
 func (*GetPaymentMethodsResponse) Descriptor() ([]byte, []int) {
-	return file_payment_proto_rawDescGZIP(), []int{19}
+	return file_payment_proto_rawDescGZIP(), []int{21}
 }
 
 func (x *GetPaymentMethodsResponse) GetPaymentMethods() []*PaymentMethod {
@@ -1409,6 +1715,399 @@ func (x *GetPaymentMethodsResponse) GetPaymentMethods() []*PaymentMethod {
 	return nil
 }
 
+// =================================
+// DeletePaymentMethod - Remove a saved payment method
+// =================================
+type DeletePaymentMethodRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	UserId          string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	PaymentMethodId string                 `protobuf:"bytes,2,opt,name=payment_method_id,json=paymentMethodId,proto3" json:"payment_method_id,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *DeletePaymentMethodRequest) Reset() {
+	*x = DeletePaymentMethodRequest{}
+	mi := &file_payment_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeletePaymentMethodRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeletePaymentMethodRequest) ProtoMessage() {}
+
+func (x *DeletePaymentMethodRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeletePaymentMethodRequest.ProtoReflect.Descriptor instead.
+func (*DeletePaymentMethodRequest) Descriptor() ([]byte, []int) {
+	return file_payment_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *DeletePaymentMethodRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *DeletePaymentMethodRequest) GetPaymentMethodId() string {
+	if x != nil {
+		return x.PaymentMethodId
+	}
+	return ""
+}
+
+type DeletePaymentMethodResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeletePaymentMethodResponse) Reset() {
+	*x = DeletePaymentMethodResponse{}
+	mi := &file_payment_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeletePaymentMethodResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeletePaymentMethodResponse) ProtoMessage() {}
+
+func (x *DeletePaymentMethodResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeletePaymentMethodResponse.ProtoReflect.Descriptor instead.
+func (*DeletePaymentMethodResponse) Descriptor() ([]byte, []int) {
+	return file_payment_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *DeletePaymentMethodResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *DeletePaymentMethodResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// =================================
+// CreateSubscription - Start recurring charges
+// =================================
+type CreateSubscriptionRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	UserId          string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	PlanId          string                 `protobuf:"bytes,2,opt,name=plan_id,json=planId,proto3" json:"plan_id,omitempty"`
+	Amount          float64                `protobuf:"fixed64,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	Currency        string                 `protobuf:"bytes,4,opt,name=currency,proto3" json:"currency,omitempty"`
+	Interval        string                 `protobuf:"bytes,5,opt,name=interval,proto3" json:"interval,omitempty"`                                        // DAY, WEEK, MONTH, YEAR
+	IntervalCount   int32                  `protobuf:"varint,6,opt,name=interval_count,json=intervalCount,proto3" json:"interval_count,omitempty"`        // Default 1
+	PaymentMethodId string                 `protobuf:"bytes,7,opt,name=payment_method_id,json=paymentMethodId,proto3" json:"payment_method_id,omitempty"` // Saved payment method to charge
+	StartAt         string                 `protobuf:"bytes,8,opt,name=start_at,json=startAt,proto3" json:"start_at,omitempty"`                           // Optional RFC3339 first charge date; default now
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *CreateSubscriptionRequest) Reset() {
+	*x = CreateSubscriptionRequest{}
+	mi := &file_payment_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateSubscriptionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateSubscriptionRequest) ProtoMessage() {}
+
+func (x *CreateSubscriptionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateSubscriptionRequest.ProtoReflect.Descriptor instead.
+func (*CreateSubscriptionRequest) Descriptor() ([]byte, []int) {
+	return file_payment_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *CreateSubscriptionRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *CreateSubscriptionRequest) GetPlanId() string {
+	if x != nil {
+		return x.PlanId
+	}
+	return ""
+}
+
+func (x *CreateSubscriptionRequest) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *CreateSubscriptionRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *CreateSubscriptionRequest) GetInterval() string {
+	if x != nil {
+		return x.Interval
+	}
+	return ""
+}
+
+func (x *CreateSubscriptionRequest) GetIntervalCount() int32 {
+	if x != nil {
+		return x.IntervalCount
+	}
+	return 0
+}
+
+func (x *CreateSubscriptionRequest) GetPaymentMethodId() string {
+	if x != nil {
+		return x.PaymentMethodId
+	}
+	return ""
+}
+
+func (x *CreateSubscriptionRequest) GetStartAt() string {
+	if x != nil {
+		return x.StartAt
+	}
+	return ""
+}
+
+type CreateSubscriptionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Subscription  *Subscription          `protobuf:"bytes,1,opt,name=subscription,proto3" json:"subscription,omitempty"`
+	Success       bool                   `protobuf:"varint,2,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateSubscriptionResponse) Reset() {
+	*x = CreateSubscriptionResponse{}
+	mi := &file_payment_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateSubscriptionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateSubscriptionResponse) ProtoMessage() {}
+
+func (x *CreateSubscriptionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateSubscriptionResponse.ProtoReflect.Descriptor instead.
+func (*CreateSubscriptionResponse) Descriptor() ([]byte, []int) {
+	return file_payment_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *CreateSubscriptionResponse) GetSubscription() *Subscription {
+	if x != nil {
+		return x.Subscription
+	}
+	return nil
+}
+
+func (x *CreateSubscriptionResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *CreateSubscriptionResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// =================================
+// CancelSubscription - Stop recurring charges
+// =================================
+type CancelSubscriptionRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	UserId         string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	SubscriptionId string                 `protobuf:"bytes,2,opt,name=subscription_id,json=subscriptionId,proto3" json:"subscription_id,omitempty"`
+	Reason         string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *CancelSubscriptionRequest) Reset() {
+	*x = CancelSubscriptionRequest{}
+	mi := &file_payment_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelSubscriptionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelSubscriptionRequest) ProtoMessage() {}
+
+func (x *CancelSubscriptionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelSubscriptionRequest.ProtoReflect.Descriptor instead.
+func (*CancelSubscriptionRequest) Descriptor() ([]byte, []int) {
+	return file_payment_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *CancelSubscriptionRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *CancelSubscriptionRequest) GetSubscriptionId() string {
+	if x != nil {
+		return x.SubscriptionId
+	}
+	return ""
+}
+
+func (x *CancelSubscriptionRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type CancelSubscriptionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Subscription  *Subscription          `protobuf:"bytes,1,opt,name=subscription,proto3" json:"subscription,omitempty"`
+	Success       bool                   `protobuf:"varint,2,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelSubscriptionResponse) Reset() {
+	*x = CancelSubscriptionResponse{}
+	mi := &file_payment_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelSubscriptionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelSubscriptionResponse) ProtoMessage() {}
+
+func (x *CancelSubscriptionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelSubscriptionResponse.ProtoReflect.Descriptor instead.
+func (*CancelSubscriptionResponse) Descriptor() ([]byte, []int) {
+	return file_payment_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *CancelSubscriptionResponse) GetSubscription() *Subscription {
+	if x != nil {
+		return x.Subscription
+	}
+	return nil
+}
+
+func (x *CancelSubscriptionResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *CancelSubscriptionResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
 // =================================
 // WebhookEvent - Handle payment gateway webhooks
 // =================================
@@ -1423,7 +2122,7 @@ type WebhookEventRequest struct {
 
 func (x *WebhookEventRequest) Reset() {
 	*x = WebhookEventRequest{}
-	mi := &file_payment_proto_msgTypes[20]
+	mi := &file_payment_proto_msgTypes[28]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1435,7 +2134,7 @@ func (x *WebhookEventRequest) String() string {
 func (*WebhookEventRequest) ProtoMessage() {}
 
 func (x *WebhookEventRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_payment_proto_msgTypes[20]
+	mi := &file_payment_proto_msgTypes[28]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1448,7 +2147,7 @@ func (x *WebhookEventRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use WebhookEventRequest.ProtoReflect.Descriptor instead.
 func (*WebhookEventRequest) Descriptor() ([]byte, []int) {
-	return file_payment_proto_rawDescGZIP(), []int{20}
+	return file_payment_proto_rawDescGZIP(), []int{28}
 }
 
 func (x *WebhookEventRequest) GetGateway() string {
@@ -1482,7 +2181,7 @@ type WebhookEventResponse struct {
 
 func (x *WebhookEventResponse) Reset() {
 	*x = WebhookEventResponse{}
-	mi := &file_payment_proto_msgTypes[21]
+	mi := &file_payment_proto_msgTypes[29]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1494,7 +2193,7 @@ func (x *WebhookEventResponse) String() string {
 func (*WebhookEventResponse) ProtoMessage() {}
 
 func (x *WebhookEventResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_payment_proto_msgTypes[21]
+	mi := &file_payment_proto_msgTypes[29]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1507,7 +2206,7 @@ func (x *WebhookEventResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use WebhookEventResponse.ProtoReflect.Descriptor instead.
 func (*WebhookEventResponse) Descriptor() ([]byte, []int) {
-	return file_payment_proto_rawDescGZIP(), []int{21}
+	return file_payment_proto_rawDescGZIP(), []int{29}
 }
 
 func (x *WebhookEventResponse) GetSuccess() bool {
@@ -1528,7 +2227,7 @@ var File_payment_proto protoreflect.FileDescriptor
 
 const file_payment_proto_rawDesc = "" +
 	"\n" +
-	"\rpayment.proto\x12\x0fpayment_service\"\x90\x03\n" +
+	"\rpayment.proto\x12\x0fpayment_service\"\xe5\x03\n" +
 	"\aPayment\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x19\n" +
 	"\border_id\x18\x02 \x01(\tR\aorderId\x12\x17\n" +
@@ -1545,7 +2244,9 @@ const file_payment_proto_rawDesc = "" +
 	"\n" +
 	"created_at\x18\f \x01(\tR\tcreatedAt\x12\x1d\n" +
 	"\n" +
-	"updated_at\x18\r \x01(\tR\tupdatedAt\"\xe9\x01\n" +
+	"updated_at\x18\r \x01(\tR\tupdatedAt\x12*\n" +
+	"\x11payment_method_id\x18\x0e \x01(\tR\x0fpaymentMethodId\x12'\n" +
+	"\x0frefunded_amount\x18\x0f \x01(\x01R\x0erefundedAmount\"\xe9\x01\n" +
 	"\x06Refund\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
 	"\n" +
@@ -1567,7 +2268,7 @@ const file_payment_proto_rawDesc = "" +
 	"\x06status\x18\x05 \x01(\tR\x06status\x12)\n" +
 	"\x10gateway_response\x18\x06 \x01(\tR\x0fgatewayResponse\x12\x1d\n" +
 	"\n" +
-	"created_at\x18\a \x01(\tR\tcreatedAt\"\xef\x01\n" +
+	"created_at\x18\a \x01(\tR\tcreatedAt\"\xa7\x02\n" +
 	"\rPaymentMethod\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
 	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x1f\n" +
@@ -1579,7 +2280,28 @@ const file_payment_proto_rawDesc = "" +
 	"\n" +
 	"is_default\x18\a \x01(\bR\tisDefault\x12\x1d\n" +
 	"\n" +
-	"created_at\x18\b \x01(\tR\tcreatedAt\"\xd2\x02\n" +
+	"created_at\x18\b \x01(\tR\tcreatedAt\x12\x1b\n" +
+	"\texp_month\x18\t \x01(\x05R\bexpMonth\x12\x19\n" +
+	"\bexp_year\x18\n" +
+	" \x01(\x05R\aexpYear\"\xfa\x03\n" +
+	"\fSubscription\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x17\n" +
+	"\aplan_id\x18\x03 \x01(\tR\x06planId\x12\x16\n" +
+	"\x06amount\x18\x04 \x01(\x01R\x06amount\x12\x1a\n" +
+	"\bcurrency\x18\x05 \x01(\tR\bcurrency\x12\x1a\n" +
+	"\binterval\x18\x06 \x01(\tR\binterval\x12%\n" +
+	"\x0einterval_count\x18\a \x01(\x05R\rintervalCount\x12*\n" +
+	"\x11payment_method_id\x18\b \x01(\tR\x0fpaymentMethodId\x12\x16\n" +
+	"\x06status\x18\t \x01(\tR\x06status\x12,\n" +
+	"\x12current_period_end\x18\n" +
+	" \x01(\tR\x10currentPeriodEnd\x12$\n" +
+	"\x0enext_charge_at\x18\v \x01(\tR\fnextChargeAt\x12'\n" +
+	"\x0ffailed_attempts\x18\f \x01(\x05R\x0efailedAttempts\x12.\n" +
+	"\x13last_failure_reason\x18\r \x01(\tR\x11lastFailureReason\x12!\n" +
+	"\fcancelled_at\x18\x0e \x01(\tR\vcancelledAt\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x0f \x01(\tR\tcreatedAt\"\xd2\x02\n" +
 	"\x15ProcessPaymentRequest\x12\x19\n" +
 	"\border_id\x18\x01 \x01(\tR\aorderId\x12\x17\n" +
 	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x16\n" +
@@ -1595,12 +2317,19 @@ const file_payment_proto_rawDesc = "" +
 	"\apayment\x18\x01 \x01(\v2\x18.payment_service.PaymentR\apayment\x12\x18\n" +
 	"\asuccess\x18\x02 \x01(\bR\asuccess\x12\x18\n" +
 	"\amessage\x18\x03 \x01(\tR\amessage\x12#\n" +
-	"\rclient_secret\x18\x04 \x01(\tR\fclientSecret\"e\n" +
+	"\rclient_secret\x18\x04 \x01(\tR\fclientSecret\"\xbb\x01\n" +
 	"\x14RefundPaymentRequest\x12\x1d\n" +
 	"\n" +
 	"payment_id\x18\x01 \x01(\tR\tpaymentId\x12\x16\n" +
 	"\x06amount\x18\x02 \x01(\x01R\x06amount\x12\x16\n" +
-	"\x06reason\x18\x03 \x01(\tR\x06reason\"|\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\x121\n" +
+	"\x05items\x18\x04 \x03(\v2\x1b.payment_service.RefundItemR\x05items\x12!\n" +
+	"\fskip_restock\x18\x05 \x01(\bR\vskipRestock\"G\n" +
+	"\n" +
+	"RefundItem\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x1a\n" +
+	"\bquantity\x18\x02 \x01(\x05R\bquantity\"|\n" +
 	"\x15RefundPaymentResponse\x12/\n" +
 	"\x06refund\x18\x01 \x01(\v2\x17.payment_service.RefundR\x06refund\x12\x18\n" +
 	"\asuccess\x18\x02 \x01(\bR\asuccess\x12\x18\n" +
@@ -1621,10 +2350,13 @@ const file_payment_proto_rawDesc = "" +
 	"\x18GetPaymentHistoryRequest\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x14\n" +
 	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x16\n" +
-	"\x06offset\x18\x03 \x01(\x05R\x06offset\"g\n" +
+	"\x06offset\x18\x03 \x01(\x05R\x06offset\"\xa3\x01\n" +
 	"\x19GetPaymentHistoryResponse\x124\n" +
 	"\bpayments\x18\x01 \x03(\v2\x18.payment_service.PaymentR\bpayments\x12\x14\n" +
-	"\x05total\x18\x02 \x01(\x05R\x05total\"b\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\x12\x1f\n" +
+	"\vtotal_pages\x18\x03 \x01(\x05R\n" +
+	"totalPages\x12\x19\n" +
+	"\bhas_more\x18\x04 \x01(\bR\ahasMore\"b\n" +
 	"\x15ConfirmPaymentRequest\x12\x1d\n" +
 	"\n" +
 	"payment_id\x18\x01 \x01(\tR\tpaymentId\x12*\n" +
@@ -1632,14 +2364,18 @@ const file_payment_proto_rawDesc = "" +
 	"\x16ConfirmPaymentResponse\x122\n" +
 	"\apayment\x18\x01 \x01(\v2\x18.payment_service.PaymentR\apayment\x12\x18\n" +
 	"\asuccess\x18\x02 \x01(\bR\asuccess\x12\x18\n" +
-	"\amessage\x18\x03 \x01(\tR\amessage\"\x9f\x01\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\"\x83\x02\n" +
 	"\x18SavePaymentMethodRequest\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1f\n" +
 	"\vmethod_type\x18\x02 \x01(\tR\n" +
 	"methodType\x12*\n" +
 	"\x11gateway_method_id\x18\x03 \x01(\tR\x0fgatewayMethodId\x12\x1d\n" +
 	"\n" +
-	"is_default\x18\x04 \x01(\bR\tisDefault\"\x96\x01\n" +
+	"is_default\x18\x04 \x01(\bR\tisDefault\x12\x14\n" +
+	"\x05last4\x18\x05 \x01(\tR\x05last4\x12\x14\n" +
+	"\x05brand\x18\x06 \x01(\tR\x05brand\x12\x1b\n" +
+	"\texp_month\x18\a \x01(\x05R\bexpMonth\x12\x19\n" +
+	"\bexp_year\x18\b \x01(\x05R\aexpYear\"\x96\x01\n" +
 	"\x19SavePaymentMethodResponse\x12E\n" +
 	"\x0epayment_method\x18\x01 \x01(\v2\x1e.payment_service.PaymentMethodR\rpaymentMethod\x12\x18\n" +
 	"\asuccess\x18\x02 \x01(\bR\asuccess\x12\x18\n" +
@@ -1647,7 +2383,34 @@ const file_payment_proto_rawDesc = "" +
 	"\x18GetPaymentMethodsRequest\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\tR\x06userId\"d\n" +
 	"\x19GetPaymentMethodsResponse\x12G\n" +
-	"\x0fpayment_methods\x18\x01 \x03(\v2\x1e.payment_service.PaymentMethodR\x0epaymentMethods\"m\n" +
+	"\x0fpayment_methods\x18\x01 \x03(\v2\x1e.payment_service.PaymentMethodR\x0epaymentMethods\"a\n" +
+	"\x1aDeletePaymentMethodRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12*\n" +
+	"\x11payment_method_id\x18\x02 \x01(\tR\x0fpaymentMethodId\"Q\n" +
+	"\x1bDeletePaymentMethodResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"\x8b\x02\n" +
+	"\x19CreateSubscriptionRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x17\n" +
+	"\aplan_id\x18\x02 \x01(\tR\x06planId\x12\x16\n" +
+	"\x06amount\x18\x03 \x01(\x01R\x06amount\x12\x1a\n" +
+	"\bcurrency\x18\x04 \x01(\tR\bcurrency\x12\x1a\n" +
+	"\binterval\x18\x05 \x01(\tR\binterval\x12%\n" +
+	"\x0einterval_count\x18\x06 \x01(\x05R\rintervalCount\x12*\n" +
+	"\x11payment_method_id\x18\a \x01(\tR\x0fpaymentMethodId\x12\x19\n" +
+	"\bstart_at\x18\b \x01(\tR\astartAt\"\x93\x01\n" +
+	"\x1aCreateSubscriptionResponse\x12A\n" +
+	"\fsubscription\x18\x01 \x01(\v2\x1d.payment_service.SubscriptionR\fsubscription\x12\x18\n" +
+	"\asuccess\x18\x02 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\"u\n" +
+	"\x19CancelSubscriptionRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12'\n" +
+	"\x0fsubscription_id\x18\x02 \x01(\tR\x0esubscriptionId\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\"\x93\x01\n" +
+	"\x1aCancelSubscriptionResponse\x12A\n" +
+	"\fsubscription\x18\x01 \x01(\v2\x1d.payment_service.SubscriptionR\fsubscription\x12\x18\n" +
+	"\asuccess\x18\x02 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\"m\n" +
 	"\x13WebhookEventRequest\x12\x18\n" +
 	"\agateway\x18\x01 \x01(\tR\agateway\x12\x1d\n" +
 	"\n" +
@@ -1656,7 +2419,7 @@ const file_payment_proto_rawDesc = "" +
 	"event_data\x18\x03 \x01(\tR\teventData\"J\n" +
 	"\x14WebhookEventResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage2\x9b\a\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage2\xeb\t\n" +
 	"\x0ePaymentService\x12a\n" +
 	"\x0eProcessPayment\x12&.payment_service.ProcessPaymentRequest\x1a'.payment_service.ProcessPaymentResponse\x12a\n" +
 	"\x0eConfirmPayment\x12&.payment_service.ConfirmPaymentRequest\x1a'.payment_service.ConfirmPaymentResponse\x12^\n" +
@@ -1666,7 +2429,10 @@ const file_payment_proto_rawDesc = "" +
 	"\x11GetPaymentByOrder\x12).payment_service.GetPaymentByOrderRequest\x1a*.payment_service.GetPaymentByOrderResponse\x12j\n" +
 	"\x11GetPaymentHistory\x12).payment_service.GetPaymentHistoryRequest\x1a*.payment_service.GetPaymentHistoryResponse\x12j\n" +
 	"\x11SavePaymentMethod\x12).payment_service.SavePaymentMethodRequest\x1a*.payment_service.SavePaymentMethodResponse\x12j\n" +
-	"\x11GetPaymentMethods\x12).payment_service.GetPaymentMethodsRequest\x1a*.payment_service.GetPaymentMethodsResponse\x12\\\n" +
+	"\x11GetPaymentMethods\x12).payment_service.GetPaymentMethodsRequest\x1a*.payment_service.GetPaymentMethodsResponse\x12p\n" +
+	"\x13DeletePaymentMethod\x12+.payment_service.DeletePaymentMethodRequest\x1a,.payment_service.DeletePaymentMethodResponse\x12m\n" +
+	"\x12CreateSubscription\x12*.payment_service.CreateSubscriptionRequest\x1a+.payment_service.CreateSubscriptionResponse\x12m\n" +
+	"\x12CancelSubscription\x12*.payment_service.CancelSubscriptionRequest\x1a+.payment_service.CancelSubscriptionResponse\x12\\\n" +
 	"\rHandleWebhook\x12$.payment_service.WebhookEventRequest\x1a%.payment_service.WebhookEventResponseB=Z;github.com/datngth03/ecommerce-go-app/proto/payment_serviceb\x06proto3"
 
 var (
@@ -1681,69 +2447,86 @@ func file_payment_proto_rawDescGZIP() []byte {
 	return file_payment_proto_rawDescData
 }
 
-var file_payment_proto_msgTypes = make([]protoimpl.MessageInfo, 23)
+var file_payment_proto_msgTypes = make([]protoimpl.MessageInfo, 31)
 var file_payment_proto_goTypes = []any{
-	(*Payment)(nil),                   // 0: payment_service.Payment
-	(*Refund)(nil),                    // 1: payment_service.Refund
-	(*Transaction)(nil),               // 2: payment_service.Transaction
-	(*PaymentMethod)(nil),             // 3: payment_service.PaymentMethod
-	(*ProcessPaymentRequest)(nil),     // 4: payment_service.ProcessPaymentRequest
-	(*ProcessPaymentResponse)(nil),    // 5: payment_service.ProcessPaymentResponse
-	(*RefundPaymentRequest)(nil),      // 6: payment_service.RefundPaymentRequest
-	(*RefundPaymentResponse)(nil),     // 7: payment_service.RefundPaymentResponse
-	(*GetPaymentRequest)(nil),         // 8: payment_service.GetPaymentRequest
-	(*GetPaymentResponse)(nil),        // 9: payment_service.GetPaymentResponse
-	(*GetPaymentByOrderRequest)(nil),  // 10: payment_service.GetPaymentByOrderRequest
-	(*GetPaymentByOrderResponse)(nil), // 11: payment_service.GetPaymentByOrderResponse
-	(*GetPaymentHistoryRequest)(nil),  // 12: payment_service.GetPaymentHistoryRequest
-	(*GetPaymentHistoryResponse)(nil), // 13: payment_service.GetPaymentHistoryResponse
-	(*ConfirmPaymentRequest)(nil),     // 14: payment_service.ConfirmPaymentRequest
-	(*ConfirmPaymentResponse)(nil),    // 15: payment_service.ConfirmPaymentResponse
-	(*SavePaymentMethodRequest)(nil),  // 16: payment_service.SavePaymentMethodRequest
-	(*SavePaymentMethodResponse)(nil), // 17: payment_service.SavePaymentMethodResponse
-	(*GetPaymentMethodsRequest)(nil),  // 18: payment_service.GetPaymentMethodsRequest
-	(*GetPaymentMethodsResponse)(nil), // 19: payment_service.GetPaymentMethodsResponse
-	(*WebhookEventRequest)(nil),       // 20: payment_service.WebhookEventRequest
-	(*WebhookEventResponse)(nil),      // 21: payment_service.WebhookEventResponse
-	nil,                               // 22: payment_service.ProcessPaymentRequest.MetadataEntry
+	(*Payment)(nil),                     // 0: payment_service.Payment
+	(*Refund)(nil),                      // 1: payment_service.Refund
+	(*Transaction)(nil),                 // 2: payment_service.Transaction
+	(*PaymentMethod)(nil),               // 3: payment_service.PaymentMethod
+	(*Subscription)(nil),                // 4: payment_service.Subscription
+	(*ProcessPaymentRequest)(nil),       // 5: payment_service.ProcessPaymentRequest
+	(*ProcessPaymentResponse)(nil),      // 6: payment_service.ProcessPaymentResponse
+	(*RefundPaymentRequest)(nil),        // 7: payment_service.RefundPaymentRequest
+	(*RefundItem)(nil),                  // 8: payment_service.RefundItem
+	(*RefundPaymentResponse)(nil),       // 9: payment_service.RefundPaymentResponse
+	(*GetPaymentRequest)(nil),           // 10: payment_service.GetPaymentRequest
+	(*GetPaymentResponse)(nil),          // 11: payment_service.GetPaymentResponse
+	(*GetPaymentByOrderRequest)(nil),    // 12: payment_service.GetPaymentByOrderRequest
+	(*GetPaymentByOrderResponse)(nil),   // 13: payment_service.GetPaymentByOrderResponse
+	(*GetPaymentHistoryRequest)(nil),    // 14: payment_service.GetPaymentHistoryRequest
+	(*GetPaymentHistoryResponse)(nil),   // 15: payment_service.GetPaymentHistoryResponse
+	(*ConfirmPaymentRequest)(nil),       // 16: payment_service.ConfirmPaymentRequest
+	(*ConfirmPaymentResponse)(nil),      // 17: payment_service.ConfirmPaymentResponse
+	(*SavePaymentMethodRequest)(nil),    // 18: payment_service.SavePaymentMethodRequest
+	(*SavePaymentMethodResponse)(nil),   // 19: payment_service.SavePaymentMethodResponse
+	(*GetPaymentMethodsRequest)(nil),    // 20: payment_service.GetPaymentMethodsRequest
+	(*GetPaymentMethodsResponse)(nil),   // 21: payment_service.GetPaymentMethodsResponse
+	(*DeletePaymentMethodRequest)(nil),  // 22: payment_service.DeletePaymentMethodRequest
+	(*DeletePaymentMethodResponse)(nil), // 23: payment_service.DeletePaymentMethodResponse
+	(*CreateSubscriptionRequest)(nil),   // 24: payment_service.CreateSubscriptionRequest
+	(*CreateSubscriptionResponse)(nil),  // 25: payment_service.CreateSubscriptionResponse
+	(*CancelSubscriptionRequest)(nil),   // 26: payment_service.CancelSubscriptionRequest
+	(*CancelSubscriptionResponse)(nil),  // 27: payment_service.CancelSubscriptionResponse
+	(*WebhookEventRequest)(nil),         // 28: payment_service.WebhookEventRequest
+	(*WebhookEventResponse)(nil),        // 29: payment_service.WebhookEventResponse
+	nil,                                 // 30: payment_service.ProcessPaymentRequest.MetadataEntry
 }
 var file_payment_proto_depIdxs = []int32{
-	22, // 0: payment_service.ProcessPaymentRequest.metadata:type_name -> payment_service.ProcessPaymentRequest.MetadataEntry
+	30, // 0: payment_service.ProcessPaymentRequest.metadata:type_name -> payment_service.ProcessPaymentRequest.MetadataEntry
 	0,  // 1: payment_service.ProcessPaymentResponse.payment:type_name -> payment_service.Payment
-	1,  // 2: payment_service.RefundPaymentResponse.refund:type_name -> payment_service.Refund
-	0,  // 3: payment_service.GetPaymentResponse.payment:type_name -> payment_service.Payment
-	2,  // 4: payment_service.GetPaymentResponse.transactions:type_name -> payment_service.Transaction
-	1,  // 5: payment_service.GetPaymentResponse.refunds:type_name -> payment_service.Refund
-	0,  // 6: payment_service.GetPaymentByOrderResponse.payment:type_name -> payment_service.Payment
-	2,  // 7: payment_service.GetPaymentByOrderResponse.transactions:type_name -> payment_service.Transaction
-	1,  // 8: payment_service.GetPaymentByOrderResponse.refunds:type_name -> payment_service.Refund
-	0,  // 9: payment_service.GetPaymentHistoryResponse.payments:type_name -> payment_service.Payment
-	0,  // 10: payment_service.ConfirmPaymentResponse.payment:type_name -> payment_service.Payment
-	3,  // 11: payment_service.SavePaymentMethodResponse.payment_method:type_name -> payment_service.PaymentMethod
-	3,  // 12: payment_service.GetPaymentMethodsResponse.payment_methods:type_name -> payment_service.PaymentMethod
-	4,  // 13: payment_service.PaymentService.ProcessPayment:input_type -> payment_service.ProcessPaymentRequest
-	14, // 14: payment_service.PaymentService.ConfirmPayment:input_type -> payment_service.ConfirmPaymentRequest
-	6,  // 15: payment_service.PaymentService.RefundPayment:input_type -> payment_service.RefundPaymentRequest
-	8,  // 16: payment_service.PaymentService.GetPayment:input_type -> payment_service.GetPaymentRequest
-	10, // 17: payment_service.PaymentService.GetPaymentByOrder:input_type -> payment_service.GetPaymentByOrderRequest
-	12, // 18: payment_service.PaymentService.GetPaymentHistory:input_type -> payment_service.GetPaymentHistoryRequest
-	16, // 19: payment_service.PaymentService.SavePaymentMethod:input_type -> payment_service.SavePaymentMethodRequest
-	18, // 20: payment_service.PaymentService.GetPaymentMethods:input_type -> payment_service.GetPaymentMethodsRequest
-	20, // 21: payment_service.PaymentService.HandleWebhook:input_type -> payment_service.WebhookEventRequest
-	5,  // 22: payment_service.PaymentService.ProcessPayment:output_type -> payment_service.ProcessPaymentResponse
-	15, // 23: payment_service.PaymentService.ConfirmPayment:output_type -> payment_service.ConfirmPaymentResponse
-	7,  // 24: payment_service.PaymentService.RefundPayment:output_type -> payment_service.RefundPaymentResponse
-	9,  // 25: payment_service.PaymentService.GetPayment:output_type -> payment_service.GetPaymentResponse
-	11, // 26: payment_service.PaymentService.GetPaymentByOrder:output_type -> payment_service.GetPaymentByOrderResponse
-	13, // 27: payment_service.PaymentService.GetPaymentHistory:output_type -> payment_service.GetPaymentHistoryResponse
-	17, // 28: payment_service.PaymentService.SavePaymentMethod:output_type -> payment_service.SavePaymentMethodResponse
-	19, // 29: payment_service.PaymentService.GetPaymentMethods:output_type -> payment_service.GetPaymentMethodsResponse
-	21, // 30: payment_service.PaymentService.HandleWebhook:output_type -> payment_service.WebhookEventResponse
-	22, // [22:31] is the sub-list for method output_type
-	13, // [13:22] is the sub-list for method input_type
-	13, // [13:13] is the sub-list for extension type_name
-	13, // [13:13] is the sub-list for extension extendee
-	0,  // [0:13] is the sub-list for field type_name
+	8,  // 2: payment_service.RefundPaymentRequest.items:type_name -> payment_service.RefundItem
+	1,  // 3: payment_service.RefundPaymentResponse.refund:type_name -> payment_service.Refund
+	0,  // 4: payment_service.GetPaymentResponse.payment:type_name -> payment_service.Payment
+	2,  // 5: payment_service.GetPaymentResponse.transactions:type_name -> payment_service.Transaction
+	1,  // 6: payment_service.GetPaymentResponse.refunds:type_name -> payment_service.Refund
+	0,  // 7: payment_service.GetPaymentByOrderResponse.payment:type_name -> payment_service.Payment
+	2,  // 8: payment_service.GetPaymentByOrderResponse.transactions:type_name -> payment_service.Transaction
+	1,  // 9: payment_service.GetPaymentByOrderResponse.refunds:type_name -> payment_service.Refund
+	0,  // 10: payment_service.GetPaymentHistoryResponse.payments:type_name -> payment_service.Payment
+	0,  // 11: payment_service.ConfirmPaymentResponse.payment:type_name -> payment_service.Payment
+	3,  // 12: payment_service.SavePaymentMethodResponse.payment_method:type_name -> payment_service.PaymentMethod
+	3,  // 13: payment_service.GetPaymentMethodsResponse.payment_methods:type_name -> payment_service.PaymentMethod
+	4,  // 14: payment_service.CreateSubscriptionResponse.subscription:type_name -> payment_service.Subscription
+	4,  // 15: payment_service.CancelSubscriptionResponse.subscription:type_name -> payment_service.Subscription
+	5,  // 16: payment_service.PaymentService.ProcessPayment:input_type -> payment_service.ProcessPaymentRequest
+	16, // 17: payment_service.PaymentService.ConfirmPayment:input_type -> payment_service.ConfirmPaymentRequest
+	7,  // 18: payment_service.PaymentService.RefundPayment:input_type -> payment_service.RefundPaymentRequest
+	10, // 19: payment_service.PaymentService.GetPayment:input_type -> payment_service.GetPaymentRequest
+	12, // 20: payment_service.PaymentService.GetPaymentByOrder:input_type -> payment_service.GetPaymentByOrderRequest
+	14, // 21: payment_service.PaymentService.GetPaymentHistory:input_type -> payment_service.GetPaymentHistoryRequest
+	18, // 22: payment_service.PaymentService.SavePaymentMethod:input_type -> payment_service.SavePaymentMethodRequest
+	20, // 23: payment_service.PaymentService.GetPaymentMethods:input_type -> payment_service.GetPaymentMethodsRequest
+	22, // 24: payment_service.PaymentService.DeletePaymentMethod:input_type -> payment_service.DeletePaymentMethodRequest
+	24, // 25: payment_service.PaymentService.CreateSubscription:input_type -> payment_service.CreateSubscriptionRequest
+	26, // 26: payment_service.PaymentService.CancelSubscription:input_type -> payment_service.CancelSubscriptionRequest
+	28, // 27: payment_service.PaymentService.HandleWebhook:input_type -> payment_service.WebhookEventRequest
+	6,  // 28: payment_service.PaymentService.ProcessPayment:output_type -> payment_service.ProcessPaymentResponse
+	17, // 29: payment_service.PaymentService.ConfirmPayment:output_type -> payment_service.ConfirmPaymentResponse
+	9,  // 30: payment_service.PaymentService.RefundPayment:output_type -> payment_service.RefundPaymentResponse
+	11, // 31: payment_service.PaymentService.GetPayment:output_type -> payment_service.GetPaymentResponse
+	13, // 32: payment_service.PaymentService.GetPaymentByOrder:output_type -> payment_service.GetPaymentByOrderResponse
+	15, // 33: payment_service.PaymentService.GetPaymentHistory:output_type -> payment_service.GetPaymentHistoryResponse
+	19, // 34: payment_service.PaymentService.SavePaymentMethod:output_type -> payment_service.SavePaymentMethodResponse
+	21, // 35: payment_service.PaymentService.GetPaymentMethods:output_type -> payment_service.GetPaymentMethodsResponse
+	23, // 36: payment_service.PaymentService.DeletePaymentMethod:output_type -> payment_service.DeletePaymentMethodResponse
+	25, // 37: payment_service.PaymentService.CreateSubscription:output_type -> payment_service.CreateSubscriptionResponse
+	27, // 38: payment_service.PaymentService.CancelSubscription:output_type -> payment_service.CancelSubscriptionResponse
+	29, // 39: payment_service.PaymentService.HandleWebhook:output_type -> payment_service.WebhookEventResponse
+	28, // [28:40] is the sub-list for method output_type
+	16, // [16:28] is the sub-list for method input_type
+	16, // [16:16] is the sub-list for extension type_name
+	16, // [16:16] is the sub-list for extension extendee
+	0,  // [0:16] is the sub-list for field type_name
 }
 
 func init() { file_payment_proto_init() }
@@ -1757,7 +2540,7 @@ func file_payment_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_payment_proto_rawDesc), len(file_payment_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   23,
+			NumMessages:   31,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
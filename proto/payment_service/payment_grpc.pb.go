@@ -19,15 +19,18 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	PaymentService_ProcessPayment_FullMethodName    = "/payment_service.PaymentService/ProcessPayment"
-	PaymentService_ConfirmPayment_FullMethodName    = "/payment_service.PaymentService/ConfirmPayment"
-	PaymentService_RefundPayment_FullMethodName     = "/payment_service.PaymentService/RefundPayment"
-	PaymentService_GetPayment_FullMethodName        = "/payment_service.PaymentService/GetPayment"
-	PaymentService_GetPaymentByOrder_FullMethodName = "/payment_service.PaymentService/GetPaymentByOrder"
-	PaymentService_GetPaymentHistory_FullMethodName = "/payment_service.PaymentService/GetPaymentHistory"
-	PaymentService_SavePaymentMethod_FullMethodName = "/payment_service.PaymentService/SavePaymentMethod"
-	PaymentService_GetPaymentMethods_FullMethodName = "/payment_service.PaymentService/GetPaymentMethods"
-	PaymentService_HandleWebhook_FullMethodName     = "/payment_service.PaymentService/HandleWebhook"
+	PaymentService_ProcessPayment_FullMethodName      = "/payment_service.PaymentService/ProcessPayment"
+	PaymentService_ConfirmPayment_FullMethodName      = "/payment_service.PaymentService/ConfirmPayment"
+	PaymentService_RefundPayment_FullMethodName       = "/payment_service.PaymentService/RefundPayment"
+	PaymentService_GetPayment_FullMethodName          = "/payment_service.PaymentService/GetPayment"
+	PaymentService_GetPaymentByOrder_FullMethodName   = "/payment_service.PaymentService/GetPaymentByOrder"
+	PaymentService_GetPaymentHistory_FullMethodName   = "/payment_service.PaymentService/GetPaymentHistory"
+	PaymentService_SavePaymentMethod_FullMethodName   = "/payment_service.PaymentService/SavePaymentMethod"
+	PaymentService_GetPaymentMethods_FullMethodName   = "/payment_service.PaymentService/GetPaymentMethods"
+	PaymentService_DeletePaymentMethod_FullMethodName = "/payment_service.PaymentService/DeletePaymentMethod"
+	PaymentService_CreateSubscription_FullMethodName  = "/payment_service.PaymentService/CreateSubscription"
+	PaymentService_CancelSubscription_FullMethodName  = "/payment_service.PaymentService/CancelSubscription"
+	PaymentService_HandleWebhook_FullMethodName       = "/payment_service.PaymentService/HandleWebhook"
 )
 
 // PaymentServiceClient is the client API for PaymentService service.
@@ -47,6 +50,10 @@ type PaymentServiceClient interface {
 	// Payment methods
 	SavePaymentMethod(ctx context.Context, in *SavePaymentMethodRequest, opts ...grpc.CallOption) (*SavePaymentMethodResponse, error)
 	GetPaymentMethods(ctx context.Context, in *GetPaymentMethodsRequest, opts ...grpc.CallOption) (*GetPaymentMethodsResponse, error)
+	DeletePaymentMethod(ctx context.Context, in *DeletePaymentMethodRequest, opts ...grpc.CallOption) (*DeletePaymentMethodResponse, error)
+	// Subscriptions
+	CreateSubscription(ctx context.Context, in *CreateSubscriptionRequest, opts ...grpc.CallOption) (*CreateSubscriptionResponse, error)
+	CancelSubscription(ctx context.Context, in *CancelSubscriptionRequest, opts ...grpc.CallOption) (*CancelSubscriptionResponse, error)
 	// Webhooks
 	HandleWebhook(ctx context.Context, in *WebhookEventRequest, opts ...grpc.CallOption) (*WebhookEventResponse, error)
 }
@@ -139,6 +146,36 @@ func (c *paymentServiceClient) GetPaymentMethods(ctx context.Context, in *GetPay
 	return out, nil
 }
 
+func (c *paymentServiceClient) DeletePaymentMethod(ctx context.Context, in *DeletePaymentMethodRequest, opts ...grpc.CallOption) (*DeletePaymentMethodResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeletePaymentMethodResponse)
+	err := c.cc.Invoke(ctx, PaymentService_DeletePaymentMethod_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentServiceClient) CreateSubscription(ctx context.Context, in *CreateSubscriptionRequest, opts ...grpc.CallOption) (*CreateSubscriptionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateSubscriptionResponse)
+	err := c.cc.Invoke(ctx, PaymentService_CreateSubscription_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentServiceClient) CancelSubscription(ctx context.Context, in *CancelSubscriptionRequest, opts ...grpc.CallOption) (*CancelSubscriptionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CancelSubscriptionResponse)
+	err := c.cc.Invoke(ctx, PaymentService_CancelSubscription_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *paymentServiceClient) HandleWebhook(ctx context.Context, in *WebhookEventRequest, opts ...grpc.CallOption) (*WebhookEventResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(WebhookEventResponse)
@@ -166,6 +203,10 @@ type PaymentServiceServer interface {
 	// Payment methods
 	SavePaymentMethod(context.Context, *SavePaymentMethodRequest) (*SavePaymentMethodResponse, error)
 	GetPaymentMethods(context.Context, *GetPaymentMethodsRequest) (*GetPaymentMethodsResponse, error)
+	DeletePaymentMethod(context.Context, *DeletePaymentMethodRequest) (*DeletePaymentMethodResponse, error)
+	// Subscriptions
+	CreateSubscription(context.Context, *CreateSubscriptionRequest) (*CreateSubscriptionResponse, error)
+	CancelSubscription(context.Context, *CancelSubscriptionRequest) (*CancelSubscriptionResponse, error)
 	// Webhooks
 	HandleWebhook(context.Context, *WebhookEventRequest) (*WebhookEventResponse, error)
 	mustEmbedUnimplementedPaymentServiceServer()
@@ -202,6 +243,15 @@ func (UnimplementedPaymentServiceServer) SavePaymentMethod(context.Context, *Sav
 func (UnimplementedPaymentServiceServer) GetPaymentMethods(context.Context, *GetPaymentMethodsRequest) (*GetPaymentMethodsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetPaymentMethods not implemented")
 }
+func (UnimplementedPaymentServiceServer) DeletePaymentMethod(context.Context, *DeletePaymentMethodRequest) (*DeletePaymentMethodResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeletePaymentMethod not implemented")
+}
+func (UnimplementedPaymentServiceServer) CreateSubscription(context.Context, *CreateSubscriptionRequest) (*CreateSubscriptionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateSubscription not implemented")
+}
+func (UnimplementedPaymentServiceServer) CancelSubscription(context.Context, *CancelSubscriptionRequest) (*CancelSubscriptionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelSubscription not implemented")
+}
 func (UnimplementedPaymentServiceServer) HandleWebhook(context.Context, *WebhookEventRequest) (*WebhookEventResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method HandleWebhook not implemented")
 }
@@ -370,6 +420,60 @@ func _PaymentService_GetPaymentMethods_Handler(srv interface{}, ctx context.Cont
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PaymentService_DeletePaymentMethod_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeletePaymentMethodRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentServiceServer).DeletePaymentMethod(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PaymentService_DeletePaymentMethod_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentServiceServer).DeletePaymentMethod(ctx, req.(*DeletePaymentMethodRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PaymentService_CreateSubscription_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateSubscriptionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentServiceServer).CreateSubscription(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PaymentService_CreateSubscription_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentServiceServer).CreateSubscription(ctx, req.(*CreateSubscriptionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PaymentService_CancelSubscription_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelSubscriptionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentServiceServer).CancelSubscription(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PaymentService_CancelSubscription_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentServiceServer).CancelSubscription(ctx, req.(*CancelSubscriptionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _PaymentService_HandleWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(WebhookEventRequest)
 	if err := dec(in); err != nil {
@@ -427,6 +531,18 @@ var PaymentService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetPaymentMethods",
 			Handler:    _PaymentService_GetPaymentMethods_Handler,
 		},
+		{
+			MethodName: "DeletePaymentMethod",
+			Handler:    _PaymentService_DeletePaymentMethod_Handler,
+		},
+		{
+			MethodName: "CreateSubscription",
+			Handler:    _PaymentService_CreateSubscription_Handler,
+		},
+		{
+			MethodName: "CancelSubscription",
+			Handler:    _PaymentService_CancelSubscription_Handler,
+		},
 		{
 			MethodName: "HandleWebhook",
 			Handler:    _PaymentService_HandleWebhook_Handler,
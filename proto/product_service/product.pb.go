@@ -113,6 +113,12 @@ type Product struct {
 	IsActive      bool                   `protobuf:"varint,8,opt,name=is_active,json=isActive,proto3" json:"is_active,omitempty"`
 	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Availability  *ProductAvailability   `protobuf:"bytes,11,opt,name=availability,proto3" json:"availability,omitempty"`              // Chỉ có khi request bật include_availability
+	Currency      string                 `protobuf:"bytes,12,opt,name=currency,proto3" json:"currency,omitempty"`                      // Mã tiền tệ của price, chỉ có khi request chỉ định currency
+	BasePrice     float64                `protobuf:"fixed64,13,opt,name=base_price,json=basePrice,proto3" json:"base_price,omitempty"` // Giá gốc theo base currency, chỉ có khi price đã được quy đổi
+	IsBundle      bool                   `protobuf:"varint,14,opt,name=is_bundle,json=isBundle,proto3" json:"is_bundle,omitempty"`     // Sản phẩm là bundle gồm nhiều sản phẩm thành phần
+	Components    []*BundleComponent     `protobuf:"bytes,15,rep,name=components,proto3" json:"components,omitempty"`                  // Thành phần của bundle, rỗng với sản phẩm thường
+	Variants      []*ProductVariant      `protobuf:"bytes,16,rep,name=variants,proto3" json:"variants,omitempty"`                      // Biến thể (size, màu...), rỗng với sản phẩm một SKU
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -217,6 +223,301 @@ func (x *Product) GetUpdatedAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *Product) GetAvailability() *ProductAvailability {
+	if x != nil {
+		return x.Availability
+	}
+	return nil
+}
+
+func (x *Product) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *Product) GetBasePrice() float64 {
+	if x != nil {
+		return x.BasePrice
+	}
+	return 0
+}
+
+func (x *Product) GetIsBundle() bool {
+	if x != nil {
+		return x.IsBundle
+	}
+	return false
+}
+
+func (x *Product) GetComponents() []*BundleComponent {
+	if x != nil {
+		return x.Components
+	}
+	return nil
+}
+
+func (x *Product) GetVariants() []*ProductVariant {
+	if x != nil {
+		return x.Variants
+	}
+	return nil
+}
+
+// BundleComponent message: Một sản phẩm thành phần của bundle.
+type BundleComponent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`          // Chỉ có trong response
+	Quantity      int32                  `protobuf:"varint,3,opt,name=quantity,proto3" json:"quantity,omitempty"` // Số lượng cho mỗi bundle
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BundleComponent) Reset() {
+	*x = BundleComponent{}
+	mi := &file_product_service_product_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BundleComponent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BundleComponent) ProtoMessage() {}
+
+func (x *BundleComponent) ProtoReflect() protoreflect.Message {
+	mi := &file_product_service_product_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BundleComponent.ProtoReflect.Descriptor instead.
+func (*BundleComponent) Descriptor() ([]byte, []int) {
+	return file_product_service_product_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *BundleComponent) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *BundleComponent) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *BundleComponent) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+// ProductVariant message: Một biến thể của sản phẩm với SKU và tồn kho riêng.
+// Inventory lưu tồn kho của biến thể theo id của biến thể.
+type ProductVariant struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"` // Chỉ có trong response
+	Sku           string                 `protobuf:"bytes,2,opt,name=sku,proto3" json:"sku,omitempty"`
+	Attributes    []*VariantAttribute    `protobuf:"bytes,3,rep,name=attributes,proto3" json:"attributes,omitempty"`     // Thuộc tính phân biệt biến thể, vd: size=M, color=red
+	Price         float64                `protobuf:"fixed64,4,opt,name=price,proto3" json:"price,omitempty"`             // Giá riêng của biến thể, 0 = dùng giá sản phẩm
+	Availability  *ProductAvailability   `protobuf:"bytes,5,opt,name=availability,proto3" json:"availability,omitempty"` // Chỉ có khi request bật include_availability
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProductVariant) Reset() {
+	*x = ProductVariant{}
+	mi := &file_product_service_product_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProductVariant) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProductVariant) ProtoMessage() {}
+
+func (x *ProductVariant) ProtoReflect() protoreflect.Message {
+	mi := &file_product_service_product_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProductVariant.ProtoReflect.Descriptor instead.
+func (*ProductVariant) Descriptor() ([]byte, []int) {
+	return file_product_service_product_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ProductVariant) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ProductVariant) GetSku() string {
+	if x != nil {
+		return x.Sku
+	}
+	return ""
+}
+
+func (x *ProductVariant) GetAttributes() []*VariantAttribute {
+	if x != nil {
+		return x.Attributes
+	}
+	return nil
+}
+
+func (x *ProductVariant) GetPrice() float64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *ProductVariant) GetAvailability() *ProductAvailability {
+	if x != nil {
+		return x.Availability
+	}
+	return nil
+}
+
+// VariantAttribute message: Một thuộc tính của biến thể.
+type VariantAttribute struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Value         string                 `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VariantAttribute) Reset() {
+	*x = VariantAttribute{}
+	mi := &file_product_service_product_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VariantAttribute) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VariantAttribute) ProtoMessage() {}
+
+func (x *VariantAttribute) ProtoReflect() protoreflect.Message {
+	mi := &file_product_service_product_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VariantAttribute.ProtoReflect.Descriptor instead.
+func (*VariantAttribute) Descriptor() ([]byte, []int) {
+	return file_product_service_product_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *VariantAttribute) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *VariantAttribute) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+// ProductAvailability message: Tình trạng tồn kho lấy từ inventory service.
+type ProductAvailability struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Status            string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"` // in_stock, out_of_stock hoặc unknown (inventory không phản hồi)
+	InStock           bool                   `protobuf:"varint,2,opt,name=in_stock,json=inStock,proto3" json:"in_stock,omitempty"`
+	AvailableQuantity int32                  `protobuf:"varint,3,opt,name=available_quantity,json=availableQuantity,proto3" json:"available_quantity,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *ProductAvailability) Reset() {
+	*x = ProductAvailability{}
+	mi := &file_product_service_product_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProductAvailability) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProductAvailability) ProtoMessage() {}
+
+func (x *ProductAvailability) ProtoReflect() protoreflect.Message {
+	mi := &file_product_service_product_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProductAvailability.ProtoReflect.Descriptor instead.
+func (*ProductAvailability) Descriptor() ([]byte, []int) {
+	return file_product_service_product_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ProductAvailability) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ProductAvailability) GetInStock() bool {
+	if x != nil {
+		return x.InStock
+	}
+	return false
+}
+
+func (x *ProductAvailability) GetAvailableQuantity() int32 {
+	if x != nil {
+		return x.AvailableQuantity
+	}
+	return 0
+}
+
 // --- Create ---
 type CreateProductRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -225,13 +526,16 @@ type CreateProductRequest struct {
 	Price         float64                `protobuf:"fixed64,3,opt,name=price,proto3" json:"price,omitempty"`
 	CategoryId    string                 `protobuf:"bytes,4,opt,name=category_id,json=categoryId,proto3" json:"category_id,omitempty"`
 	ImageUrl      string                 `protobuf:"bytes,5,opt,name=image_url,json=imageUrl,proto3" json:"image_url,omitempty"`
+	Slug          string                 `protobuf:"bytes,6,opt,name=slug,proto3" json:"slug,omitempty"`             // Tùy chọn, tự sinh từ tên nếu để trống
+	Components    []*BundleComponent     `protobuf:"bytes,7,rep,name=components,proto3" json:"components,omitempty"` // Tùy chọn, có thành phần thì sản phẩm là bundle
+	Variants      []*ProductVariant      `protobuf:"bytes,8,rep,name=variants,proto3" json:"variants,omitempty"`     // Tùy chọn, biến thể của sản phẩm
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *CreateProductRequest) Reset() {
 	*x = CreateProductRequest{}
-	mi := &file_product_service_product_proto_msgTypes[2]
+	mi := &file_product_service_product_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -243,7 +547,7 @@ func (x *CreateProductRequest) String() string {
 func (*CreateProductRequest) ProtoMessage() {}
 
 func (x *CreateProductRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_product_service_product_proto_msgTypes[2]
+	mi := &file_product_service_product_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -256,7 +560,7 @@ func (x *CreateProductRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateProductRequest.ProtoReflect.Descriptor instead.
 func (*CreateProductRequest) Descriptor() ([]byte, []int) {
-	return file_product_service_product_proto_rawDescGZIP(), []int{2}
+	return file_product_service_product_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *CreateProductRequest) GetName() string {
@@ -294,6 +598,27 @@ func (x *CreateProductRequest) GetImageUrl() string {
 	return ""
 }
 
+func (x *CreateProductRequest) GetSlug() string {
+	if x != nil {
+		return x.Slug
+	}
+	return ""
+}
+
+func (x *CreateProductRequest) GetComponents() []*BundleComponent {
+	if x != nil {
+		return x.Components
+	}
+	return nil
+}
+
+func (x *CreateProductRequest) GetVariants() []*ProductVariant {
+	if x != nil {
+		return x.Variants
+	}
+	return nil
+}
+
 type CreateProductResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Product       *Product               `protobuf:"bytes,1,opt,name=product,proto3" json:"product,omitempty"`
@@ -303,7 +628,7 @@ type CreateProductResponse struct {
 
 func (x *CreateProductResponse) Reset() {
 	*x = CreateProductResponse{}
-	mi := &file_product_service_product_proto_msgTypes[3]
+	mi := &file_product_service_product_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -315,7 +640,7 @@ func (x *CreateProductResponse) String() string {
 func (*CreateProductResponse) ProtoMessage() {}
 
 func (x *CreateProductResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_product_service_product_proto_msgTypes[3]
+	mi := &file_product_service_product_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -323,44 +648,1129 @@ func (x *CreateProductResponse) ProtoReflect() protoreflect.Message {
 		}
 		return ms
 	}
-	return mi.MessageOf(x)
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateProductResponse.ProtoReflect.Descriptor instead.
+func (*CreateProductResponse) Descriptor() ([]byte, []int) {
+	return file_product_service_product_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *CreateProductResponse) GetProduct() *Product {
+	if x != nil {
+		return x.Product
+	}
+	return nil
+}
+
+// --- Get ---
+type GetProductRequest struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	Id                  string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	IncludeAvailability bool                   `protobuf:"varint,2,opt,name=include_availability,json=includeAvailability,proto3" json:"include_availability,omitempty"` // Kèm tình trạng tồn kho (tùy chọn)
+	Currency            string                 `protobuf:"bytes,3,opt,name=currency,proto3" json:"currency,omitempty"`                                                   // Quy đổi giá sang mã tiền tệ ISO 4217 này, vd: VND (tùy chọn)
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *GetProductRequest) Reset() {
+	*x = GetProductRequest{}
+	mi := &file_product_service_product_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProductRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProductRequest) ProtoMessage() {}
+
+func (x *GetProductRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_product_service_product_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProductRequest.ProtoReflect.Descriptor instead.
+func (*GetProductRequest) Descriptor() ([]byte, []int) {
+	return file_product_service_product_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GetProductRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *GetProductRequest) GetIncludeAvailability() bool {
+	if x != nil {
+		return x.IncludeAvailability
+	}
+	return false
+}
+
+func (x *GetProductRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+type GetProductResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Product       *Product               `protobuf:"bytes,1,opt,name=product,proto3" json:"product,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetProductResponse) Reset() {
+	*x = GetProductResponse{}
+	mi := &file_product_service_product_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProductResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProductResponse) ProtoMessage() {}
+
+func (x *GetProductResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_product_service_product_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProductResponse.ProtoReflect.Descriptor instead.
+func (*GetProductResponse) Descriptor() ([]byte, []int) {
+	return file_product_service_product_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GetProductResponse) GetProduct() *Product {
+	if x != nil {
+		return x.Product
+	}
+	return nil
+}
+
+// --- Get nhiều sản phẩm ---
+type GetProductsByIdsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ids           []string               `protobuf:"bytes,1,rep,name=ids,proto3" json:"ids,omitempty"` // Tối đa 100 id
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetProductsByIdsRequest) Reset() {
+	*x = GetProductsByIdsRequest{}
+	mi := &file_product_service_product_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProductsByIdsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProductsByIdsRequest) ProtoMessage() {}
+
+func (x *GetProductsByIdsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_product_service_product_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProductsByIdsRequest.ProtoReflect.Descriptor instead.
+func (*GetProductsByIdsRequest) Descriptor() ([]byte, []int) {
+	return file_product_service_product_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GetProductsByIdsRequest) GetIds() []string {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+type GetProductsByIdsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Products      []*Product             `protobuf:"bytes,1,rep,name=products,proto3" json:"products,omitempty"`                            // Theo thứ tự của ids; bỏ qua id không tồn tại
+	NotFoundIds   []string               `protobuf:"bytes,2,rep,name=not_found_ids,json=notFoundIds,proto3" json:"not_found_ids,omitempty"` // Các id không tìm thấy
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetProductsByIdsResponse) Reset() {
+	*x = GetProductsByIdsResponse{}
+	mi := &file_product_service_product_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProductsByIdsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProductsByIdsResponse) ProtoMessage() {}
+
+func (x *GetProductsByIdsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_product_service_product_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProductsByIdsResponse.ProtoReflect.Descriptor instead.
+func (*GetProductsByIdsResponse) Descriptor() ([]byte, []int) {
+	return file_product_service_product_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GetProductsByIdsResponse) GetProducts() []*Product {
+	if x != nil {
+		return x.Products
+	}
+	return nil
+}
+
+func (x *GetProductsByIdsResponse) GetNotFoundIds() []string {
+	if x != nil {
+		return x.NotFoundIds
+	}
+	return nil
+}
+
+// --- Update ---
+type UpdateProductRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name            string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description     string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Price           float64                `protobuf:"fixed64,4,opt,name=price,proto3" json:"price,omitempty"`
+	CategoryId      string                 `protobuf:"bytes,5,opt,name=category_id,json=categoryId,proto3" json:"category_id,omitempty"`
+	ImageUrl        string                 `protobuf:"bytes,6,opt,name=image_url,json=imageUrl,proto3" json:"image_url,omitempty"`
+	IsActive        bool                   `protobuf:"varint,7,opt,name=is_active,json=isActive,proto3" json:"is_active,omitempty"`
+	Slug            string                 `protobuf:"bytes,8,opt,name=slug,proto3" json:"slug,omitempty"`                                                // Tùy chọn, ghi đè slug hiện tại
+	Components      []*BundleComponent     `protobuf:"bytes,9,rep,name=components,proto3" json:"components,omitempty"`                                    // Tùy chọn, thay thế thành phần hiện tại
+	ClearComponents bool                   `protobuf:"varint,10,opt,name=clear_components,json=clearComponents,proto3" json:"clear_components,omitempty"` // Bỏ thành phần, bundle trở lại sản phẩm thường
+	Variants        []*ProductVariant      `protobuf:"bytes,11,rep,name=variants,proto3" json:"variants,omitempty"`                                       // Tùy chọn, thay thế biến thể hiện tại; SKU đã có giữ nguyên id
+	ClearVariants   bool                   `protobuf:"varint,12,opt,name=clear_variants,json=clearVariants,proto3" json:"clear_variants,omitempty"`       // Bỏ tất cả biến thể
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *UpdateProductRequest) Reset() {
+	*x = UpdateProductRequest{}
+	mi := &file_product_service_product_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateProductRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateProductRequest) ProtoMessage() {}
+
+func (x *UpdateProductRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_product_service_product_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateProductRequest.ProtoReflect.Descriptor instead.
+func (*UpdateProductRequest) Descriptor() ([]byte, []int) {
+	return file_product_service_product_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *UpdateProductRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateProductRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *UpdateProductRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *UpdateProductRequest) GetPrice() float64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *UpdateProductRequest) GetCategoryId() string {
+	if x != nil {
+		return x.CategoryId
+	}
+	return ""
+}
+
+func (x *UpdateProductRequest) GetImageUrl() string {
+	if x != nil {
+		return x.ImageUrl
+	}
+	return ""
+}
+
+func (x *UpdateProductRequest) GetIsActive() bool {
+	if x != nil {
+		return x.IsActive
+	}
+	return false
+}
+
+func (x *UpdateProductRequest) GetSlug() string {
+	if x != nil {
+		return x.Slug
+	}
+	return ""
+}
+
+func (x *UpdateProductRequest) GetComponents() []*BundleComponent {
+	if x != nil {
+		return x.Components
+	}
+	return nil
+}
+
+func (x *UpdateProductRequest) GetClearComponents() bool {
+	if x != nil {
+		return x.ClearComponents
+	}
+	return false
+}
+
+func (x *UpdateProductRequest) GetVariants() []*ProductVariant {
+	if x != nil {
+		return x.Variants
+	}
+	return nil
+}
+
+func (x *UpdateProductRequest) GetClearVariants() bool {
+	if x != nil {
+		return x.ClearVariants
+	}
+	return false
+}
+
+type UpdateProductResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Product       *Product               `protobuf:"bytes,1,opt,name=product,proto3" json:"product,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateProductResponse) Reset() {
+	*x = UpdateProductResponse{}
+	mi := &file_product_service_product_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateProductResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateProductResponse) ProtoMessage() {}
+
+func (x *UpdateProductResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_product_service_product_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateProductResponse.ProtoReflect.Descriptor instead.
+func (*UpdateProductResponse) Descriptor() ([]byte, []int) {
+	return file_product_service_product_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *UpdateProductResponse) GetProduct() *Product {
+	if x != nil {
+		return x.Product
+	}
+	return nil
+}
+
+// --- Delete ---
+type DeleteProductRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteProductRequest) Reset() {
+	*x = DeleteProductRequest{}
+	mi := &file_product_service_product_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteProductRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteProductRequest) ProtoMessage() {}
+
+func (x *DeleteProductRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_product_service_product_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteProductRequest.ProtoReflect.Descriptor instead.
+func (*DeleteProductRequest) Descriptor() ([]byte, []int) {
+	return file_product_service_product_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *DeleteProductRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+// --- List ---
+type ListProductsRequest struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	Page                int32                  `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize            int32                  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	CategoryId          string                 `protobuf:"bytes,3,opt,name=category_id,json=categoryId,proto3" json:"category_id,omitempty"`                             // Lọc sản phẩm theo danh mục (tùy chọn)
+	BrandId             string                 `protobuf:"bytes,4,opt,name=brand_id,json=brandId,proto3" json:"brand_id,omitempty"`                                      // Lọc sản phẩm theo thương hiệu (tùy chọn)
+	TagIds              []string               `protobuf:"bytes,5,rep,name=tag_ids,json=tagIds,proto3" json:"tag_ids,omitempty"`                                         // Chỉ trả về sản phẩm có tất cả các tag này (tùy chọn)
+	IncludeAvailability bool                   `protobuf:"varint,6,opt,name=include_availability,json=includeAvailability,proto3" json:"include_availability,omitempty"` // Kèm tình trạng tồn kho cho từng sản phẩm (tùy chọn)
+	Currency            string                 `protobuf:"bytes,7,opt,name=currency,proto3" json:"currency,omitempty"`                                                   // Quy đổi giá sang mã tiền tệ ISO 4217 này, vd: VND (tùy chọn)
+	Sort                string                 `protobuf:"bytes,8,opt,name=sort,proto3" json:"sort,omitempty"`                                                           // Thứ tự: newest (mặc định), price_asc, price_desc, name
+	MinPrice            float64                `protobuf:"fixed64,9,opt,name=min_price,json=minPrice,proto3" json:"min_price,omitempty"`                                 // Giá tối thiểu (bao gồm), theo tiền tệ gốc; 0 = không giới hạn
+	MaxPrice            float64                `protobuf:"fixed64,10,opt,name=max_price,json=maxPrice,proto3" json:"max_price,omitempty"`                                // Giá tối đa (bao gồm), theo tiền tệ gốc; 0 = không giới hạn
+	InStockOnly         bool                   `protobuf:"varint,11,opt,name=in_stock_only,json=inStockOnly,proto3" json:"in_stock_only,omitempty"`                      // Chỉ trả về sản phẩm còn hàng
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *ListProductsRequest) Reset() {
+	*x = ListProductsRequest{}
+	mi := &file_product_service_product_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListProductsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListProductsRequest) ProtoMessage() {}
+
+func (x *ListProductsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_product_service_product_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListProductsRequest.ProtoReflect.Descriptor instead.
+func (*ListProductsRequest) Descriptor() ([]byte, []int) {
+	return file_product_service_product_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ListProductsRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListProductsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListProductsRequest) GetCategoryId() string {
+	if x != nil {
+		return x.CategoryId
+	}
+	return ""
+}
+
+func (x *ListProductsRequest) GetBrandId() string {
+	if x != nil {
+		return x.BrandId
+	}
+	return ""
+}
+
+func (x *ListProductsRequest) GetTagIds() []string {
+	if x != nil {
+		return x.TagIds
+	}
+	return nil
+}
+
+func (x *ListProductsRequest) GetIncludeAvailability() bool {
+	if x != nil {
+		return x.IncludeAvailability
+	}
+	return false
+}
+
+func (x *ListProductsRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *ListProductsRequest) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+func (x *ListProductsRequest) GetMinPrice() float64 {
+	if x != nil {
+		return x.MinPrice
+	}
+	return 0
+}
+
+func (x *ListProductsRequest) GetMaxPrice() float64 {
+	if x != nil {
+		return x.MaxPrice
+	}
+	return 0
+}
+
+func (x *ListProductsRequest) GetInStockOnly() bool {
+	if x != nil {
+		return x.InStockOnly
+	}
+	return false
+}
+
+type ListProductsResponse struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Products           []*Product             `protobuf:"bytes,1,rep,name=products,proto3" json:"products,omitempty"`
+	TotalCount         int64                  `protobuf:"varint,2,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	TotalPages         int32                  `protobuf:"varint,3,opt,name=total_pages,json=totalPages,proto3" json:"total_pages,omitempty"`                           // Số trang với page_size hiện tại
+	HasMore            bool                   `protobuf:"varint,4,opt,name=has_more,json=hasMore,proto3" json:"has_more,omitempty"`                                    // Còn trang sau trang này
+	StockFilterSkipped bool                   `protobuf:"varint,5,opt,name=stock_filter_skipped,json=stockFilterSkipped,proto3" json:"stock_filter_skipped,omitempty"` // in_stock_only bị bỏ qua vì không kết nối được kho hàng
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *ListProductsResponse) Reset() {
+	*x = ListProductsResponse{}
+	mi := &file_product_service_product_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListProductsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListProductsResponse) ProtoMessage() {}
+
+func (x *ListProductsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_product_service_product_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListProductsResponse.ProtoReflect.Descriptor instead.
+func (*ListProductsResponse) Descriptor() ([]byte, []int) {
+	return file_product_service_product_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ListProductsResponse) GetProducts() []*Product {
+	if x != nil {
+		return x.Products
+	}
+	return nil
+}
+
+func (x *ListProductsResponse) GetTotalCount() int64 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+func (x *ListProductsResponse) GetTotalPages() int32 {
+	if x != nil {
+		return x.TotalPages
+	}
+	return 0
+}
+
+func (x *ListProductsResponse) GetHasMore() bool {
+	if x != nil {
+		return x.HasMore
+	}
+	return false
+}
+
+func (x *ListProductsResponse) GetStockFilterSkipped() bool {
+	if x != nil {
+		return x.StockFilterSkipped
+	}
+	return false
+}
+
+// --- Autocomplete ---
+type AutocompleteRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Prefix        string                 `protobuf:"bytes,1,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"` // Mặc định 5, tối đa 10
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AutocompleteRequest) Reset() {
+	*x = AutocompleteRequest{}
+	mi := &file_product_service_product_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AutocompleteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AutocompleteRequest) ProtoMessage() {}
+
+func (x *AutocompleteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_product_service_product_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AutocompleteRequest.ProtoReflect.Descriptor instead.
+func (*AutocompleteRequest) Descriptor() ([]byte, []int) {
+	return file_product_service_product_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *AutocompleteRequest) GetPrefix() string {
+	if x != nil {
+		return x.Prefix
+	}
+	return ""
+}
+
+func (x *AutocompleteRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type AutocompleteResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Suggestions   []string               `protobuf:"bytes,1,rep,name=suggestions,proto3" json:"suggestions,omitempty"` // Tên sản phẩm; rỗng nếu tra cứu quá chậm
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AutocompleteResponse) Reset() {
+	*x = AutocompleteResponse{}
+	mi := &file_product_service_product_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AutocompleteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AutocompleteResponse) ProtoMessage() {}
+
+func (x *AutocompleteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_product_service_product_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AutocompleteResponse.ProtoReflect.Descriptor instead.
+func (*AutocompleteResponse) Descriptor() ([]byte, []int) {
+	return file_product_service_product_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *AutocompleteResponse) GetSuggestions() []string {
+	if x != nil {
+		return x.Suggestions
+	}
+	return nil
+}
+
+// --- Stream Products (xuất dữ liệu, reindex) ---
+type StreamProductsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CategoryId    string                 `protobuf:"bytes,1,opt,name=category_id,json=categoryId,proto3" json:"category_id,omitempty"` // Lọc theo danh mục (tùy chọn)
+	BrandId       string                 `protobuf:"bytes,2,opt,name=brand_id,json=brandId,proto3" json:"brand_id,omitempty"`          // Lọc theo thương hiệu (tùy chọn)
+	TagIds        []string               `protobuf:"bytes,3,rep,name=tag_ids,json=tagIds,proto3" json:"tag_ids,omitempty"`             // Chỉ sản phẩm có tất cả các tag này (tùy chọn)
+	BatchSize     int32                  `protobuf:"varint,4,opt,name=batch_size,json=batchSize,proto3" json:"batch_size,omitempty"`   // Số sản phẩm mỗi message; mặc định 500, tối đa 1000
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamProductsRequest) Reset() {
+	*x = StreamProductsRequest{}
+	mi := &file_product_service_product_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamProductsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamProductsRequest) ProtoMessage() {}
+
+func (x *StreamProductsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_product_service_product_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamProductsRequest.ProtoReflect.Descriptor instead.
+func (*StreamProductsRequest) Descriptor() ([]byte, []int) {
+	return file_product_service_product_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *StreamProductsRequest) GetCategoryId() string {
+	if x != nil {
+		return x.CategoryId
+	}
+	return ""
+}
+
+func (x *StreamProductsRequest) GetBrandId() string {
+	if x != nil {
+		return x.BrandId
+	}
+	return ""
+}
+
+func (x *StreamProductsRequest) GetTagIds() []string {
+	if x != nil {
+		return x.TagIds
+	}
+	return nil
+}
+
+func (x *StreamProductsRequest) GetBatchSize() int32 {
+	if x != nil {
+		return x.BatchSize
+	}
+	return 0
+}
+
+type StreamProductsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Products      []*Product             `protobuf:"bytes,1,rep,name=products,proto3" json:"products,omitempty"` // Một lô sản phẩm, sắp xếp theo id
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamProductsResponse) Reset() {
+	*x = StreamProductsResponse{}
+	mi := &file_product_service_product_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamProductsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamProductsResponse) ProtoMessage() {}
+
+func (x *StreamProductsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_product_service_product_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamProductsResponse.ProtoReflect.Descriptor instead.
+func (*StreamProductsResponse) Descriptor() ([]byte, []int) {
+	return file_product_service_product_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *StreamProductsResponse) GetProducts() []*Product {
+	if x != nil {
+		return x.Products
+	}
+	return nil
+}
+
+// --- Product Interactions & Stats (số liệu cho trang quản trị) ---
+type ProductInteraction struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Type          string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`                    // "view", "add_to_cart", "purchase" hoặc "purchase_cancelled"
+	Quantity      int32                  `protobuf:"varint,3,opt,name=quantity,proto3" json:"quantity,omitempty"`           // Số lượng với add_to_cart/purchase/purchase_cancelled; mặc định 1
+	UserId        int64                  `protobuf:"varint,4,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // Tùy chọn, 0 với khách chưa đăng nhập
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProductInteraction) Reset() {
+	*x = ProductInteraction{}
+	mi := &file_product_service_product_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProductInteraction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProductInteraction) ProtoMessage() {}
+
+func (x *ProductInteraction) ProtoReflect() protoreflect.Message {
+	mi := &file_product_service_product_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProductInteraction.ProtoReflect.Descriptor instead.
+func (*ProductInteraction) Descriptor() ([]byte, []int) {
+	return file_product_service_product_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ProductInteraction) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *ProductInteraction) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *ProductInteraction) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *ProductInteraction) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+type RecordProductInteractionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Interactions  []*ProductInteraction  `protobuf:"bytes,1,rep,name=interactions,proto3" json:"interactions,omitempty"` // Tối đa 100
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecordProductInteractionsRequest) Reset() {
+	*x = RecordProductInteractionsRequest{}
+	mi := &file_product_service_product_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecordProductInteractionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordProductInteractionsRequest) ProtoMessage() {}
+
+func (x *RecordProductInteractionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_product_service_product_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordProductInteractionsRequest.ProtoReflect.Descriptor instead.
+func (*RecordProductInteractionsRequest) Descriptor() ([]byte, []int) {
+	return file_product_service_product_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *RecordProductInteractionsRequest) GetInteractions() []*ProductInteraction {
+	if x != nil {
+		return x.Interactions
+	}
+	return nil
+}
+
+type GetProductStatsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	From          *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=from,proto3" json:"from,omitempty"` // Bao gồm; bỏ trống = từ đầu
+	To            *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=to,proto3" json:"to,omitempty"`     // Không bao gồm; bỏ trống = đến hiện tại
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetProductStatsRequest) Reset() {
+	*x = GetProductStatsRequest{}
+	mi := &file_product_service_product_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProductStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProductStatsRequest) ProtoMessage() {}
+
+func (x *GetProductStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_product_service_product_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProductStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetProductStatsRequest) Descriptor() ([]byte, []int) {
+	return file_product_service_product_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *GetProductStatsRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *GetProductStatsRequest) GetFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *GetProductStatsRequest) GetTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+type GetProductStatsResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ProductId      string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	ViewCount      int64                  `protobuf:"varint,2,opt,name=view_count,json=viewCount,proto3" json:"view_count,omitempty"`
+	AddToCartCount int64                  `protobuf:"varint,3,opt,name=add_to_cart_count,json=addToCartCount,proto3" json:"add_to_cart_count,omitempty"` // Số đơn vị đã thêm vào giỏ
+	PurchaseCount  int64                  `protobuf:"varint,4,opt,name=purchase_count,json=purchaseCount,proto3" json:"purchase_count,omitempty"`        // Số đơn vị đã bán, trừ các đơn đã hủy
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *GetProductStatsResponse) Reset() {
+	*x = GetProductStatsResponse{}
+	mi := &file_product_service_product_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProductStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProductStatsResponse) ProtoMessage() {}
+
+func (x *GetProductStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_product_service_product_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProductStatsResponse.ProtoReflect.Descriptor instead.
+func (*GetProductStatsResponse) Descriptor() ([]byte, []int) {
+	return file_product_service_product_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *GetProductStatsResponse) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *GetProductStatsResponse) GetViewCount() int64 {
+	if x != nil {
+		return x.ViewCount
+	}
+	return 0
 }
 
-// Deprecated: Use CreateProductResponse.ProtoReflect.Descriptor instead.
-func (*CreateProductResponse) Descriptor() ([]byte, []int) {
-	return file_product_service_product_proto_rawDescGZIP(), []int{3}
+func (x *GetProductStatsResponse) GetAddToCartCount() int64 {
+	if x != nil {
+		return x.AddToCartCount
+	}
+	return 0
 }
 
-func (x *CreateProductResponse) GetProduct() *Product {
+func (x *GetProductStatsResponse) GetPurchaseCount() int64 {
 	if x != nil {
-		return x.Product
+		return x.PurchaseCount
 	}
-	return nil
+	return 0
 }
 
-// --- Get ---
-type GetProductRequest struct {
+// SavedSearch message: Bộ lọc sản phẩm người dùng đã lưu, được chạy định kỳ.
+type SavedSearch struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId        int64                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	CategoryId    string                 `protobuf:"bytes,4,opt,name=category_id,json=categoryId,proto3" json:"category_id,omitempty"` // Lọc theo danh mục (tùy chọn)
+	BrandId       string                 `protobuf:"bytes,5,opt,name=brand_id,json=brandId,proto3" json:"brand_id,omitempty"`          // Lọc theo thương hiệu (tùy chọn)
+	TagIds        []string               `protobuf:"bytes,6,rep,name=tag_ids,json=tagIds,proto3" json:"tag_ids,omitempty"`             // Sản phẩm phải có tất cả các tag này (tùy chọn)
+	MinPrice      float64                `protobuf:"fixed64,7,opt,name=min_price,json=minPrice,proto3" json:"min_price,omitempty"`     // Giá tối thiểu (bao gồm); 0 = không giới hạn
+	MaxPrice      float64                `protobuf:"fixed64,8,opt,name=max_price,json=maxPrice,proto3" json:"max_price,omitempty"`     // Giá tối đa (bao gồm); 0 = không giới hạn
+	LastRunAt     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=last_run_at,json=lastRunAt,proto3" json:"last_run_at,omitempty"`  // Chỉ sản phẩm tạo sau thời điểm này được báo ở lần chạy tới
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetProductRequest) Reset() {
-	*x = GetProductRequest{}
-	mi := &file_product_service_product_proto_msgTypes[4]
+func (x *SavedSearch) Reset() {
+	*x = SavedSearch{}
+	mi := &file_product_service_product_proto_msgTypes[25]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetProductRequest) String() string {
+func (x *SavedSearch) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetProductRequest) ProtoMessage() {}
+func (*SavedSearch) ProtoMessage() {}
 
-func (x *GetProductRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_product_service_product_proto_msgTypes[4]
+func (x *SavedSearch) ProtoReflect() protoreflect.Message {
+	mi := &file_product_service_product_proto_msgTypes[25]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -371,91 +1781,109 @@ func (x *GetProductRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetProductRequest.ProtoReflect.Descriptor instead.
-func (*GetProductRequest) Descriptor() ([]byte, []int) {
-	return file_product_service_product_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use SavedSearch.ProtoReflect.Descriptor instead.
+func (*SavedSearch) Descriptor() ([]byte, []int) {
+	return file_product_service_product_proto_rawDescGZIP(), []int{25}
 }
 
-func (x *GetProductRequest) GetId() string {
+func (x *SavedSearch) GetId() string {
 	if x != nil {
 		return x.Id
 	}
 	return ""
 }
 
-type GetProductResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Product       *Product               `protobuf:"bytes,1,opt,name=product,proto3" json:"product,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *SavedSearch) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
 }
 
-func (x *GetProductResponse) Reset() {
-	*x = GetProductResponse{}
-	mi := &file_product_service_product_proto_msgTypes[5]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *SavedSearch) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
 }
 
-func (x *GetProductResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *SavedSearch) GetCategoryId() string {
+	if x != nil {
+		return x.CategoryId
+	}
+	return ""
 }
 
-func (*GetProductResponse) ProtoMessage() {}
+func (x *SavedSearch) GetBrandId() string {
+	if x != nil {
+		return x.BrandId
+	}
+	return ""
+}
 
-func (x *GetProductResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_product_service_product_proto_msgTypes[5]
+func (x *SavedSearch) GetTagIds() []string {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.TagIds
 	}
-	return mi.MessageOf(x)
+	return nil
 }
 
-// Deprecated: Use GetProductResponse.ProtoReflect.Descriptor instead.
-func (*GetProductResponse) Descriptor() ([]byte, []int) {
-	return file_product_service_product_proto_rawDescGZIP(), []int{5}
+func (x *SavedSearch) GetMinPrice() float64 {
+	if x != nil {
+		return x.MinPrice
+	}
+	return 0
 }
 
-func (x *GetProductResponse) GetProduct() *Product {
+func (x *SavedSearch) GetMaxPrice() float64 {
 	if x != nil {
-		return x.Product
+		return x.MaxPrice
+	}
+	return 0
+}
+
+func (x *SavedSearch) GetLastRunAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastRunAt
 	}
 	return nil
 }
 
-// --- Update ---
-type UpdateProductRequest struct {
+func (x *SavedSearch) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type SaveSearchRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
-	Price         float64                `protobuf:"fixed64,4,opt,name=price,proto3" json:"price,omitempty"`
-	CategoryId    string                 `protobuf:"bytes,5,opt,name=category_id,json=categoryId,proto3" json:"category_id,omitempty"`
-	ImageUrl      string                 `protobuf:"bytes,6,opt,name=image_url,json=imageUrl,proto3" json:"image_url,omitempty"`
-	IsActive      bool                   `protobuf:"varint,7,opt,name=is_active,json=isActive,proto3" json:"is_active,omitempty"`
+	UserId        int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"` // Tên hiển thị trong thông báo; tối đa 100 ký tự
+	CategoryId    string                 `protobuf:"bytes,3,opt,name=category_id,json=categoryId,proto3" json:"category_id,omitempty"`
+	BrandId       string                 `protobuf:"bytes,4,opt,name=brand_id,json=brandId,proto3" json:"brand_id,omitempty"`
+	TagIds        []string               `protobuf:"bytes,5,rep,name=tag_ids,json=tagIds,proto3" json:"tag_ids,omitempty"`
+	MinPrice      float64                `protobuf:"fixed64,6,opt,name=min_price,json=minPrice,proto3" json:"min_price,omitempty"`
+	MaxPrice      float64                `protobuf:"fixed64,7,opt,name=max_price,json=maxPrice,proto3" json:"max_price,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateProductRequest) Reset() {
-	*x = UpdateProductRequest{}
-	mi := &file_product_service_product_proto_msgTypes[6]
+func (x *SaveSearchRequest) Reset() {
+	*x = SaveSearchRequest{}
+	mi := &file_product_service_product_proto_msgTypes[26]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateProductRequest) String() string {
+func (x *SaveSearchRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateProductRequest) ProtoMessage() {}
+func (*SaveSearchRequest) ProtoMessage() {}
 
-func (x *UpdateProductRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_product_service_product_proto_msgTypes[6]
+func (x *SaveSearchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_product_service_product_proto_msgTypes[26]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -466,82 +1894,82 @@ func (x *UpdateProductRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateProductRequest.ProtoReflect.Descriptor instead.
-func (*UpdateProductRequest) Descriptor() ([]byte, []int) {
-	return file_product_service_product_proto_rawDescGZIP(), []int{6}
+// Deprecated: Use SaveSearchRequest.ProtoReflect.Descriptor instead.
+func (*SaveSearchRequest) Descriptor() ([]byte, []int) {
+	return file_product_service_product_proto_rawDescGZIP(), []int{26}
 }
 
-func (x *UpdateProductRequest) GetId() string {
+func (x *SaveSearchRequest) GetUserId() int64 {
 	if x != nil {
-		return x.Id
+		return x.UserId
 	}
-	return ""
+	return 0
 }
 
-func (x *UpdateProductRequest) GetName() string {
+func (x *SaveSearchRequest) GetName() string {
 	if x != nil {
 		return x.Name
 	}
 	return ""
 }
 
-func (x *UpdateProductRequest) GetDescription() string {
+func (x *SaveSearchRequest) GetCategoryId() string {
 	if x != nil {
-		return x.Description
+		return x.CategoryId
 	}
 	return ""
 }
 
-func (x *UpdateProductRequest) GetPrice() float64 {
+func (x *SaveSearchRequest) GetBrandId() string {
 	if x != nil {
-		return x.Price
+		return x.BrandId
 	}
-	return 0
+	return ""
 }
 
-func (x *UpdateProductRequest) GetCategoryId() string {
+func (x *SaveSearchRequest) GetTagIds() []string {
 	if x != nil {
-		return x.CategoryId
+		return x.TagIds
 	}
-	return ""
+	return nil
 }
 
-func (x *UpdateProductRequest) GetImageUrl() string {
+func (x *SaveSearchRequest) GetMinPrice() float64 {
 	if x != nil {
-		return x.ImageUrl
+		return x.MinPrice
 	}
-	return ""
+	return 0
 }
 
-func (x *UpdateProductRequest) GetIsActive() bool {
+func (x *SaveSearchRequest) GetMaxPrice() float64 {
 	if x != nil {
-		return x.IsActive
+		return x.MaxPrice
 	}
-	return false
+	return 0
 }
 
-type UpdateProductResponse struct {
+type SaveSearchResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Product       *Product               `protobuf:"bytes,1,opt,name=product,proto3" json:"product,omitempty"`
+	SavedSearch   *SavedSearch           `protobuf:"bytes,1,opt,name=saved_search,json=savedSearch,proto3" json:"saved_search,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateProductResponse) Reset() {
-	*x = UpdateProductResponse{}
-	mi := &file_product_service_product_proto_msgTypes[7]
+func (x *SaveSearchResponse) Reset() {
+	*x = SaveSearchResponse{}
+	mi := &file_product_service_product_proto_msgTypes[27]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateProductResponse) String() string {
+func (x *SaveSearchResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateProductResponse) ProtoMessage() {}
+func (*SaveSearchResponse) ProtoMessage() {}
 
-func (x *UpdateProductResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_product_service_product_proto_msgTypes[7]
+func (x *SaveSearchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_product_service_product_proto_msgTypes[27]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -552,41 +1980,40 @@ func (x *UpdateProductResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateProductResponse.ProtoReflect.Descriptor instead.
-func (*UpdateProductResponse) Descriptor() ([]byte, []int) {
-	return file_product_service_product_proto_rawDescGZIP(), []int{7}
+// Deprecated: Use SaveSearchResponse.ProtoReflect.Descriptor instead.
+func (*SaveSearchResponse) Descriptor() ([]byte, []int) {
+	return file_product_service_product_proto_rawDescGZIP(), []int{27}
 }
 
-func (x *UpdateProductResponse) GetProduct() *Product {
+func (x *SaveSearchResponse) GetSavedSearch() *SavedSearch {
 	if x != nil {
-		return x.Product
+		return x.SavedSearch
 	}
 	return nil
 }
 
-// --- Delete ---
-type DeleteProductRequest struct {
+type ListSavedSearchesRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId        int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteProductRequest) Reset() {
-	*x = DeleteProductRequest{}
-	mi := &file_product_service_product_proto_msgTypes[8]
+func (x *ListSavedSearchesRequest) Reset() {
+	*x = ListSavedSearchesRequest{}
+	mi := &file_product_service_product_proto_msgTypes[28]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteProductRequest) String() string {
+func (x *ListSavedSearchesRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteProductRequest) ProtoMessage() {}
+func (*ListSavedSearchesRequest) ProtoMessage() {}
 
-func (x *DeleteProductRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_product_service_product_proto_msgTypes[8]
+func (x *ListSavedSearchesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_product_service_product_proto_msgTypes[28]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -597,43 +2024,40 @@ func (x *DeleteProductRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteProductRequest.ProtoReflect.Descriptor instead.
-func (*DeleteProductRequest) Descriptor() ([]byte, []int) {
-	return file_product_service_product_proto_rawDescGZIP(), []int{8}
+// Deprecated: Use ListSavedSearchesRequest.ProtoReflect.Descriptor instead.
+func (*ListSavedSearchesRequest) Descriptor() ([]byte, []int) {
+	return file_product_service_product_proto_rawDescGZIP(), []int{28}
 }
 
-func (x *DeleteProductRequest) GetId() string {
+func (x *ListSavedSearchesRequest) GetUserId() int64 {
 	if x != nil {
-		return x.Id
+		return x.UserId
 	}
-	return ""
+	return 0
 }
 
-// --- List ---
-type ListProductsRequest struct {
+type ListSavedSearchesResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Page          int32                  `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`
-	PageSize      int32                  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
-	CategoryId    string                 `protobuf:"bytes,3,opt,name=category_id,json=categoryId,proto3" json:"category_id,omitempty"` // Lọc sản phẩm theo danh mục (tùy chọn)
+	SavedSearches []*SavedSearch         `protobuf:"bytes,1,rep,name=saved_searches,json=savedSearches,proto3" json:"saved_searches,omitempty"` // Mới nhất trước
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListProductsRequest) Reset() {
-	*x = ListProductsRequest{}
-	mi := &file_product_service_product_proto_msgTypes[9]
+func (x *ListSavedSearchesResponse) Reset() {
+	*x = ListSavedSearchesResponse{}
+	mi := &file_product_service_product_proto_msgTypes[29]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListProductsRequest) String() string {
+func (x *ListSavedSearchesResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListProductsRequest) ProtoMessage() {}
+func (*ListSavedSearchesResponse) ProtoMessage() {}
 
-func (x *ListProductsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_product_service_product_proto_msgTypes[9]
+func (x *ListSavedSearchesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_product_service_product_proto_msgTypes[29]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -644,55 +2068,41 @@ func (x *ListProductsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListProductsRequest.ProtoReflect.Descriptor instead.
-func (*ListProductsRequest) Descriptor() ([]byte, []int) {
-	return file_product_service_product_proto_rawDescGZIP(), []int{9}
-}
-
-func (x *ListProductsRequest) GetPage() int32 {
-	if x != nil {
-		return x.Page
-	}
-	return 0
-}
-
-func (x *ListProductsRequest) GetPageSize() int32 {
-	if x != nil {
-		return x.PageSize
-	}
-	return 0
+// Deprecated: Use ListSavedSearchesResponse.ProtoReflect.Descriptor instead.
+func (*ListSavedSearchesResponse) Descriptor() ([]byte, []int) {
+	return file_product_service_product_proto_rawDescGZIP(), []int{29}
 }
 
-func (x *ListProductsRequest) GetCategoryId() string {
+func (x *ListSavedSearchesResponse) GetSavedSearches() []*SavedSearch {
 	if x != nil {
-		return x.CategoryId
+		return x.SavedSearches
 	}
-	return ""
+	return nil
 }
 
-type ListProductsResponse struct {
+type DeleteSavedSearchRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Products      []*Product             `protobuf:"bytes,1,rep,name=products,proto3" json:"products,omitempty"`
-	TotalCount    int64                  `protobuf:"varint,2,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId        int64                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // Chỉ xóa được bộ lọc của chính người dùng
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListProductsResponse) Reset() {
-	*x = ListProductsResponse{}
-	mi := &file_product_service_product_proto_msgTypes[10]
+func (x *DeleteSavedSearchRequest) Reset() {
+	*x = DeleteSavedSearchRequest{}
+	mi := &file_product_service_product_proto_msgTypes[30]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListProductsResponse) String() string {
+func (x *DeleteSavedSearchRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListProductsResponse) ProtoMessage() {}
+func (*DeleteSavedSearchRequest) ProtoMessage() {}
 
-func (x *ListProductsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_product_service_product_proto_msgTypes[10]
+func (x *DeleteSavedSearchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_product_service_product_proto_msgTypes[30]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -703,21 +2113,21 @@ func (x *ListProductsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListProductsResponse.ProtoReflect.Descriptor instead.
-func (*ListProductsResponse) Descriptor() ([]byte, []int) {
-	return file_product_service_product_proto_rawDescGZIP(), []int{10}
+// Deprecated: Use DeleteSavedSearchRequest.ProtoReflect.Descriptor instead.
+func (*DeleteSavedSearchRequest) Descriptor() ([]byte, []int) {
+	return file_product_service_product_proto_rawDescGZIP(), []int{30}
 }
 
-func (x *ListProductsResponse) GetProducts() []*Product {
+func (x *DeleteSavedSearchRequest) GetId() string {
 	if x != nil {
-		return x.Products
+		return x.Id
 	}
-	return nil
+	return ""
 }
 
-func (x *ListProductsResponse) GetTotalCount() int64 {
+func (x *DeleteSavedSearchRequest) GetUserId() int64 {
 	if x != nil {
-		return x.TotalCount
+		return x.UserId
 	}
 	return 0
 }
@@ -726,13 +2136,14 @@ func (x *ListProductsResponse) GetTotalCount() int64 {
 type CreateCategoryRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Slug          string                 `protobuf:"bytes,2,opt,name=slug,proto3" json:"slug,omitempty"` // Tùy chọn, tự sinh từ tên nếu để trống
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *CreateCategoryRequest) Reset() {
 	*x = CreateCategoryRequest{}
-	mi := &file_product_service_product_proto_msgTypes[11]
+	mi := &file_product_service_product_proto_msgTypes[31]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -744,7 +2155,7 @@ func (x *CreateCategoryRequest) String() string {
 func (*CreateCategoryRequest) ProtoMessage() {}
 
 func (x *CreateCategoryRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_product_service_product_proto_msgTypes[11]
+	mi := &file_product_service_product_proto_msgTypes[31]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -757,7 +2168,7 @@ func (x *CreateCategoryRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateCategoryRequest.ProtoReflect.Descriptor instead.
 func (*CreateCategoryRequest) Descriptor() ([]byte, []int) {
-	return file_product_service_product_proto_rawDescGZIP(), []int{11}
+	return file_product_service_product_proto_rawDescGZIP(), []int{31}
 }
 
 func (x *CreateCategoryRequest) GetName() string {
@@ -767,6 +2178,13 @@ func (x *CreateCategoryRequest) GetName() string {
 	return ""
 }
 
+func (x *CreateCategoryRequest) GetSlug() string {
+	if x != nil {
+		return x.Slug
+	}
+	return ""
+}
+
 type CreateCategoryResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Category      *Category              `protobuf:"bytes,1,opt,name=category,proto3" json:"category,omitempty"`
@@ -776,7 +2194,7 @@ type CreateCategoryResponse struct {
 
 func (x *CreateCategoryResponse) Reset() {
 	*x = CreateCategoryResponse{}
-	mi := &file_product_service_product_proto_msgTypes[12]
+	mi := &file_product_service_product_proto_msgTypes[32]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -788,7 +2206,7 @@ func (x *CreateCategoryResponse) String() string {
 func (*CreateCategoryResponse) ProtoMessage() {}
 
 func (x *CreateCategoryResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_product_service_product_proto_msgTypes[12]
+	mi := &file_product_service_product_proto_msgTypes[32]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -801,7 +2219,7 @@ func (x *CreateCategoryResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateCategoryResponse.ProtoReflect.Descriptor instead.
 func (*CreateCategoryResponse) Descriptor() ([]byte, []int) {
-	return file_product_service_product_proto_rawDescGZIP(), []int{12}
+	return file_product_service_product_proto_rawDescGZIP(), []int{32}
 }
 
 func (x *CreateCategoryResponse) GetCategory() *Category {
@@ -821,7 +2239,7 @@ type GetCategoryRequest struct {
 
 func (x *GetCategoryRequest) Reset() {
 	*x = GetCategoryRequest{}
-	mi := &file_product_service_product_proto_msgTypes[13]
+	mi := &file_product_service_product_proto_msgTypes[33]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -833,7 +2251,7 @@ func (x *GetCategoryRequest) String() string {
 func (*GetCategoryRequest) ProtoMessage() {}
 
 func (x *GetCategoryRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_product_service_product_proto_msgTypes[13]
+	mi := &file_product_service_product_proto_msgTypes[33]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -846,7 +2264,7 @@ func (x *GetCategoryRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetCategoryRequest.ProtoReflect.Descriptor instead.
 func (*GetCategoryRequest) Descriptor() ([]byte, []int) {
-	return file_product_service_product_proto_rawDescGZIP(), []int{13}
+	return file_product_service_product_proto_rawDescGZIP(), []int{33}
 }
 
 func (x *GetCategoryRequest) GetId() string {
@@ -865,7 +2283,7 @@ type GetCategoryResponse struct {
 
 func (x *GetCategoryResponse) Reset() {
 	*x = GetCategoryResponse{}
-	mi := &file_product_service_product_proto_msgTypes[14]
+	mi := &file_product_service_product_proto_msgTypes[34]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -877,7 +2295,7 @@ func (x *GetCategoryResponse) String() string {
 func (*GetCategoryResponse) ProtoMessage() {}
 
 func (x *GetCategoryResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_product_service_product_proto_msgTypes[14]
+	mi := &file_product_service_product_proto_msgTypes[34]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -890,7 +2308,7 @@ func (x *GetCategoryResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetCategoryResponse.ProtoReflect.Descriptor instead.
 func (*GetCategoryResponse) Descriptor() ([]byte, []int) {
-	return file_product_service_product_proto_rawDescGZIP(), []int{14}
+	return file_product_service_product_proto_rawDescGZIP(), []int{34}
 }
 
 func (x *GetCategoryResponse) GetCategory() *Category {
@@ -905,13 +2323,14 @@ type UpdateCategoryRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Slug          string                 `protobuf:"bytes,3,opt,name=slug,proto3" json:"slug,omitempty"` // Tùy chọn, ghi đè slug hiện tại
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *UpdateCategoryRequest) Reset() {
 	*x = UpdateCategoryRequest{}
-	mi := &file_product_service_product_proto_msgTypes[15]
+	mi := &file_product_service_product_proto_msgTypes[35]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -923,7 +2342,7 @@ func (x *UpdateCategoryRequest) String() string {
 func (*UpdateCategoryRequest) ProtoMessage() {}
 
 func (x *UpdateCategoryRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_product_service_product_proto_msgTypes[15]
+	mi := &file_product_service_product_proto_msgTypes[35]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -936,7 +2355,7 @@ func (x *UpdateCategoryRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateCategoryRequest.ProtoReflect.Descriptor instead.
 func (*UpdateCategoryRequest) Descriptor() ([]byte, []int) {
-	return file_product_service_product_proto_rawDescGZIP(), []int{15}
+	return file_product_service_product_proto_rawDescGZIP(), []int{35}
 }
 
 func (x *UpdateCategoryRequest) GetId() string {
@@ -953,6 +2372,13 @@ func (x *UpdateCategoryRequest) GetName() string {
 	return ""
 }
 
+func (x *UpdateCategoryRequest) GetSlug() string {
+	if x != nil {
+		return x.Slug
+	}
+	return ""
+}
+
 type UpdateCategoryResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Category      *Category              `protobuf:"bytes,1,opt,name=category,proto3" json:"category,omitempty"`
@@ -962,7 +2388,7 @@ type UpdateCategoryResponse struct {
 
 func (x *UpdateCategoryResponse) Reset() {
 	*x = UpdateCategoryResponse{}
-	mi := &file_product_service_product_proto_msgTypes[16]
+	mi := &file_product_service_product_proto_msgTypes[36]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -974,7 +2400,7 @@ func (x *UpdateCategoryResponse) String() string {
 func (*UpdateCategoryResponse) ProtoMessage() {}
 
 func (x *UpdateCategoryResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_product_service_product_proto_msgTypes[16]
+	mi := &file_product_service_product_proto_msgTypes[36]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -987,7 +2413,7 @@ func (x *UpdateCategoryResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateCategoryResponse.ProtoReflect.Descriptor instead.
 func (*UpdateCategoryResponse) Descriptor() ([]byte, []int) {
-	return file_product_service_product_proto_rawDescGZIP(), []int{16}
+	return file_product_service_product_proto_rawDescGZIP(), []int{36}
 }
 
 func (x *UpdateCategoryResponse) GetCategory() *Category {
@@ -1007,7 +2433,7 @@ type DeleteCategoryRequest struct {
 
 func (x *DeleteCategoryRequest) Reset() {
 	*x = DeleteCategoryRequest{}
-	mi := &file_product_service_product_proto_msgTypes[17]
+	mi := &file_product_service_product_proto_msgTypes[37]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1019,7 +2445,7 @@ func (x *DeleteCategoryRequest) String() string {
 func (*DeleteCategoryRequest) ProtoMessage() {}
 
 func (x *DeleteCategoryRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_product_service_product_proto_msgTypes[17]
+	mi := &file_product_service_product_proto_msgTypes[37]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1032,7 +2458,7 @@ func (x *DeleteCategoryRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteCategoryRequest.ProtoReflect.Descriptor instead.
 func (*DeleteCategoryRequest) Descriptor() ([]byte, []int) {
-	return file_product_service_product_proto_rawDescGZIP(), []int{17}
+	return file_product_service_product_proto_rawDescGZIP(), []int{37}
 }
 
 func (x *DeleteCategoryRequest) GetId() string {
@@ -1051,7 +2477,7 @@ type ListCategoriesRequest struct {
 
 func (x *ListCategoriesRequest) Reset() {
 	*x = ListCategoriesRequest{}
-	mi := &file_product_service_product_proto_msgTypes[18]
+	mi := &file_product_service_product_proto_msgTypes[38]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1063,7 +2489,7 @@ func (x *ListCategoriesRequest) String() string {
 func (*ListCategoriesRequest) ProtoMessage() {}
 
 func (x *ListCategoriesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_product_service_product_proto_msgTypes[18]
+	mi := &file_product_service_product_proto_msgTypes[38]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1076,7 +2502,7 @@ func (x *ListCategoriesRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListCategoriesRequest.ProtoReflect.Descriptor instead.
 func (*ListCategoriesRequest) Descriptor() ([]byte, []int) {
-	return file_product_service_product_proto_rawDescGZIP(), []int{18}
+	return file_product_service_product_proto_rawDescGZIP(), []int{38}
 }
 
 type ListCategoriesResponse struct {
@@ -1088,7 +2514,7 @@ type ListCategoriesResponse struct {
 
 func (x *ListCategoriesResponse) Reset() {
 	*x = ListCategoriesResponse{}
-	mi := &file_product_service_product_proto_msgTypes[19]
+	mi := &file_product_service_product_proto_msgTypes[39]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1100,7 +2526,7 @@ func (x *ListCategoriesResponse) String() string {
 func (*ListCategoriesResponse) ProtoMessage() {}
 
 func (x *ListCategoriesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_product_service_product_proto_msgTypes[19]
+	mi := &file_product_service_product_proto_msgTypes[39]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1113,7 +2539,7 @@ func (x *ListCategoriesResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListCategoriesResponse.ProtoReflect.Descriptor instead.
 func (*ListCategoriesResponse) Descriptor() ([]byte, []int) {
-	return file_product_service_product_proto_rawDescGZIP(), []int{19}
+	return file_product_service_product_proto_rawDescGZIP(), []int{39}
 }
 
 func (x *ListCategoriesResponse) GetCategories() []*Category {
@@ -1135,7 +2561,7 @@ const file_product_service_product_proto_rawDesc = "" +
 	"\n" +
 	"created_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
 	"\n" +
-	"updated_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\xca\x02\n" +
+	"updated_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\xeb\x04\n" +
 	"\aProduct\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12\x12\n" +
@@ -1150,20 +2576,61 @@ const file_product_service_product_proto_rawDesc = "" +
 	"created_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
 	"\n" +
 	"updated_at\x18\n" +
-	" \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\xa0\x01\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12H\n" +
+	"\favailability\x18\v \x01(\v2$.product_service.ProductAvailabilityR\favailability\x12\x1a\n" +
+	"\bcurrency\x18\f \x01(\tR\bcurrency\x12\x1d\n" +
+	"\n" +
+	"base_price\x18\r \x01(\x01R\tbasePrice\x12\x1b\n" +
+	"\tis_bundle\x18\x0e \x01(\bR\bisBundle\x12@\n" +
+	"\n" +
+	"components\x18\x0f \x03(\v2 .product_service.BundleComponentR\n" +
+	"components\x12;\n" +
+	"\bvariants\x18\x10 \x03(\v2\x1f.product_service.ProductVariantR\bvariants\"`\n" +
+	"\x0fBundleComponent\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1a\n" +
+	"\bquantity\x18\x03 \x01(\x05R\bquantity\"\xd5\x01\n" +
+	"\x0eProductVariant\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x10\n" +
+	"\x03sku\x18\x02 \x01(\tR\x03sku\x12A\n" +
+	"\n" +
+	"attributes\x18\x03 \x03(\v2!.product_service.VariantAttributeR\n" +
+	"attributes\x12\x14\n" +
+	"\x05price\x18\x04 \x01(\x01R\x05price\x12H\n" +
+	"\favailability\x18\x05 \x01(\v2$.product_service.ProductAvailabilityR\favailability\"<\n" +
+	"\x10VariantAttribute\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value\"w\n" +
+	"\x13ProductAvailability\x12\x16\n" +
+	"\x06status\x18\x01 \x01(\tR\x06status\x12\x19\n" +
+	"\bin_stock\x18\x02 \x01(\bR\ainStock\x12-\n" +
+	"\x12available_quantity\x18\x03 \x01(\x05R\x11availableQuantity\"\xb3\x02\n" +
 	"\x14CreateProductRequest\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12 \n" +
 	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x14\n" +
 	"\x05price\x18\x03 \x01(\x01R\x05price\x12\x1f\n" +
 	"\vcategory_id\x18\x04 \x01(\tR\n" +
 	"categoryId\x12\x1b\n" +
-	"\timage_url\x18\x05 \x01(\tR\bimageUrl\"K\n" +
+	"\timage_url\x18\x05 \x01(\tR\bimageUrl\x12\x12\n" +
+	"\x04slug\x18\x06 \x01(\tR\x04slug\x12@\n" +
+	"\n" +
+	"components\x18\a \x03(\v2 .product_service.BundleComponentR\n" +
+	"components\x12;\n" +
+	"\bvariants\x18\b \x03(\v2\x1f.product_service.ProductVariantR\bvariants\"K\n" +
 	"\x15CreateProductResponse\x122\n" +
-	"\aproduct\x18\x01 \x01(\v2\x18.product_service.ProductR\aproduct\"#\n" +
+	"\aproduct\x18\x01 \x01(\v2\x18.product_service.ProductR\aproduct\"r\n" +
 	"\x11GetProductRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"H\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x121\n" +
+	"\x14include_availability\x18\x02 \x01(\bR\x13includeAvailability\x12\x1a\n" +
+	"\bcurrency\x18\x03 \x01(\tR\bcurrency\"H\n" +
 	"\x12GetProductResponse\x122\n" +
-	"\aproduct\x18\x01 \x01(\v2\x18.product_service.ProductR\aproduct\"\xcd\x01\n" +
+	"\aproduct\x18\x01 \x01(\v2\x18.product_service.ProductR\aproduct\"+\n" +
+	"\x17GetProductsByIdsRequest\x12\x10\n" +
+	"\x03ids\x18\x01 \x03(\tR\x03ids\"t\n" +
+	"\x18GetProductsByIdsResponse\x124\n" +
+	"\bproducts\x18\x01 \x03(\v2\x18.product_service.ProductR\bproducts\x12\"\n" +
+	"\rnot_found_ids\x18\x02 \x03(\tR\vnotFoundIds\"\xb2\x03\n" +
 	"\x14UpdateProductRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
@@ -1172,31 +2639,120 @@ const file_product_service_product_proto_rawDesc = "" +
 	"\vcategory_id\x18\x05 \x01(\tR\n" +
 	"categoryId\x12\x1b\n" +
 	"\timage_url\x18\x06 \x01(\tR\bimageUrl\x12\x1b\n" +
-	"\tis_active\x18\a \x01(\bR\bisActive\"K\n" +
+	"\tis_active\x18\a \x01(\bR\bisActive\x12\x12\n" +
+	"\x04slug\x18\b \x01(\tR\x04slug\x12@\n" +
+	"\n" +
+	"components\x18\t \x03(\v2 .product_service.BundleComponentR\n" +
+	"components\x12)\n" +
+	"\x10clear_components\x18\n" +
+	" \x01(\bR\x0fclearComponents\x12;\n" +
+	"\bvariants\x18\v \x03(\v2\x1f.product_service.ProductVariantR\bvariants\x12%\n" +
+	"\x0eclear_variants\x18\f \x01(\bR\rclearVariants\"K\n" +
 	"\x15UpdateProductResponse\x122\n" +
 	"\aproduct\x18\x01 \x01(\v2\x18.product_service.ProductR\aproduct\"&\n" +
 	"\x14DeleteProductRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"g\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\xdc\x02\n" +
 	"\x13ListProductsRequest\x12\x12\n" +
 	"\x04page\x18\x01 \x01(\x05R\x04page\x12\x1b\n" +
 	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x12\x1f\n" +
 	"\vcategory_id\x18\x03 \x01(\tR\n" +
-	"categoryId\"m\n" +
+	"categoryId\x12\x19\n" +
+	"\bbrand_id\x18\x04 \x01(\tR\abrandId\x12\x17\n" +
+	"\atag_ids\x18\x05 \x03(\tR\x06tagIds\x121\n" +
+	"\x14include_availability\x18\x06 \x01(\bR\x13includeAvailability\x12\x1a\n" +
+	"\bcurrency\x18\a \x01(\tR\bcurrency\x12\x12\n" +
+	"\x04sort\x18\b \x01(\tR\x04sort\x12\x1b\n" +
+	"\tmin_price\x18\t \x01(\x01R\bminPrice\x12\x1b\n" +
+	"\tmax_price\x18\n" +
+	" \x01(\x01R\bmaxPrice\x12\"\n" +
+	"\rin_stock_only\x18\v \x01(\bR\vinStockOnly\"\xdb\x01\n" +
 	"\x14ListProductsResponse\x124\n" +
 	"\bproducts\x18\x01 \x03(\v2\x18.product_service.ProductR\bproducts\x12\x1f\n" +
 	"\vtotal_count\x18\x02 \x01(\x03R\n" +
-	"totalCount\"+\n" +
+	"totalCount\x12\x1f\n" +
+	"\vtotal_pages\x18\x03 \x01(\x05R\n" +
+	"totalPages\x12\x19\n" +
+	"\bhas_more\x18\x04 \x01(\bR\ahasMore\x120\n" +
+	"\x14stock_filter_skipped\x18\x05 \x01(\bR\x12stockFilterSkipped\"C\n" +
+	"\x13AutocompleteRequest\x12\x16\n" +
+	"\x06prefix\x18\x01 \x01(\tR\x06prefix\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\"8\n" +
+	"\x14AutocompleteResponse\x12 \n" +
+	"\vsuggestions\x18\x01 \x03(\tR\vsuggestions\"\x8b\x01\n" +
+	"\x15StreamProductsRequest\x12\x1f\n" +
+	"\vcategory_id\x18\x01 \x01(\tR\n" +
+	"categoryId\x12\x19\n" +
+	"\bbrand_id\x18\x02 \x01(\tR\abrandId\x12\x17\n" +
+	"\atag_ids\x18\x03 \x03(\tR\x06tagIds\x12\x1d\n" +
+	"\n" +
+	"batch_size\x18\x04 \x01(\x05R\tbatchSize\"N\n" +
+	"\x16StreamProductsResponse\x124\n" +
+	"\bproducts\x18\x01 \x03(\v2\x18.product_service.ProductR\bproducts\"|\n" +
+	"\x12ProductInteraction\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x1a\n" +
+	"\bquantity\x18\x03 \x01(\x05R\bquantity\x12\x17\n" +
+	"\auser_id\x18\x04 \x01(\x03R\x06userId\"k\n" +
+	" RecordProductInteractionsRequest\x12G\n" +
+	"\finteractions\x18\x01 \x03(\v2#.product_service.ProductInteractionR\finteractions\"\x93\x01\n" +
+	"\x16GetProductStatsRequest\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12.\n" +
+	"\x04from\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x04from\x12*\n" +
+	"\x02to\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x02to\"\xa9\x01\n" +
+	"\x17GetProductStatsResponse\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x1d\n" +
+	"\n" +
+	"view_count\x18\x02 \x01(\x03R\tviewCount\x12)\n" +
+	"\x11add_to_cart_count\x18\x03 \x01(\x03R\x0eaddToCartCount\x12%\n" +
+	"\x0epurchase_count\x18\x04 \x01(\x03R\rpurchaseCount\"\xd0\x02\n" +
+	"\vSavedSearch\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\x03R\x06userId\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x1f\n" +
+	"\vcategory_id\x18\x04 \x01(\tR\n" +
+	"categoryId\x12\x19\n" +
+	"\bbrand_id\x18\x05 \x01(\tR\abrandId\x12\x17\n" +
+	"\atag_ids\x18\x06 \x03(\tR\x06tagIds\x12\x1b\n" +
+	"\tmin_price\x18\a \x01(\x01R\bminPrice\x12\x1b\n" +
+	"\tmax_price\x18\b \x01(\x01R\bmaxPrice\x12:\n" +
+	"\vlast_run_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tlastRunAt\x129\n" +
+	"\n" +
+	"created_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\xcf\x01\n" +
+	"\x11SaveSearchRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x03R\x06userId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1f\n" +
+	"\vcategory_id\x18\x03 \x01(\tR\n" +
+	"categoryId\x12\x19\n" +
+	"\bbrand_id\x18\x04 \x01(\tR\abrandId\x12\x17\n" +
+	"\atag_ids\x18\x05 \x03(\tR\x06tagIds\x12\x1b\n" +
+	"\tmin_price\x18\x06 \x01(\x01R\bminPrice\x12\x1b\n" +
+	"\tmax_price\x18\a \x01(\x01R\bmaxPrice\"U\n" +
+	"\x12SaveSearchResponse\x12?\n" +
+	"\fsaved_search\x18\x01 \x01(\v2\x1c.product_service.SavedSearchR\vsavedSearch\"3\n" +
+	"\x18ListSavedSearchesRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x03R\x06userId\"`\n" +
+	"\x19ListSavedSearchesResponse\x12C\n" +
+	"\x0esaved_searches\x18\x01 \x03(\v2\x1c.product_service.SavedSearchR\rsavedSearches\"C\n" +
+	"\x18DeleteSavedSearchRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\x03R\x06userId\"?\n" +
 	"\x15CreateCategoryRequest\x12\x12\n" +
-	"\x04name\x18\x01 \x01(\tR\x04name\"O\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04slug\x18\x02 \x01(\tR\x04slug\"O\n" +
 	"\x16CreateCategoryResponse\x125\n" +
 	"\bcategory\x18\x01 \x01(\v2\x19.product_service.CategoryR\bcategory\"$\n" +
 	"\x12GetCategoryRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\"L\n" +
 	"\x13GetCategoryResponse\x125\n" +
-	"\bcategory\x18\x01 \x01(\v2\x19.product_service.CategoryR\bcategory\";\n" +
+	"\bcategory\x18\x01 \x01(\v2\x19.product_service.CategoryR\bcategory\"O\n" +
 	"\x15UpdateCategoryRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
-	"\x04name\x18\x02 \x01(\tR\x04name\"O\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x12\n" +
+	"\x04slug\x18\x03 \x01(\tR\x04slug\"O\n" +
 	"\x16UpdateCategoryResponse\x125\n" +
 	"\bcategory\x18\x01 \x01(\v2\x19.product_service.CategoryR\bcategory\"'\n" +
 	"\x15DeleteCategoryRequest\x12\x0e\n" +
@@ -1205,14 +2761,23 @@ const file_product_service_product_proto_rawDesc = "" +
 	"\x16ListCategoriesResponse\x129\n" +
 	"\n" +
 	"categories\x18\x01 \x03(\v2\x19.product_service.CategoryR\n" +
-	"categories2\xd4\x03\n" +
+	"categories2\xe8\t\n" +
 	"\x0eProductService\x12^\n" +
 	"\rCreateProduct\x12%.product_service.CreateProductRequest\x1a&.product_service.CreateProductResponse\x12U\n" +
 	"\n" +
-	"GetProduct\x12\".product_service.GetProductRequest\x1a#.product_service.GetProductResponse\x12^\n" +
+	"GetProduct\x12\".product_service.GetProductRequest\x1a#.product_service.GetProductResponse\x12g\n" +
+	"\x10GetProductsByIds\x12(.product_service.GetProductsByIdsRequest\x1a).product_service.GetProductsByIdsResponse\x12^\n" +
 	"\rUpdateProduct\x12%.product_service.UpdateProductRequest\x1a&.product_service.UpdateProductResponse\x12N\n" +
 	"\rDeleteProduct\x12%.product_service.DeleteProductRequest\x1a\x16.google.protobuf.Empty\x12[\n" +
-	"\fListProducts\x12$.product_service.ListProductsRequest\x1a%.product_service.ListProductsResponse2\xe6\x03\n" +
+	"\fListProducts\x12$.product_service.ListProductsRequest\x1a%.product_service.ListProductsResponse\x12[\n" +
+	"\fAutocomplete\x12$.product_service.AutocompleteRequest\x1a%.product_service.AutocompleteResponse\x12c\n" +
+	"\x0eStreamProducts\x12&.product_service.StreamProductsRequest\x1a'.product_service.StreamProductsResponse0\x01\x12f\n" +
+	"\x19RecordProductInteractions\x121.product_service.RecordProductInteractionsRequest\x1a\x16.google.protobuf.Empty\x12d\n" +
+	"\x0fGetProductStats\x12'.product_service.GetProductStatsRequest\x1a(.product_service.GetProductStatsResponse\x12U\n" +
+	"\n" +
+	"SaveSearch\x12\".product_service.SaveSearchRequest\x1a#.product_service.SaveSearchResponse\x12j\n" +
+	"\x11ListSavedSearches\x12).product_service.ListSavedSearchesRequest\x1a*.product_service.ListSavedSearchesResponse\x12V\n" +
+	"\x11DeleteSavedSearch\x12).product_service.DeleteSavedSearchRequest\x1a\x16.google.protobuf.Empty2\xe6\x03\n" +
 	"\x0fCategoryService\x12a\n" +
 	"\x0eCreateCategory\x12&.product_service.CreateCategoryRequest\x1a'.product_service.CreateCategoryResponse\x12X\n" +
 	"\vGetCategory\x12#.product_service.GetCategoryRequest\x1a$.product_service.GetCategoryResponse\x12a\n" +
@@ -1232,69 +2797,123 @@ func file_product_service_product_proto_rawDescGZIP() []byte {
 	return file_product_service_product_proto_rawDescData
 }
 
-var file_product_service_product_proto_msgTypes = make([]protoimpl.MessageInfo, 20)
+var file_product_service_product_proto_msgTypes = make([]protoimpl.MessageInfo, 40)
 var file_product_service_product_proto_goTypes = []any{
-	(*Category)(nil),               // 0: product_service.Category
-	(*Product)(nil),                // 1: product_service.Product
-	(*CreateProductRequest)(nil),   // 2: product_service.CreateProductRequest
-	(*CreateProductResponse)(nil),  // 3: product_service.CreateProductResponse
-	(*GetProductRequest)(nil),      // 4: product_service.GetProductRequest
-	(*GetProductResponse)(nil),     // 5: product_service.GetProductResponse
-	(*UpdateProductRequest)(nil),   // 6: product_service.UpdateProductRequest
-	(*UpdateProductResponse)(nil),  // 7: product_service.UpdateProductResponse
-	(*DeleteProductRequest)(nil),   // 8: product_service.DeleteProductRequest
-	(*ListProductsRequest)(nil),    // 9: product_service.ListProductsRequest
-	(*ListProductsResponse)(nil),   // 10: product_service.ListProductsResponse
-	(*CreateCategoryRequest)(nil),  // 11: product_service.CreateCategoryRequest
-	(*CreateCategoryResponse)(nil), // 12: product_service.CreateCategoryResponse
-	(*GetCategoryRequest)(nil),     // 13: product_service.GetCategoryRequest
-	(*GetCategoryResponse)(nil),    // 14: product_service.GetCategoryResponse
-	(*UpdateCategoryRequest)(nil),  // 15: product_service.UpdateCategoryRequest
-	(*UpdateCategoryResponse)(nil), // 16: product_service.UpdateCategoryResponse
-	(*DeleteCategoryRequest)(nil),  // 17: product_service.DeleteCategoryRequest
-	(*ListCategoriesRequest)(nil),  // 18: product_service.ListCategoriesRequest
-	(*ListCategoriesResponse)(nil), // 19: product_service.ListCategoriesResponse
-	(*timestamppb.Timestamp)(nil),  // 20: google.protobuf.Timestamp
-	(*emptypb.Empty)(nil),          // 21: google.protobuf.Empty
+	(*Category)(nil),                         // 0: product_service.Category
+	(*Product)(nil),                          // 1: product_service.Product
+	(*BundleComponent)(nil),                  // 2: product_service.BundleComponent
+	(*ProductVariant)(nil),                   // 3: product_service.ProductVariant
+	(*VariantAttribute)(nil),                 // 4: product_service.VariantAttribute
+	(*ProductAvailability)(nil),              // 5: product_service.ProductAvailability
+	(*CreateProductRequest)(nil),             // 6: product_service.CreateProductRequest
+	(*CreateProductResponse)(nil),            // 7: product_service.CreateProductResponse
+	(*GetProductRequest)(nil),                // 8: product_service.GetProductRequest
+	(*GetProductResponse)(nil),               // 9: product_service.GetProductResponse
+	(*GetProductsByIdsRequest)(nil),          // 10: product_service.GetProductsByIdsRequest
+	(*GetProductsByIdsResponse)(nil),         // 11: product_service.GetProductsByIdsResponse
+	(*UpdateProductRequest)(nil),             // 12: product_service.UpdateProductRequest
+	(*UpdateProductResponse)(nil),            // 13: product_service.UpdateProductResponse
+	(*DeleteProductRequest)(nil),             // 14: product_service.DeleteProductRequest
+	(*ListProductsRequest)(nil),              // 15: product_service.ListProductsRequest
+	(*ListProductsResponse)(nil),             // 16: product_service.ListProductsResponse
+	(*AutocompleteRequest)(nil),              // 17: product_service.AutocompleteRequest
+	(*AutocompleteResponse)(nil),             // 18: product_service.AutocompleteResponse
+	(*StreamProductsRequest)(nil),            // 19: product_service.StreamProductsRequest
+	(*StreamProductsResponse)(nil),           // 20: product_service.StreamProductsResponse
+	(*ProductInteraction)(nil),               // 21: product_service.ProductInteraction
+	(*RecordProductInteractionsRequest)(nil), // 22: product_service.RecordProductInteractionsRequest
+	(*GetProductStatsRequest)(nil),           // 23: product_service.GetProductStatsRequest
+	(*GetProductStatsResponse)(nil),          // 24: product_service.GetProductStatsResponse
+	(*SavedSearch)(nil),                      // 25: product_service.SavedSearch
+	(*SaveSearchRequest)(nil),                // 26: product_service.SaveSearchRequest
+	(*SaveSearchResponse)(nil),               // 27: product_service.SaveSearchResponse
+	(*ListSavedSearchesRequest)(nil),         // 28: product_service.ListSavedSearchesRequest
+	(*ListSavedSearchesResponse)(nil),        // 29: product_service.ListSavedSearchesResponse
+	(*DeleteSavedSearchRequest)(nil),         // 30: product_service.DeleteSavedSearchRequest
+	(*CreateCategoryRequest)(nil),            // 31: product_service.CreateCategoryRequest
+	(*CreateCategoryResponse)(nil),           // 32: product_service.CreateCategoryResponse
+	(*GetCategoryRequest)(nil),               // 33: product_service.GetCategoryRequest
+	(*GetCategoryResponse)(nil),              // 34: product_service.GetCategoryResponse
+	(*UpdateCategoryRequest)(nil),            // 35: product_service.UpdateCategoryRequest
+	(*UpdateCategoryResponse)(nil),           // 36: product_service.UpdateCategoryResponse
+	(*DeleteCategoryRequest)(nil),            // 37: product_service.DeleteCategoryRequest
+	(*ListCategoriesRequest)(nil),            // 38: product_service.ListCategoriesRequest
+	(*ListCategoriesResponse)(nil),           // 39: product_service.ListCategoriesResponse
+	(*timestamppb.Timestamp)(nil),            // 40: google.protobuf.Timestamp
+	(*emptypb.Empty)(nil),                    // 41: google.protobuf.Empty
 }
 var file_product_service_product_proto_depIdxs = []int32{
-	20, // 0: product_service.Category.created_at:type_name -> google.protobuf.Timestamp
-	20, // 1: product_service.Category.updated_at:type_name -> google.protobuf.Timestamp
-	20, // 2: product_service.Product.created_at:type_name -> google.protobuf.Timestamp
-	20, // 3: product_service.Product.updated_at:type_name -> google.protobuf.Timestamp
-	1,  // 4: product_service.CreateProductResponse.product:type_name -> product_service.Product
-	1,  // 5: product_service.GetProductResponse.product:type_name -> product_service.Product
-	1,  // 6: product_service.UpdateProductResponse.product:type_name -> product_service.Product
-	1,  // 7: product_service.ListProductsResponse.products:type_name -> product_service.Product
-	0,  // 8: product_service.CreateCategoryResponse.category:type_name -> product_service.Category
-	0,  // 9: product_service.GetCategoryResponse.category:type_name -> product_service.Category
-	0,  // 10: product_service.UpdateCategoryResponse.category:type_name -> product_service.Category
-	0,  // 11: product_service.ListCategoriesResponse.categories:type_name -> product_service.Category
-	2,  // 12: product_service.ProductService.CreateProduct:input_type -> product_service.CreateProductRequest
-	4,  // 13: product_service.ProductService.GetProduct:input_type -> product_service.GetProductRequest
-	6,  // 14: product_service.ProductService.UpdateProduct:input_type -> product_service.UpdateProductRequest
-	8,  // 15: product_service.ProductService.DeleteProduct:input_type -> product_service.DeleteProductRequest
-	9,  // 16: product_service.ProductService.ListProducts:input_type -> product_service.ListProductsRequest
-	11, // 17: product_service.CategoryService.CreateCategory:input_type -> product_service.CreateCategoryRequest
-	13, // 18: product_service.CategoryService.GetCategory:input_type -> product_service.GetCategoryRequest
-	15, // 19: product_service.CategoryService.UpdateCategory:input_type -> product_service.UpdateCategoryRequest
-	17, // 20: product_service.CategoryService.DeleteCategory:input_type -> product_service.DeleteCategoryRequest
-	18, // 21: product_service.CategoryService.ListCategories:input_type -> product_service.ListCategoriesRequest
-	3,  // 22: product_service.ProductService.CreateProduct:output_type -> product_service.CreateProductResponse
-	5,  // 23: product_service.ProductService.GetProduct:output_type -> product_service.GetProductResponse
-	7,  // 24: product_service.ProductService.UpdateProduct:output_type -> product_service.UpdateProductResponse
-	21, // 25: product_service.ProductService.DeleteProduct:output_type -> google.protobuf.Empty
-	10, // 26: product_service.ProductService.ListProducts:output_type -> product_service.ListProductsResponse
-	12, // 27: product_service.CategoryService.CreateCategory:output_type -> product_service.CreateCategoryResponse
-	14, // 28: product_service.CategoryService.GetCategory:output_type -> product_service.GetCategoryResponse
-	16, // 29: product_service.CategoryService.UpdateCategory:output_type -> product_service.UpdateCategoryResponse
-	21, // 30: product_service.CategoryService.DeleteCategory:output_type -> google.protobuf.Empty
-	19, // 31: product_service.CategoryService.ListCategories:output_type -> product_service.ListCategoriesResponse
-	22, // [22:32] is the sub-list for method output_type
-	12, // [12:22] is the sub-list for method input_type
-	12, // [12:12] is the sub-list for extension type_name
-	12, // [12:12] is the sub-list for extension extendee
-	0,  // [0:12] is the sub-list for field type_name
+	40, // 0: product_service.Category.created_at:type_name -> google.protobuf.Timestamp
+	40, // 1: product_service.Category.updated_at:type_name -> google.protobuf.Timestamp
+	40, // 2: product_service.Product.created_at:type_name -> google.protobuf.Timestamp
+	40, // 3: product_service.Product.updated_at:type_name -> google.protobuf.Timestamp
+	5,  // 4: product_service.Product.availability:type_name -> product_service.ProductAvailability
+	2,  // 5: product_service.Product.components:type_name -> product_service.BundleComponent
+	3,  // 6: product_service.Product.variants:type_name -> product_service.ProductVariant
+	4,  // 7: product_service.ProductVariant.attributes:type_name -> product_service.VariantAttribute
+	5,  // 8: product_service.ProductVariant.availability:type_name -> product_service.ProductAvailability
+	2,  // 9: product_service.CreateProductRequest.components:type_name -> product_service.BundleComponent
+	3,  // 10: product_service.CreateProductRequest.variants:type_name -> product_service.ProductVariant
+	1,  // 11: product_service.CreateProductResponse.product:type_name -> product_service.Product
+	1,  // 12: product_service.GetProductResponse.product:type_name -> product_service.Product
+	1,  // 13: product_service.GetProductsByIdsResponse.products:type_name -> product_service.Product
+	2,  // 14: product_service.UpdateProductRequest.components:type_name -> product_service.BundleComponent
+	3,  // 15: product_service.UpdateProductRequest.variants:type_name -> product_service.ProductVariant
+	1,  // 16: product_service.UpdateProductResponse.product:type_name -> product_service.Product
+	1,  // 17: product_service.ListProductsResponse.products:type_name -> product_service.Product
+	1,  // 18: product_service.StreamProductsResponse.products:type_name -> product_service.Product
+	21, // 19: product_service.RecordProductInteractionsRequest.interactions:type_name -> product_service.ProductInteraction
+	40, // 20: product_service.GetProductStatsRequest.from:type_name -> google.protobuf.Timestamp
+	40, // 21: product_service.GetProductStatsRequest.to:type_name -> google.protobuf.Timestamp
+	40, // 22: product_service.SavedSearch.last_run_at:type_name -> google.protobuf.Timestamp
+	40, // 23: product_service.SavedSearch.created_at:type_name -> google.protobuf.Timestamp
+	25, // 24: product_service.SaveSearchResponse.saved_search:type_name -> product_service.SavedSearch
+	25, // 25: product_service.ListSavedSearchesResponse.saved_searches:type_name -> product_service.SavedSearch
+	0,  // 26: product_service.CreateCategoryResponse.category:type_name -> product_service.Category
+	0,  // 27: product_service.GetCategoryResponse.category:type_name -> product_service.Category
+	0,  // 28: product_service.UpdateCategoryResponse.category:type_name -> product_service.Category
+	0,  // 29: product_service.ListCategoriesResponse.categories:type_name -> product_service.Category
+	6,  // 30: product_service.ProductService.CreateProduct:input_type -> product_service.CreateProductRequest
+	8,  // 31: product_service.ProductService.GetProduct:input_type -> product_service.GetProductRequest
+	10, // 32: product_service.ProductService.GetProductsByIds:input_type -> product_service.GetProductsByIdsRequest
+	12, // 33: product_service.ProductService.UpdateProduct:input_type -> product_service.UpdateProductRequest
+	14, // 34: product_service.ProductService.DeleteProduct:input_type -> product_service.DeleteProductRequest
+	15, // 35: product_service.ProductService.ListProducts:input_type -> product_service.ListProductsRequest
+	17, // 36: product_service.ProductService.Autocomplete:input_type -> product_service.AutocompleteRequest
+	19, // 37: product_service.ProductService.StreamProducts:input_type -> product_service.StreamProductsRequest
+	22, // 38: product_service.ProductService.RecordProductInteractions:input_type -> product_service.RecordProductInteractionsRequest
+	23, // 39: product_service.ProductService.GetProductStats:input_type -> product_service.GetProductStatsRequest
+	26, // 40: product_service.ProductService.SaveSearch:input_type -> product_service.SaveSearchRequest
+	28, // 41: product_service.ProductService.ListSavedSearches:input_type -> product_service.ListSavedSearchesRequest
+	30, // 42: product_service.ProductService.DeleteSavedSearch:input_type -> product_service.DeleteSavedSearchRequest
+	31, // 43: product_service.CategoryService.CreateCategory:input_type -> product_service.CreateCategoryRequest
+	33, // 44: product_service.CategoryService.GetCategory:input_type -> product_service.GetCategoryRequest
+	35, // 45: product_service.CategoryService.UpdateCategory:input_type -> product_service.UpdateCategoryRequest
+	37, // 46: product_service.CategoryService.DeleteCategory:input_type -> product_service.DeleteCategoryRequest
+	38, // 47: product_service.CategoryService.ListCategories:input_type -> product_service.ListCategoriesRequest
+	7,  // 48: product_service.ProductService.CreateProduct:output_type -> product_service.CreateProductResponse
+	9,  // 49: product_service.ProductService.GetProduct:output_type -> product_service.GetProductResponse
+	11, // 50: product_service.ProductService.GetProductsByIds:output_type -> product_service.GetProductsByIdsResponse
+	13, // 51: product_service.ProductService.UpdateProduct:output_type -> product_service.UpdateProductResponse
+	41, // 52: product_service.ProductService.DeleteProduct:output_type -> google.protobuf.Empty
+	16, // 53: product_service.ProductService.ListProducts:output_type -> product_service.ListProductsResponse
+	18, // 54: product_service.ProductService.Autocomplete:output_type -> product_service.AutocompleteResponse
+	20, // 55: product_service.ProductService.StreamProducts:output_type -> product_service.StreamProductsResponse
+	41, // 56: product_service.ProductService.RecordProductInteractions:output_type -> google.protobuf.Empty
+	24, // 57: product_service.ProductService.GetProductStats:output_type -> product_service.GetProductStatsResponse
+	27, // 58: product_service.ProductService.SaveSearch:output_type -> product_service.SaveSearchResponse
+	29, // 59: product_service.ProductService.ListSavedSearches:output_type -> product_service.ListSavedSearchesResponse
+	41, // 60: product_service.ProductService.DeleteSavedSearch:output_type -> google.protobuf.Empty
+	32, // 61: product_service.CategoryService.CreateCategory:output_type -> product_service.CreateCategoryResponse
+	34, // 62: product_service.CategoryService.GetCategory:output_type -> product_service.GetCategoryResponse
+	36, // 63: product_service.CategoryService.UpdateCategory:output_type -> product_service.UpdateCategoryResponse
+	41, // 64: product_service.CategoryService.DeleteCategory:output_type -> google.protobuf.Empty
+	39, // 65: product_service.CategoryService.ListCategories:output_type -> product_service.ListCategoriesResponse
+	48, // [48:66] is the sub-list for method output_type
+	30, // [30:48] is the sub-list for method input_type
+	30, // [30:30] is the sub-list for extension type_name
+	30, // [30:30] is the sub-list for extension extendee
+	0,  // [0:30] is the sub-list for field type_name
 }
 
 func init() { file_product_service_product_proto_init() }
@@ -1308,7 +2927,7 @@ func file_product_service_product_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_product_service_product_proto_rawDesc), len(file_product_service_product_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   20,
+			NumMessages:   40,
 			NumExtensions: 0,
 			NumServices:   2,
 		},
@@ -11,15 +11,18 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/redis/go-redis/v9"
 	"golang.org/x/time/rate"
 
 	"github.com/datngth03/ecommerce-go-app/services/api-gateway/internal/clients"
 	"github.com/datngth03/ecommerce-go-app/services/api-gateway/internal/config"
+	"github.com/datngth03/ecommerce-go-app/services/api-gateway/internal/events"
 	"github.com/datngth03/ecommerce-go-app/services/api-gateway/internal/handler"
 	"github.com/datngth03/ecommerce-go-app/services/api-gateway/internal/metrics"
 	"github.com/datngth03/ecommerce-go-app/services/api-gateway/internal/middleware"
 	"github.com/datngth03/ecommerce-go-app/services/api-gateway/internal/proxy"
 
+	sharedLogger "github.com/datngth03/ecommerce-go-app/shared/pkg/logger"
 	sharedMiddleware "github.com/datngth03/ecommerce-go-app/shared/pkg/middleware"
 	sharedTLS "github.com/datngth03/ecommerce-go-app/shared/pkg/tlsutil"
 	sharedTracing "github.com/datngth03/ecommerce-go-app/shared/pkg/tracing"
@@ -33,6 +36,9 @@ func main() {
 	if err != nil {
 		log.Fatalf("❌ Failed to load config: %v", err)
 	}
+	if err := sharedLogger.Init(cfg.Logging); err != nil {
+		log.Fatalf("Failed to initialize logger: %v", err)
+	}
 	log.Println("Configuration loaded")
 
 	// Print config in development mode
@@ -71,17 +77,88 @@ func main() {
 	productProxy := proxy.NewProductProxy(grpcClients.Product)
 	log.Println("Proxies initialized")
 
+	// Order status events for live order streams; the hub reconnects on its own if RabbitMQ is down
+	var orderEvents *events.OrderEventHub
+	eventsCtx, stopEvents := context.WithCancel(context.Background())
+	defer stopEvents()
+	if cfg.RabbitMQ.Enabled {
+		orderEvents = events.NewOrderEventHub(cfg.GetRabbitMQURL())
+		go orderEvents.Run(eventsCtx)
+	}
+
 	// Initialize handlers
 	userHandler := handler.NewUserHandler(userProxy)
 	productHandler := handler.NewProductHandler(productProxy)
-	orderHandler := handler.NewOrderHandler(grpcClients.Order)
+	orderHandler := handler.NewOrderHandler(grpcClients.Order, orderEvents)
 	paymentHandler := handler.NewPaymentHandler(grpcClients.Payment)
 	inventoryHandler := handler.NewInventoryHandler(grpcClients.Inventory)
-	healthHandler := handler.NewHealthHandler(grpcClients)
+	healthHandler := handler.NewHealthHandler(grpcClients, cfg.Health.CriticalServices, cfg.Health.CheckTimeout)
 	log.Println("Handlers initialized")
 
+	// Redis backs Idempotency-Key replay, the revocation check of locally verified
+	// tokens and the maintenance flag shared by all gateway instances
+	checkRevocation := cfg.TokenAuth.LocalVerification && cfg.TokenAuth.CheckRevocation
+	var redisClient *redis.Client
+	if cfg.Redis.Enabled {
+		redisClient = redis.NewClient(&redis.Options{
+			Addr:         cfg.Redis.GetAddr(),
+			Password:     cfg.Redis.Password,
+			DB:           cfg.Redis.DB,
+			PoolSize:     cfg.Redis.PoolSize,
+			MinIdleConns: cfg.Redis.MinIdleConns,
+		})
+		defer redisClient.Close()
+	}
+
+	// Idempotency-Key support needs Redis; without it the gateway runs without replay protection
+	var idempotencyStore middleware.IdempotencyStore
+	if cfg.Idempotency.Enabled && redisClient != nil {
+		pingCtx, pingCancel := context.WithTimeout(context.Background(), 5*time.Second)
+		if err := redisClient.Ping(pingCtx).Err(); err != nil {
+			log.Printf("⚠️  Redis unavailable, Idempotency-Key disabled: %v", err)
+		} else {
+			idempotencyStore = middleware.NewRedisIdempotencyStore(redisClient)
+			log.Println("✓ Idempotency-Key support enabled")
+		}
+		pingCancel()
+	}
+
+	// Local token verification saves the ValidateToken round-trip on authenticated
+	// requests: HS256 tokens with the shared secret, RS256 tokens against the User
+	// Service's JWKS. Revocation-sensitive routes still validate remotely.
+	var localVerifier middleware.TokenVerifier
+	var revocations middleware.RevocationChecker
+	jwksCtx, stopJWKS := context.WithCancel(context.Background())
+	defer stopJWKS()
+	if cfg.TokenAuth.LocalVerification {
+		verifiers := middleware.TokenVerifiers{middleware.NewSecretVerifier(cfg.Auth.JWTSecret)}
+		if cfg.TokenAuth.JWKSURL != "" {
+			verifiers = append(verifiers, middleware.NewJWKSVerifier(jwksCtx, cfg.TokenAuth.JWKSURL, cfg.TokenAuth.JWKSRefreshInterval))
+		}
+		localVerifier = verifiers
+		// Blacklist lookups that fail fall back to remote validation per request
+		if checkRevocation {
+			revocations = middleware.NewRedisRevocationChecker(redisClient)
+		}
+		log.Printf("✓ Local token verification enabled (revocation check: %v)", checkRevocation)
+	}
+	authenticator := middleware.NewAuthenticator(userProxy, localVerifier, revocations)
+
+	// Maintenance mode starts from MAINTENANCE_MODE; admin changes are shared through Redis
+	var maintenanceStore middleware.MaintenanceStore
+	if redisClient != nil {
+		maintenanceStore = middleware.NewRedisMaintenanceStore(redisClient)
+	} else {
+		log.Println("⚠️  Redis disabled, maintenance mode changes apply to this instance only")
+	}
+	maintenance := middleware.NewMaintenanceMode(maintenanceStore, middleware.MaintenanceState{
+		Enabled:    cfg.Maintenance.Enabled,
+		Message:    cfg.Maintenance.Message,
+		RetryAfter: cfg.Maintenance.RetryAfter,
+	}, cfg.Maintenance.RefreshInterval)
+
 	// Setup HTTP server
-	router := setupRouter(cfg, userHandler, productHandler, orderHandler, paymentHandler, inventoryHandler, healthHandler, userProxy)
+	router := setupRouter(cfg, userHandler, productHandler, orderHandler, paymentHandler, inventoryHandler, healthHandler, userProxy, authenticator, idempotencyStore, maintenance)
 
 	// Create HTTP server with TLS support
 	srv := &http.Server{
@@ -146,6 +223,9 @@ func setupRouter(
 	inventoryHandler *handler.InventoryHandler,
 	healthHandler *handler.HealthHandler,
 	userProxy *proxy.UserProxy,
+	authenticator *middleware.Authenticator,
+	idempotencyStore middleware.IdempotencyStore,
+	maintenance *middleware.MaintenanceMode,
 ) *gin.Engine {
 	// Set Gin mode
 	if cfg.IsProduction() {
@@ -174,11 +254,12 @@ func setupRouter(
 
 	// CORS middleware
 	if cfg.Security.CORS.Enabled {
-		securityMiddlewares = append(securityMiddlewares, sharedMiddleware.CORSMiddleware(cfg.Security.CORS.AllowedOrigins))
+		securityMiddlewares = append(securityMiddlewares, sharedMiddleware.CORSWithConfig(corsConfig(cfg.Security.CORS)))
 	}
 
-	// Timeout middleware
-	securityMiddlewares = append(securityMiddlewares, sharedMiddleware.TimeoutMiddleware(cfg.Security.RequestTimeout))
+	// Timeout middleware (not for Server-Sent Events streams, which stay open).
+	// Route groups with their own budget replace it with routeTimeout below.
+	securityMiddlewares = append(securityMiddlewares, middleware.SkipForEventStream(middleware.RequestTimeoutMiddleware(cfg.Security.RequestTimeout)))
 
 	// Add security middleware first
 	for _, mw := range securityMiddlewares {
@@ -191,7 +272,8 @@ func setupRouter(
 	}
 
 	// Response compression middleware (after security, before business logic)
-	router.Use(sharedMiddleware.CompressionMiddleware())
+	// Event streams are skipped: compression buffers writes
+	router.Use(middleware.SkipForEventStream(sharedMiddleware.CompressionMiddleware()))
 
 	// Global middleware
 	router.Use(gin.Recovery())
@@ -201,6 +283,7 @@ func setupRouter(
 	// Health endpoints
 	router.GET("/health", healthHandler.HealthCheck)
 	router.GET("/ready", healthHandler.ReadinessCheck)
+	router.GET("/health/services", healthHandler.ServicesHealth)
 	router.GET("/health/pools", healthHandler.PoolsHealth)
 	router.GET("/health/pools/detailed", healthHandler.DetailedPoolsHealth)
 
@@ -209,13 +292,85 @@ func setupRouter(
 
 	// API v1 routes
 	v1 := router.Group("/api/v1")
+
+	// Maintenance mode blocks writes but not reads; health and /debug routes are
+	// outside v1, and login stays open so admins can get a token to switch it off
+	v1.Use(middleware.MaintenanceMiddleware(maintenance, "/api/v1/auth/login", "/api/v1/auth/refresh"))
+
+	// Per-user rate limiting on top of the per-IP limit: identify the caller first,
+	// so authenticated users get their own bucket instead of sharing their IP's
+	if cfg.Security.UserRateLimit.Enabled {
+		userLimits := cfg.Security.UserRateLimit
+		userRateLimiter := middleware.NewUserRateLimiter(
+			middleware.RateLimitTier{RequestsPerSecond: userLimits.Anonymous.RequestsPerSecond, Burst: userLimits.Anonymous.BurstSize},
+			middleware.RateLimitTier{RequestsPerSecond: userLimits.Authenticated.RequestsPerSecond, Burst: userLimits.Authenticated.BurstSize},
+			middleware.RateLimitTier{RequestsPerSecond: userLimits.Admin.RequestsPerSecond, Burst: userLimits.Admin.BurstSize},
+		)
+		v1.Use(middleware.OptionalAuth(authenticator), middleware.UserRateLimitMiddleware(userRateLimiter))
+	}
+
+	// Stricter limits for sensitive route groups; one limiter per group so routes
+	// in the same group share a bucket
+	routeLimiters := make(map[string]gin.HandlerFunc)
+	routeLimit := func(group string) gin.HandlerFunc {
+		if limiter, ok := routeLimiters[group]; ok {
+			return limiter
+		}
+		tier, ok := cfg.Security.RouteRateLimit.Groups[group]
+		if !cfg.Security.RouteRateLimit.Enabled || !ok {
+			return func(c *gin.Context) { c.Next() }
+		}
+		limiter := middleware.RouteRateLimitMiddleware(group, middleware.RateLimitTier{
+			RequestsPerSecond: tier.RequestsPerSecond,
+			Burst:             tier.BurstSize,
+		})
+		routeLimiters[group] = limiter
+		return limiter
+	}
+
+	// Replay protection for mutating POSTs; runs after AuthMiddleware so keys are scoped per user
+	idempotency := func(c *gin.Context) { c.Next() }
+	if idempotencyStore != nil {
+		idempotency = middleware.IdempotencyMiddleware(idempotencyStore, middleware.IdempotencyOptions{
+			TTL:     cfg.Idempotency.TTL,
+			LockTTL: cfg.Idempotency.LockTTL,
+		})
+	}
+
+	// Per-route-group request timeouts; the deadline carries over to backend calls
+	routeTimeout := func(group string) gin.HandlerFunc {
+		return middleware.SkipForEventStream(middleware.RouteTimeoutMiddleware(group, cfg.Security.RouteTimeouts[group]))
+	}
+
+	// Audit trail of successful mutations, recorded in user-service; runs after AuthMiddleware
+	audit := func(action string) gin.HandlerFunc {
+		return middleware.Audit(userProxy, action)
+	}
+
+	// Revocation-sensitive routes validate the token with user-service even when the
+	// gateway verifies tokens locally, so a logged-out token can't reach them
+	remoteAuth := middleware.RemoteAuthMiddleware(authenticator)
+
+	// Runtime log level, to switch to debug without a redeploy
+	debug := router.Group("/debug")
+	debug.Use(remoteAuth, middleware.RequireAdmin())
+	{
+		debug.GET("/loglevel", handler.GetLogLevel)
+		debug.PUT("/loglevel", audit("log_level.update"), handler.SetLogLevel)
+
+		maintenanceHandler := handler.NewMaintenanceHandler(maintenance)
+		debug.GET("/maintenance", maintenanceHandler.GetMaintenance)
+		debug.PUT("/maintenance", audit("maintenance.update"), maintenanceHandler.SetMaintenance)
+		debug.DELETE("/maintenance", audit("maintenance.reset"), maintenanceHandler.ResetMaintenance)
+	}
+
 	{
 		// Auth routes (public)
 		auth := v1.Group("/auth")
 		{
-			auth.POST("/register", userHandler.Register)
-			auth.POST("/login", userHandler.Login)
-			auth.POST("/refresh", userHandler.RefreshToken)
+			auth.POST("/register", routeLimit(config.RouteGroupAuth), userHandler.Register)
+			auth.POST("/login", routeLimit(config.RouteGroupAuthLogin), userHandler.Login)
+			auth.POST("/refresh", routeLimit(config.RouteGroupAuth), userHandler.RefreshToken)
 		}
 
 		// User routes
@@ -225,90 +380,172 @@ func setupRouter(
 			users.GET("/:id", userHandler.GetUser)
 
 			// Protected routes (require authentication)
-			users.Use(middleware.AuthMiddleware(userProxy))
+			users.Use(middleware.AuthMiddleware(authenticator))
 			users.GET("/me", userHandler.GetProfile)
-			users.PUT("/:id", userHandler.UpdateUser)
-			users.DELETE("/:id", userHandler.DeleteUser)
+			users.PUT("/me/password", remoteAuth, routeLimit(config.RouteGroupAuthLogin), userHandler.ChangePassword)
+			users.POST("/me/2fa/enable", remoteAuth, userHandler.EnableTwoFactor)
+			users.POST("/me/2fa/confirm", remoteAuth, routeLimit(config.RouteGroupAuthLogin), userHandler.ConfirmTwoFactor)
+			users.POST("/me/2fa/disable", remoteAuth, routeLimit(config.RouteGroupAuthLogin), userHandler.DisableTwoFactor)
+			users.PUT("/:id", remoteAuth, userHandler.UpdateUser)
+			users.DELETE("/:id", remoteAuth, userHandler.DeleteUser)
 		}
 
 		// Product routes
 		products := v1.Group("/products")
+		products.Use(routeTimeout(config.TimeoutGroupCatalog))
 		{
 			// Public routes - anyone can browse products
 			products.GET("", productHandler.ListProducts)
+			products.GET("/autocomplete", productHandler.Autocomplete)
 			products.GET("/:id", productHandler.GetProduct)
 
 			// Protected routes - require authentication
-			products.Use(middleware.AuthMiddleware(userProxy))
-			products.POST("", productHandler.CreateProduct)
-			products.PUT("/:id", productHandler.UpdateProduct)
-			products.DELETE("/:id", productHandler.DeleteProduct)
+			products.Use(middleware.AuthMiddleware(authenticator))
+			products.POST("", remoteAuth, audit("product.create"), productHandler.CreateProduct)
+			products.PUT("/:id", remoteAuth, audit("product.update"), productHandler.UpdateProduct)
+			products.DELETE("/:id", remoteAuth, audit("product.delete"), productHandler.DeleteProduct)
 		}
 
 		// Category routes
 		categories := v1.Group("/categories")
+		categories.Use(routeTimeout(config.TimeoutGroupCatalog))
 		{
 			// Public routes
 			categories.GET("", productHandler.ListCategories)
 			categories.GET("/:id", productHandler.GetCategory)
 
 			// Protected routes
-			categories.Use(middleware.AuthMiddleware(userProxy))
-			categories.POST("", productHandler.CreateCategory)
-			categories.PUT("/:id", productHandler.UpdateCategory)
-			categories.DELETE("/:id", productHandler.DeleteCategory)
+			categories.Use(middleware.AuthMiddleware(authenticator))
+			categories.POST("", remoteAuth, audit("category.create"), productHandler.CreateCategory)
+			categories.PUT("/:id", remoteAuth, audit("category.update"), productHandler.UpdateCategory)
+			categories.DELETE("/:id", remoteAuth, audit("category.delete"), productHandler.DeleteCategory)
+		}
+
+		// Saved searches: new matching products are pushed to the user
+		savedSearches := v1.Group("/saved-searches")
+		savedSearches.Use(middleware.AuthMiddleware(authenticator), idempotency)
+		{
+			savedSearches.POST("", productHandler.SaveSearch)
+			savedSearches.GET("", productHandler.ListSavedSearches)
+			savedSearches.DELETE("/:id", productHandler.DeleteSavedSearch)
 		}
 
 		// Order routes
 		orders := v1.Group("/orders")
-		orders.Use(middleware.AuthMiddleware(userProxy))
+		orders.Use(middleware.AuthMiddleware(authenticator), idempotency)
 		{
 			orders.POST("", orderHandler.CreateOrder)
 			orders.GET("/:id", orderHandler.GetOrder)
+			orders.GET("/by-number/:number", orderHandler.GetOrderByNumber)
+			orders.GET("/:id/stream", orderHandler.StreamOrderStatus)
+			orders.GET("/:id/notes", orderHandler.ListOrderNotes)
+			orders.POST("/:id/returns", orderHandler.RequestReturn)
 			orders.GET("", orderHandler.ListOrders)
 			orders.DELETE("/:id", orderHandler.CancelOrder)
 		}
 
+		// Admin order routes
+		adminOrders := v1.Group("/admin/orders")
+		adminOrders.Use(remoteAuth, middleware.RequireAdmin())
+		{
+			adminOrders.GET("", orderHandler.AdminListOrders)
+			adminOrders.GET("/by-number/:number", orderHandler.AdminGetOrderByNumber)
+			adminOrders.GET("/:id/notes", orderHandler.AdminListOrderNotes)
+			adminOrders.POST("/:id/notes", audit("order.note.create"), orderHandler.AdminAddOrderNote)
+		}
+
+		// Return (RMA) routes
+		returns := v1.Group("/returns")
+		returns.Use(middleware.AuthMiddleware(authenticator))
+		{
+			returns.GET("", orderHandler.ListReturns)
+			returns.GET("/:id", orderHandler.GetReturn)
+		}
+
+		// Admin return routes
+		adminReturns := v1.Group("/admin/returns")
+		adminReturns.Use(remoteAuth, middleware.RequireAdmin())
+		{
+			adminReturns.GET("", orderHandler.AdminListReturns)
+			adminReturns.GET("/:id", orderHandler.AdminGetReturn)
+			adminReturns.PUT("/:id/status", audit("return.status.update"), orderHandler.AdminUpdateReturnStatus)
+		}
+
+		// Admin product dashboard: view, add-to-cart and purchase counts
+		adminProducts := v1.Group("/admin/products")
+		adminProducts.Use(remoteAuth, middleware.RequireAdmin())
+		{
+			adminProducts.GET("/:id/stats", productHandler.GetProductStats)
+		}
+
+		// Audit log of admin mutations
+		adminAudit := v1.Group("/admin/audit-log")
+		adminAudit.Use(remoteAuth, middleware.RequireAdmin())
+		{
+			adminAudit.GET("", userHandler.GetAuditLog)
+		}
+
 		// Cart routes
 		cart := v1.Group("/cart")
-		cart.Use(middleware.AuthMiddleware(userProxy))
+		cart.Use(middleware.AuthMiddleware(authenticator), idempotency)
 		{
 			cart.POST("", orderHandler.AddToCart)
+			cart.POST("/items", orderHandler.AddItemsToCart)
 			cart.GET("", orderHandler.GetCart)
 			cart.PUT("/:product_id", orderHandler.UpdateCartItem)
 			cart.DELETE("/:product_id", orderHandler.RemoveFromCart)
 			cart.DELETE("", orderHandler.ClearCart)
 		}
 
+		// Wishlist routes
+		wishlist := v1.Group("/wishlist")
+		wishlist.Use(middleware.AuthMiddleware(authenticator), idempotency)
+		{
+			wishlist.POST("", orderHandler.AddToWishlist)
+			wishlist.GET("", orderHandler.GetWishlist)
+			wishlist.DELETE("/:product_id", orderHandler.RemoveFromWishlist)
+			wishlist.POST("/:product_id/move-to-cart", orderHandler.MoveToCart)
+		}
+
 		// Payment routes
 		payments := v1.Group("/payments")
-		payments.Use(middleware.AuthMiddleware(userProxy))
+		payments.Use(routeTimeout(config.TimeoutGroupPayments), remoteAuth, routeLimit(config.RouteGroupPayments), idempotency)
 		{
 			payments.POST("", paymentHandler.ProcessPayment)
 			payments.GET("/:id", paymentHandler.GetPayment)
 			payments.GET("/order/:order_id", paymentHandler.GetPaymentByOrder)
 			payments.GET("", paymentHandler.GetPaymentHistory)
 			payments.POST("/:id/confirm", paymentHandler.ConfirmPayment)
-			payments.POST("/:id/refund", paymentHandler.RefundPayment)
+			payments.POST("/:id/refund", audit("payment.refund"), paymentHandler.RefundPayment)
 		}
 
 		// Payment Methods routes
 		paymentMethods := v1.Group("/payment-methods")
-		paymentMethods.Use(middleware.AuthMiddleware(userProxy))
+		paymentMethods.Use(routeTimeout(config.TimeoutGroupPayments), remoteAuth, routeLimit(config.RouteGroupPayments), idempotency)
 		{
 			paymentMethods.POST("", paymentHandler.SavePaymentMethod)
 			paymentMethods.GET("", paymentHandler.GetPaymentMethods)
+			paymentMethods.DELETE("/:id", paymentHandler.DeletePaymentMethod)
+		}
+
+		// Subscription routes
+		subscriptions := v1.Group("/subscriptions")
+		subscriptions.Use(routeTimeout(config.TimeoutGroupPayments), remoteAuth, routeLimit(config.RouteGroupPayments), idempotency)
+		{
+			subscriptions.POST("", paymentHandler.CreateSubscription)
+			subscriptions.POST("/:id/cancel", paymentHandler.CancelSubscription)
 		}
 
 		// Inventory routes (public for checking stock)
 		inventory := v1.Group("/inventory")
+		inventory.Use(routeTimeout(config.TimeoutGroupCatalog))
 		{
 			inventory.GET("/:product_id", inventoryHandler.GetStock)
 			inventory.POST("/check-availability", inventoryHandler.CheckAvailability)
 
 			// Admin routes
-			inventory.Use(middleware.AuthMiddleware(userProxy))
-			inventory.PUT("/:product_id", inventoryHandler.UpdateStock)
+			inventory.Use(middleware.AuthMiddleware(authenticator))
+			inventory.PUT("/:product_id", remoteAuth, audit("inventory.update"), inventoryHandler.UpdateStock)
 			inventory.GET("/:product_id/history", inventoryHandler.GetStockHistory)
 		}
 
@@ -317,3 +554,28 @@ func setupRouter(
 
 	return router
 }
+
+// corsConfig converts the gateway's CORS settings for the shared middleware
+func corsConfig(cors config.CORSConfig) sharedMiddleware.CORSConfig {
+	policies := make(map[string]sharedMiddleware.CORSOriginPolicy)
+	for origin, methods := range cors.OriginMethods {
+		policy := policies[origin]
+		policy.AllowedMethods = methods
+		policies[origin] = policy
+	}
+	for origin, headers := range cors.OriginHeaders {
+		policy := policies[origin]
+		policy.AllowedHeaders = headers
+		policies[origin] = policy
+	}
+
+	return sharedMiddleware.CORSConfig{
+		AllowedOrigins:   cors.AllowedOrigins,
+		AllowedMethods:   cors.AllowedMethods,
+		AllowedHeaders:   cors.AllowedHeaders,
+		ExposeHeaders:    cors.ExposeHeaders,
+		AllowCredentials: cors.AllowCredentials,
+		MaxAge:           cors.MaxAge,
+		OriginPolicies:   policies,
+	}
+}
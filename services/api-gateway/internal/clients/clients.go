@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"log"
 
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
 
 	"github.com/datngth03/ecommerce-go-app/services/api-gateway/internal/config"
@@ -34,7 +35,7 @@ func NewClients(cfg *config.Config) (*Clients, error) {
 		if !cfg.Server.TLS.Enabled {
 			return nil, nil
 		}
-		return sharedTLS.ClientTLSConfig(cfg.Server.TLS.CAFile, serverName)
+		return sharedTLS.ClientCredentials(cfg.Server.TLS, serverName)
 	}
 
 	// Create TLS credentials for each service (mỗi service có credentials riêng)
@@ -91,6 +92,10 @@ func NewClients(cfg *config.Config) (*Clients, error) {
 		NotificationServiceTLSCreds: notificationTLSCreds,
 		DefaultPoolSize:             5, // 5 connections per service
 		TLSEnabled:                  cfg.Server.TLS.Enabled,
+		MaxMessageSize:              cfg.Server.MaxMessageSize,
+		DialOptions: []grpc.DialOption{
+			grpc.WithUnaryInterceptor(DeadlineInterceptor(cfg.Security.DeadlineBuffer)),
+		},
 	}
 
 	if err := poolManager.CreateCommonPools(serviceConfig); err != nil {
@@ -0,0 +1,33 @@
+package clients
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// DeadlineInterceptor shortens the deadline of backend calls by buffer, leaving
+// the gateway time to turn a timeout into a response before the HTTP request
+// times out. gRPC sends the deadline to the backend, which cancels its work
+// when it passes. A call with less than buffer left fails at once rather than
+// starting work nobody will wait for; calls without a deadline are unchanged.
+func DeadlineInterceptor(buffer time.Duration) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		deadline, ok := ctx.Deadline()
+		if !ok || buffer <= 0 {
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+
+		deadline = deadline.Add(-buffer)
+		if time.Until(deadline) <= 0 {
+			return status.Errorf(codes.DeadlineExceeded, "request deadline too close to call %s", method)
+		}
+
+		ctx, cancel := context.WithDeadline(ctx, deadline)
+		defer cancel()
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
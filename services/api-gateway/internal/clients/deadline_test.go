@@ -0,0 +1,61 @@
+package clients
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestDeadlineInterceptor(t *testing.T) {
+	intercept := DeadlineInterceptor(100 * time.Millisecond)
+
+	var got time.Time
+	var hasDeadline bool
+	invoker := func(ctx context.Context, _ string, _, _ interface{}, _ *grpc.ClientConn, _ ...grpc.CallOption) error {
+		got, hasDeadline = ctx.Deadline()
+		return nil
+	}
+
+	t.Run("shortens the request deadline", func(t *testing.T) {
+		deadline := time.Now().Add(time.Second)
+		ctx, cancel := context.WithDeadline(context.Background(), deadline)
+		defer cancel()
+
+		if err := intercept(ctx, "/svc/Method", nil, nil, nil, invoker); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if want := deadline.Add(-100 * time.Millisecond); !got.Equal(want) {
+			t.Errorf("backend deadline = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("fails fast when the buffer is used up", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		called := false
+		err := intercept(ctx, "/svc/Method", nil, nil, nil, func(context.Context, string, interface{}, interface{}, *grpc.ClientConn, ...grpc.CallOption) error {
+			called = true
+			return nil
+		})
+		if status.Code(err) != codes.DeadlineExceeded {
+			t.Errorf("code = %v, want DeadlineExceeded", status.Code(err))
+		}
+		if called {
+			t.Error("backend was called")
+		}
+	})
+
+	t.Run("leaves calls without a deadline alone", func(t *testing.T) {
+		if err := intercept(context.Background(), "/svc/Method", nil, nil, nil, invoker); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if hasDeadline {
+			t.Error("a deadline was added")
+		}
+	})
+}
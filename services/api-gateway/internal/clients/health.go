@@ -0,0 +1,75 @@
+package clients
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+// StatusUnreachable is reported for a backend whose health check call failed
+const StatusUnreachable = "UNREACHABLE"
+
+// ServiceHealth is the result of one backend's gRPC health check
+type ServiceHealth struct {
+	Status  string // Serving status reported by the backend, e.g. "SERVING", or StatusUnreachable
+	Latency time.Duration
+	Error   string
+}
+
+// CheckServicesHealth queries the standard gRPC health service of every backend
+// concurrently; each query is bounded by timeout
+func (c *Clients) CheckServicesHealth(ctx context.Context, timeout time.Duration) map[string]ServiceHealth {
+	if c.poolManager == nil {
+		return nil
+	}
+
+	conns := make(map[string]grpc.ClientConnInterface)
+	for _, name := range c.poolManager.List() {
+		if pool, ok := c.poolManager.Get(name); ok {
+			conns[name] = pool.Get()
+		}
+	}
+	return checkHealth(ctx, conns, timeout)
+}
+
+// checkHealth runs one health check per connection in parallel
+func checkHealth(ctx context.Context, conns map[string]grpc.ClientConnInterface, timeout time.Duration) map[string]ServiceHealth {
+	var (
+		mu      sync.Mutex
+		wg      sync.WaitGroup
+		results = make(map[string]ServiceHealth, len(conns))
+	)
+	for name, conn := range conns {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			result := checkServiceHealth(ctx, conn, timeout)
+			mu.Lock()
+			results[name] = result
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+	return results
+}
+
+// checkServiceHealth asks for the server's overall status (the empty service name)
+func checkServiceHealth(ctx context.Context, conn grpc.ClientConnInterface, timeout time.Duration) ServiceHealth {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	start := time.Now()
+	resp, err := grpc_health_v1.NewHealthClient(conn).Check(ctx, &grpc_health_v1.HealthCheckRequest{})
+	result := ServiceHealth{Latency: time.Since(start)}
+	if err != nil {
+		result.Status = StatusUnreachable
+		result.Error = status.Convert(err).Message()
+		return result
+	}
+	result.Status = resp.GetStatus().String()
+	return result
+}
@@ -0,0 +1,72 @@
+package clients
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// hangingHealthServer never answers until the caller gives up
+type hangingHealthServer struct {
+	grpc_health_v1.UnimplementedHealthServer
+}
+
+func (hangingHealthServer) Check(ctx context.Context, _ *grpc_health_v1.HealthCheckRequest) (*grpc_health_v1.HealthCheckResponse, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func startHealthServer(t *testing.T, healthServer grpc_health_v1.HealthServer) grpc.ClientConnInterface {
+	t.Helper()
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := grpc.NewServer()
+	grpc_health_v1.RegisterHealthServer(server, healthServer)
+	go server.Serve(lis)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient(lis.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+func TestCheckHealth(t *testing.T) {
+	serving := health.NewServer()
+	notServing := health.NewServer()
+	notServing.SetServingStatus("", grpc_health_v1.HealthCheckResponse_NOT_SERVING)
+
+	conns := map[string]grpc.ClientConnInterface{
+		"user-service":         startHealthServer(t, serving),
+		"payment-service":      startHealthServer(t, notServing),
+		"notification-service": startHealthServer(t, hangingHealthServer{}),
+	}
+
+	timeout := 200 * time.Millisecond
+	start := time.Now()
+	results := checkHealth(context.Background(), conns, timeout)
+	// The checks run concurrently, so the hanging one bounds the total
+	if elapsed := time.Since(start); elapsed > 2*timeout {
+		t.Errorf("checks took %v, want about %v", elapsed, timeout)
+	}
+
+	if got := results["user-service"]; got.Status != "SERVING" || got.Error != "" || got.Latency <= 0 {
+		t.Errorf("user-service = %+v, want SERVING with a latency", got)
+	}
+	if got := results["payment-service"].Status; got != "NOT_SERVING" {
+		t.Errorf("payment-service status = %q, want NOT_SERVING", got)
+	}
+	if got := results["notification-service"]; got.Status != StatusUnreachable || got.Error == "" || got.Latency < timeout {
+		t.Errorf("notification-service = %+v, want unreachable after the timeout", got)
+	}
+}
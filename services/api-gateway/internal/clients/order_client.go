@@ -88,12 +88,60 @@ func (c *OrderClient) GetOrder(ctx context.Context, req *pb.GetOrderRequest) (*p
 	return client.GetOrder(ctx, req)
 }
 
+// GetOrderByNumber retrieves an order by its human-friendly number
+func (c *OrderClient) GetOrderByNumber(ctx context.Context, req *pb.GetOrderByNumberRequest) (*pb.GetOrderResponse, error) {
+	client := c.getClient()
+	return client.GetOrderByNumber(ctx, req)
+}
+
 // ListOrders lists orders for a user
 func (c *OrderClient) ListOrders(ctx context.Context, req *pb.ListOrdersRequest) (*pb.ListOrdersResponse, error) {
 	client := c.getClient()
 	return client.ListOrders(ctx, req)
 }
 
+// GetOrdersByStatus lists orders of all users by status and time range (admin)
+func (c *OrderClient) GetOrdersByStatus(ctx context.Context, req *pb.GetOrdersByStatusRequest) (*pb.ListOrdersResponse, error) {
+	client := c.getClient()
+	return client.GetOrdersByStatus(ctx, req)
+}
+
+// AddOrderNote attaches a staff note to an order
+func (c *OrderClient) AddOrderNote(ctx context.Context, req *pb.AddOrderNoteRequest) (*pb.OrderNoteResponse, error) {
+	client := c.getClient()
+	return client.AddOrderNote(ctx, req)
+}
+
+// ListOrderNotes lists the notes of an order
+func (c *OrderClient) ListOrderNotes(ctx context.Context, req *pb.ListOrderNotesRequest) (*pb.ListOrderNotesResponse, error) {
+	client := c.getClient()
+	return client.ListOrderNotes(ctx, req)
+}
+
+// RequestReturn opens a return of items of a delivered order
+func (c *OrderClient) RequestReturn(ctx context.Context, req *pb.RequestReturnRequest) (*pb.ReturnResponse, error) {
+	client := c.getClient()
+	return client.RequestReturn(ctx, req)
+}
+
+// GetReturn retrieves a return by ID
+func (c *OrderClient) GetReturn(ctx context.Context, req *pb.GetReturnRequest) (*pb.ReturnResponse, error) {
+	client := c.getClient()
+	return client.GetReturn(ctx, req)
+}
+
+// ListReturns lists returns of a user, or of all users for admins
+func (c *OrderClient) ListReturns(ctx context.Context, req *pb.ListReturnsRequest) (*pb.ListReturnsResponse, error) {
+	client := c.getClient()
+	return client.ListReturns(ctx, req)
+}
+
+// UpdateReturnStatus moves a return to a new status (admin)
+func (c *OrderClient) UpdateReturnStatus(ctx context.Context, req *pb.UpdateReturnStatusRequest) (*pb.ReturnResponse, error) {
+	client := c.getClient()
+	return client.UpdateReturnStatus(ctx, req)
+}
+
 // UpdateOrderStatus updates order status
 func (c *OrderClient) UpdateOrderStatus(ctx context.Context, req *pb.UpdateOrderStatusRequest) (*pb.UpdateOrderStatusResponse, error) {
 	client := c.getClient()
@@ -113,6 +161,11 @@ func (c *OrderClient) AddToCart(ctx context.Context, req *pb.AddToCartRequest) (
 	return client.AddToCart(ctx, req)
 }
 
+func (c *OrderClient) AddItemsToCart(ctx context.Context, req *pb.AddItemsToCartRequest) (*pb.AddItemsToCartResponse, error) {
+	client := c.getClient()
+	return client.AddItemsToCart(ctx, req)
+}
+
 func (c *OrderClient) GetCart(ctx context.Context, req *pb.GetCartRequest) (*pb.CartResponse, error) {
 	client := c.getClient()
 	return client.GetCart(ctx, req)
@@ -133,3 +186,24 @@ func (c *OrderClient) ClearCart(ctx context.Context, req *pb.ClearCartRequest) e
 	_, err := client.ClearCart(ctx, req)
 	return err
 }
+
+// Wishlist operations
+func (c *OrderClient) AddToWishlist(ctx context.Context, req *pb.AddToWishlistRequest) (*pb.WishlistResponse, error) {
+	client := c.getClient()
+	return client.AddToWishlist(ctx, req)
+}
+
+func (c *OrderClient) GetWishlist(ctx context.Context, req *pb.GetWishlistRequest) (*pb.WishlistResponse, error) {
+	client := c.getClient()
+	return client.GetWishlist(ctx, req)
+}
+
+func (c *OrderClient) RemoveFromWishlist(ctx context.Context, req *pb.RemoveFromWishlistRequest) (*pb.WishlistResponse, error) {
+	client := c.getClient()
+	return client.RemoveFromWishlist(ctx, req)
+}
+
+func (c *OrderClient) MoveToCart(ctx context.Context, req *pb.MoveToCartRequest) (*pb.MoveToCartResponse, error) {
+	client := c.getClient()
+	return client.MoveToCart(ctx, req)
+}
@@ -123,3 +123,21 @@ func (c *PaymentClient) GetPaymentMethods(ctx context.Context, req *pb.GetPaymen
 	client := c.getClient()
 	return client.GetPaymentMethods(ctx, req)
 }
+
+// DeletePaymentMethod removes a saved payment method
+func (c *PaymentClient) DeletePaymentMethod(ctx context.Context, req *pb.DeletePaymentMethodRequest) (*pb.DeletePaymentMethodResponse, error) {
+	client := c.getClient()
+	return client.DeletePaymentMethod(ctx, req)
+}
+
+// CreateSubscription starts a subscription
+func (c *PaymentClient) CreateSubscription(ctx context.Context, req *pb.CreateSubscriptionRequest) (*pb.CreateSubscriptionResponse, error) {
+	client := c.getClient()
+	return client.CreateSubscription(ctx, req)
+}
+
+// CancelSubscription cancels a subscription
+func (c *PaymentClient) CancelSubscription(ctx context.Context, req *pb.CancelSubscriptionRequest) (*pb.CancelSubscriptionResponse, error) {
+	client := c.getClient()
+	return client.CancelSubscription(ctx, req)
+}
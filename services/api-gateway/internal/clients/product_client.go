@@ -97,33 +97,96 @@ func (c *ProductClient) getCategoryClient() pb.CategoryServiceClient {
 }
 
 // GetProduct retrieves a product by ID
-func (c *ProductClient) GetProduct(ctx context.Context, id string) (*pb.Product, error) {
+func (c *ProductClient) GetProduct(ctx context.Context, req *pb.GetProductRequest) (*pb.Product, error) {
 	ctx, cancel := context.WithTimeout(ctx, c.timeout)
 	defer cancel()
 
 	client := c.getProductClient()
-	resp, err := client.GetProduct(ctx, &pb.GetProductRequest{Id: id})
+	resp, err := client.GetProduct(ctx, req)
 	if err != nil {
 		return nil, err
 	}
 	return resp.Product, nil
 }
 
-// ListProducts retrieves a list of products with pagination
-func (c *ProductClient) ListProducts(ctx context.Context, page, pageSize int32, categoryID string) ([]*pb.Product, int64, error) {
+// ListProducts retrieves a list of products with pagination and filters
+func (c *ProductClient) ListProducts(ctx context.Context, req *pb.ListProductsRequest) (*pb.ListProductsResponse, error) {
 	ctx, cancel := context.WithTimeout(ctx, c.timeout)
 	defer cancel()
 
 	client := c.getProductClient()
-	resp, err := client.ListProducts(ctx, &pb.ListProductsRequest{
-		Page:       page,
-		PageSize:   pageSize,
-		CategoryId: categoryID,
-	})
+	return client.ListProducts(ctx, req)
+}
+
+// autocompleteTimeout bounds autocomplete calls; suggestions are useless once the user typed on
+const autocompleteTimeout = 150 * time.Millisecond
+
+// Autocomplete retrieves product name suggestions for a prefix
+func (c *ProductClient) Autocomplete(ctx context.Context, req *pb.AutocompleteRequest) ([]string, error) {
+	ctx, cancel := context.WithTimeout(ctx, autocompleteTimeout)
+	defer cancel()
+
+	client := c.getProductClient()
+	resp, err := client.Autocomplete(ctx, req)
 	if err != nil {
-		return nil, 0, err
+		return nil, err
 	}
-	return resp.Products, resp.TotalCount, nil
+	return resp.Suggestions, nil
+}
+
+// RecordProductInteractions records product views, add-to-cart and purchase events
+func (c *ProductClient) RecordProductInteractions(ctx context.Context, req *pb.RecordProductInteractionsRequest) error {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	client := c.getProductClient()
+	_, err := client.RecordProductInteractions(ctx, req)
+	return err
+}
+
+// GetProductStats retrieves the interaction counts of a product
+func (c *ProductClient) GetProductStats(ctx context.Context, req *pb.GetProductStatsRequest) (*pb.GetProductStatsResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	client := c.getProductClient()
+	return client.GetProductStats(ctx, req)
+}
+
+// SaveSearch stores a user's saved search
+func (c *ProductClient) SaveSearch(ctx context.Context, req *pb.SaveSearchRequest) (*pb.SavedSearch, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	client := c.getProductClient()
+	resp, err := client.SaveSearch(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return resp.SavedSearch, nil
+}
+
+// ListSavedSearches retrieves the saved searches of a user
+func (c *ProductClient) ListSavedSearches(ctx context.Context, userID int64) ([]*pb.SavedSearch, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	client := c.getProductClient()
+	resp, err := client.ListSavedSearches(ctx, &pb.ListSavedSearchesRequest{UserId: userID})
+	if err != nil {
+		return nil, err
+	}
+	return resp.SavedSearches, nil
+}
+
+// DeleteSavedSearch deletes a saved search of a user
+func (c *ProductClient) DeleteSavedSearch(ctx context.Context, id string, userID int64) error {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	client := c.getProductClient()
+	_, err := client.DeleteSavedSearch(ctx, &pb.DeleteSavedSearchRequest{Id: id, UserId: userID})
+	return err
 }
 
 // CreateProduct creates a new product
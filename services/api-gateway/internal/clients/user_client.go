@@ -10,6 +10,7 @@ import (
 	sharedTracing "github.com/datngth03/ecommerce-go-app/shared/pkg/tracing"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
 )
 
 // UserClient wraps the gRPC client for user-service with connection pooling
@@ -158,3 +159,63 @@ func (c *UserClient) RefreshToken(ctx context.Context, refreshToken string) (*pb
 	client := c.getClient()
 	return client.RefreshToken(ctx, &pb.RefreshTokenRequest{RefreshToken: refreshToken})
 }
+
+// ChangePassword changes the password of the user the access token belongs to
+func (c *UserClient) ChangePassword(ctx context.Context, accessToken string, req *pb.ChangePasswordRequest) (*pb.ChangePasswordResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	client := c.getClient()
+	return client.ChangePassword(withAccessToken(ctx, accessToken), req)
+}
+
+// EnableTwoFactor generates a TOTP secret for the user the access token belongs to
+func (c *UserClient) EnableTwoFactor(ctx context.Context, accessToken string) (*pb.EnableTwoFactorResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	client := c.getClient()
+	return client.EnableTwoFactor(withAccessToken(ctx, accessToken), &pb.EnableTwoFactorRequest{})
+}
+
+// ConfirmTwoFactor activates two-factor authentication with the first code
+func (c *UserClient) ConfirmTwoFactor(ctx context.Context, accessToken, code string) (*pb.ConfirmTwoFactorResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	client := c.getClient()
+	return client.ConfirmTwoFactor(withAccessToken(ctx, accessToken), &pb.ConfirmTwoFactorRequest{Code: code})
+}
+
+// DisableTwoFactor turns two-factor authentication off
+func (c *UserClient) DisableTwoFactor(ctx context.Context, accessToken string, req *pb.DisableTwoFactorRequest) (*pb.DisableTwoFactorResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	client := c.getClient()
+	return client.DisableTwoFactor(withAccessToken(ctx, accessToken), req)
+}
+
+// withAccessToken passes the caller's access token to the user service, which
+// reads the user of self-service calls from it
+func withAccessToken(ctx context.Context, accessToken string) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+accessToken)
+}
+
+// RecordAuditEntry stores an audit log entry
+func (c *UserClient) RecordAuditEntry(ctx context.Context, req *pb.RecordAuditEntryRequest) (*pb.RecordAuditEntryResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	client := c.getClient()
+	return client.RecordAuditEntry(ctx, req)
+}
+
+// GetAuditLog lists audit log entries
+func (c *UserClient) GetAuditLog(ctx context.Context, req *pb.GetAuditLogRequest) (*pb.GetAuditLogResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	client := c.getClient()
+	return client.GetAuditLog(ctx, req)
+}
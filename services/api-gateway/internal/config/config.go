@@ -2,6 +2,7 @@ package config
 
 import (
 	"fmt"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -11,21 +12,47 @@ import (
 
 // Config holds API Gateway specific configuration
 type Config struct {
-	Service   sharedConfig.ServiceInfo
-	Server    sharedConfig.ServerConfig
-	Services  sharedConfig.ExternalServices
-	Auth      sharedConfig.AuthConfig
-	RateLimit RateLimitConfig
-	Logging   sharedConfig.LoggingConfig
-	External  ExternalConfig
-	Security  SecurityConfig
+	Service     sharedConfig.ServiceInfo
+	Server      sharedConfig.ServerConfig
+	Services    sharedConfig.ExternalServices
+	Auth        sharedConfig.AuthConfig
+	RateLimit   RateLimitConfig
+	Logging     sharedConfig.LoggingConfig
+	Redis       sharedConfig.RedisConfig
+	RabbitMQ    sharedConfig.RabbitMQConfig
+	External    ExternalConfig
+	Security    SecurityConfig
+	Idempotency IdempotencyConfig
+	Health      HealthConfig
+	TokenAuth   TokenAuthConfig
+	Maintenance MaintenanceConfig
+}
+
+// MaintenanceConfig contains maintenance-mode settings; admins can switch it at runtime
+type MaintenanceConfig struct {
+	Enabled         bool          // State at startup, until an admin sets one
+	Message         string        // Error message of rejected writes
+	RetryAfter      time.Duration // Sent as Retry-After with rejected writes
+	RefreshInterval time.Duration // How often the shared state is reread from Redis
+}
+
+// TokenAuthConfig contains settings for verifying access tokens in the gateway
+type TokenAuthConfig struct {
+	LocalVerification   bool          // Verify signature and expiry in the gateway; false validates every token with the User Service over gRPC
+	JWKSURL             string        // User Service JWKS for RS256 tokens; HS256 tokens are verified with JWT_SECRET
+	JWKSRefreshInterval time.Duration // How often the cached keys are refetched
+	CheckRevocation     bool          // Check locally verified tokens against the User Service's blacklist in Redis
 }
 
 // SecurityConfig contains security middleware settings
 type SecurityConfig struct {
 	RateLimit      SecurityRateLimitConfig
+	UserRateLimit  UserRateLimitConfig
+	RouteRateLimit RouteRateLimitConfig
 	CORS           CORSConfig
 	RequestTimeout time.Duration
+	RouteTimeouts  map[string]time.Duration // Per-route-group budgets replacing RequestTimeout, keyed by TimeoutGroup*
+	DeadlineBuffer time.Duration            // Taken off the request's remaining time for the deadline of backend calls
 }
 
 // SecurityRateLimitConfig contains rate limiting settings for security middleware
@@ -35,10 +62,52 @@ type SecurityRateLimitConfig struct {
 	Enabled           bool
 }
 
+// UserRateLimitConfig contains per-user rate limits, applied after the per-IP limit
+type UserRateLimitConfig struct {
+	Enabled       bool
+	Anonymous     RateLimitTierConfig // Keyed on client IP
+	Authenticated RateLimitTierConfig // Keyed on user ID
+	Admin         RateLimitTierConfig // Keyed on user ID
+}
+
+// Route groups with their own rate limit
+const (
+	RouteGroupAuthLogin = "auth_login" // POST /auth/login, PUT /users/me/password, /users/me/2fa/confirm and /disable
+	RouteGroupAuth      = "auth"       // POST /auth/register, /auth/refresh
+	RouteGroupPayments  = "payments"   // /payments, /payment-methods, /subscriptions
+)
+
+// Route groups with their own request timeout
+const (
+	TimeoutGroupCatalog  = "catalog"  // /products, /categories, /inventory
+	TimeoutGroupPayments = "payments" // /payments, /payment-methods, /subscriptions
+)
+
+// RouteRateLimitConfig contains per-route-group limits, applied in addition to the
+// global and per-user limits. Each group is keyed per caller like the per-user limit.
+type RouteRateLimitConfig struct {
+	Enabled bool
+	Groups  map[string]RateLimitTierConfig
+}
+
+// RateLimitTierConfig is a token bucket rate and burst
+type RateLimitTierConfig struct {
+	RequestsPerSecond float64
+	BurstSize         int
+}
+
 // CORSConfig contains CORS settings
 type CORSConfig struct {
-	AllowedOrigins []string
-	Enabled        bool
+	AllowedOrigins   []string
+	Enabled          bool
+	AllowedMethods   []string
+	AllowedHeaders   []string
+	ExposeHeaders    []string
+	AllowCredentials bool
+	MaxAge           time.Duration
+	// Per-origin overrides of the allowed methods and headers
+	OriginMethods map[string][]string
+	OriginHeaders map[string][]string
 }
 
 // RateLimitConfig contains rate limiting settings
@@ -48,6 +117,21 @@ type RateLimitConfig struct {
 	BurstSize      int
 }
 
+// IdempotencyConfig contains Idempotency-Key handling settings (requires Redis)
+type IdempotencyConfig struct {
+	Enabled bool
+	TTL     time.Duration // How long a response is replayed for the same key
+	LockTTL time.Duration // How long an in-flight request holds its key at most
+}
+
+// HealthConfig contains readiness check settings
+type HealthConfig struct {
+	// Backends the gateway cannot serve without; the others only degrade it
+	CriticalServices []string
+	// Bounds each backend's gRPC health check on /health/services
+	CheckTimeout time.Duration
+}
+
 // ExternalConfig contains external API configurations
 type ExternalConfig struct {
 	Stripe StripeConfig
@@ -109,6 +193,54 @@ func Load() (*Config, error) {
 			},
 		},
 		Security: LoadSecurityConfig(),
+		Redis:    sharedConfig.LoadRedisConfig(),
+		RabbitMQ: sharedConfig.LoadRabbitMQConfig(),
+		Idempotency: IdempotencyConfig{
+			Enabled: sharedConfig.GetEnvAsBool("IDEMPOTENCY_ENABLED", true),
+			TTL:     sharedConfig.GetEnvAsDurationHours("IDEMPOTENCY_TTL_HOURS", 24*time.Hour),
+			LockTTL: sharedConfig.GetEnvAsDuration("IDEMPOTENCY_LOCK_TTL", time.Minute),
+		},
+		Health: HealthConfig{
+			CriticalServices: getEnvAsList("HEALTH_CRITICAL_SERVICES", []string{"user-service", "product-service", "order-service"}),
+			CheckTimeout:     sharedConfig.GetEnvAsDuration("HEALTH_CHECK_TIMEOUT", time.Second),
+		},
+		TokenAuth: TokenAuthConfig{
+			LocalVerification:   sharedConfig.GetEnvAsBool("AUTH_LOCAL_VERIFICATION", false),
+			JWKSURL:             sharedConfig.GetEnv("AUTH_JWKS_URL", ""),
+			JWKSRefreshInterval: sharedConfig.GetEnvAsDuration("AUTH_JWKS_REFRESH_INTERVAL", 5*time.Minute), // seconds
+			CheckRevocation:     sharedConfig.GetEnvAsBool("AUTH_LOCAL_CHECK_REVOCATION", true),
+		},
+		Maintenance: MaintenanceConfig{
+			Enabled:         sharedConfig.GetEnvAsBool("MAINTENANCE_MODE", false),
+			Message:         sharedConfig.GetEnv("MAINTENANCE_MESSAGE", "We are performing scheduled maintenance. Browsing still works; please try again shortly."),
+			RetryAfter:      sharedConfig.GetEnvAsDuration("MAINTENANCE_RETRY_AFTER", 5*time.Minute),      // seconds
+			RefreshInterval: sharedConfig.GetEnvAsDuration("MAINTENANCE_REFRESH_INTERVAL", 2*time.Second), // seconds
+		},
+	}
+
+	v := sharedConfig.NewValidator(cfg.Service)
+	v.CheckServer(cfg.Server)
+	v.CheckRedis(cfg.Redis)
+	v.CheckRabbitMQ(cfg.RabbitMQ)
+	v.CheckAuth(cfg.Auth)
+	cors := cfg.Security.CORS
+	v.Check(!cors.AllowCredentials || !slices.Contains(cors.AllowedOrigins, "*"),
+		"CORS_ALLOW_CREDENTIALS must not be true when CORS_ALLOWED_ORIGINS contains *")
+	v.Check(cors.MaxAge >= 0, "CORS_MAX_AGE must not be negative")
+	v.Check(cfg.Health.CheckTimeout > 0, "HEALTH_CHECK_TIMEOUT must be positive")
+	v.Check(cfg.Security.DeadlineBuffer >= 0 && cfg.Security.DeadlineBuffer < cfg.Security.RequestTimeout,
+		"GRPC_DEADLINE_BUFFER_MS must not be negative and must be shorter than SECURITY_REQUEST_TIMEOUT")
+	for _, group := range []string{TimeoutGroupCatalog, TimeoutGroupPayments} {
+		v.Check(cfg.Security.RouteTimeouts[group] > cfg.Security.DeadlineBuffer,
+			"ROUTE_TIMEOUT_"+strings.ToUpper(group)+" must be longer than GRPC_DEADLINE_BUFFER_MS")
+	}
+	v.Check(cfg.TokenAuth.JWKSURL == "" || cfg.TokenAuth.JWKSRefreshInterval > 0, "AUTH_JWKS_REFRESH_INTERVAL must be positive")
+	v.Check(!cfg.TokenAuth.LocalVerification || !cfg.TokenAuth.CheckRevocation || cfg.Redis.Enabled,
+		"AUTH_LOCAL_CHECK_REVOCATION needs REDIS_ENABLED; set AUTH_LOCAL_CHECK_REVOCATION=false to accept revoked tokens until they expire")
+	v.Check(cfg.Maintenance.RetryAfter > 0, "MAINTENANCE_RETRY_AFTER must be positive")
+	v.Check(cfg.Maintenance.RefreshInterval > 0, "MAINTENANCE_REFRESH_INTERVAL must be positive")
+	if err := v.Err(); err != nil {
+		return nil, err
 	}
 
 	return cfg, nil
@@ -123,29 +255,93 @@ func LoadSecurityConfig() SecurityConfig {
 		}
 	}
 
-	// Load CORS origins from environment
-	corsOrigins := []string{"http://localhost:3000", "http://localhost:8080"}
-	if corsEnv := sharedConfig.GetEnv("CORS_ALLOWED_ORIGINS", ""); corsEnv != "" {
-		origins := strings.Split(corsEnv, ",")
-		corsOrigins = make([]string, 0, len(origins))
-		for _, origin := range origins {
-			if trimmed := strings.TrimSpace(origin); trimmed != "" {
-				corsOrigins = append(corsOrigins, trimmed)
-			}
-		}
-	}
-
 	return SecurityConfig{
 		RateLimit: SecurityRateLimitConfig{
 			Enabled:           sharedConfig.GetEnvAsBool("SECURITY_RATE_LIMIT_ENABLED", true),
 			RequestsPerSecond: rateLimitRPS,
 			BurstSize:         sharedConfig.GetEnvAsInt("SECURITY_RATE_LIMIT_BURST", 100),
 		},
+		UserRateLimit: UserRateLimitConfig{
+			Enabled:       sharedConfig.GetEnvAsBool("USER_RATE_LIMIT_ENABLED", true),
+			Anonymous:     loadRateLimitTier("USER_RATE_LIMIT_ANONYMOUS", 5, 20),
+			Authenticated: loadRateLimitTier("USER_RATE_LIMIT_AUTHENTICATED", 20, 50),
+			Admin:         loadRateLimitTier("USER_RATE_LIMIT_ADMIN", 50, 100),
+		},
+		RouteRateLimit: RouteRateLimitConfig{
+			Enabled: sharedConfig.GetEnvAsBool("ROUTE_RATE_LIMIT_ENABLED", true),
+			Groups: map[string]RateLimitTierConfig{
+				RouteGroupAuthLogin: loadRateLimitTier("ROUTE_RATE_LIMIT_AUTH_LOGIN", 0.2, 5),
+				RouteGroupAuth:      loadRateLimitTier("ROUTE_RATE_LIMIT_AUTH", 1, 10),
+				RouteGroupPayments:  loadRateLimitTier("ROUTE_RATE_LIMIT_PAYMENTS", 2, 10),
+			},
+		},
 		CORS: CORSConfig{
 			Enabled:        sharedConfig.GetEnvAsBool("SECURITY_CORS_ENABLED", true),
-			AllowedOrigins: corsOrigins,
+			AllowedOrigins: getEnvAsList("CORS_ALLOWED_ORIGINS", []string{"http://localhost:3000", "http://localhost:8080"}),
+			AllowedMethods: getEnvAsList("CORS_ALLOWED_METHODS", []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"}),
+			AllowedHeaders: getEnvAsList("CORS_ALLOWED_HEADERS", []string{"Content-Type", "Authorization", "Idempotency-Key"}),
+			ExposeHeaders:  getEnvAsList("CORS_EXPOSE_HEADERS", []string{"Retry-After", "Idempotent-Replayed"}),
+			// Off by default: the gateway authenticates with bearer tokens, not cookies
+			AllowCredentials: sharedConfig.GetEnvAsBool("CORS_ALLOW_CREDENTIALS", false),
+			MaxAge:           sharedConfig.GetEnvAsDuration("CORS_MAX_AGE", 24*time.Hour),
+			OriginMethods:    getEnvAsOriginLists("CORS_ORIGIN_METHODS"),
+			OriginHeaders:    getEnvAsOriginLists("CORS_ORIGIN_HEADERS"),
 		},
 		RequestTimeout: sharedConfig.GetEnvAsDuration("SECURITY_REQUEST_TIMEOUT", 30*time.Second),
+		RouteTimeouts: map[string]time.Duration{
+			// Catalog reads are single lookups; fail fast instead of holding connections
+			TimeoutGroupCatalog: sharedConfig.GetEnvAsDuration("ROUTE_TIMEOUT_CATALOG", 10*time.Second),
+			// Payment confirmation waits on the payment provider
+			TimeoutGroupPayments: sharedConfig.GetEnvAsDuration("ROUTE_TIMEOUT_PAYMENTS", 60*time.Second),
+		},
+		DeadlineBuffer: time.Duration(sharedConfig.GetEnvAsInt("GRPC_DEADLINE_BUFFER_MS", 100)) * time.Millisecond,
+	}
+}
+
+// getEnvAsList reads a comma-separated list, ignoring empty entries
+func getEnvAsList(key string, defaultValue []string) []string {
+	value := sharedConfig.GetEnv(key, "")
+	if value == "" {
+		return defaultValue
+	}
+	var list []string
+	for _, item := range strings.Split(value, ",") {
+		if trimmed := strings.TrimSpace(item); trimmed != "" {
+			list = append(list, trimmed)
+		}
+	}
+	return list
+}
+
+// getEnvAsOriginLists reads per-origin lists formatted as
+// "https://admin.example.com=GET,PATCH;https://partner.example.com=GET"
+func getEnvAsOriginLists(key string) map[string][]string {
+	lists := make(map[string][]string)
+	for _, entry := range strings.Split(sharedConfig.GetEnv(key, ""), ";") {
+		origin, values, ok := strings.Cut(entry, "=")
+		origin = strings.TrimSpace(origin)
+		if !ok || origin == "" {
+			continue
+		}
+		for _, value := range strings.Split(values, ",") {
+			if trimmed := strings.TrimSpace(value); trimmed != "" {
+				lists[origin] = append(lists[origin], trimmed)
+			}
+		}
+	}
+	return lists
+}
+
+// loadRateLimitTier reads <prefix>_RPS and <prefix>_BURST
+func loadRateLimitTier(prefix string, defaultRPS float64, defaultBurst int) RateLimitTierConfig {
+	rps := defaultRPS
+	if parsed, err := strconv.ParseFloat(sharedConfig.GetEnv(prefix+"_RPS", ""), 64); err == nil && parsed > 0 {
+		rps = parsed
+	}
+
+	return RateLimitTierConfig{
+		RequestsPerSecond: rps,
+		BurstSize:         sharedConfig.GetEnvAsInt(prefix+"_BURST", defaultBurst),
 	}
 }
 
@@ -164,6 +360,12 @@ func (c *Config) GetServerAddress() string {
 	return c.Server.Host + ":" + c.Server.HTTPPort
 }
 
+// GetRabbitMQURL returns the RabbitMQ connection URL
+func (c *Config) GetRabbitMQURL() string {
+	base := sharedConfig.Config{RabbitMQ: c.RabbitMQ}
+	return base.GetRabbitMQURL()
+}
+
 // PrintConfig prints the configuration
 func (c *Config) PrintConfig() {
 	baseConfig := sharedConfig.Config{
@@ -172,6 +374,8 @@ func (c *Config) PrintConfig() {
 		Services: c.Services,
 		Auth:     c.Auth,
 		Logging:  c.Logging,
+		Redis:    c.Redis,
+		RabbitMQ: c.RabbitMQ,
 	}
 	baseConfig.PrintConfig()
 
@@ -181,8 +385,42 @@ func (c *Config) PrintConfig() {
 	fmt.Printf("    Enabled: %v\n", c.Security.RateLimit.Enabled)
 	fmt.Printf("    Requests/Second: %.2f\n", c.Security.RateLimit.RequestsPerSecond)
 	fmt.Printf("    Burst Size: %d\n", c.Security.RateLimit.BurstSize)
+	fmt.Printf("  User Rate Limit:\n")
+	fmt.Printf("    Enabled: %v\n", c.Security.UserRateLimit.Enabled)
+	fmt.Printf("    Anonymous: %.2f/s (burst %d)\n", c.Security.UserRateLimit.Anonymous.RequestsPerSecond, c.Security.UserRateLimit.Anonymous.BurstSize)
+	fmt.Printf("    Authenticated: %.2f/s (burst %d)\n", c.Security.UserRateLimit.Authenticated.RequestsPerSecond, c.Security.UserRateLimit.Authenticated.BurstSize)
+	fmt.Printf("    Admin: %.2f/s (burst %d)\n", c.Security.UserRateLimit.Admin.RequestsPerSecond, c.Security.UserRateLimit.Admin.BurstSize)
+	fmt.Printf("  Route Rate Limit:\n")
+	fmt.Printf("    Enabled: %v\n", c.Security.RouteRateLimit.Enabled)
+	for _, group := range []string{RouteGroupAuthLogin, RouteGroupAuth, RouteGroupPayments} {
+		tier := c.Security.RouteRateLimit.Groups[group]
+		fmt.Printf("    %s: %.2f/s (burst %d)\n", group, tier.RequestsPerSecond, tier.BurstSize)
+	}
 	fmt.Printf("  CORS:\n")
 	fmt.Printf("    Enabled: %v\n", c.Security.CORS.Enabled)
 	fmt.Printf("    Allowed Origins: %v\n", c.Security.CORS.AllowedOrigins)
+	fmt.Printf("    Allowed Methods: %v\n", c.Security.CORS.AllowedMethods)
+	fmt.Printf("    Allow Credentials: %v\n", c.Security.CORS.AllowCredentials)
 	fmt.Printf("  Request Timeout: %v\n", c.Security.RequestTimeout)
+	for _, group := range []string{TimeoutGroupCatalog, TimeoutGroupPayments} {
+		fmt.Printf("    %s: %v\n", group, c.Security.RouteTimeouts[group])
+	}
+	fmt.Printf("  gRPC Deadline Buffer: %v\n", c.Security.DeadlineBuffer)
+	fmt.Printf("Token Auth:\n")
+	if c.TokenAuth.LocalVerification {
+		fmt.Printf("  Verification: local\n")
+		if c.TokenAuth.JWKSURL != "" {
+			fmt.Printf("  JWKS: %s (refreshed every %v)\n", c.TokenAuth.JWKSURL, c.TokenAuth.JWKSRefreshInterval)
+		}
+		fmt.Printf("  Revocation Check: %v\n", c.TokenAuth.CheckRevocation)
+	} else {
+		fmt.Printf("  Verification: remote (User Service)\n")
+	}
+	fmt.Printf("Maintenance:\n")
+	fmt.Printf("  Enabled at Startup: %v\n", c.Maintenance.Enabled)
+	fmt.Printf("  Retry-After: %v\n", c.Maintenance.RetryAfter)
+	fmt.Printf("Idempotency:\n")
+	fmt.Printf("  Enabled: %v\n", c.Idempotency.Enabled)
+	fmt.Printf("  TTL: %v\n", c.Idempotency.TTL)
+	fmt.Printf("  Lock TTL: %v\n", c.Idempotency.LockTTL)
 }
@@ -0,0 +1,224 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// Order events published by order-service
+const (
+	OrderExchange           = "ecommerce.orders"
+	EventOrderCreated       = "order.created"
+	EventOrderStatusChanged = "order.status.changed"
+	EventOrderCancelled     = "order.cancelled"
+
+	reconnectDelay   = 5 * time.Second
+	subscriberBuffer = 8 // Events kept for a slow client before they are dropped
+)
+
+// OrderStatusEvent is a status change of one order, as pushed to clients
+type OrderStatusEvent struct {
+	OrderID   string    `json:"order_id"`
+	UserID    int64     `json:"user_id"`
+	Status    string    `json:"status"`
+	Reason    string    `json:"reason,omitempty"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// orderEventMessage covers the fields used from order.created, order.status.changed and order.cancelled
+type orderEventMessage struct {
+	OrderID     string    `json:"order_id"`
+	UserID      int64     `json:"user_id"`
+	NewStatus   string    `json:"new_status"`
+	Reason      string    `json:"reason"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+	CancelledAt time.Time `json:"cancelled_at"`
+}
+
+// OrderEventHub consumes order events from RabbitMQ and fans them out to subscribers by order ID.
+// Each gateway instance gets its own exclusive queue, deleted by RabbitMQ when the instance disconnects.
+type OrderEventHub struct {
+	amqpURL string
+
+	mu          sync.Mutex
+	subscribers map[string]map[chan OrderStatusEvent]struct{}
+}
+
+// NewOrderEventHub creates a hub; call Run to start consuming
+func NewOrderEventHub(amqpURL string) *OrderEventHub {
+	return &OrderEventHub{
+		amqpURL:     amqpURL,
+		subscribers: make(map[string]map[chan OrderStatusEvent]struct{}),
+	}
+}
+
+// Subscribe returns a channel receiving status changes of orderID.
+// The caller must call unsubscribe when done; it closes the channel.
+func (h *OrderEventHub) Subscribe(orderID string) (<-chan OrderStatusEvent, func()) {
+	ch := make(chan OrderStatusEvent, subscriberBuffer)
+
+	h.mu.Lock()
+	if h.subscribers[orderID] == nil {
+		h.subscribers[orderID] = make(map[chan OrderStatusEvent]struct{})
+	}
+	h.subscribers[orderID][ch] = struct{}{}
+	h.mu.Unlock()
+
+	var once sync.Once
+	unsubscribe := func() {
+		once.Do(func() {
+			h.mu.Lock()
+			delete(h.subscribers[orderID], ch)
+			if len(h.subscribers[orderID]) == 0 {
+				delete(h.subscribers, orderID)
+			}
+			h.mu.Unlock()
+			close(ch)
+		})
+	}
+
+	return ch, unsubscribe
+}
+
+// publish delivers event to the subscribers of its order without blocking on slow clients
+func (h *OrderEventHub) publish(event OrderStatusEvent) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for ch := range h.subscribers[event.OrderID] {
+		select {
+		case ch <- event:
+		default:
+			log.Printf("Dropping status event for order %s: subscriber is not keeping up", event.OrderID)
+		}
+	}
+}
+
+// Run consumes order events until ctx is cancelled, reconnecting after connection loss
+func (h *OrderEventHub) Run(ctx context.Context) {
+	for {
+		if err := h.consume(ctx); err != nil {
+			log.Printf("Order event stream interrupted: %v (retrying in %v)", err, reconnectDelay)
+		}
+
+		select {
+		case <-ctx.Done():
+			log.Println("Order event hub stopped")
+			return
+		case <-time.After(reconnectDelay):
+		}
+	}
+}
+
+// consume runs one RabbitMQ session; it returns when ctx is done or the connection drops
+func (h *OrderEventHub) consume(ctx context.Context) error {
+	conn, err := amqp.Dial(h.amqpURL)
+	if err != nil {
+		return fmt.Errorf("failed to connect to RabbitMQ: %w", err)
+	}
+	defer conn.Close()
+
+	channel, err := conn.Channel()
+	if err != nil {
+		return fmt.Errorf("failed to open channel: %w", err)
+	}
+	defer channel.Close()
+
+	err = channel.ExchangeDeclare(
+		OrderExchange,
+		"topic",
+		true,  // durable
+		false, // auto-deleted
+		false, // internal
+		false, // no-wait
+		nil,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to declare exchange: %w", err)
+	}
+
+	// Server-named, exclusive queue: only live streams on this instance care about these events
+	queue, err := channel.QueueDeclare(
+		"",
+		false, // durable
+		true,  // auto-delete
+		true,  // exclusive
+		false, // no-wait
+		nil,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to declare queue: %w", err)
+	}
+
+	for _, routingKey := range []string{EventOrderCreated, EventOrderStatusChanged, EventOrderCancelled} {
+		if err := channel.QueueBind(queue.Name, routingKey, OrderExchange, false, nil); err != nil {
+			return fmt.Errorf("failed to bind %s: %w", routingKey, err)
+		}
+	}
+
+	msgs, err := channel.Consume(
+		queue.Name,
+		"",
+		true,  // auto-ack: events for streams that are gone are not worth redelivering
+		true,  // exclusive
+		false, // no-local
+		false, // no-wait
+		nil,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to start consuming: %w", err)
+	}
+
+	log.Println("Order event hub consuming order status events")
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case msg, ok := <-msgs:
+			if !ok {
+				return fmt.Errorf("delivery channel closed")
+			}
+			if event, ok := parseOrderEvent(msg.RoutingKey, msg.Body); ok {
+				h.publish(event)
+			}
+		}
+	}
+}
+
+// parseOrderEvent converts an order-service event into the status pushed to clients
+func parseOrderEvent(routingKey string, body []byte) (OrderStatusEvent, bool) {
+	var msg orderEventMessage
+	if err := json.Unmarshal(body, &msg); err != nil {
+		log.Printf("Failed to unmarshal %s event: %v", routingKey, err)
+		return OrderStatusEvent{}, false
+	}
+
+	event := OrderStatusEvent{OrderID: msg.OrderID, UserID: msg.UserID}
+	switch routingKey {
+	case EventOrderCreated:
+		event.Status = "pending"
+		event.UpdatedAt = msg.CreatedAt
+	case EventOrderStatusChanged:
+		event.Status = msg.NewStatus
+		event.UpdatedAt = msg.UpdatedAt
+	case EventOrderCancelled:
+		event.Status = "cancelled"
+		event.Reason = msg.Reason
+		event.UpdatedAt = msg.CancelledAt
+	default:
+		return OrderStatusEvent{}, false
+	}
+
+	if event.OrderID == "" || event.Status == "" {
+		return OrderStatusEvent{}, false
+	}
+	return event, true
+}
@@ -0,0 +1,40 @@
+package events
+
+import "testing"
+
+func TestOrderEventHubDeliversToOrderSubscribers(t *testing.T) {
+	hub := NewOrderEventHub("")
+	updates, unsubscribe := hub.Subscribe("order-1")
+	other, unsubscribeOther := hub.Subscribe("order-2")
+	defer unsubscribeOther()
+
+	event, ok := parseOrderEvent(EventOrderStatusChanged, []byte(`{"order_id":"order-1","user_id":7,"new_status":"shipped"}`))
+	if !ok {
+		t.Fatal("status changed event was not parsed")
+	}
+	hub.publish(event)
+
+	select {
+	case got := <-updates:
+		if got.Status != "shipped" || got.UserID != 7 {
+			t.Fatalf("got %+v", got)
+		}
+	default:
+		t.Fatal("subscriber did not receive the event")
+	}
+	select {
+	case got := <-other:
+		t.Fatalf("subscriber of another order received %+v", got)
+	default:
+	}
+
+	unsubscribe()
+	if _, open := <-updates; open {
+		t.Fatal("unsubscribe did not close the channel")
+	}
+	hub.publish(event) // Must not panic on the closed channel
+
+	if cancelled, _ := parseOrderEvent(EventOrderCancelled, []byte(`{"order_id":"order-2","reason":"User cancelled"}`)); cancelled.Status != "cancelled" {
+		t.Fatalf("cancelled event status = %q", cancelled.Status)
+	}
+}
@@ -1,42 +1,158 @@
 package handler
 
 import (
+	"context"
 	"net/http"
+	"sort"
+	"time"
 
 	"github.com/datngth03/ecommerce-go-app/services/api-gateway/internal/clients"
+	"github.com/datngth03/ecommerce-go-app/shared/pkg/grpcpool"
 	"github.com/gin-gonic/gin"
 )
 
 // HealthHandler handles health check endpoints
 type HealthHandler struct {
-	clients *clients.Clients
+	poolStats     func() map[string]*grpcpool.PoolStats
+	checkServices func(ctx context.Context, timeout time.Duration) map[string]clients.ServiceHealth
+	critical      []string
+	checkTimeout  time.Duration
 }
 
-// NewHealthHandler creates a new health handler
-func NewHealthHandler(clients *clients.Clients) *HealthHandler {
+// NewHealthHandler creates a new health handler. The gateway is ready only while
+// every critical service is reachable; the other backends are optional.
+// checkTimeout bounds each backend's gRPC health check.
+func NewHealthHandler(clients *clients.Clients, criticalServices []string, checkTimeout time.Duration) *HealthHandler {
 	return &HealthHandler{
-		clients: clients,
+		poolStats:     clients.GetPoolStats,
+		checkServices: clients.CheckServicesHealth,
+		critical:      criticalServices,
+		checkTimeout:  checkTimeout,
 	}
 }
 
-// HealthCheck returns basic health status
+// HealthCheck returns the gateway's health with the status of each backend.
+// It answers 200 while the gateway is running, even when backends are down.
 func (h *HealthHandler) HealthCheck(c *gin.Context) {
+	services, criticalDown, optionalDown := h.backendStatus()
+
+	status := "healthy"
+	if len(criticalDown) > 0 || len(optionalDown) > 0 {
+		status = "degraded"
+	}
 	c.JSON(http.StatusOK, gin.H{
-		"status":  "healthy",
-		"service": "api-gateway",
+		"status":   status,
+		"service":  "api-gateway",
+		"services": services,
 	})
 }
 
-// ReadinessCheck returns readiness status
+// ReadinessCheck returns 503 only when a critical backend is unreachable, so an
+// outage of an optional backend does not take the gateway out of rotation
 func (h *HealthHandler) ReadinessCheck(c *gin.Context) {
-	c.JSON(http.StatusOK, gin.H{
-		"status": "ready",
+	services, criticalDown, optionalDown := h.backendStatus()
+
+	if len(criticalDown) > 0 {
+		c.JSON(http.StatusServiceUnavailable, gin.H{
+			"status":      "not ready",
+			"unavailable": criticalDown,
+			"services":    services,
+		})
+		return
+	}
+
+	response := gin.H{
+		"status":   "ready",
+		"services": services,
+	}
+	if len(optionalDown) > 0 {
+		response["degraded"] = optionalDown
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// backendStatus reports each backend as "up" while its pool has a usable
+// connection, and lists the critical and optional backends that are down
+func (h *HealthHandler) backendStatus() (services map[string]gin.H, criticalDown, optionalDown []string) {
+	stats := h.poolStats()
+	isCritical := make(map[string]bool, len(h.critical))
+	for _, name := range h.critical {
+		isCritical[name] = true
+	}
+
+	services = make(map[string]gin.H, len(stats))
+	for name, stat := range stats {
+		status := "up"
+		if !stat.IsHealthy() {
+			status = "down"
+			if isCritical[name] {
+				criticalDown = append(criticalDown, name)
+			} else {
+				optionalDown = append(optionalDown, name)
+			}
+		}
+		services[name] = gin.H{"status": status, "critical": isCritical[name]}
+	}
+
+	// A critical service without a connection pool cannot serve requests either
+	for _, name := range h.critical {
+		if _, ok := stats[name]; !ok {
+			services[name] = gin.H{"status": "unavailable", "critical": true}
+			criticalDown = append(criticalDown, name)
+		}
+	}
+
+	sort.Strings(criticalDown)
+	sort.Strings(optionalDown)
+	return services, criticalDown, optionalDown
+}
+
+// ServicesHealth handles GET /health/services: it runs every backend's gRPC health
+// check concurrently and reports each status with its latency. It answers 503
+// only when a critical service is not serving, like ReadinessCheck.
+func (h *HealthHandler) ServicesHealth(c *gin.Context) {
+	results := h.checkServices(c.Request.Context(), h.checkTimeout)
+	isCritical := make(map[string]bool, len(h.critical))
+	for _, name := range h.critical {
+		isCritical[name] = true
+	}
+
+	overall := "healthy"
+	services := make(map[string]gin.H, len(results))
+	for name, result := range results {
+		service := gin.H{
+			"status":     result.Status,
+			"latency_ms": float64(result.Latency.Microseconds()) / 1000,
+			"critical":   isCritical[name],
+		}
+		if result.Error != "" {
+			service["error"] = result.Error
+		}
+		services[name] = service
+
+		if result.Status != "SERVING" && overall == "healthy" {
+			overall = "degraded"
+		}
+	}
+	for _, name := range h.critical {
+		if result, ok := results[name]; !ok || result.Status != "SERVING" {
+			overall = "unhealthy"
+		}
+	}
+
+	httpStatus := http.StatusOK
+	if overall == "unhealthy" {
+		httpStatus = http.StatusServiceUnavailable
+	}
+	c.JSON(httpStatus, gin.H{
+		"status":   overall,
+		"services": services,
 	})
 }
 
 // PoolsHealth returns connection pool health statistics
 func (h *HealthHandler) PoolsHealth(c *gin.Context) {
-	stats := h.clients.GetPoolStats()
+	stats := h.poolStats()
 
 	if stats == nil {
 		c.JSON(http.StatusServiceUnavailable, gin.H{
@@ -92,7 +208,7 @@ func (h *HealthHandler) PoolsHealth(c *gin.Context) {
 
 // DetailedPoolsHealth returns detailed connection pool statistics
 func (h *HealthHandler) DetailedPoolsHealth(c *gin.Context) {
-	stats := h.clients.GetPoolStats()
+	stats := h.poolStats()
 
 	if stats == nil {
 		c.JSON(http.StatusServiceUnavailable, gin.H{
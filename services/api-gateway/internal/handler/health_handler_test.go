@@ -0,0 +1,158 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/datngth03/ecommerce-go-app/services/api-gateway/internal/clients"
+	"github.com/datngth03/ecommerce-go-app/shared/pkg/grpcpool"
+	"github.com/gin-gonic/gin"
+)
+
+func TestReadinessDistinguishesCriticalBackends(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	up := &grpcpool.PoolStats{PoolSize: 2, ReadyCount: 2}
+	down := &grpcpool.PoolStats{PoolSize: 2, FailureCount: 2}
+
+	tests := []struct {
+		name            string
+		stats           map[string]*grpcpool.PoolStats
+		wantStatus      int
+		wantUnavailable []string
+		wantDegraded    []string
+	}{
+		{
+			name:       "all up",
+			stats:      map[string]*grpcpool.PoolStats{"user-service": up, "order-service": up, "notification-service": up},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:         "optional backend down",
+			stats:        map[string]*grpcpool.PoolStats{"user-service": up, "order-service": up, "notification-service": down},
+			wantStatus:   http.StatusOK,
+			wantDegraded: []string{"notification-service"},
+		},
+		{
+			name:            "critical backend down",
+			stats:           map[string]*grpcpool.PoolStats{"user-service": up, "order-service": down, "notification-service": down},
+			wantStatus:      http.StatusServiceUnavailable,
+			wantUnavailable: []string{"order-service"},
+		},
+		{
+			name:            "critical backend without a pool",
+			stats:           map[string]*grpcpool.PoolStats{"user-service": up},
+			wantStatus:      http.StatusServiceUnavailable,
+			wantUnavailable: []string{"order-service"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &HealthHandler{
+				poolStats: func() map[string]*grpcpool.PoolStats { return tt.stats },
+				critical:  []string{"user-service", "order-service"},
+			}
+			router := gin.New()
+			router.GET("/health", h.HealthCheck)
+			router.GET("/ready", h.ReadinessCheck)
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/ready", nil))
+			if w.Code != tt.wantStatus {
+				t.Fatalf("readiness status = %d, want %d (body %s)", w.Code, tt.wantStatus, w.Body)
+			}
+			var ready struct {
+				Unavailable []string `json:"unavailable"`
+				Degraded    []string `json:"degraded"`
+			}
+			if err := json.Unmarshal(w.Body.Bytes(), &ready); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(ready.Unavailable, tt.wantUnavailable) || !reflect.DeepEqual(ready.Degraded, tt.wantDegraded) {
+				t.Errorf("unavailable %v, degraded %v; want %v, %v", ready.Unavailable, ready.Degraded, tt.wantUnavailable, tt.wantDegraded)
+			}
+
+			// Health lists every backend and stays 200 for liveness probes
+			w = httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/health", nil))
+			var health struct {
+				Services map[string]struct {
+					Status   string `json:"status"`
+					Critical bool   `json:"critical"`
+				} `json:"services"`
+			}
+			if err := json.Unmarshal(w.Body.Bytes(), &health); err != nil {
+				t.Fatal(err)
+			}
+			if w.Code != http.StatusOK || len(health.Services) < len(tt.stats) {
+				t.Errorf("health status %d, services %v", w.Code, health.Services)
+			}
+			if s := health.Services["user-service"]; s.Status != "up" || !s.Critical {
+				t.Errorf("user-service = %+v, want up and critical", s)
+			}
+		})
+	}
+}
+
+func TestServicesHealth(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	serving := clients.ServiceHealth{Status: "SERVING", Latency: 1500 * time.Microsecond}
+	unreachable := clients.ServiceHealth{Status: clients.StatusUnreachable, Latency: time.Second, Error: "connection refused"}
+
+	tests := []struct {
+		name       string
+		results    map[string]clients.ServiceHealth
+		wantCode   int
+		wantStatus string
+	}{
+		{"all serving", map[string]clients.ServiceHealth{"user-service": serving, "payment-service": serving}, http.StatusOK, "healthy"},
+		{"optional down", map[string]clients.ServiceHealth{"user-service": serving, "payment-service": unreachable}, http.StatusOK, "degraded"},
+		{"critical down", map[string]clients.ServiceHealth{"user-service": unreachable, "payment-service": serving}, http.StatusServiceUnavailable, "unhealthy"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotTimeout time.Duration
+			h := &HealthHandler{
+				checkServices: func(_ context.Context, timeout time.Duration) map[string]clients.ServiceHealth {
+					gotTimeout = timeout
+					return tt.results
+				},
+				critical:     []string{"user-service"},
+				checkTimeout: 500 * time.Millisecond,
+			}
+			router := gin.New()
+			router.GET("/health/services", h.ServicesHealth)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/health/services", nil))
+
+			var resp struct {
+				Status   string `json:"status"`
+				Services map[string]struct {
+					Status    string  `json:"status"`
+					LatencyMs float64 `json:"latency_ms"`
+					Error     string  `json:"error"`
+				} `json:"services"`
+			}
+			if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+				t.Fatal(err)
+			}
+			if w.Code != tt.wantCode || resp.Status != tt.wantStatus {
+				t.Errorf("got %d %q, want %d %q", w.Code, resp.Status, tt.wantCode, tt.wantStatus)
+			}
+			if gotTimeout != h.checkTimeout {
+				t.Errorf("checks ran with timeout %v, want %v", gotTimeout, h.checkTimeout)
+			}
+			for name, want := range tt.results {
+				got := resp.Services[name]
+				if got.Status != want.Status || got.Error != want.Error || got.LatencyMs != float64(want.Latency.Microseconds())/1000 {
+					t.Errorf("%s = %+v, want %+v", name, got, want)
+				}
+			}
+		})
+	}
+}
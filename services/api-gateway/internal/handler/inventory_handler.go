@@ -22,6 +22,7 @@ func NewInventoryHandler(inventoryClient *clients.InventoryClient) *InventoryHan
 }
 
 // GetStock handles GET /api/v1/inventory/:product_id
+// ?variant_id= returns the stock of one variant of the product.
 func (h *InventoryHandler) GetStock(c *gin.Context) {
 	productID := c.Param("product_id")
 	if productID == "" {
@@ -32,6 +33,7 @@ func (h *InventoryHandler) GetStock(c *gin.Context) {
 	start := time.Now()
 	resp, err := h.inventoryClient.GetStock(c.Request.Context(), &pb.GetStockRequest{
 		ProductId: productID,
+		VariantId: c.Query("variant_id"),
 	})
 
 	status := "success"
@@ -58,12 +60,12 @@ func (h *InventoryHandler) UpdateStock(c *gin.Context) {
 	}
 
 	var req struct {
-		Quantity int32  `json:"quantity" binding:"required"`
-		Reason   string `json:"reason"`
+		Quantity  int32  `json:"quantity" binding:"required"`
+		Reason    string `json:"reason"`
+		VariantID string `json:"variant_id"` // Optional, updates the variant's stock
 	}
 
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if !bindJSON(c, &req) {
 		return
 	}
 
@@ -71,10 +73,11 @@ func (h *InventoryHandler) UpdateStock(c *gin.Context) {
 		ProductId: productID,
 		Quantity:  req.Quantity,
 		Reason:    req.Reason,
+		VariantId: req.VariantID,
 	})
 
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		handleGRPCError(c, err)
 		return
 	}
 
@@ -89,12 +92,12 @@ func (h *InventoryHandler) CheckAvailability(c *gin.Context) {
 	var req struct {
 		Items []struct {
 			ProductID string `json:"product_id" binding:"required"`
+			VariantID string `json:"variant_id"`
 			Quantity  int32  `json:"quantity" binding:"required,min=1"`
-		} `json:"items" binding:"required,min=1"`
+		} `json:"items" binding:"required,min=1,dive"`
 	}
 
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if !bindJSON(c, &req) {
 		return
 	}
 
@@ -103,6 +106,7 @@ func (h *InventoryHandler) CheckAvailability(c *gin.Context) {
 	for i, item := range req.Items {
 		items[i] = &pb.StockItem{
 			ProductId: item.ProductID,
+			VariantId: item.VariantID,
 			Quantity:  item.Quantity,
 		}
 	}
@@ -112,7 +116,7 @@ func (h *InventoryHandler) CheckAvailability(c *gin.Context) {
 	})
 
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		handleGRPCError(c, err)
 		return
 	}
 
@@ -134,14 +138,22 @@ func (h *InventoryHandler) GetStockHistory(c *gin.Context) {
 	page, _ := strconv.ParseInt(c.DefaultQuery("page", "1"), 10, 32)
 	pageSize, _ := strconv.ParseInt(c.DefaultQuery("page_size", "20"), 10, 32)
 
+	if page < 1 {
+		page = 1
+	}
+
 	resp, err := h.inventoryClient.GetStockHistory(c.Request.Context(), &pb.GetStockHistoryRequest{
-		ProductId: productID,
-		Limit:     int32(pageSize),
-		Offset:    int32((page - 1) * pageSize),
+		ProductId:    productID,
+		VariantId:    c.Query("variant_id"),
+		Limit:        int32(pageSize),
+		Offset:       int32((page - 1) * pageSize),
+		MovementType: c.Query("movement_type"),
+		StartDate:    c.Query("start_date"),
+		EndDate:      c.Query("end_date"),
 	})
 
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		handleGRPCError(c, err)
 		return
 	}
 
@@ -0,0 +1,34 @@
+package handler
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/datngth03/ecommerce-go-app/shared/pkg/logger"
+	"github.com/gin-gonic/gin"
+)
+
+// GetLogLevel handles GET /debug/loglevel
+func GetLogLevel(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"level": logger.Level()})
+}
+
+// SetLogLevel handles PUT /debug/loglevel. The change lasts until the gateway
+// restarts, which brings back LOG_LEVEL.
+func SetLogLevel(c *gin.Context) {
+	var req struct {
+		Level string `json:"level" binding:"required"`
+	}
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	previous := logger.Level()
+	if err := logger.SetLevel(req.Level); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	log.Printf("Log level changed from %s to %s by user %v", previous, logger.Level(), c.GetInt64("user_id"))
+
+	c.JSON(http.StatusOK, gin.H{"level": logger.Level()})
+}
@@ -0,0 +1,43 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/datngth03/ecommerce-go-app/shared/pkg/logger"
+	"github.com/gin-gonic/gin"
+)
+
+func TestSetLogLevel(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/debug/loglevel", GetLogLevel)
+	router.PUT("/debug/loglevel", SetLogLevel)
+	t.Cleanup(func() { logger.SetLevel("info") })
+
+	put := func(body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPut, "/debug/loglevel", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	if w := put(`{"level":"debug"}`); w.Code != http.StatusOK || logger.Level() != "debug" {
+		t.Fatalf("status %d, level %q; want 200 and debug", w.Code, logger.Level())
+	}
+	if w := put(`{"level":"verbose"}`); w.Code != http.StatusBadRequest || logger.Level() != "debug" {
+		t.Errorf("unknown level: status %d, level %q", w.Code, logger.Level())
+	}
+	if w := put(`{}`); w.Code != http.StatusUnprocessableEntity {
+		t.Errorf("missing level: status %d, want 422", w.Code)
+	}
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/debug/loglevel", nil))
+	if !strings.Contains(w.Body.String(), `"level":"debug"`) {
+		t.Errorf("GET body = %s", w.Body)
+	}
+}
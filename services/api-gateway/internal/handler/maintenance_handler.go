@@ -0,0 +1,77 @@
+package handler
+
+import (
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/datngth03/ecommerce-go-app/services/api-gateway/internal/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+// MaintenanceHandler reads and switches the gateway's maintenance mode
+type MaintenanceHandler struct {
+	mode *middleware.MaintenanceMode
+}
+
+// NewMaintenanceHandler creates a new maintenance handler
+func NewMaintenanceHandler(mode *middleware.MaintenanceMode) *MaintenanceHandler {
+	return &MaintenanceHandler{mode: mode}
+}
+
+type maintenanceResponse struct {
+	Enabled           bool   `json:"enabled"`
+	Message           string `json:"message"`
+	RetryAfterSeconds int    `json:"retry_after_seconds"`
+}
+
+func newMaintenanceResponse(state middleware.MaintenanceState) maintenanceResponse {
+	return maintenanceResponse{
+		Enabled:           state.Enabled,
+		Message:           state.Message,
+		RetryAfterSeconds: int(state.RetryAfter.Seconds()),
+	}
+}
+
+// GetMaintenance handles GET /debug/maintenance
+func (h *MaintenanceHandler) GetMaintenance(c *gin.Context) {
+	c.JSON(http.StatusOK, newMaintenanceResponse(h.mode.State(c.Request.Context())))
+}
+
+// SetMaintenance handles PUT /debug/maintenance. The setting is shared by all
+// gateway instances and overrides MAINTENANCE_MODE until it is reset.
+func (h *MaintenanceHandler) SetMaintenance(c *gin.Context) {
+	var req struct {
+		Enabled           *bool  `json:"enabled" binding:"required"`
+		Message           string `json:"message" binding:"max=500"`
+		RetryAfterSeconds int    `json:"retry_after_seconds" binding:"min=0,max=86400"`
+	}
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	state := middleware.MaintenanceState{
+		Enabled:    *req.Enabled,
+		Message:    req.Message,
+		RetryAfter: time.Duration(req.RetryAfterSeconds) * time.Second,
+	}
+	if err := h.mode.Set(c.Request.Context(), state); err != nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "failed to store maintenance mode"})
+		return
+	}
+	log.Printf("Maintenance mode set to %v by user %v", state.Enabled, c.GetInt64("user_id"))
+
+	c.JSON(http.StatusOK, newMaintenanceResponse(h.mode.State(c.Request.Context())))
+}
+
+// ResetMaintenance handles DELETE /debug/maintenance, returning every gateway
+// instance to its MAINTENANCE_MODE setting
+func (h *MaintenanceHandler) ResetMaintenance(c *gin.Context) {
+	if err := h.mode.Reset(c.Request.Context()); err != nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "failed to reset maintenance mode"})
+		return
+	}
+	log.Printf("Maintenance mode reset to its configured default by user %v", c.GetInt64("user_id"))
+
+	c.JSON(http.StatusOK, newMaintenanceResponse(h.mode.State(c.Request.Context())))
+}
@@ -1,23 +1,28 @@
 package handler
 
 import (
+	"log"
 	"net/http"
 	"strconv"
 	"time"
 
 	pb "github.com/datngth03/ecommerce-go-app/proto/order_service"
 	"github.com/datngth03/ecommerce-go-app/services/api-gateway/internal/clients"
+	"github.com/datngth03/ecommerce-go-app/services/api-gateway/internal/events"
 	"github.com/datngth03/ecommerce-go-app/services/api-gateway/internal/metrics"
 	"github.com/gin-gonic/gin"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 type OrderHandler struct {
 	orderClient *clients.OrderClient
+	orderEvents *events.OrderEventHub // nil when RabbitMQ is unavailable
 }
 
-func NewOrderHandler(orderClient *clients.OrderClient) *OrderHandler {
+func NewOrderHandler(orderClient *clients.OrderClient, orderEvents *events.OrderEventHub) *OrderHandler {
 	return &OrderHandler{
 		orderClient: orderClient,
+		orderEvents: orderEvents,
 	}
 }
 
@@ -32,25 +37,42 @@ func (h *OrderHandler) CreateOrder(c *gin.Context) {
 	var req struct {
 		ShippingAddress string `json:"shipping_address" binding:"required"`
 		PaymentMethod   string `json:"payment_method" binding:"required"`
+		IsGift          bool   `json:"is_gift"`
+		GiftMessage     string `json:"gift_message" binding:"max=250"`
+		CouponCode      string `json:"coupon_code" binding:"max=50"`
+		// Prices the client displayed; the order service only compares them
+		Items []struct {
+			ProductID string  `json:"product_id" binding:"required"`
+			Quantity  int32   `json:"quantity"`
+			Price     float64 `json:"price" binding:"gte=0"`
+		} `json:"items" binding:"max=100,dive"`
 	}
 
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if !bindJSON(c, &req) {
 		return
 	}
 
+	items := make([]*pb.CreateOrderItem, len(req.Items))
+	for i, item := range req.Items {
+		items[i] = &pb.CreateOrderItem{ProductId: item.ProductID, Quantity: item.Quantity, Price: item.Price}
+	}
+
 	start := time.Now()
 	resp, err := h.orderClient.CreateOrder(c.Request.Context(), &pb.CreateOrderRequest{
 		UserId:          userID.(int64),
 		ShippingAddress: req.ShippingAddress,
 		PaymentMethod:   req.PaymentMethod,
+		IsGift:          req.IsGift,
+		GiftMessage:     req.GiftMessage,
+		CouponCode:      req.CouponCode,
+		Items:           items,
 	})
 
 	status := "success"
 	if err != nil {
 		status = "error"
 		metrics.RecordGRPCClientRequest("order-service", "CreateOrder", status, time.Since(start))
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		handleGRPCError(c, err)
 		return
 	}
 	metrics.RecordGRPCClientRequest("order-service", "CreateOrder", status, time.Since(start))
@@ -89,6 +111,150 @@ func (h *OrderHandler) GetOrder(c *gin.Context) {
 	})
 }
 
+// GetOrderByNumber handles GET /api/v1/orders/by-number/:number, for the
+// human-friendly number shown to customers, e.g. ORD-2025-000123
+func (h *OrderHandler) GetOrderByNumber(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	h.getOrderByNumber(c, userID.(int64))
+}
+
+// AdminGetOrderByNumber handles GET /api/v1/admin/orders/by-number/:number,
+// for support staff looking up an order a customer reads out
+func (h *OrderHandler) AdminGetOrderByNumber(c *gin.Context) {
+	h.getOrderByNumber(c, 0)
+}
+
+func (h *OrderHandler) getOrderByNumber(c *gin.Context, userID int64) {
+	start := time.Now()
+	resp, err := h.orderClient.GetOrderByNumber(c.Request.Context(), &pb.GetOrderByNumberRequest{
+		OrderNumber: c.Param("number"),
+		UserId:      userID,
+	})
+	if err != nil {
+		metrics.RecordGRPCClientRequest("order-service", "GetOrderByNumber", "error", time.Since(start))
+		handleGRPCError(c, err)
+		return
+	}
+	metrics.RecordGRPCClientRequest("order-service", "GetOrderByNumber", "success", time.Since(start))
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "order retrieved successfully",
+		"data":    resp.Order,
+	})
+}
+
+// StreamOrderStatus handles GET /api/v1/orders/:id/stream.
+// It pushes the order's status as Server-Sent Events: the current status first, then
+// every change, until the order reaches a final status or the client disconnects.
+func (h *OrderHandler) StreamOrderStatus(c *gin.Context) {
+	if h.orderEvents == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "order status streaming is unavailable"})
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	orderID := c.Param("id")
+	if orderID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "order_id is required"})
+		return
+	}
+
+	// Subscribe before reading the current status so no change in between is missed
+	updates, unsubscribe := h.orderEvents.Subscribe(orderID)
+	defer unsubscribe()
+
+	start := time.Now()
+	resp, err := h.orderClient.GetOrder(c.Request.Context(), &pb.GetOrderRequest{
+		Id: orderID,
+	})
+	if err != nil {
+		metrics.RecordGRPCClientRequest("order-service", "GetOrder", "error", time.Since(start))
+		handleGRPCError(c, err)
+		return
+	}
+	metrics.RecordGRPCClientRequest("order-service", "GetOrder", "success", time.Since(start))
+
+	// Other users' orders look the same as missing ones
+	if resp.Order.UserId != userID.(int64) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "order not found"})
+		return
+	}
+
+	// The stream outlives the server's write timeout
+	if err := http.NewResponseController(c.Writer).SetWriteDeadline(time.Time{}); err != nil {
+		log.Printf("Failed to clear write deadline for order stream: %v", err)
+	}
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no") // Disable proxy buffering (nginx)
+
+	current := events.OrderStatusEvent{
+		OrderID: resp.Order.Id,
+		UserID:  resp.Order.UserId,
+		Status:  resp.Order.Status,
+	}
+	if resp.Order.UpdatedAt != nil {
+		current.UpdatedAt = resp.Order.UpdatedAt.AsTime()
+	}
+	c.SSEvent("status", current)
+	c.Writer.Flush()
+	if isFinalOrderStatus(current.Status) {
+		return
+	}
+
+	heartbeat := time.NewTicker(orderStreamHeartbeat)
+	defer heartbeat.Stop()
+	maxDuration := time.NewTimer(orderStreamMaxDuration)
+	defer maxDuration.Stop()
+
+	for {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		case <-maxDuration.C:
+			// Clients reconnect and get the current status again
+			return
+		case <-heartbeat.C:
+			// Comment line keeps idle connections open through proxies
+			if _, err := c.Writer.WriteString(": ping\n\n"); err != nil {
+				return
+			}
+			c.Writer.Flush()
+		case event, ok := <-updates:
+			if !ok {
+				return
+			}
+			c.SSEvent("status", event)
+			c.Writer.Flush()
+			if isFinalOrderStatus(event.Status) {
+				return
+			}
+		}
+	}
+}
+
+const (
+	orderStreamHeartbeat   = 15 * time.Second
+	orderStreamMaxDuration = 30 * time.Minute
+)
+
+// isFinalOrderStatus reports whether an order status can no longer change
+func isFinalOrderStatus(status string) bool {
+	return status == "delivered" || status == "cancelled"
+}
+
 // ListOrders handles GET /api/v1/orders
 func (h *OrderHandler) ListOrders(c *gin.Context) {
 	userID, exists := c.Get("user_id")
@@ -119,11 +285,319 @@ func (h *OrderHandler) ListOrders(c *gin.Context) {
 	metrics.RecordGRPCClientRequest("order-service", "ListOrders", statusMetric, time.Since(start))
 
 	c.JSON(http.StatusOK, gin.H{
-		"message":   "orders retrieved successfully",
-		"data":      resp.Orders,
-		"total":     resp.TotalCount,
-		"page":      page,
-		"page_size": pageSize,
+		"message":     "orders retrieved successfully",
+		"data":        resp.Orders,
+		"total":       resp.TotalCount,
+		"total_pages": resp.TotalPages,
+		"has_more":    resp.HasMore,
+		"page":        page,
+		"page_size":   pageSize,
+	})
+}
+
+// AdminListOrders handles GET /api/v1/admin/orders (Admin only).
+// status is required; created_after, created_before, updated_after and
+// updated_before are optional RFC 3339 times.
+func (h *OrderHandler) AdminListOrders(c *gin.Context) {
+	status := c.Query("status")
+	if status == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "status is required"})
+		return
+	}
+
+	req := &pb.GetOrdersByStatusRequest{Status: status}
+	bounds := []struct {
+		param string
+		dest  **timestamppb.Timestamp
+	}{
+		{"created_after", &req.CreatedAfter},
+		{"created_before", &req.CreatedBefore},
+		{"updated_after", &req.UpdatedAfter},
+		{"updated_before", &req.UpdatedBefore},
+	}
+	for _, b := range bounds {
+		value := c.Query(b.param)
+		if value == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": b.param + " must be an RFC 3339 time"})
+			return
+		}
+		*b.dest = timestamppb.New(t)
+	}
+
+	page, _ := strconv.ParseInt(c.DefaultQuery("page", "1"), 10, 32)
+	pageSize, _ := strconv.ParseInt(c.DefaultQuery("page_size", "50"), 10, 32)
+	req.Page = int32(page)
+	req.PageSize = int32(pageSize)
+
+	start := time.Now()
+	resp, err := h.orderClient.GetOrdersByStatus(c.Request.Context(), req)
+	if err != nil {
+		metrics.RecordGRPCClientRequest("order-service", "GetOrdersByStatus", "error", time.Since(start))
+		handleGRPCError(c, err)
+		return
+	}
+	metrics.RecordGRPCClientRequest("order-service", "GetOrdersByStatus", "success", time.Since(start))
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":     "orders retrieved successfully",
+		"data":        resp.Orders,
+		"total":       resp.TotalCount,
+		"total_pages": resp.TotalPages,
+		"has_more":    resp.HasMore,
+		"page":        page,
+		"page_size":   pageSize,
+	})
+}
+
+// ListOrderNotes handles GET /api/v1/orders/:id/notes.
+// Customers only see the notes staff marked as visible to them.
+func (h *OrderHandler) ListOrderNotes(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	h.listOrderNotes(c, &pb.ListOrderNotesRequest{
+		OrderId: c.Param("id"),
+		UserId:  userID.(int64),
+	})
+}
+
+// AdminListOrderNotes handles GET /api/v1/admin/orders/:id/notes, internal notes included
+func (h *OrderHandler) AdminListOrderNotes(c *gin.Context) {
+	h.listOrderNotes(c, &pb.ListOrderNotesRequest{
+		OrderId:         c.Param("id"),
+		IncludeInternal: true,
+	})
+}
+
+func (h *OrderHandler) listOrderNotes(c *gin.Context, req *pb.ListOrderNotesRequest) {
+	start := time.Now()
+	resp, err := h.orderClient.ListOrderNotes(c.Request.Context(), req)
+	if err != nil {
+		metrics.RecordGRPCClientRequest("order-service", "ListOrderNotes", "error", time.Since(start))
+		handleGRPCError(c, err)
+		return
+	}
+	metrics.RecordGRPCClientRequest("order-service", "ListOrderNotes", "success", time.Since(start))
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "order notes retrieved successfully",
+		"data":    resp.Notes,
+	})
+}
+
+// AdminAddOrderNote handles POST /api/v1/admin/orders/:id/notes.
+// The signed-in staff user is recorded as the author.
+func (h *OrderHandler) AdminAddOrderNote(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	var req struct {
+		Body       string `json:"body" binding:"required,max=2000"`
+		IsInternal *bool  `json:"is_internal"` // Defaults to true
+	}
+	if !bindJSON(c, &req) {
+		return
+	}
+	internal := req.IsInternal == nil || *req.IsInternal
+
+	start := time.Now()
+	resp, err := h.orderClient.AddOrderNote(c.Request.Context(), &pb.AddOrderNoteRequest{
+		OrderId:    c.Param("id"),
+		AuthorId:   userID.(int64),
+		Body:       req.Body,
+		IsInternal: internal,
+	})
+	if err != nil {
+		metrics.RecordGRPCClientRequest("order-service", "AddOrderNote", "error", time.Since(start))
+		handleGRPCError(c, err)
+		return
+	}
+	metrics.RecordGRPCClientRequest("order-service", "AddOrderNote", "success", time.Since(start))
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "order note added successfully",
+		"data":    resp.Note,
+	})
+}
+
+// RequestReturn handles POST /api/v1/orders/:id/returns.
+// Only delivered orders within the return window can be returned.
+func (h *OrderHandler) RequestReturn(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	var req struct {
+		Items []struct {
+			ProductID string `json:"product_id" binding:"required"`
+			Quantity  int32  `json:"quantity" binding:"required,min=1"`
+		} `json:"items" binding:"required,min=1,max=50,dive"`
+		Reason string `json:"reason" binding:"required,max=500"`
+	}
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	items := make([]*pb.ReturnItemRequest, len(req.Items))
+	for i, item := range req.Items {
+		items[i] = &pb.ReturnItemRequest{ProductId: item.ProductID, Quantity: item.Quantity}
+	}
+
+	start := time.Now()
+	resp, err := h.orderClient.RequestReturn(c.Request.Context(), &pb.RequestReturnRequest{
+		OrderId: c.Param("id"),
+		UserId:  userID.(int64),
+		Items:   items,
+		Reason:  req.Reason,
+	})
+	if err != nil {
+		metrics.RecordGRPCClientRequest("order-service", "RequestReturn", "error", time.Since(start))
+		handleGRPCError(c, err)
+		return
+	}
+	metrics.RecordGRPCClientRequest("order-service", "RequestReturn", "success", time.Since(start))
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "return requested successfully",
+		"data":    resp.Return,
+	})
+}
+
+// GetReturn handles GET /api/v1/returns/:id
+func (h *OrderHandler) GetReturn(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	h.getReturn(c, userID.(int64))
+}
+
+// AdminGetReturn handles GET /api/v1/admin/returns/:id
+func (h *OrderHandler) AdminGetReturn(c *gin.Context) {
+	h.getReturn(c, 0)
+}
+
+func (h *OrderHandler) getReturn(c *gin.Context, userID int64) {
+	start := time.Now()
+	resp, err := h.orderClient.GetReturn(c.Request.Context(), &pb.GetReturnRequest{
+		Id:     c.Param("id"),
+		UserId: userID,
+	})
+	if err != nil {
+		metrics.RecordGRPCClientRequest("order-service", "GetReturn", "error", time.Since(start))
+		handleGRPCError(c, err)
+		return
+	}
+	metrics.RecordGRPCClientRequest("order-service", "GetReturn", "success", time.Since(start))
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "return retrieved successfully",
+		"data":    resp.Return,
+	})
+}
+
+// ListReturns handles GET /api/v1/returns, the signed-in user's returns
+func (h *OrderHandler) ListReturns(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	h.listReturns(c, &pb.ListReturnsRequest{
+		UserId:  userID.(int64),
+		OrderId: c.Query("order_id"),
+		Status:  c.Query("status"),
+	})
+}
+
+// AdminListReturns handles GET /api/v1/admin/returns (Admin only).
+// status, order_id and user_id are optional filters.
+func (h *OrderHandler) AdminListReturns(c *gin.Context) {
+	var userID int64
+	if value := c.Query("user_id"); value != "" {
+		id, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || id <= 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "user_id must be a positive integer"})
+			return
+		}
+		userID = id
+	}
+
+	h.listReturns(c, &pb.ListReturnsRequest{
+		UserId:  userID,
+		OrderId: c.Query("order_id"),
+		Status:  c.Query("status"),
+	})
+}
+
+func (h *OrderHandler) listReturns(c *gin.Context, req *pb.ListReturnsRequest) {
+	page, _ := strconv.ParseInt(c.DefaultQuery("page", "1"), 10, 32)
+	pageSize, _ := strconv.ParseInt(c.DefaultQuery("page_size", "10"), 10, 32)
+	req.Page = int32(page)
+	req.PageSize = int32(pageSize)
+
+	start := time.Now()
+	resp, err := h.orderClient.ListReturns(c.Request.Context(), req)
+	if err != nil {
+		metrics.RecordGRPCClientRequest("order-service", "ListReturns", "error", time.Since(start))
+		handleGRPCError(c, err)
+		return
+	}
+	metrics.RecordGRPCClientRequest("order-service", "ListReturns", "success", time.Since(start))
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":     "returns retrieved successfully",
+		"data":        resp.Returns,
+		"total":       resp.TotalCount,
+		"total_pages": resp.TotalPages,
+		"has_more":    resp.HasMore,
+		"page":        page,
+		"page_size":   pageSize,
+	})
+}
+
+// AdminUpdateReturnStatus handles PUT /api/v1/admin/returns/:id/status.
+// Receiving a return restocks its items; refunding it refunds the payment.
+func (h *OrderHandler) AdminUpdateReturnStatus(c *gin.Context) {
+	var req struct {
+		Status          string `json:"status" binding:"required,oneof=approved rejected received refunded"`
+		RejectionReason string `json:"rejection_reason" binding:"required_if=Status rejected,max=500"`
+	}
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	start := time.Now()
+	resp, err := h.orderClient.UpdateReturnStatus(c.Request.Context(), &pb.UpdateReturnStatusRequest{
+		Id:              c.Param("id"),
+		Status:          req.Status,
+		RejectionReason: req.RejectionReason,
+	})
+	if err != nil {
+		metrics.RecordGRPCClientRequest("order-service", "UpdateReturnStatus", "error", time.Since(start))
+		handleGRPCError(c, err)
+		return
+	}
+	metrics.RecordGRPCClientRequest("order-service", "UpdateReturnStatus", "success", time.Since(start))
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "return status updated successfully",
+		"data":    resp.Return,
 	})
 }
 
@@ -147,7 +621,7 @@ func (h *OrderHandler) CancelOrder(c *gin.Context) {
 	if err != nil {
 		status = "error"
 		metrics.RecordGRPCClientRequest("order-service", "CancelOrder", status, time.Since(start))
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		handleGRPCError(c, err)
 		return
 	}
 	metrics.RecordGRPCClientRequest("order-service", "CancelOrder", status, time.Since(start))
@@ -170,8 +644,7 @@ func (h *OrderHandler) AddToCart(c *gin.Context) {
 		Quantity  int32  `json:"quantity" binding:"required,min=1"`
 	}
 
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if !bindJSON(c, &req) {
 		return
 	}
 
@@ -182,7 +655,7 @@ func (h *OrderHandler) AddToCart(c *gin.Context) {
 	})
 
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		handleGRPCError(c, err)
 		return
 	}
 
@@ -192,6 +665,53 @@ func (h *OrderHandler) AddToCart(c *gin.Context) {
 	})
 }
 
+// AddItemsToCart handles POST /api/v1/cart/items
+// Items that cannot be added are listed in failed_items; the rest are still added.
+func (h *OrderHandler) AddItemsToCart(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	var req struct {
+		Items []struct {
+			ProductID string `json:"product_id"`
+			Quantity  int32  `json:"quantity"`
+		} `json:"items" binding:"required,min=1,max=50"`
+	}
+
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	items := make([]*pb.CartItemInput, len(req.Items))
+	for i, item := range req.Items {
+		items[i] = &pb.CartItemInput{ProductId: item.ProductID, Quantity: item.Quantity}
+	}
+
+	resp, err := h.orderClient.AddItemsToCart(c.Request.Context(), &pb.AddItemsToCartRequest{
+		UserId: userID.(int64),
+		Items:  items,
+	})
+
+	if err != nil {
+		handleGRPCError(c, err)
+		return
+	}
+
+	failedItems := resp.FailedItems
+	if failedItems == nil {
+		failedItems = []*pb.CartItemFailure{}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":      "items added to cart",
+		"data":         resp.Cart,
+		"failed_items": failedItems,
+	})
+}
+
 // GetCart handles GET /api/v1/cart
 func (h *OrderHandler) GetCart(c *gin.Context) {
 	userID, exists := c.Get("user_id")
@@ -233,8 +753,7 @@ func (h *OrderHandler) UpdateCartItem(c *gin.Context) {
 		Quantity int32 `json:"quantity" binding:"required,min=1"`
 	}
 
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if !bindJSON(c, &req) {
 		return
 	}
 
@@ -245,7 +764,7 @@ func (h *OrderHandler) UpdateCartItem(c *gin.Context) {
 	})
 
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		handleGRPCError(c, err)
 		return
 	}
 
@@ -275,7 +794,7 @@ func (h *OrderHandler) RemoveFromCart(c *gin.Context) {
 	})
 
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		handleGRPCError(c, err)
 		return
 	}
 
@@ -298,7 +817,7 @@ func (h *OrderHandler) ClearCart(c *gin.Context) {
 	})
 
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		handleGRPCError(c, err)
 		return
 	}
 
@@ -306,3 +825,133 @@ func (h *OrderHandler) ClearCart(c *gin.Context) {
 		"message": "cart cleared successfully",
 	})
 }
+
+// AddToWishlist handles POST /api/v1/wishlist
+func (h *OrderHandler) AddToWishlist(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	var req struct {
+		ProductID string `json:"product_id" binding:"required"`
+	}
+
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	resp, err := h.orderClient.AddToWishlist(c.Request.Context(), &pb.AddToWishlistRequest{
+		UserId:    userID.(int64),
+		ProductId: req.ProductID,
+	})
+
+	if err != nil {
+		handleGRPCError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "item added to wishlist successfully",
+		"data":    resp.Wishlist,
+	})
+}
+
+// GetWishlist handles GET /api/v1/wishlist
+func (h *OrderHandler) GetWishlist(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	resp, err := h.orderClient.GetWishlist(c.Request.Context(), &pb.GetWishlistRequest{
+		UserId: userID.(int64),
+	})
+
+	if err != nil {
+		handleGRPCError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "wishlist retrieved successfully",
+		"data":    resp.Wishlist,
+	})
+}
+
+// RemoveFromWishlist handles DELETE /api/v1/wishlist/:product_id
+func (h *OrderHandler) RemoveFromWishlist(c *gin.Context) {
+	productID := c.Param("product_id")
+	if productID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "product_id is required"})
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	resp, err := h.orderClient.RemoveFromWishlist(c.Request.Context(), &pb.RemoveFromWishlistRequest{
+		UserId:    userID.(int64),
+		ProductId: productID,
+	})
+
+	if err != nil {
+		handleGRPCError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "item removed from wishlist successfully",
+		"data":    resp.Wishlist,
+	})
+}
+
+// MoveToCart handles POST /api/v1/wishlist/:product_id/move-to-cart
+func (h *OrderHandler) MoveToCart(c *gin.Context) {
+	productID := c.Param("product_id")
+	if productID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "product_id is required"})
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	// Body is optional, quantity defaults to 1
+	var req struct {
+		Quantity int32 `json:"quantity" binding:"omitempty,min=1"`
+	}
+
+	if c.Request.ContentLength > 0 {
+		if !bindJSON(c, &req) {
+			return
+		}
+	}
+
+	resp, err := h.orderClient.MoveToCart(c.Request.Context(), &pb.MoveToCartRequest{
+		UserId:    userID.(int64),
+		ProductId: productID,
+		Quantity:  req.Quantity,
+	})
+
+	if err != nil {
+		handleGRPCError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "item moved to cart successfully",
+		"data": gin.H{
+			"cart":     resp.Cart,
+			"wishlist": resp.Wishlist,
+		},
+	})
+}
@@ -32,14 +32,13 @@ func (h *PaymentHandler) ProcessPayment(c *gin.Context) {
 
 	var req struct {
 		OrderID         string  `json:"order_id" binding:"required"`
-		Amount          float64 `json:"amount" binding:"required,min=0"`
+		Amount          float64 `json:"amount" binding:"required,gt=0"`
 		Method          string  `json:"method" binding:"required"`
-		Currency        string  `json:"currency"`
+		Currency        string  `json:"currency" binding:"omitempty,len=3"`
 		PaymentMethodID string  `json:"payment_method_id"`
 	}
 
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if !bindJSON(c, &req) {
 		return
 	}
 
@@ -61,7 +60,7 @@ func (h *PaymentHandler) ProcessPayment(c *gin.Context) {
 	if err != nil {
 		status = "error"
 		metrics.RecordGRPCClientRequest("payment-service", "ProcessPayment", status, time.Since(start))
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		handleGRPCError(c, err)
 		return
 	}
 	metrics.RecordGRPCClientRequest("payment-service", "ProcessPayment", status, time.Since(start))
@@ -143,11 +142,13 @@ func (h *PaymentHandler) GetPaymentHistory(c *gin.Context) {
 	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"message":   "payment history retrieved successfully",
-		"data":      resp.Payments,
-		"total":     resp.Total,
-		"page":      page,
-		"page_size": pageSize,
+		"message":     "payment history retrieved successfully",
+		"data":        resp.Payments,
+		"total":       resp.Total,
+		"total_pages": resp.TotalPages,
+		"has_more":    resp.HasMore,
+		"page":        page,
+		"page_size":   pageSize,
 	})
 }
 
@@ -163,8 +164,7 @@ func (h *PaymentHandler) ConfirmPayment(c *gin.Context) {
 		PaymentIntentID string `json:"payment_intent_id" binding:"required"`
 	}
 
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if !bindJSON(c, &req) {
 		return
 	}
 
@@ -194,23 +194,32 @@ func (h *PaymentHandler) RefundPayment(c *gin.Context) {
 	}
 
 	var req struct {
-		Amount float64 `json:"amount" binding:"required,min=0"`
+		Amount float64 `json:"amount" binding:"min=0"` // 0 refunds whatever is still refundable
 		Reason string  `json:"reason"`
+		Items  []struct {
+			ProductID string `json:"product_id" binding:"required"`
+			Quantity  int32  `json:"quantity" binding:"required,min=1"`
+		} `json:"items" binding:"dive"`
 	}
 
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if !bindJSON(c, &req) {
 		return
 	}
 
+	items := make([]*pb.RefundItem, len(req.Items))
+	for i, item := range req.Items {
+		items[i] = &pb.RefundItem{ProductId: item.ProductID, Quantity: item.Quantity}
+	}
+
 	resp, err := h.paymentClient.RefundPayment(c.Request.Context(), &pb.RefundPaymentRequest{
 		PaymentId: paymentID,
 		Amount:    req.Amount,
 		Reason:    req.Reason,
+		Items:     items,
 	})
 
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		handleGRPCError(c, err)
 		return
 	}
 
@@ -229,14 +238,19 @@ func (h *PaymentHandler) SavePaymentMethod(c *gin.Context) {
 		return
 	}
 
+	// Card details are tokenized client-side by the provider; only the token and the
+	// display metadata it returned are accepted here
 	var req struct {
 		MethodType      string `json:"method_type" binding:"required"`
 		GatewayMethodID string `json:"gateway_method_id" binding:"required"`
 		IsDefault       bool   `json:"is_default"`
+		Last4           string `json:"last4"`
+		Brand           string `json:"brand"`
+		ExpMonth        int32  `json:"exp_month"`
+		ExpYear         int32  `json:"exp_year"`
 	}
 
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if !bindJSON(c, &req) {
 		return
 	}
 
@@ -245,10 +259,14 @@ func (h *PaymentHandler) SavePaymentMethod(c *gin.Context) {
 		MethodType:      req.MethodType,
 		GatewayMethodId: req.GatewayMethodID,
 		IsDefault:       req.IsDefault,
+		Last4:           req.Last4,
+		Brand:           req.Brand,
+		ExpMonth:        req.ExpMonth,
+		ExpYear:         req.ExpYear,
 	})
 
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		handleGRPCError(c, err)
 		return
 	}
 
@@ -281,3 +299,104 @@ func (h *PaymentHandler) GetPaymentMethods(c *gin.Context) {
 		"data":    resp.PaymentMethods,
 	})
 }
+
+// DeletePaymentMethod handles DELETE /api/v1/payment-methods/:id
+func (h *PaymentHandler) DeletePaymentMethod(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	_, err := h.paymentClient.DeletePaymentMethod(c.Request.Context(), &pb.DeletePaymentMethodRequest{
+		UserId:          fmt.Sprintf("%d", userID.(int64)),
+		PaymentMethodId: c.Param("id"),
+	})
+	if err != nil {
+		handleGRPCError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "payment method deleted successfully",
+	})
+}
+
+// CreateSubscription handles POST /api/v1/subscriptions
+func (h *PaymentHandler) CreateSubscription(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	var req struct {
+		PlanID          string  `json:"plan_id" binding:"required"`
+		Amount          float64 `json:"amount" binding:"required,gt=0"`
+		Currency        string  `json:"currency"`
+		Interval        string  `json:"interval" binding:"required"`
+		IntervalCount   int32   `json:"interval_count"`
+		PaymentMethodID string  `json:"payment_method_id" binding:"required"`
+		StartAt         string  `json:"start_at"`
+	}
+
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	resp, err := h.paymentClient.CreateSubscription(c.Request.Context(), &pb.CreateSubscriptionRequest{
+		UserId:          fmt.Sprintf("%d", userID.(int64)),
+		PlanId:          req.PlanID,
+		Amount:          req.Amount,
+		Currency:        req.Currency,
+		Interval:        req.Interval,
+		IntervalCount:   req.IntervalCount,
+		PaymentMethodId: req.PaymentMethodID,
+		StartAt:         req.StartAt,
+	})
+	if err != nil {
+		handleGRPCError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "subscription created successfully",
+		"data":    resp.Subscription,
+		"success": resp.Success,
+	})
+}
+
+// CancelSubscription handles POST /api/v1/subscriptions/:id/cancel
+func (h *PaymentHandler) CancelSubscription(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	var req struct {
+		Reason string `json:"reason"`
+	}
+	// The body is optional
+	if c.Request.ContentLength > 0 {
+		if !bindJSON(c, &req) {
+			return
+		}
+	}
+
+	resp, err := h.paymentClient.CancelSubscription(c.Request.Context(), &pb.CancelSubscriptionRequest{
+		UserId:         fmt.Sprintf("%d", userID.(int64)),
+		SubscriptionId: c.Param("id"),
+		Reason:         req.Reason,
+	})
+	if err != nil {
+		handleGRPCError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "subscription cancelled successfully",
+		"data":    resp.Subscription,
+		"success": resp.Success,
+	})
+}
@@ -1,15 +1,22 @@
 package handler
 
 import (
+	"context"
 	"encoding/json"
+	"log"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	pb "github.com/datngth03/ecommerce-go-app/proto/product_service"
+	"github.com/datngth03/ecommerce-go-app/services/api-gateway/internal/middleware"
 	"github.com/datngth03/ecommerce-go-app/services/api-gateway/internal/proxy"
 	"github.com/gin-gonic/gin"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 // ProductHandler handles HTTP requests for products
@@ -30,20 +37,84 @@ func (h *ProductHandler) GetProduct(c *gin.Context) {
 		return
 	}
 
-	product, err := h.proxy.GetProduct(c.Request.Context(), id)
+	includeAvailability, _ := strconv.ParseBool(c.Query("include_availability"))
+
+	product, err := h.proxy.GetProduct(c.Request.Context(), &pb.GetProductRequest{
+		Id:                  id,
+		IncludeAvailability: includeAvailability,
+		Currency:            c.Query("currency"),
+	})
 	if err != nil {
 		handleGRPCError(c, err)
 		return
 	}
 
+	h.recordView(product.Id, c.GetInt64("user_id"))
+
 	c.JSON(http.StatusOK, gin.H{"data": product})
 }
 
+// viewRecordTimeout bounds recording a product view, which runs after the response
+const viewRecordTimeout = 2 * time.Second
+
+// recordView counts a product page view for the merchandising stats. It runs in
+// the background: a failure loses one view but never fails the page.
+func (h *ProductHandler) recordView(productID string, userID int64) {
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), viewRecordTimeout)
+		defer cancel()
+		err := h.proxy.RecordProductInteractions(ctx, &pb.RecordProductInteractionsRequest{
+			Interactions: []*pb.ProductInteraction{{ProductId: productID, Type: "view", UserId: userID}},
+		})
+		if err != nil {
+			log.Printf("Warning: failed to record view of product %s: %v", productID, err)
+		}
+	}()
+}
+
+// GetProductStats handles GET /api/v1/admin/products/:id/stats. from (inclusive)
+// and to (exclusive) are optional RFC 3339 times.
+func (h *ProductHandler) GetProductStats(c *gin.Context) {
+	req := &pb.GetProductStatsRequest{ProductId: c.Param("id")}
+	bounds := []struct {
+		param string
+		dest  **timestamppb.Timestamp
+	}{
+		{"from", &req.From},
+		{"to", &req.To},
+	}
+	for _, b := range bounds {
+		value := c.Query(b.param)
+		if value == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": b.param + " must be an RFC 3339 time"})
+			return
+		}
+		*b.dest = timestamppb.New(t)
+	}
+
+	stats, err := h.proxy.GetProductStats(c.Request.Context(), req)
+	if err != nil {
+		handleGRPCError(c, err)
+		return
+	}
+
+	// Zero counts are meaningful, so they are written out rather than omitted
+	c.JSON(http.StatusOK, gin.H{"data": gin.H{
+		"product_id":        stats.ProductId,
+		"view_count":        stats.ViewCount,
+		"add_to_cart_count": stats.AddToCartCount,
+		"purchase_count":    stats.PurchaseCount,
+	}})
+}
+
 // ListProducts handles GET /api/v1/products
 func (h *ProductHandler) ListProducts(c *gin.Context) {
 	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
 	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "20"))
-	categoryID := c.Query("category_id")
 
 	if page < 1 {
 		page = 1
@@ -52,7 +123,50 @@ func (h *ProductHandler) ListProducts(c *gin.Context) {
 		pageSize = 20
 	}
 
-	products, total, err := h.proxy.ListProducts(c.Request.Context(), int32(page), int32(pageSize), categoryID)
+	// tag_ids may be repeated (?tag_ids=a&tag_ids=b) or comma-separated (?tag_ids=a,b)
+	var tagIDs []string
+	for _, value := range c.QueryArray("tag_ids") {
+		for _, id := range strings.Split(value, ",") {
+			if id = strings.TrimSpace(id); id != "" {
+				tagIDs = append(tagIDs, id)
+			}
+		}
+	}
+
+	includeAvailability, _ := strconv.ParseBool(c.Query("include_availability"))
+	inStockOnly, _ := strconv.ParseBool(c.Query("in_stock_only"))
+
+	// Price bounds are optional; product service checks the range itself
+	var minPrice, maxPrice float64
+	for _, bound := range []struct {
+		name  string
+		value *float64
+	}{{"min_price", &minPrice}, {"max_price", &maxPrice}} {
+		raw := c.Query(bound.name)
+		if raw == "" {
+			continue
+		}
+		value, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": bound.name + " must be a number"})
+			return
+		}
+		*bound.value = value
+	}
+
+	resp, err := h.proxy.ListProducts(c.Request.Context(), &pb.ListProductsRequest{
+		Page:                int32(page),
+		PageSize:            int32(pageSize),
+		CategoryId:          c.Query("category_id"),
+		BrandId:             c.Query("brand_id"),
+		TagIds:              tagIDs,
+		IncludeAvailability: includeAvailability,
+		Currency:            c.Query("currency"),
+		Sort:                c.Query("sort"),
+		MinPrice:            minPrice,
+		MaxPrice:            maxPrice,
+		InStockOnly:         inStockOnly,
+	})
 	if err != nil {
 		handleGRPCError(c, err)
 		return
@@ -60,28 +174,103 @@ func (h *ProductHandler) ListProducts(c *gin.Context) {
 
 	c.JSON(http.StatusOK, gin.H{
 		"data": gin.H{
-			"products":    products,
-			"total_count": total,
-			"page":        page,
-			"page_size":   pageSize,
+			"products":             resp.Products,
+			"total_count":          resp.TotalCount,
+			"total_pages":          resp.TotalPages,
+			"has_more":             resp.HasMore,
+			"page":                 page,
+			"page_size":            pageSize,
+			"stock_filter_skipped": resp.StockFilterSkipped,
 		},
 	})
 }
 
+// Autocomplete handles GET /api/v1/products/autocomplete
+// Slow or failing lookups return an empty list so the search bar keeps working.
+func (h *ProductHandler) Autocomplete(c *gin.Context) {
+	prefix := strings.TrimSpace(c.Query("q"))
+	if prefix == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "q is required"})
+		return
+	}
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "5"))
+
+	suggestions, err := h.proxy.Autocomplete(c.Request.Context(), &pb.AutocompleteRequest{
+		Prefix: prefix,
+		Limit:  int32(limit),
+	})
+	if err != nil {
+		if status.Code(err) == codes.InvalidArgument {
+			handleGRPCError(c, err)
+			return
+		}
+		suggestions = []string{}
+	}
+	if suggestions == nil {
+		suggestions = []string{}
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": gin.H{"suggestions": suggestions}})
+}
+
 // CreateProduct handles POST /api/v1/products
 func (h *ProductHandler) CreateProduct(c *gin.Context) {
-	var req pb.CreateProductRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+	var req struct {
+		Name        string  `json:"name" binding:"required,max=255"`
+		Description string  `json:"description" binding:"max=5000"`
+		Price       float64 `json:"price" binding:"required,gt=0,lte=999999.99"`
+		CategoryID  string  `json:"category_id" binding:"required"`
+		ImageURL    string  `json:"image_url" binding:"omitempty,url,max=500"`
+		Slug        string  `json:"slug"`
+		// Components make the product a bundle
+		Components []struct {
+			ProductID string `json:"product_id" binding:"required"`
+			Quantity  int32  `json:"quantity" binding:"required,gt=0,lte=100"`
+		} `json:"components" binding:"omitempty,max=20,dive"`
+		// Variants such as sizes or colors, each with its own SKU and stock
+		Variants []struct {
+			SKU        string `json:"sku" binding:"required,max=64"`
+			Attributes []struct {
+				Name  string `json:"name" binding:"required,max=50"`
+				Value string `json:"value" binding:"required,max=50"`
+			} `json:"attributes" binding:"required,min=1,max=5,dive"`
+			Price float64 `json:"price" binding:"omitempty,gt=0,lte=999999.99"`
+		} `json:"variants" binding:"omitempty,max=100,dive"`
+	}
+	if !bindJSON(c, &req) {
 		return
 	}
 
-	product, err := h.proxy.CreateProduct(c.Request.Context(), &req)
+	components := make([]*pb.BundleComponent, len(req.Components))
+	for i, component := range req.Components {
+		components[i] = &pb.BundleComponent{ProductId: component.ProductID, Quantity: component.Quantity}
+	}
+
+	variants := make([]*pb.ProductVariant, len(req.Variants))
+	for i, variant := range req.Variants {
+		attributes := make([]*pb.VariantAttribute, len(variant.Attributes))
+		for j, attribute := range variant.Attributes {
+			attributes[j] = &pb.VariantAttribute{Name: attribute.Name, Value: attribute.Value}
+		}
+		variants[i] = &pb.ProductVariant{Sku: variant.SKU, Attributes: attributes, Price: variant.Price}
+	}
+
+	product, err := h.proxy.CreateProduct(c.Request.Context(), &pb.CreateProductRequest{
+		Name:        req.Name,
+		Description: req.Description,
+		Price:       req.Price,
+		CategoryId:  req.CategoryID,
+		ImageUrl:    req.ImageURL,
+		Slug:        req.Slug,
+		Components:  components,
+		Variants:    variants,
+	})
 	if err != nil {
 		handleGRPCError(c, err)
 		return
 	}
 
+	c.Set(middleware.AuditTargetKey, product.GetId())
 	c.JSON(http.StatusCreated, gin.H{"data": product})
 }
 
@@ -94,8 +283,7 @@ func (h *ProductHandler) UpdateProduct(c *gin.Context) {
 	}
 
 	var req pb.UpdateProductRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+	if !bindJSON(c, &req) {
 		return
 	}
 	req.Id = id
@@ -125,6 +313,77 @@ func (h *ProductHandler) DeleteProduct(c *gin.Context) {
 	c.JSON(http.StatusNoContent, nil)
 }
 
+// SaveSearch handles POST /api/v1/saved-searches. New products matching the filters
+// are pushed to the user's devices.
+func (h *ProductHandler) SaveSearch(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	var req struct {
+		Name       string   `json:"name" binding:"required,max=100"`
+		CategoryID string   `json:"category_id"`
+		BrandID    string   `json:"brand_id"`
+		TagIDs     []string `json:"tag_ids" binding:"max=20"`
+		MinPrice   float64  `json:"min_price" binding:"gte=0"`
+		MaxPrice   float64  `json:"max_price" binding:"gte=0"`
+	}
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	search, err := h.proxy.SaveSearch(c.Request.Context(), &pb.SaveSearchRequest{
+		UserId:     userID.(int64),
+		Name:       req.Name,
+		CategoryId: req.CategoryID,
+		BrandId:    req.BrandID,
+		TagIds:     req.TagIDs,
+		MinPrice:   req.MinPrice,
+		MaxPrice:   req.MaxPrice,
+	})
+	if err != nil {
+		handleGRPCError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"data": search})
+}
+
+// ListSavedSearches handles GET /api/v1/saved-searches
+func (h *ProductHandler) ListSavedSearches(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	searches, err := h.proxy.ListSavedSearches(c.Request.Context(), userID.(int64))
+	if err != nil {
+		handleGRPCError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": searches})
+}
+
+// DeleteSavedSearch handles DELETE /api/v1/saved-searches/:id
+func (h *ProductHandler) DeleteSavedSearch(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	if err := h.proxy.DeleteSavedSearch(c.Request.Context(), c.Param("id"), userID.(int64)); err != nil {
+		handleGRPCError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
+
 // GetCategory handles GET /api/v1/categories/:id
 func (h *ProductHandler) GetCategory(c *gin.Context) {
 	id := c.Param("id")
@@ -156,8 +415,7 @@ func (h *ProductHandler) ListCategories(c *gin.Context) {
 // CreateCategory handles POST /api/v1/categories
 func (h *ProductHandler) CreateCategory(c *gin.Context) {
 	var req pb.CreateCategoryRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+	if !bindJSON(c, &req) {
 		return
 	}
 
@@ -167,6 +425,7 @@ func (h *ProductHandler) CreateCategory(c *gin.Context) {
 		return
 	}
 
+	c.Set(middleware.AuditTargetKey, category.GetId())
 	c.JSON(http.StatusCreated, gin.H{"data": category})
 }
 
@@ -179,8 +438,7 @@ func (h *ProductHandler) UpdateCategory(c *gin.Context) {
 	}
 
 	var req pb.UpdateCategoryRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+	if !bindJSON(c, &req) {
 		return
 	}
 	req.Id = id
@@ -224,7 +482,7 @@ func handleGRPCError(c *gin.Context, err error) {
 		httpStatus = http.StatusNotFound
 	case codes.InvalidArgument:
 		httpStatus = http.StatusBadRequest
-	case codes.AlreadyExists:
+	case codes.AlreadyExists, codes.Aborted:
 		httpStatus = http.StatusConflict
 	case codes.PermissionDenied:
 		httpStatus = http.StatusForbidden
@@ -232,11 +490,24 @@ func handleGRPCError(c *gin.Context, err error) {
 		httpStatus = http.StatusUnauthorized
 	case codes.FailedPrecondition:
 		httpStatus = http.StatusBadRequest
+	case codes.Unavailable:
+		httpStatus = http.StatusServiceUnavailable
+	case codes.DeadlineExceeded:
+		httpStatus = http.StatusGatewayTimeout
 	default:
 		httpStatus = http.StatusInternalServerError
 	}
 
-	c.JSON(httpStatus, gin.H{"error": st.Message()})
+	body := gin.H{"error": st.Message()}
+	if st.Code() == codes.AlreadyExists {
+		// The backend names the existing entity, so clients need not search for it
+		for _, detail := range st.Details() {
+			if info, ok := detail.(*errdetails.ResourceInfo); ok && info.ResourceName != "" {
+				body["existing_id"] = info.ResourceName
+			}
+		}
+	}
+	c.JSON(httpStatus, body)
 }
 
 // MarshalJSON ensures proper JSON marshaling
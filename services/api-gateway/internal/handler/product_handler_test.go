@@ -0,0 +1,45 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestHandleGRPCErrorAlreadyExists(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	serve := func(err error) (int, map[string]string) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		handleGRPCError(c, err)
+		var body map[string]string
+		if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+			t.Fatalf("decode body: %v", err)
+		}
+		return w.Code, body
+	}
+
+	st, err := status.New(codes.AlreadyExists, "category with name 'Books' already exists").WithDetails(
+		&errdetails.ResourceInfo{ResourceType: "category", ResourceName: "63b957bf-0f16-4f32-8c34-8215ccc5bc46"},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	code, body := serve(st.Err())
+	if code != http.StatusConflict || body["existing_id"] != "63b957bf-0f16-4f32-8c34-8215ccc5bc46" {
+		t.Fatalf("got %d %v, want 409 with the existing id", code, body)
+	}
+
+	// Backends that do not name the existing entity still get a plain 409
+	code, body = serve(status.Error(codes.AlreadyExists, "already exists"))
+	if _, ok := body["existing_id"]; code != http.StatusConflict || ok {
+		t.Fatalf("got %d %v, want 409 without existing_id", code, body)
+	}
+}
@@ -3,10 +3,13 @@ package handler
 import (
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	pb "github.com/datngth03/ecommerce-go-app/proto/user_service"
 	"github.com/datngth03/ecommerce-go-app/services/api-gateway/internal/proxy"
 	"github.com/gin-gonic/gin"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 // UserHandler handles HTTP requests for users
@@ -21,13 +24,23 @@ func NewUserHandler(proxy *proxy.UserProxy) *UserHandler {
 
 // Register handles POST /api/v1/auth/register
 func (h *UserHandler) Register(c *gin.Context) {
-	var req pb.CreateUserRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+	// The rules match the user service's so invalid input fails here with field errors
+	var req struct {
+		Email    string `json:"email" binding:"required,email"`
+		Name     string `json:"name" binding:"required,min=2,max=100"`
+		Phone    string `json:"phone" binding:"omitempty,min=10,max=20"`
+		Password string `json:"password" binding:"required,min=8,strong_password"`
+	}
+	if !bindJSON(c, &req) {
 		return
 	}
 
-	resp, err := h.proxy.CreateUser(c.Request.Context(), &req)
+	resp, err := h.proxy.CreateUser(c.Request.Context(), &pb.CreateUserRequest{
+		Email:    req.Email,
+		Name:     req.Name,
+		Phone:    req.Phone,
+		Password: req.Password,
+	})
 	if err != nil {
 		handleGRPCError(c, err)
 		return
@@ -38,13 +51,20 @@ func (h *UserHandler) Register(c *gin.Context) {
 
 // Login handles POST /api/v1/auth/login
 func (h *UserHandler) Login(c *gin.Context) {
-	var req pb.LoginRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+	var req struct {
+		Email         string `json:"email" binding:"required,email"`
+		Password      string `json:"password" binding:"required"`
+		TwoFactorCode string `json:"two_factor_code"`
+	}
+	if !bindJSON(c, &req) {
 		return
 	}
 
-	resp, err := h.proxy.Login(c.Request.Context(), &req)
+	resp, err := h.proxy.Login(c.Request.Context(), &pb.LoginRequest{
+		Email:         req.Email,
+		Password:      req.Password,
+		TwoFactorCode: req.TwoFactorCode,
+	})
 	if err != nil {
 		handleGRPCError(c, err)
 		return
@@ -58,8 +78,7 @@ func (h *UserHandler) RefreshToken(c *gin.Context) {
 	var req struct {
 		RefreshToken string `json:"refresh_token" binding:"required"`
 	}
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+	if !bindJSON(c, &req) {
 		return
 	}
 
@@ -119,6 +138,90 @@ func (h *UserHandler) GetProfile(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"data": user})
 }
 
+// ChangePassword handles PUT /api/v1/users/me/password. Other sessions are
+// signed out; passing the current refresh_token keeps this one signed in.
+func (h *UserHandler) ChangePassword(c *gin.Context) {
+	var req struct {
+		CurrentPassword string `json:"current_password" binding:"required"`
+		NewPassword     string `json:"new_password" binding:"required,min=8,strong_password"`
+		RefreshToken    string `json:"refresh_token"`
+	}
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	resp, err := h.proxy.ChangePassword(c.Request.Context(), accessToken(c), &pb.ChangePasswordRequest{
+		OldPassword:  req.CurrentPassword,
+		NewPassword:  req.NewPassword,
+		RefreshToken: req.RefreshToken,
+	})
+	if err != nil {
+		handleGRPCError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": resp.Message})
+}
+
+// EnableTwoFactor handles POST /api/v1/users/me/2fa/enable. The returned
+// provisioning_uri is shown as a QR code; 2FA is active only after confirm.
+func (h *UserHandler) EnableTwoFactor(c *gin.Context) {
+	resp, err := h.proxy.EnableTwoFactor(c.Request.Context(), accessToken(c))
+	if err != nil {
+		handleGRPCError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": resp})
+}
+
+// ConfirmTwoFactor handles POST /api/v1/users/me/2fa/confirm
+func (h *UserHandler) ConfirmTwoFactor(c *gin.Context) {
+	var req struct {
+		Code string `json:"code" binding:"required,len=6,numeric"`
+	}
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	resp, err := h.proxy.ConfirmTwoFactor(c.Request.Context(), accessToken(c), req.Code)
+	if err != nil {
+		handleGRPCError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": resp})
+}
+
+// DisableTwoFactor handles POST /api/v1/users/me/2fa/disable. code is a
+// current TOTP code or a recovery code.
+func (h *UserHandler) DisableTwoFactor(c *gin.Context) {
+	var req struct {
+		Password string `json:"password" binding:"required"`
+		Code     string `json:"code" binding:"required"`
+	}
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	resp, err := h.proxy.DisableTwoFactor(c.Request.Context(), accessToken(c), &pb.DisableTwoFactorRequest{
+		Password: req.Password,
+		Code:     req.Code,
+	})
+	if err != nil {
+		handleGRPCError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": resp.Message})
+}
+
+// accessToken returns the bearer token of the request; AuthMiddleware has
+// already checked the header's format and the token
+func accessToken(c *gin.Context) string {
+	return strings.TrimPrefix(c.GetHeader("Authorization"), "Bearer ")
+}
+
 // UpdateUser handles PUT /api/v1/users/:id
 func (h *UserHandler) UpdateUser(c *gin.Context) {
 	idStr := c.Param("id")
@@ -134,8 +237,7 @@ func (h *UserHandler) UpdateUser(c *gin.Context) {
 	}
 
 	var req pb.UpdateUserRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+	if !bindJSON(c, &req) {
 		return
 	}
 	req.Id = id
@@ -170,3 +272,59 @@ func (h *UserHandler) DeleteUser(c *gin.Context) {
 
 	c.JSON(http.StatusNoContent, nil)
 }
+
+// GetAuditLog handles GET /api/v1/admin/audit-log (Admin only).
+// actor_user_id, action and target_id filter exactly; from and to are
+// optional RFC 3339 times. Entries are returned newest first.
+func (h *UserHandler) GetAuditLog(c *gin.Context) {
+	req := &pb.GetAuditLogRequest{
+		Action:   c.Query("action"),
+		TargetId: c.Query("target_id"),
+	}
+	if actor := c.Query("actor_user_id"); actor != "" {
+		actorID, err := strconv.ParseInt(actor, 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "actor_user_id must be a number"})
+			return
+		}
+		req.ActorUserId = actorID
+	}
+
+	bounds := []struct {
+		param string
+		dest  **timestamppb.Timestamp
+	}{
+		{"from", &req.From},
+		{"to", &req.To},
+	}
+	for _, b := range bounds {
+		value := c.Query(b.param)
+		if value == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": b.param + " must be an RFC 3339 time"})
+			return
+		}
+		*b.dest = timestamppb.New(t)
+	}
+
+	page, _ := strconv.ParseInt(c.DefaultQuery("page", "1"), 10, 32)
+	pageSize, _ := strconv.ParseInt(c.DefaultQuery("page_size", "50"), 10, 32)
+	req.Page = int32(page)
+	req.PageSize = int32(pageSize)
+
+	resp, err := h.proxy.GetAuditLog(c.Request.Context(), req)
+	if err != nil {
+		handleGRPCError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":      resp.Entries,
+		"total":     resp.TotalCount,
+		"page":      page,
+		"page_size": pageSize,
+	})
+}
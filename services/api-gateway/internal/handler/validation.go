@@ -0,0 +1,141 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+
+	sharedValidator "github.com/datngth03/ecommerce-go-app/shared/pkg/validator"
+)
+
+func init() {
+	// Report fields by their JSON names so clients can match errors to their input
+	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		v.RegisterTagNameFunc(jsonFieldName)
+		v.RegisterValidation("strong_password", strongPassword)
+	}
+}
+
+// bindJSON decodes the request body into req and checks its `binding` tags.
+// When the body is unusable it writes the response and returns false: 400 for
+// malformed JSON, 422 with a message per field when the body fails validation.
+// Fields are keyed by their JSON path, e.g. "items[0].quantity".
+func bindJSON(c *gin.Context, req interface{}) bool {
+	err := c.ShouldBindJSON(req)
+	if err == nil {
+		return true
+	}
+
+	var validationErrs validator.ValidationErrors
+	if errors.As(err, &validationErrs) {
+		typeName := reflect.Indirect(reflect.ValueOf(req)).Type().Name()
+		fields := make(map[string]string, len(validationErrs))
+		for _, fe := range validationErrs {
+			fields[strings.TrimPrefix(fe.Namespace(), typeName+".")] = fieldErrorMessage(fe)
+		}
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "validation failed", "fields": fields})
+		return false
+	}
+
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) && typeErr.Field != "" {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{
+			"error":  "validation failed",
+			"fields": map[string]string{typeErr.Field: "must be " + jsonTypeName(typeErr.Type)},
+		})
+		return false
+	}
+
+	c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+	return false
+}
+
+// strongPassword applies the user service's password rules, so weak
+// passwords fail here with a field error
+func strongPassword(fl validator.FieldLevel) bool {
+	return sharedValidator.ValidatePassword(fl.Field().String()) == nil
+}
+
+// jsonFieldName is the name a struct field has in JSON
+func jsonFieldName(field reflect.StructField) string {
+	name := strings.SplitN(field.Tag.Get("json"), ",", 2)[0]
+	switch name {
+	case "-":
+		return ""
+	case "":
+		return field.Name
+	}
+	return name
+}
+
+// fieldErrorMessage describes a failed rule in terms a client can act on
+func fieldErrorMessage(fe validator.FieldError) string {
+	switch fe.Tag() {
+	case "required", "required_if":
+		return "is required"
+	case "email":
+		return "must be a valid email address"
+	case "url":
+		return "must be a valid URL"
+	case "numeric":
+		return "must contain only digits"
+	case "oneof":
+		return "must be one of: " + strings.ReplaceAll(fe.Param(), " ", ", ")
+	case "len":
+		return "must be exactly " + sizeDescription(fe)
+	case "min":
+		return "must be at least " + sizeDescription(fe)
+	case "max":
+		return "must be at most " + sizeDescription(fe)
+	case "gt":
+		return "must be greater than " + fe.Param()
+	case "gte":
+		return "must be at least " + fe.Param()
+	case "lt":
+		return "must be less than " + fe.Param()
+	case "lte":
+		return "must be at most " + fe.Param()
+	case "strong_password":
+		return "must be at most 128 characters and contain an uppercase letter, a lowercase letter and a digit"
+	default:
+		return fmt.Sprintf("is invalid (%s)", fe.Tag())
+	}
+}
+
+// sizeDescription phrases a min/max/len parameter for the field's kind
+func sizeDescription(fe validator.FieldError) string {
+	switch fe.Kind() {
+	case reflect.String:
+		return fe.Param() + " characters"
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return fe.Param() + " items"
+	default:
+		return fe.Param()
+	}
+}
+
+// jsonTypeName names the JSON type a Go type is decoded from
+func jsonTypeName(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.String:
+		return "a string"
+	case reflect.Bool:
+		return "a boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "an integer"
+	case reflect.Float32, reflect.Float64:
+		return "a number"
+	case reflect.Slice, reflect.Array:
+		return "an array"
+	default:
+		return "an object"
+	}
+}
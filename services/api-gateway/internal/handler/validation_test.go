@@ -0,0 +1,141 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestRequestValidation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	authenticated := func(c *gin.Context) { c.Set("user_id", int64(7)) }
+	// Handlers have no backends: every request below must be rejected before reaching one
+	router.POST("/auth/register", (&UserHandler{}).Register)
+	router.POST("/users/me/password", authenticated, (&UserHandler{}).ChangePassword)
+	router.POST("/users/me/2fa/confirm", authenticated, (&UserHandler{}).ConfirmTwoFactor)
+	router.POST("/orders", authenticated, (&OrderHandler{}).CreateOrder)
+	router.POST("/payments", authenticated, (&PaymentHandler{}).ProcessPayment)
+	router.POST("/inventory/check", (&InventoryHandler{}).CheckAvailability)
+
+	tests := []struct {
+		name       string
+		path       string
+		body       string
+		wantStatus int
+		wantFields map[string]string
+	}{
+		{
+			name:       "register missing fields",
+			path:       "/auth/register",
+			body:       `{"name":"Jo"}`,
+			wantStatus: http.StatusUnprocessableEntity,
+			wantFields: map[string]string{"email": "is required", "password": "is required"},
+		},
+		{
+			name:       "register invalid fields",
+			path:       "/auth/register",
+			body:       `{"email":"not-an-email","name":"J","password":"short"}`,
+			wantStatus: http.StatusUnprocessableEntity,
+			wantFields: map[string]string{
+				"email":    "must be a valid email address",
+				"name":     "must be at least 2 characters",
+				"password": "must be at least 8 characters",
+			},
+		},
+		{
+			name:       "register weak password",
+			path:       "/auth/register",
+			body:       `{"email":"jo@example.com","name":"Jo","password":"alllowercase1"}`,
+			wantStatus: http.StatusUnprocessableEntity,
+			wantFields: map[string]string{
+				"password": "must be at most 128 characters and contain an uppercase letter, a lowercase letter and a digit",
+			},
+		},
+		{
+			name:       "change password missing current and weak new",
+			path:       "/users/me/password",
+			body:       `{"new_password":"NoDigitsHere"}`,
+			wantStatus: http.StatusUnprocessableEntity,
+			wantFields: map[string]string{
+				"current_password": "is required",
+				"new_password":     "must be at most 128 characters and contain an uppercase letter, a lowercase letter and a digit",
+			},
+		},
+		{
+			name:       "two-factor code not six digits",
+			path:       "/users/me/2fa/confirm",
+			body:       `{"code":"12a45b"}`,
+			wantStatus: http.StatusUnprocessableEntity,
+			wantFields: map[string]string{"code": "must contain only digits"},
+		},
+		{
+			name:       "order missing address",
+			path:       "/orders",
+			body:       `{"payment_method":"card","coupon_code":"` + strings.Repeat("X", 51) + `"}`,
+			wantStatus: http.StatusUnprocessableEntity,
+			wantFields: map[string]string{
+				"shipping_address": "is required",
+				"coupon_code":      "must be at most 50 characters",
+			},
+		},
+		{
+			name:       "payment invalid amount and currency",
+			path:       "/payments",
+			body:       `{"order_id":"o-1","amount":-5,"method":"card","currency":"DOLLARS"}`,
+			wantStatus: http.StatusUnprocessableEntity,
+			wantFields: map[string]string{
+				"amount":   "must be greater than 0",
+				"currency": "must be exactly 3 characters",
+			},
+		},
+		{
+			name:       "payment amount of the wrong type",
+			path:       "/payments",
+			body:       `{"order_id":"o-1","amount":"ten","method":"card"}`,
+			wantStatus: http.StatusUnprocessableEntity,
+			wantFields: map[string]string{"amount": "must be a number"},
+		},
+		{
+			name:       "nested item fields",
+			path:       "/inventory/check",
+			body:       `{"items":[{"product_id":"p-1","quantity":1},{"quantity":0}]}`,
+			wantStatus: http.StatusUnprocessableEntity,
+			wantFields: map[string]string{"items[1].product_id": "is required", "items[1].quantity": "is required"},
+		},
+		{
+			name:       "malformed JSON",
+			path:       "/auth/register",
+			body:       `{"email":`,
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, tt.path, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d (body %s)", w.Code, tt.wantStatus, w.Body)
+			}
+			var resp struct {
+				Error  string            `json:"error"`
+				Fields map[string]string `json:"fields"`
+			}
+			if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("invalid response body %s: %v", w.Body, err)
+			}
+			if tt.wantFields != nil && !reflect.DeepEqual(resp.Fields, tt.wantFields) {
+				t.Errorf("fields = %v, want %v", resp.Fields, tt.wantFields)
+			}
+		})
+	}
+}
@@ -27,7 +27,8 @@ var (
 	authFailuresTotal prometheus.Counter
 
 	// Rate limit metrics
-	rateLimitExceededTotal *prometheus.CounterVec
+	rateLimitExceededTotal  *prometheus.CounterVec
+	rateLimitThrottledTotal *prometheus.CounterVec
 
 	// Active connections
 	activeConnections prometheus.Gauge
@@ -118,6 +119,14 @@ func initMetrics() {
 			[]string{"ip"},
 		)
 
+		rateLimitThrottledTotal = prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "api_gateway_rate_limit_throttled_total",
+				Help: "Total number of requests rejected by a rate limiter, by route group",
+			},
+			[]string{"route_group"},
+		)
+
 		// Active connections
 		activeConnections = prometheus.NewGauge(
 			prometheus.GaugeOpts{
@@ -137,6 +146,7 @@ func initMetrics() {
 			authRequestsTotal,
 			authFailuresTotal,
 			rateLimitExceededTotal,
+			rateLimitThrottledTotal,
 			activeConnections,
 		}
 
@@ -205,3 +215,9 @@ func RecordRateLimitExceeded(ip string) {
 	initMetrics()
 	rateLimitExceededTotal.WithLabelValues(ip).Inc()
 }
+
+// RecordRateLimitThrottled increments the throttled request counter for a route group
+func RecordRateLimitThrottled(routeGroup string) {
+	initMetrics()
+	rateLimitThrottledTotal.WithLabelValues(routeGroup).Inc()
+}
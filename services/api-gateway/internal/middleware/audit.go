@@ -0,0 +1,76 @@
+package middleware
+
+import (
+	"context"
+	"log"
+	"time"
+
+	pb "github.com/datngth03/ecommerce-go-app/proto/user_service"
+	"github.com/gin-gonic/gin"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// AuditTargetKey is the context key a handler sets to the ID of a resource it
+// created, since create routes carry no ID in the path
+const AuditTargetKey = "audit_target_id"
+
+// auditTimeout bounds recording one entry after the response was sent
+const auditTimeout = 5 * time.Second
+
+// AuditRecorder stores audit log entries
+type AuditRecorder interface {
+	RecordAuditEntry(ctx context.Context, req *pb.RecordAuditEntryRequest) (*pb.RecordAuditEntryResponse, error)
+}
+
+// Audit records action (e.g. "product.update") in the audit log when an authenticated
+// request succeeds with a 2xx. It must run after AuthMiddleware. Recording happens
+// after the response so it adds no latency; a failure is logged, not returned.
+func Audit(recorder AuditRecorder, action string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		statusCode := c.Writer.Status()
+		if statusCode < 200 || statusCode > 299 {
+			return
+		}
+		userID, ok := c.Get("user_id")
+		if !ok {
+			return
+		}
+		actorID, _ := userID.(int64)
+
+		req := &pb.RecordAuditEntryRequest{
+			ActorUserId: actorID,
+			Action:      action,
+			TargetId:    auditTarget(c),
+			Method:      c.Request.Method,
+			Path:        c.Request.URL.Path,
+			StatusCode:  int32(statusCode),
+			SourceIp:    c.ClientIP(),
+			OccurredAt:  timestamppb.Now(),
+		}
+
+		// Keep trace values but not the request's cancellation
+		ctx := context.WithoutCancel(c.Request.Context())
+		go func() {
+			ctx, cancel := context.WithTimeout(ctx, auditTimeout)
+			defer cancel()
+			if _, err := recorder.RecordAuditEntry(ctx, req); err != nil {
+				log.Printf("Failed to record audit entry %s by user %d on %s: %v", req.Action, req.ActorUserId, req.TargetId, err)
+			}
+		}()
+	}
+}
+
+// auditTarget is the ID a handler reported, or else the resource ID in the path
+func auditTarget(c *gin.Context) string {
+	if target := c.GetString(AuditTargetKey); target != "" {
+		return target
+	}
+	for _, param := range []string{"id", "product_id"} {
+		if value := c.Param(param); value != "" {
+			return value
+		}
+	}
+	return ""
+}
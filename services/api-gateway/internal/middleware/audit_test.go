@@ -0,0 +1,73 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	pb "github.com/datngth03/ecommerce-go-app/proto/user_service"
+	"github.com/gin-gonic/gin"
+)
+
+type recorderFunc func(ctx context.Context, req *pb.RecordAuditEntryRequest) (*pb.RecordAuditEntryResponse, error)
+
+func (f recorderFunc) RecordAuditEntry(ctx context.Context, req *pb.RecordAuditEntryRequest) (*pb.RecordAuditEntryResponse, error) {
+	return f(ctx, req)
+}
+
+func TestAuditRecordsSuccessfulMutations(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	entries := make(chan *pb.RecordAuditEntryRequest, 4)
+	recorder := recorderFunc(func(_ context.Context, req *pb.RecordAuditEntryRequest) (*pb.RecordAuditEntryResponse, error) {
+		entries <- req
+		return &pb.RecordAuditEntryResponse{Success: true}, nil
+	})
+
+	router := gin.New()
+	authenticated := func(c *gin.Context) { c.Set("user_id", int64(7)) }
+	router.PUT("/products/:id", authenticated, Audit(recorder, "product.update"), func(c *gin.Context) { c.Status(http.StatusOK) })
+	router.POST("/products", authenticated, Audit(recorder, "product.create"), func(c *gin.Context) {
+		c.Set(AuditTargetKey, "p-new")
+		c.Status(http.StatusCreated)
+	})
+	router.DELETE("/products/:id", authenticated, Audit(recorder, "product.delete"), func(c *gin.Context) { c.Status(http.StatusNotFound) })
+	router.DELETE("/categories/:id", Audit(recorder, "category.delete"), func(c *gin.Context) { c.Status(http.StatusNoContent) })
+
+	send := func(method, path string) {
+		req := httptest.NewRequest(method, path, nil)
+		req.RemoteAddr = "10.0.0.1:1234"
+		router.ServeHTTP(httptest.NewRecorder(), req)
+	}
+	next := func() *pb.RecordAuditEntryRequest {
+		select {
+		case entry := <-entries:
+			return entry
+		case <-time.After(time.Second):
+			t.Fatal("audit entry not recorded")
+			return nil
+		}
+	}
+
+	send(http.MethodPut, "/products/p-1")
+	entry := next()
+	if entry.ActorUserId != 7 || entry.Action != "product.update" || entry.TargetId != "p-1" ||
+		entry.Method != http.MethodPut || entry.StatusCode != http.StatusOK || entry.SourceIp != "10.0.0.1" {
+		t.Errorf("unexpected entry %+v", entry)
+	}
+
+	send(http.MethodPost, "/products")
+	if entry := next(); entry.TargetId != "p-new" {
+		t.Errorf("create target = %q, want the ID set by the handler", entry.TargetId)
+	}
+
+	// Failed requests and unauthenticated callers are not audited
+	send(http.MethodDelete, "/products/p-1")
+	send(http.MethodDelete, "/categories/c-1")
+	select {
+	case entry := <-entries:
+		t.Errorf("unexpected entry %+v", entry)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
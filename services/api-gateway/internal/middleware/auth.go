@@ -2,7 +2,9 @@ package middleware
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log"
 	"net/http"
 	"strings"
 
@@ -19,9 +21,72 @@ type UserInfo struct {
 	IsActive bool   `json:"is_active"`
 }
 
-// AuthMiddleware validates JWT token by calling User Service via proxy
-func AuthMiddleware(userProxy *proxy.UserProxy) gin.HandlerFunc {
+// remoteValidatedKey marks a request whose token the User Service has validated
+const remoteValidatedKey = "token_remote_validated"
+
+// Authenticator validates access tokens, locally when a TokenVerifier is
+// configured and by calling the User Service otherwise
+type Authenticator struct {
+	userProxy   *proxy.UserProxy
+	local       TokenVerifier     // Optional; nil validates every token remotely
+	revocations RevocationChecker // Optional; checked after local verification
+}
+
+// NewAuthenticator creates an Authenticator; local and revocations may be nil.
+// Without a RevocationChecker, locally verified tokens stay usable after
+// logout until they expire.
+func NewAuthenticator(userProxy *proxy.UserProxy, local TokenVerifier, revocations RevocationChecker) *Authenticator {
+	return &Authenticator{userProxy: userProxy, local: local, revocations: revocations}
+}
+
+// authenticate validates token locally when possible and reports whether the
+// User Service validated it. Tokens the local verifier has no key for, and
+// tokens whose revocation can't be checked, are validated by the User Service.
+func (a *Authenticator) authenticate(ctx context.Context, token string) (*UserInfo, bool, error) {
+	if a.local != nil {
+		userInfo, err := a.local.Verify(token)
+		switch {
+		case errors.Is(err, errNotLocallyVerifiable):
+		case err != nil:
+			return nil, false, err
+		case a.revocations == nil:
+			return userInfo, false, nil
+		default:
+			revoked, err := a.revocations.IsRevoked(ctx, token)
+			if err == nil {
+				if revoked {
+					return nil, false, errors.New("token has been revoked")
+				}
+				return userInfo, false, nil
+			}
+			log.Printf("Warning: token revocation check failed, validating remotely: %v", err)
+		}
+	}
+
+	userInfo, err := validateTokenWithUserProxy(ctx, a.userProxy, token)
+	return userInfo, err == nil, err
+}
+
+// AuthMiddleware validates JWT token locally or by calling User Service via proxy
+func AuthMiddleware(auth *Authenticator) gin.HandlerFunc {
+	return authMiddleware(auth, false)
+}
+
+// RemoteAuthMiddleware always validates JWT token with User Service, which
+// checks it against the logout blacklist, even when local verification is on.
+// Use it for revocation-sensitive routes: credentials, account changes, payments and admin.
+func RemoteAuthMiddleware(auth *Authenticator) gin.HandlerFunc {
+	return authMiddleware(auth, true)
+}
+
+func authMiddleware(auth *Authenticator, remoteOnly bool) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		// Token already validated by OptionalAuth or AuthMiddleware earlier in the chain
+		if _, exists := c.Get("user"); exists && (!remoteOnly || c.GetBool(remoteValidatedKey)) {
+			c.Next()
+			return
+		}
+
 		// Extract token from header
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" {
@@ -44,8 +109,16 @@ func AuthMiddleware(userProxy *proxy.UserProxy) gin.HandlerFunc {
 
 		token := parts[1]
 
-		// Validate token with User Service via proxy
-		userInfo, err := validateTokenWithUserProxy(userProxy, token)
+		// Validate token locally or with User Service via proxy
+		var userInfo *UserInfo
+		var remote bool
+		var err error
+		if remoteOnly {
+			userInfo, err = validateTokenWithUserProxy(c.Request.Context(), auth.userProxy, token)
+			remote = true
+		} else {
+			userInfo, remote, err = auth.authenticate(c.Request.Context(), token)
+		}
 		if err != nil {
 			c.JSON(http.StatusUnauthorized, gin.H{
 				"error": "Invalid or expired token",
@@ -57,6 +130,7 @@ func AuthMiddleware(userProxy *proxy.UserProxy) gin.HandlerFunc {
 		// Store user info in context
 		c.Set("user", userInfo)
 		c.Set("user_id", userInfo.ID)
+		c.Set(remoteValidatedKey, remote)
 		// No role field available in current schema
 
 		c.Next()
@@ -85,8 +159,7 @@ func RequireAdmin() gin.HandlerFunc {
 			return
 		}
 
-		// Check if user is admin by email (temporary solution until role field is added)
-		if userInfo.Email != "admin@example.com" {
+		if !isAdmin(userInfo) {
 			c.JSON(http.StatusForbidden, gin.H{
 				"error": "Admin access required",
 			})
@@ -98,8 +171,13 @@ func RequireAdmin() gin.HandlerFunc {
 	}
 }
 
+// isAdmin checks admin by email (temporary solution until role field is added)
+func isAdmin(userInfo *UserInfo) bool {
+	return userInfo.Email == "admin@example.com"
+}
+
 // OptionalAuth tries to validate token but doesn't fail if missing
-func OptionalAuth(userProxy *proxy.UserProxy) gin.HandlerFunc {
+func OptionalAuth(auth *Authenticator) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" {
@@ -110,10 +188,11 @@ func OptionalAuth(userProxy *proxy.UserProxy) gin.HandlerFunc {
 		parts := strings.Split(authHeader, " ")
 		if len(parts) == 2 && parts[0] == "Bearer" {
 			token := parts[1]
-			userInfo, err := validateTokenWithUserProxy(userProxy, token)
+			userInfo, remote, err := auth.authenticate(c.Request.Context(), token)
 			if err == nil {
 				c.Set("user", userInfo)
 				c.Set("user_id", userInfo.ID)
+				c.Set(remoteValidatedKey, remote)
 				// No role field available in current schema
 			}
 		}
@@ -123,9 +202,8 @@ func OptionalAuth(userProxy *proxy.UserProxy) gin.HandlerFunc {
 }
 
 // validateTokenWithUserProxy calls User Service via gRPC proxy to validate token
-func validateTokenWithUserProxy(userProxy *proxy.UserProxy, token string) (*UserInfo, error) {
+func validateTokenWithUserProxy(ctx context.Context, userProxy *proxy.UserProxy, token string) (*UserInfo, error) {
 	// Call user service via gRPC
-	ctx := context.Background()
 	response, err := userProxy.ValidateToken(ctx, token)
 	if err != nil {
 		return nil, fmt.Errorf("failed to validate token with user service: %w", err)
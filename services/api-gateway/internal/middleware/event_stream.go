@@ -0,0 +1,30 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+// eventStreamRoutes are the route patterns serving Server-Sent Events. Streams are
+// recognised by route, not by the client-controlled Accept header, so a caller can't
+// opt an ordinary request out of timeouts and compression.
+var eventStreamRoutes = map[string]bool{
+	"/api/v1/orders/:id/stream": true,
+}
+
+// IsEventStream reports whether the request is for a Server-Sent Events route
+func IsEventStream(c *gin.Context) bool {
+	return eventStreamRoutes[c.FullPath()]
+}
+
+// SkipForEventStream bypasses mw for Server-Sent Events requests. Use it for middleware
+// that breaks long-lived streams, such as response compression (buffers writes) or
+// request timeouts.
+func SkipForEventStream(mw gin.HandlerFunc) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if IsEventStream(c) {
+			c.Next()
+			return
+		}
+		mw(c)
+	}
+}
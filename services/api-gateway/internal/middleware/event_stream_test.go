@@ -0,0 +1,35 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestSkipForEventStreamMatchesRouteNotHeader(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(SkipForEventStream(RequestTimeoutMiddleware(20 * time.Millisecond)))
+
+	router.GET("/api/v1/orders/:id/stream", slowBackend(100*time.Millisecond))
+	router.GET("/api/v1/orders/:id", slowBackend(100*time.Millisecond))
+
+	serve := func(path string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.Header.Set("Accept", "text/event-stream")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	if w := serve("/api/v1/orders/1/stream"); w.Code != http.StatusOK {
+		t.Fatalf("order stream: got %d, want 200 without a timeout", w.Code)
+	}
+	// The Accept header alone must not lift the timeout of an ordinary route
+	if w := serve("/api/v1/orders/1"); w.Code != http.StatusGatewayTimeout {
+		t.Fatalf("ordinary route asking for an event stream: got %d, want 504", w.Code)
+	}
+}
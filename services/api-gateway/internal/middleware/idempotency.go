@@ -0,0 +1,249 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// IdempotencyKeyHeader is the request header carrying the client's idempotency key
+	IdempotencyKeyHeader = "Idempotency-Key"
+	// IdempotentReplayedHeader is set on responses replayed from the cache
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+
+	maxIdempotencyKeyLength = 255
+	idempotencyStoreTimeout = time.Second
+)
+
+// CachedResponse is the stored result of the first request made with an idempotency key
+type CachedResponse struct {
+	Fingerprint string `json:"fingerprint"` // Hash of method, path and body
+	Status      int    `json:"status"`
+	ContentType string `json:"content_type"`
+	Body        []byte `json:"body"`
+}
+
+// IdempotencyStore persists in-flight locks and cached responses per idempotency key
+type IdempotencyStore interface {
+	// Lock claims key for an in-flight request; false means another request holds it
+	Lock(ctx context.Context, key string, ttl time.Duration) (bool, error)
+	// Unlock releases the in-flight lock without caching a response
+	Unlock(ctx context.Context, key string) error
+	// Get returns the cached response, or nil when there is none
+	Get(ctx context.Context, key string) (*CachedResponse, error)
+	// Save caches the response and releases the in-flight lock
+	Save(ctx context.Context, key string, resp *CachedResponse, ttl time.Duration) error
+}
+
+// IdempotencyOptions configures the idempotency middleware
+type IdempotencyOptions struct {
+	TTL     time.Duration // How long a response is replayed
+	LockTTL time.Duration // Upper bound on an in-flight request holding its key
+}
+
+// IdempotencyMiddleware makes POST requests carrying an Idempotency-Key safe to retry.
+// The first response for a key (scoped to the caller) is cached and replayed for later
+// requests with the same key; while the first request is still in flight, retries get 409.
+// Reusing a key with a different method, path or body returns 422. 5xx responses are not
+// cached so the client can retry them. It must run after AuthMiddleware. If the store is
+// unavailable, requests pass through without idempotency.
+func IdempotencyMiddleware(store IdempotencyStore, opts IdempotencyOptions) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		idempotencyKey := c.GetHeader(IdempotencyKeyHeader)
+		if c.Request.Method != http.MethodPost || idempotencyKey == "" {
+			c.Next()
+			return
+		}
+		if len(idempotencyKey) > maxIdempotencyKeyLength {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+				"error":   "Invalid idempotency key",
+				"message": fmt.Sprintf("%s must be at most %d characters", IdempotencyKeyHeader, maxIdempotencyKeyLength),
+			})
+			return
+		}
+
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+				"error":   "Invalid request body",
+				"message": err.Error(),
+			})
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		scope, _ := callerKey(c)
+		key := scope + ":" + idempotencyKey
+		fingerprint := requestFingerprint(c.Request.Method, c.Request.URL.Path, body)
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), idempotencyStoreTimeout)
+		defer cancel()
+
+		if cached, err := store.Get(ctx, key); err != nil {
+			log.Printf("Idempotency store unavailable, skipping key %q: %v", idempotencyKey, err)
+			c.Next()
+			return
+		} else if cached != nil {
+			replayCachedResponse(c, cached, fingerprint)
+			return
+		}
+
+		locked, err := store.Lock(ctx, key, opts.LockTTL)
+		if err != nil {
+			log.Printf("Idempotency store unavailable, skipping key %q: %v", idempotencyKey, err)
+			c.Next()
+			return
+		}
+		if !locked {
+			// The first request may have finished between Get and Lock
+			if cached, err := store.Get(ctx, key); err == nil && cached != nil {
+				replayCachedResponse(c, cached, fingerprint)
+				return
+			}
+			c.Header("Retry-After", "1")
+			c.AbortWithStatusJSON(http.StatusConflict, gin.H{
+				"error":   "Request in progress",
+				"message": "A request with this idempotency key is still being processed",
+			})
+			return
+		}
+
+		recorder := &responseRecorder{ResponseWriter: c.Writer}
+		c.Writer = recorder
+
+		c.Next()
+
+		// The request context may be done by now; store with a fresh timeout
+		storeCtx, storeCancel := context.WithTimeout(context.Background(), idempotencyStoreTimeout)
+		defer storeCancel()
+
+		status := recorder.Status()
+		if status >= http.StatusInternalServerError {
+			if err := store.Unlock(storeCtx, key); err != nil {
+				log.Printf("Failed to release idempotency key %q: %v", idempotencyKey, err)
+			}
+			return
+		}
+
+		resp := &CachedResponse{
+			Fingerprint: fingerprint,
+			Status:      status,
+			ContentType: recorder.Header().Get("Content-Type"),
+			Body:        recorder.body.Bytes(),
+		}
+		if err := store.Save(storeCtx, key, resp, opts.TTL); err != nil {
+			log.Printf("Failed to cache response for idempotency key %q: %v", idempotencyKey, err)
+		}
+	}
+}
+
+// replayCachedResponse writes a cached response, or 422 if the key was used for another request
+func replayCachedResponse(c *gin.Context, cached *CachedResponse, fingerprint string) {
+	if cached.Fingerprint != fingerprint {
+		c.AbortWithStatusJSON(http.StatusUnprocessableEntity, gin.H{
+			"error":   "Idempotency key reused",
+			"message": "This idempotency key was already used for a different request",
+		})
+		return
+	}
+
+	c.Header(IdempotentReplayedHeader, "true")
+	c.Data(cached.Status, cached.ContentType, cached.Body)
+	c.Abort()
+}
+
+// requestFingerprint hashes what makes two requests with the same key identical
+func requestFingerprint(method, path string, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(method + " " + path + "\n"))
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// responseRecorder copies the response body while writing it to the client
+type responseRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *responseRecorder) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *responseRecorder) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
+
+// RedisIdempotencyStore keeps idempotency state in Redis so it is shared by all gateway instances
+type RedisIdempotencyStore struct {
+	client *redis.Client
+}
+
+// NewRedisIdempotencyStore creates a Redis-backed idempotency store
+func NewRedisIdempotencyStore(client *redis.Client) *RedisIdempotencyStore {
+	return &RedisIdempotencyStore{client: client}
+}
+
+func idempotencyResponseKey(key string) string {
+	return "idempotency:" + key
+}
+
+func idempotencyLockKey(key string) string {
+	return "idempotency:" + key + ":lock"
+}
+
+// Lock claims key with SET NX; the TTL frees it if the gateway dies mid-request
+func (s *RedisIdempotencyStore) Lock(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	return s.client.SetNX(ctx, idempotencyLockKey(key), 1, ttl).Result()
+}
+
+// Unlock releases the in-flight lock
+func (s *RedisIdempotencyStore) Unlock(ctx context.Context, key string) error {
+	return s.client.Del(ctx, idempotencyLockKey(key)).Err()
+}
+
+// Get returns the cached response for key, or nil if there is none
+func (s *RedisIdempotencyStore) Get(ctx context.Context, key string) (*CachedResponse, error) {
+	data, err := s.client.Get(ctx, idempotencyResponseKey(key)).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var resp CachedResponse
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, fmt.Errorf("failed to decode cached response: %w", err)
+	}
+	return &resp, nil
+}
+
+// Save caches the response and releases the lock in one transaction
+func (s *RedisIdempotencyStore) Save(ctx context.Context, key string, resp *CachedResponse, ttl time.Duration) error {
+	data, err := json.Marshal(resp)
+	if err != nil {
+		return fmt.Errorf("failed to encode response: %w", err)
+	}
+
+	_, err = s.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Set(ctx, idempotencyResponseKey(key), data, ttl)
+		pipe.Del(ctx, idempotencyLockKey(key))
+		return nil
+	})
+	return err
+}
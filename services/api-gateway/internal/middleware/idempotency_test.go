@@ -0,0 +1,118 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// memoryIdempotencyStore is an in-process IdempotencyStore for tests
+type memoryIdempotencyStore struct {
+	mu        sync.Mutex
+	locks     map[string]bool
+	responses map[string]*CachedResponse
+}
+
+func newMemoryIdempotencyStore() *memoryIdempotencyStore {
+	return &memoryIdempotencyStore{
+		locks:     make(map[string]bool),
+		responses: make(map[string]*CachedResponse),
+	}
+}
+
+func (s *memoryIdempotencyStore) Lock(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.locks[key] {
+		return false, nil
+	}
+	s.locks[key] = true
+	return true, nil
+}
+
+func (s *memoryIdempotencyStore) Unlock(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.locks, key)
+	return nil
+}
+
+func (s *memoryIdempotencyStore) Get(ctx context.Context, key string) (*CachedResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.responses[key], nil
+}
+
+func (s *memoryIdempotencyStore) Save(ctx context.Context, key string, resp *CachedResponse, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.responses[key] = resp
+	delete(s.locks, key)
+	return nil
+}
+
+func TestIdempotencyMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	store := newMemoryIdempotencyStore()
+	calls := 0
+
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("user", &UserInfo{ID: 1, Email: "alice@example.com"})
+		c.Next()
+	})
+	router.Use(IdempotencyMiddleware(store, IdempotencyOptions{TTL: time.Hour, LockTTL: time.Minute}))
+	router.POST("/orders", func(c *gin.Context) {
+		calls++
+		c.JSON(http.StatusCreated, gin.H{"order_id": calls})
+	})
+
+	post := func(key, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/orders", strings.NewReader(body))
+		if key != "" {
+			req.Header.Set(IdempotencyKeyHeader, key)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	first := post("abc", `{"items":[1]}`)
+	if first.Code != http.StatusCreated {
+		t.Fatalf("first request: got %d", first.Code)
+	}
+
+	replay := post("abc", `{"items":[1]}`)
+	if replay.Code != http.StatusCreated || replay.Body.String() != first.Body.String() {
+		t.Fatalf("replay: got %d %s, want %d %s", replay.Code, replay.Body, first.Code, first.Body)
+	}
+	if replay.Header().Get(IdempotentReplayedHeader) != "true" {
+		t.Error("replayed response is missing Idempotent-Replayed")
+	}
+	if calls != 1 {
+		t.Fatalf("backend called %d times, want 1", calls)
+	}
+
+	if w := post("abc", `{"items":[2]}`); w.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("key reused with another body: got %d, want 422", w.Code)
+	}
+
+	// A request still in flight holds the lock
+	store.Lock(context.Background(), "user:1:pending", time.Minute)
+	if w := post("pending", `{}`); w.Code != http.StatusConflict {
+		t.Fatalf("in-flight key: got %d, want 409", w.Code)
+	}
+
+	// Requests without a key are never deduplicated
+	post("", `{}`)
+	post("", `{}`)
+	if calls != 3 {
+		t.Fatalf("backend called %d times, want 3", calls)
+	}
+}
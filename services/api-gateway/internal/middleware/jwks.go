@@ -0,0 +1,164 @@
+package middleware
+
+import (
+	"context"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"log"
+	"math/big"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// minKeyRefetchInterval limits refetches triggered by tokens with an unknown kid
+const minKeyRefetchInterval = 30 * time.Second
+
+// JWKSVerifier verifies RS256 access tokens against the User Service's JWKS.
+// Keys are cached and refetched every refresh interval, and early when a token
+// names a kid not seen yet, so rotated keys are picked up without a restart.
+type JWKSVerifier struct {
+	url        string
+	httpClient *http.Client
+
+	mu          sync.RWMutex
+	keys        map[string]*rsa.PublicKey
+	lastFetched time.Time
+}
+
+// NewJWKSVerifier fetches the key set at url and refreshes it every
+// refreshInterval until ctx is done. A failed first fetch is logged and retried
+// on the next token; until then tokens fall back to remote validation.
+func NewJWKSVerifier(ctx context.Context, url string, refreshInterval time.Duration) *JWKSVerifier {
+	v := &JWKSVerifier{
+		url:        url,
+		httpClient: &http.Client{Timeout: 5 * time.Second},
+		keys:       make(map[string]*rsa.PublicKey),
+	}
+
+	if err := v.refresh(ctx); err != nil {
+		log.Printf("Warning: failed to fetch JWKS from %s: %v", url, err)
+	}
+
+	if refreshInterval > 0 {
+		go func() {
+			ticker := time.NewTicker(refreshInterval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+					if err := v.refresh(ctx); err != nil {
+						log.Printf("Warning: failed to refresh JWKS from %s: %v", url, err)
+					}
+				}
+			}
+		}()
+	}
+
+	return v
+}
+
+// Verify checks an access token's RS256 signature and expiry. Tokens without
+// a known kid return errNotLocallyVerifiable.
+func (v *JWKSVerifier) Verify(token string) (*UserInfo, error) {
+	return parseAccessToken(token, v.keyFor)
+}
+
+// keyFor returns the public key named by the token's kid. Only RS256 tokens
+// are checked here; others are left to the User Service.
+func (v *JWKSVerifier) keyFor(token *jwt.Token) (interface{}, error) {
+	if token.Method != jwt.SigningMethodRS256 {
+		return nil, errNotLocallyVerifiable
+	}
+	kid, _ := token.Header["kid"].(string)
+	if kid == "" {
+		return nil, errNotLocallyVerifiable
+	}
+
+	if key := v.key(kid); key != nil {
+		return key, nil
+	}
+
+	// Possibly a newly rotated key: refetch, but not on every unknown kid
+	v.mu.RLock()
+	stale := time.Since(v.lastFetched) >= minKeyRefetchInterval
+	v.mu.RUnlock()
+	if stale {
+		if err := v.refresh(context.Background()); err != nil {
+			log.Printf("Warning: failed to refresh JWKS from %s: %v", v.url, err)
+		}
+		if key := v.key(kid); key != nil {
+			return key, nil
+		}
+	}
+	return nil, errNotLocallyVerifiable
+}
+
+func (v *JWKSVerifier) key(kid string) *rsa.PublicKey {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	return v.keys[kid]
+}
+
+// refresh replaces the cached keys with the current key set
+func (v *JWKSVerifier) refresh(ctx context.Context) error {
+	v.mu.Lock()
+	v.lastFetched = time.Now()
+	v.mu.Unlock()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, v.url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := v.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+
+	var set struct {
+		Keys []struct {
+			KeyType  string `json:"kty"`
+			KeyID    string `json:"kid"`
+			Modulus  string `json:"n"`
+			Exponent string `json:"e"`
+		} `json:"keys"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&set); err != nil {
+		return fmt.Errorf("failed to decode key set: %w", err)
+	}
+
+	keys := make(map[string]*rsa.PublicKey, len(set.Keys))
+	for _, k := range set.Keys {
+		if k.KeyType != "RSA" || k.KeyID == "" {
+			continue
+		}
+		n, err := base64.RawURLEncoding.DecodeString(k.Modulus)
+		if err != nil {
+			return fmt.Errorf("invalid modulus for key %s: %w", k.KeyID, err)
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.Exponent)
+		if err != nil {
+			return fmt.Errorf("invalid exponent for key %s: %w", k.KeyID, err)
+		}
+		keys[k.KeyID] = &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}
+	}
+
+	v.mu.Lock()
+	v.keys = keys
+	v.mu.Unlock()
+	return nil
+}
@@ -0,0 +1,114 @@
+package middleware
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func TestJWKSVerifier(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rotated, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	published := map[string]*rsa.PublicKey{"k1": &key.PublicKey}
+	var fetches atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		fetches.Add(1)
+		keys := []map[string]string{}
+		for kid, pub := range published {
+			keys = append(keys, map[string]string{
+				"kty": "RSA", "use": "sig", "alg": "RS256", "kid": kid,
+				"n": base64.RawURLEncoding.EncodeToString(pub.N.Bytes()),
+				"e": base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes()),
+			})
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"keys": keys})
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	verifier := NewJWKSVerifier(ctx, server.URL, time.Hour)
+
+	sign := func(method jwt.SigningMethod, kid string, signingKey interface{}, expiresIn time.Duration) string {
+		token := jwt.NewWithClaims(method, &accessTokenClaims{
+			UserID:           42,
+			Email:            "user@example.com",
+			RegisteredClaims: jwt.RegisteredClaims{ExpiresAt: jwt.NewNumericDate(time.Now().Add(expiresIn))},
+		})
+		if kid != "" {
+			token.Header["kid"] = kid
+		}
+		signed, err := token.SignedString(signingKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return signed
+	}
+
+	t.Run("accepts a valid token", func(t *testing.T) {
+		user, err := verifier.Verify(sign(jwt.SigningMethodRS256, "k1", key, time.Minute))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if user.ID != 42 || user.Email != "user@example.com" {
+			t.Errorf("unexpected user %+v", user)
+		}
+	})
+
+	t.Run("rejects expired and forged tokens", func(t *testing.T) {
+		if _, err := verifier.Verify(sign(jwt.SigningMethodRS256, "k1", key, -time.Minute)); err == nil || errors.Is(err, errNotLocallyVerifiable) {
+			t.Errorf("expired token: err = %v, want rejection", err)
+		}
+		if _, err := verifier.Verify(sign(jwt.SigningMethodRS256, "k1", rotated, time.Minute)); err == nil || errors.Is(err, errNotLocallyVerifiable) {
+			t.Errorf("forged token: err = %v, want rejection", err)
+		}
+	})
+
+	t.Run("leaves HS256 tokens to the User Service", func(t *testing.T) {
+		_, err := verifier.Verify(sign(jwt.SigningMethodHS256, "", []byte("secret"), time.Minute))
+		if !errors.Is(err, errNotLocallyVerifiable) {
+			t.Errorf("err = %v, want errNotLocallyVerifiable", err)
+		}
+	})
+
+	t.Run("refetches keys for an unknown kid", func(t *testing.T) {
+		published["k2"] = &rotated.PublicKey
+		verifier.mu.Lock()
+		verifier.lastFetched = time.Time{}
+		verifier.mu.Unlock()
+
+		before := fetches.Load()
+		if _, err := verifier.Verify(sign(jwt.SigningMethodRS256, "k2", rotated, time.Minute)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if fetches.Load() != before+1 {
+			t.Errorf("fetches = %d, want %d", fetches.Load(), before+1)
+		}
+
+		// A second unknown kid right after is not refetched
+		if _, err := verifier.Verify(sign(jwt.SigningMethodRS256, "k3", rotated, time.Minute)); !errors.Is(err, errNotLocallyVerifiable) {
+			t.Errorf("err = %v, want errNotLocallyVerifiable", err)
+		}
+		if fetches.Load() != before+1 {
+			t.Errorf("fetches = %d, want %d", fetches.Load(), before+1)
+		}
+	})
+}
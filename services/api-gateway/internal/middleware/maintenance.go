@@ -0,0 +1,214 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
+)
+
+const maintenanceStoreTimeout = time.Second
+
+// MaintenanceState is the maintenance-mode setting shared by all gateway instances
+type MaintenanceState struct {
+	Enabled    bool          `json:"enabled"`
+	Message    string        `json:"message"`
+	RetryAfter time.Duration `json:"retry_after"`
+}
+
+// MaintenanceStore persists the maintenance state
+type MaintenanceStore interface {
+	// Get returns the stored state, or nil when none has been set
+	Get(ctx context.Context) (*MaintenanceState, error)
+	Set(ctx context.Context, state MaintenanceState) error
+	// Clear removes the stored state, so instances fall back to their configured default
+	Clear(ctx context.Context) error
+}
+
+// MaintenanceMode holds the current maintenance state. It starts from the
+// configured default; a state set through Set is stored and picked up by every
+// instance within the refresh interval. Without a store, changes only apply
+// to this instance.
+type MaintenanceMode struct {
+	store           MaintenanceStore
+	defaults        MaintenanceState
+	refreshInterval time.Duration
+
+	mu        sync.Mutex
+	state     MaintenanceState
+	fetchedAt time.Time
+	version   uint64 // Bumped by Set and Reset, so a slower read started before them is dropped
+}
+
+// NewMaintenanceMode creates a MaintenanceMode; store may be nil
+func NewMaintenanceMode(store MaintenanceStore, defaults MaintenanceState, refreshInterval time.Duration) *MaintenanceMode {
+	return &MaintenanceMode{
+		store:           store,
+		defaults:        defaults,
+		refreshInterval: refreshInterval,
+		state:           defaults,
+	}
+}
+
+// State returns the current state, reading the store at most once per refresh
+// interval. If the store can't be read, the last known state is kept. The store
+// is read without holding the lock: other requests get the cached state meanwhile.
+func (m *MaintenanceMode) State(ctx context.Context) MaintenanceState {
+	m.mu.Lock()
+	if m.store == nil || time.Since(m.fetchedAt) < m.refreshInterval {
+		defer m.mu.Unlock()
+		return m.state
+	}
+	m.fetchedAt = time.Now()
+	version := m.version
+	m.mu.Unlock()
+
+	storeCtx, cancel := context.WithTimeout(ctx, maintenanceStoreTimeout)
+	defer cancel()
+	stored, err := m.store.Get(storeCtx)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.version != version {
+		// Set or Reset ran meanwhile; their state is newer than what was read
+		return m.state
+	}
+	switch {
+	case err != nil:
+		log.Printf("Warning: failed to read maintenance state, keeping the last known one: %v", err)
+	case stored == nil:
+		m.state = m.defaults
+	default:
+		m.state = *stored
+	}
+	return m.state
+}
+
+// Set changes the maintenance state for every instance sharing the store
+func (m *MaintenanceMode) Set(ctx context.Context, state MaintenanceState) error {
+	if state.Message == "" {
+		state.Message = m.defaults.Message
+	}
+	if state.RetryAfter <= 0 {
+		state.RetryAfter = m.defaults.RetryAfter
+	}
+
+	if m.store != nil {
+		storeCtx, cancel := context.WithTimeout(ctx, maintenanceStoreTimeout)
+		defer cancel()
+		if err := m.store.Set(storeCtx, state); err != nil {
+			return err
+		}
+	}
+
+	m.mu.Lock()
+	m.state = state
+	m.fetchedAt = time.Now()
+	m.version++
+	m.mu.Unlock()
+	return nil
+}
+
+// Reset drops the state set through Set, returning every instance to its configured default
+func (m *MaintenanceMode) Reset(ctx context.Context) error {
+	if m.store != nil {
+		storeCtx, cancel := context.WithTimeout(ctx, maintenanceStoreTimeout)
+		defer cancel()
+		if err := m.store.Clear(storeCtx); err != nil {
+			return err
+		}
+	}
+
+	m.mu.Lock()
+	m.state = m.defaults
+	m.fetchedAt = time.Now()
+	m.version++
+	m.mu.Unlock()
+	return nil
+}
+
+// MaintenanceMiddleware rejects POST, PUT, PATCH and DELETE requests with 503
+// and a Retry-After header while maintenance mode is on; reads keep working.
+// Routes in exempt (by route pattern) are let through, so admins can still log
+// in and switch maintenance off.
+func MaintenanceMiddleware(mode *MaintenanceMode, exempt ...string) gin.HandlerFunc {
+	exemptRoutes := make(map[string]bool, len(exempt))
+	for _, route := range exempt {
+		exemptRoutes[route] = true
+	}
+
+	return func(c *gin.Context) {
+		switch c.Request.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			c.Next()
+			return
+		}
+		if exemptRoutes[c.FullPath()] {
+			c.Next()
+			return
+		}
+
+		state := mode.State(c.Request.Context())
+		if !state.Enabled {
+			c.Next()
+			return
+		}
+
+		c.Header("Retry-After", strconv.Itoa(int(state.RetryAfter.Seconds())))
+		c.AbortWithStatusJSON(http.StatusServiceUnavailable, gin.H{
+			"error":       state.Message,
+			"maintenance": true,
+		})
+	}
+}
+
+// RedisMaintenanceStore keeps the maintenance state in Redis so it is shared by all gateway instances
+type RedisMaintenanceStore struct {
+	client *redis.Client
+}
+
+// NewRedisMaintenanceStore creates a Redis-backed maintenance store
+func NewRedisMaintenanceStore(client *redis.Client) *RedisMaintenanceStore {
+	return &RedisMaintenanceStore{client: client}
+}
+
+const maintenanceKey = "gateway:maintenance"
+
+// Get returns the stored state, or nil if it has never been set
+func (s *RedisMaintenanceStore) Get(ctx context.Context) (*MaintenanceState, error) {
+	data, err := s.client.Get(ctx, maintenanceKey).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var state MaintenanceState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("failed to decode maintenance state: %w", err)
+	}
+	return &state, nil
+}
+
+// Set stores the state without expiry; it stays until changed again
+func (s *RedisMaintenanceStore) Set(ctx context.Context, state MaintenanceState) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("failed to encode maintenance state: %w", err)
+	}
+	return s.client.Set(ctx, maintenanceKey, data, 0).Err()
+}
+
+// Clear deletes the stored state
+func (s *RedisMaintenanceStore) Clear(ctx context.Context) error {
+	return s.client.Del(ctx, maintenanceKey).Err()
+}
@@ -0,0 +1,131 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// memoryMaintenanceStore stands in for Redis, shared by several MaintenanceModes
+type memoryMaintenanceStore struct {
+	state *MaintenanceState
+}
+
+func (s *memoryMaintenanceStore) Get(context.Context) (*MaintenanceState, error) {
+	return s.state, nil
+}
+
+func (s *memoryMaintenanceStore) Set(_ context.Context, state MaintenanceState) error {
+	s.state = &state
+	return nil
+}
+
+func (s *memoryMaintenanceStore) Clear(context.Context) error {
+	s.state = nil
+	return nil
+}
+
+func TestMaintenanceMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	store := &memoryMaintenanceStore{}
+	defaults := MaintenanceState{Message: "down for maintenance", RetryAfter: 2 * time.Minute}
+	admin := NewMaintenanceMode(store, defaults, 0)
+	replica := NewMaintenanceMode(store, defaults, 0)
+
+	router := gin.New()
+	router.Use(MaintenanceMiddleware(replica, "/auth/login"))
+	ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+	router.GET("/products", ok)
+	router.POST("/products", ok)
+	router.DELETE("/products/:id", ok)
+	router.POST("/auth/login", ok)
+
+	send := func(method, path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(method, path, nil))
+		return w
+	}
+
+	if w := send(http.MethodPost, "/products"); w.Code != http.StatusOK {
+		t.Fatalf("maintenance off: POST status %d, want 200", w.Code)
+	}
+
+	if err := admin.Set(context.Background(), MaintenanceState{Enabled: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	w := send(http.MethodPost, "/products")
+	if w.Code != http.StatusServiceUnavailable {
+		t.Fatalf("maintenance on: POST status %d, want 503", w.Code)
+	}
+	if got := w.Header().Get("Retry-After"); got != "120" {
+		t.Errorf("Retry-After = %q, want 120", got)
+	}
+	if w := send(http.MethodDelete, "/products/p-1"); w.Code != http.StatusServiceUnavailable {
+		t.Errorf("maintenance on: DELETE status %d, want 503", w.Code)
+	}
+	if w := send(http.MethodGet, "/products"); w.Code != http.StatusOK {
+		t.Errorf("maintenance on: GET status %d, want 200", w.Code)
+	}
+	if w := send(http.MethodPost, "/auth/login"); w.Code != http.StatusOK {
+		t.Errorf("maintenance on: exempt login status %d, want 200", w.Code)
+	}
+
+	if err := admin.Reset(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if w := send(http.MethodPost, "/products"); w.Code != http.StatusOK {
+		t.Errorf("after reset: POST status %d, want 200", w.Code)
+	}
+}
+
+// blockingMaintenanceStore is a store whose reads hang until released
+type blockingMaintenanceStore struct {
+	memoryMaintenanceStore
+	reading chan struct{}
+	release chan struct{}
+}
+
+func (s *blockingMaintenanceStore) Get(ctx context.Context) (*MaintenanceState, error) {
+	s.reading <- struct{}{}
+	<-s.release
+	return s.memoryMaintenanceStore.Get(ctx)
+}
+
+func TestMaintenanceStateDoesNotWaitOnStoreRead(t *testing.T) {
+	store := &blockingMaintenanceStore{reading: make(chan struct{}), release: make(chan struct{})}
+	mode := NewMaintenanceMode(store, MaintenanceState{Message: "down"}, time.Hour)
+
+	refreshed := make(chan MaintenanceState)
+	go func() { refreshed <- mode.State(context.Background()) }()
+	<-store.reading
+
+	// While the refresh waits on the store, other requests get the cached state
+	// and a toggle is not held up
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		if mode.State(context.Background()).Enabled {
+			t.Error("cached state: maintenance on, want off")
+		}
+		if err := mode.Set(context.Background(), MaintenanceState{Enabled: true}); err != nil {
+			t.Error(err)
+		}
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("State or Set waited on the store read")
+	}
+
+	// The read started before Set must not overwrite it
+	close(store.release)
+	<-refreshed
+	if !mode.State(context.Background()).Enabled {
+		t.Fatal("a stale store read overwrote the state set meanwhile")
+	}
+}
@@ -0,0 +1,64 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// baseContextKey holds the request context before any timeout was applied, so a
+// route group can replace the global budget instead of only shortening it
+const baseContextKey = "request_base_context"
+
+// writeDeadlineGrace is the time past the request budget left to write the response
+const writeDeadlineGrace = 5 * time.Second
+
+// RequestTimeoutMiddleware bounds every request by timeout. The deadline is set on
+// the request context, so backend calls made with it inherit it as their gRPC
+// deadline (see clients.DeadlineInterceptor).
+func RequestTimeoutMiddleware(timeout time.Duration) gin.HandlerFunc {
+	return RouteTimeoutMiddleware(DefaultRouteGroup, timeout)
+}
+
+// RouteTimeoutMiddleware gives one route group (e.g. payments) its own request budget,
+// replacing the one set by RequestTimeoutMiddleware, which may be longer or shorter.
+// A request still running when the budget runs out gets 504 unless the handler
+// already responded (e.g. with the DeadlineExceeded of a backend call).
+//
+// The connection's write deadline is moved to the end of the budget plus
+// writeDeadlineGrace, so a budget longer than the server's WriteTimeout is not
+// cut short by it.
+func RouteTimeoutMiddleware(group string, timeout time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		base := c.Request.Context()
+		if v, ok := c.Get(baseContextKey); ok {
+			base = v.(context.Context)
+		} else {
+			c.Set(baseContextKey, base)
+		}
+
+		ctx, cancel := context.WithTimeout(base, timeout)
+		defer cancel()
+
+		// Not every writer has a deadline (e.g. httptest.ResponseRecorder)
+		err := http.NewResponseController(c.Writer).SetWriteDeadline(time.Now().Add(timeout + writeDeadlineGrace))
+		if err != nil && !errors.Is(err, http.ErrNotSupported) {
+			log.Printf("Failed to set write deadline for %s request: %v", group, err)
+		}
+
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+
+		// Check the context the handler ran with: a route group's budget replaces ours
+		if errors.Is(c.Request.Context().Err(), context.DeadlineExceeded) && !c.Writer.Written() {
+			c.AbortWithStatusJSON(http.StatusGatewayTimeout, gin.H{
+				"error":   "Request timeout",
+				"message": "The " + group + " request did not complete within " + timeout.String() + ".",
+			})
+		}
+	}
+}
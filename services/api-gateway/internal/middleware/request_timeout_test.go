@@ -0,0 +1,103 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// slowBackend stands in for a handler waiting on a gRPC call that honours the request deadline
+func slowBackend(d time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		select {
+		case <-time.After(d):
+			c.Status(http.StatusOK)
+		case <-c.Request.Context().Done():
+		}
+	}
+}
+
+func TestRouteTimeoutReplacesGlobalBudget(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(RequestTimeoutMiddleware(50 * time.Millisecond))
+
+	payments := router.Group("/payments", RouteTimeoutMiddleware("payments", time.Second))
+	payments.POST("/:id/confirm", slowBackend(150*time.Millisecond))
+
+	products := router.Group("/products", RouteTimeoutMiddleware("catalog", 20*time.Millisecond))
+	products.GET("/:id", slowBackend(150*time.Millisecond))
+
+	serve := func(method, path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(method, path, nil))
+		return w
+	}
+
+	// Longer than the global budget but within the payments one
+	if w := serve(http.MethodPost, "/payments/1/confirm"); w.Code != http.StatusOK {
+		t.Fatalf("slow payment within its budget: got %d", w.Code)
+	}
+	if w := serve(http.MethodGet, "/products/1"); w.Code != http.StatusGatewayTimeout {
+		t.Fatalf("product over its budget: got %d, want 504", w.Code)
+	}
+}
+
+func TestRouteTimeoutOutlastsServerWriteTimeout(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(RequestTimeoutMiddleware(50 * time.Millisecond))
+	router.POST("/payments/:id/confirm", RouteTimeoutMiddleware("payments", time.Second), slowBackend(150*time.Millisecond))
+
+	srv := httptest.NewUnstartedServer(router)
+	srv.Config.WriteTimeout = 50 * time.Millisecond
+	srv.Start()
+	defer srv.Close()
+
+	resp, err := srv.Client().Post(srv.URL+"/payments/1/confirm", "application/json", nil)
+	if err != nil {
+		t.Fatalf("payment within its budget cut off by the server write timeout: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("slow payment within its budget: got %d", resp.StatusCode)
+	}
+}
+
+func TestRouteTimeoutSetsDeadline(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(RequestTimeoutMiddleware(30 * time.Second))
+
+	var remaining time.Duration
+	router.GET("/products/:id", RouteTimeoutMiddleware("catalog", 2*time.Second), func(c *gin.Context) {
+		deadline, _ := c.Request.Context().Deadline()
+		remaining = time.Until(deadline)
+		c.Status(http.StatusOK)
+	})
+
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/products/1", nil))
+	if remaining <= 0 || remaining > 2*time.Second {
+		t.Fatalf("backend calls should inherit the route budget, %v remaining", remaining)
+	}
+}
+
+func TestRequestTimeoutKeepsHandlerResponse(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(RequestTimeoutMiddleware(10 * time.Millisecond))
+	router.GET("/orders", func(c *gin.Context) {
+		<-c.Request.Context().Done()
+		// e.g. handleGRPCError for a call that failed with DeadlineExceeded
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "unavailable"})
+	})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/orders", nil))
+	if w.Code != http.StatusServiceUnavailable {
+		t.Fatalf("got %d, want the handler's status", w.Code)
+	}
+}
@@ -0,0 +1,27 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+// DefaultRouteGroup labels throttling by the per-user limiter that covers every route
+const DefaultRouteGroup = "default"
+
+// RouteRateLimitMiddleware applies a dedicated limit to one route group (e.g. login),
+// on top of the global limits. Callers are keyed like UserRateLimitMiddleware, so it
+// should run after auth on protected routes. Share one instance across all routes of a group.
+func RouteRateLimitMiddleware(routeGroup string, tier RateLimitTier) gin.HandlerFunc {
+	// Same tier for every caller; the group limit is the same for users and admins
+	limiter := NewUserRateLimiter(tier, tier, tier)
+
+	return func(c *gin.Context) {
+		key, _ := callerKey(c)
+
+		if allowed, retryAfter := limiter.Allow(key, tier); !allowed {
+			abortRateLimited(c, routeGroup, retryAfter)
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,36 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestRouteRateLimitSharesBucketWithinGroup(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	limit := RouteRateLimitMiddleware("auth", RateLimitTier{RequestsPerSecond: 0.001, Burst: 1})
+	router.GET("/login", limit, func(c *gin.Context) { c.Status(http.StatusOK) })
+	router.GET("/refresh", limit, func(c *gin.Context) { c.Status(http.StatusOK) })
+	router.GET("/products", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	get := func(path string) int {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.RemoteAddr = "10.0.0.1:1234"
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	if code := get("/login"); code != http.StatusOK {
+		t.Fatalf("first request: got %d", code)
+	}
+	if code := get("/refresh"); code != http.StatusTooManyRequests {
+		t.Fatalf("second route in group: got %d, want 429", code)
+	}
+	if code := get("/products"); code != http.StatusOK {
+		t.Fatalf("route outside group: got %d", code)
+	}
+}
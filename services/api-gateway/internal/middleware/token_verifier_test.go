@@ -0,0 +1,79 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+type revocationFunc func(ctx context.Context, token string) (bool, error)
+
+func (f revocationFunc) IsRevoked(ctx context.Context, token string) (bool, error) {
+	return f(ctx, token)
+}
+
+func signHS256(t *testing.T, secret string, expiresIn time.Duration) string {
+	t.Helper()
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, &accessTokenClaims{
+		UserID:           7,
+		Email:            "user@example.com",
+		RegisteredClaims: jwt.RegisteredClaims{ExpiresAt: jwt.NewNumericDate(time.Now().Add(expiresIn))},
+	})
+	signed, err := token.SignedString([]byte(secret))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signed
+}
+
+func TestSecretVerifier(t *testing.T) {
+	verifier := NewSecretVerifier("secret")
+
+	user, err := verifier.Verify(signHS256(t, "secret", time.Minute))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if user.ID != 7 || user.Email != "user@example.com" {
+		t.Errorf("unexpected user %+v", user)
+	}
+
+	if _, err := verifier.Verify(signHS256(t, "other", time.Minute)); err == nil || errors.Is(err, errNotLocallyVerifiable) {
+		t.Errorf("wrong secret: err = %v, want rejection", err)
+	}
+	if _, err := verifier.Verify(signHS256(t, "secret", -time.Minute)); err == nil || errors.Is(err, errNotLocallyVerifiable) {
+		t.Errorf("expired token: err = %v, want rejection", err)
+	}
+
+	// An RS256 token is left to the next verifier
+	chain := TokenVerifiers{verifier}
+	rs256 := jwt.NewWithClaims(jwt.SigningMethodRS256, &accessTokenClaims{})
+	unsigned, _ := rs256.SigningString()
+	if _, err := chain.Verify(unsigned + ".c2ln"); !errors.Is(err, errNotLocallyVerifiable) {
+		t.Errorf("RS256 token: err = %v, want errNotLocallyVerifiable", err)
+	}
+}
+
+func TestAuthenticatorChecksRevocation(t *testing.T) {
+	token := signHS256(t, "secret", time.Minute)
+	revoked := map[string]bool{}
+	auth := NewAuthenticator(nil, NewSecretVerifier("secret"), revocationFunc(func(_ context.Context, tok string) (bool, error) {
+		return revoked[tok], nil
+	}))
+
+	user, remote, err := auth.authenticate(context.Background(), token)
+	if err != nil || remote || user.ID != 7 {
+		t.Fatalf("authenticate = %+v, %v, %v; want local success", user, remote, err)
+	}
+
+	revoked[token] = true
+	if _, _, err := auth.authenticate(context.Background(), token); err == nil {
+		t.Error("revoked token was accepted")
+	}
+
+	if _, _, err := auth.authenticate(context.Background(), signHS256(t, "other", time.Minute)); err == nil {
+		t.Error("forged token was accepted")
+	}
+}
@@ -0,0 +1,139 @@
+package middleware
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/datngth03/ecommerce-go-app/services/api-gateway/internal/metrics"
+	"github.com/gin-gonic/gin"
+	"golang.org/x/time/rate"
+)
+
+// userLimiterIdleTTL is how long an unused bucket is kept before cleanup
+const userLimiterIdleTTL = 10 * time.Minute
+
+// RateLimitTier is the token bucket applied to one class of caller
+type RateLimitTier struct {
+	RequestsPerSecond float64
+	Burst             int
+}
+
+// UserRateLimiter keeps a token bucket per authenticated user, or per IP for
+// anonymous requests, with separate limits for anonymous, authenticated and admin callers
+type UserRateLimiter struct {
+	mu      sync.Mutex
+	buckets map[string]*userBucket
+
+	anonymous     RateLimitTier
+	authenticated RateLimitTier
+	admin         RateLimitTier
+}
+
+type userBucket struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+// NewUserRateLimiter creates a user rate limiter and starts cleanup of idle buckets
+func NewUserRateLimiter(anonymous, authenticated, admin RateLimitTier) *UserRateLimiter {
+	rl := &UserRateLimiter{
+		buckets:       make(map[string]*userBucket),
+		anonymous:     anonymous,
+		authenticated: authenticated,
+		admin:         admin,
+	}
+
+	go rl.cleanup()
+
+	return rl
+}
+
+// cleanup removes buckets that have not been used for a while
+func (rl *UserRateLimiter) cleanup() {
+	ticker := time.NewTicker(time.Minute)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		rl.mu.Lock()
+		now := time.Now()
+		for key, bucket := range rl.buckets {
+			if now.Sub(bucket.lastSeen) > userLimiterIdleTTL {
+				delete(rl.buckets, key)
+			}
+		}
+		rl.mu.Unlock()
+	}
+}
+
+// Allow reports whether a request under key and tier may proceed.
+// When it may not, retryAfter is how long until a token is available.
+func (rl *UserRateLimiter) Allow(key string, tier RateLimitTier) (allowed bool, retryAfter time.Duration) {
+	rl.mu.Lock()
+	bucket, exists := rl.buckets[key]
+	if !exists {
+		bucket = &userBucket{limiter: rate.NewLimiter(rate.Limit(tier.RequestsPerSecond), tier.Burst)}
+		rl.buckets[key] = bucket
+	}
+	bucket.lastSeen = time.Now()
+	rl.mu.Unlock()
+
+	reservation := bucket.limiter.Reserve()
+	if !reservation.OK() {
+		// Burst of zero: the tier allows nothing
+		return false, time.Minute
+	}
+	if delay := reservation.Delay(); delay > 0 {
+		reservation.Cancel()
+		return false, delay
+	}
+	return true, 0
+}
+
+// UserRateLimitMiddleware limits requests per user. It must run after OptionalAuth or
+// AuthMiddleware: authenticated requests are keyed on user_id, anonymous ones on client IP.
+func UserRateLimitMiddleware(limiter *UserRateLimiter) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key, userInfo := callerKey(c)
+
+		tier := limiter.anonymous
+		if userInfo != nil {
+			tier = limiter.authenticated
+			if isAdmin(userInfo) {
+				tier = limiter.admin
+			}
+		}
+
+		if allowed, retryAfter := limiter.Allow(key, tier); !allowed {
+			abortRateLimited(c, DefaultRouteGroup, retryAfter)
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// callerKey returns the rate limit key of the request: user ID when authenticated, client IP otherwise
+func callerKey(c *gin.Context) (string, *UserInfo) {
+	if user, exists := c.Get("user"); exists {
+		if userInfo, ok := user.(*UserInfo); ok {
+			return fmt.Sprintf("user:%d", userInfo.ID), userInfo
+		}
+	}
+	return "ip:" + c.ClientIP(), nil
+}
+
+// abortRateLimited rejects a throttled request with 429 and Retry-After
+func abortRateLimited(c *gin.Context, routeGroup string, retryAfter time.Duration) {
+	metrics.RecordRateLimitThrottled(routeGroup)
+
+	c.Header("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+	c.JSON(http.StatusTooManyRequests, gin.H{
+		"error":   "Rate limit exceeded",
+		"message": "Too many requests. Please try again later.",
+	})
+	c.Abort()
+}
@@ -0,0 +1,82 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func newUserRateLimitRouter(limiter *UserRateLimiter) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	// Stand-in for OptionalAuth: X-Test-User selects the authenticated user
+	router.Use(func(c *gin.Context) {
+		switch c.GetHeader("X-Test-User") {
+		case "1":
+			c.Set("user", &UserInfo{ID: 1, Email: "alice@example.com"})
+		case "2":
+			c.Set("user", &UserInfo{ID: 2, Email: "bob@example.com"})
+		case "admin":
+			c.Set("user", &UserInfo{ID: 3, Email: "admin@example.com"})
+		}
+		c.Next()
+	})
+	router.Use(UserRateLimitMiddleware(limiter))
+	router.GET("/", func(c *gin.Context) { c.Status(http.StatusOK) })
+	return router
+}
+
+func doRequest(router *gin.Engine, user string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.RemoteAddr = "10.0.0.1:1234" // Everyone behind the same NAT
+	if user != "" {
+		req.Header.Set("X-Test-User", user)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestUserRateLimitKeysOnUserBehindSharedIP(t *testing.T) {
+	router := newUserRateLimitRouter(NewUserRateLimiter(
+		RateLimitTier{RequestsPerSecond: 0.001, Burst: 1},
+		RateLimitTier{RequestsPerSecond: 0.001, Burst: 2},
+		RateLimitTier{RequestsPerSecond: 0.001, Burst: 3},
+	))
+
+	// Anonymous callers share the IP bucket
+	if w := doRequest(router, ""); w.Code != http.StatusOK {
+		t.Fatalf("first anonymous request: got %d", w.Code)
+	}
+	w := doRequest(router, "")
+	if w.Code != http.StatusTooManyRequests {
+		t.Fatalf("second anonymous request: got %d, want 429", w.Code)
+	}
+	if w.Header().Get("Retry-After") == "" {
+		t.Error("429 response is missing Retry-After")
+	}
+
+	// Each user has their own bucket despite the exhausted IP bucket
+	for _, user := range []string{"1", "2"} {
+		for i := 0; i < 2; i++ {
+			if w := doRequest(router, user); w.Code != http.StatusOK {
+				t.Fatalf("user %s request %d: got %d", user, i+1, w.Code)
+			}
+		}
+		if w := doRequest(router, user); w.Code != http.StatusTooManyRequests {
+			t.Fatalf("user %s over limit: got %d, want 429", user, w.Code)
+		}
+	}
+
+	// Admins get the admin tier
+	for i := 0; i < 3; i++ {
+		if w := doRequest(router, "admin"); w.Code != http.StatusOK {
+			t.Fatalf("admin request %d: got %d", i+1, w.Code)
+		}
+	}
+	if w := doRequest(router, "admin"); w.Code != http.StatusTooManyRequests {
+		t.Fatalf("admin over limit: got %d, want 429", w.Code)
+	}
+}
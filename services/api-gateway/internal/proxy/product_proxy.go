@@ -20,9 +20,9 @@ func NewProductProxy(client *clients.ProductClient) *ProductProxy {
 }
 
 // GetProduct retrieves a product by ID
-func (p *ProductProxy) GetProduct(ctx context.Context, id string) (*pb.Product, error) {
+func (p *ProductProxy) GetProduct(ctx context.Context, req *pb.GetProductRequest) (*pb.Product, error) {
 	start := time.Now()
-	resp, err := p.client.GetProduct(ctx, id)
+	resp, err := p.client.GetProduct(ctx, req)
 
 	status := "success"
 	if err != nil {
@@ -34,10 +34,10 @@ func (p *ProductProxy) GetProduct(ctx context.Context, id string) (*pb.Product,
 	return resp, err
 }
 
-// ListProducts retrieves products with pagination
-func (p *ProductProxy) ListProducts(ctx context.Context, page, pageSize int32, categoryID string) ([]*pb.Product, int64, error) {
+// ListProducts retrieves products with pagination and filters
+func (p *ProductProxy) ListProducts(ctx context.Context, req *pb.ListProductsRequest) (*pb.ListProductsResponse, error) {
 	start := time.Now()
-	products, total, err := p.client.ListProducts(ctx, page, pageSize, categoryID)
+	resp, err := p.client.ListProducts(ctx, req)
 
 	status := "success"
 	if err != nil {
@@ -46,7 +46,97 @@ func (p *ProductProxy) ListProducts(ctx context.Context, page, pageSize int32, c
 	metrics.RecordGRPCClientRequest("product-service", "ListProducts", status, time.Since(start))
 	metrics.RecordProxyRequest("product-service", status, time.Since(start))
 
-	return products, total, err
+	return resp, err
+}
+
+// Autocomplete retrieves product name suggestions for a prefix
+func (p *ProductProxy) Autocomplete(ctx context.Context, req *pb.AutocompleteRequest) ([]string, error) {
+	start := time.Now()
+	suggestions, err := p.client.Autocomplete(ctx, req)
+
+	status := "success"
+	if err != nil {
+		status = "error"
+	}
+	metrics.RecordGRPCClientRequest("product-service", "Autocomplete", status, time.Since(start))
+	metrics.RecordProxyRequest("product-service", status, time.Since(start))
+
+	return suggestions, err
+}
+
+// RecordProductInteractions records product views, add-to-cart and purchase events
+func (p *ProductProxy) RecordProductInteractions(ctx context.Context, req *pb.RecordProductInteractionsRequest) error {
+	start := time.Now()
+	err := p.client.RecordProductInteractions(ctx, req)
+
+	status := "success"
+	if err != nil {
+		status = "error"
+	}
+	metrics.RecordGRPCClientRequest("product-service", "RecordProductInteractions", status, time.Since(start))
+	metrics.RecordProxyRequest("product-service", status, time.Since(start))
+
+	return err
+}
+
+// GetProductStats retrieves the interaction counts of a product
+func (p *ProductProxy) GetProductStats(ctx context.Context, req *pb.GetProductStatsRequest) (*pb.GetProductStatsResponse, error) {
+	start := time.Now()
+	resp, err := p.client.GetProductStats(ctx, req)
+
+	status := "success"
+	if err != nil {
+		status = "error"
+	}
+	metrics.RecordGRPCClientRequest("product-service", "GetProductStats", status, time.Since(start))
+	metrics.RecordProxyRequest("product-service", status, time.Since(start))
+
+	return resp, err
+}
+
+// SaveSearch stores a user's saved search
+func (p *ProductProxy) SaveSearch(ctx context.Context, req *pb.SaveSearchRequest) (*pb.SavedSearch, error) {
+	start := time.Now()
+	resp, err := p.client.SaveSearch(ctx, req)
+
+	status := "success"
+	if err != nil {
+		status = "error"
+	}
+	metrics.RecordGRPCClientRequest("product-service", "SaveSearch", status, time.Since(start))
+	metrics.RecordProxyRequest("product-service", status, time.Since(start))
+
+	return resp, err
+}
+
+// ListSavedSearches retrieves the saved searches of a user
+func (p *ProductProxy) ListSavedSearches(ctx context.Context, userID int64) ([]*pb.SavedSearch, error) {
+	start := time.Now()
+	resp, err := p.client.ListSavedSearches(ctx, userID)
+
+	status := "success"
+	if err != nil {
+		status = "error"
+	}
+	metrics.RecordGRPCClientRequest("product-service", "ListSavedSearches", status, time.Since(start))
+	metrics.RecordProxyRequest("product-service", status, time.Since(start))
+
+	return resp, err
+}
+
+// DeleteSavedSearch deletes a saved search of a user
+func (p *ProductProxy) DeleteSavedSearch(ctx context.Context, id string, userID int64) error {
+	start := time.Now()
+	err := p.client.DeleteSavedSearch(ctx, id, userID)
+
+	status := "success"
+	if err != nil {
+		status = "error"
+	}
+	metrics.RecordGRPCClientRequest("product-service", "DeleteSavedSearch", status, time.Since(start))
+	metrics.RecordProxyRequest("product-service", status, time.Since(start))
+
+	return err
 }
 
 // CreateProduct creates a new product
@@ -126,3 +126,91 @@ func (p *UserProxy) RefreshToken(ctx context.Context, refreshToken string) (*pb.
 
 	return resp, err
 }
+
+// ChangePassword changes the authenticated user's password
+func (p *UserProxy) ChangePassword(ctx context.Context, accessToken string, req *pb.ChangePasswordRequest) (*pb.ChangePasswordResponse, error) {
+	start := time.Now()
+	resp, err := p.client.ChangePassword(ctx, accessToken, req)
+
+	status := "success"
+	if err != nil {
+		status = "error"
+		metrics.RecordAuthFailure()
+	}
+	metrics.RecordGRPCClientRequest("user-service", "ChangePassword", status, time.Since(start))
+	metrics.RecordAuthRequest("change_password", status)
+
+	return resp, err
+}
+
+// EnableTwoFactor starts two-factor setup for the authenticated user
+func (p *UserProxy) EnableTwoFactor(ctx context.Context, accessToken string) (*pb.EnableTwoFactorResponse, error) {
+	start := time.Now()
+	resp, err := p.client.EnableTwoFactor(ctx, accessToken)
+
+	status := "success"
+	if err != nil {
+		status = "error"
+	}
+	metrics.RecordGRPCClientRequest("user-service", "EnableTwoFactor", status, time.Since(start))
+
+	return resp, err
+}
+
+// ConfirmTwoFactor completes two-factor setup for the authenticated user
+func (p *UserProxy) ConfirmTwoFactor(ctx context.Context, accessToken, code string) (*pb.ConfirmTwoFactorResponse, error) {
+	start := time.Now()
+	resp, err := p.client.ConfirmTwoFactor(ctx, accessToken, code)
+
+	status := "success"
+	if err != nil {
+		status = "error"
+	}
+	metrics.RecordGRPCClientRequest("user-service", "ConfirmTwoFactor", status, time.Since(start))
+
+	return resp, err
+}
+
+// DisableTwoFactor turns two-factor authentication off for the authenticated user
+func (p *UserProxy) DisableTwoFactor(ctx context.Context, accessToken string, req *pb.DisableTwoFactorRequest) (*pb.DisableTwoFactorResponse, error) {
+	start := time.Now()
+	resp, err := p.client.DisableTwoFactor(ctx, accessToken, req)
+
+	status := "success"
+	if err != nil {
+		status = "error"
+		metrics.RecordAuthFailure()
+	}
+	metrics.RecordGRPCClientRequest("user-service", "DisableTwoFactor", status, time.Since(start))
+
+	return resp, err
+}
+
+// RecordAuditEntry stores an audit log entry
+func (p *UserProxy) RecordAuditEntry(ctx context.Context, req *pb.RecordAuditEntryRequest) (*pb.RecordAuditEntryResponse, error) {
+	start := time.Now()
+	resp, err := p.client.RecordAuditEntry(ctx, req)
+
+	status := "success"
+	if err != nil {
+		status = "error"
+	}
+	metrics.RecordGRPCClientRequest("user-service", "RecordAuditEntry", status, time.Since(start))
+
+	return resp, err
+}
+
+// GetAuditLog lists audit log entries
+func (p *UserProxy) GetAuditLog(ctx context.Context, req *pb.GetAuditLogRequest) (*pb.GetAuditLogResponse, error) {
+	start := time.Now()
+	resp, err := p.client.GetAuditLog(ctx, req)
+
+	status := "success"
+	if err != nil {
+		status = "error"
+	}
+	metrics.RecordGRPCClientRequest("user-service", "GetAuditLog", status, time.Since(start))
+	metrics.RecordProxyRequest("user-service", status, time.Since(start))
+
+	return resp, err
+}
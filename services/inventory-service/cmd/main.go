@@ -7,12 +7,11 @@ import (
 	"net"
 	"net/http"
 	"os"
-	"os/signal"
 	"strconv"
-	"syscall"
 	"time"
 
 	"github.com/datngth03/ecommerce-go-app/proto/inventory_service"
+	"github.com/datngth03/ecommerce-go-app/services/inventory-service/internal/client"
 	"github.com/datngth03/ecommerce-go-app/services/inventory-service/internal/config"
 	"github.com/datngth03/ecommerce-go-app/services/inventory-service/internal/events"
 
@@ -22,6 +21,9 @@ import (
 	"github.com/datngth03/ecommerce-go-app/services/inventory-service/internal/service"
 
 	sharedCache "github.com/datngth03/ecommerce-go-app/shared/pkg/cache"
+	"github.com/datngth03/ecommerce-go-app/shared/pkg/grpcsize"
+	"github.com/datngth03/ecommerce-go-app/shared/pkg/lifecycle"
+	sharedLogger "github.com/datngth03/ecommerce-go-app/shared/pkg/logger"
 	sharedMiddleware "github.com/datngth03/ecommerce-go-app/shared/pkg/middleware"
 	sharedTLS "github.com/datngth03/ecommerce-go-app/shared/pkg/tlsutil"
 	sharedTracing "github.com/datngth03/ecommerce-go-app/shared/pkg/tracing"
@@ -45,6 +47,9 @@ func main() {
 	if err != nil {
 		log.Fatalf("Failed to load config: %v", err)
 	}
+	if err := sharedLogger.Init(cfg.Logging); err != nil {
+		log.Fatalf("Failed to initialize logger: %v", err)
+	}
 
 	// Initialize distributed tracing
 	tracerCleanup, err := sharedTracing.InitTracer(sharedTracing.TracerConfig{
@@ -108,26 +113,44 @@ func main() {
 	}
 
 	// Initialize service
-	svc := service.NewInventoryService(finalRepo)
+	svc := service.NewInventoryService(finalRepo, service.ReservationOptions{
+		TTL:             cfg.Reservation.TTL,
+		ExpiryInterval:  cfg.Reservation.ExpiryInterval,
+		ExpiryBatchSize: cfg.Reservation.ExpiryBatchSize,
+	})
 
 	// Initialize gRPC server with tracing interceptor and TLS
 	var grpcServerOpts []grpc.ServerOption
 	grpcServerOpts = append(grpcServerOpts, grpc.UnaryInterceptor(sharedTracing.UnaryServerInterceptor()))
 
+	// Same message size limit as the other services and their clients
+	grpcServerOpts = append(grpcServerOpts, grpcsize.ServerOptions(cfg.Server.MaxMessageSize)...)
+
 	// Enable TLS if configured
 	if cfg.Server.TLS.Enabled {
-		tlsCreds, err := sharedTLS.ServerTLSConfig(cfg.Server.TLS.CertFile, cfg.Server.TLS.KeyFile)
+		tlsCreds, err := sharedTLS.ServerCredentials(cfg.Server.TLS)
 		if err != nil {
 			log.Fatalf("Failed to load TLS credentials: %v", err)
 		}
 		grpcServerOpts = append(grpcServerOpts, grpc.Creds(tlsCreds))
-		log.Printf("✓ TLS enabled for gRPC server (cert: %s)", cfg.Server.TLS.CertFile)
+		log.Printf("✓ TLS enabled for gRPC server (cert: %s, client certificates required: %v)", cfg.Server.TLS.CertFile, cfg.Server.TLS.ClientAuth)
 	} else {
 		log.Println("⚠️  TLS disabled - using insecure connection")
 	}
 
 	grpcServer := grpc.NewServer(grpcServerOpts...)
-	inventoryServer := rpc.NewInventoryServer(svc)
+	// Variant stock is only served when the product service can confirm which product a variant belongs to
+	var variants rpc.VariantChecker
+	if cfg.Services.ProductService.Enabled {
+		productClient, err := client.NewProductClient(cfg.Services.ProductService, cfg.Server)
+		if err != nil {
+			log.Printf("Warning: Failed to create product client: %v (variant stock requests will be rejected)", err)
+		} else {
+			variants = productClient
+			defer productClient.Close()
+		}
+	}
+	inventoryServer := rpc.NewInventoryServer(svc, variants)
 	inventory_service.RegisterInventoryServiceServer(grpcServer, inventoryServer)
 
 	// Register health check
@@ -142,7 +165,7 @@ func main() {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
-	subscriber, err := events.NewEventSubscriber(svc, cfg.GetRabbitMQURL())
+	subscriber, err := events.NewEventSubscriber(svc, cfg.GetRabbitMQURL(), cfg.Events.MaxRetries)
 	if err != nil {
 		log.Printf("Warning: Failed to initialize event subscriber: %v", err)
 	} else {
@@ -150,9 +173,12 @@ func main() {
 		if err != nil {
 			log.Printf("Warning: Failed to start event subscriber: %v", err)
 		}
-		defer subscriber.Close()
 	}
 
+	// Release reservations that were not confirmed before their expiry
+	go svc.RunReservationExpiry(ctx)
+	log.Printf("✓ Reservation expiry started (TTL: %v, interval: %v)", cfg.Reservation.TTL, cfg.Reservation.ExpiryInterval)
+
 	// Start gRPC server
 	go func() {
 		lis, err := net.Listen("tcp", fmt.Sprintf(":%s", cfg.Server.GRPCPort))
@@ -166,135 +192,136 @@ func main() {
 		}
 	}()
 
-	// Start HTTP health check server
-	go func() {
-		// Set Gin mode
-		if cfg.Service.Environment == "production" {
-			gin.SetMode(gin.ReleaseMode)
-		}
+	// HTTP server for health checks and metrics
+	if cfg.Service.Environment == "production" {
+		gin.SetMode(gin.ReleaseMode)
+	}
 
-		router := gin.New()
+	router := gin.New()
 
-		// Add distributed tracing middleware FIRST
-		router.Use(sharedTracing.GinMiddleware(cfg.Service.Name))
+	// Add distributed tracing middleware FIRST
+	router.Use(sharedTracing.GinMiddleware(cfg.Service.Name))
 
-		// Initialize security middleware
-		var securityMiddlewares []gin.HandlerFunc
+	// Initialize security middleware
+	var securityMiddlewares []gin.HandlerFunc
 
-		// Rate limiting middleware
-		if cfg.Security.RateLimit.Enabled {
-			rateLimiter := sharedMiddleware.NewIPRateLimiter(
-				rate.Limit(cfg.Security.RateLimit.RequestsPerSecond),
-				cfg.Security.RateLimit.BurstSize,
-			)
-			securityMiddlewares = append(securityMiddlewares, sharedMiddleware.RateLimitMiddleware(rateLimiter))
-		}
+	// Rate limiting middleware
+	if cfg.Security.RateLimit.Enabled {
+		rateLimiter := sharedMiddleware.NewIPRateLimiter(
+			rate.Limit(cfg.Security.RateLimit.RequestsPerSecond),
+			cfg.Security.RateLimit.BurstSize,
+		)
+		securityMiddlewares = append(securityMiddlewares, sharedMiddleware.RateLimitMiddleware(rateLimiter))
+	}
 
-		// Security headers middleware
-		securityMiddlewares = append(securityMiddlewares, sharedMiddleware.SecurityHeadersMiddleware())
+	// Security headers middleware
+	securityMiddlewares = append(securityMiddlewares, sharedMiddleware.SecurityHeadersMiddleware())
 
-		// CORS middleware
-		if cfg.Security.CORS.Enabled {
-			securityMiddlewares = append(securityMiddlewares, sharedMiddleware.CORSMiddleware(cfg.Security.CORS.AllowedOrigins))
-		}
+	// CORS middleware
+	if cfg.Security.CORS.Enabled {
+		securityMiddlewares = append(securityMiddlewares, sharedMiddleware.CORSMiddleware(cfg.Security.CORS.AllowedOrigins))
+	}
 
-		// Timeout middleware
-		securityMiddlewares = append(securityMiddlewares, sharedMiddleware.TimeoutMiddleware(cfg.Security.RequestTimeout))
+	// Timeout middleware
+	securityMiddlewares = append(securityMiddlewares, sharedMiddleware.TimeoutMiddleware(cfg.Security.RequestTimeout))
 
-		// Add security middleware first
-		for _, mw := range securityMiddlewares {
-			router.Use(mw)
-		}
+	// Add security middleware first
+	for _, mw := range securityMiddlewares {
+		router.Use(mw)
+	}
 
-		// Enhanced input validation middlewares (5MB max request size)
-		for _, mw := range sharedMiddleware.EnhancedValidationMiddlewares(5 * 1024 * 1024) {
-			router.Use(mw)
-		}
+	// Enhanced input validation middlewares (5MB max request size)
+	for _, mw := range sharedMiddleware.EnhancedValidationMiddlewares(5 * 1024 * 1024) {
+		router.Use(mw)
+	}
 
-		// Response compression middleware
-		router.Use(sharedMiddleware.CompressionMiddleware())
+	// Response compression middleware
+	router.Use(sharedMiddleware.CompressionMiddleware())
 
-		// Add other middleware
-		router.Use(gin.Logger())
-		router.Use(gin.Recovery())
-		router.Use(middleware.PrometheusGinMiddleware())
+	// Add other middleware
+	router.Use(gin.Logger())
+	router.Use(gin.Recovery())
+	router.Use(middleware.PrometheusGinMiddleware())
 
-		// Health check endpoint
-		router.GET("/health", func(c *gin.Context) {
-			c.JSON(http.StatusOK, gin.H{
-				"status":  "healthy",
-				"service": "inventory-service",
-			})
+	// Health check endpoint
+	router.GET("/health", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{
+			"status":  "healthy",
+			"service": "inventory-service",
 		})
+	})
 
-		// Readiness check endpoint
-		router.GET("/ready", func(c *gin.Context) {
-			// Check database connection
-			sqlDB, err := db.DB()
-			if err != nil || sqlDB.Ping() != nil {
-				c.JSON(http.StatusServiceUnavailable, gin.H{
-					"status": "not ready",
-					"error":  "Database not ready",
-				})
-				return
-			}
-
-			// Check Redis connection
-			if err := redisClient.Ping(context.Background()).Err(); err != nil {
-				c.JSON(http.StatusServiceUnavailable, gin.H{
-					"status": "not ready",
-					"error":  "Redis not ready",
-				})
-				return
-			}
+	// Readiness check endpoint
+	router.GET("/ready", func(c *gin.Context) {
+		// Check database connection
+		sqlDB, err := db.DB()
+		if err != nil || sqlDB.Ping() != nil {
+			c.JSON(http.StatusServiceUnavailable, gin.H{
+				"status": "not ready",
+				"error":  "Database not ready",
+			})
+			return
+		}
 
-			c.JSON(http.StatusOK, gin.H{
-				"status": "ready",
+		// Check Redis connection
+		if err := redisClient.Ping(context.Background()).Err(); err != nil {
+			c.JSON(http.StatusServiceUnavailable, gin.H{
+				"status": "not ready",
+				"error":  "Redis not ready",
 			})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"status": "ready",
 		})
+	})
 
-		// Prometheus metrics endpoint
-		router.GET("/metrics", gin.WrapH(promhttp.Handler()))
-
-		httpAddr := fmt.Sprintf(":%s", cfg.Server.HTTPPort)
-		log.Printf("Inventory HTTP server listening on port %s", cfg.Server.HTTPPort)
-		log.Printf("Health check: http://localhost:%s/health", cfg.Server.HTTPPort)
-		log.Printf("Ready check: http://localhost:%s/ready", cfg.Server.HTTPPort)
-		log.Printf("Metrics endpoint: http://localhost:%s/metrics", cfg.Server.HTTPPort)
-
-		httpServer := &http.Server{
-			Addr:         httpAddr,
-			Handler:      router,
-			ReadTimeout:  15 * time.Second,
-			WriteTimeout: 15 * time.Second,
-			IdleTimeout:  60 * time.Second,
-		}
+	// Prometheus metrics endpoint
+	router.GET("/metrics", gin.WrapH(promhttp.Handler()))
+
+	httpAddr := fmt.Sprintf(":%s", cfg.Server.HTTPPort)
+	log.Printf("Inventory HTTP server listening on port %s", cfg.Server.HTTPPort)
+	log.Printf("Health check: http://localhost:%s/health", cfg.Server.HTTPPort)
+	log.Printf("Ready check: http://localhost:%s/ready", cfg.Server.HTTPPort)
+	log.Printf("Metrics endpoint: http://localhost:%s/metrics", cfg.Server.HTTPPort)
+
+	httpServer := &http.Server{
+		Addr:         httpAddr,
+		Handler:      router,
+		ReadTimeout:  15 * time.Second,
+		WriteTimeout: 15 * time.Second,
+		IdleTimeout:  60 * time.Second,
+	}
 
+	go func() {
 		if err := httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
 			log.Fatalf("Failed to start HTTP server: %v", err)
 		}
 	}()
 
-	// Wait for interrupt signal
-	quit := make(chan os.Signal, 1)
-	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
-	<-quit
-
-	log.Println("Shutting down Inventory Service...")
-
-	// Graceful shutdown
-	grpcServer.GracefulStop()
-
-	// Close database
-	sqlDB, _ := db.DB()
-	if sqlDB != nil {
-		sqlDB.Close()
+	// Graceful shutdown: stop taking requests, then background work, then connections
+	shutdown := lifecycle.NewManager("Inventory Service", cfg.Server.ShutdownTimeout)
+	shutdown.Register("HTTP server", httpServer)
+	shutdown.Register("gRPC server", lifecycle.GRPCServer(grpcServer))
+	shutdown.Register("background workers", lifecycle.ComponentFunc(func(context.Context) error {
+		cancel()
+		return nil
+	}))
+	if subscriber != nil {
+		shutdown.Register("event subscriber", lifecycle.Closer(subscriber.Close))
+	}
+	shutdown.Register("database", lifecycle.Closer(func() error {
+		sqlDB, err := db.DB()
+		if err != nil {
+			return err
+		}
+		return sqlDB.Close()
+	}))
+	shutdown.Register("Redis", lifecycle.Closer(redisClient.Close))
+	if err := shutdown.Wait(); err != nil {
+		log.Printf("Inventory Service shutdown completed with errors: %v", err)
 	}
-
-	// Close Redis
-	redisClient.Close()
-
-	log.Println("Inventory Service stopped")
 }
 
 // initDB initializes database connection
@@ -0,0 +1,98 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+
+	pb "github.com/datngth03/ecommerce-go-app/proto/product_service"
+	sharedConfig "github.com/datngth03/ecommerce-go-app/shared/pkg/config"
+	"github.com/datngth03/ecommerce-go-app/shared/pkg/grpcretry"
+	"github.com/datngth03/ecommerce-go-app/shared/pkg/grpcsize"
+	sharedTLS "github.com/datngth03/ecommerce-go-app/shared/pkg/tlsutil"
+	sharedTracing "github.com/datngth03/ecommerce-go-app/shared/pkg/tracing"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ProductClient looks up products in the product service to check which product a variant belongs to
+type ProductClient struct {
+	conn   *grpc.ClientConn
+	client pb.ProductServiceClient
+	policy grpcretry.Policy // Timeout and retries of calls
+
+	// Confirmed product/variant pairs. A variant never moves to another product,
+	// so a confirmed pair stays valid.
+	owned sync.Map
+}
+
+// NewProductClient creates a new product service gRPC client. It connects lazily,
+// verifies the server certificate when TLS is enabled and uses the server's MaxMessageSize.
+func NewProductClient(endpoint sharedConfig.ServiceEndpoint, server sharedConfig.ServerConfig) (*ProductClient, error) {
+	tlsCfg := server.TLS
+	addr := endpoint.GRPCAddr
+	if addr == "" {
+		return nil, fmt.Errorf("product service address is required")
+	}
+
+	creds, err := sharedTLS.ClientCredentials(tlsCfg, "product-service")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create product service credentials: %w", err)
+	}
+
+	opts := []grpc.DialOption{
+		grpc.WithUnaryInterceptor(sharedTracing.UnaryClientInterceptor()),
+		grpc.WithTransportCredentials(creds),
+		grpcsize.DialOption(server.MaxMessageSize),
+	}
+
+	conn, err := grpc.Dial(addr, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to product service at %s: %w", addr, err)
+	}
+
+	log.Printf("Product service client configured for %s (TLS: %v)", addr, tlsCfg.Enabled)
+
+	return &ProductClient{
+		conn:   conn,
+		client: pb.NewProductServiceClient(conn),
+		policy: grpcretry.PolicyFor(endpoint),
+	}, nil
+}
+
+// VariantBelongsTo reports whether variantID is a variant of productID. An unknown
+// product is reported as false, not as an error.
+func (c *ProductClient) VariantBelongsTo(ctx context.Context, productID, variantID string) (bool, error) {
+	key := productID + "/" + variantID
+	if _, ok := c.owned.Load(key); ok {
+		return true, nil
+	}
+
+	resp, err := grpcretry.CallIdempotent(ctx, c.policy, func(ctx context.Context) (*pb.GetProductResponse, error) {
+		return c.client.GetProduct(ctx, &pb.GetProductRequest{Id: productID})
+	})
+	if code := status.Code(err); code == codes.NotFound || code == codes.InvalidArgument {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to get product: %w", err)
+	}
+
+	for _, variant := range resp.Product.Variants {
+		if variant.Id == variantID {
+			c.owned.Store(key, true)
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// Close closes the gRPC connection
+func (c *ProductClient) Close() error {
+	if c.conn != nil {
+		return c.conn.Close()
+	}
+	return nil
+}
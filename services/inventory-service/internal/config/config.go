@@ -11,14 +11,28 @@ import (
 
 // Config holds inventory service specific configuration
 type Config struct {
-	Service  sharedConfig.ServiceInfo
-	Server   sharedConfig.ServerConfig
-	Database sharedConfig.DatabaseConfig
-	Redis    sharedConfig.RedisConfig
-	RabbitMQ sharedConfig.RabbitMQConfig
-	Services sharedConfig.ExternalServices
-	Logging  sharedConfig.LoggingConfig
-	Security SecurityConfig
+	Service     sharedConfig.ServiceInfo
+	Server      sharedConfig.ServerConfig
+	Database    sharedConfig.DatabaseConfig
+	Redis       sharedConfig.RedisConfig
+	RabbitMQ    sharedConfig.RabbitMQConfig
+	Services    sharedConfig.ExternalServices
+	Logging     sharedConfig.LoggingConfig
+	Security    SecurityConfig
+	Reservation ReservationConfig
+	Events      EventsConfig
+}
+
+// EventsConfig contains event consumer settings
+type EventsConfig struct {
+	MaxRetries int // Attempts after the first before a failing event is dead-lettered
+}
+
+// ReservationConfig contains stock reservation settings
+type ReservationConfig struct {
+	TTL             time.Duration // How long reserved stock is held awaiting payment
+	ExpiryInterval  time.Duration // How often expired reservations are released
+	ExpiryBatchSize int           // Max reservations released per run
 }
 
 // SecurityConfig contains security middleware settings
@@ -56,6 +70,30 @@ func Load() (*Config, error) {
 		Services: sharedConfig.LoadExternalServices(),
 		Logging:  sharedConfig.LoadLoggingConfig(),
 		Security: LoadSecurityConfig(),
+		Reservation: ReservationConfig{
+			TTL:             sharedConfig.GetEnvAsDurationMinutes("RESERVATION_TTL_MINUTES", 30*time.Minute),
+			ExpiryInterval:  sharedConfig.GetEnvAsDuration("RESERVATION_EXPIRY_INTERVAL", time.Minute), // seconds
+			ExpiryBatchSize: sharedConfig.GetEnvAsInt("RESERVATION_EXPIRY_BATCH_SIZE", 100),
+		},
+		Events: EventsConfig{
+			MaxRetries: sharedConfig.GetEnvAsInt("EVENT_MAX_RETRIES", 3),
+		},
+	}
+
+	if cfg.Reservation.ExpiryBatchSize <= 0 {
+		cfg.Reservation.ExpiryBatchSize = 100
+	}
+
+	v := sharedConfig.NewValidator(cfg.Service)
+	v.CheckServer(cfg.Server)
+	v.CheckDatabase(cfg.Database)
+	v.CheckRedis(cfg.Redis)
+	v.CheckRabbitMQ(cfg.RabbitMQ)
+	v.Check(cfg.Reservation.TTL > 0, "RESERVATION_TTL_MINUTES must be positive")
+	v.Check(cfg.Reservation.ExpiryInterval > 0, "RESERVATION_EXPIRY_INTERVAL must be positive")
+	v.Check(cfg.Events.MaxRetries >= 0, "EVENT_MAX_RETRIES must not be negative")
+	if err := v.Err(); err != nil {
+		return nil, err
 	}
 
 	return cfg, nil
@@ -102,6 +140,14 @@ func (c *Config) PrintConfig() {
 	fmt.Printf("    Enabled: %v\n", c.Security.CORS.Enabled)
 	fmt.Printf("    Allowed Origins: %v\n", c.Security.CORS.AllowedOrigins)
 	fmt.Printf("  Request Timeout: %v\n", c.Security.RequestTimeout)
+
+	fmt.Printf("Reservation:\n")
+	fmt.Printf("  TTL: %v\n", c.Reservation.TTL)
+	fmt.Printf("  Expiry Interval: %v\n", c.Reservation.ExpiryInterval)
+	fmt.Printf("  Expiry Batch Size: %d\n", c.Reservation.ExpiryBatchSize)
+
+	fmt.Printf("Events:\n")
+	fmt.Printf("  Max Retries: %d\n", c.Events.MaxRetries)
 }
 
 // LoadSecurityConfig loads security middleware configuration
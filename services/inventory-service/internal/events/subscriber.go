@@ -5,25 +5,60 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
+	"time"
 
+	"github.com/datngth03/ecommerce-go-app/services/inventory-service/internal/middleware"
+	"github.com/datngth03/ecommerce-go-app/services/inventory-service/internal/models"
 	"github.com/datngth03/ecommerce-go-app/services/inventory-service/internal/service"
+	"github.com/datngth03/ecommerce-go-app/shared/pkg/domainerr"
 	amqp "github.com/rabbitmq/amqp091-go"
 )
 
+// Event routing
+const (
+	InventoryExchange     = "inventory"
+	PaymentExchange       = "payments"
+	EventPaymentCompleted = "payment.completed"
+	EventPaymentFailed    = "payment.failed"
+	EventPaymentRefunded  = "payment.refunded"
+	EventReturnReceived   = "return.received"
+	EventStockChanged     = "stock.changed"
+
+	ordersQueue        = "inventory.orders"
+	lagPollInterval    = 15 * time.Second // How often the consumer lag metric is refreshed
+	DeadLetterExchange = "inventory.dead-letter"
+	DeadLetterQueue    = "inventory.orders.dead-letter"
+)
+
+// Headers carried by retried and dead-lettered messages
+const (
+	headerRetryCount         = "x-retry-count"
+	headerOriginalRoutingKey = "x-original-routing-key"
+	headerOriginalExchange   = "x-original-exchange"
+	headerError              = "x-error"
+)
+
 // EventSubscriber handles inventory-related events
 type EventSubscriber struct {
-	service *service.InventoryService
-	conn    *amqp.Connection
-	channel *amqp.Channel
+	service    *service.InventoryService
+	conn       *amqp.Connection
+	channel    *amqp.Channel
+	maxRetries int // Redeliveries of a failing event before it is dead-lettered
 }
 
-// OrderCreatedEvent represents an order creation event
+// OrderCreatedEvent represents an order creation event. StockItems lists what the
+// order takes from stock, with bundles replaced by their components; events from
+// before bundles existed only carry Items.
 type OrderCreatedEvent struct {
 	OrderID string `json:"order_id"`
 	Items   []struct {
 		ProductID string `json:"product_id"`
 		Quantity  int32  `json:"quantity"`
 	} `json:"items"`
+	StockItems []struct {
+		ProductID string `json:"product_id"`
+		Quantity  int32  `json:"quantity"`
+	} `json:"stock_items"`
 }
 
 // OrderCancelledEvent represents an order cancellation event
@@ -32,8 +67,63 @@ type OrderCancelledEvent struct {
 	Reason  string `json:"reason"`
 }
 
-// NewEventSubscriber creates a new event subscriber
-func NewEventSubscriber(svc *service.InventoryService, rabbitmqURL string) (*EventSubscriber, error) {
+// PaymentCompletedEvent is published by payment service when a payment succeeds.
+// Items are empty when payment service could not look up the order.
+type PaymentCompletedEvent struct {
+	PaymentID string `json:"payment_id"`
+	OrderID   string `json:"order_id"`
+	Items     []struct {
+		ProductID string `json:"product_id"`
+		Quantity  int32  `json:"quantity"`
+	} `json:"items"`
+}
+
+// PaymentFailedEvent is published by payment service when a payment fails for good
+type PaymentFailedEvent struct {
+	PaymentID string `json:"payment_id"`
+	OrderID   string `json:"order_id"`
+	Reason    string `json:"reason"`
+}
+
+// PaymentRefundedEvent is published by payment service after a refund.
+// Items are what goes back to stock; money-only refunds carry none.
+type PaymentRefundedEvent struct {
+	RefundID  string `json:"refund_id"`
+	PaymentID string `json:"payment_id"`
+	OrderID   string `json:"order_id"`
+	Items     []struct {
+		ProductID string `json:"product_id"`
+		Quantity  int32  `json:"quantity"`
+	} `json:"items"`
+}
+
+// ReturnReceivedEvent is published by order service when the items of a return
+// (RMA) arrive back. StockItems is what goes back to stock, with bundles replaced
+// by their components.
+type ReturnReceivedEvent struct {
+	ReturnID   string `json:"return_id"`
+	OrderID    string `json:"order_id"`
+	StockItems []struct {
+		ProductID string `json:"product_id"`
+		Quantity  int32  `json:"quantity"`
+	} `json:"stock_items"`
+}
+
+// StockChangedEvent is published after stock levels of a product change
+type StockChangedEvent struct {
+	EventType   string    `json:"event_type"`
+	ProductID   string    `json:"product_id"`
+	Available   int32     `json:"available"`
+	Reserved    int32     `json:"reserved"`
+	Total       int32     `json:"total"`
+	ReferenceID string    `json:"reference_id"` // e.g. order ID
+	Reason      string    `json:"reason"`
+	ChangedAt   time.Time `json:"changed_at"`
+}
+
+// NewEventSubscriber creates a new event subscriber. An event whose processing keeps
+// failing is retried maxRetries times, then moved to the dead-letter queue.
+func NewEventSubscriber(svc *service.InventoryService, rabbitmqURL string, maxRetries int) (*EventSubscriber, error) {
 	conn, err := amqp.Dial(rabbitmqURL)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to RabbitMQ: %w", err)
@@ -46,9 +136,10 @@ func NewEventSubscriber(svc *service.InventoryService, rabbitmqURL string) (*Eve
 	}
 
 	return &EventSubscriber{
-		service: svc,
-		conn:    conn,
-		channel: channel,
+		service:    svc,
+		conn:       conn,
+		channel:    channel,
+		maxRetries: maxRetries,
 	}, nil
 }
 
@@ -68,9 +159,68 @@ func (s *EventSubscriber) Start(ctx context.Context) error {
 		return fmt.Errorf("failed to declare exchange: %w", err)
 	}
 
+	// Declare exchange for events published by inventory
+	err = s.channel.ExchangeDeclare(
+		InventoryExchange,
+		"topic",
+		true,
+		false,
+		false,
+		false,
+		nil,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to declare exchange: %w", err)
+	}
+
+	// Declare exchange for events published by payment
+	err = s.channel.ExchangeDeclare(
+		PaymentExchange,
+		"topic",
+		true,
+		false,
+		false,
+		false,
+		nil,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to declare exchange: %w", err)
+	}
+
+	// Dead-letter exchange and queue: failed events are kept there for inspection and replay
+	err = s.channel.ExchangeDeclare(
+		DeadLetterExchange,
+		"topic",
+		true,
+		false,
+		false,
+		false,
+		nil,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to declare exchange: %w", err)
+	}
+
+	deadLetters, err := s.channel.QueueDeclare(
+		DeadLetterQueue,
+		true,
+		false,
+		false,
+		false,
+		nil,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to declare dead-letter queue: %w", err)
+	}
+
+	err = s.channel.QueueBind(deadLetters.Name, "#", DeadLetterExchange, false, nil)
+	if err != nil {
+		return fmt.Errorf("failed to bind dead-letter queue: %w", err)
+	}
+
 	// Declare queue
 	queue, err := s.channel.QueueDeclare(
-		"inventory.orders",
+		ordersQueue,
 		true,
 		false,
 		false,
@@ -105,6 +255,44 @@ func (s *EventSubscriber) Start(ctx context.Context) error {
 		return fmt.Errorf("failed to bind order.cancelled: %w", err)
 	}
 
+	// Bind to return.received
+	err = s.channel.QueueBind(
+		queue.Name,
+		EventReturnReceived,
+		"orders",
+		false,
+		nil,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to bind return.received: %w", err)
+	}
+
+	// Bind to payment.completed and payment.failed
+	for _, routingKey := range []string{EventPaymentCompleted, EventPaymentFailed} {
+		err = s.channel.QueueBind(
+			queue.Name,
+			routingKey,
+			PaymentExchange,
+			false,
+			nil,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to bind %s: %w", routingKey, err)
+		}
+	}
+
+	// Bind to payment.refunded
+	err = s.channel.QueueBind(
+		queue.Name,
+		EventPaymentRefunded,
+		PaymentExchange,
+		false,
+		nil,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to bind payment.refunded: %w", err)
+	}
+
 	// Start consuming
 	msgs, err := s.channel.Consume(
 		queue.Name,
@@ -120,6 +308,7 @@ func (s *EventSubscriber) Start(ctx context.Context) error {
 	}
 
 	log.Println("Inventory event subscriber started")
+	go s.watchLag(ctx, queue.Name)
 
 	// Process messages
 	go func() {
@@ -139,38 +328,93 @@ func (s *EventSubscriber) Start(ctx context.Context) error {
 
 // handleMessage processes incoming messages
 func (s *EventSubscriber) handleMessage(ctx context.Context, msg amqp.Delivery) {
-	log.Printf("Received event: %s", msg.RoutingKey)
+	routingKey := eventRoutingKey(msg)
+	log.Printf("Received event: %s", routingKey)
+	start := time.Now()
+	defer func() {
+		middleware.RecordEventProcessed(routingKey, time.Since(start))
+	}()
 
-	switch msg.RoutingKey {
+	switch routingKey {
 	case "order.created":
 		s.handleOrderCreated(ctx, msg)
 	case "order.cancelled":
 		s.handleOrderCancelled(ctx, msg)
+	case EventPaymentCompleted:
+		s.handlePaymentCompleted(ctx, msg)
+	case EventPaymentFailed:
+		s.handlePaymentFailed(ctx, msg)
+	case EventPaymentRefunded:
+		s.handlePaymentRefunded(ctx, msg)
+	case EventReturnReceived:
+		s.handleReturnReceived(ctx, msg)
 	default:
-		log.Printf("Unknown routing key: %s", msg.RoutingKey)
+		log.Printf("Unknown routing key: %s", routingKey)
 		msg.Ack(false)
 	}
 }
 
+// watchLag refreshes the consumer lag metric with the number of events waiting in
+// queue until ctx is done. It uses its own channel: a failed passive declare closes
+// the channel it runs on, which must not stop consumption.
+func (s *EventSubscriber) watchLag(ctx context.Context, queue string) {
+	ticker := time.NewTicker(lagPollInterval)
+	defer ticker.Stop()
+
+	for {
+		if waiting, err := s.queueDepth(queue); err != nil {
+			log.Printf("Failed to read depth of queue %s: %v", queue, err)
+		} else {
+			middleware.RecordConsumerLag(queue, waiting)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// queueDepth returns the number of messages ready for delivery in queue
+func (s *EventSubscriber) queueDepth(queue string) (int, error) {
+	channel, err := s.conn.Channel()
+	if err != nil {
+		return 0, err
+	}
+	defer channel.Close()
+
+	q, err := channel.QueueDeclarePassive(queue, true, false, false, false, nil)
+	if err != nil {
+		return 0, err
+	}
+	return q.Messages, nil
+}
+
 // handleOrderCreated handles order creation event
 func (s *EventSubscriber) handleOrderCreated(ctx context.Context, msg amqp.Delivery) {
 	var event OrderCreatedEvent
 	err := json.Unmarshal(msg.Body, &event)
 	if err != nil {
 		log.Printf("Failed to unmarshal order.created event: %v", err)
-		msg.Nack(false, false)
+		s.fail(ctx, msg, err, false)
 		return
 	}
 
 	log.Printf("Reserving stock for order: %s", event.OrderID)
 
+	eventItems := event.StockItems
+	if len(eventItems) == 0 {
+		eventItems = event.Items
+	}
+
 	// Convert items format
 	items := make([]struct {
 		ProductID string
 		Quantity  int32
-	}, len(event.Items))
+	}, len(eventItems))
 
-	for i, item := range event.Items {
+	for i, item := range eventItems {
 		items[i] = struct {
 			ProductID string
 			Quantity  int32
@@ -184,7 +428,7 @@ func (s *EventSubscriber) handleOrderCreated(ctx context.Context, msg amqp.Deliv
 	_, err = s.service.ReserveStock(ctx, event.OrderID, items)
 	if err != nil {
 		log.Printf("Failed to reserve stock for order %s: %v", event.OrderID, err)
-		msg.Nack(false, true) // requeue
+		s.fail(ctx, msg, err, true)
 		return
 	}
 
@@ -198,17 +442,23 @@ func (s *EventSubscriber) handleOrderCancelled(ctx context.Context, msg amqp.Del
 	err := json.Unmarshal(msg.Body, &event)
 	if err != nil {
 		log.Printf("Failed to unmarshal order.cancelled event: %v", err)
-		msg.Nack(false, false)
+		s.fail(ctx, msg, err, false)
 		return
 	}
 
 	log.Printf("Releasing stock for cancelled order: %s", event.OrderID)
 
-	// Release reserved stock
+	// Release reserved stock. Only reserved stock is released: a paid order's
+	// reservation is already committed and its stock returns with the refund.
 	err = s.service.ReleaseStock(ctx, event.OrderID, event.Reason)
+	if domainerr.Is(err, domainerr.KindNotFound) {
+		log.Printf("No reserved stock to release for cancelled order: %s", event.OrderID)
+		msg.Ack(false)
+		return
+	}
 	if err != nil {
 		log.Printf("Failed to release stock for order %s: %v", event.OrderID, err)
-		msg.Nack(false, true) // requeue
+		s.fail(ctx, msg, err, true)
 		return
 	}
 
@@ -216,6 +466,322 @@ func (s *EventSubscriber) handleOrderCancelled(ctx context.Context, msg amqp.Del
 	msg.Ack(false)
 }
 
+// handlePaymentCompleted commits the reserved stock of a paid order and emits stock.changed
+func (s *EventSubscriber) handlePaymentCompleted(ctx context.Context, msg amqp.Delivery) {
+	var event PaymentCompletedEvent
+	err := json.Unmarshal(msg.Body, &event)
+	if err != nil {
+		log.Printf("Failed to unmarshal payment.completed event: %v", err)
+		s.fail(ctx, msg, err, false)
+		return
+	}
+
+	log.Printf("Committing reserved stock for order %s (payment %s)", event.OrderID, event.PaymentID)
+
+	items := make([]models.SaleItem, len(event.Items))
+	for i, item := range event.Items {
+		items[i] = models.SaleItem{
+			ProductID: item.ProductID,
+			Quantity:  item.Quantity,
+		}
+	}
+
+	stocks, applied, err := s.service.ProcessPaymentCompleted(ctx, event.OrderID, items)
+	if domainerr.Is(err, domainerr.KindNotFound) {
+		// Nothing was reserved (or it was released) and the event names no items
+		log.Printf("No reserved stock to commit for order %s (payment %s)", event.OrderID, event.PaymentID)
+		msg.Ack(false)
+		return
+	}
+	if err != nil {
+		log.Printf("Failed to commit stock for order %s (payment %s): %v", event.OrderID, event.PaymentID, err)
+		s.fail(ctx, msg, err, retryable(err))
+		return
+	}
+
+	if !applied {
+		log.Printf("Order %s already processed, skipping payment.completed for payment %s", event.OrderID, event.PaymentID)
+		msg.Ack(false)
+		return
+	}
+
+	for _, stock := range stocks {
+		changed := StockChangedEvent{
+			EventType:   EventStockChanged,
+			ProductID:   stock.ProductID,
+			Available:   stock.Available,
+			Reserved:    stock.Reserved,
+			Total:       stock.Total,
+			ReferenceID: event.OrderID,
+			Reason:      EventPaymentCompleted,
+			ChangedAt:   time.Now(),
+		}
+		if err := s.publish(ctx, EventStockChanged, changed); err != nil {
+			log.Printf("Warning: failed to publish stock.changed for product %s: %v", stock.ProductID, err)
+		}
+	}
+
+	log.Printf("Reserved stock committed for order: %s", event.OrderID)
+	msg.Ack(false)
+}
+
+// handlePaymentFailed releases the reserved stock of an order whose payment failed.
+// Only pending reservations are released, so a redelivered event, or one arriving
+// after another payment of the order succeeded, changes nothing.
+func (s *EventSubscriber) handlePaymentFailed(ctx context.Context, msg amqp.Delivery) {
+	var event PaymentFailedEvent
+	err := json.Unmarshal(msg.Body, &event)
+	if err != nil {
+		log.Printf("Failed to unmarshal payment.failed event: %v", err)
+		s.fail(ctx, msg, err, false)
+		return
+	}
+
+	log.Printf("Releasing reserved stock for order %s (payment %s failed)", event.OrderID, event.PaymentID)
+
+	reason := "Payment failed"
+	if event.Reason != "" {
+		reason += ": " + event.Reason
+	}
+	err = s.service.ReleaseStock(ctx, event.OrderID, reason)
+	if domainerr.Is(err, domainerr.KindNotFound) {
+		log.Printf("No reserved stock to release for order %s (payment %s)", event.OrderID, event.PaymentID)
+		msg.Ack(false)
+		return
+	}
+	if err != nil {
+		log.Printf("Failed to release stock for order %s: %v", event.OrderID, err)
+		s.fail(ctx, msg, err, retryable(err))
+		return
+	}
+
+	log.Printf("Reserved stock released for order: %s", event.OrderID)
+	msg.Ack(false)
+}
+
+// handlePaymentRefunded returns refunded items to stock and emits stock.changed
+func (s *EventSubscriber) handlePaymentRefunded(ctx context.Context, msg amqp.Delivery) {
+	var event PaymentRefundedEvent
+	err := json.Unmarshal(msg.Body, &event)
+	if err != nil {
+		log.Printf("Failed to unmarshal payment.refunded event: %v", err)
+		s.fail(ctx, msg, err, false)
+		return
+	}
+
+	if len(event.Items) == 0 {
+		log.Printf("Refund %s returns no items, nothing to restock", event.RefundID)
+		msg.Ack(false)
+		return
+	}
+
+	log.Printf("Restocking refunded items of order %s (refund %s)", event.OrderID, event.RefundID)
+
+	items := make([]models.ReturnItem, len(event.Items))
+	for i, item := range event.Items {
+		items[i] = models.ReturnItem{
+			ProductID: item.ProductID,
+			Quantity:  item.Quantity,
+		}
+	}
+
+	stocks, applied, err := s.service.ProcessRefund(ctx, event.OrderID, event.RefundID, items)
+	if err != nil {
+		log.Printf("Failed to restock refund %s: %v", event.RefundID, err)
+		s.fail(ctx, msg, err, retryable(err))
+		return
+	}
+
+	if !applied {
+		log.Printf("Refund %s already processed, skipping redelivered payment.refunded", event.RefundID)
+		msg.Ack(false)
+		return
+	}
+
+	for _, stock := range stocks {
+		changed := StockChangedEvent{
+			EventType:   EventStockChanged,
+			ProductID:   stock.ProductID,
+			Available:   stock.Available,
+			Reserved:    stock.Reserved,
+			Total:       stock.Total,
+			ReferenceID: event.OrderID,
+			Reason:      EventPaymentRefunded,
+			ChangedAt:   time.Now(),
+		}
+		if err := s.publish(ctx, EventStockChanged, changed); err != nil {
+			log.Printf("Warning: failed to publish stock.changed for product %s: %v", stock.ProductID, err)
+		}
+	}
+
+	log.Printf("Refunded items restocked for order: %s", event.OrderID)
+	msg.Ack(false)
+}
+
+// handleReturnReceived returns the items of a received return to stock and emits stock.changed.
+// The refund that follows skips restocking, so the items go back only once.
+func (s *EventSubscriber) handleReturnReceived(ctx context.Context, msg amqp.Delivery) {
+	var event ReturnReceivedEvent
+	err := json.Unmarshal(msg.Body, &event)
+	if err != nil {
+		log.Printf("Failed to unmarshal return.received event: %v", err)
+		s.fail(ctx, msg, err, false)
+		return
+	}
+
+	log.Printf("Restocking returned items of order %s (return %s)", event.OrderID, event.ReturnID)
+
+	items := make([]models.ReturnItem, len(event.StockItems))
+	for i, item := range event.StockItems {
+		items[i] = models.ReturnItem{
+			ProductID: item.ProductID,
+			Quantity:  item.Quantity,
+		}
+	}
+
+	stocks, applied, err := s.service.ProcessReturnReceived(ctx, event.OrderID, event.ReturnID, items)
+	if err != nil {
+		log.Printf("Failed to restock return %s: %v", event.ReturnID, err)
+		s.fail(ctx, msg, err, retryable(err))
+		return
+	}
+
+	if !applied {
+		log.Printf("Return %s already processed, skipping redelivered return.received", event.ReturnID)
+		msg.Ack(false)
+		return
+	}
+
+	for _, stock := range stocks {
+		changed := StockChangedEvent{
+			EventType:   EventStockChanged,
+			ProductID:   stock.ProductID,
+			Available:   stock.Available,
+			Reserved:    stock.Reserved,
+			Total:       stock.Total,
+			ReferenceID: event.OrderID,
+			Reason:      EventReturnReceived,
+			ChangedAt:   time.Now(),
+		}
+		if err := s.publish(ctx, EventStockChanged, changed); err != nil {
+			log.Printf("Warning: failed to publish stock.changed for product %s: %v", stock.ProductID, err)
+		}
+	}
+
+	log.Printf("Returned items restocked for order: %s", event.OrderID)
+	msg.Ack(false)
+}
+
+// retryable reports whether a failed event may succeed on a later attempt. Domain
+// errors (invalid payloads, unknown products, oversold items) won't; database and
+// other unexpected failures may.
+func retryable(err error) bool {
+	switch domainerr.KindOf(err) {
+	case domainerr.KindInternal, domainerr.KindAborted, domainerr.KindUnavailable:
+		return true
+	}
+	return false
+}
+
+// fail settles a message whose processing failed. Retryable failures go back to the end
+// of the queue until maxRetries is reached, so one bad event cannot block the ones behind
+// it; after that, or straight away for permanent failures, the message is dead-lettered
+// with the error and the original payload.
+func (s *EventSubscriber) fail(ctx context.Context, msg amqp.Delivery, cause error, retryable bool) {
+	retries := retryCount(msg)
+	if retryable && retries < s.maxRetries {
+		if err := s.republish(ctx, msg, ordersQueue, "", retries+1, cause); err != nil {
+			log.Printf("Failed to schedule retry of %s: %v", eventRoutingKey(msg), err)
+			msg.Nack(false, true)
+			return
+		}
+		msg.Ack(false)
+		return
+	}
+
+	if err := s.republish(ctx, msg, eventRoutingKey(msg), DeadLetterExchange, retries, cause); err != nil {
+		// Keep the message rather than lose it
+		log.Printf("Failed to dead-letter %s: %v", eventRoutingKey(msg), err)
+		msg.Nack(false, true)
+		return
+	}
+
+	middleware.RecordEventDeadLettered(eventRoutingKey(msg))
+	log.Printf("Dead-lettered %s after %d retries: %v", eventRoutingKey(msg), retries, cause)
+	msg.Ack(false)
+}
+
+// republish sends a copy of msg to exchange with the retry count and the last error
+func (s *EventSubscriber) republish(ctx context.Context, msg amqp.Delivery, routingKey, exchange string, retries int, cause error) error {
+	headers := amqp.Table{}
+	for k, v := range msg.Headers {
+		headers[k] = v
+	}
+	headers[headerRetryCount] = int32(retries)
+	headers[headerError] = cause.Error()
+	if _, ok := headers[headerOriginalRoutingKey]; !ok {
+		headers[headerOriginalRoutingKey] = msg.RoutingKey
+		headers[headerOriginalExchange] = msg.Exchange
+	}
+
+	return s.channel.PublishWithContext(ctx,
+		exchange,
+		routingKey,
+		false,
+		false,
+		amqp.Publishing{
+			Headers:      headers,
+			ContentType:  msg.ContentType,
+			Body:         msg.Body,
+			DeliveryMode: amqp.Persistent,
+			Timestamp:    time.Now(),
+		},
+	)
+}
+
+// eventRoutingKey returns the routing key the event was originally published with;
+// retried messages arrive through the default exchange under the queue name
+func eventRoutingKey(msg amqp.Delivery) string {
+	if key, ok := msg.Headers[headerOriginalRoutingKey].(string); ok && key != "" {
+		return key
+	}
+	return msg.RoutingKey
+}
+
+// retryCount returns how many times msg has already been retried
+func retryCount(msg amqp.Delivery) int {
+	switch n := msg.Headers[headerRetryCount].(type) {
+	case int32:
+		return int(n)
+	case int64:
+		return int(n)
+	case int:
+		return n
+	}
+	return 0
+}
+
+// publish sends an event to the inventory exchange
+func (s *EventSubscriber) publish(ctx context.Context, routingKey string, event interface{}) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event: %w", err)
+	}
+
+	return s.channel.PublishWithContext(ctx,
+		InventoryExchange,
+		routingKey,
+		false,
+		false,
+		amqp.Publishing{
+			ContentType:  "application/json",
+			Body:         body,
+			DeliveryMode: amqp.Persistent,
+			Timestamp:    time.Now(),
+		},
+	)
+}
+
 // Close closes the connection
 func (s *EventSubscriber) Close() error {
 	if s.channel != nil {
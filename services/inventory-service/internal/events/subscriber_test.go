@@ -0,0 +1,33 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/datngth03/ecommerce-go-app/shared/pkg/domainerr"
+)
+
+func TestRetryable(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"database failure", errors.New("failed to lock stock: connection reset"), true},
+		{"timeout", context.DeadlineExceeded, true},
+		{"wrapped unavailable", fmt.Errorf("commit: %w", domainerr.Unavailable(errors.New("down"), "database unavailable")), true},
+		{"invalid payload", domainerr.InvalidArgument("product_id is required"), false},
+		{"oversold", domainerr.Conflict("insufficient stock for product p1: need 2, have 1"), false},
+		{"unknown product", domainerr.NotFound("stock not found for product p1"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := retryable(tt.err); got != tt.want {
+				t.Errorf("retryable(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
@@ -50,6 +50,10 @@ var (
 	DatabaseQueryDuration   *prometheus.HistogramVec
 	grpcRequestsTotal       *prometheus.CounterVec
 	grpcRequestDuration     *prometheus.HistogramVec
+	EventsDeadLetteredTotal *prometheus.CounterVec
+	EventsProcessedTotal    *prometheus.CounterVec
+	EventProcessingDuration *prometheus.HistogramVec
+	EventConsumerLag        *prometheus.GaugeVec
 	businessMetricsOnce     sync.Once
 )
 
@@ -120,6 +124,40 @@ func initBusinessMetrics() {
 			[]string{"method"},
 		)
 
+		EventsDeadLetteredTotal = prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "inventory_events_dead_lettered_total",
+				Help: "Total number of events moved to the dead-letter queue",
+			},
+			[]string{"event"},
+		)
+
+		// Event consumer metrics
+		EventsProcessedTotal = prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "inventory_events_processed_total",
+				Help: "Total number of events consumed from the message broker",
+			},
+			[]string{"event"},
+		)
+
+		EventProcessingDuration = prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Name:    "inventory_event_processing_duration_seconds",
+				Help:    "Time to process one consumed event in seconds",
+				Buckets: prometheus.DefBuckets,
+			},
+			[]string{"event"},
+		)
+
+		EventConsumerLag = prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "inventory_event_consumer_lag",
+				Help: "Number of events waiting in the queue to be consumed",
+			},
+			[]string{"queue"},
+		)
+
 		// Register all business metrics with duplicate handling
 		registerMetric(StockLevelGauge)
 		registerMetric(ReservationsActive)
@@ -129,6 +167,10 @@ func initBusinessMetrics() {
 		registerMetric(DatabaseQueryDuration)
 		registerMetric(grpcRequestsTotal)
 		registerMetric(grpcRequestDuration)
+		registerMetric(EventsDeadLetteredTotal)
+		registerMetric(EventsProcessedTotal)
+		registerMetric(EventProcessingDuration)
+		registerMetric(EventConsumerLag)
 	})
 }
 
@@ -233,6 +275,25 @@ func RecordGRPCRequest(method, status string, duration time.Duration) {
 	grpcRequestDuration.WithLabelValues(method).Observe(duration.Seconds())
 }
 
+// RecordEventDeadLettered increments the dead-lettered event counter
+func RecordEventDeadLettered(event string) {
+	initBusinessMetrics()
+	EventsDeadLetteredTotal.WithLabelValues(event).Inc()
+}
+
+// RecordEventProcessed records one consumed event and how long it took
+func RecordEventProcessed(event string, duration time.Duration) {
+	initBusinessMetrics()
+	EventsProcessedTotal.WithLabelValues(event).Inc()
+	EventProcessingDuration.WithLabelValues(event).Observe(duration.Seconds())
+}
+
+// RecordConsumerLag sets the number of events waiting in queue
+func RecordConsumerLag(queue string, waiting int) {
+	initBusinessMetrics()
+	EventConsumerLag.WithLabelValues(queue).Set(float64(waiting))
+}
+
 // PrometheusGinMiddleware records HTTP metrics for Gin framework
 func PrometheusGinMiddleware() gin.HandlerFunc {
 	initMetrics()
@@ -58,17 +58,36 @@ func (StockMovement) TableName() string {
 	return "stock_movements"
 }
 
+// IsValidMovementType reports whether t is one of the MovementType constants
+func IsValidMovementType(t string) bool {
+	switch t {
+	case MovementTypeInbound, MovementTypeOutbound, MovementTypeReserved,
+		MovementTypeReleased, MovementTypeCommitted, MovementTypeAdjustment:
+		return true
+	}
+	return false
+}
+
+// MovementFilter narrows down stock movement history queries.
+// Zero values mean "no filter".
+type MovementFilter struct {
+	MovementType string
+	From         *time.Time // inclusive
+	To           *time.Time // inclusive
+}
+
 // Reservation represents a stock reservation for pending orders
 type Reservation struct {
-	ID          string    `json:"id" gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
-	OrderID     string    `json:"order_id" gorm:"uniqueIndex;not null"`
-	ProductID   string    `json:"product_id" gorm:"index;not null"`
-	Quantity    int32     `json:"quantity" gorm:"not null"`
-	Status      string    `json:"status" gorm:"not null;default:'PENDING'"` // PENDING, COMMITTED, RELEASED
-	WarehouseID string    `json:"warehouse_id" gorm:"default:'default'"`
-	ExpiresAt   time.Time `json:"expires_at"` // Auto-release if not committed
-	CreatedAt   time.Time `json:"created_at" gorm:"autoCreateTime"`
-	UpdatedAt   time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+	ID            string    `json:"id" gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	ReservationID string    `json:"reservation_id" gorm:"type:uuid;index;not null"` // Shared by all lines reserved in one ReserveStock call
+	OrderID       string    `json:"order_id" gorm:"index;not null"`
+	ProductID     string    `json:"product_id" gorm:"index;not null"`
+	Quantity      int32     `json:"quantity" gorm:"not null"`
+	Status        string    `json:"status" gorm:"not null;default:'PENDING'"` // PENDING, COMMITTED, RELEASED, EXPIRED
+	WarehouseID   string    `json:"warehouse_id" gorm:"default:'default'"`
+	ExpiresAt     time.Time `json:"expires_at"` // Released back to available stock if not committed by then
+	CreatedAt     time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt     time.Time `json:"updated_at" gorm:"autoUpdateTime"`
 }
 
 // TableName specifies the table name for Reservation
@@ -83,3 +102,27 @@ const (
 	ReservationStatusReleased  = "RELEASED"
 	ReservationStatusExpired   = "EXPIRED"
 )
+
+// ProcessedOrderEvent records an order event already applied to stock (idempotency key)
+type ProcessedOrderEvent struct {
+	OrderID     string    `json:"order_id" gorm:"primaryKey"`
+	EventType   string    `json:"event_type" gorm:"primaryKey"`
+	ProcessedAt time.Time `json:"processed_at" gorm:"autoCreateTime"`
+}
+
+// TableName specifies the table name for ProcessedOrderEvent
+func (ProcessedOrderEvent) TableName() string {
+	return "processed_order_events"
+}
+
+// ReturnItem represents a refunded line item going back to stock
+type ReturnItem struct {
+	ProductID string `json:"product_id"`
+	Quantity  int32  `json:"quantity"`
+}
+
+// SaleItem represents a sold line item of a paid order
+type SaleItem struct {
+	ProductID string `json:"product_id"`
+	Quantity  int32  `json:"quantity"`
+}
@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/datngth03/ecommerce-go-app/services/inventory-service/internal/models"
@@ -61,6 +62,43 @@ func (r *CachedInventoryRepository) GetStock(ctx context.Context, productID stri
 	return dbStock, nil
 }
 
+// GetStocks retrieves stock for multiple products, only hitting the DB for cache misses
+func (r *CachedInventoryRepository) GetStocks(ctx context.Context, productIDs []string) ([]*models.Stock, error) {
+	stocks := make([]*models.Stock, 0, len(productIDs))
+	var missing []string
+
+	for _, productID := range productIDs {
+		var stock models.Stock
+		err := r.cache.Get(ctx, fmt.Sprintf("stock:product:%s", productID), &stock)
+		if err == nil {
+			stocks = append(stocks, &stock)
+			continue
+		}
+		if !cache.IsCacheMiss(err) {
+			fmt.Printf("Cache error for stock product %s: %v\n", productID, err)
+		}
+		missing = append(missing, productID)
+	}
+
+	if len(missing) == 0 {
+		return stocks, nil
+	}
+
+	dbStocks, err := r.repo.GetStocks(ctx, missing)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, stock := range dbStocks {
+		cacheKey := fmt.Sprintf("stock:product:%s", stock.ProductID)
+		if err := r.cache.Set(ctx, cacheKey, stock, StockCacheTTL); err != nil {
+			fmt.Printf("Warning: failed to cache stock for product %s: %v\n", stock.ProductID, err)
+		}
+	}
+
+	return append(stocks, dbStocks...), nil
+}
+
 // UpdateStock updates stock and invalidates cache immediately
 func (r *CachedInventoryRepository) UpdateStock(ctx context.Context, productID string, quantity int32, reason string) (*models.Stock, error) {
 	// Update in database
@@ -73,6 +111,7 @@ func (r *CachedInventoryRepository) UpdateStock(ctx context.Context, productID s
 	cacheKeys := []string{
 		fmt.Sprintf("stock:product:%s", productID),
 		fmt.Sprintf("availability:product:%s:*", productID), // Pattern for all availability checks
+		fmt.Sprintf("movements:product:%s:*", productID),    // Every update writes a movement
 	}
 
 	for _, key := range cacheKeys {
@@ -121,9 +160,9 @@ func (r *CachedInventoryRepository) CheckAvailability(ctx context.Context, produ
 }
 
 // CreateReservation creates a reservation and invalidates related caches
-func (r *CachedInventoryRepository) CreateReservation(ctx context.Context, orderID, productID string, quantity int32) (*models.Reservation, error) {
+func (r *CachedInventoryRepository) CreateReservation(ctx context.Context, reservationID, orderID, productID string, quantity int32, expiresAt time.Time) (*models.Reservation, error) {
 	// Create in database
-	reservation, err := r.repo.CreateReservation(ctx, orderID, productID, quantity)
+	reservation, err := r.repo.CreateReservation(ctx, reservationID, orderID, productID, quantity, expiresAt)
 	if err != nil {
 		return nil, err
 	}
@@ -142,6 +181,9 @@ func (r *CachedInventoryRepository) CreateReservation(ctx context.Context, order
 	if err := r.cache.DeletePattern(ctx, fmt.Sprintf("availability:product:%s:*", productID)); err != nil {
 		fmt.Printf("Warning: failed to invalidate availability pattern: %v\n", err)
 	}
+	if err := r.cache.DeletePattern(ctx, fmt.Sprintf("movements:product:%s:*", productID)); err != nil {
+		fmt.Printf("Warning: failed to invalidate movement history: %v\n", err)
+	}
 
 	return reservation, nil
 }
@@ -176,6 +218,74 @@ func (r *CachedInventoryRepository) GetReservation(ctx context.Context, orderID
 	return dbReservations, nil
 }
 
+// GetReservationsByID retrieves the lines of a reservation (no caching - used for state checks)
+func (r *CachedInventoryRepository) GetReservationsByID(ctx context.Context, reservationID string) ([]*models.Reservation, error) {
+	return r.repo.GetReservationsByID(ctx, reservationID)
+}
+
+// CommitReservationByID commits a reservation and invalidates caches of its products
+func (r *CachedInventoryRepository) CommitReservationByID(ctx context.Context, reservationID string) ([]*models.Reservation, error) {
+	committed, err := r.repo.CommitReservationByID(ctx, reservationID)
+	if err != nil {
+		return nil, err
+	}
+
+	r.invalidateReservationCaches(ctx, committed)
+	return committed, nil
+}
+
+// ReleaseReservationByID releases a reservation and invalidates caches of its products
+func (r *CachedInventoryRepository) ReleaseReservationByID(ctx context.Context, reservationID, reason string) ([]*models.Reservation, error) {
+	released, err := r.repo.ReleaseReservationByID(ctx, reservationID, reason)
+	if err != nil {
+		return nil, err
+	}
+
+	r.invalidateReservationCaches(ctx, released)
+	return released, nil
+}
+
+// ReleaseExpiredReservations releases expired reservations and invalidates caches of their products
+func (r *CachedInventoryRepository) ReleaseExpiredReservations(ctx context.Context, now time.Time, limit int) ([]*models.Reservation, error) {
+	expired, err := r.repo.ReleaseExpiredReservations(ctx, now, limit)
+	// Invalidate whatever was released even if a later reservation failed
+	r.invalidateReservationCaches(ctx, expired)
+	return expired, err
+}
+
+// invalidateReservationCaches drops the order, stock, availability and movement caches touched by reservations
+func (r *CachedInventoryRepository) invalidateReservationCaches(ctx context.Context, reservations []*models.Reservation) {
+	if len(reservations) == 0 {
+		return
+	}
+
+	var keysToInvalidate []string
+	seenOrders := make(map[string]bool)
+	seenProducts := make(map[string]bool)
+	for _, res := range reservations {
+		if !seenOrders[res.OrderID] {
+			seenOrders[res.OrderID] = true
+			keysToInvalidate = append(keysToInvalidate, fmt.Sprintf("reservation:order:%s", res.OrderID))
+		}
+		if seenProducts[res.ProductID] {
+			continue
+		}
+		seenProducts[res.ProductID] = true
+		keysToInvalidate = append(keysToInvalidate, fmt.Sprintf("stock:product:%s", res.ProductID))
+
+		if err := r.cache.DeletePattern(ctx, fmt.Sprintf("availability:product:%s:*", res.ProductID)); err != nil {
+			fmt.Printf("Warning: failed to invalidate availability for product %s: %v\n", res.ProductID, err)
+		}
+		if err := r.cache.DeletePattern(ctx, fmt.Sprintf("movements:product:%s:*", res.ProductID)); err != nil {
+			fmt.Printf("Warning: failed to invalidate movement history for product %s: %v\n", res.ProductID, err)
+		}
+	}
+
+	if err := r.cache.Delete(ctx, keysToInvalidate...); err != nil {
+		fmt.Printf("Warning: failed to invalidate reservation caches: %v\n", err)
+	}
+}
+
 // CommitReservation commits a reservation and invalidates all related caches
 func (r *CachedInventoryRepository) CommitReservation(ctx context.Context, orderID string) error {
 	// Get reservations first to know which products to invalidate
@@ -202,6 +312,9 @@ func (r *CachedInventoryRepository) CommitReservation(ctx context.Context, order
 		if err := r.cache.DeletePattern(ctx, fmt.Sprintf("availability:product:%s:*", res.ProductID)); err != nil {
 			fmt.Printf("Warning: failed to invalidate availability for product %s: %v\n", res.ProductID, err)
 		}
+		if err := r.cache.DeletePattern(ctx, fmt.Sprintf("movements:product:%s:*", res.ProductID)); err != nil {
+			fmt.Printf("Warning: failed to invalidate movement history for product %s: %v\n", res.ProductID, err)
+		}
 	}
 
 	if err := r.cache.Delete(ctx, keysToInvalidate...); err != nil {
@@ -211,6 +324,62 @@ func (r *CachedInventoryRepository) CommitReservation(ctx context.Context, order
 	return nil
 }
 
+// CommitOrderSale applies a paid order and invalidates caches of affected products
+func (r *CachedInventoryRepository) CommitOrderSale(ctx context.Context, orderID, eventType string, items []models.SaleItem) ([]*models.Stock, bool, error) {
+	stocks, applied, err := r.repo.CommitOrderSale(ctx, orderID, eventType, items)
+	if err != nil || !applied {
+		return stocks, applied, err
+	}
+
+	keysToInvalidate := []string{
+		fmt.Sprintf("reservation:order:%s", orderID),
+	}
+	for _, stock := range stocks {
+		keysToInvalidate = append(keysToInvalidate, fmt.Sprintf("stock:product:%s", stock.ProductID))
+
+		if err := r.cache.DeletePattern(ctx, fmt.Sprintf("availability:product:%s:*", stock.ProductID)); err != nil {
+			fmt.Printf("Warning: failed to invalidate availability for product %s: %v\n", stock.ProductID, err)
+		}
+		if err := r.cache.DeletePattern(ctx, fmt.Sprintf("movements:product:%s:*", stock.ProductID)); err != nil {
+			fmt.Printf("Warning: failed to invalidate movement history for product %s: %v\n", stock.ProductID, err)
+		}
+	}
+
+	if err := r.cache.Delete(ctx, keysToInvalidate...); err != nil {
+		fmt.Printf("Warning: failed to invalidate caches after order sale: %v\n", err)
+	}
+
+	return stocks, applied, nil
+}
+
+// RestockReturnedItems applies a refund and invalidates caches of affected products
+func (r *CachedInventoryRepository) RestockReturnedItems(ctx context.Context, orderID, eventType string, items []models.ReturnItem) ([]*models.Stock, bool, error) {
+	stocks, applied, err := r.repo.RestockReturnedItems(ctx, orderID, eventType, items)
+	if err != nil || !applied {
+		return stocks, applied, err
+	}
+
+	keysToInvalidate := make([]string, 0, len(stocks))
+	for _, stock := range stocks {
+		keysToInvalidate = append(keysToInvalidate, fmt.Sprintf("stock:product:%s", stock.ProductID))
+
+		if err := r.cache.DeletePattern(ctx, fmt.Sprintf("availability:product:%s:*", stock.ProductID)); err != nil {
+			fmt.Printf("Warning: failed to invalidate availability for product %s: %v\n", stock.ProductID, err)
+		}
+		if err := r.cache.DeletePattern(ctx, fmt.Sprintf("movements:product:%s:*", stock.ProductID)); err != nil {
+			fmt.Printf("Warning: failed to invalidate movement history for product %s: %v\n", stock.ProductID, err)
+		}
+	}
+
+	if len(keysToInvalidate) > 0 {
+		if err := r.cache.Delete(ctx, keysToInvalidate...); err != nil {
+			fmt.Printf("Warning: failed to invalidate caches after refund restock: %v\n", err)
+		}
+	}
+
+	return stocks, true, nil
+}
+
 // ReleaseReservation releases a reservation and invalidates caches
 func (r *CachedInventoryRepository) ReleaseReservation(ctx context.Context, orderID string, reason string) error {
 	// Get reservations first to know which products to invalidate
@@ -237,6 +406,9 @@ func (r *CachedInventoryRepository) ReleaseReservation(ctx context.Context, orde
 		if err := r.cache.DeletePattern(ctx, fmt.Sprintf("availability:product:%s:*", res.ProductID)); err != nil {
 			fmt.Printf("Warning: failed to invalidate availability for product %s: %v\n", res.ProductID, err)
 		}
+		if err := r.cache.DeletePattern(ctx, fmt.Sprintf("movements:product:%s:*", res.ProductID)); err != nil {
+			fmt.Printf("Warning: failed to invalidate movement history for product %s: %v\n", res.ProductID, err)
+		}
 	}
 
 	if err := r.cache.Delete(ctx, keysToInvalidate...); err != nil {
@@ -262,8 +434,9 @@ func (r *CachedInventoryRepository) CreateMovement(ctx context.Context, movement
 }
 
 // GetMovementHistory retrieves stock movement history with caching
-func (r *CachedInventoryRepository) GetMovementHistory(ctx context.Context, productID string, limit, offset int) ([]*models.StockMovement, int, error) {
-	cacheKey := fmt.Sprintf("movements:product:%s:limit:%d:offset:%d", productID, limit, offset)
+func (r *CachedInventoryRepository) GetMovementHistory(ctx context.Context, productID string, filter models.MovementFilter, limit, offset int) ([]*models.StockMovement, int, error) {
+	cacheKey := fmt.Sprintf("movements:product:%s:type:%s:from:%s:to:%s:limit:%d:offset:%d",
+		productID, filter.MovementType, formatFilterTime(filter.From), formatFilterTime(filter.To), limit, offset)
 
 	var cachedResult struct {
 		Movements []*models.StockMovement
@@ -281,7 +454,7 @@ func (r *CachedInventoryRepository) GetMovementHistory(ctx context.Context, prod
 	}
 
 	// Fetch from DB
-	movements, total, err := r.repo.GetMovementHistory(ctx, productID, limit, offset)
+	movements, total, err := r.repo.GetMovementHistory(ctx, productID, filter, limit, offset)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -302,6 +475,14 @@ func (r *CachedInventoryRepository) GetMovementHistory(ctx context.Context, prod
 	return movements, total, nil
 }
 
+// formatFilterTime renders an optional filter bound for use in cache keys
+func formatFilterTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return strconv.FormatInt(t.UnixNano(), 10)
+}
+
 // InvalidateProductCache manually invalidates all caches for a product
 func (r *CachedInventoryRepository) InvalidateProductCache(ctx context.Context, productID string) error {
 	patterns := []string{
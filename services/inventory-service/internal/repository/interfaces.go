@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/datngth03/ecommerce-go-app/services/inventory-service/internal/models"
 )
@@ -10,16 +11,23 @@ import (
 type InventoryRepository interface {
 	// Stock operations
 	GetStock(ctx context.Context, productID string) (*models.Stock, error)
+	GetStocks(ctx context.Context, productIDs []string) ([]*models.Stock, error)
 	UpdateStock(ctx context.Context, productID string, quantity int32, reason string) (*models.Stock, error)
 	CheckAvailability(ctx context.Context, productID string, quantity int32) (bool, error)
 
 	// Reservation operations
-	CreateReservation(ctx context.Context, orderID, productID string, quantity int32) (*models.Reservation, error)
+	CreateReservation(ctx context.Context, reservationID, orderID, productID string, quantity int32, expiresAt time.Time) (*models.Reservation, error)
 	GetReservation(ctx context.Context, orderID string) ([]*models.Reservation, error)
+	GetReservationsByID(ctx context.Context, reservationID string) ([]*models.Reservation, error)
 	CommitReservation(ctx context.Context, orderID string) error
+	CommitReservationByID(ctx context.Context, reservationID string) ([]*models.Reservation, error)
 	ReleaseReservation(ctx context.Context, orderID string, reason string) error
+	ReleaseReservationByID(ctx context.Context, reservationID, reason string) ([]*models.Reservation, error)
+	ReleaseExpiredReservations(ctx context.Context, now time.Time, limit int) ([]*models.Reservation, error)
+	CommitOrderSale(ctx context.Context, orderID, eventType string, items []models.SaleItem) ([]*models.Stock, bool, error)
+	RestockReturnedItems(ctx context.Context, orderID, eventType string, items []models.ReturnItem) ([]*models.Stock, bool, error)
 
 	// Stock movement operations
 	CreateMovement(ctx context.Context, movement *models.StockMovement) error
-	GetMovementHistory(ctx context.Context, productID string, limit, offset int) ([]*models.StockMovement, int, error)
+	GetMovementHistory(ctx context.Context, productID string, filter models.MovementFilter, limit, offset int) ([]*models.StockMovement, int, error)
 }
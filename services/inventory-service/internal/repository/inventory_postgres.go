@@ -8,6 +8,7 @@ import (
 
 	"github.com/datngth03/ecommerce-go-app/services/inventory-service/internal/middleware"
 	"github.com/datngth03/ecommerce-go-app/services/inventory-service/internal/models"
+	"github.com/datngth03/ecommerce-go-app/shared/pkg/domainerr"
 	"github.com/go-redis/redis/v8"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
@@ -74,6 +75,27 @@ func (r *inventoryRepository) GetStock(ctx context.Context, productID string) (*
 	return &stock, nil
 }
 
+// GetStocks retrieves stock for multiple products in a single query.
+// Unlike GetStock it does not initialize missing records; products without
+// stock are simply absent from the result.
+func (r *inventoryRepository) GetStocks(ctx context.Context, productIDs []string) ([]*models.Stock, error) {
+	start := time.Now()
+	defer func() {
+		middleware.RecordDatabaseQuery("SELECT", "stocks", time.Since(start))
+	}()
+
+	var stocks []*models.Stock
+	if len(productIDs) == 0 {
+		return stocks, nil
+	}
+
+	if err := r.db.WithContext(ctx).Where("product_id IN ?", productIDs).Find(&stocks).Error; err != nil {
+		return nil, fmt.Errorf("failed to get stocks: %w", err)
+	}
+
+	return stocks, nil
+}
+
 // UpdateStock updates stock quantity (with transaction)
 func (r *inventoryRepository) UpdateStock(ctx context.Context, productID string, quantity int32, reason string) (*models.Stock, error) {
 	start := time.Now()
@@ -190,8 +212,8 @@ func (r *inventoryRepository) CheckAvailability(ctx context.Context, productID s
 	return stock.Available >= quantity, nil
 }
 
-// CreateReservation reserves stock for an order
-func (r *inventoryRepository) CreateReservation(ctx context.Context, orderID, productID string, quantity int32) (*models.Reservation, error) {
+// CreateReservation reserves stock for one line of an order under reservationID
+func (r *inventoryRepository) CreateReservation(ctx context.Context, reservationID, orderID, productID string, quantity int32, expiresAt time.Time) (*models.Reservation, error) {
 	start := time.Now()
 	defer func() {
 		middleware.RecordDatabaseQuery("INSERT", "reservations", time.Since(start))
@@ -231,12 +253,13 @@ func (r *inventoryRepository) CreateReservation(ctx context.Context, orderID, pr
 
 	// Create reservation
 	reservation := &models.Reservation{
-		OrderID:     orderID,
-		ProductID:   productID,
-		Quantity:    quantity,
-		Status:      models.ReservationStatusPending,
-		WarehouseID: stock.WarehouseID,
-		ExpiresAt:   time.Now().Add(30 * time.Minute), // 30 min to complete payment
+		ReservationID: reservationID,
+		OrderID:       orderID,
+		ProductID:     productID,
+		Quantity:      quantity,
+		Status:        models.ReservationStatusPending,
+		WarehouseID:   stock.WarehouseID,
+		ExpiresAt:     expiresAt,
 	}
 
 	if err := tx.Create(reservation).Error; err != nil {
@@ -291,8 +314,47 @@ func (r *inventoryRepository) GetReservation(ctx context.Context, orderID string
 	return reservations, nil
 }
 
+// GetReservationsByID retrieves the lines of a reservation
+func (r *inventoryRepository) GetReservationsByID(ctx context.Context, reservationID string) ([]*models.Reservation, error) {
+	start := time.Now()
+	defer func() {
+		middleware.RecordDatabaseQuery("SELECT", "reservations", time.Since(start))
+	}()
+
+	var reservations []*models.Reservation
+	if err := r.db.WithContext(ctx).Where("reservation_id = ?", reservationID).Find(&reservations).Error; err != nil {
+		return nil, fmt.Errorf("failed to get reservations: %w", err)
+	}
+	return reservations, nil
+}
+
 // CommitReservation commits reserved stock (payment completed)
 func (r *inventoryRepository) CommitReservation(ctx context.Context, orderID string) error {
+	committed, err := r.commitReservations(ctx, "order_id", orderID)
+	if err != nil {
+		return err
+	}
+	if len(committed) == 0 {
+		return domainerr.NotFound("no pending reservations found for order %s", orderID)
+	}
+	return nil
+}
+
+// CommitReservationByID commits the pending lines of a reservation (payment completed)
+func (r *inventoryRepository) CommitReservationByID(ctx context.Context, reservationID string) ([]*models.Reservation, error) {
+	committed, err := r.commitReservations(ctx, "reservation_id", reservationID)
+	if err != nil {
+		return nil, err
+	}
+	if len(committed) == 0 {
+		return nil, r.notPendingError(ctx, reservationID)
+	}
+	return committed, nil
+}
+
+// commitReservations commits all pending reservations matching column = key.
+// Reservation rows are locked, so a reservation cannot be committed and released concurrently.
+func (r *inventoryRepository) commitReservations(ctx context.Context, column, key string) ([]*models.Reservation, error) {
 	start := time.Now()
 	defer func() {
 		middleware.RecordDatabaseQuery("UPDATE", "reservations", time.Since(start))
@@ -307,18 +369,21 @@ func (r *inventoryRepository) CommitReservation(ctx context.Context, orderID str
 
 	// Get reservations
 	var reservations []*models.Reservation
-	if err := tx.Where("order_id = ? AND status = ?", orderID, models.ReservationStatusPending).
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where(column+" = ? AND status = ?", key, models.ReservationStatusPending).
+		Order("product_id").
 		Find(&reservations).Error; err != nil {
 		tx.Rollback()
-		return fmt.Errorf("failed to get reservations: %w", err)
+		return nil, fmt.Errorf("failed to get reservations: %w", err)
 	}
 
 	if len(reservations) == 0 {
 		tx.Rollback()
-		return fmt.Errorf("no pending reservations found for order %s", orderID)
+		return nil, nil
 	}
 
 	// Process each reservation
+	stocks := make([]models.Stock, 0, len(reservations))
 	for _, res := range reservations {
 		// Lock stock
 		var stock models.Stock
@@ -326,7 +391,7 @@ func (r *inventoryRepository) CommitReservation(ctx context.Context, orderID str
 			Where("product_id = ?", res.ProductID).
 			First(&stock).Error; err != nil {
 			tx.Rollback()
-			return fmt.Errorf("failed to lock stock: %w", err)
+			return nil, fmt.Errorf("failed to lock stock: %w", err)
 		}
 
 		// Update stock (reduce reserved and total)
@@ -336,14 +401,14 @@ func (r *inventoryRepository) CommitReservation(ctx context.Context, orderID str
 
 		if err := tx.Save(&stock).Error; err != nil {
 			tx.Rollback()
-			return fmt.Errorf("failed to update stock: %w", err)
+			return nil, fmt.Errorf("failed to update stock: %w", err)
 		}
 
 		// Update reservation status
 		res.Status = models.ReservationStatusCommitted
 		if err := tx.Save(res).Error; err != nil {
 			tx.Rollback()
-			return fmt.Errorf("failed to update reservation: %w", err)
+			return nil, fmt.Errorf("failed to update reservation: %w", err)
 		}
 
 		// Create movement record
@@ -354,34 +419,329 @@ func (r *inventoryRepository) CommitReservation(ctx context.Context, orderID str
 			BeforeQuantity: beforeTotal,
 			AfterQuantity:  stock.Total,
 			ReferenceType:  models.ReferenceTypeOrder,
-			ReferenceID:    orderID,
+			ReferenceID:    res.OrderID,
 			Reason:         "Stock committed (order completed)",
 		}
 
 		if err := tx.Create(movement).Error; err != nil {
 			tx.Rollback()
-			return fmt.Errorf("failed to create movement: %w", err)
+			return nil, fmt.Errorf("failed to create movement: %w", err)
 		}
 
+		stocks = append(stocks, stock)
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	for i, res := range reservations {
 		// Invalidate cache
-		cacheKey := fmt.Sprintf("stock:%s", res.ProductID)
-		r.redisClient.Del(ctx, cacheKey)
+		r.redisClient.Del(ctx, fmt.Sprintf("stock:%s", res.ProductID))
 
 		// Record metrics
 		middleware.RecordStockMovement("committed", res.ProductID)
 		middleware.ReservationsActive.Dec()
+		middleware.RecordStockLevel(stocks[i].ProductID, stocks[i].WarehouseID, stocks[i].Available)
+	}
+
+	return reservations, nil
+}
+
+// CommitOrderSale converts the line items of a paid order into sales.
+// Pending reservations are committed (reserved -> out); quantities without a
+// reservation are taken straight from available stock. The whole order is
+// applied in one transaction and keyed by (order_id, eventType), so a
+// redelivered event returns applied=false without touching stock again.
+func (r *inventoryRepository) CommitOrderSale(ctx context.Context, orderID, eventType string, items []models.SaleItem) ([]*models.Stock, bool, error) {
+	start := time.Now()
+	defer func() {
+		middleware.RecordDatabaseQuery("UPDATE", "stocks", time.Since(start))
+	}()
+
+	tx := r.db.WithContext(ctx).Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	// Claim the idempotency key first; a conflict means the order was already applied
+	result := tx.Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&models.ProcessedOrderEvent{OrderID: orderID, EventType: eventType})
+	if result.Error != nil {
+		tx.Rollback()
+		return nil, false, fmt.Errorf("failed to record processed event: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		tx.Rollback()
+		return nil, false, nil
+	}
+
+	// Lock pending reservations in the same order as commitReservations and
+	// releaseReservations, so a concurrent expiry or release either finishes first
+	// (and its lines are no longer pending) or waits for this sale
+	var reservations []*models.Reservation
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("order_id = ? AND status = ?", orderID, models.ReservationStatusPending).
+		Order("product_id").
+		Find(&reservations).Error; err != nil {
+		tx.Rollback()
+		return nil, false, fmt.Errorf("failed to get reservations: %w", err)
+	}
+	reservationsByProduct := make(map[string][]*models.Reservation)
+	for _, res := range reservations {
+		reservationsByProduct[res.ProductID] = append(reservationsByProduct[res.ProductID], res)
+	}
+
+	var stocks []*models.Stock
+	var committedReservations int
+	for _, item := range items {
+		// Lock stock
+		var stock models.Stock
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("product_id = ?", item.ProductID).
+			First(&stock).Error; err != nil {
+			tx.Rollback()
+			if err == gorm.ErrRecordNotFound {
+				return nil, false, domainerr.Conflict("insufficient stock for product %s: no stock record", item.ProductID)
+			}
+			return nil, false, fmt.Errorf("failed to lock stock: %w", err)
+		}
+
+		remaining := item.Quantity
+
+		// Commit reservations; any reserved quantity beyond what was sold goes back to available
+		for _, res := range reservationsByProduct[item.ProductID] {
+			covered := res.Quantity
+			if covered > remaining {
+				covered = remaining
+			}
+
+			beforeTotal := stock.Total
+			stock.Reserved -= res.Quantity
+			stock.Available += res.Quantity - covered
+			stock.Total -= covered
+			remaining -= covered
+
+			res.Status = models.ReservationStatusCommitted
+			if err := tx.Save(res).Error; err != nil {
+				tx.Rollback()
+				return nil, false, fmt.Errorf("failed to update reservation: %w", err)
+			}
+			committedReservations++
+
+			movement := &models.StockMovement{
+				ProductID:      item.ProductID,
+				MovementType:   models.MovementTypeCommitted,
+				Quantity:       -covered,
+				BeforeQuantity: beforeTotal,
+				AfterQuantity:  stock.Total,
+				ReferenceType:  models.ReferenceTypeOrder,
+				ReferenceID:    orderID,
+				Reason:         "Reserved stock sold (order paid)",
+			}
+			if err := tx.Create(movement).Error; err != nil {
+				tx.Rollback()
+				return nil, false, fmt.Errorf("failed to create movement: %w", err)
+			}
+		}
+		delete(reservationsByProduct, item.ProductID)
+
+		// No (or not enough) reservation: sell directly from available stock
+		if remaining > 0 {
+			if stock.Available < remaining {
+				tx.Rollback()
+				return nil, false, domainerr.Conflict("insufficient stock for product %s: need %d, have %d",
+					item.ProductID, remaining, stock.Available)
+			}
+
+			beforeTotal := stock.Total
+			stock.Available -= remaining
+			stock.Total -= remaining
+
+			movement := &models.StockMovement{
+				ProductID:      item.ProductID,
+				MovementType:   models.MovementTypeOutbound,
+				Quantity:       -remaining,
+				BeforeQuantity: beforeTotal,
+				AfterQuantity:  stock.Total,
+				ReferenceType:  models.ReferenceTypeOrder,
+				ReferenceID:    orderID,
+				Reason:         "Stock sold without reservation (order paid)",
+			}
+			if err := tx.Create(movement).Error; err != nil {
+				tx.Rollback()
+				return nil, false, fmt.Errorf("failed to create movement: %w", err)
+			}
+		}
+
+		if err := tx.Save(&stock).Error; err != nil {
+			tx.Rollback()
+			return nil, false, fmt.Errorf("failed to update stock: %w", err)
+		}
+
+		updated := stock
+		stocks = append(stocks, &updated)
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, false, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	for _, stock := range stocks {
+		// Invalidate cache
+		r.redisClient.Del(ctx, fmt.Sprintf("stock:%s", stock.ProductID))
+
+		middleware.RecordStockMovement("committed", stock.ProductID)
 		middleware.RecordStockLevel(stock.ProductID, stock.WarehouseID, stock.Available)
 	}
+	for i := 0; i < committedReservations; i++ {
+		middleware.ReservationsActive.Dec()
+	}
+
+	return stocks, true, nil
+}
+
+// RestockReturnedItems puts refunded items of an order back into available stock.
+// Like CommitOrderSale it runs in one transaction keyed by (order_id, eventType),
+// so a redelivered event returns applied=false without touching stock again.
+func (r *inventoryRepository) RestockReturnedItems(ctx context.Context, orderID, eventType string, items []models.ReturnItem) ([]*models.Stock, bool, error) {
+	start := time.Now()
+	defer func() {
+		middleware.RecordDatabaseQuery("UPDATE", "stocks", time.Since(start))
+	}()
+
+	tx := r.db.WithContext(ctx).Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	result := tx.Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&models.ProcessedOrderEvent{OrderID: orderID, EventType: eventType})
+	if result.Error != nil {
+		tx.Rollback()
+		return nil, false, fmt.Errorf("failed to record processed event: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		tx.Rollback()
+		return nil, false, nil
+	}
+
+	var stocks []*models.Stock
+	for _, item := range items {
+		var stock models.Stock
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("product_id = ?", item.ProductID).
+			First(&stock).Error; err != nil {
+			tx.Rollback()
+			if err == gorm.ErrRecordNotFound {
+				return nil, false, domainerr.NotFound("stock not found for product %s", item.ProductID)
+			}
+			return nil, false, fmt.Errorf("failed to lock stock: %w", err)
+		}
+
+		beforeTotal := stock.Total
+		stock.Available += item.Quantity
+		stock.Total += item.Quantity
+
+		if err := tx.Save(&stock).Error; err != nil {
+			tx.Rollback()
+			return nil, false, fmt.Errorf("failed to update stock: %w", err)
+		}
+
+		movement := &models.StockMovement{
+			ProductID:      item.ProductID,
+			MovementType:   models.MovementTypeInbound,
+			Quantity:       item.Quantity,
+			BeforeQuantity: beforeTotal,
+			AfterQuantity:  stock.Total,
+			ReferenceType:  models.ReferenceTypeReturn,
+			ReferenceID:    orderID,
+			Reason:         "Refunded items returned to stock",
+		}
+		if err := tx.Create(movement).Error; err != nil {
+			tx.Rollback()
+			return nil, false, fmt.Errorf("failed to create movement: %w", err)
+		}
+
+		updated := stock
+		stocks = append(stocks, &updated)
+	}
 
 	if err := tx.Commit().Error; err != nil {
-		return fmt.Errorf("failed to commit transaction: %w", err)
+		return nil, false, fmt.Errorf("failed to commit transaction: %w", err)
 	}
 
-	return nil
+	for _, stock := range stocks {
+		// Invalidate cache
+		r.redisClient.Del(ctx, fmt.Sprintf("stock:%s", stock.ProductID))
+
+		middleware.RecordStockMovement("inbound", stock.ProductID)
+		middleware.RecordStockLevel(stock.ProductID, stock.WarehouseID, stock.Available)
+	}
+
+	return stocks, true, nil
 }
 
 // ReleaseReservation releases reserved stock (order cancelled)
 func (r *inventoryRepository) ReleaseReservation(ctx context.Context, orderID string, reason string) error {
+	released, err := r.releaseReservations(ctx, "order_id", orderID, reason, models.ReservationStatusReleased)
+	if err != nil {
+		return err
+	}
+	if len(released) == 0 {
+		return domainerr.NotFound("no pending reservations found for order %s", orderID)
+	}
+	return nil
+}
+
+// ReleaseReservationByID returns the pending lines of a reservation to available stock
+func (r *inventoryRepository) ReleaseReservationByID(ctx context.Context, reservationID, reason string) ([]*models.Reservation, error) {
+	released, err := r.releaseReservations(ctx, "reservation_id", reservationID, reason, models.ReservationStatusReleased)
+	if err != nil {
+		return nil, err
+	}
+	if len(released) == 0 {
+		return nil, r.notPendingError(ctx, reservationID)
+	}
+	return released, nil
+}
+
+// ReleaseExpiredReservations releases up to limit reservations whose expiry has passed
+// and returns the expired lines. Each reservation is released in its own transaction.
+func (r *inventoryRepository) ReleaseExpiredReservations(ctx context.Context, now time.Time, limit int) ([]*models.Reservation, error) {
+	start := time.Now()
+	var reservationIDs []string
+	err := r.db.WithContext(ctx).Model(&models.Reservation{}).
+		Where("status = ? AND expires_at <= ?", models.ReservationStatusPending, now).
+		Distinct("reservation_id").
+		Limit(limit).
+		Pluck("reservation_id", &reservationIDs).Error
+	middleware.RecordDatabaseQuery("SELECT", "reservations", time.Since(start))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get expired reservations: %w", err)
+	}
+
+	var expired []*models.Reservation
+	for _, reservationID := range reservationIDs {
+		// Lines committed or released since the lookup are no longer pending and are skipped
+		released, err := r.releaseReservations(ctx, "reservation_id", reservationID, "Reservation expired", models.ReservationStatusExpired)
+		if err != nil {
+			return expired, fmt.Errorf("failed to release expired reservation %s: %w", reservationID, err)
+		}
+		middleware.ReservationExpiredTotal.Add(float64(len(released)))
+		expired = append(expired, released...)
+	}
+
+	return expired, nil
+}
+
+// releaseReservations returns all pending reservations matching column = key to
+// available stock and moves them to status (RELEASED or EXPIRED).
+func (r *inventoryRepository) releaseReservations(ctx context.Context, column, key, reason, status string) ([]*models.Reservation, error) {
 	start := time.Now()
 	defer func() {
 		middleware.RecordDatabaseQuery("UPDATE", "reservations", time.Since(start))
@@ -396,18 +756,21 @@ func (r *inventoryRepository) ReleaseReservation(ctx context.Context, orderID st
 
 	// Get reservations
 	var reservations []*models.Reservation
-	if err := tx.Where("order_id = ? AND status = ?", orderID, models.ReservationStatusPending).
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where(column+" = ? AND status = ?", key, models.ReservationStatusPending).
+		Order("product_id").
 		Find(&reservations).Error; err != nil {
 		tx.Rollback()
-		return fmt.Errorf("failed to get reservations: %w", err)
+		return nil, fmt.Errorf("failed to get reservations: %w", err)
 	}
 
 	if len(reservations) == 0 {
 		tx.Rollback()
-		return fmt.Errorf("no pending reservations found for order %s", orderID)
+		return nil, nil
 	}
 
 	// Process each reservation
+	stocks := make([]models.Stock, 0, len(reservations))
 	for _, res := range reservations {
 		// Lock stock
 		var stock models.Stock
@@ -415,7 +778,7 @@ func (r *inventoryRepository) ReleaseReservation(ctx context.Context, orderID st
 			Where("product_id = ?", res.ProductID).
 			First(&stock).Error; err != nil {
 			tx.Rollback()
-			return fmt.Errorf("failed to lock stock: %w", err)
+			return nil, fmt.Errorf("failed to lock stock: %w", err)
 		}
 
 		// Update stock (reduce reserved, increase available)
@@ -425,14 +788,14 @@ func (r *inventoryRepository) ReleaseReservation(ctx context.Context, orderID st
 
 		if err := tx.Save(&stock).Error; err != nil {
 			tx.Rollback()
-			return fmt.Errorf("failed to update stock: %w", err)
+			return nil, fmt.Errorf("failed to update stock: %w", err)
 		}
 
 		// Update reservation status
-		res.Status = models.ReservationStatusReleased
+		res.Status = status
 		if err := tx.Save(res).Error; err != nil {
 			tx.Rollback()
-			return fmt.Errorf("failed to update reservation: %w", err)
+			return nil, fmt.Errorf("failed to update reservation: %w", err)
 		}
 
 		// Create movement record
@@ -443,30 +806,53 @@ func (r *inventoryRepository) ReleaseReservation(ctx context.Context, orderID st
 			BeforeQuantity: beforeAvailable,
 			AfterQuantity:  stock.Available,
 			ReferenceType:  models.ReferenceTypeOrder,
-			ReferenceID:    orderID,
+			ReferenceID:    res.OrderID,
 			Reason:         reason,
 		}
 
 		if err := tx.Create(movement).Error; err != nil {
 			tx.Rollback()
-			return fmt.Errorf("failed to create movement: %w", err)
+			return nil, fmt.Errorf("failed to create movement: %w", err)
 		}
 
+		stocks = append(stocks, stock)
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	for i, res := range reservations {
 		// Invalidate cache
-		cacheKey := fmt.Sprintf("stock:%s", res.ProductID)
-		r.redisClient.Del(ctx, cacheKey)
+		r.redisClient.Del(ctx, fmt.Sprintf("stock:%s", res.ProductID))
 
 		// Record metrics
 		middleware.RecordStockMovement("released", res.ProductID)
 		middleware.ReservationsActive.Dec()
-		middleware.RecordStockLevel(stock.ProductID, stock.WarehouseID, stock.Available)
+		middleware.RecordStockLevel(stocks[i].ProductID, stocks[i].WarehouseID, stocks[i].Available)
 	}
 
-	if err := tx.Commit().Error; err != nil {
-		return fmt.Errorf("failed to commit transaction: %w", err)
+	return reservations, nil
+}
+
+// notPendingError explains why a reservation has no pending lines left
+func (r *inventoryRepository) notPendingError(ctx context.Context, reservationID string) error {
+	reservations, err := r.GetReservationsByID(ctx, reservationID)
+	if err != nil {
+		return err
+	}
+	if len(reservations) == 0 {
+		return fmt.Errorf("reservation %s not found", reservationID)
 	}
 
-	return nil
+	switch reservations[0].Status {
+	case models.ReservationStatusExpired:
+		return fmt.Errorf("reservation %s has expired", reservationID)
+	case models.ReservationStatusCommitted:
+		return fmt.Errorf("reservation %s is already confirmed", reservationID)
+	default:
+		return fmt.Errorf("reservation %s is already released", reservationID)
+	}
 }
 
 // CreateMovement creates a stock movement record
@@ -478,11 +864,20 @@ func (r *inventoryRepository) CreateMovement(ctx context.Context, movement *mode
 }
 
 // GetMovementHistory retrieves stock movement history
-func (r *inventoryRepository) GetMovementHistory(ctx context.Context, productID string, limit, offset int) ([]*models.StockMovement, int, error) {
+func (r *inventoryRepository) GetMovementHistory(ctx context.Context, productID string, filter models.MovementFilter, limit, offset int) ([]*models.StockMovement, int, error) {
 	var movements []*models.StockMovement
 	var total int64
 
 	query := r.db.WithContext(ctx).Where("product_id = ?", productID)
+	if filter.MovementType != "" {
+		query = query.Where("movement_type = ?", filter.MovementType)
+	}
+	if filter.From != nil {
+		query = query.Where("created_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("created_at <= ?", *filter.To)
+	}
 
 	// Get total count
 	if err := query.Model(&models.StockMovement{}).Count(&total).Error; err != nil {
@@ -2,25 +2,36 @@ package rpc
 
 import (
 	"context"
+	"fmt"
+	"strings"
 	"time"
 
 	pb "github.com/datngth03/ecommerce-go-app/proto/inventory_service"
 	"github.com/datngth03/ecommerce-go-app/services/inventory-service/internal/middleware"
+	"github.com/datngth03/ecommerce-go-app/services/inventory-service/internal/models"
 	"github.com/datngth03/ecommerce-go-app/services/inventory-service/internal/service"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
+// VariantChecker reports whether a variant belongs to a product
+type VariantChecker interface {
+	VariantBelongsTo(ctx context.Context, productID, variantID string) (bool, error)
+}
+
 // InventoryServer implements the gRPC inventory service
 type InventoryServer struct {
 	pb.UnimplementedInventoryServiceServer
-	service *service.InventoryService
+	service  *service.InventoryService
+	variants VariantChecker // nil when the product service is not configured
 }
 
-// NewInventoryServer creates a new gRPC inventory server
-func NewInventoryServer(svc *service.InventoryService) *InventoryServer {
+// NewInventoryServer creates a new gRPC inventory server. variants may be nil, in
+// which case requests for a variant's stock are rejected.
+func NewInventoryServer(svc *service.InventoryService, variants VariantChecker) *InventoryServer {
 	return &InventoryServer{
-		service: svc,
+		service:  svc,
+		variants: variants,
 	}
 }
 
@@ -32,7 +43,13 @@ func (s *InventoryServer) GetStock(ctx context.Context, req *pb.GetStockRequest)
 		middleware.RecordGRPCRequest("GetStock", statusCode, time.Since(start))
 	}()
 
-	stock, err := s.service.GetStock(ctx, req.ProductId)
+	key, err := s.stockKey(ctx, req.ProductId, req.VariantId)
+	if err != nil {
+		statusCode = "error"
+		return nil, err
+	}
+
+	stock, err := s.service.GetStock(ctx, key)
 	if err != nil {
 		statusCode = "error"
 		return nil, status.Error(codes.Internal, err.Error())
@@ -40,14 +57,40 @@ func (s *InventoryServer) GetStock(ctx context.Context, req *pb.GetStockRequest)
 
 	statusCode = "success"
 	return &pb.GetStockResponse{
-		Stock: &pb.Stock{
+		Stock: stockToProto(stock, req.ProductId, req.VariantId),
+	}, nil
+}
+
+// GetStocks retrieves stock information for multiple products
+func (s *InventoryServer) GetStocks(ctx context.Context, req *pb.GetStocksRequest) (*pb.GetStocksResponse, error) {
+	start := time.Now()
+	var statusCode string
+	defer func() {
+		middleware.RecordGRPCRequest("GetStocks", statusCode, time.Since(start))
+	}()
+
+	stocks, err := s.service.GetStocks(ctx, req.ProductIds)
+	if err != nil {
+		statusCode = "error"
+		if strings.Contains(err.Error(), "required") || strings.Contains(err.Error(), "too many") {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	statusCode = "success"
+	pbStocks := make([]*pb.Stock, len(stocks))
+	for i, stock := range stocks {
+		pbStocks[i] = &pb.Stock{
 			ProductId:   stock.ProductID,
 			Available:   stock.Available,
 			Reserved:    stock.Reserved,
 			Total:       stock.Total,
 			WarehouseId: stock.WarehouseID,
-		},
-	}, nil
+		}
+	}
+
+	return &pb.GetStocksResponse{Stocks: pbStocks}, nil
 }
 
 // UpdateStock updates stock quantity
@@ -58,7 +101,13 @@ func (s *InventoryServer) UpdateStock(ctx context.Context, req *pb.UpdateStockRe
 		middleware.RecordGRPCRequest("UpdateStock", statusCode, time.Since(start))
 	}()
 
-	stock, err := s.service.UpdateStock(ctx, req.ProductId, req.Quantity, req.Reason)
+	key, err := s.stockKey(ctx, req.ProductId, req.VariantId)
+	if err != nil {
+		statusCode = "error"
+		return nil, err
+	}
+
+	stock, err := s.service.UpdateStock(ctx, key, req.Quantity, req.Reason)
 	if err != nil {
 		statusCode = "error"
 		return nil, status.Error(codes.Internal, err.Error())
@@ -66,13 +115,7 @@ func (s *InventoryServer) UpdateStock(ctx context.Context, req *pb.UpdateStockRe
 
 	statusCode = "success"
 	return &pb.UpdateStockResponse{
-		Stock: &pb.Stock{
-			ProductId:   stock.ProductID,
-			Available:   stock.Available,
-			Reserved:    stock.Reserved,
-			Total:       stock.Total,
-			WarehouseId: stock.WarehouseID,
-		},
+		Stock: stockToProto(stock, req.ProductId, req.VariantId),
 	}, nil
 }
 
@@ -91,16 +134,21 @@ func (s *InventoryServer) ReserveStock(ctx context.Context, req *pb.ReserveStock
 	}, len(req.Items))
 
 	for i, item := range req.Items {
+		key, err := s.stockKey(ctx, item.ProductId, item.VariantId)
+		if err != nil {
+			statusCode = "error"
+			return nil, err
+		}
 		items[i] = struct {
 			ProductID string
 			Quantity  int32
 		}{
-			ProductID: item.ProductId,
+			ProductID: key,
 			Quantity:  item.Quantity,
 		}
 	}
 
-	orderID, err := s.service.ReserveStock(ctx, req.OrderId, items)
+	reservations, err := s.service.ReserveStock(ctx, req.OrderId, items)
 	if err != nil {
 		statusCode = "error"
 		return nil, status.Error(codes.Internal, err.Error())
@@ -108,9 +156,11 @@ func (s *InventoryServer) ReserveStock(ctx context.Context, req *pb.ReserveStock
 
 	statusCode = "success"
 	return &pb.ReserveStockResponse{
-		ReservationId: orderID,
+		ReservationId: reservations[0].ReservationID,
 		Success:       true,
 		Message:       "Stock reserved successfully",
+		ExpiresAt:     reservations[0].ExpiresAt.Format(time.RFC3339),
+		Reservations:  reservationsToProto(reservations),
 	}, nil
 }
 
@@ -122,10 +172,15 @@ func (s *InventoryServer) ReleaseStock(ctx context.Context, req *pb.ReleaseStock
 		middleware.RecordGRPCRequest("ReleaseStock", statusCode, time.Since(start))
 	}()
 
-	err := s.service.ReleaseStock(ctx, req.OrderId, req.Reason)
+	var err error
+	if req.OrderId == "" && req.ReservationId != "" {
+		_, err = s.service.ReleaseReservation(ctx, req.ReservationId, req.Reason)
+	} else {
+		err = s.service.ReleaseStock(ctx, req.OrderId, req.Reason)
+	}
 	if err != nil {
 		statusCode = "error"
-		return nil, status.Error(codes.Internal, err.Error())
+		return nil, reservationError(err)
 	}
 
 	statusCode = "success"
@@ -143,10 +198,15 @@ func (s *InventoryServer) CommitStock(ctx context.Context, req *pb.CommitStockRe
 		middleware.RecordGRPCRequest("CommitStock", statusCode, time.Since(start))
 	}()
 
-	err := s.service.CommitStock(ctx, req.OrderId)
+	var err error
+	if req.OrderId == "" && req.ReservationId != "" {
+		_, err = s.service.ConfirmReservation(ctx, req.ReservationId)
+	} else {
+		err = s.service.CommitStock(ctx, req.OrderId)
+	}
 	if err != nil {
 		statusCode = "error"
-		return nil, status.Error(codes.Internal, err.Error())
+		return nil, reservationError(err)
 	}
 
 	statusCode = "success"
@@ -156,6 +216,50 @@ func (s *InventoryServer) CommitStock(ctx context.Context, req *pb.CommitStockRe
 	}, nil
 }
 
+// ConfirmReservation commits a reservation after payment
+func (s *InventoryServer) ConfirmReservation(ctx context.Context, req *pb.ConfirmReservationRequest) (*pb.ConfirmReservationResponse, error) {
+	start := time.Now()
+	var statusCode string
+	defer func() {
+		middleware.RecordGRPCRequest("ConfirmReservation", statusCode, time.Since(start))
+	}()
+
+	reservations, err := s.service.ConfirmReservation(ctx, req.ReservationId)
+	if err != nil {
+		statusCode = "error"
+		return nil, reservationError(err)
+	}
+
+	statusCode = "success"
+	return &pb.ConfirmReservationResponse{
+		Success:      true,
+		Message:      "Reservation confirmed successfully",
+		Reservations: reservationsToProto(reservations),
+	}, nil
+}
+
+// ReleaseReservation returns a reservation to available stock
+func (s *InventoryServer) ReleaseReservation(ctx context.Context, req *pb.ReleaseReservationRequest) (*pb.ReleaseReservationResponse, error) {
+	start := time.Now()
+	var statusCode string
+	defer func() {
+		middleware.RecordGRPCRequest("ReleaseReservation", statusCode, time.Since(start))
+	}()
+
+	reservations, err := s.service.ReleaseReservation(ctx, req.ReservationId, req.Reason)
+	if err != nil {
+		statusCode = "error"
+		return nil, reservationError(err)
+	}
+
+	statusCode = "success"
+	return &pb.ReleaseReservationResponse{
+		Success:      true,
+		Message:      "Reservation released successfully",
+		Reservations: reservationsToProto(reservations),
+	}, nil
+}
+
 // CheckAvailability checks if products are available
 func (s *InventoryServer) CheckAvailability(ctx context.Context, req *pb.CheckAvailabilityRequest) (*pb.CheckAvailabilityResponse, error) {
 	start := time.Now()
@@ -171,11 +275,16 @@ func (s *InventoryServer) CheckAvailability(ctx context.Context, req *pb.CheckAv
 	}, len(req.Items))
 
 	for i, item := range req.Items {
+		key, err := s.stockKey(ctx, item.ProductId, item.VariantId)
+		if err != nil {
+			statusCode = "error"
+			return nil, err
+		}
 		items[i] = struct {
 			ProductID string
 			Quantity  int32
 		}{
-			ProductID: item.ProductId,
+			ProductID: key,
 			Quantity:  item.Quantity,
 		}
 	}
@@ -187,14 +296,25 @@ func (s *InventoryServer) CheckAvailability(ctx context.Context, req *pb.CheckAv
 	}
 
 	statusCode = "success"
-	// Convert unavailable items to proto format
+	// Convert unavailable items to proto format, reporting variants under their product
+	products := make(map[string]string, len(req.Items))
+	for _, item := range req.Items {
+		if item.VariantId != "" {
+			products[item.VariantId] = item.ProductId
+		}
+	}
 	unavailableItems := make([]*pb.UnavailableItem, len(unavailable))
 	for i, item := range unavailable {
+		key := item["product_id"].(string)
 		unavailableItems[i] = &pb.UnavailableItem{
-			ProductId: item["product_id"].(string),
+			ProductId: key,
 			Requested: item["requested"].(int32),
 			Available: item["available"].(int32),
 		}
+		if productID, ok := products[key]; ok {
+			unavailableItems[i].ProductId = productID
+			unavailableItems[i].VariantId = key
+		}
 	}
 
 	return &pb.CheckAvailabilityResponse{
@@ -211,9 +331,29 @@ func (s *InventoryServer) GetStockHistory(ctx context.Context, req *pb.GetStockH
 		middleware.RecordGRPCRequest("GetStockHistory", statusCode, time.Since(start))
 	}()
 
-	movements, total, err := s.service.GetStockHistory(ctx, req.ProductId, int(req.Limit), int(req.Offset))
+	filter := models.MovementFilter{MovementType: req.MovementType}
+	var err error
+	if filter.From, err = parseHistoryDate("start_date", req.StartDate); err != nil {
+		statusCode = "error"
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if filter.To, err = parseHistoryDate("end_date", req.EndDate); err != nil {
+		statusCode = "error"
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	key, err := s.stockKey(ctx, req.ProductId, req.VariantId)
+	if err != nil {
+		statusCode = "error"
+		return nil, err
+	}
+
+	movements, total, err := s.service.GetStockHistory(ctx, key, filter, int(req.Limit), int(req.Offset))
 	if err != nil {
 		statusCode = "error"
+		if strings.Contains(err.Error(), "required") || strings.Contains(err.Error(), "invalid") {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
 		return nil, status.Error(codes.Internal, err.Error())
 	}
 
@@ -240,3 +380,84 @@ func (s *InventoryServer) GetStockHistory(ctx context.Context, req *pb.GetStockH
 		Total:     int32(total),
 	}, nil
 }
+
+// stockKey is the key stock is kept under: the variant ID for a variant of a
+// product, otherwise the product ID. The variant must belong to the product, so a
+// caller can't reserve or adjust the stock of another product's variant.
+func (s *InventoryServer) stockKey(ctx context.Context, productID, variantID string) (string, error) {
+	if variantID == "" {
+		return productID, nil
+	}
+	if s.variants == nil {
+		return "", status.Error(codes.FailedPrecondition, "variant stock is unavailable: product service is not configured")
+	}
+
+	owned, err := s.variants.VariantBelongsTo(ctx, productID, variantID)
+	if err != nil {
+		return "", status.Errorf(codes.Unavailable, "failed to check variant %s: %v", variantID, err)
+	}
+	if !owned {
+		return "", status.Errorf(codes.InvalidArgument, "variant %s does not belong to product %s", variantID, productID)
+	}
+	return variantID, nil
+}
+
+// stockToProto converts stock to proto format. Stock kept for a variant is
+// reported under its product, with the variant ID set.
+func stockToProto(stock *models.Stock, productID, variantID string) *pb.Stock {
+	pbStock := &pb.Stock{
+		ProductId:   stock.ProductID,
+		Available:   stock.Available,
+		Reserved:    stock.Reserved,
+		Total:       stock.Total,
+		WarehouseId: stock.WarehouseID,
+	}
+	if variantID != "" {
+		pbStock.ProductId = productID
+		pbStock.VariantId = variantID
+	}
+	return pbStock
+}
+
+// parseHistoryDate parses an optional RFC3339 date used to filter stock history
+func parseHistoryDate(field, value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: must be RFC3339 (e.g. 2024-01-02T15:04:05Z)", field)
+	}
+	return &t, nil
+}
+
+// reservationError maps reservation errors to gRPC status codes
+func reservationError(err error) error {
+	msg := err.Error()
+	switch {
+	case strings.Contains(msg, "required") || strings.Contains(msg, "invalid"):
+		return status.Error(codes.InvalidArgument, msg)
+	case strings.Contains(msg, "not found") || strings.Contains(msg, "no pending reservations"):
+		return status.Error(codes.NotFound, msg)
+	case strings.Contains(msg, "expired") || strings.Contains(msg, "already"):
+		return status.Error(codes.FailedPrecondition, msg)
+	default:
+		return status.Error(codes.Internal, msg)
+	}
+}
+
+// reservationsToProto converts reservation lines to proto format
+func reservationsToProto(reservations []*models.Reservation) []*pb.Reservation {
+	pbReservations := make([]*pb.Reservation, len(reservations))
+	for i, r := range reservations {
+		pbReservations[i] = &pb.Reservation{
+			ReservationId: r.ReservationID,
+			OrderId:       r.OrderID,
+			ProductId:     r.ProductID,
+			Quantity:      r.Quantity,
+			Status:        r.Status,
+			ExpiresAt:     r.ExpiresAt.Format(time.RFC3339),
+		}
+	}
+	return pbReservations
+}
@@ -3,20 +3,35 @@ package service
 import (
 	"context"
 	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
 
 	"github.com/datngth03/ecommerce-go-app/services/inventory-service/internal/models"
 	"github.com/datngth03/ecommerce-go-app/services/inventory-service/internal/repository"
+	"github.com/datngth03/ecommerce-go-app/shared/pkg/domainerr"
 )
 
+// ReservationOptions configures how long reservations are held and how they expire
+type ReservationOptions struct {
+	TTL             time.Duration // How long reserved stock is held awaiting payment
+	ExpiryInterval  time.Duration // How often expired reservations are released
+	ExpiryBatchSize int           // Max reservations released per run
+}
+
 // InventoryService handles inventory business logic
 type InventoryService struct {
-	repo repository.InventoryRepository
+	repo         repository.InventoryRepository
+	reservations ReservationOptions
 }
 
 // NewInventoryService creates a new inventory service
-func NewInventoryService(repo repository.InventoryRepository) *InventoryService {
+func NewInventoryService(repo repository.InventoryRepository, reservations ReservationOptions) *InventoryService {
 	return &InventoryService{
-		repo: repo,
+		repo:         repo,
+		reservations: reservations,
 	}
 }
 
@@ -29,6 +44,34 @@ func (s *InventoryService) GetStock(ctx context.Context, productID string) (*mod
 	return s.repo.GetStock(ctx, productID)
 }
 
+// maxBatchStockLookup caps the number of products accepted by GetStocks
+const maxBatchStockLookup = 100
+
+// GetStocks retrieves current stock for multiple products
+func (s *InventoryService) GetStocks(ctx context.Context, productIDs []string) ([]*models.Stock, error) {
+	if len(productIDs) == 0 {
+		return nil, fmt.Errorf("product_ids is required")
+	}
+	if len(productIDs) > maxBatchStockLookup {
+		return nil, fmt.Errorf("too many product_ids: maximum is %d", maxBatchStockLookup)
+	}
+
+	seen := make(map[string]struct{}, len(productIDs))
+	ids := make([]string, 0, len(productIDs))
+	for _, id := range productIDs {
+		if id == "" {
+			return nil, fmt.Errorf("product_id is required")
+		}
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		ids = append(ids, id)
+	}
+
+	return s.repo.GetStocks(ctx, ids)
+}
+
 // UpdateStock updates stock quantity
 func (s *InventoryService) UpdateStock(ctx context.Context, productID string, quantity int32, reason string) (*models.Stock, error) {
 	if productID == "" {
@@ -42,56 +85,63 @@ func (s *InventoryService) UpdateStock(ctx context.Context, productID string, qu
 	return s.repo.UpdateStock(ctx, productID, quantity, reason)
 }
 
-// ReserveStock reserves stock for an order
+// ReserveStock reserves stock for an order until the reservation TTL passes.
+// All lines share one reservation ID, which is used to confirm or release them.
 func (s *InventoryService) ReserveStock(ctx context.Context, orderID string, items []struct {
 	ProductID string
 	Quantity  int32
-}) (string, error) {
+}) ([]*models.Reservation, error) {
 	if orderID == "" {
-		return "", fmt.Errorf("order_id is required")
+		return nil, fmt.Errorf("order_id is required")
 	}
 
 	if len(items) == 0 {
-		return "", fmt.Errorf("items are required")
+		return nil, fmt.Errorf("items are required")
 	}
 
 	// Check availability for all items first
 	for _, item := range items {
 		available, err := s.repo.CheckAvailability(ctx, item.ProductID, item.Quantity)
 		if err != nil {
-			return "", fmt.Errorf("failed to check availability for %s: %w", item.ProductID, err)
+			return nil, fmt.Errorf("failed to check availability for %s: %w", item.ProductID, err)
 		}
 
 		if !available {
 			stock, _ := s.repo.GetStock(ctx, item.ProductID)
-			return "", fmt.Errorf("insufficient stock for product %s: need %d, have %d",
+			return nil, fmt.Errorf("insufficient stock for product %s: need %d, have %d",
 				item.ProductID, item.Quantity, stock.Available)
 		}
 	}
 
-	// Reserve all items
+	// Reserve all items under one reservation ID
+	reservationID := uuid.New().String()
+	expiresAt := time.Now().Add(s.reservations.TTL)
+	reservations := make([]*models.Reservation, 0, len(items))
 	for _, item := range items {
-		_, err := s.repo.CreateReservation(ctx, orderID, item.ProductID, item.Quantity)
+		reservation, err := s.repo.CreateReservation(ctx, reservationID, orderID, item.ProductID, item.Quantity, expiresAt)
 		if err != nil {
-			// Rollback: release already reserved items
-			s.repo.ReleaseReservation(ctx, orderID, "Reservation failed")
-			return "", fmt.Errorf("failed to reserve stock for %s: %w", item.ProductID, err)
+			// Rollback: release the items of this reservation already reserved
+			if len(reservations) > 0 {
+				s.repo.ReleaseReservationByID(ctx, reservationID, "Reservation failed")
+			}
+			return nil, fmt.Errorf("failed to reserve stock for %s: %w", item.ProductID, err)
 		}
+		reservations = append(reservations, reservation)
 	}
 
-	return orderID, nil
+	return reservations, nil
 }
 
-// ReleaseStock releases reserved stock
+// ReleaseStock releases reserved stock of an order
 func (s *InventoryService) ReleaseStock(ctx context.Context, orderID string, reason string) error {
 	if orderID == "" {
-		return fmt.Errorf("order_id is required")
+		return domainerr.InvalidArgument("order_id is required")
 	}
 
 	return s.repo.ReleaseReservation(ctx, orderID, reason)
 }
 
-// CommitStock commits reserved stock
+// CommitStock commits reserved stock of an order
 func (s *InventoryService) CommitStock(ctx context.Context, orderID string) error {
 	if orderID == "" {
 		return fmt.Errorf("order_id is required")
@@ -100,6 +150,171 @@ func (s *InventoryService) CommitStock(ctx context.Context, orderID string) erro
 	return s.repo.CommitReservation(ctx, orderID)
 }
 
+// ConfirmReservation commits a reservation once its order is paid.
+// A reservation past its expiry that the expiry job has not released yet can still be confirmed.
+func (s *InventoryService) ConfirmReservation(ctx context.Context, reservationID string) ([]*models.Reservation, error) {
+	if err := validateReservationID(reservationID); err != nil {
+		return nil, err
+	}
+
+	return s.repo.CommitReservationByID(ctx, reservationID)
+}
+
+// ReleaseReservation returns a reservation to available stock
+func (s *InventoryService) ReleaseReservation(ctx context.Context, reservationID, reason string) ([]*models.Reservation, error) {
+	if err := validateReservationID(reservationID); err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(reason) == "" {
+		reason = "Reservation released"
+	}
+
+	return s.repo.ReleaseReservationByID(ctx, reservationID, reason)
+}
+
+// RunReservationExpiry periodically releases reservations that were not confirmed in time
+func (s *InventoryService) RunReservationExpiry(ctx context.Context) {
+	ticker := time.NewTicker(s.reservations.ExpiryInterval)
+	defer ticker.Stop()
+
+	for {
+		for {
+			expired, err := s.repo.ReleaseExpiredReservations(ctx, time.Now(), s.reservations.ExpiryBatchSize)
+			if len(expired) > 0 {
+				log.Printf("Released %d expired reservation lines", len(expired))
+			}
+			if err != nil {
+				if ctx.Err() == nil {
+					log.Printf("Failed to release expired reservations: %v", err)
+				}
+				break
+			}
+			// A short batch means the backlog is drained
+			if len(expired) < s.reservations.ExpiryBatchSize {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func validateReservationID(reservationID string) error {
+	if reservationID == "" {
+		return fmt.Errorf("reservation_id is required")
+	}
+	if _, err := uuid.Parse(reservationID); err != nil {
+		return fmt.Errorf("invalid reservation_id")
+	}
+	return nil
+}
+
+// ProcessPaymentCompleted converts the items of an order whose payment succeeded
+// into sales. Without items the order's pending reservations are sold as reserved.
+// It is idempotent per order: applied is false when the order was already processed.
+func (s *InventoryService) ProcessPaymentCompleted(ctx context.Context, orderID string, items []models.SaleItem) (stocks []*models.Stock, applied bool, err error) {
+	if orderID == "" {
+		return nil, false, domainerr.InvalidArgument("order_id is required")
+	}
+
+	if len(items) == 0 {
+		reservations, err := s.repo.GetReservation(ctx, orderID)
+		if err != nil {
+			return nil, false, err
+		}
+		for _, res := range reservations {
+			if res.Status == models.ReservationStatusPending {
+				items = append(items, models.SaleItem{ProductID: res.ProductID, Quantity: res.Quantity})
+			}
+		}
+		if len(items) == 0 {
+			return nil, false, domainerr.NotFound("no pending reservations found for order %s", orderID)
+		}
+	}
+
+	// Merge duplicate lines so each product's stock row is updated once
+	merged := make([]models.SaleItem, 0, len(items))
+	index := make(map[string]int, len(items))
+	for _, item := range items {
+		if item.ProductID == "" {
+			return nil, false, domainerr.InvalidArgument("product_id is required")
+		}
+		if item.Quantity <= 0 {
+			return nil, false, domainerr.InvalidArgument("quantity must be positive for product %s", item.ProductID)
+		}
+		if i, ok := index[item.ProductID]; ok {
+			merged[i].Quantity += item.Quantity
+			continue
+		}
+		index[item.ProductID] = len(merged)
+		merged = append(merged, item)
+	}
+
+	return s.repo.CommitOrderSale(ctx, orderID, "payment.completed", merged)
+}
+
+// ProcessRefund returns the refunded items of an order to stock. It is idempotent
+// per refund: applied is false when the refund was already processed.
+func (s *InventoryService) ProcessRefund(ctx context.Context, orderID, refundID string, items []models.ReturnItem) (stocks []*models.Stock, applied bool, err error) {
+	if orderID == "" || refundID == "" {
+		return nil, false, domainerr.InvalidArgument("order_id and refund_id are required")
+	}
+
+	merged, err := mergeReturnItems(items)
+	if err != nil {
+		return nil, false, err
+	}
+
+	// One order can be refunded several times, so the key includes the refund
+	return s.repo.RestockReturnedItems(ctx, orderID, "payment.refunded:"+refundID, merged)
+}
+
+// ProcessReturnReceived returns the items of a received return (RMA) to stock.
+// It is idempotent per return: applied is false when the return was already processed.
+func (s *InventoryService) ProcessReturnReceived(ctx context.Context, orderID, returnID string, items []models.ReturnItem) (stocks []*models.Stock, applied bool, err error) {
+	if orderID == "" || returnID == "" {
+		return nil, false, domainerr.InvalidArgument("order_id and return_id are required")
+	}
+
+	merged, err := mergeReturnItems(items)
+	if err != nil {
+		return nil, false, err
+	}
+
+	// One order can have several returns, so the key includes the return
+	return s.repo.RestockReturnedItems(ctx, orderID, "return.received:"+returnID, merged)
+}
+
+// mergeReturnItems validates returned items and merges duplicate lines so each
+// product's stock row is updated once
+func mergeReturnItems(items []models.ReturnItem) ([]models.ReturnItem, error) {
+	if len(items) == 0 {
+		return nil, domainerr.InvalidArgument("items are required")
+	}
+
+	merged := make([]models.ReturnItem, 0, len(items))
+	index := make(map[string]int, len(items))
+	for _, item := range items {
+		if item.ProductID == "" {
+			return nil, domainerr.InvalidArgument("product_id is required")
+		}
+		if item.Quantity <= 0 {
+			return nil, domainerr.InvalidArgument("quantity must be positive for product %s", item.ProductID)
+		}
+		if i, ok := index[item.ProductID]; ok {
+			merged[i].Quantity += item.Quantity
+			continue
+		}
+		index[item.ProductID] = len(merged)
+		merged = append(merged, item)
+	}
+	return merged, nil
+}
+
 // CheckAvailability checks if products are available
 func (s *InventoryService) CheckAvailability(ctx context.Context, items []struct {
 	ProductID string
@@ -126,19 +341,35 @@ func (s *InventoryService) CheckAvailability(ctx context.Context, items []struct
 	return len(unavailable) == 0, unavailable, nil
 }
 
-// GetStockHistory retrieves stock movement history
-func (s *InventoryService) GetStockHistory(ctx context.Context, productID string, limit, offset int) ([]*models.StockMovement, int, error) {
+// maxStockHistoryLimit caps the page size accepted by GetStockHistory
+const maxStockHistoryLimit = 100
+
+// GetStockHistory retrieves stock movement history, optionally filtered by
+// movement type and creation time range
+func (s *InventoryService) GetStockHistory(ctx context.Context, productID string, filter models.MovementFilter, limit, offset int) ([]*models.StockMovement, int, error) {
 	if productID == "" {
 		return nil, 0, fmt.Errorf("product_id is required")
 	}
 
+	filter.MovementType = strings.ToUpper(strings.TrimSpace(filter.MovementType))
+	if filter.MovementType != "" && !models.IsValidMovementType(filter.MovementType) {
+		return nil, 0, fmt.Errorf("invalid movement_type: %s", filter.MovementType)
+	}
+
+	if filter.From != nil && filter.To != nil && filter.From.After(*filter.To) {
+		return nil, 0, fmt.Errorf("invalid date range: start_date must not be after end_date")
+	}
+
 	if limit <= 0 {
 		limit = 10
 	}
+	if limit > maxStockHistoryLimit {
+		limit = maxStockHistoryLimit
+	}
 
 	if offset < 0 {
 		offset = 0
 	}
 
-	return s.repo.GetMovementHistory(ctx, productID, limit, offset)
+	return s.repo.GetMovementHistory(ctx, productID, filter, limit, offset)
 }
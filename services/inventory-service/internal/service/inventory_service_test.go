@@ -0,0 +1,193 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/datngth03/ecommerce-go-app/services/inventory-service/internal/models"
+	"github.com/datngth03/ecommerce-go-app/services/inventory-service/internal/repository"
+	"github.com/datngth03/ecommerce-go-app/shared/pkg/domainerr"
+)
+
+// saleRepo keeps stock and reservations in memory and applies CommitOrderSale the way
+// the PostgreSQL repository does: once per (order, event type), committing pending
+// reservations first and selling the rest from available stock
+type saleRepo struct {
+	repository.InventoryRepository
+	stocks       map[string]*models.Stock
+	reservations []*models.Reservation
+	processed    map[string]bool
+}
+
+func newSaleRepo(stocks ...models.Stock) *saleRepo {
+	r := &saleRepo{stocks: make(map[string]*models.Stock), processed: make(map[string]bool)}
+	for i := range stocks {
+		r.stocks[stocks[i].ProductID] = &stocks[i]
+	}
+	return r
+}
+
+func (r *saleRepo) GetReservation(ctx context.Context, orderID string) ([]*models.Reservation, error) {
+	var found []*models.Reservation
+	for _, res := range r.reservations {
+		if res.OrderID == orderID {
+			found = append(found, res)
+		}
+	}
+	return found, nil
+}
+
+func (r *saleRepo) CommitOrderSale(ctx context.Context, orderID, eventType string, items []models.SaleItem) ([]*models.Stock, bool, error) {
+	key := orderID + "/" + eventType
+	if r.processed[key] {
+		return nil, false, nil
+	}
+
+	var stocks []*models.Stock
+	for _, item := range items {
+		stock, ok := r.stocks[item.ProductID]
+		if !ok {
+			return nil, false, domainerr.Conflict("insufficient stock for product %s: no stock record", item.ProductID)
+		}
+		remaining := item.Quantity
+		for _, res := range r.reservations {
+			if res.OrderID != orderID || res.ProductID != item.ProductID || res.Status != models.ReservationStatusPending {
+				continue
+			}
+			covered := min(res.Quantity, remaining)
+			stock.Reserved -= res.Quantity
+			stock.Available += res.Quantity - covered
+			stock.Total -= covered
+			remaining -= covered
+			res.Status = models.ReservationStatusCommitted
+		}
+		if remaining > stock.Available {
+			return nil, false, domainerr.Conflict("insufficient stock for product %s: need %d, have %d",
+				item.ProductID, remaining, stock.Available)
+		}
+		stock.Available -= remaining
+		stock.Total -= remaining
+		stocks = append(stocks, stock)
+	}
+
+	r.processed[key] = true
+	return stocks, true, nil
+}
+
+func TestProcessPaymentCompletedCommitsReservations(t *testing.T) {
+	repo := newSaleRepo(
+		models.Stock{ProductID: "p1", Available: 8, Reserved: 2, Total: 10},
+		models.Stock{ProductID: "p2", Available: 4, Reserved: 1, Total: 5},
+	)
+	repo.reservations = []*models.Reservation{
+		{OrderID: "o1", ProductID: "p1", Quantity: 2, Status: models.ReservationStatusPending},
+		{OrderID: "o1", ProductID: "p2", Quantity: 1, Status: models.ReservationStatusPending},
+	}
+	svc := NewInventoryService(repo, ReservationOptions{})
+
+	// payment.completed without items commits the reservations as they are
+	stocks, applied, err := svc.ProcessPaymentCompleted(context.Background(), "o1", nil)
+	if err != nil {
+		t.Fatalf("ProcessPaymentCompleted: %v", err)
+	}
+	if !applied || len(stocks) != 2 {
+		t.Fatalf("applied = %v with %d stocks, want true with 2", applied, len(stocks))
+	}
+
+	want := map[string]models.Stock{
+		"p1": {Available: 8, Reserved: 0, Total: 8},
+		"p2": {Available: 4, Reserved: 0, Total: 4},
+	}
+	for id, w := range want {
+		got := repo.stocks[id]
+		if got.Available != w.Available || got.Reserved != w.Reserved || got.Total != w.Total {
+			t.Errorf("%s: available/reserved/total = %d/%d/%d, want %d/%d/%d",
+				id, got.Available, got.Reserved, got.Total, w.Available, w.Reserved, w.Total)
+		}
+	}
+	for _, res := range repo.reservations {
+		if res.Status != models.ReservationStatusCommitted {
+			t.Errorf("reservation of %s is %s, want %s", res.ProductID, res.Status, models.ReservationStatusCommitted)
+		}
+	}
+}
+
+func TestProcessPaymentCompletedRepeatDelivery(t *testing.T) {
+	repo := newSaleRepo(models.Stock{ProductID: "p1", Available: 8, Reserved: 2, Total: 10})
+	repo.reservations = []*models.Reservation{
+		{OrderID: "o1", ProductID: "p1", Quantity: 2, Status: models.ReservationStatusPending},
+	}
+	svc := NewInventoryService(repo, ReservationOptions{})
+	items := []models.SaleItem{{ProductID: "p1", Quantity: 1}, {ProductID: "p1", Quantity: 1}}
+
+	if _, applied, err := svc.ProcessPaymentCompleted(context.Background(), "o1", items); err != nil || !applied {
+		t.Fatalf("first delivery: applied = %v, err = %v", applied, err)
+	}
+	// A redelivered event carries the same items and must not sell them again
+	stocks, applied, err := svc.ProcessPaymentCompleted(context.Background(), "o1", items)
+	if err != nil {
+		t.Fatalf("repeat delivery: %v", err)
+	}
+	if applied || stocks != nil {
+		t.Errorf("repeat delivery applied = %v, want false", applied)
+	}
+
+	if got := repo.stocks["p1"]; got.Available != 8 || got.Reserved != 0 || got.Total != 8 {
+		t.Errorf("available/reserved/total = %d/%d/%d, want 8/0/8", got.Available, got.Reserved, got.Total)
+	}
+}
+
+func TestProcessPaymentCompletedExpiredReservation(t *testing.T) {
+	// The expiry job already returned the reserved unit to available stock
+	repo := newSaleRepo(models.Stock{ProductID: "p1", Available: 10, Reserved: 0, Total: 10})
+	repo.reservations = []*models.Reservation{
+		{OrderID: "o1", ProductID: "p1", Quantity: 2, Status: models.ReservationStatusExpired},
+	}
+	svc := NewInventoryService(repo, ReservationOptions{})
+
+	// Without items there is nothing left to commit; this is final, not a retryable failure
+	_, _, err := svc.ProcessPaymentCompleted(context.Background(), "o1", nil)
+	if !domainerr.Is(err, domainerr.KindNotFound) {
+		t.Fatalf("without items: error = %v, want NotFound", err)
+	}
+	if repo.stocks["p1"].Total != 10 {
+		t.Errorf("total = %d after a failed commit, want 10", repo.stocks["p1"].Total)
+	}
+
+	// With items the paid quantity is sold from available stock
+	_, applied, err := svc.ProcessPaymentCompleted(context.Background(), "o1", []models.SaleItem{{ProductID: "p1", Quantity: 2}})
+	if err != nil || !applied {
+		t.Fatalf("with items: applied = %v, err = %v", applied, err)
+	}
+	if got := repo.stocks["p1"]; got.Available != 8 || got.Total != 8 {
+		t.Errorf("available/total = %d/%d, want 8/8", got.Available, got.Total)
+	}
+	if repo.reservations[0].Status != models.ReservationStatusExpired {
+		t.Errorf("expired reservation became %s", repo.reservations[0].Status)
+	}
+}
+
+func TestProcessPaymentCompletedRejectsInvalidEvents(t *testing.T) {
+	svc := NewInventoryService(newSaleRepo(models.Stock{ProductID: "p1", Available: 1, Total: 1}), ReservationOptions{})
+
+	tests := []struct {
+		name    string
+		orderID string
+		items   []models.SaleItem
+		kind    domainerr.Kind
+	}{
+		{"missing order", "", []models.SaleItem{{ProductID: "p1", Quantity: 1}}, domainerr.KindInvalidArgument},
+		{"missing product", "o1", []models.SaleItem{{Quantity: 1}}, domainerr.KindInvalidArgument},
+		{"zero quantity", "o1", []models.SaleItem{{ProductID: "p1"}}, domainerr.KindInvalidArgument},
+		{"oversold", "o1", []models.SaleItem{{ProductID: "p1", Quantity: 2}}, domainerr.KindConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := svc.ProcessPaymentCompleted(context.Background(), tt.orderID, tt.items)
+			if !domainerr.Is(err, tt.kind) {
+				t.Errorf("error = %v, want kind %d", err, tt.kind)
+			}
+		})
+	}
+}
@@ -0,0 +1,9 @@
+-- Track order events that have already been applied to stock so that
+-- redelivered messages (e.g. order.paid) are processed at most once
+CREATE TABLE IF NOT EXISTS processed_order_events (
+    order_id VARCHAR(255) NOT NULL,
+    event_type VARCHAR(100) NOT NULL,
+    processed_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+
+    PRIMARY KEY (order_id, event_type)
+);
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS idx_stock_movements_product_type_created;
@@ -0,0 +1,3 @@
+-- Supports filtering stock history by movement type within a product
+CREATE INDEX IF NOT EXISTS idx_stock_movements_product_type_created
+    ON stock_movements(product_id, movement_type, created_at DESC);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_reservations_pending_expires_at;
+DROP INDEX IF EXISTS idx_reservations_reservation_id;
+ALTER TABLE reservations DROP COLUMN IF EXISTS reservation_id;
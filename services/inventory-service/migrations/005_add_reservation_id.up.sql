@@ -0,0 +1,10 @@
+-- Groups the lines of one ReserveStock call under a single reservation_id
+ALTER TABLE reservations ADD COLUMN IF NOT EXISTS reservation_id UUID;
+UPDATE reservations SET reservation_id = id WHERE reservation_id IS NULL;
+ALTER TABLE reservations ALTER COLUMN reservation_id SET NOT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_reservations_reservation_id ON reservations(reservation_id);
+
+-- Supports the expiry job scanning for pending reservations past their deadline
+CREATE INDEX IF NOT EXISTS idx_reservations_pending_expires_at
+    ON reservations(expires_at) WHERE status = 'PENDING';
@@ -7,8 +7,6 @@ import (
 	"net"
 	"net/http"
 	"os"
-	"os/signal"
-	"syscall"
 	"time"
 
 	"golang.org/x/time/rate"
@@ -17,9 +15,13 @@ import (
 	"github.com/datngth03/ecommerce-go-app/services/notification-service/internal/config"
 	"github.com/datngth03/ecommerce-go-app/services/notification-service/internal/email"
 	"github.com/datngth03/ecommerce-go-app/services/notification-service/internal/metrics"
+	"github.com/datngth03/ecommerce-go-app/services/notification-service/internal/push"
 	"github.com/datngth03/ecommerce-go-app/services/notification-service/internal/repository"
 	"github.com/datngth03/ecommerce-go-app/services/notification-service/internal/rpc"
 	"github.com/datngth03/ecommerce-go-app/services/notification-service/internal/service"
+	"github.com/datngth03/ecommerce-go-app/shared/pkg/grpcsize"
+	"github.com/datngth03/ecommerce-go-app/shared/pkg/lifecycle"
+	sharedLogger "github.com/datngth03/ecommerce-go-app/shared/pkg/logger"
 	sharedMiddleware "github.com/datngth03/ecommerce-go-app/shared/pkg/middleware"
 	sharedTLS "github.com/datngth03/ecommerce-go-app/shared/pkg/tlsutil"
 	sharedTracing "github.com/datngth03/ecommerce-go-app/shared/pkg/tracing"
@@ -40,6 +42,9 @@ func main() {
 	if err != nil {
 		log.Fatalf("Failed to load config: %v", err)
 	}
+	if err := sharedLogger.Init(cfg.Logging); err != nil {
+		log.Fatalf("Failed to initialize logger: %v", err)
+	}
 
 	// Initialize distributed tracing
 	tracerCleanup, err := sharedTracing.InitTracer(sharedTracing.TracerConfig{
@@ -79,27 +84,59 @@ func main() {
 		cfg.Email.FromName,
 	)
 
+	// Initialize push provider
+	var pushProvider push.Provider
+	switch cfg.Push.Provider {
+	case "fcm":
+		fcmProvider, err := push.NewFCMProvider(cfg.Push.FCMCredentialsFile, cfg.Push.FCMProjectID, cfg.Push.Timeout)
+		if err != nil {
+			log.Fatalf("Failed to initialize FCM push provider: %v", err)
+		}
+		pushProvider = fcmProvider
+		log.Println("✓ Push notifications delivered via FCM")
+	default:
+		pushProvider = push.NewLogProvider()
+		log.Println("⚠️  Push provider is log-only - notifications are not delivered")
+	}
+
 	// Initialize service
-	svc := service.NewNotificationService(repo, emailService)
+	svc := service.NewNotificationService(repo, emailService, pushProvider)
+	bulkService := service.NewBulkEmailService(repo, emailService, service.BulkEmailOptions{
+		Workers:       cfg.BulkEmail.Workers,
+		RatePerSecond: cfg.BulkEmail.RatePerSecond,
+		MaxRecipients: cfg.BulkEmail.MaxRecipients,
+	})
+
+	// Process bulk email jobs in the background, resuming unfinished ones,
+	// and prune device tokens the push provider rejected
+	bgCtx, stopBackground := context.WithCancel(context.Background())
+	defer stopBackground()
+	go bulkService.Run(bgCtx)
+	go svc.RunDeviceTokenPruning(bgCtx)
+	log.Printf("✓ Bulk email processor started (%d workers, %.1f emails/s)",
+		cfg.BulkEmail.Workers, cfg.BulkEmail.RatePerSecond)
 
 	// Initialize gRPC server with tracing interceptor and TLS
 	var grpcServerOpts []grpc.ServerOption
 	grpcServerOpts = append(grpcServerOpts, grpc.UnaryInterceptor(sharedTracing.UnaryServerInterceptor()))
 
+	// Same message size limit as the other services and their clients
+	grpcServerOpts = append(grpcServerOpts, grpcsize.ServerOptions(cfg.Server.MaxMessageSize)...)
+
 	// Enable TLS if configured
 	if cfg.Server.TLS.Enabled {
-		tlsCreds, err := sharedTLS.ServerTLSConfig(cfg.Server.TLS.CertFile, cfg.Server.TLS.KeyFile)
+		tlsCreds, err := sharedTLS.ServerCredentials(cfg.Server.TLS)
 		if err != nil {
 			log.Fatalf("Failed to load TLS credentials: %v", err)
 		}
 		grpcServerOpts = append(grpcServerOpts, grpc.Creds(tlsCreds))
-		log.Printf("✓ TLS enabled for gRPC server (cert: %s)", cfg.Server.TLS.CertFile)
+		log.Printf("✓ TLS enabled for gRPC server (cert: %s, client certificates required: %v)", cfg.Server.TLS.CertFile, cfg.Server.TLS.ClientAuth)
 	} else {
 		log.Println("⚠️  TLS disabled - using insecure connection")
 	}
 
 	grpcServer := grpc.NewServer(grpcServerOpts...)
-	notificationServer := rpc.NewNotificationServer(svc)
+	notificationServer := rpc.NewNotificationServer(svc, bulkService)
 	notification_service.RegisterNotificationServiceServer(grpcServer, notificationServer)
 
 	// Register health check
@@ -123,95 +160,104 @@ func main() {
 		}
 	}()
 
-	// Start HTTP server with Gin and Prometheus
-	go func() {
-		gin.SetMode(gin.ReleaseMode)
-		router := gin.New()
-
-		// Add distributed tracing middleware FIRST
-		router.Use(sharedTracing.GinMiddleware(cfg.Service.Name))
+	// HTTP server with Gin and Prometheus
+	gin.SetMode(gin.ReleaseMode)
+	router := gin.New()
 
-		// Enhanced input validation middlewares (5MB max request size)
-		for _, mw := range sharedMiddleware.EnhancedValidationMiddlewares(5 * 1024 * 1024) {
-			router.Use(mw)
-		}
+	// Add distributed tracing middleware FIRST
+	router.Use(sharedTracing.GinMiddleware(cfg.Service.Name))
 
-		// Response compression middleware
-		router.Use(sharedMiddleware.CompressionMiddleware())
-
-		router.Use(gin.Recovery())
-
-		// Security middleware
-		var securityMiddleware []gin.HandlerFunc
-		if cfg.Security.RateLimit.Enabled {
-			rateLimiter := sharedMiddleware.NewIPRateLimiter(
-				rate.Limit(cfg.Security.RateLimit.RequestsPerSecond),
-				cfg.Security.RateLimit.BurstSize,
-			)
-			securityMiddleware = append(securityMiddleware, sharedMiddleware.RateLimitMiddleware(rateLimiter))
-			log.Printf("✓ Rate limiting enabled: %.1f req/s, burst: %d",
-				cfg.Security.RateLimit.RequestsPerSecond, cfg.Security.RateLimit.BurstSize)
-		}
+	// Enhanced input validation middlewares (5MB max request size)
+	for _, mw := range sharedMiddleware.EnhancedValidationMiddlewares(5 * 1024 * 1024) {
+		router.Use(mw)
+	}
 
-		securityMiddleware = append(securityMiddleware, sharedMiddleware.SecurityHeadersMiddleware())
+	// Response compression middleware
+	router.Use(sharedMiddleware.CompressionMiddleware())
+
+	router.Use(gin.Recovery())
+
+	// Security middleware
+	var securityMiddleware []gin.HandlerFunc
+	if cfg.Security.RateLimit.Enabled {
+		rateLimiter := sharedMiddleware.NewIPRateLimiter(
+			rate.Limit(cfg.Security.RateLimit.RequestsPerSecond),
+			cfg.Security.RateLimit.BurstSize,
+		)
+		securityMiddleware = append(securityMiddleware, sharedMiddleware.RateLimitMiddleware(rateLimiter))
+		log.Printf("✓ Rate limiting enabled: %.1f req/s, burst: %d",
+			cfg.Security.RateLimit.RequestsPerSecond, cfg.Security.RateLimit.BurstSize)
+	}
 
-		if cfg.Security.CORS.Enabled {
-			securityMiddleware = append(securityMiddleware,
-				sharedMiddleware.CORSMiddleware(cfg.Security.CORS.AllowedOrigins))
-			log.Printf("✓ CORS enabled for origins: %v", cfg.Security.CORS.AllowedOrigins)
-		}
+	securityMiddleware = append(securityMiddleware, sharedMiddleware.SecurityHeadersMiddleware())
 
+	if cfg.Security.CORS.Enabled {
 		securityMiddleware = append(securityMiddleware,
-			sharedMiddleware.TimeoutMiddleware(cfg.Security.RequestTimeout))
+			sharedMiddleware.CORSMiddleware(cfg.Security.CORS.AllowedOrigins))
+		log.Printf("✓ CORS enabled for origins: %v", cfg.Security.CORS.AllowedOrigins)
+	}
 
-		router.Use(securityMiddleware...)
-		router.Use(metrics.PrometheusGinMiddleware())
+	securityMiddleware = append(securityMiddleware,
+		sharedMiddleware.TimeoutMiddleware(cfg.Security.RequestTimeout))
 
-		// Health check endpoints
-		router.GET("/health", func(c *gin.Context) {
-			c.JSON(http.StatusOK, gin.H{
-				"status":  "healthy",
-				"service": "notification-service",
-			})
+	router.Use(securityMiddleware...)
+	router.Use(metrics.PrometheusGinMiddleware())
+
+	// Health check endpoints
+	router.GET("/health", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{
+			"status":  "healthy",
+			"service": "notification-service",
 		})
+	})
 
-		router.GET("/ready", func(c *gin.Context) {
-			sqlDB, err := db.DB()
-			if err != nil || sqlDB.Ping() != nil {
-				c.JSON(http.StatusServiceUnavailable, gin.H{
-					"status": "not ready",
-					"error":  "database not ready",
-				})
-				return
-			}
-			c.JSON(http.StatusOK, gin.H{
-				"status": "ready",
+	router.GET("/ready", func(c *gin.Context) {
+		sqlDB, err := db.DB()
+		if err != nil || sqlDB.Ping() != nil {
+			c.JSON(http.StatusServiceUnavailable, gin.H{
+				"status": "not ready",
+				"error":  "database not ready",
 			})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{
+			"status": "ready",
 		})
+	})
+
+	// Prometheus metrics endpoint
+	router.GET("/metrics", gin.WrapH(promhttp.Handler()))
 
-		// Prometheus metrics endpoint
-		router.GET("/metrics", gin.WrapH(promhttp.Handler()))
+	httpServer := &http.Server{
+		Addr:    fmt.Sprintf(":%s", cfg.Server.HTTPPort),
+		Handler: router,
+	}
 
+	go func() {
 		log.Printf("✓ Notification HTTP server listening on port %s", cfg.Server.HTTPPort)
-		if err := router.Run(fmt.Sprintf(":%s", cfg.Server.HTTPPort)); err != nil {
+		if err := httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
 			log.Fatalf("Failed to start HTTP server: %v", err)
 		}
 	}()
 
-	// Wait for interrupt signal
-	quit := make(chan os.Signal, 1)
-	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
-	<-quit
-
-	log.Println("Shutting down Notification Service...")
-	grpcServer.GracefulStop()
-
-	sqlDB, _ := db.DB()
-	if sqlDB != nil {
-		sqlDB.Close()
+	// Graceful shutdown: stop taking requests, then background work, then the database
+	shutdown := lifecycle.NewManager("Notification Service", cfg.Server.ShutdownTimeout)
+	shutdown.Register("HTTP server", httpServer)
+	shutdown.Register("gRPC server", lifecycle.GRPCServer(grpcServer))
+	shutdown.Register("background workers", lifecycle.ComponentFunc(func(context.Context) error {
+		stopBackground()
+		return nil
+	}))
+	shutdown.Register("database", lifecycle.Closer(func() error {
+		sqlDB, err := db.DB()
+		if err != nil {
+			return err
+		}
+		return sqlDB.Close()
+	}))
+	if err := shutdown.Wait(); err != nil {
+		log.Printf("Notification Service shutdown completed with errors: %v", err)
 	}
-
-	log.Println("Notification Service stopped")
 }
 
 func initDB(cfg *config.Config) (*gorm.DB, error) {
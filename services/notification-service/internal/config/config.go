@@ -30,15 +30,17 @@ type CORSConfig struct {
 
 // Config holds notification service specific configuration
 type Config struct {
-	Service  sharedConfig.ServiceInfo
-	Server   sharedConfig.ServerConfig
-	Database sharedConfig.DatabaseConfig
-	RabbitMQ sharedConfig.RabbitMQConfig
-	Services sharedConfig.ExternalServices
-	Logging  sharedConfig.LoggingConfig
-	Email    EmailConfig
-	SMS      SMSConfig
-	Security SecurityConfig
+	Service   sharedConfig.ServiceInfo
+	Server    sharedConfig.ServerConfig
+	Database  sharedConfig.DatabaseConfig
+	RabbitMQ  sharedConfig.RabbitMQConfig
+	Services  sharedConfig.ExternalServices
+	Logging   sharedConfig.LoggingConfig
+	Email     EmailConfig
+	BulkEmail BulkEmailConfig
+	SMS       SMSConfig
+	Push      PushConfig
+	Security  SecurityConfig
 }
 
 // EmailConfig contains email service settings
@@ -51,6 +53,13 @@ type EmailConfig struct {
 	FromName     string
 }
 
+// BulkEmailConfig contains bulk email processing settings
+type BulkEmailConfig struct {
+	Workers       int
+	RatePerSecond float64 // Keeps bulk sends under the SMTP provider's limit
+	MaxRecipients int
+}
+
 // SMSConfig contains SMS service settings
 type SMSConfig struct {
 	TwilioAccountSID string
@@ -79,17 +88,74 @@ func Load() (*Config, error) {
 			FromAddress:  sharedConfig.GetEnv("EMAIL_FROM_ADDRESS", "noreply@ecommerce.com"),
 			FromName:     sharedConfig.GetEnv("EMAIL_FROM_NAME", "E-Commerce"),
 		},
+		BulkEmail: LoadBulkEmailConfig(),
 		SMS: SMSConfig{
 			TwilioAccountSID: sharedConfig.GetEnv("TWILIO_ACCOUNT_SID", ""),
 			TwilioAuthToken:  sharedConfig.GetEnv("TWILIO_AUTH_TOKEN", ""),
 			TwilioFromNumber: sharedConfig.GetEnv("TWILIO_FROM_NUMBER", ""),
 		},
+		Push:     LoadPushConfig(),
 		Security: LoadSecurityConfig(),
 	}
 
+	v := sharedConfig.NewValidator(cfg.Service)
+	v.CheckServer(cfg.Server)
+	v.CheckDatabase(cfg.Database)
+	v.CheckRabbitMQ(cfg.RabbitMQ)
+	if err := v.Err(); err != nil {
+		return nil, err
+	}
+
 	return cfg, nil
 }
 
+// PushConfig contains push notification settings
+type PushConfig struct {
+	Provider           string // "fcm" or "log" (logs only, for local development)
+	FCMCredentialsFile string // Firebase service account key (JSON)
+	FCMProjectID       string // Optional, defaults to the project of the service account
+	Timeout            time.Duration
+}
+
+// LoadPushConfig loads push notification configuration from environment
+func LoadPushConfig() PushConfig {
+	timeout, err := time.ParseDuration(sharedConfig.GetEnv("PUSH_TIMEOUT", "10s"))
+	if err != nil {
+		timeout = 10 * time.Second
+	}
+
+	return PushConfig{
+		Provider:           strings.ToLower(sharedConfig.GetEnv("PUSH_PROVIDER", "log")),
+		FCMCredentialsFile: sharedConfig.GetEnv("FCM_CREDENTIALS_FILE", ""),
+		FCMProjectID:       sharedConfig.GetEnv("FCM_PROJECT_ID", ""),
+		Timeout:            timeout,
+	}
+}
+
+// LoadBulkEmailConfig loads bulk email configuration from environment
+func LoadBulkEmailConfig() BulkEmailConfig {
+	workers, err := strconv.Atoi(sharedConfig.GetEnv("BULK_EMAIL_WORKERS", "5"))
+	if err != nil || workers <= 0 {
+		workers = 5
+	}
+
+	ratePerSecond, err := strconv.ParseFloat(sharedConfig.GetEnv("BULK_EMAIL_RATE_PER_SECOND", "10"), 64)
+	if err != nil || ratePerSecond <= 0 {
+		ratePerSecond = 10
+	}
+
+	maxRecipients, err := strconv.Atoi(sharedConfig.GetEnv("BULK_EMAIL_MAX_RECIPIENTS", "10000"))
+	if err != nil || maxRecipients <= 0 {
+		maxRecipients = 10000
+	}
+
+	return BulkEmailConfig{
+		Workers:       workers,
+		RatePerSecond: ratePerSecond,
+		MaxRecipients: maxRecipients,
+	}
+}
+
 // LoadSecurityConfig loads security configuration from environment
 func LoadSecurityConfig() SecurityConfig {
 	// Parse rate limit RPS
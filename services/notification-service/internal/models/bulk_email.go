@@ -0,0 +1,62 @@
+package models
+
+import "time"
+
+// Bulk email job statuses
+const (
+	BulkJobStatusPending   = "PENDING"
+	BulkJobStatusRunning   = "RUNNING"
+	BulkJobStatusCompleted = "COMPLETED"
+)
+
+// Bulk email recipient statuses
+const (
+	BulkRecipientStatusPending = "PENDING"
+	BulkRecipientStatusSent    = "SENT"
+	BulkRecipientStatusFailed  = "FAILED"
+	BulkRecipientStatusSkipped = "SKIPPED" // Recipient opted out of email
+)
+
+// BulkEmailJob is one bulk send, processed in the background
+type BulkEmailJob struct {
+	ID          string     `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	Subject     string     `gorm:"type:varchar(500)" json:"subject"`
+	Body        string     `gorm:"type:text;not null" json:"body"`
+	TemplateID  *string    `gorm:"type:uuid" json:"template_id,omitempty"`
+	Variables   string     `gorm:"type:jsonb" json:"variables,omitempty"` // Shared template variables
+	Status      string     `gorm:"type:varchar(50);not null;index" json:"status"`
+	Total       int        `gorm:"not null" json:"total"`
+	CreatedAt   time.Time  `gorm:"autoCreateTime" json:"created_at"`
+	StartedAt   *time.Time `json:"started_at,omitempty"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+}
+
+// BulkEmailRecipient records the result of sending a bulk job to one recipient
+type BulkEmailRecipient struct {
+	ID           string     `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	JobID        string     `gorm:"type:uuid;not null;index" json:"job_id"`
+	UserID       string     `gorm:"type:varchar(255)" json:"user_id,omitempty"`
+	Recipient    string     `gorm:"type:varchar(255);not null" json:"recipient"`
+	Variables    string     `gorm:"type:jsonb" json:"variables,omitempty"` // Per-recipient template variables
+	Status       string     `gorm:"type:varchar(50);not null" json:"status"`
+	ErrorMessage string     `gorm:"type:text" json:"error_message,omitempty"`
+	SentAt       *time.Time `json:"sent_at,omitempty"`
+}
+
+// BulkJobProgress counts recipients of a job per status
+type BulkJobProgress struct {
+	Pending int
+	Sent    int
+	Failed  int
+	Skipped int
+}
+
+// TableName specifies the table name for BulkEmailJob
+func (BulkEmailJob) TableName() string {
+	return "bulk_email_jobs"
+}
+
+// TableName specifies the table name for BulkEmailRecipient
+func (BulkEmailRecipient) TableName() string {
+	return "bulk_email_recipients"
+}
@@ -0,0 +1,37 @@
+package models
+
+import "time"
+
+// Device platforms
+const (
+	DevicePlatformIOS     = "ios"
+	DevicePlatformAndroid = "android"
+	DevicePlatformWeb     = "web"
+)
+
+// IsValidDevicePlatform reports whether platform is a supported device platform
+func IsValidDevicePlatform(platform string) bool {
+	switch platform {
+	case DevicePlatformIOS, DevicePlatformAndroid, DevicePlatformWeb:
+		return true
+	}
+	return false
+}
+
+// DeviceToken is a push notification token of a device.
+// Tokens rejected by the push provider are marked stale so they can be pruned.
+type DeviceToken struct {
+	Token       string     `gorm:"type:varchar(512);primaryKey" json:"token"`
+	UserID      string     `gorm:"type:varchar(255);index" json:"user_id,omitempty"`
+	Platform    string     `gorm:"type:varchar(20)" json:"platform,omitempty"`
+	IsStale     bool       `gorm:"not null;default:false" json:"is_stale"`
+	StaleReason string     `gorm:"type:text" json:"stale_reason,omitempty"`
+	StaleAt     *time.Time `json:"stale_at,omitempty"`
+	CreatedAt   time.Time  `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt   time.Time  `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// TableName specifies the table name for DeviceToken
+func (DeviceToken) TableName() string {
+	return "device_tokens"
+}
@@ -26,24 +26,27 @@ const (
 	NotificationChannelSMTP   = "SMTP"
 	NotificationChannelTwilio = "TWILIO"
 	NotificationChannelFCM    = "FCM"
+	NotificationChannelLog    = "LOG" // Push provider for local development
 )
 
 // Notification represents a notification record
 type Notification struct {
-	ID           string         `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
-	UserID       string         `gorm:"type:varchar(255);index" json:"user_id"`
-	Type         string         `gorm:"type:varchar(50);not null;index" json:"type"`
-	Channel      string         `gorm:"type:varchar(50);not null" json:"channel"`
-	Recipient    string         `gorm:"type:varchar(255);not null" json:"recipient"`
-	Subject      string         `gorm:"type:varchar(500)" json:"subject"`
-	Content      string         `gorm:"type:text;not null" json:"content"`
-	Status       string         `gorm:"type:varchar(50);not null;index" json:"status"`
-	ErrorMessage string         `gorm:"type:text" json:"error_message,omitempty"`
-	TemplateID   string         `gorm:"type:uuid" json:"template_id,omitempty"`
-	Metadata     string         `gorm:"type:jsonb" json:"metadata,omitempty"`
-	CreatedAt    time.Time      `gorm:"autoCreateTime" json:"created_at"`
-	SentAt       *time.Time     `json:"sent_at,omitempty"`
-	DeletedAt    gorm.DeletedAt `gorm:"index" json:"-"`
+	ID           string `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	UserID       string `gorm:"type:varchar(255);index" json:"user_id"`
+	Type         string `gorm:"type:varchar(50);not null;index" json:"type"`
+	Channel      string `gorm:"type:varchar(50);not null" json:"channel"`
+	Recipient    string `gorm:"type:varchar(255);not null" json:"recipient"`
+	Subject      string `gorm:"type:varchar(500)" json:"subject"`
+	Content      string `gorm:"type:text;not null" json:"content"`
+	Status       string `gorm:"type:varchar(50);not null;index" json:"status"`
+	ErrorMessage string `gorm:"type:text" json:"error_message,omitempty"`
+	TemplateID   string `gorm:"type:uuid" json:"template_id,omitempty"`
+	Metadata     string `gorm:"type:jsonb" json:"metadata,omitempty"`
+	// Message ID returned by the delivery provider (e.g. FCM)
+	ProviderMessageID string         `gorm:"type:varchar(255)" json:"provider_message_id,omitempty"`
+	CreatedAt         time.Time      `gorm:"autoCreateTime" json:"created_at"`
+	SentAt            *time.Time     `json:"sent_at,omitempty"`
+	DeletedAt         gorm.DeletedAt `gorm:"index" json:"-"`
 }
 
 // Template represents a notification template
@@ -0,0 +1,28 @@
+package models
+
+import "time"
+
+// NotificationPreference holds the channels a user agreed to receive.
+// Users without a row receive every channel.
+type NotificationPreference struct {
+	UserID       string    `gorm:"type:varchar(255);primaryKey" json:"user_id"`
+	EmailEnabled bool      `gorm:"not null;default:true" json:"email_enabled"`
+	SMSEnabled   bool      `gorm:"column:sms_enabled;not null;default:true" json:"sms_enabled"`
+	PushEnabled  bool      `gorm:"not null;default:true" json:"push_enabled"`
+	UpdatedAt    time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// DefaultNotificationPreference returns the preference used when a user has not set one
+func DefaultNotificationPreference(userID string) *NotificationPreference {
+	return &NotificationPreference{
+		UserID:       userID,
+		EmailEnabled: true,
+		SMSEnabled:   true,
+		PushEnabled:  true,
+	}
+}
+
+// TableName specifies the table name for NotificationPreference
+func (NotificationPreference) TableName() string {
+	return "notification_preferences"
+}
@@ -0,0 +1,139 @@
+package push
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// DefaultFCMEndpoint is the FCM HTTP v1 send endpoint; {project} is replaced by the project ID
+const DefaultFCMEndpoint = "https://fcm.googleapis.com/v1/projects/{project}/messages:send"
+
+// FCMProvider delivers push notifications through Firebase Cloud Messaging (HTTP v1 API)
+type FCMProvider struct {
+	endpoint string
+	tokens   *serviceAccountTokenSource
+	client   *http.Client
+}
+
+// NewFCMProvider creates an FCM provider from a service account key file.
+// projectID overrides the project of the service account when set.
+func NewFCMProvider(credentialsFile, projectID string, timeout time.Duration) (*FCMProvider, error) {
+	client := &http.Client{Timeout: timeout}
+
+	tokens, err := newServiceAccountTokenSource(credentialsFile, client)
+	if err != nil {
+		return nil, err
+	}
+
+	if projectID == "" {
+		projectID = tokens.account.ProjectID
+	}
+	if projectID == "" {
+		return nil, fmt.Errorf("fcm project id is required")
+	}
+
+	return &FCMProvider{
+		endpoint: strings.ReplaceAll(DefaultFCMEndpoint, "{project}", projectID),
+		tokens:   tokens,
+		client:   client,
+	}, nil
+}
+
+// Name returns the notification channel
+func (p *FCMProvider) Name() string {
+	return "FCM"
+}
+
+type fcmRequest struct {
+	Message fcmMessage `json:"message"`
+}
+
+type fcmMessage struct {
+	Token        string            `json:"token"`
+	Notification *fcmNotification  `json:"notification,omitempty"`
+	Data         map[string]string `json:"data,omitempty"`
+}
+
+type fcmNotification struct {
+	Title string `json:"title,omitempty"`
+	Body  string `json:"body,omitempty"`
+}
+
+type fcmResponse struct {
+	Name  string    `json:"name"` // projects/{project}/messages/{message_id}
+	Error *fcmError `json:"error"`
+}
+
+type fcmError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+	Status  string `json:"status"`
+	Details []struct {
+		ErrorCode string `json:"errorCode"`
+	} `json:"details"`
+}
+
+// Send delivers msg and returns the FCM message name
+func (p *FCMProvider) Send(ctx context.Context, msg Message) (string, error) {
+	accessToken, err := p.tokens.Token(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	payload := fcmRequest{Message: fcmMessage{Token: msg.Token, Data: msg.Data}}
+	if msg.Title != "" || msg.Body != "" {
+		payload.Message.Notification = &fcmNotification{Title: msg.Title, Body: msg.Body}
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode fcm message: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return "", fmt.Errorf("failed to build fcm request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to send fcm message: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var result fcmResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", fmt.Errorf("failed to decode fcm response (status %d): %w", resp.StatusCode, err)
+	}
+
+	if resp.StatusCode != http.StatusOK || result.Error != nil {
+		if result.Error == nil {
+			return "", fmt.Errorf("fcm returned status %d", resp.StatusCode)
+		}
+		if isInvalidTokenError(result.Error) {
+			return "", fmt.Errorf("%w: %s", ErrInvalidToken, result.Error.Message)
+		}
+		return "", fmt.Errorf("fcm error %s: %s", result.Error.Status, result.Error.Message)
+	}
+
+	return result.Name, nil
+}
+
+// isInvalidTokenError reports whether FCM rejected the device token itself
+func isInvalidTokenError(e *fcmError) bool {
+	for _, detail := range e.Details {
+		switch detail.ErrorCode {
+		case "UNREGISTERED", "SENDER_ID_MISMATCH":
+			return true
+		}
+	}
+	// A malformed token is reported as a generic INVALID_ARGUMENT
+	return e.Status == "INVALID_ARGUMENT" && strings.Contains(e.Message, "registration token")
+}
@@ -0,0 +1,128 @@
+package push
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func newTestFCMProvider(t *testing.T, handler http.HandlerFunc) *FCMProvider {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatalf("marshal key: %v", err)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{"access_token": "test-token", "expires_in": 3600})
+	})
+	mux.HandleFunc("/send", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer test-token" {
+			t.Errorf("unexpected authorization header %q", r.Header.Get("Authorization"))
+		}
+		handler(w, r)
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	credentials, _ := json.Marshal(serviceAccount{
+		ProjectID:   "test-project",
+		ClientEmail: "push@test-project.iam.gserviceaccount.com",
+		PrivateKey:  string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})),
+		TokenURI:    server.URL + "/token",
+	})
+	credentialsFile := filepath.Join(t.TempDir(), "credentials.json")
+	if err := os.WriteFile(credentialsFile, credentials, 0o600); err != nil {
+		t.Fatalf("write credentials: %v", err)
+	}
+
+	provider, err := NewFCMProvider(credentialsFile, "", 5*time.Second)
+	if err != nil {
+		t.Fatalf("NewFCMProvider: %v", err)
+	}
+	provider.endpoint = server.URL + "/send"
+	return provider
+}
+
+func TestFCMProviderSend(t *testing.T) {
+	provider := newTestFCMProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		var req fcmRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("decode request: %v", err)
+		}
+		if req.Message.Token != "device-1" || req.Message.Notification.Title != "Sale" || req.Message.Data["order_id"] != "42" {
+			t.Errorf("unexpected message %+v", req.Message)
+		}
+		w.Write([]byte(`{"name":"projects/test-project/messages/0:123"}`))
+	})
+
+	messageID, err := provider.Send(context.Background(), Message{
+		Token: "device-1",
+		Title: "Sale",
+		Body:  "50% off",
+		Data:  map[string]string{"order_id": "42"},
+	})
+	if err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	if messageID != "projects/test-project/messages/0:123" {
+		t.Errorf("messageID = %q", messageID)
+	}
+}
+
+func TestFCMProviderSendInvalidToken(t *testing.T) {
+	tests := []struct {
+		name     string
+		response string
+		invalid  bool
+	}{
+		{
+			name:     "unregistered",
+			response: `{"error":{"code":404,"message":"Requested entity was not found.","status":"NOT_FOUND","details":[{"errorCode":"UNREGISTERED"}]}}`,
+			invalid:  true,
+		},
+		{
+			name:     "malformed token",
+			response: `{"error":{"code":400,"message":"The registration token is not a valid FCM registration token","status":"INVALID_ARGUMENT"}}`,
+			invalid:  true,
+		},
+		{
+			name:     "quota exceeded",
+			response: `{"error":{"code":429,"message":"Quota exceeded","status":"RESOURCE_EXHAUSTED","details":[{"errorCode":"QUOTA_EXCEEDED"}]}}`,
+			invalid:  false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := newTestFCMProvider(t, func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(tt.response))
+			})
+
+			_, err := provider.Send(context.Background(), Message{Token: "device-1", Title: "Sale"})
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			if got := errors.Is(err, ErrInvalidToken); got != tt.invalid {
+				t.Errorf("errors.Is(err, ErrInvalidToken) = %v, want %v (err: %v)", got, tt.invalid, err)
+			}
+		})
+	}
+}
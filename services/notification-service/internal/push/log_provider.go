@@ -0,0 +1,36 @@
+package push
+
+import (
+	"context"
+	"log"
+
+	"github.com/google/uuid"
+)
+
+// LogProvider only logs push notifications, for local development
+type LogProvider struct{}
+
+// NewLogProvider creates a log-only push provider
+func NewLogProvider() *LogProvider {
+	return &LogProvider{}
+}
+
+// Name returns the notification channel
+func (p *LogProvider) Name() string {
+	return "LOG"
+}
+
+// Send logs msg and returns a generated message ID
+func (p *LogProvider) Send(ctx context.Context, msg Message) (string, error) {
+	messageID := "log-" + uuid.New().String()
+	log.Printf("[push] %s to %s: %q %q data=%v", messageID, maskToken(msg.Token), msg.Title, msg.Body, msg.Data)
+	return messageID, nil
+}
+
+// maskToken keeps device tokens out of logs
+func maskToken(token string) string {
+	if len(token) <= 8 {
+		return "****"
+	}
+	return token[:4] + "****" + token[len(token)-4:]
+}
@@ -0,0 +1,26 @@
+package push
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrInvalidToken is returned when the device token is unknown, expired or unregistered.
+// Such tokens should be marked stale and no longer used.
+var ErrInvalidToken = errors.New("invalid device token")
+
+// Message is a push notification for one device
+type Message struct {
+	Token string
+	Title string
+	Body  string
+	Data  map[string]string
+}
+
+// Provider delivers push notifications
+type Provider interface {
+	// Name is the notification channel recorded for sent messages
+	Name() string
+	// Send delivers msg and returns the provider's message ID
+	Send(ctx context.Context, msg Message) (string, error)
+}
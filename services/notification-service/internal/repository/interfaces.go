@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/datngth03/ecommerce-go-app/services/notification-service/internal/models"
 )
@@ -20,4 +21,24 @@ type NotificationRepository interface {
 	GetTemplateByName(ctx context.Context, name string) (*models.Template, error)
 	ListTemplates(ctx context.Context, notifType string) ([]*models.Template, error)
 	UpdateTemplate(ctx context.Context, template *models.Template) error
+
+	// Bulk email operations
+	CreateBulkJob(ctx context.Context, job *models.BulkEmailJob, recipients []*models.BulkEmailRecipient) error
+	GetBulkJob(ctx context.Context, jobID string) (*models.BulkEmailJob, error)
+	UpdateBulkJob(ctx context.Context, job *models.BulkEmailJob) error
+	ListUnfinishedBulkJobs(ctx context.Context) ([]*models.BulkEmailJob, error)
+	ListBulkRecipients(ctx context.Context, jobID, status string, limit, offset int) ([]*models.BulkEmailRecipient, error)
+	UpdateBulkRecipient(ctx context.Context, recipient *models.BulkEmailRecipient) error
+	GetBulkJobProgress(ctx context.Context, jobID string) (*models.BulkJobProgress, error)
+
+	// Device token operations
+	RegisterDeviceToken(ctx context.Context, deviceToken *models.DeviceToken) error
+	UnregisterDeviceToken(ctx context.Context, userID, token string) (bool, error)
+	ListActiveDeviceTokens(ctx context.Context, userID string) ([]*models.DeviceToken, error)
+	MarkDeviceTokenStale(ctx context.Context, token, reason string) error
+	DeleteStaleDeviceTokens(ctx context.Context, staleBefore time.Time) (int64, error)
+
+	// Preference operations
+	GetPreferences(ctx context.Context, userIDs []string) (map[string]*models.NotificationPreference, error)
+	UpsertPreference(ctx context.Context, preference *models.NotificationPreference) error
 }
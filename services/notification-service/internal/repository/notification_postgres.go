@@ -2,9 +2,11 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/datngth03/ecommerce-go-app/services/notification-service/internal/models"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type notificationRepository struct {
@@ -112,3 +114,184 @@ func (r *notificationRepository) ListTemplates(ctx context.Context, notifType st
 func (r *notificationRepository) UpdateTemplate(ctx context.Context, template *models.Template) error {
 	return r.db.WithContext(ctx).Save(template).Error
 }
+
+// CreateBulkJob creates a bulk email job together with its recipients
+func (r *notificationRepository) CreateBulkJob(ctx context.Context, job *models.BulkEmailJob, recipients []*models.BulkEmailRecipient) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(job).Error; err != nil {
+			return err
+		}
+		for _, recipient := range recipients {
+			recipient.JobID = job.ID
+		}
+		return tx.CreateInBatches(recipients, 500).Error
+	})
+}
+
+// GetBulkJob retrieves a bulk email job by ID
+func (r *notificationRepository) GetBulkJob(ctx context.Context, jobID string) (*models.BulkEmailJob, error) {
+	var job models.BulkEmailJob
+	err := r.db.WithContext(ctx).Where("id = ?", jobID).First(&job).Error
+	if err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+// UpdateBulkJob updates a bulk email job
+func (r *notificationRepository) UpdateBulkJob(ctx context.Context, job *models.BulkEmailJob) error {
+	return r.db.WithContext(ctx).Save(job).Error
+}
+
+// ListUnfinishedBulkJobs retrieves jobs that were not completed, oldest first
+func (r *notificationRepository) ListUnfinishedBulkJobs(ctx context.Context) ([]*models.BulkEmailJob, error) {
+	var jobs []*models.BulkEmailJob
+	err := r.db.WithContext(ctx).
+		Where("status IN ?", []string{models.BulkJobStatusPending, models.BulkJobStatusRunning}).
+		Order("created_at ASC").
+		Find(&jobs).Error
+	if err != nil {
+		return nil, err
+	}
+	return jobs, nil
+}
+
+// ListBulkRecipients retrieves recipients of a job, optionally filtered by status
+func (r *notificationRepository) ListBulkRecipients(ctx context.Context, jobID, status string, limit, offset int) ([]*models.BulkEmailRecipient, error) {
+	var recipients []*models.BulkEmailRecipient
+	query := r.db.WithContext(ctx).Where("job_id = ?", jobID)
+
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	if limit > 0 {
+		query = query.Limit(limit).Offset(offset)
+	}
+
+	err := query.Order("id").Find(&recipients).Error
+	if err != nil {
+		return nil, err
+	}
+	return recipients, nil
+}
+
+// UpdateBulkRecipient updates the result for one recipient
+func (r *notificationRepository) UpdateBulkRecipient(ctx context.Context, recipient *models.BulkEmailRecipient) error {
+	return r.db.WithContext(ctx).Save(recipient).Error
+}
+
+// GetBulkJobProgress counts recipients of a job per status
+func (r *notificationRepository) GetBulkJobProgress(ctx context.Context, jobID string) (*models.BulkJobProgress, error) {
+	var rows []struct {
+		Status string
+		Count  int
+	}
+	err := r.db.WithContext(ctx).Model(&models.BulkEmailRecipient{}).
+		Select("status, COUNT(*) AS count").
+		Where("job_id = ?", jobID).
+		Group("status").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	progress := &models.BulkJobProgress{}
+	for _, row := range rows {
+		switch row.Status {
+		case models.BulkRecipientStatusPending:
+			progress.Pending = row.Count
+		case models.BulkRecipientStatusSent:
+			progress.Sent = row.Count
+		case models.BulkRecipientStatusFailed:
+			progress.Failed = row.Count
+		case models.BulkRecipientStatusSkipped:
+			progress.Skipped = row.Count
+		}
+	}
+	return progress, nil
+}
+
+// RegisterDeviceToken stores a device token for a user.
+// Registering an existing token moves it to the user and clears its stale flag.
+func (r *notificationRepository) RegisterDeviceToken(ctx context.Context, deviceToken *models.DeviceToken) error {
+	deviceToken.IsStale = false
+	deviceToken.StaleReason = ""
+	deviceToken.StaleAt = nil
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "token"}},
+		DoUpdates: clause.AssignmentColumns([]string{"user_id", "platform", "is_stale", "stale_reason", "stale_at", "updated_at"}),
+	}).Create(deviceToken).Error
+}
+
+// UnregisterDeviceToken deletes a user's device token and reports whether it existed
+func (r *notificationRepository) UnregisterDeviceToken(ctx context.Context, userID, token string) (bool, error) {
+	result := r.db.WithContext(ctx).Where("token = ? AND user_id = ?", token, userID).Delete(&models.DeviceToken{})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// ListActiveDeviceTokens retrieves a user's device tokens that are not stale
+func (r *notificationRepository) ListActiveDeviceTokens(ctx context.Context, userID string) ([]*models.DeviceToken, error) {
+	var tokens []*models.DeviceToken
+	err := r.db.WithContext(ctx).
+		Where("user_id = ? AND is_stale = ?", userID, false).
+		Order("updated_at DESC").
+		Find(&tokens).Error
+	if err != nil {
+		return nil, err
+	}
+	return tokens, nil
+}
+
+// MarkDeviceTokenStale flags a token the push provider rejected, creating it if unknown
+func (r *notificationRepository) MarkDeviceTokenStale(ctx context.Context, token, reason string) error {
+	now := time.Now()
+	deviceToken := &models.DeviceToken{
+		Token:       token,
+		IsStale:     true,
+		StaleReason: reason,
+		StaleAt:     &now,
+	}
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "token"}},
+		DoUpdates: clause.AssignmentColumns([]string{"is_stale", "stale_reason", "stale_at", "updated_at"}),
+	}).Create(deviceToken).Error
+}
+
+// DeleteStaleDeviceTokens prunes tokens marked stale before staleBefore
+func (r *notificationRepository) DeleteStaleDeviceTokens(ctx context.Context, staleBefore time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).
+		Where("is_stale = ? AND stale_at < ?", true, staleBefore).
+		Delete(&models.DeviceToken{})
+	return result.RowsAffected, result.Error
+}
+
+// GetPreferences retrieves the preferences of the given users, keyed by user ID.
+// Users without stored preferences are absent from the result.
+func (r *notificationRepository) GetPreferences(ctx context.Context, userIDs []string) (map[string]*models.NotificationPreference, error) {
+	result := make(map[string]*models.NotificationPreference, len(userIDs))
+	if len(userIDs) == 0 {
+		return result, nil
+	}
+
+	var preferences []*models.NotificationPreference
+	err := r.db.WithContext(ctx).Where("user_id IN ?", userIDs).Find(&preferences).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for _, preference := range preferences {
+		result[preference.UserID] = preference
+	}
+	return result, nil
+}
+
+// UpsertPreference creates or replaces a user's preferences
+func (r *notificationRepository) UpsertPreference(ctx context.Context, preference *models.NotificationPreference) error {
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"email_enabled", "sms_enabled", "push_enabled", "updated_at"}),
+	}).Create(preference).Error
+}
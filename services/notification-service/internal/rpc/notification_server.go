@@ -2,11 +2,17 @@ package rpc
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"strings"
 	"time"
 
 	pb "github.com/datngth03/ecommerce-go-app/proto/notification_service"
 	"github.com/datngth03/ecommerce-go-app/services/notification-service/internal/metrics"
+	"github.com/datngth03/ecommerce-go-app/services/notification-service/internal/models"
+	"github.com/datngth03/ecommerce-go-app/services/notification-service/internal/push"
 	"github.com/datngth03/ecommerce-go-app/services/notification-service/internal/service"
+	"github.com/datngth03/ecommerce-go-app/shared/pkg/validator"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
@@ -14,13 +20,15 @@ import (
 // NotificationServer implements the gRPC notification service
 type NotificationServer struct {
 	pb.UnimplementedNotificationServiceServer
-	service *service.NotificationService
+	service     *service.NotificationService
+	bulkService *service.BulkEmailService
 }
 
 // NewNotificationServer creates a new gRPC notification server
-func NewNotificationServer(svc *service.NotificationService) *NotificationServer {
+func NewNotificationServer(svc *service.NotificationService, bulkSvc *service.BulkEmailService) *NotificationServer {
 	return &NotificationServer{
-		service: svc,
+		service:     svc,
+		bulkService: bulkSvc,
 	}
 }
 
@@ -126,8 +134,156 @@ func (s *NotificationServer) SendSMS(ctx context.Context, req *pb.SendSMSRequest
 	}, nil
 }
 
+// SendPushNotification sends a push notification to one device,
+// or to all registered devices of the user when no device token is given
+func (s *NotificationServer) SendPushNotification(ctx context.Context, req *pb.SendPushNotificationRequest) (*pb.SendPushNotificationResponse, error) {
+	if req.DeviceToken == "" && req.UserId != "" {
+		return s.sendPushToUser(ctx, req)
+	}
+
+	start := time.Now()
+
+	notification, err := s.service.SendPushNotification(
+		ctx,
+		req.UserId,
+		req.DeviceToken,
+		req.Title,
+		req.Body,
+		req.Data,
+	)
+
+	duration := time.Since(start)
+	grpcStatus := "success"
+	notifStatus := "sent"
+
+	if err != nil {
+		if notification == nil && strings.Contains(err.Error(), "required") {
+			metrics.RecordGRPCRequest("SendPushNotification", "error", duration)
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+
+		grpcStatus = "error"
+		notifStatus = "failed"
+		metrics.RecordGRPCRequest("SendPushNotification", grpcStatus, duration)
+		metrics.RecordNotificationSent("push", notifStatus, duration)
+		metrics.RecordPushNotificationSent(notifStatus)
+		return &pb.SendPushNotificationResponse{
+			Success:      false,
+			Message:      err.Error(),
+			TokenInvalid: errors.Is(err, push.ErrInvalidToken),
+		}, nil
+	}
+
+	metrics.RecordGRPCRequest("SendPushNotification", grpcStatus, duration)
+	metrics.RecordNotificationSent("push", notification.Status, duration)
+	metrics.RecordPushNotificationSent(notification.Status)
+
+	return &pb.SendPushNotificationResponse{
+		Notification: pushNotificationToProto(notification),
+		Success:      true,
+		Message:      "Push notification sent successfully",
+	}, nil
+}
+
+// sendPushToUser fans a push notification out to all registered devices of the user
+func (s *NotificationServer) sendPushToUser(ctx context.Context, req *pb.SendPushNotificationRequest) (*pb.SendPushNotificationResponse, error) {
+	start := time.Now()
+
+	notifications, err := s.service.SendPushToUser(ctx, req.UserId, req.Title, req.Body, req.Data)
+	duration := time.Since(start)
+	if err != nil {
+		metrics.RecordGRPCRequest("SendPushNotification", "error", duration)
+		switch {
+		case strings.Contains(err.Error(), "required"):
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		case strings.Contains(err.Error(), "no registered devices"):
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	resp := &pb.SendPushNotificationResponse{}
+	for _, notification := range notifications {
+		notifStatus := "sent"
+		if notification.Status == models.NotificationStatusFailed {
+			notifStatus = "failed"
+			resp.Failed++
+		} else {
+			resp.Sent++
+		}
+		metrics.RecordNotificationSent("push", notifStatus, duration)
+		metrics.RecordPushNotificationSent(notifStatus)
+		resp.Notifications = append(resp.Notifications, pushNotificationToProto(notification))
+	}
+
+	resp.Success = resp.Sent > 0
+	resp.Message = fmt.Sprintf("Push notification sent to %d of %d devices", resp.Sent, len(notifications))
+	metrics.RecordGRPCRequest("SendPushNotification", "success", duration)
+
+	return resp, nil
+}
+
+// RegisterDeviceToken registers a push token for a user's device
+func (s *NotificationServer) RegisterDeviceToken(ctx context.Context, req *pb.RegisterDeviceTokenRequest) (*pb.RegisterDeviceTokenResponse, error) {
+	device, err := s.service.RegisterDeviceToken(ctx, req.UserId, req.DeviceToken, req.Platform)
+	if err != nil {
+		if strings.Contains(err.Error(), "required") || strings.Contains(err.Error(), "invalid platform") {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &pb.RegisterDeviceTokenResponse{
+		Device: &pb.DeviceToken{
+			Token:     device.Token,
+			UserId:    device.UserID,
+			Platform:  device.Platform,
+			CreatedAt: device.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+			UpdatedAt: device.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		},
+	}, nil
+}
+
+// UnregisterDeviceToken removes a push token of a user's device
+func (s *NotificationServer) UnregisterDeviceToken(ctx context.Context, req *pb.UnregisterDeviceTokenRequest) (*pb.UnregisterDeviceTokenResponse, error) {
+	err := s.service.UnregisterDeviceToken(ctx, req.UserId, req.DeviceToken)
+	if err != nil {
+		switch {
+		case strings.Contains(err.Error(), "required"):
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		case strings.Contains(err.Error(), "not found"):
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &pb.UnregisterDeviceTokenResponse{Success: true}, nil
+}
+
+func pushNotificationToProto(notification *models.Notification) *pb.Notification {
+	return &pb.Notification{
+		Id:                notification.ID,
+		UserId:            notification.UserID,
+		Type:              notification.Type,
+		Channel:           notification.Channel,
+		Recipient:         notification.Recipient,
+		Subject:           notification.Subject,
+		Content:           notification.Content,
+		Status:            notification.Status,
+		ErrorMessage:      notification.ErrorMessage,
+		Metadata:          notification.Metadata,
+		ProviderMessageId: notification.ProviderMessageID,
+		CreatedAt:         notification.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		SentAt:            formatOptionalTime(notification.SentAt),
+	}
+}
+
 // GetNotification retrieves a notification
 func (s *NotificationServer) GetNotification(ctx context.Context, req *pb.GetNotificationRequest) (*pb.GetNotificationResponse, error) {
+	if err := validator.ValidateUUIDArg(req.NotificationId, "notification_id"); err != nil {
+		return nil, err
+	}
+
 	notification, err := s.service.GetNotification(ctx, req.NotificationId)
 	if err != nil {
 		return nil, status.Error(codes.NotFound, err.Error())
@@ -140,19 +296,20 @@ func (s *NotificationServer) GetNotification(ctx context.Context, req *pb.GetNot
 
 	return &pb.GetNotificationResponse{
 		Notification: &pb.Notification{
-			Id:           notification.ID,
-			UserId:       notification.UserID,
-			Type:         notification.Type,
-			Channel:      notification.Channel,
-			Recipient:    notification.Recipient,
-			Subject:      notification.Subject,
-			Content:      notification.Content,
-			Status:       notification.Status,
-			ErrorMessage: notification.ErrorMessage,
-			TemplateId:   notification.TemplateID,
-			Metadata:     notification.Metadata,
-			CreatedAt:    notification.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
-			SentAt:       sentAt,
+			Id:                notification.ID,
+			UserId:            notification.UserID,
+			Type:              notification.Type,
+			Channel:           notification.Channel,
+			Recipient:         notification.Recipient,
+			Subject:           notification.Subject,
+			Content:           notification.Content,
+			Status:            notification.Status,
+			ErrorMessage:      notification.ErrorMessage,
+			TemplateId:        notification.TemplateID,
+			Metadata:          notification.Metadata,
+			CreatedAt:         notification.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+			SentAt:            sentAt,
+			ProviderMessageId: notification.ProviderMessageID,
 		},
 	}, nil
 }
@@ -189,3 +346,144 @@ func (s *NotificationServer) GetNotificationHistory(ctx context.Context, req *pb
 		Total:         int32(total),
 	}, nil
 }
+
+// SendBulkEmail queues an email to many recipients and returns the job to poll
+func (s *NotificationServer) SendBulkEmail(ctx context.Context, req *pb.SendBulkEmailRequest) (*pb.SendBulkEmailResponse, error) {
+	start := time.Now()
+
+	recipients := make([]service.BulkRecipient, len(req.Recipients))
+	for i, r := range req.Recipients {
+		recipients[i] = service.BulkRecipient{
+			UserID:    r.UserId,
+			Email:     r.Email,
+			Variables: r.Variables,
+		}
+	}
+
+	job, err := s.bulkService.SendBulkEmail(ctx, service.BulkEmailRequest{
+		Recipients: recipients,
+		Subject:    req.Subject,
+		Body:       req.Body,
+		TemplateID: req.TemplateId,
+		Variables:  req.Variables,
+	})
+	if err != nil {
+		metrics.RecordGRPCRequest("SendBulkEmail", "error", time.Since(start))
+		switch {
+		case strings.Contains(err.Error(), "invalid bulk email"):
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		case strings.Contains(err.Error(), "template not found"):
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	metrics.RecordGRPCRequest("SendBulkEmail", "success", time.Since(start))
+
+	return &pb.SendBulkEmailResponse{
+		Job: bulkJobToProto(job, &models.BulkJobProgress{Pending: job.Total}),
+	}, nil
+}
+
+// GetBulkJobStatus returns the progress and per-recipient results of a bulk email job
+func (s *NotificationServer) GetBulkJobStatus(ctx context.Context, req *pb.GetBulkJobStatusRequest) (*pb.GetBulkJobStatusResponse, error) {
+	if err := validator.ValidateUUIDArg(req.JobId, "job_id"); err != nil {
+		return nil, err
+	}
+
+	job, progress, results, err := s.bulkService.GetBulkJobStatus(ctx, req.JobId, req.RecipientStatus, int(req.Limit), int(req.Offset))
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	pbResults := make([]*pb.BulkEmailResult, len(results))
+	for i, r := range results {
+		pbResults[i] = &pb.BulkEmailResult{
+			UserId:       r.UserID,
+			Recipient:    r.Recipient,
+			Status:       r.Status,
+			ErrorMessage: r.ErrorMessage,
+			SentAt:       formatOptionalTime(r.SentAt),
+		}
+	}
+
+	return &pb.GetBulkJobStatusResponse{
+		Job:     bulkJobToProto(job, progress),
+		Results: pbResults,
+	}, nil
+}
+
+// GetNotificationPreferences retrieves a user's notification preferences
+func (s *NotificationServer) GetNotificationPreferences(ctx context.Context, req *pb.GetNotificationPreferencesRequest) (*pb.NotificationPreferencesResponse, error) {
+	preference, err := s.service.GetPreferences(ctx, req.UserId)
+	if err != nil {
+		if strings.Contains(err.Error(), "required") {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &pb.NotificationPreferencesResponse{
+		Preferences: preferenceToProto(preference),
+	}, nil
+}
+
+// UpdateNotificationPreferences stores a user's notification preferences
+func (s *NotificationServer) UpdateNotificationPreferences(ctx context.Context, req *pb.UpdateNotificationPreferencesRequest) (*pb.NotificationPreferencesResponse, error) {
+	preference, err := s.service.UpdatePreferences(ctx, &models.NotificationPreference{
+		UserID:       req.UserId,
+		EmailEnabled: req.EmailEnabled,
+		SMSEnabled:   req.SmsEnabled,
+		PushEnabled:  req.PushEnabled,
+	})
+	if err != nil {
+		if strings.Contains(err.Error(), "required") {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &pb.NotificationPreferencesResponse{
+		Preferences: preferenceToProto(preference),
+	}, nil
+}
+
+func bulkJobToProto(job *models.BulkEmailJob, progress *models.BulkJobProgress) *pb.BulkEmailJob {
+	return &pb.BulkEmailJob{
+		Id:          job.ID,
+		Status:      job.Status,
+		Total:       int32(job.Total),
+		Pending:     int32(progress.Pending),
+		Sent:        int32(progress.Sent),
+		Failed:      int32(progress.Failed),
+		Skipped:     int32(progress.Skipped),
+		CreatedAt:   job.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		StartedAt:   formatOptionalTime(job.StartedAt),
+		CompletedAt: formatOptionalTime(job.CompletedAt),
+	}
+}
+
+func preferenceToProto(preference *models.NotificationPreference) *pb.NotificationPreferences {
+	updatedAt := ""
+	if !preference.UpdatedAt.IsZero() {
+		updatedAt = preference.UpdatedAt.Format("2006-01-02T15:04:05Z07:00")
+	}
+
+	return &pb.NotificationPreferences{
+		UserId:       preference.UserID,
+		EmailEnabled: preference.EmailEnabled,
+		SmsEnabled:   preference.SMSEnabled,
+		PushEnabled:  preference.PushEnabled,
+		UpdatedAt:    updatedAt,
+	}
+}
+
+func formatOptionalTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format("2006-01-02T15:04:05Z07:00")
+}
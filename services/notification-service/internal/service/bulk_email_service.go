@@ -0,0 +1,338 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/mail"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+	"gorm.io/gorm"
+
+	"github.com/datngth03/ecommerce-go-app/services/notification-service/internal/email"
+	"github.com/datngth03/ecommerce-go-app/services/notification-service/internal/metrics"
+	"github.com/datngth03/ecommerce-go-app/services/notification-service/internal/models"
+	"github.com/datngth03/ecommerce-go-app/services/notification-service/internal/repository"
+)
+
+const (
+	bulkRecipientBatchSize = 500              // Recipients loaded per batch while processing a job
+	bulkJobPollInterval    = 30 * time.Second // How often unfinished jobs are picked up without a new submission
+)
+
+// BulkEmailOptions configures bulk email processing
+type BulkEmailOptions struct {
+	Workers       int     // Concurrent senders
+	RatePerSecond float64 // Max emails per second across all workers
+	MaxRecipients int     // Max recipients per job
+}
+
+// BulkRecipient is one recipient of a bulk email
+type BulkRecipient struct {
+	UserID    string
+	Email     string
+	Variables map[string]string
+}
+
+// BulkEmailRequest describes a bulk email; either TemplateID or Body is required
+type BulkEmailRequest struct {
+	Recipients []BulkRecipient
+	Subject    string
+	Body       string
+	TemplateID string
+	Variables  map[string]string
+}
+
+// BulkEmailService sends bulk emails in the background through a rate-limited worker pool.
+// Jobs and per-recipient results are stored, so unfinished jobs resume after a restart.
+type BulkEmailService struct {
+	repo          repository.NotificationRepository
+	emailService  *email.EmailService
+	limiter       *rate.Limiter
+	workers       int
+	maxRecipients int
+	wake          chan struct{}
+}
+
+// NewBulkEmailService creates a new bulk email service
+func NewBulkEmailService(repo repository.NotificationRepository, emailService *email.EmailService, opts BulkEmailOptions) *BulkEmailService {
+	return &BulkEmailService{
+		repo:          repo,
+		emailService:  emailService,
+		limiter:       rate.NewLimiter(rate.Limit(opts.RatePerSecond), 1),
+		workers:       opts.Workers,
+		maxRecipients: opts.MaxRecipients,
+		wake:          make(chan struct{}, 1),
+	}
+}
+
+// SendBulkEmail validates the request and queues a bulk email job
+func (s *BulkEmailService) SendBulkEmail(ctx context.Context, req BulkEmailRequest) (*models.BulkEmailJob, error) {
+	if len(req.Recipients) == 0 {
+		return nil, fmt.Errorf("invalid bulk email: at least one recipient is required")
+	}
+	if len(req.Recipients) > s.maxRecipients {
+		return nil, fmt.Errorf("invalid bulk email: at most %d recipients are allowed", s.maxRecipients)
+	}
+
+	subject, body := req.Subject, req.Body
+	if req.TemplateID != "" {
+		template, err := s.repo.GetTemplate(ctx, req.TemplateID)
+		if err != nil {
+			return nil, fmt.Errorf("template not found: %w", err)
+		}
+		// Rendered per recipient when sending
+		subject, body = template.Subject, template.Body
+	}
+	if strings.TrimSpace(subject) == "" || strings.TrimSpace(body) == "" {
+		return nil, fmt.Errorf("invalid bulk email: subject and body or template_id are required")
+	}
+
+	recipients := make([]*models.BulkEmailRecipient, 0, len(req.Recipients))
+	seen := make(map[string]bool, len(req.Recipients))
+	for _, r := range req.Recipients {
+		address, err := mail.ParseAddress(strings.TrimSpace(r.Email))
+		if err != nil {
+			return nil, fmt.Errorf("invalid bulk email: invalid recipient %q", r.Email)
+		}
+
+		key := strings.ToLower(address.Address)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		variablesJSON, _ := json.Marshal(r.Variables)
+		recipients = append(recipients, &models.BulkEmailRecipient{
+			UserID:    r.UserID,
+			Recipient: address.Address,
+			Variables: string(variablesJSON),
+			Status:    models.BulkRecipientStatusPending,
+		})
+	}
+
+	variablesJSON, _ := json.Marshal(req.Variables)
+	job := &models.BulkEmailJob{
+		Subject:   subject,
+		Body:      body,
+		Variables: string(variablesJSON),
+		Status:    models.BulkJobStatusPending,
+		Total:     len(recipients),
+	}
+	if req.TemplateID != "" {
+		job.TemplateID = &req.TemplateID
+	}
+
+	if err := s.repo.CreateBulkJob(ctx, job, recipients); err != nil {
+		return nil, fmt.Errorf("failed to create bulk email job: %w", err)
+	}
+
+	// Wake the processor without blocking; a pending wake-up already covers this job
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+
+	return job, nil
+}
+
+// GetBulkJobStatus returns a job, its progress and a page of per-recipient results
+func (s *BulkEmailService) GetBulkJobStatus(ctx context.Context, jobID, recipientStatus string, limit, offset int) (*models.BulkEmailJob, *models.BulkJobProgress, []*models.BulkEmailRecipient, error) {
+	job, err := s.repo.GetBulkJob(ctx, jobID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, nil, fmt.Errorf("bulk email job not found")
+		}
+		return nil, nil, nil, err
+	}
+
+	progress, err := s.repo.GetBulkJobProgress(ctx, jobID)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to get bulk email progress: %w", err)
+	}
+
+	if limit <= 0 {
+		limit = 10
+	}
+	if limit > 100 {
+		limit = 100
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	results, err := s.repo.ListBulkRecipients(ctx, jobID, strings.ToUpper(recipientStatus), limit, offset)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to get bulk email results: %w", err)
+	}
+
+	return job, progress, results, nil
+}
+
+// Run processes queued and unfinished jobs one at a time until ctx is cancelled
+func (s *BulkEmailService) Run(ctx context.Context) {
+	ticker := time.NewTicker(bulkJobPollInterval)
+	defer ticker.Stop()
+
+	for {
+		jobs, err := s.repo.ListUnfinishedBulkJobs(ctx)
+		if err != nil {
+			log.Printf("Failed to list bulk email jobs: %v", err)
+		}
+		for _, job := range jobs {
+			if ctx.Err() != nil {
+				return
+			}
+			if err := s.processJob(ctx, job); err != nil {
+				log.Printf("Bulk email job %s interrupted: %v", job.ID, err)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.wake:
+		case <-ticker.C:
+		}
+	}
+}
+
+// processJob sends a job to all of its pending recipients
+func (s *BulkEmailService) processJob(ctx context.Context, job *models.BulkEmailJob) error {
+	if job.Status == models.BulkJobStatusPending {
+		now := time.Now()
+		job.Status = models.BulkJobStatusRunning
+		job.StartedAt = &now
+		if err := s.repo.UpdateBulkJob(ctx, job); err != nil {
+			return fmt.Errorf("failed to start job: %w", err)
+		}
+	}
+
+	var sharedVariables map[string]string
+	json.Unmarshal([]byte(job.Variables), &sharedVariables)
+
+	for {
+		// Sent recipients leave the PENDING filter, so the first page is always the next batch
+		recipients, err := s.repo.ListBulkRecipients(ctx, job.ID, models.BulkRecipientStatusPending, bulkRecipientBatchSize, 0)
+		if err != nil {
+			return fmt.Errorf("failed to load recipients: %w", err)
+		}
+		if len(recipients) == 0 {
+			break
+		}
+
+		if err := s.processBatch(ctx, job, sharedVariables, recipients); err != nil {
+			return err
+		}
+	}
+
+	now := time.Now()
+	job.Status = models.BulkJobStatusCompleted
+	job.CompletedAt = &now
+	if err := s.repo.UpdateBulkJob(ctx, job); err != nil {
+		return fmt.Errorf("failed to complete job: %w", err)
+	}
+
+	log.Printf("Bulk email job %s completed (%d recipients)", job.ID, job.Total)
+	return nil
+}
+
+// processBatch sends one batch of recipients through the worker pool
+func (s *BulkEmailService) processBatch(ctx context.Context, job *models.BulkEmailJob, sharedVariables map[string]string, recipients []*models.BulkEmailRecipient) error {
+	preferences, err := s.repo.GetPreferences(ctx, recipientUserIDs(recipients))
+	if err != nil {
+		return fmt.Errorf("failed to load notification preferences: %w", err)
+	}
+
+	queue := make(chan *models.BulkEmailRecipient)
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var firstErr error
+
+	for i := 0; i < s.workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for recipient := range queue {
+				if err := s.sendToRecipient(ctx, job, sharedVariables, recipient, preferences[recipient.UserID]); err != nil {
+					mu.Lock()
+					if firstErr == nil {
+						firstErr = err
+					}
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+
+	for _, recipient := range recipients {
+		if ctx.Err() != nil {
+			break
+		}
+		queue <- recipient
+	}
+	close(queue)
+	wg.Wait()
+
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	return firstErr
+}
+
+// sendToRecipient sends the job to one recipient and records the result.
+// Only failing to record the result is returned as an error; send failures are stored per recipient.
+func (s *BulkEmailService) sendToRecipient(ctx context.Context, job *models.BulkEmailJob, sharedVariables map[string]string, recipient *models.BulkEmailRecipient, preference *models.NotificationPreference) error {
+	if preference != nil && !preference.EmailEnabled {
+		recipient.Status = models.BulkRecipientStatusSkipped
+		recipient.ErrorMessage = "recipient opted out of email"
+		return s.repo.UpdateBulkRecipient(ctx, recipient)
+	}
+
+	if err := s.limiter.Wait(ctx); err != nil {
+		// Shutting down, the recipient stays pending
+		return nil
+	}
+
+	variables := make(map[string]string, len(sharedVariables))
+	for key, value := range sharedVariables {
+		variables[key] = value
+	}
+	var recipientVariables map[string]string
+	json.Unmarshal([]byte(recipient.Variables), &recipientVariables)
+	for key, value := range recipientVariables {
+		variables[key] = value
+	}
+
+	subject := s.emailService.RenderTemplate(job.Subject, variables)
+	body := s.emailService.RenderTemplate(job.Body, variables)
+
+	if err := s.emailService.SendEmail(recipient.Recipient, subject, body); err != nil {
+		recipient.Status = models.BulkRecipientStatusFailed
+		recipient.ErrorMessage = err.Error()
+		metrics.RecordEmailSent("failed")
+	} else {
+		now := time.Now()
+		recipient.Status = models.BulkRecipientStatusSent
+		recipient.SentAt = &now
+		metrics.RecordEmailSent("sent")
+	}
+
+	// The email is out; record it even if shutdown started meanwhile so it is not sent twice
+	return s.repo.UpdateBulkRecipient(context.WithoutCancel(ctx), recipient)
+}
+
+func recipientUserIDs(recipients []*models.BulkEmailRecipient) []string {
+	userIDs := make([]string, 0, len(recipients))
+	for _, recipient := range recipients {
+		if recipient.UserID != "" {
+			userIDs = append(userIDs, recipient.UserID)
+		}
+	}
+	return userIDs
+}
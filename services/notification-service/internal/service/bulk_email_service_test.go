@@ -0,0 +1,61 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/datngth03/ecommerce-go-app/services/notification-service/internal/models"
+	"github.com/datngth03/ecommerce-go-app/services/notification-service/internal/repository"
+)
+
+// bulkJobRepo records the jobs created by SendBulkEmail
+type bulkJobRepo struct {
+	repository.NotificationRepository
+	templates map[string]*models.Template
+	jobs      []*models.BulkEmailJob
+}
+
+func (r *bulkJobRepo) GetTemplate(ctx context.Context, templateID string) (*models.Template, error) {
+	return r.templates[templateID], nil
+}
+
+func (r *bulkJobRepo) CreateBulkJob(ctx context.Context, job *models.BulkEmailJob, recipients []*models.BulkEmailRecipient) error {
+	r.jobs = append(r.jobs, job)
+	return nil
+}
+
+func TestSendBulkEmailTemplateID(t *testing.T) {
+	templateID := "6f1c2a52-7a7e-4d8b-9a53-0c5b1e2f3d4a"
+	repo := &bulkJobRepo{templates: map[string]*models.Template{
+		templateID: {ID: templateID, Subject: "Hi {{name}}", Body: "Our sale starts today"},
+	}}
+	svc := NewBulkEmailService(repo, nil, BulkEmailOptions{Workers: 1, RatePerSecond: 1, MaxRecipients: 10})
+	recipients := []BulkRecipient{{Email: "ann@example.com"}}
+
+	// Without a template the column must be NULL: '' is not a valid uuid
+	job, err := svc.SendBulkEmail(context.Background(), BulkEmailRequest{
+		Recipients: recipients,
+		Subject:    "Sale",
+		Body:       "Our sale starts today",
+	})
+	if err != nil {
+		t.Fatalf("bulk email without a template: %v", err)
+	}
+	if job.TemplateID != nil {
+		t.Errorf("template_id = %q, want nil", *job.TemplateID)
+	}
+
+	job, err = svc.SendBulkEmail(context.Background(), BulkEmailRequest{
+		Recipients: recipients,
+		TemplateID: templateID,
+	})
+	if err != nil {
+		t.Fatalf("bulk email with a template: %v", err)
+	}
+	if job.TemplateID == nil || *job.TemplateID != templateID {
+		t.Errorf("template_id = %v, want %s", job.TemplateID, templateID)
+	}
+	if len(repo.jobs) != 2 {
+		t.Errorf("created %d jobs, want 2", len(repo.jobs))
+	}
+}
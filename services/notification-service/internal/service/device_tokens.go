@@ -0,0 +1,112 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/datngth03/ecommerce-go-app/services/notification-service/internal/models"
+)
+
+const (
+	staleDeviceTokenRetention = 7 * 24 * time.Hour // Stale tokens are kept this long before being pruned
+	deviceTokenPruneInterval  = 6 * time.Hour
+)
+
+// RegisterDeviceToken stores a push token for one of the user's devices
+func (s *NotificationService) RegisterDeviceToken(ctx context.Context, userID, token, platform string) (*models.DeviceToken, error) {
+	token = strings.TrimSpace(token)
+	platform = strings.ToLower(strings.TrimSpace(platform))
+
+	if userID == "" {
+		return nil, fmt.Errorf("user_id is required")
+	}
+	if token == "" {
+		return nil, fmt.Errorf("device_token is required")
+	}
+	if !models.IsValidDevicePlatform(platform) {
+		return nil, fmt.Errorf("invalid platform: must be one of ios, android, web")
+	}
+
+	deviceToken := &models.DeviceToken{
+		Token:    token,
+		UserID:   userID,
+		Platform: platform,
+	}
+	if err := s.repo.RegisterDeviceToken(ctx, deviceToken); err != nil {
+		return nil, fmt.Errorf("failed to register device token: %w", err)
+	}
+
+	return deviceToken, nil
+}
+
+// UnregisterDeviceToken removes a push token, e.g. on logout
+func (s *NotificationService) UnregisterDeviceToken(ctx context.Context, userID, token string) error {
+	if userID == "" {
+		return fmt.Errorf("user_id is required")
+	}
+	if strings.TrimSpace(token) == "" {
+		return fmt.Errorf("device_token is required")
+	}
+
+	deleted, err := s.repo.UnregisterDeviceToken(ctx, userID, strings.TrimSpace(token))
+	if err != nil {
+		return fmt.Errorf("failed to unregister device token: %w", err)
+	}
+	if !deleted {
+		return fmt.Errorf("device token not found")
+	}
+
+	return nil
+}
+
+// SendPushToUser sends a push notification to every registered device of the user.
+// A notification is recorded per device; devices rejecting their token are marked stale.
+func (s *NotificationService) SendPushToUser(ctx context.Context, userID, title, body string, data map[string]string) ([]*models.Notification, error) {
+	if userID == "" {
+		return nil, fmt.Errorf("user_id is required")
+	}
+
+	devices, err := s.repo.ListActiveDeviceTokens(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get device tokens: %w", err)
+	}
+	if len(devices) == 0 {
+		return nil, fmt.Errorf("no registered devices found for user %s", userID)
+	}
+
+	notifications := make([]*models.Notification, 0, len(devices))
+	for _, device := range devices {
+		notification, err := s.SendPushNotification(ctx, userID, device.Token, title, body, data)
+		if notification == nil {
+			// Validation or storage error, the same for every device
+			return nil, err
+		}
+		notifications = append(notifications, notification)
+	}
+
+	return notifications, nil
+}
+
+// RunDeviceTokenPruning periodically deletes tokens that have been stale for a while
+func (s *NotificationService) RunDeviceTokenPruning(ctx context.Context) {
+	ticker := time.NewTicker(deviceTokenPruneInterval)
+	defer ticker.Stop()
+
+	for {
+		pruned, err := s.repo.DeleteStaleDeviceTokens(ctx, time.Now().Add(-staleDeviceTokenRetention))
+		if err != nil {
+			log.Printf("Failed to prune stale device tokens: %v", err)
+		} else if pruned > 0 {
+			log.Printf("Pruned %d stale device tokens", pruned)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -3,11 +3,14 @@ package service
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/datngth03/ecommerce-go-app/services/notification-service/internal/email"
 	"github.com/datngth03/ecommerce-go-app/services/notification-service/internal/models"
+	"github.com/datngth03/ecommerce-go-app/services/notification-service/internal/push"
 	"github.com/datngth03/ecommerce-go-app/services/notification-service/internal/repository"
 )
 
@@ -15,13 +18,15 @@ import (
 type NotificationService struct {
 	repo         repository.NotificationRepository
 	emailService *email.EmailService
+	pushProvider push.Provider
 }
 
 // NewNotificationService creates a new notification service
-func NewNotificationService(repo repository.NotificationRepository, emailService *email.EmailService) *NotificationService {
+func NewNotificationService(repo repository.NotificationRepository, emailService *email.EmailService, pushProvider push.Provider) *NotificationService {
 	return &NotificationService{
 		repo:         repo,
 		emailService: emailService,
+		pushProvider: pushProvider,
 	}
 }
 
@@ -98,20 +103,62 @@ func (s *NotificationService) SendSMS(ctx context.Context, userID, recipient, me
 	return notification, fmt.Errorf("SMS sending not implemented")
 }
 
-// SendBulkEmail sends email to multiple recipients
-func (s *NotificationService) SendBulkEmail(ctx context.Context, recipients []string, subject, body, templateID string, variables map[string]string) (int, int, error) {
-	// If template is specified, use it
-	if templateID != "" {
-		template, err := s.repo.GetTemplate(ctx, templateID)
-		if err != nil {
-			return 0, 0, fmt.Errorf("template not found: %w", err)
+// SendPushNotification delivers a push notification to one device
+func (s *NotificationService) SendPushNotification(ctx context.Context, userID, deviceToken, title, body string, data map[string]string) (*models.Notification, error) {
+	deviceToken = strings.TrimSpace(deviceToken)
+	if deviceToken == "" {
+		return nil, fmt.Errorf("device_token is required")
+	}
+	if title == "" && body == "" {
+		return nil, fmt.Errorf("title or body is required")
+	}
+
+	// Create notification record
+	metadataJSON, _ := json.Marshal(data)
+	notification := &models.Notification{
+		UserID:    userID,
+		Type:      models.NotificationTypePush,
+		Channel:   s.pushProvider.Name(),
+		Recipient: deviceToken,
+		Subject:   title,
+		Content:   body,
+		Status:    models.NotificationStatusPending,
+		Metadata:  string(metadataJSON),
+	}
+
+	err := s.repo.CreateNotification(ctx, notification)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create notification: %w", err)
+	}
+
+	// Send push notification
+	messageID, err := s.pushProvider.Send(ctx, push.Message{
+		Token: deviceToken,
+		Title: title,
+		Body:  body,
+		Data:  data,
+	})
+	if err != nil {
+		if errors.Is(err, push.ErrInvalidToken) {
+			// Keep the token for pruning, but never use it again
+			s.repo.MarkDeviceTokenStale(ctx, deviceToken, err.Error())
 		}
 
-		subject = s.emailService.RenderTemplate(template.Subject, variables)
-		body = s.emailService.RenderTemplate(template.Body, variables)
+		// Update status to failed
+		notification.Status = models.NotificationStatusFailed
+		notification.ErrorMessage = err.Error()
+		s.repo.UpdateNotification(ctx, notification)
+		return notification, fmt.Errorf("failed to send push notification: %w", err)
 	}
 
-	return s.emailService.SendBulkEmail(recipients, subject, body)
+	// Update status to sent
+	now := time.Now()
+	notification.Status = models.NotificationStatusSent
+	notification.ProviderMessageID = messageID
+	notification.SentAt = &now
+	s.repo.UpdateNotification(ctx, notification)
+
+	return notification, nil
 }
 
 // GetNotification retrieves a notification
@@ -159,3 +206,34 @@ func (s *NotificationService) GetTemplate(ctx context.Context, templateID string
 func (s *NotificationService) ListTemplates(ctx context.Context, notifType string) ([]*models.Template, error) {
 	return s.repo.ListTemplates(ctx, notifType)
 }
+
+// GetPreferences retrieves a user's notification preferences, defaulting to all channels enabled
+func (s *NotificationService) GetPreferences(ctx context.Context, userID string) (*models.NotificationPreference, error) {
+	if userID == "" {
+		return nil, fmt.Errorf("user_id is required")
+	}
+
+	preferences, err := s.repo.GetPreferences(ctx, []string{userID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get preferences: %w", err)
+	}
+
+	if preference, ok := preferences[userID]; ok {
+		return preference, nil
+	}
+	return models.DefaultNotificationPreference(userID), nil
+}
+
+// UpdatePreferences stores a user's notification preferences.
+// Bulk emails skip users with email disabled; transactional emails are always sent.
+func (s *NotificationService) UpdatePreferences(ctx context.Context, preference *models.NotificationPreference) (*models.NotificationPreference, error) {
+	if preference.UserID == "" {
+		return nil, fmt.Errorf("user_id is required")
+	}
+
+	if err := s.repo.UpsertPreference(ctx, preference); err != nil {
+		return nil, fmt.Errorf("failed to update preferences: %w", err)
+	}
+
+	return preference, nil
+}
@@ -0,0 +1,4 @@
+DROP TRIGGER IF EXISTS update_notification_preferences_updated_at ON notification_preferences;
+DROP TABLE IF EXISTS notification_preferences;
+DROP TABLE IF EXISTS bulk_email_recipients;
+DROP TABLE IF EXISTS bulk_email_jobs;
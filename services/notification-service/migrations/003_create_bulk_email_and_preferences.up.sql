@@ -0,0 +1,41 @@
+-- Create bulk_email_jobs table
+CREATE TABLE IF NOT EXISTS bulk_email_jobs (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    subject VARCHAR(500),
+    body TEXT NOT NULL,
+    template_id UUID,
+    variables JSONB,
+    status VARCHAR(50) NOT NULL,
+    total INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    started_at TIMESTAMP WITH TIME ZONE,
+    completed_at TIMESTAMP WITH TIME ZONE
+);
+
+-- Create bulk_email_recipients table (per-recipient results)
+CREATE TABLE IF NOT EXISTS bulk_email_recipients (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    job_id UUID NOT NULL REFERENCES bulk_email_jobs(id) ON DELETE CASCADE,
+    user_id VARCHAR(255),
+    recipient VARCHAR(255) NOT NULL,
+    variables JSONB,
+    status VARCHAR(50) NOT NULL,
+    error_message TEXT,
+    sent_at TIMESTAMP WITH TIME ZONE
+);
+
+-- Create notification_preferences table
+CREATE TABLE IF NOT EXISTS notification_preferences (
+    user_id VARCHAR(255) PRIMARY KEY,
+    email_enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    sms_enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    push_enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create indexes
+CREATE INDEX IF NOT EXISTS idx_bulk_email_jobs_status ON bulk_email_jobs(status);
+CREATE INDEX IF NOT EXISTS idx_bulk_email_recipients_job_status ON bulk_email_recipients(job_id, status);
+
+CREATE TRIGGER update_notification_preferences_updated_at BEFORE UPDATE ON notification_preferences
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
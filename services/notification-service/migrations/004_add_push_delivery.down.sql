@@ -0,0 +1,3 @@
+DROP TRIGGER IF EXISTS update_device_tokens_updated_at ON device_tokens;
+DROP TABLE IF EXISTS device_tokens;
+ALTER TABLE notifications DROP COLUMN IF EXISTS provider_message_id;
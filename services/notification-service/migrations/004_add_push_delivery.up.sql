@@ -0,0 +1,19 @@
+-- Store the message ID returned by the delivery provider
+ALTER TABLE notifications ADD COLUMN IF NOT EXISTS provider_message_id VARCHAR(255);
+
+-- Create device_tokens table (tokens rejected by the push provider are marked stale)
+CREATE TABLE IF NOT EXISTS device_tokens (
+    token VARCHAR(512) PRIMARY KEY,
+    user_id VARCHAR(255),
+    is_stale BOOLEAN NOT NULL DEFAULT FALSE,
+    stale_reason TEXT,
+    stale_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_device_tokens_user_id ON device_tokens(user_id);
+CREATE INDEX IF NOT EXISTS idx_device_tokens_stale ON device_tokens(stale_at) WHERE is_stale = true;
+
+CREATE TRIGGER update_device_tokens_updated_at BEFORE UPDATE ON device_tokens
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
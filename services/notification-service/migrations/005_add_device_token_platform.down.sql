@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_device_tokens_user_active;
+ALTER TABLE device_tokens DROP COLUMN IF EXISTS platform;
@@ -0,0 +1,5 @@
+-- Store device metadata for registered push tokens
+ALTER TABLE device_tokens ADD COLUMN IF NOT EXISTS platform VARCHAR(20);
+
+-- Active devices of a user (push fan-out)
+CREATE INDEX IF NOT EXISTS idx_device_tokens_user_active ON device_tokens(user_id) WHERE is_stale = false;
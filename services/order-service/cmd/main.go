@@ -7,8 +7,6 @@ import (
 	"net"
 	"net/http"
 	"os"
-	"os/signal"
-	"syscall"
 	"time"
 
 	"golang.org/x/time/rate"
@@ -18,10 +16,16 @@ import (
 	"github.com/datngth03/ecommerce-go-app/services/order-service/internal/config"
 	"github.com/datngth03/ecommerce-go-app/services/order-service/internal/events"
 	"github.com/datngth03/ecommerce-go-app/services/order-service/internal/metrics"
+	"github.com/datngth03/ecommerce-go-app/services/order-service/internal/models"
 	"github.com/datngth03/ecommerce-go-app/services/order-service/internal/repository"
 	"github.com/datngth03/ecommerce-go-app/services/order-service/internal/rpc"
 	"github.com/datngth03/ecommerce-go-app/services/order-service/internal/service"
+	"github.com/datngth03/ecommerce-go-app/shared/pkg/domainerr"
+	"github.com/datngth03/ecommerce-go-app/shared/pkg/grpcsize"
+	"github.com/datngth03/ecommerce-go-app/shared/pkg/lifecycle"
+	sharedLogger "github.com/datngth03/ecommerce-go-app/shared/pkg/logger"
 	sharedMiddleware "github.com/datngth03/ecommerce-go-app/shared/pkg/middleware"
+	"github.com/datngth03/ecommerce-go-app/shared/pkg/money"
 	sharedTLS "github.com/datngth03/ecommerce-go-app/shared/pkg/tlsutil"
 	sharedTracing "github.com/datngth03/ecommerce-go-app/shared/pkg/tracing"
 
@@ -41,6 +45,9 @@ func main() {
 	if err != nil {
 		log.Fatalf("Failed to load configuration: %v", err)
 	}
+	if err := sharedLogger.Init(cfg.Logging); err != nil {
+		log.Fatalf("Failed to initialize logger: %v", err)
+	}
 	log.Printf("Order Service v%s starting in %s mode...", cfg.Service.Version, cfg.Service.Environment)
 
 	// 2. Initialize Distributed Tracing
@@ -106,6 +113,10 @@ func main() {
 	// 4. Initialize Repositories
 	orderRepo := repository.NewOrderPostgresRepository(db)
 	cartRepo := repository.NewCartPostgresRepository(db, redisClient)
+	wishlistRepo := repository.NewWishlistPostgresRepository(db, redisClient)
+	couponRepo := repository.NewCouponPostgresRepository(db)
+	orderNoteRepo := repository.NewOrderNotePostgresRepository(db)
+	returnRepo := repository.NewReturnPostgresRepository(db)
 	log.Println("✓ Repositories initialized")
 
 	// 5. Initialize RabbitMQ Publisher
@@ -139,22 +150,41 @@ func main() {
 	}()
 
 	// 7. Initialize Services
-	orderService := service.NewOrderService(orderRepo, cartRepo, clients.Product, clients.User, publisher)
-	cartService := service.NewCartService(cartRepo, clients.Product)
+	roundingMode, _ := money.ParseMode(cfg.Pricing.RoundingMode) // validated by config.Load
+	rounding := money.NewRounding(roundingMode, cfg.Pricing.CurrencyPrecision)
+	couponService := service.NewCouponService(couponRepo, rounding)
+	orderNumbers, _ := models.NewOrderNumberFormat(cfg.Orders.NumberFormat, cfg.Orders.NumberDigits) // validated by config.Load
+	orderService := service.NewOrderService(orderRepo, cartRepo, couponService, clients.Product, clients.User, clients.Payment, publisher, rounding, orderNumbers)
+	cartLimits := models.CartLimits{
+		MaxItems:        cfg.Cart.MaxItems,
+		MaxItemQuantity: int32(cfg.Cart.MaxItemQuantity),
+	}
+	cartService := service.NewCartService(cartRepo, clients.Product, cartLimits, models.ParseCartPricePolicy(cfg.Cart.PricePolicy))
+	wishlistService := service.NewWishlistService(wishlistRepo, cartRepo, clients.Product, cartLimits)
+	orderNoteService := service.NewOrderNoteService(orderNoteRepo, orderRepo)
+	returnWindow := time.Duration(cfg.Returns.WindowDays) * 24 * time.Hour
+	returnService := service.NewReturnService(returnRepo, orderRepo, clients.Payment, publisher, rounding, returnWindow)
 	log.Println("✓ Services initialized")
 
-	// 6. Initialize gRPC Server with Tracing Interceptor and TLS
+	// 6. Initialize gRPC Server with Tracing and Error-Mapping Interceptors and TLS
 	var grpcServerOpts []grpc.ServerOption
-	grpcServerOpts = append(grpcServerOpts, grpc.UnaryInterceptor(sharedTracing.UnaryServerInterceptor()))
+	// Tracing runs outermost so spans record the mapped status codes
+	grpcServerOpts = append(grpcServerOpts, grpc.ChainUnaryInterceptor(
+		sharedTracing.UnaryServerInterceptor(),
+		domainerr.UnaryServerInterceptor(),
+	))
+
+	// Same message size limit as the other services and their clients
+	grpcServerOpts = append(grpcServerOpts, grpcsize.ServerOptions(cfg.Server.MaxMessageSize)...)
 
 	// Enable TLS if configured
 	if cfg.Server.TLS.Enabled {
-		tlsCreds, err := sharedTLS.ServerTLSConfig(cfg.Server.TLS.CertFile, cfg.Server.TLS.KeyFile)
+		tlsCreds, err := sharedTLS.ServerCredentials(cfg.Server.TLS)
 		if err != nil {
 			log.Fatalf("Failed to load TLS credentials: %v", err)
 		}
 		grpcServerOpts = append(grpcServerOpts, grpc.Creds(tlsCreds))
-		log.Printf("✓ TLS enabled for gRPC server (cert: %s)", cfg.Server.TLS.CertFile)
+		log.Printf("✓ TLS enabled for gRPC server (cert: %s, client certificates required: %v)", cfg.Server.TLS.CertFile, cfg.Server.TLS.ClientAuth)
 	} else {
 		log.Println("⚠️  TLS disabled - using insecure connection")
 	}
@@ -162,7 +192,7 @@ func main() {
 	grpcServer := grpc.NewServer(grpcServerOpts...)
 
 	// Register Order Service
-	orderGRPCServer := rpc.NewOrderServer(orderService, cartService)
+	orderGRPCServer := rpc.NewOrderServer(orderService, cartService, wishlistService, orderNoteService, returnService)
 	pb.RegisterOrderServiceServer(grpcServer, orderGRPCServer)
 
 	// Register Health Check Service
@@ -252,26 +282,11 @@ func main() {
 		}
 	}()
 
-	// 9. Graceful Shutdown
-	quit := make(chan os.Signal, 1)
-	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
-
-	log.Println(" Order Service is running. Press Ctrl+C to exit...")
-	<-quit
-
-	log.Println("Shutting down Order Service...")
-
-	// Stop HTTP server
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
-	if err := httpServer.Shutdown(ctx); err != nil {
-		log.Printf("HTTP server shutdown error: %v", err)
+	// 9. Graceful Shutdown: stop taking requests first, then drain in-flight RPCs
+	shutdown := lifecycle.NewManager("Order Service", cfg.Server.ShutdownTimeout)
+	shutdown.Register("HTTP server", httpServer)
+	shutdown.Register("gRPC server", lifecycle.GRPCServer(grpcServer))
+	if err := shutdown.Wait(); err != nil {
+		log.Printf("Order Service shutdown completed with errors: %v", err)
 	}
-	log.Println(" HTTP server stopped")
-
-	// Stop gRPC server gracefully
-	grpcServer.GracefulStop()
-	log.Println(" gRPC server stopped")
-
-	log.Println(" Order Service shutdown completed")
 }
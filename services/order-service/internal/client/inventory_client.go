@@ -7,6 +7,7 @@ import (
 	pb "github.com/datngth03/ecommerce-go-app/proto/inventory_service"
 	sharedConfig "github.com/datngth03/ecommerce-go-app/shared/pkg/config"
 	"github.com/datngth03/ecommerce-go-app/shared/pkg/grpcpool"
+	"github.com/datngth03/ecommerce-go-app/shared/pkg/grpcretry"
 	sharedTracing "github.com/datngth03/ecommerce-go-app/shared/pkg/tracing"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
@@ -16,6 +17,7 @@ type InventoryClient struct {
 	conn   *grpc.ClientConn
 	client pb.InventoryServiceClient
 	pool   *grpcpool.ConnectionPool // Connection pool support
+	policy grpcretry.Policy         // Timeout and retries of calls
 }
 
 func NewInventoryClient(endpoint sharedConfig.ServiceEndpoint) (*InventoryClient, error) {
@@ -32,6 +34,7 @@ func NewInventoryClient(endpoint sharedConfig.ServiceEndpoint) (*InventoryClient
 	return &InventoryClient{
 		conn:   conn,
 		client: pb.NewInventoryServiceClient(conn),
+		policy: grpcretry.PolicyFor(endpoint),
 	}, nil
 }
 
@@ -48,7 +51,8 @@ func NewInventoryClientWithPool(endpoint sharedConfig.ServiceEndpoint, poolManag
 	}
 
 	return &InventoryClient{
-		pool: pool,
+		pool:   pool,
+		policy: grpcretry.PolicyFor(endpoint),
 	}, nil
 }
 
@@ -79,9 +83,11 @@ func (c *InventoryClient) ReserveStock(ctx context.Context, orderID string, item
 		return "", err
 	}
 
-	resp, err := client.ReserveStock(ctx, &pb.ReserveStockRequest{
-		OrderId: orderID,
-		Items:   items,
+	resp, err := grpcretry.Call(ctx, c.policy, func(ctx context.Context) (*pb.ReserveStockResponse, error) {
+		return client.ReserveStock(ctx, &pb.ReserveStockRequest{
+			OrderId: orderID,
+			Items:   items,
+		})
 	})
 	if err != nil {
 		return "", fmt.Errorf("failed to reserve stock: %w", err)
@@ -101,8 +107,10 @@ func (c *InventoryClient) CommitStock(ctx context.Context, reservationID string)
 		return err
 	}
 
-	resp, err := client.CommitStock(ctx, &pb.CommitStockRequest{
-		ReservationId: reservationID,
+	resp, err := grpcretry.Call(ctx, c.policy, func(ctx context.Context) (*pb.CommitStockResponse, error) {
+		return client.CommitStock(ctx, &pb.CommitStockRequest{
+			ReservationId: reservationID,
+		})
 	})
 	if err != nil {
 		return fmt.Errorf("failed to commit stock: %w", err)
@@ -122,8 +130,10 @@ func (c *InventoryClient) ReleaseStock(ctx context.Context, reservationID string
 		return err
 	}
 
-	resp, err := client.ReleaseStock(ctx, &pb.ReleaseStockRequest{
-		ReservationId: reservationID,
+	resp, err := grpcretry.Call(ctx, c.policy, func(ctx context.Context) (*pb.ReleaseStockResponse, error) {
+		return client.ReleaseStock(ctx, &pb.ReleaseStockRequest{
+			ReservationId: reservationID,
+		})
 	})
 	if err != nil {
 		return fmt.Errorf("failed to release stock: %w", err)
@@ -143,8 +153,10 @@ func (c *InventoryClient) CheckAvailability(ctx context.Context, items []*pb.Sto
 		return false, nil, err
 	}
 
-	resp, err := client.CheckAvailability(ctx, &pb.CheckAvailabilityRequest{
-		Items: items,
+	resp, err := grpcretry.CallIdempotent(ctx, c.policy, func(ctx context.Context) (*pb.CheckAvailabilityResponse, error) {
+		return client.CheckAvailability(ctx, &pb.CheckAvailabilityRequest{
+			Items: items,
+		})
 	})
 	if err != nil {
 		return false, nil, fmt.Errorf("failed to check availability: %w", err)
@@ -160,8 +172,10 @@ func (c *InventoryClient) GetStock(ctx context.Context, productID string) (*pb.S
 		return nil, err
 	}
 
-	resp, err := client.GetStock(ctx, &pb.GetStockRequest{
-		ProductId: productID,
+	resp, err := grpcretry.CallIdempotent(ctx, c.policy, func(ctx context.Context) (*pb.GetStockResponse, error) {
+		return client.GetStock(ctx, &pb.GetStockRequest{
+			ProductId: productID,
+		})
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to get stock: %w", err)
@@ -7,6 +7,7 @@ import (
 	pb "github.com/datngth03/ecommerce-go-app/proto/payment_service"
 	sharedConfig "github.com/datngth03/ecommerce-go-app/shared/pkg/config"
 	"github.com/datngth03/ecommerce-go-app/shared/pkg/grpcpool"
+	"github.com/datngth03/ecommerce-go-app/shared/pkg/grpcretry"
 	sharedTracing "github.com/datngth03/ecommerce-go-app/shared/pkg/tracing"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
@@ -16,6 +17,7 @@ type PaymentClient struct {
 	conn   *grpc.ClientConn
 	client pb.PaymentServiceClient
 	pool   *grpcpool.ConnectionPool // Connection pool support
+	policy grpcretry.Policy         // Timeout and retries of calls
 }
 
 func NewPaymentClient(endpoint sharedConfig.ServiceEndpoint) (*PaymentClient, error) {
@@ -32,6 +34,7 @@ func NewPaymentClient(endpoint sharedConfig.ServiceEndpoint) (*PaymentClient, er
 	return &PaymentClient{
 		conn:   conn,
 		client: pb.NewPaymentServiceClient(conn),
+		policy: grpcretry.PolicyFor(endpoint),
 	}, nil
 }
 
@@ -48,7 +51,8 @@ func NewPaymentClientWithPool(endpoint sharedConfig.ServiceEndpoint, poolManager
 	}
 
 	return &PaymentClient{
-		pool: pool,
+		pool:   pool,
+		policy: grpcretry.PolicyFor(endpoint),
 	}, nil
 }
 
@@ -79,7 +83,9 @@ func (c *PaymentClient) ProcessPayment(ctx context.Context, req *pb.ProcessPayme
 		return nil, err
 	}
 
-	resp, err := client.ProcessPayment(ctx, req)
+	resp, err := grpcretry.Call(ctx, c.policy, func(ctx context.Context) (*pb.ProcessPaymentResponse, error) {
+		return client.ProcessPayment(ctx, req)
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to process payment: %w", err)
 	}
@@ -94,8 +100,10 @@ func (c *PaymentClient) ConfirmPayment(ctx context.Context, paymentID string) er
 		return err
 	}
 
-	resp, err := client.ConfirmPayment(ctx, &pb.ConfirmPaymentRequest{
-		PaymentId: paymentID,
+	resp, err := grpcretry.Call(ctx, c.policy, func(ctx context.Context) (*pb.ConfirmPaymentResponse, error) {
+		return client.ConfirmPayment(ctx, &pb.ConfirmPaymentRequest{
+			PaymentId: paymentID,
+		})
 	})
 	if err != nil {
 		return fmt.Errorf("failed to confirm payment: %w", err)
@@ -115,8 +123,10 @@ func (c *PaymentClient) GetPayment(ctx context.Context, paymentID string) (*pb.P
 		return nil, err
 	}
 
-	resp, err := client.GetPayment(ctx, &pb.GetPaymentRequest{
-		PaymentId: paymentID,
+	resp, err := grpcretry.CallIdempotent(ctx, c.policy, func(ctx context.Context) (*pb.GetPaymentResponse, error) {
+		return client.GetPayment(ctx, &pb.GetPaymentRequest{
+			PaymentId: paymentID,
+		})
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to get payment: %w", err)
@@ -132,8 +142,10 @@ func (c *PaymentClient) GetPaymentByOrderID(ctx context.Context, orderID string)
 		return nil, err
 	}
 
-	resp, err := client.GetPaymentByOrder(ctx, &pb.GetPaymentByOrderRequest{
-		OrderId: orderID,
+	resp, err := grpcretry.CallIdempotent(ctx, c.policy, func(ctx context.Context) (*pb.GetPaymentByOrderResponse, error) {
+		return client.GetPaymentByOrder(ctx, &pb.GetPaymentByOrderRequest{
+			OrderId: orderID,
+		})
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to get payment by order ID: %w", err)
@@ -142,17 +154,22 @@ func (c *PaymentClient) GetPaymentByOrderID(ctx context.Context, orderID string)
 	return resp.Payment, nil
 }
 
-// RefundPayment initiates a refund
-func (c *PaymentClient) RefundPayment(ctx context.Context, paymentID string, amount float64, reason string) error {
+// RefundPayment initiates a refund. amount 0 refunds whatever the payment has not
+// refunded yet. skipRestock is set when the refunded items went back to stock some
+// other way, e.g. when a return was received.
+func (c *PaymentClient) RefundPayment(ctx context.Context, paymentID string, amount float64, reason string, skipRestock bool) error {
 	client, err := c.getClient()
 	if err != nil {
 		return err
 	}
 
-	resp, err := client.RefundPayment(ctx, &pb.RefundPaymentRequest{
-		PaymentId: paymentID,
-		Amount:    amount,
-		Reason:    reason,
+	resp, err := grpcretry.Call(ctx, c.policy, func(ctx context.Context) (*pb.RefundPaymentResponse, error) {
+		return client.RefundPayment(ctx, &pb.RefundPaymentRequest{
+			PaymentId:   paymentID,
+			Amount:      amount,
+			Reason:      reason,
+			SkipRestock: skipRestock,
+		})
 	})
 	if err != nil {
 		return fmt.Errorf("failed to refund payment: %w", err)
@@ -7,15 +7,18 @@ import (
 	pb "github.com/datngth03/ecommerce-go-app/proto/product_service"
 	sharedConfig "github.com/datngth03/ecommerce-go-app/shared/pkg/config"
 	"github.com/datngth03/ecommerce-go-app/shared/pkg/grpcpool"
+	"github.com/datngth03/ecommerce-go-app/shared/pkg/grpcretry"
 	sharedTracing "github.com/datngth03/ecommerce-go-app/shared/pkg/tracing"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/types/known/emptypb"
 )
 
 type ProductClient struct {
 	conn   *grpc.ClientConn
 	client pb.ProductServiceClient
 	pool   *grpcpool.ConnectionPool // Connection pool support
+	policy grpcretry.Policy         // Timeout and retries of calls
 }
 
 func NewProductClient(endpoint sharedConfig.ServiceEndpoint) (*ProductClient, error) {
@@ -32,6 +35,7 @@ func NewProductClient(endpoint sharedConfig.ServiceEndpoint) (*ProductClient, er
 	return &ProductClient{
 		conn:   conn,
 		client: pb.NewProductServiceClient(conn),
+		policy: grpcretry.PolicyFor(endpoint),
 	}, nil
 }
 
@@ -48,7 +52,8 @@ func NewProductClientWithPool(endpoint sharedConfig.ServiceEndpoint, poolManager
 	}
 
 	return &ProductClient{
-		pool: pool,
+		pool:   pool,
+		policy: grpcretry.PolicyFor(endpoint),
 	}, nil
 }
 
@@ -79,8 +84,10 @@ func (c *ProductClient) GetProduct(ctx context.Context, productID string) (*pb.P
 		return nil, err
 	}
 
-	resp, err := client.GetProduct(ctx, &pb.GetProductRequest{
-		Id: productID,
+	resp, err := grpcretry.CallIdempotent(ctx, c.policy, func(ctx context.Context) (*pb.GetProductResponse, error) {
+		return client.GetProduct(ctx, &pb.GetProductRequest{
+			Id: productID,
+		})
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to get product: %w", err)
@@ -88,6 +95,25 @@ func (c *ProductClient) GetProduct(ctx context.Context, productID string) (*pb.P
 	return resp.Product, nil
 }
 
+// GetProductsByIds retrieves multiple products in a single call.
+// It also returns the IDs that do not match any product.
+func (c *ProductClient) GetProductsByIds(ctx context.Context, productIDs []string) ([]*pb.Product, []string, error) {
+	client, err := c.getClient()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	resp, err := grpcretry.CallIdempotent(ctx, c.policy, func(ctx context.Context) (*pb.GetProductsByIdsResponse, error) {
+		return client.GetProductsByIds(ctx, &pb.GetProductsByIdsRequest{
+			Ids: productIDs,
+		})
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get products: %w", err)
+	}
+	return resp.Products, resp.NotFoundIds, nil
+}
+
 // CheckStock validates if product exists (stock management should be in Inventory Service)
 func (c *ProductClient) CheckStock(ctx context.Context, productID string, quantity int32) (bool, error) {
 	// For now, just check if product exists
@@ -113,3 +139,22 @@ func (c *ProductClient) GetProducts(ctx context.Context, productIDs []string) ([
 
 	return products, nil
 }
+
+// RecordProductInteractions reports cart and purchase activity for product statistics.
+// Recording is not idempotent, so the call is never retried.
+func (c *ProductClient) RecordProductInteractions(ctx context.Context, interactions []*pb.ProductInteraction) error {
+	client, err := c.getClient()
+	if err != nil {
+		return err
+	}
+
+	_, err = grpcretry.Call(ctx, c.policy, func(ctx context.Context) (*emptypb.Empty, error) {
+		return client.RecordProductInteractions(ctx, &pb.RecordProductInteractionsRequest{
+			Interactions: interactions,
+		})
+	})
+	if err != nil {
+		return fmt.Errorf("failed to record product interactions: %w", err)
+	}
+	return nil
+}
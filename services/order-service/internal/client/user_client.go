@@ -7,6 +7,7 @@ import (
 	pb "github.com/datngth03/ecommerce-go-app/proto/user_service"
 	sharedConfig "github.com/datngth03/ecommerce-go-app/shared/pkg/config"
 	"github.com/datngth03/ecommerce-go-app/shared/pkg/grpcpool"
+	"github.com/datngth03/ecommerce-go-app/shared/pkg/grpcretry"
 	sharedTracing "github.com/datngth03/ecommerce-go-app/shared/pkg/tracing"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
@@ -16,6 +17,7 @@ type UserClient struct {
 	conn   *grpc.ClientConn
 	client pb.UserServiceClient
 	pool   *grpcpool.ConnectionPool // Connection pool support
+	policy grpcretry.Policy         // Timeout and retries of calls
 }
 
 func NewUserClient(endpoint sharedConfig.ServiceEndpoint) (*UserClient, error) {
@@ -32,6 +34,7 @@ func NewUserClient(endpoint sharedConfig.ServiceEndpoint) (*UserClient, error) {
 	return &UserClient{
 		conn:   conn,
 		client: pb.NewUserServiceClient(conn),
+		policy: grpcretry.PolicyFor(endpoint),
 	}, nil
 }
 
@@ -48,7 +51,8 @@ func NewUserClientWithPool(endpoint sharedConfig.ServiceEndpoint, poolManager *g
 	}
 
 	return &UserClient{
-		pool: pool,
+		pool:   pool,
+		policy: grpcretry.PolicyFor(endpoint),
 	}, nil
 }
 
@@ -79,8 +83,10 @@ func (c *UserClient) GetUser(ctx context.Context, userID int64) (*pb.User, error
 		return nil, err
 	}
 
-	resp, err := client.GetUser(ctx, &pb.GetUserRequest{
-		Identifier: &pb.GetUserRequest_Id{Id: userID},
+	resp, err := grpcretry.CallIdempotent(ctx, c.policy, func(ctx context.Context) (*pb.UserResponse, error) {
+		return client.GetUser(ctx, &pb.GetUserRequest{
+			Identifier: &pb.GetUserRequest_Id{Id: userID},
+		})
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to get user: %w", err)
@@ -93,6 +99,24 @@ func (c *UserClient) GetUser(ctx context.Context, userID int64) (*pb.User, error
 	return resp.User, nil
 }
 
+// GetUsersByIds retrieves the public profiles of several users in one call.
+// Unknown and deleted users are missing from the result.
+func (c *UserClient) GetUsersByIds(ctx context.Context, userIDs []int64) ([]*pb.UserProfile, error) {
+	client, err := c.getClient()
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := grpcretry.CallIdempotent(ctx, c.policy, func(ctx context.Context) (*pb.GetUsersByIdsResponse, error) {
+		return client.GetUsersByIds(ctx, &pb.GetUsersByIdsRequest{Ids: userIDs})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get users: %w", err)
+	}
+
+	return resp.Users, nil
+}
+
 // ValidateUser checks if user exists and is active
 func (c *UserClient) ValidateUser(ctx context.Context, userID int64) (bool, error) {
 	user, err := c.GetUser(ctx, userID)
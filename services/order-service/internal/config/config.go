@@ -1,11 +1,14 @@
 package config
 
 import (
+	"fmt"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/datngth03/ecommerce-go-app/services/order-service/internal/models"
 	sharedConfig "github.com/datngth03/ecommerce-go-app/shared/pkg/config"
+	"github.com/datngth03/ecommerce-go-app/shared/pkg/money"
 )
 
 // SecurityConfig holds security-related configuration
@@ -38,6 +41,34 @@ type Config struct {
 	Services sharedConfig.ExternalServices
 	Logging  sharedConfig.LoggingConfig
 	Security SecurityConfig
+	Cart     CartConfig
+	Pricing  PricingConfig
+	Returns  ReturnsConfig
+	Orders   OrdersConfig
+}
+
+// CartConfig holds cart size limits (0 disables a limit) and the price refresh policy
+type CartConfig struct {
+	MaxItems        int    // Distinct products per cart
+	MaxItemQuantity int    // Quantity of one product
+	PricePolicy     string // off, flag or update; see models.CartPricePolicy
+}
+
+// PricingConfig holds how order amounts are rounded
+type PricingConfig struct {
+	RoundingMode      string // half_up or half_even; see money.ParseMode
+	CurrencyPrecision int    // Decimal places of the currency, 2 for cents
+}
+
+// ReturnsConfig holds the return (RMA) policy
+type ReturnsConfig struct {
+	WindowDays int // Days after delivery a return can be requested; 0 disables the limit
+}
+
+// OrdersConfig holds how human-friendly order numbers are formatted
+type OrdersConfig struct {
+	NumberFormat string // Template with {YYYY}, {YY}, {MM} and {SEQ}; see models.OrderNumberFormat
+	NumberDigits int    // Minimum digits of {SEQ}
 }
 
 // Load loads configuration from environment variables
@@ -55,6 +86,43 @@ func Load() (*Config, error) {
 		Services: sharedConfig.LoadExternalServices(),
 		Logging:  sharedConfig.LoadLoggingConfig(),
 		Security: LoadSecurityConfig(),
+		Cart: CartConfig{
+			MaxItems:        sharedConfig.GetEnvAsInt("CART_MAX_ITEMS", 50),
+			MaxItemQuantity: sharedConfig.GetEnvAsInt("CART_MAX_ITEM_QUANTITY", 99),
+			PricePolicy:     sharedConfig.GetEnv("CART_PRICE_REFRESH", "off"),
+		},
+		Pricing: PricingConfig{
+			RoundingMode:      sharedConfig.GetEnv("PRICE_ROUNDING_MODE", "half_up"),
+			CurrencyPrecision: sharedConfig.GetEnvAsInt("CURRENCY_PRECISION", 2),
+		},
+		Returns: ReturnsConfig{
+			WindowDays: sharedConfig.GetEnvAsInt("RETURN_WINDOW_DAYS", 30),
+		},
+		Orders: OrdersConfig{
+			NumberFormat: sharedConfig.GetEnv("ORDER_NUMBER_FORMAT", models.DefaultOrderNumberTemplate),
+			NumberDigits: sharedConfig.GetEnvAsInt("ORDER_NUMBER_DIGITS", 6),
+		},
+	}
+
+	v := sharedConfig.NewValidator(cfg.Service)
+	v.CheckServer(cfg.Server)
+	v.CheckDatabase(cfg.Database)
+	v.CheckRedis(cfg.Redis)
+	v.CheckRabbitMQ(cfg.RabbitMQ)
+	if err := v.Err(); err != nil {
+		return nil, err
+	}
+	if _, err := money.ParseMode(cfg.Pricing.RoundingMode); err != nil {
+		return nil, fmt.Errorf("invalid PRICE_ROUNDING_MODE: %w", err)
+	}
+	if cfg.Pricing.CurrencyPrecision < 0 || cfg.Pricing.CurrencyPrecision > money.MaxPrecision {
+		return nil, fmt.Errorf("invalid CURRENCY_PRECISION: must be between 0 and %d", money.MaxPrecision)
+	}
+	if cfg.Returns.WindowDays < 0 {
+		return nil, fmt.Errorf("invalid RETURN_WINDOW_DAYS: must not be negative")
+	}
+	if _, err := models.NewOrderNumberFormat(cfg.Orders.NumberFormat, cfg.Orders.NumberDigits); err != nil {
+		return nil, fmt.Errorf("invalid ORDER_NUMBER_FORMAT or ORDER_NUMBER_DIGITS: %w", err)
 	}
 
 	return cfg, nil
@@ -134,4 +202,19 @@ func (c *Config) PrintConfig() {
 		Logging:  c.Logging,
 	}
 	baseConfig.PrintConfig()
+
+	fmt.Printf("Cart:\n")
+	fmt.Printf("  Max Items: %d\n", c.Cart.MaxItems)
+	fmt.Printf("  Max Item Quantity: %d\n", c.Cart.MaxItemQuantity)
+	fmt.Printf("  Price Refresh: %s\n", c.Cart.PricePolicy)
+
+	fmt.Printf("Pricing:\n")
+	fmt.Printf("  Rounding Mode: %s\n", c.Pricing.RoundingMode)
+	fmt.Printf("  Currency Precision: %d\n", c.Pricing.CurrencyPrecision)
+
+	fmt.Printf("Returns:\n")
+	fmt.Printf("  Window: %d days\n", c.Returns.WindowDays)
+
+	fmt.Printf("Orders:\n")
+	fmt.Printf("  Number Format: %s (%d digits)\n", c.Orders.NumberFormat, c.Orders.NumberDigits)
 }
@@ -18,22 +18,31 @@ const (
 type OrderCreatedEvent struct {
 	EventType       string           `json:"event_type"`
 	OrderID         string           `json:"order_id"`
+	OrderNumber     string           `json:"order_number"`
 	UserID          int64            `json:"user_id"`
+	SubtotalAmount  float64          `json:"subtotal_amount"`
+	DiscountAmount  float64          `json:"discount_amount"`
+	CouponCode      string           `json:"coupon_code,omitempty"`
 	TotalAmount     float64          `json:"total_amount"`
 	ShippingAddress string           `json:"shipping_address"`
 	PaymentMethod   string           `json:"payment_method"`
+	IsGift          bool             `json:"is_gift"` // Packing slip must omit prices
+	GiftMessage     string           `json:"gift_message,omitempty"`
 	Items           []OrderItemEvent `json:"items"`
-	CreatedAt       time.Time        `json:"created_at"`
+	// StockItems is what the order takes from stock, with bundles replaced by their components
+	StockItems []models.StockItem `json:"stock_items"`
+	CreatedAt  time.Time          `json:"created_at"`
 }
 
 // OrderStatusChangedEvent represents order status change event
 type OrderStatusChangedEvent struct {
-	EventType string    `json:"event_type"`
-	OrderID   string    `json:"order_id"`
-	UserID    int64     `json:"user_id"`
-	OldStatus string    `json:"old_status"`
-	NewStatus string    `json:"new_status"`
-	UpdatedAt time.Time `json:"updated_at"`
+	EventType   string    `json:"event_type"`
+	OrderID     string    `json:"order_id"`
+	OrderNumber string    `json:"order_number"`
+	UserID      int64     `json:"user_id"`
+	OldStatus   string    `json:"old_status"`
+	NewStatus   string    `json:"new_status"`
+	UpdatedAt   time.Time `json:"updated_at"`
 }
 
 // OrderCancelledEvent represents order cancellation event
@@ -71,23 +80,31 @@ func NewOrderCreatedEvent(order *models.Order) *OrderCreatedEvent {
 	return &OrderCreatedEvent{
 		EventType:       EventOrderCreated,
 		OrderID:         order.ID,
+		OrderNumber:     order.OrderNumber,
 		UserID:          order.UserID,
+		SubtotalAmount:  order.SubtotalAmount,
+		DiscountAmount:  order.DiscountAmount,
+		CouponCode:      order.CouponCode,
 		TotalAmount:     order.TotalAmount,
 		ShippingAddress: order.ShippingAddress,
 		PaymentMethod:   order.PaymentMethod,
+		IsGift:          order.IsGift,
+		GiftMessage:     order.GiftMessage,
 		Items:           items,
+		StockItems:      models.StockItems(order.Items),
 		CreatedAt:       order.CreatedAt,
 	}
 }
 
 func NewOrderStatusChangedEvent(order *models.Order, oldStatus string) *OrderStatusChangedEvent {
 	return &OrderStatusChangedEvent{
-		EventType: EventOrderStatusChanged,
-		OrderID:   order.ID,
-		UserID:    order.UserID,
-		OldStatus: oldStatus,
-		NewStatus: order.Status,
-		UpdatedAt: order.UpdatedAt,
+		EventType:   EventOrderStatusChanged,
+		OrderID:     order.ID,
+		OrderNumber: order.OrderNumber,
+		UserID:      order.UserID,
+		OldStatus:   oldStatus,
+		NewStatus:   order.Status,
+		UpdatedAt:   order.UpdatedAt,
 	}
 }
 
@@ -74,19 +74,24 @@ func (p *Publisher) PublishOrderCreated(ctx context.Context, order *models.Order
 }
 
 // PublishOrderStatusChanged publishes order status changed event
-func (p *Publisher) PublishOrderStatusChanged(ctx context.Context, order *models.Order) error {
-	// Note: We don't have old status in current implementation
-	// You might want to pass it as parameter or fetch from DB
-	event := NewOrderStatusChangedEvent(order, "")
+func (p *Publisher) PublishOrderStatusChanged(ctx context.Context, order *models.Order, oldStatus string) error {
+	event := NewOrderStatusChangedEvent(order, oldStatus)
 	return p.publish(ctx, EventOrderStatusChanged, event)
 }
 
 // PublishOrderCancelled publishes order cancelled event
-func (p *Publisher) PublishOrderCancelled(ctx context.Context, order *models.Order) error {
-	event := NewOrderCancelledEvent(order, "User cancelled")
+func (p *Publisher) PublishOrderCancelled(ctx context.Context, order *models.Order, reason string) error {
+	event := NewOrderCancelledEvent(order, reason)
 	return p.publish(ctx, EventOrderCancelled, event)
 }
 
+// PublishReturnEvent publishes the event of the status a return just entered,
+// e.g. return.received
+func (p *Publisher) PublishReturnEvent(ctx context.Context, ret *models.Return) error {
+	event := NewReturnEvent(ret)
+	return p.publish(ctx, event.EventType, event)
+}
+
 // publish is the internal method to publish events
 func (p *Publisher) publish(ctx context.Context, routingKey string, event interface{}) error {
 	if p.channel == nil {
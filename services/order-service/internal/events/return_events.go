@@ -0,0 +1,57 @@
+package events
+
+import (
+	"time"
+
+	"github.com/datngth03/ecommerce-go-app/services/order-service/internal/models"
+)
+
+// Return event types, one per status a return enters
+const (
+	EventReturnRequested = "return.requested"
+	EventReturnApproved  = "return.approved"
+	EventReturnRejected  = "return.rejected"
+	EventReturnReceived  = "return.received"
+	EventReturnRefunded  = "return.refunded"
+)
+
+// ReturnEvent represents a return entering a status
+type ReturnEvent struct {
+	EventType       string              `json:"event_type"`
+	ReturnID        string              `json:"return_id"`
+	OrderID         string              `json:"order_id"`
+	UserID          int64               `json:"user_id"`
+	Status          string              `json:"status"`
+	Reason          string              `json:"reason"`
+	RejectionReason string              `json:"rejection_reason,omitempty"`
+	RefundAmount    float64             `json:"refund_amount"`
+	Items           []models.ReturnItem `json:"items"`
+	// StockItems is what the return puts back in stock, with bundles replaced by their components
+	StockItems []models.StockItem `json:"stock_items"`
+	UpdatedAt  time.Time          `json:"updated_at"`
+}
+
+// returnEventTypes maps return statuses to the event published on entering them
+var returnEventTypes = map[string]string{
+	models.ReturnStatusRequested: EventReturnRequested,
+	models.ReturnStatusApproved:  EventReturnApproved,
+	models.ReturnStatusRejected:  EventReturnRejected,
+	models.ReturnStatusReceived:  EventReturnReceived,
+	models.ReturnStatusRefunded:  EventReturnRefunded,
+}
+
+func NewReturnEvent(ret *models.Return) *ReturnEvent {
+	return &ReturnEvent{
+		EventType:       returnEventTypes[ret.Status],
+		ReturnID:        ret.ID,
+		OrderID:         ret.OrderID,
+		UserID:          ret.UserID,
+		Status:          ret.Status,
+		Reason:          ret.Reason,
+		RejectionReason: ret.RejectionReason,
+		RefundAmount:    ret.RefundAmount,
+		Items:           ret.Items,
+		StockItems:      ret.StockItems(),
+		UpdatedAt:       ret.UpdatedAt,
+	}
+}
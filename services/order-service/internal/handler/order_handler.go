@@ -3,7 +3,9 @@ package handler
 import (
 	"net/http"
 	"strconv"
+	"strings"
 
+	"github.com/datngth03/ecommerce-go-app/services/order-service/internal/models"
 	"github.com/datngth03/ecommerce-go-app/services/order-service/internal/service"
 	"github.com/datngth03/ecommerce-go-app/shared/pkg/validator"
 	"github.com/gin-gonic/gin"
@@ -65,9 +67,21 @@ func (h *OrderHandler) CreateOrder(c *gin.Context) {
 
 	// Sanitize inputs to prevent XSS
 	req.ShippingAddress = validator.SanitizeString(req.ShippingAddress)
+	req.GiftMessage = validator.SanitizeString(req.GiftMessage)
 
-	order, err := h.orderService.CreateOrder(c.Request.Context(), userID, req.ShippingAddress, req.PaymentMethod)
+	order, err := h.orderService.CreateOrder(c.Request.Context(), userID, req.ShippingAddress, req.PaymentMethod, models.CreateOrderOptions{
+		Gift: models.GiftOptions{
+			IsGift:  req.IsGift,
+			Message: req.GiftMessage,
+		},
+		CouponCode: req.CouponCode,
+	})
 	if err != nil {
+		if strings.Contains(err.Error(), "invalid gift_message") || strings.Contains(err.Error(), "invalid coupon") ||
+			strings.Contains(err.Error(), "unavailable products") {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -135,7 +149,7 @@ func (h *OrderHandler) ListOrders(c *gin.Context) {
 		}
 	}
 
-	orders, total, err := h.orderService.ListOrders(c.Request.Context(), userID, int32(page), int32(pageSize), status)
+	list, err := h.orderService.ListOrders(c.Request.Context(), userID, int32(page), int32(pageSize), status)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -143,12 +157,7 @@ func (h *OrderHandler) ListOrders(c *gin.Context) {
 
 	c.JSON(http.StatusOK, gin.H{
 		"success": true,
-		"data": gin.H{
-			"orders":      orders,
-			"total_count": total,
-			"page":        page,
-			"page_size":   pageSize,
-		},
+		"data":    list,
 	})
 }
 
@@ -227,6 +236,9 @@ func (h *OrderHandler) CancelOrder(c *gin.Context) {
 type CreateOrderRequest struct {
 	ShippingAddress string `json:"shipping_address" binding:"required"`
 	PaymentMethod   string `json:"payment_method" binding:"required"`
+	IsGift          bool   `json:"is_gift"`
+	GiftMessage     string `json:"gift_message"`
+	CouponCode      string `json:"coupon_code"`
 }
 
 type UpdateOrderStatusRequest struct {
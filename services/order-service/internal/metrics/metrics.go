@@ -17,6 +17,9 @@ var (
 	dbQueriesTotal  *prometheus.CounterVec
 	dbQueryDuration *prometheus.HistogramVec
 
+	// Per-operation repository query latency
+	dbOperationDuration *prometheus.HistogramVec
+
 	// Order-specific metrics
 	ordersTotal     *prometheus.CounterVec
 	orderValueTotal *prometheus.CounterVec
@@ -75,6 +78,15 @@ func initMetrics() {
 			[]string{"operation", "table"},
 		)
 
+		dbOperationDuration = prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Name:    "order_service_db_operation_duration_seconds",
+				Help:    "Repository query duration in seconds by named operation",
+				Buckets: prometheus.DefBuckets,
+			},
+			[]string{"operation", "status"},
+		)
+
 		// Order-specific metrics
 		ordersTotal = prometheus.NewCounterVec(
 			prometheus.CounterOpts{
@@ -141,6 +153,7 @@ func initMetrics() {
 			httpRequestDuration,
 			dbQueriesTotal,
 			dbQueryDuration,
+			dbOperationDuration,
 			ordersTotal,
 			orderValueTotal,
 			activeOrders,
@@ -214,6 +227,16 @@ func RecordDBQuery(operation, table, status string, duration time.Duration) {
 	dbQueryDuration.WithLabelValues(operation, table).Observe(duration.Seconds())
 }
 
+// RecordDBOperation records the duration of a named repository query; it matches dbquery.Observer
+func RecordDBOperation(operation string, err error, duration time.Duration) {
+	initMetrics()
+	status := "success"
+	if err != nil {
+		status = "error"
+	}
+	dbOperationDuration.WithLabelValues(operation, status).Observe(duration.Seconds())
+}
+
 // RecordGRPCRequest records a gRPC request metric
 func RecordGRPCRequest(method, status string, duration time.Duration) {
 	initMetrics()
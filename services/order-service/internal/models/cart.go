@@ -1,6 +1,11 @@
 package models
 
-import "time"
+import (
+	"strings"
+	"time"
+
+	"github.com/datngth03/ecommerce-go-app/shared/pkg/domainerr"
+)
 
 type Cart struct {
 	ID          string     `json:"id"`
@@ -21,4 +26,136 @@ type CartItem struct {
 	Subtotal    float64   `json:"subtotal"`
 	CreatedAt   time.Time `json:"created_at"`
 	UpdatedAt   time.Time `json:"updated_at"`
+
+	// Set when the product price no longer matches the price stored when the item was added
+	PriceChanged bool    `json:"price_changed,omitempty"`
+	OldPrice     float64 `json:"old_price,omitempty"`
+	NewPrice     float64 `json:"new_price,omitempty"`
+
+	// Set when the product was deleted or deactivated after the item was added
+	Unavailable bool `json:"unavailable,omitempty"`
+}
+
+// CartPricePolicy controls how GetCart treats items whose product price changed since they were added
+type CartPricePolicy string
+
+const (
+	CartPriceOff    CartPricePolicy = "off"    // Prices are not re-checked
+	CartPriceFlag   CartPricePolicy = "flag"   // Changed items are flagged and keep their stored price
+	CartPriceUpdate CartPricePolicy = "update" // Changed items are flagged and repriced
+)
+
+// ParseCartPricePolicy parses a policy name; unknown names disable the refresh
+func ParseCartPricePolicy(s string) CartPricePolicy {
+	switch p := CartPricePolicy(strings.ToLower(strings.TrimSpace(s))); p {
+	case CartPriceFlag, CartPriceUpdate:
+		return p
+	default:
+		return CartPriceOff
+	}
+}
+
+// FlagPriceChanges compares the cart against current product prices, keyed by product ID.
+// Items whose price differs are flagged with the old and new price; with reprice they
+// also take the new price. Products missing from current are left alone. It returns the
+// new prices of the changed items.
+func (c *Cart) FlagPriceChanges(current map[string]float64, reprice bool) map[string]float64 {
+	changed := make(map[string]float64)
+	for i := range c.Items {
+		item := &c.Items[i]
+		price, ok := current[item.ProductID]
+		if !ok || price == item.Price {
+			continue
+		}
+		item.PriceChanged = true
+		item.OldPrice = item.Price
+		item.NewPrice = price
+		if reprice {
+			item.Price = price
+		}
+		changed[item.ProductID] = price
+	}
+	return changed
+}
+
+// MarkUnavailable flags the items whose product is missing from current, i.e. no
+// longer exists or is inactive, and returns them
+func (c *Cart) MarkUnavailable(current map[string]float64) []CartItem {
+	var unavailable []CartItem
+	for i := range c.Items {
+		if _, ok := current[c.Items[i].ProductID]; ok {
+			continue
+		}
+		c.Items[i].Unavailable = true
+		unavailable = append(unavailable, c.Items[i])
+	}
+	return unavailable
+}
+
+// PriceMismatch is a cart item priced differently from its product's current price,
+// either in the cart or by the client placing the order
+type PriceMismatch struct {
+	ProductID string
+	Source    string // "cart" or "client"
+	Price     float64
+	Current   float64
+}
+
+// PriceMismatches compares the prices stored in the cart, and the prices the client
+// expected by product ID, with current product prices. Products missing from current
+// are skipped. Mismatches only point to a stale cart: orders charge current prices.
+func (c *Cart) PriceMismatches(current, expected map[string]float64) []PriceMismatch {
+	var mismatches []PriceMismatch
+	for _, item := range c.Items {
+		price, ok := current[item.ProductID]
+		if !ok {
+			continue
+		}
+		if item.Price != price {
+			mismatches = append(mismatches, PriceMismatch{ProductID: item.ProductID, Source: "cart", Price: item.Price, Current: price})
+		}
+		if seen, ok := expected[item.ProductID]; ok && seen != price {
+			mismatches = append(mismatches, PriceMismatch{ProductID: item.ProductID, Source: "client", Price: seen, Current: price})
+		}
+	}
+	return mismatches
+}
+
+// CartItemInput is one product of a bulk add to cart
+type CartItemInput struct {
+	ProductID string `json:"product_id"`
+	Quantity  int32  `json:"quantity"`
+}
+
+// CartItemFailure is an item of a bulk add that was not added, with the reason why
+type CartItemFailure struct {
+	ProductID string `json:"product_id"`
+	Reason    string `json:"reason"`
+}
+
+// CartLimits caps the size of a cart; a zero value disables that limit
+type CartLimits struct {
+	MaxItems        int   // Distinct products per cart
+	MaxItemQuantity int32 // Quantity of one product
+}
+
+// CheckAdd validates adding addQuantity of a product to a cart holding itemCount distinct
+// products, currentQuantity of which is this product (0 when it is not in the cart yet)
+func (l CartLimits) CheckAdd(itemCount int, currentQuantity, addQuantity int32) error {
+	if currentQuantity == 0 && l.MaxItems > 0 && itemCount >= l.MaxItems {
+		return domainerr.Conflict("cart limit exceeded: a cart can hold at most %d different items", l.MaxItems)
+	}
+	if l.MaxItemQuantity > 0 && int64(currentQuantity)+int64(addQuantity) > int64(l.MaxItemQuantity) {
+		return domainerr.Conflict("cart limit exceeded: at most %d of each item per cart", l.MaxItemQuantity)
+	}
+	return nil
+}
+
+// CheckUpdate validates setting a product's quantity. Lowering it is always allowed,
+// so carts filled before the limits were introduced can still be reduced.
+func (l CartLimits) CheckUpdate(currentQuantity, quantity int32) error {
+	if l.MaxItemQuantity > 0 && quantity > l.MaxItemQuantity && quantity > currentQuantity {
+		return domainerr.Conflict("cart limit exceeded: at most %d of each item per cart", l.MaxItemQuantity)
+	}
+	return nil
 }
@@ -0,0 +1,115 @@
+package models
+
+import "testing"
+
+func TestCartLimits(t *testing.T) {
+	limits := CartLimits{MaxItems: 2, MaxItemQuantity: 5}
+
+	if err := limits.CheckAdd(2, 0, 1); err == nil {
+		t.Error("adding a third distinct item should fail")
+	}
+	if err := limits.CheckAdd(2, 3, 2); err != nil {
+		t.Errorf("adding to an item already in a full cart: %v", err)
+	}
+	if err := limits.CheckAdd(1, 3, 3); err == nil {
+		t.Error("exceeding the per-item quantity should fail")
+	}
+	if err := limits.CheckAdd(0, 0, 2147483647); err == nil {
+		t.Error("huge quantity should fail, not overflow")
+	}
+
+	// Carts over the limit from before it existed can still be reduced
+	if err := limits.CheckUpdate(20, 10); err != nil {
+		t.Errorf("reducing an oversized item: %v", err)
+	}
+	if err := limits.CheckUpdate(20, 21); err == nil {
+		t.Error("raising an oversized item should fail")
+	}
+
+	if err := (CartLimits{}).CheckAdd(1000, 0, 1000); err != nil {
+		t.Errorf("zero limits should disable checks: %v", err)
+	}
+}
+
+func TestCartFlagPriceChanges(t *testing.T) {
+	newCart := func() *Cart {
+		return &Cart{Items: []CartItem{
+			{ProductID: "a", Price: 10},
+			{ProductID: "b", Price: 20},
+			{ProductID: "c", Price: 30},
+		}}
+	}
+	current := map[string]float64{"a": 12, "b": 20}
+
+	cart := newCart()
+	changed := cart.FlagPriceChanges(current, false)
+	if len(changed) != 1 || changed["a"] != 12 {
+		t.Fatalf("changed = %v, want only a at 12", changed)
+	}
+	a := cart.Items[0]
+	if !a.PriceChanged || a.OldPrice != 10 || a.NewPrice != 12 || a.Price != 10 {
+		t.Errorf("flagged item = %+v, want old 10, new 12, price kept at 10", a)
+	}
+	if cart.Items[1].PriceChanged || cart.Items[2].PriceChanged {
+		t.Error("unchanged and unknown products should not be flagged")
+	}
+
+	cart = newCart()
+	cart.FlagPriceChanges(current, true)
+	if a := cart.Items[0]; !a.PriceChanged || a.Price != 12 || a.OldPrice != 10 {
+		t.Errorf("repriced item = %+v, want price 12 and old price 10", a)
+	}
+}
+
+func TestCartMarkUnavailable(t *testing.T) {
+	cart := &Cart{Items: []CartItem{
+		{ProductID: "a", ProductName: "Kept"},
+		{ProductID: "b", ProductName: "Deleted"},
+	}}
+
+	unavailable := cart.MarkUnavailable(map[string]float64{"a": 10})
+	if len(unavailable) != 1 || unavailable[0].ProductName != "Deleted" {
+		t.Fatalf("unavailable = %+v, want only the deleted product", unavailable)
+	}
+	if cart.Items[0].Unavailable || !cart.Items[1].Unavailable {
+		t.Errorf("items = %+v, want only b flagged", cart.Items)
+	}
+}
+
+func TestCartPriceMismatches(t *testing.T) {
+	cart := &Cart{Items: []CartItem{
+		{ProductID: "a", Price: 10},
+		{ProductID: "b", Price: 20},
+		{ProductID: "c", Price: 30},
+	}}
+	current := map[string]float64{"a": 12, "b": 20}
+	expected := map[string]float64{"a": 12, "b": 0}
+
+	mismatches := cart.PriceMismatches(current, expected)
+	want := []PriceMismatch{
+		{ProductID: "a", Source: "cart", Price: 10, Current: 12},
+		{ProductID: "b", Source: "client", Price: 0, Current: 20},
+	}
+	if len(mismatches) != len(want) {
+		t.Fatalf("mismatches = %+v, want %+v", mismatches, want)
+	}
+	for i := range want {
+		if mismatches[i] != want[i] {
+			t.Errorf("mismatch %d = %+v, want %+v", i, mismatches[i], want[i])
+		}
+	}
+}
+
+func TestParseCartPricePolicy(t *testing.T) {
+	for in, want := range map[string]CartPricePolicy{
+		"flag":    CartPriceFlag,
+		" Update": CartPriceUpdate,
+		"off":     CartPriceOff,
+		"":        CartPriceOff,
+		"bogus":   CartPriceOff,
+	} {
+		if got := ParseCartPricePolicy(in); got != want {
+			t.Errorf("ParseCartPricePolicy(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
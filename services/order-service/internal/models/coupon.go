@@ -0,0 +1,44 @@
+package models
+
+import (
+	"time"
+
+	"github.com/datngth03/ecommerce-go-app/shared/pkg/money"
+)
+
+// Coupon discount types
+const (
+	CouponTypePercentage = "percentage" // DiscountValue is a percent of the order subtotal
+	CouponTypeFixed      = "fixed"      // DiscountValue is an amount off the order subtotal
+)
+
+type Coupon struct {
+	ID             string     `db:"id" json:"id"`
+	Code           string     `db:"code" json:"code"`
+	DiscountType   string     `db:"discount_type" json:"discount_type"`
+	DiscountValue  float64    `db:"discount_value" json:"discount_value"`
+	MinOrderAmount float64    `db:"min_order_amount" json:"min_order_amount"`
+	UsageLimit     *int32     `db:"usage_limit" json:"usage_limit,omitempty"` // nil means unlimited
+	UsedCount      int32      `db:"used_count" json:"used_count"`
+	StartsAt       *time.Time `db:"starts_at" json:"starts_at,omitempty"`
+	ExpiresAt      *time.Time `db:"expires_at" json:"expires_at,omitempty"`
+	IsActive       bool       `db:"is_active" json:"is_active"`
+	CreatedAt      time.Time  `db:"created_at" json:"created_at"`
+	UpdatedAt      time.Time  `db:"updated_at" json:"updated_at"`
+}
+
+// Discount returns the amount taken off subtotal, rounded once and never more than subtotal itself
+func (c *Coupon) Discount(subtotal money.Amount, r money.Rounding) money.Amount {
+	var discount money.Amount
+	switch c.DiscountType {
+	case CouponTypePercentage:
+		discount = r.Percent(subtotal, c.DiscountValue)
+	case CouponTypeFixed:
+		discount = r.FromFloat(c.DiscountValue)
+	}
+
+	if discount > subtotal {
+		discount = subtotal
+	}
+	return discount
+}
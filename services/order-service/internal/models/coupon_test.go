@@ -0,0 +1,35 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/datngth03/ecommerce-go-app/shared/pkg/money"
+)
+
+func TestCouponDiscount(t *testing.T) {
+	halfUp := money.NewRounding(money.HalfUp, 2)
+	halfEven := money.NewRounding(money.HalfEven, 2)
+
+	tests := []struct {
+		name     string
+		coupon   Coupon
+		subtotal money.Amount
+		rounding money.Rounding
+		want     money.Amount
+	}{
+		{"percentage", Coupon{DiscountType: CouponTypePercentage, DiscountValue: 15}, 5997, halfUp, 900}, // 899.55 cents
+		{"percentage tie half up", Coupon{DiscountType: CouponTypePercentage, DiscountValue: 10}, 125, halfUp, 13},
+		{"percentage tie half even", Coupon{DiscountType: CouponTypePercentage, DiscountValue: 10}, 125, halfEven, 12},
+		{"fixed", Coupon{DiscountType: CouponTypeFixed, DiscountValue: 5.005}, 10000, halfUp, 501},
+		{"capped at subtotal", Coupon{DiscountType: CouponTypeFixed, DiscountValue: 50}, 1999, halfUp, 1999},
+		{"unknown type", Coupon{DiscountType: "bogus", DiscountValue: 10}, 1000, halfUp, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.coupon.Discount(tt.subtotal, tt.rounding); got != tt.want {
+				t.Errorf("Discount(%d) = %d, want %d", tt.subtotal, got, tt.want)
+			}
+		})
+	}
+}
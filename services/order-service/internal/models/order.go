@@ -4,11 +4,19 @@ import "time"
 
 type Order struct {
 	ID              string      `db:"id" json:"id"`
+	OrderNumber     string      `db:"order_number" json:"order_number"` // Human-friendly, e.g. ORD-2025-000123
 	UserID          int64       `db:"user_id" json:"user_id"`
 	Status          string      `db:"status" json:"status"`
-	TotalAmount     float64     `db:"total_amount" json:"total_amount"`
+	SubtotalAmount  float64     `db:"subtotal_amount" json:"subtotal_amount"` // Sum of items before discount
+	DiscountAmount  float64     `db:"discount_amount" json:"discount_amount"`
+	CouponCode      string      `db:"coupon_code" json:"coupon_code,omitempty"`
+	CouponID        string      `db:"-" json:"-"`                       // Set on create so the coupon usage is recorded with the order
+	TotalAmount     float64     `db:"total_amount" json:"total_amount"` // Amount to pay: subtotal - discount
 	ShippingAddress string      `db:"shipping_address" json:"shipping_address"`
 	PaymentMethod   string      `db:"payment_method" json:"payment_method"`
+	IsGift          bool        `db:"is_gift" json:"is_gift"`
+	GiftMessage     string      `db:"gift_message" json:"gift_message,omitempty"`
+	UserName        string      `db:"-" json:"user_name,omitempty"` // Filled in for admin listings only
 	CreatedAt       time.Time   `db:"created_at" json:"created_at"`
 	UpdatedAt       time.Time   `db:"updated_at" json:"updated_at"`
 	Items           []OrderItem `json:"items,omitempty"`
@@ -23,8 +31,91 @@ type OrderItem struct {
 	Price       float64   `db:"price" json:"price"`
 	Subtotal    float64   `db:"subtotal" json:"subtotal"`
 	CreatedAt   time.Time `db:"created_at" json:"created_at"`
+
+	// Components snapshots a bundle's composition when the order is placed, so
+	// stock is reserved and committed per component; empty for regular products
+	Components []OrderItemComponent `db:"bundle_components" json:"components,omitempty"`
+}
+
+// OrderItemComponent is a product inside a bundle order item
+type OrderItemComponent struct {
+	ProductID   string `json:"product_id"`
+	ProductName string `json:"product_name"`
+	Quantity    int32  `json:"quantity"` // Per bundle unit
+}
+
+// StockItem is a quantity of one product taken from stock
+type StockItem struct {
+	ProductID string `json:"product_id"`
+	Quantity  int32  `json:"quantity"`
+}
+
+// StockItems returns what the items take from stock: bundles count as their
+// components times the ordered quantity. Products are merged, in first-seen order.
+func StockItems(items []OrderItem) []StockItem {
+	var stock []StockItem
+	index := make(map[string]int, len(items))
+	add := func(productID string, quantity int32) {
+		if i, ok := index[productID]; ok {
+			stock[i].Quantity += quantity
+			return
+		}
+		index[productID] = len(stock)
+		stock = append(stock, StockItem{ProductID: productID, Quantity: quantity})
+	}
+
+	for _, item := range items {
+		if len(item.Components) == 0 {
+			add(item.ProductID, item.Quantity)
+			continue
+		}
+		for _, component := range item.Components {
+			add(component.ProductID, component.Quantity*item.Quantity)
+		}
+	}
+	return stock
+}
+
+// GiftOptions marks an order as a gift
+type GiftOptions struct {
+	IsGift  bool
+	Message string
+}
+
+// CreateOrderOptions holds the optional parts of a new order
+type CreateOrderOptions struct {
+	Gift       GiftOptions
+	CouponCode string
+
+	// Prices the client last saw, by product ID. They are never charged: items are
+	// priced from the product service, and a different price is only logged.
+	ExpectedPrices map[string]float64
+}
+
+// OrderFilter selects orders of all users by status and time range.
+// Zero times leave that bound open; ranges include After and exclude Before.
+type OrderFilter struct {
+	Status        string
+	CreatedAfter  time.Time
+	CreatedBefore time.Time
+	UpdatedAfter  time.Time
+	UpdatedBefore time.Time
 }
 
+// OrderList is one page of an order listing. TotalCount counts the orders matching
+// the same filters as the page.
+type OrderList struct {
+	Orders     []*Order `json:"orders"`
+	TotalCount int64    `json:"total_count"`
+	Page       int32    `json:"page"`
+	PageSize   int32    `json:"page_size"`
+	TotalPages int32    `json:"total_pages"`
+	HasMore    bool     `json:"has_more"`
+}
+
+// MaxGiftMessageLength matches the gift_message column size
+const MaxGiftMessageLength = 250
+
 const (
 	OrderStatusPending    = "pending"
 	OrderStatusConfirmed  = "confirmed"
@@ -33,3 +124,33 @@ const (
 	OrderStatusDelivered  = "delivered"
 	OrderStatusCancelled  = "cancelled"
 )
+
+// IsValidOrderStatus reports whether status is a known order status
+func IsValidOrderStatus(status string) bool {
+	switch status {
+	case OrderStatusPending, OrderStatusConfirmed, OrderStatusProcessing,
+		OrderStatusShipped, OrderStatusDelivered, OrderStatusCancelled:
+		return true
+	}
+	return false
+}
+
+// orderTransitions lists the statuses an order may move to from each status.
+// Orders can be cancelled until they ship (paid ones are refunded); shipped
+// orders go through returns, and delivered and cancelled orders are final.
+var orderTransitions = map[string][]string{
+	OrderStatusPending:    {OrderStatusConfirmed, OrderStatusCancelled},
+	OrderStatusConfirmed:  {OrderStatusProcessing, OrderStatusCancelled},
+	OrderStatusProcessing: {OrderStatusShipped, OrderStatusCancelled},
+	OrderStatusShipped:    {OrderStatusDelivered},
+}
+
+// CanTransition reports whether an order in status from may move to status to
+func CanTransition(from, to string) bool {
+	for _, next := range orderTransitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
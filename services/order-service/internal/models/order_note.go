@@ -0,0 +1,17 @@
+package models
+
+import "time"
+
+// MaxOrderNoteLength is the longest note body, in characters
+const MaxOrderNoteLength = 2000
+
+// OrderNote is a note staff attached to an order. Internal notes are for support
+// staff only; the others are shown to the customer with the order.
+type OrderNote struct {
+	ID         string    `db:"id" json:"id"`
+	OrderID    string    `db:"order_id" json:"order_id"`
+	AuthorID   int64     `db:"author_id" json:"author_id"`
+	Body       string    `db:"body" json:"body"`
+	IsInternal bool      `db:"is_internal" json:"is_internal"`
+	CreatedAt  time.Time `db:"created_at" json:"created_at"`
+}
@@ -0,0 +1,59 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Order number template placeholders
+const (
+	OrderNumberYear      = "{YYYY}" // Four-digit year the order was placed
+	OrderNumberShortYear = "{YY}"   // Two-digit year
+	OrderNumberMonth     = "{MM}"   // Two-digit month
+	OrderNumberSequence  = "{SEQ}"  // Sequence number, zero-padded; required
+)
+
+// DefaultOrderNumberTemplate renders numbers like ORD-2025-000123
+const DefaultOrderNumberTemplate = "ORD-{YYYY}-{SEQ}"
+
+// MaxOrderNumberLength matches the order_number column size
+const MaxOrderNumberLength = 50
+
+// OrderNumberFormat renders human-friendly order numbers from a template and a
+// global sequence. The sequence never resets, so numbers stay unique whatever
+// the template; the year only makes them easier to read.
+type OrderNumberFormat struct {
+	Template string
+	Digits   int // Minimum digits of the sequence number; longer numbers are not cut
+}
+
+// NewOrderNumberFormat checks a template. It must contain {SEQ} exactly once,
+// and a number with an 18-digit sequence must fit the order_number column.
+func NewOrderNumberFormat(template string, digits int) (OrderNumberFormat, error) {
+	f := OrderNumberFormat{Template: strings.TrimSpace(template), Digits: digits}
+	if f.Template == "" {
+		f.Template = DefaultOrderNumberTemplate
+	}
+	if strings.Count(f.Template, OrderNumberSequence) != 1 {
+		return f, fmt.Errorf("order number format %q must contain %s exactly once", f.Template, OrderNumberSequence)
+	}
+	if digits < 1 || digits > 18 {
+		return f, fmt.Errorf("order number digits must be between 1 and 18, got %d", digits)
+	}
+	if longest := f.Format(999999999999999999, time.Now()); len(longest) > MaxOrderNumberLength {
+		return f, fmt.Errorf("order number format %q is too long: numbers can reach %d characters, the limit is %d",
+			f.Template, len(longest), MaxOrderNumberLength)
+	}
+	return f, nil
+}
+
+// Format renders the order number for sequence value seq of an order placed at t
+func (f OrderNumberFormat) Format(seq int64, t time.Time) string {
+	return strings.NewReplacer(
+		OrderNumberYear, fmt.Sprintf("%04d", t.Year()),
+		OrderNumberShortYear, fmt.Sprintf("%02d", t.Year()%100),
+		OrderNumberMonth, fmt.Sprintf("%02d", int(t.Month())),
+		OrderNumberSequence, fmt.Sprintf("%0*d", f.Digits, seq),
+	).Replace(f.Template)
+}
@@ -0,0 +1,51 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestOrderNumberFormat(t *testing.T) {
+	placed := time.Date(2024, time.March, 5, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		template string
+		digits   int
+		seq      int64
+		want     string
+	}{
+		{"", 6, 123, "ORD-2024-000123"},
+		{"ORD-{YYYY}-{SEQ}", 6, 1234567, "ORD-2024-1234567"},
+		{"{YY}{MM}-{SEQ}", 4, 42, "2403-0042"},
+		{"{SEQ}", 1, 7, "7"},
+	}
+
+	for _, tt := range tests {
+		f, err := NewOrderNumberFormat(tt.template, tt.digits)
+		if err != nil {
+			t.Fatalf("NewOrderNumberFormat(%q, %d): %v", tt.template, tt.digits, err)
+		}
+		if got := f.Format(tt.seq, placed); got != tt.want {
+			t.Errorf("Format(%q, %d) = %q, want %q", tt.template, tt.seq, got, tt.want)
+		}
+	}
+}
+
+func TestNewOrderNumberFormatRejects(t *testing.T) {
+	tests := []struct {
+		template string
+		digits   int
+	}{
+		{"ORD-{YYYY}", 6},  // no sequence, numbers would repeat
+		{"{SEQ}-{SEQ}", 6}, // sequence twice
+		{"ORD-{SEQ}", 0},   // no digits
+		{"ORD-{SEQ}", 19},  // beyond int64
+		{"ORDER-NUMBER-FOR-{YYYY}-{MM}-{SEQ}-RETAIL-STORE", 6}, // longer than the column
+	}
+
+	for _, tt := range tests {
+		if _, err := NewOrderNumberFormat(tt.template, tt.digits); err == nil {
+			t.Errorf("NewOrderNumberFormat(%q, %d) succeeded, want an error", tt.template, tt.digits)
+		}
+	}
+}
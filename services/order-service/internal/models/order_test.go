@@ -0,0 +1,55 @@
+package models
+
+import "testing"
+
+func TestCanTransition(t *testing.T) {
+	tests := []struct {
+		from, to string
+		want     bool
+	}{
+		{OrderStatusPending, OrderStatusConfirmed, true},
+		{OrderStatusPending, OrderStatusCancelled, true},
+		{OrderStatusConfirmed, OrderStatusProcessing, true},
+		{OrderStatusProcessing, OrderStatusShipped, true},
+		{OrderStatusShipped, OrderStatusDelivered, true},
+		{OrderStatusConfirmed, OrderStatusCancelled, true},
+		{OrderStatusProcessing, OrderStatusCancelled, true},
+		{OrderStatusShipped, OrderStatusCancelled, false},
+		{OrderStatusCancelled, OrderStatusConfirmed, false},
+		{OrderStatusConfirmed, OrderStatusConfirmed, false},
+		{OrderStatusPending, OrderStatusShipped, false},
+		{OrderStatusDelivered, OrderStatusCancelled, false},
+	}
+
+	for _, tt := range tests {
+		if got := CanTransition(tt.from, tt.to); got != tt.want {
+			t.Errorf("CanTransition(%s, %s) = %v, want %v", tt.from, tt.to, got, tt.want)
+		}
+	}
+}
+
+func TestStockItems(t *testing.T) {
+	items := []OrderItem{
+		{ProductID: "camera-kit", Quantity: 2, Components: []OrderItemComponent{
+			{ProductID: "camera", Quantity: 1},
+			{ProductID: "memory-card", Quantity: 2},
+		}},
+		{ProductID: "memory-card", Quantity: 1},
+		{ProductID: "bag", Quantity: 3},
+	}
+
+	got := StockItems(items)
+	want := []StockItem{
+		{ProductID: "camera", Quantity: 2},
+		{ProductID: "memory-card", Quantity: 5},
+		{ProductID: "bag", Quantity: 3},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("StockItems() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("StockItems()[%d] = %v, want %v", i, got[i], want[i])
+		}
+	}
+}
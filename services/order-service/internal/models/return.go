@@ -0,0 +1,95 @@
+package models
+
+import "time"
+
+// MaxReturnReasonLength bounds the customer's reason and the rejection reason, in characters
+const MaxReturnReasonLength = 500
+
+const (
+	ReturnStatusRequested = "requested"
+	ReturnStatusApproved  = "approved"
+	ReturnStatusReceived  = "received"
+	ReturnStatusRefunded  = "refunded"
+	ReturnStatusRejected  = "rejected"
+)
+
+// IsValidReturnStatus reports whether status is a known return status
+func IsValidReturnStatus(status string) bool {
+	switch status {
+	case ReturnStatusRequested, ReturnStatusApproved, ReturnStatusReceived,
+		ReturnStatusRefunded, ReturnStatusRejected:
+		return true
+	}
+	return false
+}
+
+// returnTransitions lists the statuses a return may move to from each status.
+// A return can be rejected until its items are received; refunded and
+// rejected returns are final.
+var returnTransitions = map[string][]string{
+	ReturnStatusRequested: {ReturnStatusApproved, ReturnStatusRejected},
+	ReturnStatusApproved:  {ReturnStatusReceived, ReturnStatusRejected},
+	ReturnStatusReceived:  {ReturnStatusRefunded},
+}
+
+// CanTransitionReturn reports whether a return in status from may move to status to
+func CanTransitionReturn(from, to string) bool {
+	for _, next := range returnTransitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// Return is a customer's request to send back items of a delivered order (an RMA).
+// The items go back to stock when received and are refunded after that.
+type Return struct {
+	ID              string       `db:"id" json:"id"`
+	OrderID         string       `db:"order_id" json:"order_id"`
+	UserID          int64        `db:"user_id" json:"user_id"`
+	Status          string       `db:"status" json:"status"`
+	Reason          string       `db:"reason" json:"reason"`
+	RejectionReason string       `db:"rejection_reason" json:"rejection_reason,omitempty"`
+	RefundAmount    float64      `db:"refund_amount" json:"refund_amount"` // Item prices less their share of the order discount
+	CreatedAt       time.Time    `db:"created_at" json:"created_at"`
+	UpdatedAt       time.Time    `db:"updated_at" json:"updated_at"`
+	Items           []ReturnItem `json:"items"`
+}
+
+// ReturnItem is a quantity of one ordered product being returned. Name, price
+// and bundle composition are copied from the order item.
+type ReturnItem struct {
+	ProductID   string               `db:"product_id" json:"product_id"`
+	ProductName string               `db:"product_name" json:"product_name"`
+	Quantity    int32                `db:"quantity" json:"quantity"`
+	Price       float64              `db:"price" json:"price"`
+	Components  []OrderItemComponent `db:"bundle_components" json:"components,omitempty"`
+}
+
+// StockItems returns what the returned items put back in stock, with bundles
+// replaced by their components
+func (r *Return) StockItems() []StockItem {
+	items := make([]OrderItem, len(r.Items))
+	for i, item := range r.Items {
+		items[i] = OrderItem{ProductID: item.ProductID, Quantity: item.Quantity, Components: item.Components}
+	}
+	return StockItems(items)
+}
+
+// ReturnFilter selects returns; zero fields match all
+type ReturnFilter struct {
+	UserID  int64
+	OrderID string
+	Status  string
+}
+
+// ReturnList is one page of a return listing
+type ReturnList struct {
+	Returns    []*Return `json:"returns"`
+	TotalCount int64     `json:"total_count"`
+	Page       int32     `json:"page"`
+	PageSize   int32     `json:"page_size"`
+	TotalPages int32     `json:"total_pages"`
+	HasMore    bool      `json:"has_more"`
+}
@@ -0,0 +1,51 @@
+package models
+
+import "testing"
+
+func TestCanTransitionReturn(t *testing.T) {
+	tests := []struct {
+		from, to string
+		want     bool
+	}{
+		{ReturnStatusRequested, ReturnStatusApproved, true},
+		{ReturnStatusRequested, ReturnStatusRejected, true},
+		{ReturnStatusApproved, ReturnStatusReceived, true},
+		{ReturnStatusApproved, ReturnStatusRejected, true},
+		{ReturnStatusReceived, ReturnStatusRefunded, true},
+		{ReturnStatusRequested, ReturnStatusReceived, false},
+		{ReturnStatusRequested, ReturnStatusRefunded, false},
+		{ReturnStatusReceived, ReturnStatusRejected, false},
+		{ReturnStatusRefunded, ReturnStatusReceived, false},
+		{ReturnStatusRejected, ReturnStatusApproved, false},
+	}
+
+	for _, tt := range tests {
+		if got := CanTransitionReturn(tt.from, tt.to); got != tt.want {
+			t.Errorf("CanTransitionReturn(%s, %s) = %v, want %v", tt.from, tt.to, got, tt.want)
+		}
+	}
+}
+
+func TestReturnStockItems(t *testing.T) {
+	ret := &Return{Items: []ReturnItem{
+		{ProductID: "camera-kit", Quantity: 1, Components: []OrderItemComponent{
+			{ProductID: "camera", Quantity: 1},
+			{ProductID: "memory-card", Quantity: 2},
+		}},
+		{ProductID: "memory-card", Quantity: 1},
+	}}
+
+	got := ret.StockItems()
+	want := []StockItem{
+		{ProductID: "camera", Quantity: 1},
+		{ProductID: "memory-card", Quantity: 3},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("StockItems() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("StockItems()[%d] = %v, want %v", i, got[i], want[i])
+		}
+	}
+}
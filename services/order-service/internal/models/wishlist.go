@@ -0,0 +1,17 @@
+package models
+
+import "time"
+
+type Wishlist struct {
+	UserID int64          `json:"user_id"`
+	Items  []WishlistItem `json:"items"`
+}
+
+type WishlistItem struct {
+	ID          string    `json:"id"`
+	UserID      int64     `json:"user_id"`
+	ProductID   string    `json:"product_id"`
+	ProductName string    `json:"product_name"`
+	Price       float64   `json:"price"` // Price when the item was saved
+	CreatedAt   time.Time `json:"created_at"`
+}
@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/datngth03/ecommerce-go-app/shared/pkg/domainerr"
+	"github.com/go-redis/redis/v8"
+	"github.com/google/uuid"
+)
+
+// Cart lock settings
+const (
+	cartLockTTL        = 5 * time.Second       // Lock expires on its own if the holder dies mid-mutation
+	cartLockWait       = 2 * time.Second       // How long a mutation retries before giving up
+	cartLockRetryDelay = 20 * time.Millisecond // Base delay between attempts, jittered
+)
+
+// ErrCartBusy is returned when the cart lock could not be acquired in time
+var ErrCartBusy = domainerr.Aborted("cart is busy, please retry")
+
+// releaseCartLock deletes the lock only if it is still held by the caller's token,
+// so a holder whose lock expired cannot release someone else's lock
+var releaseCartLock = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0`)
+
+// withCartLock runs fn while holding the user's cart lock.
+// Every cart mutation must go through it so concurrent requests cannot interleave.
+func withCartLock(ctx context.Context, redisClient *redis.Client, userID int64, fn func() error) error {
+	lockKey := fmt.Sprintf("lock:cart:user:%d", userID)
+	token := uuid.New().String()
+	deadline := time.Now().Add(cartLockWait)
+
+	for {
+		acquired, err := redisClient.SetNX(ctx, lockKey, token, cartLockTTL).Result()
+		if err != nil {
+			return fmt.Errorf("failed to acquire cart lock: %w", err)
+		}
+		if acquired {
+			break
+		}
+		if time.Now().After(deadline) {
+			return ErrCartBusy
+		}
+
+		delay := cartLockRetryDelay + time.Duration(rand.Int63n(int64(cartLockRetryDelay)))
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+		}
+	}
+
+	defer func() {
+		// Release even if ctx was cancelled while fn ran
+		releaseCtx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		releaseCartLock.Run(releaseCtx, redisClient, []string{lockKey}, token)
+	}()
+
+	return fn()
+}
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/datngth03/ecommerce-go-app/services/order-service/internal/models"
+	"github.com/datngth03/ecommerce-go-app/shared/pkg/domainerr"
 	"github.com/go-redis/redis/v8"
 	"github.com/google/uuid"
 )
@@ -81,140 +82,250 @@ func (r *CartPostgresRepository) Get(ctx context.Context, userID int64) (*models
 	return cart, nil
 }
 
-// AddItem adds or updates item in cart
-func (r *CartPostgresRepository) AddItem(ctx context.Context, userID int64, item *models.CartItem) (*models.Cart, error) {
-	cart, err := r.Get(ctx, userID)
-	if err != nil {
-		return nil, err
-	}
+// AddItem adds or updates item in cart, within limits
+func (r *CartPostgresRepository) AddItem(ctx context.Context, userID int64, item *models.CartItem, limits models.CartLimits) (*models.Cart, error) {
+	err := withCartLock(ctx, r.redisClient, userID, func() error {
+		cart, err := r.Get(ctx, userID)
+		if err != nil {
+			return err
+		}
 
-	// Check if item already exists
-	var existingItem *models.CartItem
-	for i := range cart.Items {
-		if cart.Items[i].ProductID == item.ProductID {
-			existingItem = &cart.Items[i]
-			break
+		// Checked under the cart lock so concurrent adds cannot overshoot the limits
+		if err := limits.CheckAdd(len(cart.Items), cartItemQuantity(cart, item.ProductID), item.Quantity); err != nil {
+			return err
 		}
-	}
 
-	if existingItem != nil {
-		// Update quantity
-		existingItem.Quantity += item.Quantity
-		query := `
-			UPDATE cart_items 
-			SET quantity = $1, updated_at = NOW()
-			WHERE id = $2`
-		_, err = r.db.ExecContext(ctx, query, existingItem.Quantity, existingItem.ID)
-	} else {
-		// Insert new item
+		// Increment in SQL so the new quantity never depends on a cached copy of the cart
 		item.ID = uuid.New().String()
 		item.CartID = cart.ID
 		query := `
 			INSERT INTO cart_items (id, cart_id, product_id, product_name, quantity, price, created_at, updated_at)
-			VALUES ($1, $2, $3, $4, $5, $6, NOW(), NOW())`
+			VALUES ($1, $2, $3, $4, $5, $6, NOW(), NOW())
+			ON CONFLICT (cart_id, product_id)
+			DO UPDATE SET quantity = cart_items.quantity + EXCLUDED.quantity, updated_at = NOW()`
 		_, err = r.db.ExecContext(ctx, query,
 			item.ID, item.CartID, item.ProductID, item.ProductName, item.Quantity, item.Price)
-		if err == nil {
-			cart.Items = append(cart.Items, *item)
+		if err != nil {
+			return fmt.Errorf("failed to add item to cart: %w", err)
 		}
-	}
 
+		// Update cart timestamp
+		r.db.ExecContext(ctx, "UPDATE carts SET updated_at = NOW() WHERE id = $1", cart.ID)
+
+		// Invalidate cache
+		r.invalidateCache(ctx, userID)
+		return nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to add item to cart: %w", err)
+		return nil, err
 	}
 
-	// Update cart timestamp
-	r.db.ExecContext(ctx, "UPDATE carts SET updated_at = NOW() WHERE id = $1", cart.ID)
-
-	// Invalidate cache
-	r.invalidateCache(ctx, userID)
-
 	return r.Get(ctx, userID)
 }
 
-// UpdateItem updates item quantity in cart
-func (r *CartPostgresRepository) UpdateItem(ctx context.Context, userID int64, productID string, quantity int32) (*models.Cart, error) {
+// AddItems adds several items to the cart in one transaction. Each item is checked against
+// the limits as if the previous ones were already added; the ones that do not fit are
+// skipped and returned as failures instead of failing the whole batch.
+func (r *CartPostgresRepository) AddItems(ctx context.Context, userID int64, items []models.CartItem, limits models.CartLimits) (*models.Cart, []models.CartItemFailure, error) {
+	var failures []models.CartItemFailure
+
+	err := withCartLock(ctx, r.redisClient, userID, func() error {
+		cart, err := r.Get(ctx, userID)
+		if err != nil {
+			return err
+		}
+
+		quantities := make(map[string]int32, len(cart.Items)+len(items))
+		for _, item := range cart.Items {
+			quantities[item.ProductID] = item.Quantity
+		}
+
+		tx, err := r.db.BeginTx(ctx, nil)
+		if err != nil {
+			return fmt.Errorf("failed to begin transaction: %w", err)
+		}
+		defer tx.Rollback()
+
+		query := `
+			INSERT INTO cart_items (id, cart_id, product_id, product_name, quantity, price, created_at, updated_at)
+			VALUES ($1, $2, $3, $4, $5, $6, NOW(), NOW())
+			ON CONFLICT (cart_id, product_id)
+			DO UPDATE SET quantity = cart_items.quantity + EXCLUDED.quantity, updated_at = NOW()`
+
+		added := 0
+		for _, item := range items {
+			if err := limits.CheckAdd(len(quantities), quantities[item.ProductID], item.Quantity); err != nil {
+				failures = append(failures, models.CartItemFailure{ProductID: item.ProductID, Reason: err.Error()})
+				continue
+			}
+
+			_, err = tx.ExecContext(ctx, query,
+				uuid.New().String(), cart.ID, item.ProductID, item.ProductName, item.Quantity, item.Price)
+			if err != nil {
+				return fmt.Errorf("failed to add item to cart: %w", err)
+			}
+			quantities[item.ProductID] += item.Quantity
+			added++
+		}
+
+		if added == 0 {
+			return nil
+		}
+
+		if _, err := tx.ExecContext(ctx, "UPDATE carts SET updated_at = NOW() WHERE id = $1", cart.ID); err != nil {
+			return fmt.Errorf("failed to update cart: %w", err)
+		}
+		if err := tx.Commit(); err != nil {
+			return fmt.Errorf("failed to commit transaction: %w", err)
+		}
+
+		// Invalidate cache
+		r.invalidateCache(ctx, userID)
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
 	cart, err := r.Get(ctx, userID)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
+	return cart, failures, nil
+}
 
-	var itemID string
-	for _, item := range cart.Items {
-		if item.ProductID == productID {
-			itemID = item.ID
-			break
+// UpdateItem updates item quantity in cart, within limits
+func (r *CartPostgresRepository) UpdateItem(ctx context.Context, userID int64, productID string, quantity int32, limits models.CartLimits) (*models.Cart, error) {
+	err := withCartLock(ctx, r.redisClient, userID, func() error {
+		cart, err := r.Get(ctx, userID)
+		if err != nil {
+			return err
 		}
-	}
 
-	if itemID == "" {
-		return nil, fmt.Errorf("item not found in cart")
-	}
+		if err := limits.CheckUpdate(cartItemQuantity(cart, productID), quantity); err != nil {
+			return err
+		}
 
-	query := `
-		UPDATE cart_items 
-		SET quantity = $1, updated_at = NOW()
-		WHERE id = $2 AND cart_id = $3`
+		query := `
+			UPDATE cart_items 
+			SET quantity = $1, updated_at = NOW()
+			WHERE cart_id = $2 AND product_id = $3`
 
-	result, err := r.db.ExecContext(ctx, query, quantity, itemID, cart.ID)
+		result, err := r.db.ExecContext(ctx, query, quantity, cart.ID, productID)
+		if err != nil {
+			return fmt.Errorf("failed to update cart item: %w", err)
+		}
+
+		rowsAffected, _ := result.RowsAffected()
+		if rowsAffected == 0 {
+			return domainerr.NotFound("item not found in cart")
+		}
+
+		// Invalidate cache
+		r.invalidateCache(ctx, userID)
+		return nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to update cart item: %w", err)
+		return nil, err
 	}
 
-	rowsAffected, _ := result.RowsAffected()
-	if rowsAffected == 0 {
-		return nil, fmt.Errorf("item not found")
-	}
+	return r.Get(ctx, userID)
+}
 
-	// Invalidate cache
-	r.invalidateCache(ctx, userID)
+// UpdatePrices sets the stored price of the given products in the cart, keyed by product ID
+func (r *CartPostgresRepository) UpdatePrices(ctx context.Context, userID int64, prices map[string]float64) error {
+	return withCartLock(ctx, r.redisClient, userID, func() error {
+		cart, err := r.Get(ctx, userID)
+		if err != nil {
+			return err
+		}
 
-	return r.Get(ctx, userID)
+		tx, err := r.db.BeginTx(ctx, nil)
+		if err != nil {
+			return fmt.Errorf("failed to begin transaction: %w", err)
+		}
+		defer tx.Rollback()
+
+		query := `
+			UPDATE cart_items
+			SET price = $1, updated_at = NOW()
+			WHERE cart_id = $2 AND product_id = $3`
+
+		for productID, price := range prices {
+			if _, err := tx.ExecContext(ctx, query, price, cart.ID, productID); err != nil {
+				return fmt.Errorf("failed to update cart item price: %w", err)
+			}
+		}
+		if err := tx.Commit(); err != nil {
+			return fmt.Errorf("failed to commit transaction: %w", err)
+		}
+
+		// Invalidate cache
+		r.invalidateCache(ctx, userID)
+		return nil
+	})
 }
 
 // RemoveItem removes item from cart
 func (r *CartPostgresRepository) RemoveItem(ctx context.Context, userID int64, productID string) (*models.Cart, error) {
-	cart, err := r.Get(ctx, userID)
-	if err != nil {
-		return nil, err
-	}
+	err := withCartLock(ctx, r.redisClient, userID, func() error {
+		cart, err := r.Get(ctx, userID)
+		if err != nil {
+			return err
+		}
 
-	query := `
-		DELETE FROM cart_items 
-		WHERE cart_id = $1 AND product_id = $2`
+		query := `
+			DELETE FROM cart_items 
+			WHERE cart_id = $1 AND product_id = $2`
 
-	_, err = r.db.ExecContext(ctx, query, cart.ID, productID)
+		_, err = r.db.ExecContext(ctx, query, cart.ID, productID)
+		if err != nil {
+			return fmt.Errorf("failed to remove item from cart: %w", err)
+		}
+
+		// Invalidate cache
+		r.invalidateCache(ctx, userID)
+		return nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to remove item from cart: %w", err)
+		return nil, err
 	}
 
-	// Invalidate cache
-	r.invalidateCache(ctx, userID)
-
 	return r.Get(ctx, userID)
 }
 
 // Clear removes all items from cart
 func (r *CartPostgresRepository) Clear(ctx context.Context, userID int64) error {
-	cart, err := r.Get(ctx, userID)
-	if err != nil {
-		return err
-	}
-
-	query := `DELETE FROM cart_items WHERE cart_id = $1`
-	_, err = r.db.ExecContext(ctx, query, cart.ID)
-	if err != nil {
-		return fmt.Errorf("failed to clear cart: %w", err)
-	}
+	return withCartLock(ctx, r.redisClient, userID, func() error {
+		cart, err := r.Get(ctx, userID)
+		if err != nil {
+			return err
+		}
 
-	// Invalidate cache
-	r.invalidateCache(ctx, userID)
+		query := `DELETE FROM cart_items WHERE cart_id = $1`
+		_, err = r.db.ExecContext(ctx, query, cart.ID)
+		if err != nil {
+			return fmt.Errorf("failed to clear cart: %w", err)
+		}
 
-	return nil
+		// Invalidate cache
+		r.invalidateCache(ctx, userID)
+		return nil
+	})
 }
 
 // Helper methods
 
+// cartItemQuantity returns the quantity of productID in cart, 0 if absent
+func cartItemQuantity(cart *models.Cart, productID string) int32 {
+	for _, item := range cart.Items {
+		if item.ProductID == productID {
+			return item.Quantity
+		}
+	}
+	return 0
+}
+
 func (r *CartPostgresRepository) createCart(ctx context.Context, userID int64) (*models.Cart, error) {
 	cart := &models.Cart{
 		ID:     uuid.New().String(),
@@ -222,13 +333,15 @@ func (r *CartPostgresRepository) createCart(ctx context.Context, userID int64) (
 		Items:  []models.CartItem{},
 	}
 
+	// A concurrent request may have created the cart already; return that one
 	query := `
 		INSERT INTO carts (id, user_id, created_at, updated_at)
 		VALUES ($1, $2, NOW(), NOW())
-		RETURNING created_at, updated_at`
+		ON CONFLICT (user_id) DO UPDATE SET user_id = EXCLUDED.user_id
+		RETURNING id, created_at, updated_at`
 
 	err := r.db.QueryRowContext(ctx, query, cart.ID, cart.UserID).Scan(
-		&cart.CreatedAt, &cart.UpdatedAt,
+		&cart.ID, &cart.CreatedAt, &cart.UpdatedAt,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create cart: %w", err)
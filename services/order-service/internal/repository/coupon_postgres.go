@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/datngth03/ecommerce-go-app/services/order-service/internal/models"
+	"github.com/datngth03/ecommerce-go-app/shared/pkg/domainerr"
+)
+
+type CouponPostgresRepository struct {
+	db *sql.DB
+}
+
+func NewCouponPostgresRepository(db *sql.DB) *CouponPostgresRepository {
+	return &CouponPostgresRepository{db: db}
+}
+
+// GetByCode retrieves a coupon by its (case-insensitive) code
+func (r *CouponPostgresRepository) GetByCode(ctx context.Context, code string) (*models.Coupon, error) {
+	coupon := &models.Coupon{}
+
+	query := `
+		SELECT id, code, discount_type, discount_value, min_order_amount, usage_limit, used_count,
+		       starts_at, expires_at, is_active, created_at, updated_at
+		FROM coupons WHERE code = $1`
+
+	var usageLimit sql.NullInt32
+	var startsAt, expiresAt sql.NullTime
+
+	err := r.db.QueryRowContext(ctx, query, strings.ToUpper(code)).Scan(
+		&coupon.ID, &coupon.Code, &coupon.DiscountType, &coupon.DiscountValue,
+		&coupon.MinOrderAmount, &usageLimit, &coupon.UsedCount,
+		&startsAt, &expiresAt, &coupon.IsActive, &coupon.CreatedAt, &coupon.UpdatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, domainerr.NotFound("coupon not found")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get coupon: %w", err)
+	}
+
+	if usageLimit.Valid {
+		coupon.UsageLimit = &usageLimit.Int32
+	}
+	if startsAt.Valid {
+		coupon.StartsAt = &startsAt.Time
+	}
+	if expiresAt.Valid {
+		coupon.ExpiresAt = &expiresAt.Time
+	}
+
+	return coupon, nil
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/datngth03/ecommerce-go-app/services/order-service/internal/models"
 )
@@ -9,15 +10,59 @@ import (
 type OrderRepository interface {
 	Create(ctx context.Context, order *models.Order) (*models.Order, error)
 	GetByID(ctx context.Context, id string) (*models.Order, error)
+	GetByNumber(ctx context.Context, orderNumber string) (*models.Order, error)
+	// NextOrderSequence returns a sequence value no other order gets, for its order number
+	NextOrderSequence(ctx context.Context) (int64, error)
 	List(ctx context.Context, userID int64, page, pageSize int32, status string) ([]*models.Order, int64, error)
-	UpdateStatus(ctx context.Context, id, status string) (*models.Order, error)
-	Cancel(ctx context.Context, id string, userID int64) error
+	// ListByFilter lists orders of all users; the filter status is required
+	ListByFilter(ctx context.Context, filter models.OrderFilter, page, pageSize int32) ([]*models.Order, int64, error)
+	// UpdateStatus applies a status transition under a row lock and also returns the previous status.
+	// A non-empty from additionally requires the order to still be in that status.
+	UpdateStatus(ctx context.Context, id string, userID int64, from, status string) (*models.Order, string, error)
+	// RestoreStatus undoes a status change whose follow-up step failed, if nothing changed the order since
+	RestoreStatus(ctx context.Context, id, from, to string) error
+	// StatusChangedAt returns when the order last entered status, from its status history
+	StatusChangedAt(ctx context.Context, id, status string) (time.Time, error)
+}
+
+// OrderNoteRepository stores support notes on orders, separate from the order itself
+type OrderNoteRepository interface {
+	Create(ctx context.Context, note *models.OrderNote) error
+	// ListByOrder returns the notes of an order oldest first; internal ones only when includeInternal
+	ListByOrder(ctx context.Context, orderID string, includeInternal bool) ([]models.OrderNote, error)
+}
+
+// ReturnRepository stores return requests (RMAs) and their items
+type ReturnRepository interface {
+	// Create rejects items beyond what is ordered and not already in an open return, and
+	// caps the refund at refundable less the refunds of other returns still open
+	Create(ctx context.Context, ret *models.Return, refundable float64) error
+	GetByID(ctx context.Context, id string) (*models.Return, error)
+	List(ctx context.Context, filter models.ReturnFilter, page, pageSize int32) ([]*models.Return, int64, error)
+	// UpdateStatus applies a status change only if the return is still in status from
+	UpdateStatus(ctx context.Context, id, from, to, rejectionReason string) (*models.Return, error)
+}
+
+type CouponRepository interface {
+	GetByCode(ctx context.Context, code string) (*models.Coupon, error)
 }
 
 type CartRepository interface {
 	Get(ctx context.Context, userID int64) (*models.Cart, error)
-	AddItem(ctx context.Context, userID int64, item *models.CartItem) (*models.Cart, error)
-	UpdateItem(ctx context.Context, userID int64, productID string, quantity int32) (*models.Cart, error)
+	AddItem(ctx context.Context, userID int64, item *models.CartItem, limits models.CartLimits) (*models.Cart, error)
+	// AddItems adds several items in one transaction; items over the limits are skipped and reported
+	AddItems(ctx context.Context, userID int64, items []models.CartItem, limits models.CartLimits) (*models.Cart, []models.CartItemFailure, error)
+	UpdateItem(ctx context.Context, userID int64, productID string, quantity int32, limits models.CartLimits) (*models.Cart, error)
+	// UpdatePrices sets the stored price of cart items, keyed by product ID
+	UpdatePrices(ctx context.Context, userID int64, prices map[string]float64) error
 	RemoveItem(ctx context.Context, userID int64, productID string) (*models.Cart, error)
 	Clear(ctx context.Context, userID int64) error
 }
+
+type WishlistRepository interface {
+	Get(ctx context.Context, userID int64) (*models.Wishlist, error)
+	AddItem(ctx context.Context, userID int64, item *models.WishlistItem) (*models.Wishlist, error)
+	RemoveItem(ctx context.Context, userID int64, productID string) (*models.Wishlist, error)
+	// MoveToCart adds item to the user's cart and removes it from the wishlist in one transaction
+	MoveToCart(ctx context.Context, userID int64, item *models.CartItem, limits models.CartLimits) error
+}
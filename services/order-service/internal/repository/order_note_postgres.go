@@ -0,0 +1,57 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/datngth03/ecommerce-go-app/services/order-service/internal/models"
+)
+
+type OrderNotePostgresRepository struct {
+	db *sql.DB
+}
+
+func NewOrderNotePostgresRepository(db *sql.DB) *OrderNotePostgresRepository {
+	return &OrderNotePostgresRepository{db: db}
+}
+
+// Create stores a note and fills in its ID and creation time
+func (r *OrderNotePostgresRepository) Create(ctx context.Context, note *models.OrderNote) error {
+	query := `
+		INSERT INTO order_notes (order_id, author_id, body, is_internal)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at`
+
+	err := r.db.QueryRowContext(ctx, query, note.OrderID, note.AuthorID, note.Body, note.IsInternal).
+		Scan(&note.ID, &note.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create order note: %w", err)
+	}
+	return nil
+}
+
+// ListByOrder returns the notes of an order, oldest first
+func (r *OrderNotePostgresRepository) ListByOrder(ctx context.Context, orderID string, includeInternal bool) ([]models.OrderNote, error) {
+	query := `
+		SELECT id, order_id, author_id, body, is_internal, created_at
+		FROM order_notes
+		WHERE order_id = $1 AND ($2 OR NOT is_internal)
+		ORDER BY created_at, id`
+
+	rows, err := r.db.QueryContext(ctx, query, orderID, includeInternal)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list order notes: %w", err)
+	}
+	defer rows.Close()
+
+	notes := []models.OrderNote{}
+	for rows.Next() {
+		var note models.OrderNote
+		if err := rows.Scan(&note.ID, &note.OrderID, &note.AuthorID, &note.Body, &note.IsInternal, &note.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan order note: %w", err)
+		}
+		notes = append(notes, note)
+	}
+	return notes, rows.Err()
+}
@@ -3,19 +3,26 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
 	"time"
 
+	"github.com/datngth03/ecommerce-go-app/services/order-service/internal/metrics"
 	"github.com/datngth03/ecommerce-go-app/services/order-service/internal/models"
+	"github.com/datngth03/ecommerce-go-app/shared/pkg/dbquery"
+	"github.com/datngth03/ecommerce-go-app/shared/pkg/domainerr"
 	"github.com/google/uuid"
 )
 
+// OrderPostgresRepository stores orders in PostgreSQL. Queries are timed per
+// operation by q, and the hot order reads run as cached prepared statements.
 type OrderPostgresRepository struct {
 	db *sql.DB
+	q  *dbquery.Instrumenter
 }
 
 func NewOrderPostgresRepository(db *sql.DB) *OrderPostgresRepository {
-	return &OrderPostgresRepository{db: db}
+	return &OrderPostgresRepository{db: db, q: dbquery.New(metrics.RecordDBOperation)}
 }
 
 func (r *OrderPostgresRepository) Create(ctx context.Context, order *models.Order) (*models.Order, error) {
@@ -28,33 +35,54 @@ func (r *OrderPostgresRepository) Create(ctx context.Context, order *models.Orde
 	defer tx.Rollback()
 
 	query := `
-		INSERT INTO orders (id, user_id, status, total_amount, shipping_address, payment_method, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, NOW(), NOW())
+		INSERT INTO orders (id, order_number, user_id, status, subtotal_amount, discount_amount, coupon_code, total_amount,
+		                    shipping_address, payment_method, is_gift, gift_message, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, NOW(), NOW())
 		RETURNING created_at, updated_at`
 
-	err = tx.QueryRowContext(ctx, query,
-		order.ID, order.UserID, order.Status, order.TotalAmount,
-		order.ShippingAddress, order.PaymentMethod,
-	).Scan(&order.CreatedAt, &order.UpdatedAt)
+	err = r.q.Do("orders.create", func() error {
+		return tx.QueryRowContext(ctx, query,
+			order.ID, order.OrderNumber, order.UserID, order.Status, order.SubtotalAmount, order.DiscountAmount, order.CouponCode,
+			order.TotalAmount, order.ShippingAddress, order.PaymentMethod, order.IsGift, order.GiftMessage,
+		).Scan(&order.CreatedAt, &order.UpdatedAt)
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to create order: %w", err)
 	}
 
+	// Redeem the coupon in the same transaction so usage limits hold under concurrency
+	if order.CouponID != "" {
+		if err = redeemCoupon(ctx, tx, order); err != nil {
+			return nil, err
+		}
+	}
+
 	// Insert order items
 	itemQuery := `
-		INSERT INTO order_items (id, order_id, product_id, product_name, quantity, price, subtotal, created_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, NOW())`
+		INSERT INTO order_items (id, order_id, product_id, product_name, quantity, price, subtotal, bundle_components, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, NOW())`
 
 	for i := range order.Items {
 		order.Items[i].ID = uuid.New().String()
 		order.Items[i].OrderID = order.ID
-		order.Items[i].Subtotal = float64(order.Items[i].Quantity) * order.Items[i].Price
 
-		_, err = tx.ExecContext(ctx, itemQuery,
-			order.Items[i].ID, order.Items[i].OrderID, order.Items[i].ProductID,
-			order.Items[i].ProductName, order.Items[i].Quantity, order.Items[i].Price,
-			order.Items[i].Subtotal,
-		)
+		// Regular products store NULL
+		var components []byte
+		if len(order.Items[i].Components) > 0 {
+			components, err = json.Marshal(order.Items[i].Components)
+			if err != nil {
+				return nil, fmt.Errorf("failed to encode bundle components: %w", err)
+			}
+		}
+
+		err = r.q.Do("orders.create_item", func() error {
+			_, err := tx.ExecContext(ctx, itemQuery,
+				order.Items[i].ID, order.Items[i].OrderID, order.Items[i].ProductID,
+				order.Items[i].ProductName, order.Items[i].Quantity, order.Items[i].Price,
+				order.Items[i].Subtotal, components,
+			)
+			return err
+		})
 		if err != nil {
 			return nil, fmt.Errorf("failed to create order item: %w", err)
 		}
@@ -67,20 +95,60 @@ func (r *OrderPostgresRepository) Create(ctx context.Context, order *models.Orde
 	return order, nil
 }
 
+// redeemCoupon increments the coupon usage if it is still redeemable and records it for the order
+func redeemCoupon(ctx context.Context, tx *sql.Tx, order *models.Order) error {
+	query := `
+		UPDATE coupons SET used_count = used_count + 1, updated_at = NOW()
+		WHERE id = $1 AND is_active
+		  AND (usage_limit IS NULL OR used_count < usage_limit)
+		  AND (starts_at IS NULL OR starts_at <= NOW())
+		  AND (expires_at IS NULL OR expires_at > NOW())`
+
+	result, err := tx.ExecContext(ctx, query, order.CouponID)
+	if err != nil {
+		return fmt.Errorf("failed to redeem coupon: %w", err)
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		return domainerr.InvalidArgument("invalid coupon: %s has expired or reached its usage limit", order.CouponCode)
+	}
+
+	usageQuery := `
+		INSERT INTO coupon_usages (id, coupon_id, order_id, user_id, discount_amount, created_at)
+		VALUES ($1, $2, $3, $4, $5, NOW())`
+
+	_, err = tx.ExecContext(ctx, usageQuery,
+		uuid.New().String(), order.CouponID, order.ID, order.UserID, order.DiscountAmount)
+	if err != nil {
+		return fmt.Errorf("failed to record coupon usage: %w", err)
+	}
+
+	return nil
+}
+
 func (r *OrderPostgresRepository) GetByID(ctx context.Context, id string) (*models.Order, error) {
 	order := &models.Order{}
 
 	query := `
-		SELECT id, user_id, status, total_amount, shipping_address, payment_method, created_at, updated_at
+		SELECT id, order_number, user_id, status, subtotal_amount, discount_amount, coupon_code, total_amount,
+		       shipping_address, payment_method, is_gift, gift_message, created_at, updated_at
 		FROM orders WHERE id = $1`
 
-	err := r.db.QueryRowContext(ctx, query, id).Scan(
-		&order.ID, &order.UserID, &order.Status, &order.TotalAmount,
-		&order.ShippingAddress, &order.PaymentMethod,
-		&order.CreatedAt, &order.UpdatedAt,
-	)
+	err := r.q.Do("orders.get_by_id", func() error {
+		stmt, err := r.q.Prepare(ctx, r.db, query)
+		if err != nil {
+			return err
+		}
+		return stmt.QueryRowContext(ctx, id).Scan(
+			&order.ID, &order.OrderNumber, &order.UserID, &order.Status,
+			&order.SubtotalAmount, &order.DiscountAmount, &order.CouponCode, &order.TotalAmount,
+			&order.ShippingAddress, &order.PaymentMethod, &order.IsGift, &order.GiftMessage,
+			&order.CreatedAt, &order.UpdatedAt,
+		)
+	})
 	if err == sql.ErrNoRows {
-		return nil, fmt.Errorf("order not found")
+		return nil, domainerr.NotFound("order not found")
 	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to get order: %w", err)
@@ -88,28 +156,72 @@ func (r *OrderPostgresRepository) GetByID(ctx context.Context, id string) (*mode
 
 	// Get order items
 	itemQuery := `
-		SELECT id, order_id, product_id, product_name, quantity, price, subtotal, created_at
+		SELECT id, order_id, product_id, product_name, quantity, price, subtotal, bundle_components, created_at
 		FROM order_items WHERE order_id = $1 ORDER BY created_at`
 
-	rows, err := r.db.QueryContext(ctx, itemQuery, id)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get order items: %w", err)
-	}
-	defer rows.Close()
-
-	for rows.Next() {
-		var item models.OrderItem
-		err = rows.Scan(&item.ID, &item.OrderID, &item.ProductID, &item.ProductName,
-			&item.Quantity, &item.Price, &item.Subtotal, &item.CreatedAt)
+	err = r.q.Do("orders.get_items", func() error {
+		stmt, err := r.q.Prepare(ctx, r.db, itemQuery)
 		if err != nil {
-			return nil, fmt.Errorf("failed to scan order item: %w", err)
+			return fmt.Errorf("failed to get order items: %w", err)
+		}
+		rows, err := stmt.QueryContext(ctx, id)
+		if err != nil {
+			return fmt.Errorf("failed to get order items: %w", err)
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			var item models.OrderItem
+			var components []byte
+			err = rows.Scan(&item.ID, &item.OrderID, &item.ProductID, &item.ProductName,
+				&item.Quantity, &item.Price, &item.Subtotal, &components, &item.CreatedAt)
+			if err != nil {
+				return fmt.Errorf("failed to scan order item: %w", err)
+			}
+			if components != nil {
+				if err := json.Unmarshal(components, &item.Components); err != nil {
+					return fmt.Errorf("failed to decode bundle components: %w", err)
+				}
+			}
+			order.Items = append(order.Items, item)
 		}
-		order.Items = append(order.Items, item)
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return order, nil
 }
 
+// GetByNumber returns the order with a human-friendly order number
+func (r *OrderPostgresRepository) GetByNumber(ctx context.Context, orderNumber string) (*models.Order, error) {
+	var id string
+	err := r.q.Do("orders.get_by_number", func() error {
+		return r.db.QueryRowContext(ctx, `SELECT id FROM orders WHERE order_number = $1`, orderNumber).Scan(&id)
+	})
+	if err == sql.ErrNoRows {
+		return nil, domainerr.NotFound("order not found")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get order by number: %w", err)
+	}
+	return r.GetByID(ctx, id)
+}
+
+// NextOrderSequence takes the next value of the order number sequence. Values
+// are never handed out twice, also under concurrent checkouts.
+func (r *OrderPostgresRepository) NextOrderSequence(ctx context.Context) (int64, error) {
+	var seq int64
+	err := r.q.Do("orders.next_number", func() error {
+		return r.db.QueryRowContext(ctx, `SELECT nextval('order_number_seq')`).Scan(&seq)
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to generate order number: %w", err)
+	}
+	return seq, nil
+}
+
 func (r *OrderPostgresRepository) List(ctx context.Context, userID int64, page, pageSize int32, status string) ([]*models.Order, int64, error) {
 	offset := (page - 1) * pageSize
 
@@ -122,14 +234,21 @@ func (r *OrderPostgresRepository) List(ctx context.Context, userID int64, page,
 	}
 
 	var total int64
-	err := r.db.QueryRowContext(ctx, countQuery, args...).Scan(&total)
+	err := r.q.Do("orders.count_by_user", func() error {
+		stmt, err := r.q.Prepare(ctx, r.db, countQuery)
+		if err != nil {
+			return err
+		}
+		return stmt.QueryRowContext(ctx, args...).Scan(&total)
+	})
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to count orders: %w", err)
 	}
 
 	// Get orders
 	query := `
-		SELECT id, user_id, status, total_amount, shipping_address, payment_method, created_at, updated_at
+		SELECT id, order_number, user_id, status, subtotal_amount, discount_amount, coupon_code, total_amount,
+		       shipping_address, payment_method, is_gift, gift_message, created_at, updated_at
 		FROM orders WHERE user_id = $1`
 	if status != "" {
 		query += ` AND status = $2`
@@ -137,50 +256,171 @@ func (r *OrderPostgresRepository) List(ctx context.Context, userID int64, page,
 	query += ` ORDER BY created_at DESC LIMIT $` + fmt.Sprintf("%d", len(args)+1) + ` OFFSET $` + fmt.Sprintf("%d", len(args)+2)
 	args = append(args, pageSize, offset)
 
+	var orders []*models.Order
+	err = r.q.Do("orders.list_by_user", func() error {
+		stmt, err := r.q.Prepare(ctx, r.db, query)
+		if err != nil {
+			return fmt.Errorf("failed to list orders: %w", err)
+		}
+		rows, err := stmt.QueryContext(ctx, args...)
+		if err != nil {
+			return fmt.Errorf("failed to list orders: %w", err)
+		}
+		defer rows.Close()
+
+		orders, err = scanOrders(rows)
+		return err
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	return orders, total, nil
+}
+
+// ListByFilter lists orders of all users, oldest update first, so the orders
+// that have waited longest come first
+func (r *OrderPostgresRepository) ListByFilter(ctx context.Context, filter models.OrderFilter, page, pageSize int32) ([]*models.Order, int64, error) {
+	where := ` WHERE status = $1`
+	args := []interface{}{filter.Status}
+	addBound := func(cond string, t time.Time) {
+		if !t.IsZero() {
+			args = append(args, t)
+			where += fmt.Sprintf(" AND %s $%d", cond, len(args))
+		}
+	}
+	addBound("created_at >=", filter.CreatedAfter)
+	addBound("created_at <", filter.CreatedBefore)
+	addBound("updated_at >=", filter.UpdatedAfter)
+	addBound("updated_at <", filter.UpdatedBefore)
+
+	var total int64
+	if err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM orders`+where, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count orders: %w", err)
+	}
+
+	query := `
+		SELECT id, order_number, user_id, status, subtotal_amount, discount_amount, coupon_code, total_amount,
+		       shipping_address, payment_method, is_gift, gift_message, created_at, updated_at
+		FROM orders` + where +
+		fmt.Sprintf(" ORDER BY updated_at, id LIMIT $%d OFFSET $%d", len(args)+1, len(args)+2)
+	args = append(args, pageSize, (page-1)*pageSize)
+
 	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to list orders: %w", err)
 	}
 	defer rows.Close()
 
+	orders, err := scanOrders(rows)
+	if err != nil {
+		return nil, 0, err
+	}
+	return orders, total, nil
+}
+
+// scanOrders reads order rows without their items
+func scanOrders(rows *sql.Rows) ([]*models.Order, error) {
 	orders := []*models.Order{}
 	for rows.Next() {
 		order := &models.Order{}
-		err = rows.Scan(&order.ID, &order.UserID, &order.Status, &order.TotalAmount,
-			&order.ShippingAddress, &order.PaymentMethod, &order.CreatedAt, &order.UpdatedAt)
+		err := rows.Scan(&order.ID, &order.OrderNumber, &order.UserID, &order.Status,
+			&order.SubtotalAmount, &order.DiscountAmount, &order.CouponCode, &order.TotalAmount,
+			&order.ShippingAddress, &order.PaymentMethod, &order.IsGift, &order.GiftMessage,
+			&order.CreatedAt, &order.UpdatedAt)
 		if err != nil {
-			return nil, 0, fmt.Errorf("failed to scan order: %w", err)
+			return nil, fmt.Errorf("failed to scan order: %w", err)
 		}
 		orders = append(orders, order)
 	}
-
-	return orders, total, nil
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read orders: %w", err)
+	}
+	return orders, nil
 }
 
-func (r *OrderPostgresRepository) UpdateStatus(ctx context.Context, id, status string) (*models.Order, error) {
-	query := `UPDATE orders SET status = $1, updated_at = NOW() WHERE id = $2`
-	_, err := r.db.ExecContext(ctx, query, status, id)
+// UpdateStatus moves an order to status and returns it with its previous status.
+// The row is locked for the check and the update, so of two concurrent changes
+// (e.g. cancel and confirm) only one can leave the current status.
+func (r *OrderPostgresRepository) UpdateStatus(ctx context.Context, id string, userID int64, from, status string) (*models.Order, string, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to update order status: %w", err)
+		return nil, "", fmt.Errorf("failed to begin transaction: %w", err)
 	}
-	return r.GetByID(ctx, id)
-}
+	defer tx.Rollback()
 
-func (r *OrderPostgresRepository) Cancel(ctx context.Context, id string, userID int64) error {
-	query := `UPDATE orders SET status = $1, updated_at = NOW() WHERE id = $2 AND user_id = $3 AND status = $4`
-	result, err := r.db.ExecContext(ctx, query, models.OrderStatusCancelled, id, userID, models.OrderStatusPending)
+	var ownerID int64
+	var previous string
+	err = tx.QueryRowContext(ctx, `SELECT user_id, status FROM orders WHERE id = $1 FOR UPDATE`, id).Scan(&ownerID, &previous)
+	if err == sql.ErrNoRows || (err == nil && ownerID != userID) {
+		return nil, "", domainerr.NotFound("order not found")
+	}
 	if err != nil {
-		return fmt.Errorf("failed to cancel order: %w", err)
+		return nil, "", fmt.Errorf("failed to lock order: %w", err)
 	}
 
-	rowsAffected, _ := result.RowsAffected()
-	if rowsAffected == 0 {
-		return fmt.Errorf("order not found or cannot be cancelled")
+	if (from != "" && previous != from) || !models.CanTransition(previous, status) {
+		return nil, "", domainerr.Conflict("invalid status transition from %s to %s", previous, status)
 	}
 
+	err = r.q.Do("orders.update_status", func() error {
+		_, err := tx.ExecContext(ctx, `UPDATE orders SET status = $1, updated_at = NOW() WHERE id = $2`, status, id)
+		return err
+	})
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to update order status: %w", err)
+	}
+	_, err = tx.ExecContext(ctx,
+		`INSERT INTO order_status_history (order_id, from_status, to_status, changed_at) VALUES ($1, $2, $3, NOW())`,
+		id, previous, status)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to record status change: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, "", fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	order, err := r.GetByID(ctx, id)
+	if err != nil {
+		return nil, "", err
+	}
+	return order, previous, nil
+}
+
+// RestoreStatus sets an order back from one status to another without a transition check
+func (r *OrderPostgresRepository) RestoreStatus(ctx context.Context, id, from, to string) error {
+	result, err := r.db.ExecContext(ctx, `
+		WITH restored AS (
+			UPDATE orders SET status = $1, updated_at = NOW() WHERE id = $2 AND status = $3
+			RETURNING id
+		)
+		INSERT INTO order_status_history (order_id, from_status, to_status, changed_at)
+		SELECT id, $3, $1, NOW() FROM restored`, to, id, from)
+	if err != nil {
+		return fmt.Errorf("failed to restore order status: %w", err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return domainerr.Conflict("order %s is no longer %s", id, from)
+	}
 	return nil
 }
 
+// StatusChangedAt returns when an order last entered status
+func (r *OrderPostgresRepository) StatusChangedAt(ctx context.Context, id, status string) (time.Time, error) {
+	var changedAt time.Time
+	err := r.db.QueryRowContext(ctx, `
+		SELECT changed_at FROM order_status_history
+		WHERE order_id = $1 AND to_status = $2
+		ORDER BY changed_at DESC, id DESC
+		LIMIT 1`, id, status).Scan(&changedAt)
+	if err == sql.ErrNoRows {
+		return time.Time{}, domainerr.NotFound("order %s was never %s", id, status)
+	}
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to get status history: %w", err)
+	}
+	return changedAt, nil
+}
+
 // ConnectPostgres creates a PostgreSQL database connection
 func ConnectPostgres(dsn string, maxOpenConns, maxIdleConns int) (*sql.DB, error) {
 	db, err := sql.Open("postgres", dsn)
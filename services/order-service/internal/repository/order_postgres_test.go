@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"context"
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/datngth03/ecommerce-go-app/services/order-service/internal/models"
+	_ "github.com/lib/pq"
+)
+
+// TestUpdateStatusConcurrentCancelAndConfirm races a cancellation against a payment
+// confirmation on the same pending order. It needs a migrated database in
+// ORDER_TEST_DATABASE_URL.
+func TestUpdateStatusConcurrentCancelAndConfirm(t *testing.T) {
+	dsn := os.Getenv("ORDER_TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("ORDER_TEST_DATABASE_URL not set")
+	}
+	db, err := ConnectPostgres(dsn, 10, 10)
+	if err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+	defer db.Close()
+
+	repo := NewOrderPostgresRepository(db)
+	ctx := context.Background()
+	const userID = 424242
+
+	for i := 0; i < 20; i++ {
+		var id string
+		err := db.QueryRowContext(ctx,
+			`INSERT INTO orders (order_number, user_id, status, shipping_address, payment_method)
+			 VALUES ('TEST-' || nextval('order_number_seq'), $1, $2, 'test', 'card') RETURNING id`,
+			userID, models.OrderStatusPending).Scan(&id)
+		if err != nil {
+			t.Fatalf("insert order: %v", err)
+		}
+		defer db.ExecContext(ctx, `DELETE FROM orders WHERE id = $1`, id)
+
+		// Cancelling an unpaid order requires it to still be pending;
+		// a paid order is cancelled with a refund instead
+		targets := []string{models.OrderStatusCancelled, models.OrderStatusConfirmed}
+		errs := make([]error, len(targets))
+		var wg sync.WaitGroup
+		for j, target := range targets {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, _, errs[j] = repo.UpdateStatus(ctx, id, userID, models.OrderStatusPending, target)
+			}()
+		}
+		wg.Wait()
+
+		winner := ""
+		for j, err := range errs {
+			if err == nil {
+				if winner != "" {
+					t.Fatalf("order %s: both cancel and confirm succeeded", id)
+				}
+				winner = targets[j]
+			}
+		}
+		if winner == "" {
+			t.Fatalf("order %s: neither change succeeded: %v", id, errs)
+		}
+
+		order, err := repo.GetByID(ctx, id)
+		if err != nil {
+			t.Fatalf("get order: %v", err)
+		}
+		if order.Status != winner {
+			t.Fatalf("order %s: status = %s, want %s", id, order.Status, winner)
+		}
+	}
+}
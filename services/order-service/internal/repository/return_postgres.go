@@ -0,0 +1,287 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/datngth03/ecommerce-go-app/services/order-service/internal/models"
+	"github.com/datngth03/ecommerce-go-app/shared/pkg/domainerr"
+	"github.com/lib/pq"
+)
+
+type ReturnPostgresRepository struct {
+	db *sql.DB
+}
+
+func NewReturnPostgresRepository(db *sql.DB) *ReturnPostgresRepository {
+	return &ReturnPostgresRepository{db: db}
+}
+
+// Create stores a return and fills in its ID, status and timestamps. The order
+// row is locked while the items are checked against what is ordered and not yet
+// in another open return, so concurrent requests cannot return an item twice.
+// Under the same lock the refund is capped at refundable (what the payment has
+// not refunded yet) less the refunds other open returns are still owed.
+func (r *ReturnPostgresRepository) Create(ctx context.Context, ret *models.Return, refundable float64) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var orderID string
+	err = tx.QueryRowContext(ctx, `SELECT id FROM orders WHERE id = $1 FOR UPDATE`, ret.OrderID).Scan(&orderID)
+	if err == sql.ErrNoRows {
+		return domainerr.NotFound("order not found")
+	}
+	if err != nil {
+		return fmt.Errorf("failed to lock order: %w", err)
+	}
+
+	returnable, err := returnableQuantities(ctx, tx, ret.OrderID)
+	if err != nil {
+		return err
+	}
+	for _, item := range ret.Items {
+		if item.Quantity > returnable[item.ProductID] {
+			return domainerr.Conflict("return quantity for product %s exceeds the %d not yet returned",
+				item.ProductID, returnable[item.ProductID])
+		}
+	}
+
+	var remaining float64
+	err = tx.QueryRowContext(ctx, `
+		SELECT CAST($2 AS DECIMAL(12, 2)) - COALESCE(SUM(refund_amount), 0)
+		FROM returns
+		WHERE order_id = $1 AND status IN ($3, $4, $5)`,
+		ret.OrderID, refundable,
+		models.ReturnStatusRequested, models.ReturnStatusApproved, models.ReturnStatusReceived).Scan(&remaining)
+	if err != nil {
+		return fmt.Errorf("failed to get refundable amount: %w", err)
+	}
+	if ret.RefundAmount > remaining {
+		if remaining <= 0 {
+			return domainerr.Conflict("order %s has nothing left to refund", ret.OrderID)
+		}
+		ret.RefundAmount = remaining
+	}
+
+	query := `
+		INSERT INTO returns (order_id, user_id, status, reason, refund_amount)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, created_at, updated_at`
+
+	ret.Status = models.ReturnStatusRequested
+	err = tx.QueryRowContext(ctx, query, ret.OrderID, ret.UserID, ret.Status, ret.Reason, ret.RefundAmount).
+		Scan(&ret.ID, &ret.CreatedAt, &ret.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create return: %w", err)
+	}
+
+	itemQuery := `
+		INSERT INTO return_items (return_id, product_id, product_name, quantity, price, bundle_components)
+		VALUES ($1, $2, $3, $4, $5, $6)`
+
+	for _, item := range ret.Items {
+		// Regular products store NULL
+		var components []byte
+		if len(item.Components) > 0 {
+			components, err = json.Marshal(item.Components)
+			if err != nil {
+				return fmt.Errorf("failed to encode bundle components: %w", err)
+			}
+		}
+		_, err = tx.ExecContext(ctx, itemQuery,
+			ret.ID, item.ProductID, item.ProductName, item.Quantity, item.Price, components)
+		if err != nil {
+			return fmt.Errorf("failed to create return item: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// returnableQuantities returns per product the ordered quantity less what is in
+// returns that were not rejected
+func returnableQuantities(ctx context.Context, tx *sql.Tx, orderID string) (map[string]int32, error) {
+	query := `
+		SELECT oi.product_id, SUM(oi.quantity) - COALESCE((
+		           SELECT SUM(ri.quantity)
+		           FROM return_items ri JOIN returns r ON r.id = ri.return_id
+		           WHERE r.order_id = $1 AND r.status <> $2 AND ri.product_id = oi.product_id), 0)
+		FROM order_items oi
+		WHERE oi.order_id = $1
+		GROUP BY oi.product_id`
+
+	rows, err := tx.QueryContext(ctx, query, orderID, models.ReturnStatusRejected)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get returnable quantities: %w", err)
+	}
+	defer rows.Close()
+
+	returnable := make(map[string]int32)
+	for rows.Next() {
+		var productID string
+		var quantity int32
+		if err := rows.Scan(&productID, &quantity); err != nil {
+			return nil, fmt.Errorf("failed to scan returnable quantity: %w", err)
+		}
+		returnable[productID] = quantity
+	}
+	return returnable, rows.Err()
+}
+
+// GetByID returns a return with its items
+func (r *ReturnPostgresRepository) GetByID(ctx context.Context, id string) (*models.Return, error) {
+	query := `
+		SELECT id, order_id, user_id, status, reason, rejection_reason, refund_amount, created_at, updated_at
+		FROM returns WHERE id = $1`
+
+	rows, err := r.db.QueryContext(ctx, query, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get return: %w", err)
+	}
+	defer rows.Close()
+
+	returns, err := scanReturns(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(returns) == 0 {
+		return nil, domainerr.NotFound("return not found")
+	}
+	if err := r.attachItems(ctx, returns); err != nil {
+		return nil, err
+	}
+	return returns[0], nil
+}
+
+// List returns a page of returns matching filter with their items, newest first
+func (r *ReturnPostgresRepository) List(ctx context.Context, filter models.ReturnFilter, page, pageSize int32) ([]*models.Return, int64, error) {
+	where := ` WHERE TRUE`
+	var args []interface{}
+	if filter.UserID != 0 {
+		args = append(args, filter.UserID)
+		where += fmt.Sprintf(" AND user_id = $%d", len(args))
+	}
+	if filter.OrderID != "" {
+		args = append(args, filter.OrderID)
+		where += fmt.Sprintf(" AND order_id = $%d", len(args))
+	}
+	if filter.Status != "" {
+		args = append(args, filter.Status)
+		where += fmt.Sprintf(" AND status = $%d", len(args))
+	}
+
+	var total int64
+	if err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM returns`+where, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count returns: %w", err)
+	}
+
+	query := `
+		SELECT id, order_id, user_id, status, reason, rejection_reason, refund_amount, created_at, updated_at
+		FROM returns` + where +
+		fmt.Sprintf(" ORDER BY created_at DESC, id LIMIT $%d OFFSET $%d", len(args)+1, len(args)+2)
+	args = append(args, pageSize, (page-1)*pageSize)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list returns: %w", err)
+	}
+	defer rows.Close()
+
+	returns, err := scanReturns(rows)
+	if err != nil {
+		return nil, 0, err
+	}
+	if err := r.attachItems(ctx, returns); err != nil {
+		return nil, 0, err
+	}
+	return returns, total, nil
+}
+
+// UpdateStatus moves a return from one status to another and returns it. It
+// fails with a conflict if the return is no longer in from, so of two
+// concurrent changes only one applies. rejectionReason is stored if not empty.
+func (r *ReturnPostgresRepository) UpdateStatus(ctx context.Context, id, from, to, rejectionReason string) (*models.Return, error) {
+	query := `
+		UPDATE returns
+		SET status = $1, rejection_reason = COALESCE(NULLIF($2, ''), rejection_reason), updated_at = NOW()
+		WHERE id = $3 AND status = $4`
+
+	result, err := r.db.ExecContext(ctx, query, to, rejectionReason, id, from)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update return status: %w", err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		current, err := r.GetByID(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		return nil, domainerr.Conflict("invalid return status transition from %s to %s", current.Status, to)
+	}
+	return r.GetByID(ctx, id)
+}
+
+// scanReturns reads return rows without their items
+func scanReturns(rows *sql.Rows) ([]*models.Return, error) {
+	returns := []*models.Return{}
+	for rows.Next() {
+		ret := &models.Return{Items: []models.ReturnItem{}}
+		err := rows.Scan(&ret.ID, &ret.OrderID, &ret.UserID, &ret.Status, &ret.Reason,
+			&ret.RejectionReason, &ret.RefundAmount, &ret.CreatedAt, &ret.UpdatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan return: %w", err)
+		}
+		returns = append(returns, ret)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read returns: %w", err)
+	}
+	return returns, nil
+}
+
+// attachItems loads the items of returns in one query
+func (r *ReturnPostgresRepository) attachItems(ctx context.Context, returns []*models.Return) error {
+	if len(returns) == 0 {
+		return nil
+	}
+	byID := make(map[string]*models.Return, len(returns))
+	ids := make([]string, len(returns))
+	for i, ret := range returns {
+		byID[ret.ID] = ret
+		ids[i] = ret.ID
+	}
+
+	query := `
+		SELECT return_id, product_id, product_name, quantity, price, bundle_components
+		FROM return_items WHERE return_id = ANY($1::uuid[]) ORDER BY return_id, product_id`
+
+	rows, err := r.db.QueryContext(ctx, query, pq.Array(ids))
+	if err != nil {
+		return fmt.Errorf("failed to get return items: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var returnID string
+		var item models.ReturnItem
+		var components []byte
+		err := rows.Scan(&returnID, &item.ProductID, &item.ProductName, &item.Quantity, &item.Price, &components)
+		if err != nil {
+			return fmt.Errorf("failed to scan return item: %w", err)
+		}
+		if components != nil {
+			if err := json.Unmarshal(components, &item.Components); err != nil {
+				return fmt.Errorf("failed to decode bundle components: %w", err)
+			}
+		}
+		byID[returnID].Items = append(byID[returnID].Items, item)
+	}
+	return rows.Err()
+}
@@ -0,0 +1,210 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/datngth03/ecommerce-go-app/services/order-service/internal/models"
+	"github.com/datngth03/ecommerce-go-app/shared/pkg/domainerr"
+	"github.com/go-redis/redis/v8"
+	"github.com/google/uuid"
+)
+
+type WishlistPostgresRepository struct {
+	db          *sql.DB
+	redisClient *redis.Client
+}
+
+func NewWishlistPostgresRepository(db *sql.DB, redisClient *redis.Client) *WishlistPostgresRepository {
+	return &WishlistPostgresRepository{
+		db:          db,
+		redisClient: redisClient,
+	}
+}
+
+// Get retrieves wishlist from Redis cache first, fallback to PostgreSQL
+func (r *WishlistPostgresRepository) Get(ctx context.Context, userID int64) (*models.Wishlist, error) {
+	// Try Redis cache first
+	cacheKey := fmt.Sprintf("wishlist:user:%d", userID)
+	cachedData, err := r.redisClient.Get(ctx, cacheKey).Result()
+	if err == nil {
+		var wishlist models.Wishlist
+		if err := json.Unmarshal([]byte(cachedData), &wishlist); err == nil {
+			return &wishlist, nil
+		}
+	}
+
+	// Fallback to PostgreSQL
+	wishlist := &models.Wishlist{UserID: userID, Items: []models.WishlistItem{}}
+
+	query := `
+		SELECT id, user_id, product_id, product_name, price, created_at
+		FROM wishlist_items WHERE user_id = $1
+		ORDER BY created_at DESC`
+
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get wishlist items: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var item models.WishlistItem
+		err = rows.Scan(&item.ID, &item.UserID, &item.ProductID, &item.ProductName,
+			&item.Price, &item.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan wishlist item: %w", err)
+		}
+		wishlist.Items = append(wishlist.Items, item)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get wishlist items: %w", err)
+	}
+
+	// Cache in Redis for 1 hour
+	r.cacheWishlist(ctx, wishlist)
+
+	return wishlist, nil
+}
+
+// AddItem adds item to wishlist; adding a product twice is a no-op
+func (r *WishlistPostgresRepository) AddItem(ctx context.Context, userID int64, item *models.WishlistItem) (*models.Wishlist, error) {
+	item.ID = uuid.New().String()
+	item.UserID = userID
+
+	query := `
+		INSERT INTO wishlist_items (id, user_id, product_id, product_name, price, created_at)
+		VALUES ($1, $2, $3, $4, $5, NOW())
+		ON CONFLICT (user_id, product_id) DO NOTHING`
+
+	_, err := r.db.ExecContext(ctx, query,
+		item.ID, item.UserID, item.ProductID, item.ProductName, item.Price)
+	if err != nil {
+		return nil, fmt.Errorf("failed to add item to wishlist: %w", err)
+	}
+
+	// Invalidate cache
+	r.invalidateCache(ctx, userID)
+
+	return r.Get(ctx, userID)
+}
+
+// RemoveItem removes item from wishlist
+func (r *WishlistPostgresRepository) RemoveItem(ctx context.Context, userID int64, productID string) (*models.Wishlist, error) {
+	query := `
+		DELETE FROM wishlist_items
+		WHERE user_id = $1 AND product_id = $2`
+
+	_, err := r.db.ExecContext(ctx, query, userID, productID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to remove item from wishlist: %w", err)
+	}
+
+	// Invalidate cache
+	r.invalidateCache(ctx, userID)
+
+	return r.Get(ctx, userID)
+}
+
+// MoveToCart adds item to the user's cart and removes it from the wishlist atomically.
+// If the product is already in the cart its quantity is increased, within the cart limits.
+func (r *WishlistPostgresRepository) MoveToCart(ctx context.Context, userID int64, item *models.CartItem, limits models.CartLimits) error {
+	return withCartLock(ctx, r.redisClient, userID, func() error {
+		return r.moveToCart(ctx, userID, item, limits)
+	})
+}
+
+func (r *WishlistPostgresRepository) moveToCart(ctx context.Context, userID int64, item *models.CartItem, limits models.CartLimits) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Remove from wishlist first so a concurrent move of the same item cannot add it twice
+	result, err := tx.ExecContext(ctx,
+		`DELETE FROM wishlist_items WHERE user_id = $1 AND product_id = $2`,
+		userID, item.ProductID)
+	if err != nil {
+		return fmt.Errorf("failed to remove item from wishlist: %w", err)
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		return domainerr.NotFound("item not found in wishlist")
+	}
+
+	// Ensure the user has a cart
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO carts (id, user_id, created_at, updated_at)
+		VALUES ($1, $2, NOW(), NOW())
+		ON CONFLICT (user_id) DO NOTHING`,
+		uuid.New().String(), userID)
+	if err != nil {
+		return fmt.Errorf("failed to create cart: %w", err)
+	}
+
+	var cartID string
+	if err = tx.QueryRowContext(ctx, `SELECT id FROM carts WHERE user_id = $1`, userID).Scan(&cartID); err != nil {
+		return fmt.Errorf("failed to get cart: %w", err)
+	}
+
+	var itemCount int
+	var currentQuantity int32
+	err = tx.QueryRowContext(ctx, `
+		SELECT COUNT(*), COALESCE(SUM(quantity) FILTER (WHERE product_id = $2), 0)
+		FROM cart_items WHERE cart_id = $1`,
+		cartID, item.ProductID).Scan(&itemCount, &currentQuantity)
+	if err != nil {
+		return fmt.Errorf("failed to count cart items: %w", err)
+	}
+	if err := limits.CheckAdd(itemCount, currentQuantity, item.Quantity); err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO cart_items (id, cart_id, product_id, product_name, quantity, price, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, NOW(), NOW())
+		ON CONFLICT (cart_id, product_id)
+		DO UPDATE SET quantity = cart_items.quantity + EXCLUDED.quantity, updated_at = NOW()`
+
+	_, err = tx.ExecContext(ctx, query,
+		uuid.New().String(), cartID, item.ProductID, item.ProductName, item.Quantity, item.Price)
+	if err != nil {
+		return fmt.Errorf("failed to add item to cart: %w", err)
+	}
+
+	// Update cart timestamp
+	if _, err = tx.ExecContext(ctx, "UPDATE carts SET updated_at = NOW() WHERE id = $1", cartID); err != nil {
+		return fmt.Errorf("failed to update cart: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	// Invalidate both caches
+	r.invalidateCache(ctx, userID)
+	r.redisClient.Del(ctx, fmt.Sprintf("cart:user:%d", userID))
+
+	return nil
+}
+
+// Helper methods
+
+func (r *WishlistPostgresRepository) cacheWishlist(ctx context.Context, wishlist *models.Wishlist) {
+	data, err := json.Marshal(wishlist)
+	if err != nil {
+		return
+	}
+	cacheKey := fmt.Sprintf("wishlist:user:%d", wishlist.UserID)
+	r.redisClient.Set(ctx, cacheKey, data, time.Hour)
+}
+
+func (r *WishlistPostgresRepository) invalidateCache(ctx context.Context, userID int64) {
+	cacheKey := fmt.Sprintf("wishlist:user:%d", userID)
+	r.redisClient.Del(ctx, cacheKey)
+}
@@ -8,22 +8,27 @@ import (
 	"github.com/datngth03/ecommerce-go-app/services/order-service/internal/metrics"
 	"github.com/datngth03/ecommerce-go-app/services/order-service/internal/models"
 	"github.com/datngth03/ecommerce-go-app/services/order-service/internal/service"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
+	"github.com/datngth03/ecommerce-go-app/shared/pkg/validator"
 	"google.golang.org/protobuf/types/known/emptypb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 type OrderServer struct {
 	pb.UnimplementedOrderServiceServer
-	orderService *service.OrderService
-	cartService  *service.CartService
+	orderService     *service.OrderService
+	cartService      *service.CartService
+	wishlistService  *service.WishlistService
+	orderNoteService *service.OrderNoteService
+	returnService    *service.ReturnService
 }
 
-func NewOrderServer(orderService *service.OrderService, cartService *service.CartService) *OrderServer {
+func NewOrderServer(orderService *service.OrderService, cartService *service.CartService, wishlistService *service.WishlistService, orderNoteService *service.OrderNoteService, returnService *service.ReturnService) *OrderServer {
 	return &OrderServer{
-		orderService: orderService,
-		cartService:  cartService,
+		orderService:     orderService,
+		cartService:      cartService,
+		wishlistService:  wishlistService,
+		orderNoteService: orderNoteService,
+		returnService:    returnService,
 	}
 }
 
@@ -31,13 +36,20 @@ func NewOrderServer(orderService *service.OrderService, cartService *service.Car
 func (s *OrderServer) CreateOrder(ctx context.Context, req *pb.CreateOrderRequest) (*pb.CreateOrderResponse, error) {
 	start := time.Now()
 
-	order, err := s.orderService.CreateOrder(ctx, req.UserId, req.ShippingAddress, req.PaymentMethod)
+	order, err := s.orderService.CreateOrder(ctx, req.UserId, req.ShippingAddress, req.PaymentMethod, models.CreateOrderOptions{
+		Gift: models.GiftOptions{
+			IsGift:  req.IsGift,
+			Message: req.GiftMessage,
+		},
+		CouponCode:     req.CouponCode,
+		ExpectedPrices: expectedPrices(req.Items),
+	})
 
 	grpcStatus := "success"
 	if err != nil {
 		grpcStatus = "error"
 		metrics.RecordGRPCRequest("CreateOrder", grpcStatus, time.Since(start))
-		return nil, status.Errorf(codes.Internal, "failed to create order: %v", err)
+		return nil, err
 	}
 
 	metrics.RecordGRPCRequest("CreateOrder", grpcStatus, time.Since(start))
@@ -48,8 +60,23 @@ func (s *OrderServer) CreateOrder(ctx context.Context, req *pb.CreateOrderReques
 	}, nil
 }
 
+// expectedPrices collects the prices the client sent with CreateOrder; items without one are skipped
+func expectedPrices(items []*pb.CreateOrderItem) map[string]float64 {
+	prices := make(map[string]float64, len(items))
+	for _, item := range items {
+		if item.Price > 0 {
+			prices[item.ProductId] = item.Price
+		}
+	}
+	return prices
+}
+
 // GetOrder retrieves an order by ID
 func (s *OrderServer) GetOrder(ctx context.Context, req *pb.GetOrderRequest) (*pb.GetOrderResponse, error) {
+	if err := validator.ValidateUUIDArg(req.Id, "id"); err != nil {
+		return nil, err
+	}
+
 	start := time.Now()
 
 	// Extract user ID from context (from auth middleware)
@@ -61,7 +88,7 @@ func (s *OrderServer) GetOrder(ctx context.Context, req *pb.GetOrderRequest) (*p
 	if err != nil {
 		grpcStatus = "error"
 		metrics.RecordGRPCRequest("GetOrder", grpcStatus, time.Since(start))
-		return nil, status.Errorf(codes.NotFound, "order not found: %v", err)
+		return nil, err
 	}
 
 	metrics.RecordGRPCRequest("GetOrder", grpcStatus, time.Since(start))
@@ -71,34 +98,188 @@ func (s *OrderServer) GetOrder(ctx context.Context, req *pb.GetOrderRequest) (*p
 	}, nil
 }
 
+// GetOrderByNumber retrieves an order by its human-friendly number
+func (s *OrderServer) GetOrderByNumber(ctx context.Context, req *pb.GetOrderByNumberRequest) (*pb.GetOrderResponse, error) {
+	start := time.Now()
+
+	order, err := s.orderService.GetOrderByNumber(ctx, req.OrderNumber, req.UserId)
+	if err != nil {
+		metrics.RecordGRPCRequest("GetOrderByNumber", "error", time.Since(start))
+		return nil, err
+	}
+	metrics.RecordGRPCRequest("GetOrderByNumber", "success", time.Since(start))
+
+	return &pb.GetOrderResponse{Order: orderToProto(order)}, nil
+}
+
 // ListOrders retrieves user's orders
 func (s *OrderServer) ListOrders(ctx context.Context, req *pb.ListOrdersRequest) (*pb.ListOrdersResponse, error) {
 	start := time.Now()
 
-	orders, total, err := s.orderService.ListOrders(ctx, req.UserId, req.Page, req.PageSize, req.Status)
+	list, err := s.orderService.ListOrders(ctx, req.UserId, req.Page, req.PageSize, req.Status)
 
 	grpcStatus := "success"
 	if err != nil {
 		grpcStatus = "error"
 		metrics.RecordGRPCRequest("ListOrders", grpcStatus, time.Since(start))
-		return nil, status.Errorf(codes.Internal, "failed to list orders: %v", err)
+		return nil, err
 	}
 
 	metrics.RecordGRPCRequest("ListOrders", grpcStatus, time.Since(start))
 
-	pbOrders := make([]*pb.Order, len(orders))
-	for i, order := range orders {
-		pbOrders[i] = orderToProto(order)
+	return orderListToProto(list), nil
+}
+
+// GetOrdersByStatus lists orders of all users by status and time range (admin)
+func (s *OrderServer) GetOrdersByStatus(ctx context.Context, req *pb.GetOrdersByStatusRequest) (*pb.ListOrdersResponse, error) {
+	start := time.Now()
+
+	filter := models.OrderFilter{
+		Status:        req.Status,
+		CreatedAfter:  timeValue(req.CreatedAfter),
+		CreatedBefore: timeValue(req.CreatedBefore),
+		UpdatedAfter:  timeValue(req.UpdatedAfter),
+		UpdatedBefore: timeValue(req.UpdatedBefore),
 	}
+	list, err := s.orderService.GetOrdersByStatus(ctx, filter, req.Page, req.PageSize)
+	if err != nil {
+		metrics.RecordGRPCRequest("GetOrdersByStatus", "error", time.Since(start))
+		return nil, err
+	}
+	metrics.RecordGRPCRequest("GetOrdersByStatus", "success", time.Since(start))
 
-	return &pb.ListOrdersResponse{
-		Orders:     pbOrders,
-		TotalCount: total,
-	}, nil
+	return orderListToProto(list), nil
+}
+
+// AddOrderNote attaches a staff note to an order
+func (s *OrderServer) AddOrderNote(ctx context.Context, req *pb.AddOrderNoteRequest) (*pb.OrderNoteResponse, error) {
+	if err := validator.ValidateUUIDArg(req.OrderId, "order_id"); err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+
+	note, err := s.orderNoteService.AddNote(ctx, req.OrderId, req.AuthorId, req.Body, req.IsInternal)
+	if err != nil {
+		metrics.RecordGRPCRequest("AddOrderNote", "error", time.Since(start))
+		return nil, err
+	}
+	metrics.RecordGRPCRequest("AddOrderNote", "success", time.Since(start))
+
+	return &pb.OrderNoteResponse{Note: orderNoteToProto(note)}, nil
+}
+
+// ListOrderNotes lists the notes of an order, oldest first
+func (s *OrderServer) ListOrderNotes(ctx context.Context, req *pb.ListOrderNotesRequest) (*pb.ListOrderNotesResponse, error) {
+	if err := validator.ValidateUUIDArg(req.OrderId, "order_id"); err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+
+	notes, err := s.orderNoteService.ListNotes(ctx, req.OrderId, req.UserId, req.IncludeInternal)
+	if err != nil {
+		metrics.RecordGRPCRequest("ListOrderNotes", "error", time.Since(start))
+		return nil, err
+	}
+	metrics.RecordGRPCRequest("ListOrderNotes", "success", time.Since(start))
+
+	resp := &pb.ListOrderNotesResponse{Notes: make([]*pb.OrderNote, len(notes))}
+	for i := range notes {
+		resp.Notes[i] = orderNoteToProto(&notes[i])
+	}
+	return resp, nil
+}
+
+// RequestReturn opens a return of items of a delivered order
+func (s *OrderServer) RequestReturn(ctx context.Context, req *pb.RequestReturnRequest) (*pb.ReturnResponse, error) {
+	if err := validator.ValidateUUIDArg(req.OrderId, "order_id"); err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+
+	items := make([]models.ReturnItem, len(req.Items))
+	for i, item := range req.Items {
+		items[i] = models.ReturnItem{ProductID: item.ProductId, Quantity: item.Quantity}
+	}
+
+	ret, err := s.returnService.RequestReturn(ctx, req.OrderId, req.UserId, items, req.Reason)
+	if err != nil {
+		metrics.RecordGRPCRequest("RequestReturn", "error", time.Since(start))
+		return nil, err
+	}
+	metrics.RecordGRPCRequest("RequestReturn", "success", time.Since(start))
+
+	return &pb.ReturnResponse{Return: returnToProto(ret)}, nil
+}
+
+// GetReturn retrieves a return
+func (s *OrderServer) GetReturn(ctx context.Context, req *pb.GetReturnRequest) (*pb.ReturnResponse, error) {
+	if err := validator.ValidateUUIDArg(req.Id, "id"); err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+
+	ret, err := s.returnService.GetReturn(ctx, req.Id, req.UserId)
+	if err != nil {
+		metrics.RecordGRPCRequest("GetReturn", "error", time.Since(start))
+		return nil, err
+	}
+	metrics.RecordGRPCRequest("GetReturn", "success", time.Since(start))
+
+	return &pb.ReturnResponse{Return: returnToProto(ret)}, nil
+}
+
+// ListReturns lists returns newest first
+func (s *OrderServer) ListReturns(ctx context.Context, req *pb.ListReturnsRequest) (*pb.ListReturnsResponse, error) {
+	start := time.Now()
+
+	filter := models.ReturnFilter{UserID: req.UserId, OrderID: req.OrderId, Status: req.Status}
+	list, err := s.returnService.ListReturns(ctx, filter, req.Page, req.PageSize)
+	if err != nil {
+		metrics.RecordGRPCRequest("ListReturns", "error", time.Since(start))
+		return nil, err
+	}
+	metrics.RecordGRPCRequest("ListReturns", "success", time.Since(start))
+
+	resp := &pb.ListReturnsResponse{
+		Returns:    make([]*pb.Return, len(list.Returns)),
+		TotalCount: list.TotalCount,
+		TotalPages: list.TotalPages,
+		HasMore:    list.HasMore,
+	}
+	for i, ret := range list.Returns {
+		resp.Returns[i] = returnToProto(ret)
+	}
+	return resp, nil
+}
+
+// UpdateReturnStatus moves a return to a new status
+func (s *OrderServer) UpdateReturnStatus(ctx context.Context, req *pb.UpdateReturnStatusRequest) (*pb.ReturnResponse, error) {
+	if err := validator.ValidateUUIDArg(req.Id, "id"); err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+
+	ret, err := s.returnService.UpdateReturnStatus(ctx, req.Id, req.Status, req.RejectionReason)
+	if err != nil {
+		metrics.RecordGRPCRequest("UpdateReturnStatus", "error", time.Since(start))
+		return nil, err
+	}
+	metrics.RecordGRPCRequest("UpdateReturnStatus", "success", time.Since(start))
+
+	return &pb.ReturnResponse{Return: returnToProto(ret)}, nil
 }
 
 // UpdateOrderStatus updates order status
 func (s *OrderServer) UpdateOrderStatus(ctx context.Context, req *pb.UpdateOrderStatusRequest) (*pb.UpdateOrderStatusResponse, error) {
+	if err := validator.ValidateUUIDArg(req.Id, "id"); err != nil {
+		return nil, err
+	}
+
 	start := time.Now()
 
 	userID := getUserIDFromContext(ctx)
@@ -109,7 +290,7 @@ func (s *OrderServer) UpdateOrderStatus(ctx context.Context, req *pb.UpdateOrder
 	if err != nil {
 		grpcStatus = "error"
 		metrics.RecordGRPCRequest("UpdateOrderStatus", grpcStatus, time.Since(start))
-		return nil, status.Errorf(codes.Internal, "failed to update order status: %v", err)
+		return nil, err
 	}
 
 	metrics.RecordGRPCRequest("UpdateOrderStatus", grpcStatus, time.Since(start))
@@ -121,6 +302,10 @@ func (s *OrderServer) UpdateOrderStatus(ctx context.Context, req *pb.UpdateOrder
 
 // CancelOrder cancels an order
 func (s *OrderServer) CancelOrder(ctx context.Context, req *pb.CancelOrderRequest) (*emptypb.Empty, error) {
+	if err := validator.ValidateUUIDArg(req.Id, "id"); err != nil {
+		return nil, err
+	}
+
 	start := time.Now()
 
 	err := s.orderService.CancelOrder(ctx, req.Id, req.UserId)
@@ -129,7 +314,7 @@ func (s *OrderServer) CancelOrder(ctx context.Context, req *pb.CancelOrderReques
 	if err != nil {
 		grpcStatus = "error"
 		metrics.RecordGRPCRequest("CancelOrder", grpcStatus, time.Since(start))
-		return nil, status.Errorf(codes.Internal, "failed to cancel order: %v", err)
+		return nil, err
 	}
 
 	metrics.RecordGRPCRequest("CancelOrder", grpcStatus, time.Since(start))
@@ -148,7 +333,7 @@ func (s *OrderServer) AddToCart(ctx context.Context, req *pb.AddToCartRequest) (
 		grpcStatus = "error"
 		metrics.RecordGRPCRequest("AddToCart", grpcStatus, time.Since(start))
 		metrics.RecordCartOperation("add", grpcStatus)
-		return nil, status.Errorf(codes.Internal, "failed to add to cart: %v", err)
+		return nil, err
 	}
 
 	metrics.RecordGRPCRequest("AddToCart", grpcStatus, time.Since(start))
@@ -159,6 +344,39 @@ func (s *OrderServer) AddToCart(ctx context.Context, req *pb.AddToCartRequest) (
 	}, nil
 }
 
+// AddItemsToCart adds several items to cart; items that cannot be added are listed in failed_items
+func (s *OrderServer) AddItemsToCart(ctx context.Context, req *pb.AddItemsToCartRequest) (*pb.AddItemsToCartResponse, error) {
+	start := time.Now()
+
+	inputs := make([]models.CartItemInput, len(req.Items))
+	for i, item := range req.Items {
+		inputs[i] = models.CartItemInput{ProductID: item.ProductId, Quantity: item.Quantity}
+	}
+
+	cart, failures, err := s.cartService.AddItemsToCart(ctx, req.UserId, inputs)
+
+	grpcStatus := "success"
+	if err != nil {
+		grpcStatus = "error"
+		metrics.RecordGRPCRequest("AddItemsToCart", grpcStatus, time.Since(start))
+		metrics.RecordCartOperation("add_bulk", grpcStatus)
+		return nil, err
+	}
+
+	metrics.RecordGRPCRequest("AddItemsToCart", grpcStatus, time.Since(start))
+	metrics.RecordCartOperation("add_bulk", grpcStatus)
+
+	failedItems := make([]*pb.CartItemFailure, len(failures))
+	for i, failure := range failures {
+		failedItems[i] = &pb.CartItemFailure{ProductId: failure.ProductID, Reason: failure.Reason}
+	}
+
+	return &pb.AddItemsToCartResponse{
+		Cart:        cartToProto(cart),
+		FailedItems: failedItems,
+	}, nil
+}
+
 // GetCart retrieves user's cart
 func (s *OrderServer) GetCart(ctx context.Context, req *pb.GetCartRequest) (*pb.CartResponse, error) {
 	start := time.Now()
@@ -169,7 +387,7 @@ func (s *OrderServer) GetCart(ctx context.Context, req *pb.GetCartRequest) (*pb.
 	if err != nil {
 		grpcStatus = "error"
 		metrics.RecordGRPCRequest("GetCart", grpcStatus, time.Since(start))
-		return nil, status.Errorf(codes.Internal, "failed to get cart: %v", err)
+		return nil, err
 	}
 
 	metrics.RecordGRPCRequest("GetCart", grpcStatus, time.Since(start))
@@ -183,7 +401,7 @@ func (s *OrderServer) GetCart(ctx context.Context, req *pb.GetCartRequest) (*pb.
 func (s *OrderServer) UpdateCartItem(ctx context.Context, req *pb.UpdateCartItemRequest) (*pb.CartResponse, error) {
 	cart, err := s.cartService.UpdateCartItem(ctx, req.UserId, req.ProductId, req.Quantity)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to update cart item: %v", err)
+		return nil, err
 	}
 
 	return &pb.CartResponse{
@@ -195,7 +413,7 @@ func (s *OrderServer) UpdateCartItem(ctx context.Context, req *pb.UpdateCartItem
 func (s *OrderServer) RemoveFromCart(ctx context.Context, req *pb.RemoveFromCartRequest) (*pb.CartResponse, error) {
 	cart, err := s.cartService.RemoveFromCart(ctx, req.UserId, req.ProductId)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to remove from cart: %v", err)
+		return nil, err
 	}
 
 	return &pb.CartResponse{
@@ -207,14 +425,112 @@ func (s *OrderServer) RemoveFromCart(ctx context.Context, req *pb.RemoveFromCart
 func (s *OrderServer) ClearCart(ctx context.Context, req *pb.ClearCartRequest) (*emptypb.Empty, error) {
 	err := s.cartService.ClearCart(ctx, req.UserId)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to clear cart: %v", err)
+		return nil, err
 	}
 
 	return &emptypb.Empty{}, nil
 }
 
+// AddToWishlist saves a product to the wishlist
+func (s *OrderServer) AddToWishlist(ctx context.Context, req *pb.AddToWishlistRequest) (*pb.WishlistResponse, error) {
+	start := time.Now()
+
+	wishlist, err := s.wishlistService.AddToWishlist(ctx, req.UserId, req.ProductId)
+
+	grpcStatus := "success"
+	if err != nil {
+		grpcStatus = "error"
+		metrics.RecordGRPCRequest("AddToWishlist", grpcStatus, time.Since(start))
+		return nil, err
+	}
+
+	metrics.RecordGRPCRequest("AddToWishlist", grpcStatus, time.Since(start))
+
+	return &pb.WishlistResponse{
+		Wishlist: wishlistToProto(wishlist),
+	}, nil
+}
+
+// GetWishlist retrieves user's wishlist
+func (s *OrderServer) GetWishlist(ctx context.Context, req *pb.GetWishlistRequest) (*pb.WishlistResponse, error) {
+	start := time.Now()
+
+	wishlist, err := s.wishlistService.GetWishlist(ctx, req.UserId)
+
+	grpcStatus := "success"
+	if err != nil {
+		grpcStatus = "error"
+		metrics.RecordGRPCRequest("GetWishlist", grpcStatus, time.Since(start))
+		return nil, err
+	}
+
+	metrics.RecordGRPCRequest("GetWishlist", grpcStatus, time.Since(start))
+
+	return &pb.WishlistResponse{
+		Wishlist: wishlistToProto(wishlist),
+	}, nil
+}
+
+// RemoveFromWishlist removes a product from the wishlist
+func (s *OrderServer) RemoveFromWishlist(ctx context.Context, req *pb.RemoveFromWishlistRequest) (*pb.WishlistResponse, error) {
+	wishlist, err := s.wishlistService.RemoveFromWishlist(ctx, req.UserId, req.ProductId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.WishlistResponse{
+		Wishlist: wishlistToProto(wishlist),
+	}, nil
+}
+
+// MoveToCart moves a wishlist item into the cart
+func (s *OrderServer) MoveToCart(ctx context.Context, req *pb.MoveToCartRequest) (*pb.MoveToCartResponse, error) {
+	start := time.Now()
+
+	cart, wishlist, err := s.wishlistService.MoveToCart(ctx, req.UserId, req.ProductId, req.Quantity)
+
+	grpcStatus := "success"
+	if err != nil {
+		grpcStatus = "error"
+		metrics.RecordGRPCRequest("MoveToCart", grpcStatus, time.Since(start))
+		metrics.RecordCartOperation("move_from_wishlist", grpcStatus)
+		return nil, err
+	}
+
+	metrics.RecordGRPCRequest("MoveToCart", grpcStatus, time.Since(start))
+	metrics.RecordCartOperation("move_from_wishlist", grpcStatus)
+
+	return &pb.MoveToCartResponse{
+		Cart:     cartToProto(cart),
+		Wishlist: wishlistToProto(wishlist),
+	}, nil
+}
+
 // Helper functions
 
+// orderListToProto converts a page of orders with its paging metadata
+func orderListToProto(list *models.OrderList) *pb.ListOrdersResponse {
+	pbOrders := make([]*pb.Order, len(list.Orders))
+	for i, order := range list.Orders {
+		pbOrders[i] = orderToProto(order)
+	}
+
+	return &pb.ListOrdersResponse{
+		Orders:     pbOrders,
+		TotalCount: list.TotalCount,
+		TotalPages: list.TotalPages,
+		HasMore:    list.HasMore,
+	}
+}
+
+// timeValue converts an optional timestamp; unset gives the zero time
+func timeValue(ts *timestamppb.Timestamp) time.Time {
+	if ts == nil {
+		return time.Time{}
+	}
+	return ts.AsTime()
+}
+
 func orderToProto(order *models.Order) *pb.Order {
 	items := make([]*pb.OrderItem, len(order.Items))
 	for i, item := range order.Items {
@@ -227,6 +543,13 @@ func orderToProto(order *models.Order) *pb.Order {
 			Price:       item.Price,
 			Subtotal:    item.Subtotal,
 		}
+		for _, component := range item.Components {
+			items[i].Components = append(items[i].Components, &pb.OrderItemComponent{
+				ProductId:   component.ProductID,
+				ProductName: component.ProductName,
+				Quantity:    component.Quantity,
+			})
+		}
 	}
 
 	return &pb.Order{
@@ -239,6 +562,56 @@ func orderToProto(order *models.Order) *pb.Order {
 		Items:           items,
 		CreatedAt:       timestamppb.New(order.CreatedAt),
 		UpdatedAt:       timestamppb.New(order.UpdatedAt),
+		IsGift:          order.IsGift,
+		GiftMessage:     order.GiftMessage,
+		SubtotalAmount:  order.SubtotalAmount,
+		DiscountAmount:  order.DiscountAmount,
+		CouponCode:      order.CouponCode,
+		UserName:        order.UserName,
+		OrderNumber:     order.OrderNumber,
+	}
+}
+
+func orderNoteToProto(note *models.OrderNote) *pb.OrderNote {
+	return &pb.OrderNote{
+		Id:         note.ID,
+		OrderId:    note.OrderID,
+		AuthorId:   note.AuthorID,
+		Body:       note.Body,
+		IsInternal: note.IsInternal,
+		CreatedAt:  timestamppb.New(note.CreatedAt),
+	}
+}
+
+func returnToProto(ret *models.Return) *pb.Return {
+	items := make([]*pb.ReturnItem, len(ret.Items))
+	for i, item := range ret.Items {
+		items[i] = &pb.ReturnItem{
+			ProductId:   item.ProductID,
+			ProductName: item.ProductName,
+			Quantity:    item.Quantity,
+			Price:       item.Price,
+		}
+		for _, component := range item.Components {
+			items[i].Components = append(items[i].Components, &pb.OrderItemComponent{
+				ProductId:   component.ProductID,
+				ProductName: component.ProductName,
+				Quantity:    component.Quantity,
+			})
+		}
+	}
+
+	return &pb.Return{
+		Id:              ret.ID,
+		OrderId:         ret.OrderID,
+		UserId:          ret.UserID,
+		Status:          ret.Status,
+		Reason:          ret.Reason,
+		RejectionReason: ret.RejectionReason,
+		RefundAmount:    ret.RefundAmount,
+		Items:           items,
+		CreatedAt:       timestamppb.New(ret.CreatedAt),
+		UpdatedAt:       timestamppb.New(ret.UpdatedAt),
 	}
 }
 
@@ -251,11 +624,15 @@ func cartToProto(cart *models.Cart) *pb.Cart {
 		totalAmount += subtotal
 
 		items[i] = &pb.CartItem{
-			ProductId:   item.ProductID,
-			ProductName: item.ProductName,
-			Quantity:    item.Quantity,
-			Price:       item.Price,
-			Subtotal:    subtotal,
+			ProductId:    item.ProductID,
+			ProductName:  item.ProductName,
+			Quantity:     item.Quantity,
+			Price:        item.Price,
+			Subtotal:     subtotal,
+			PriceChanged: item.PriceChanged,
+			OldPrice:     item.OldPrice,
+			NewPrice:     item.NewPrice,
+			Unavailable:  item.Unavailable,
 		}
 	}
 
@@ -267,6 +644,23 @@ func cartToProto(cart *models.Cart) *pb.Cart {
 	}
 }
 
+func wishlistToProto(wishlist *models.Wishlist) *pb.Wishlist {
+	items := make([]*pb.WishlistItem, len(wishlist.Items))
+	for i, item := range wishlist.Items {
+		items[i] = &pb.WishlistItem{
+			ProductId:   item.ProductID,
+			ProductName: item.ProductName,
+			Price:       item.Price,
+			AddedAt:     timestamppb.New(item.CreatedAt),
+		}
+	}
+
+	return &pb.Wishlist{
+		UserId: wishlist.UserID,
+		Items:  items,
+	}
+}
+
 func getUserIDFromContext(ctx context.Context) int64 {
 	// Extract user ID from context metadata (set by auth middleware)
 	// For now, return 0 - should be implemented based on your auth strategy
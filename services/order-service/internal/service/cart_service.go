@@ -2,49 +2,146 @@ package service
 
 import (
 	"context"
-	"fmt"
+	"log"
+	"strings"
 
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	productpb "github.com/datngth03/ecommerce-go-app/proto/product_service"
 	"github.com/datngth03/ecommerce-go-app/services/order-service/internal/client"
 	"github.com/datngth03/ecommerce-go-app/services/order-service/internal/models"
 	"github.com/datngth03/ecommerce-go-app/services/order-service/internal/repository"
+	"github.com/datngth03/ecommerce-go-app/shared/pkg/domainerr"
 )
 
 type CartService struct {
 	cartRepo      repository.CartRepository
 	productClient *client.ProductClient
+	limits        models.CartLimits
+	pricePolicy   models.CartPricePolicy
 }
 
 func NewCartService(
 	cartRepo repository.CartRepository,
 	productClient *client.ProductClient,
+	limits models.CartLimits,
+	pricePolicy models.CartPricePolicy,
 ) *CartService {
 	return &CartService{
 		cartRepo:      cartRepo,
 		productClient: productClient,
+		limits:        limits,
+		pricePolicy:   pricePolicy,
 	}
 }
 
-// GetCart retrieves user's cart
+// GetCart retrieves user's cart. Items whose product was deleted or deactivated are
+// flagged unavailable. Unless the price policy is off, item prices are also checked
+// against the product service and changed items are flagged, and repriced with the
+// update policy. A repriced item is flagged only in the response that repriced it.
 func (s *CartService) GetCart(ctx context.Context, userID int64) (*models.Cart, error) {
-	return s.cartRepo.Get(ctx, userID)
+	cart, err := s.cartRepo.Get(ctx, userID)
+	if err != nil || len(cart.Items) == 0 {
+		return cart, err
+	}
+
+	// The cart stays readable while the product service is down, just without the checks
+	products, err := lookupCartProducts(ctx, s.productClient, cart)
+	if err != nil {
+		log.Printf("Warning: failed to check cart products for user %d: %v", userID, err)
+		return cart, nil
+	}
+
+	current := productPrices(products)
+	cart.MarkUnavailable(current)
+	if s.pricePolicy == models.CartPriceOff {
+		return cart, nil
+	}
+
+	changed := cart.FlagPriceChanges(current, s.pricePolicy == models.CartPriceUpdate)
+	if len(changed) > 0 && s.pricePolicy == models.CartPriceUpdate {
+		if err := s.cartRepo.UpdatePrices(ctx, userID, changed); err != nil {
+			return nil, err
+		}
+	}
+	return cart, nil
+}
+
+// productLookupBatch is GetProductsByIds' limit on IDs per call
+const productLookupBatch = 100
+
+// lookupCartProducts fetches the products in the cart with batched GetProductsByIds calls
+// and returns the available ones by ID. Deleted and inactive products are left out.
+func lookupCartProducts(ctx context.Context, productClient *client.ProductClient, cart *models.Cart) (map[string]*productpb.Product, error) {
+	products := make(map[string]*productpb.Product, len(cart.Items))
+	for start := 0; start < len(cart.Items); start += productLookupBatch {
+		end := min(start+productLookupBatch, len(cart.Items))
+		productIDs := make([]string, 0, end-start)
+		for _, item := range cart.Items[start:end] {
+			productIDs = append(productIDs, item.ProductID)
+		}
+
+		found, _, err := productClient.GetProductsByIds(ctx, productIDs)
+		if err != nil {
+			return nil, err
+		}
+		for _, product := range found {
+			if product.IsActive {
+				products[product.Id] = product
+			}
+		}
+	}
+	return products, nil
+}
+
+// productPrices returns the price of each product by ID
+func productPrices(products map[string]*productpb.Product) map[string]float64 {
+	prices := make(map[string]float64, len(products))
+	for id, product := range products {
+		prices[id] = product.Price
+	}
+	return prices
+}
+
+// bundleComponents snapshots the composition of a bundle product; nil for regular products
+func bundleComponents(product *productpb.Product) []models.OrderItemComponent {
+	if !product.IsBundle {
+		return nil
+	}
+	components := make([]models.OrderItemComponent, len(product.Components))
+	for i, component := range product.Components {
+		components[i] = models.OrderItemComponent{
+			ProductID:   component.ProductId,
+			ProductName: component.Name,
+			Quantity:    component.Quantity,
+		}
+	}
+	return components
 }
 
 // AddToCart adds item to cart
 func (s *CartService) AddToCart(ctx context.Context, userID int64, productID string, quantity int32) (*models.Cart, error) {
 	if quantity <= 0 {
-		return nil, fmt.Errorf("quantity must be greater than 0")
+		return nil, domainerr.InvalidArgument("quantity must be greater than 0")
+	}
+
+	// Reject an oversized quantity before calling the product service
+	if err := s.limits.CheckAdd(0, 0, quantity); err != nil {
+		return nil, err
 	}
 
 	// Validate product exists
 	product, err := s.productClient.GetProduct(ctx, productID)
 	if err != nil {
-		return nil, fmt.Errorf("product not found: %w", err)
+		return nil, productLookupError(err, productID)
 	}
 
 	// Check stock
 	hasStock, err := s.productClient.CheckStock(ctx, productID, quantity)
 	if err != nil || !hasStock {
-		return nil, fmt.Errorf("insufficient stock for product: %s", product.Name)
+		return nil, domainerr.Conflict("insufficient stock for product: %s", product.Name)
 	}
 
 	// Add to cart
@@ -55,22 +152,152 @@ func (s *CartService) AddToCart(ctx context.Context, userID int64, productID str
 		Price:       product.Price,
 	}
 
-	return s.cartRepo.AddItem(ctx, userID, item)
+	cart, err := s.cartRepo.AddItem(ctx, userID, item, s.limits)
+	if err != nil {
+		return nil, err
+	}
+
+	recordInteractions(s.productClient, []*productpb.ProductInteraction{{
+		ProductId: productID,
+		Type:      interactionAddToCart,
+		Quantity:  quantity,
+		UserId:    userID,
+	}})
+	return cart, nil
+}
+
+// productLookupError maps a failed GetProduct call. Only a product the product service
+// reports missing (or a malformed ID) is the client's fault; a timeout or an unavailable
+// product service is not a 404.
+func productLookupError(err error, productID string) error {
+	switch status.Code(err) {
+	case codes.NotFound:
+		return domainerr.NotFound("product %s not found", productID)
+	case codes.InvalidArgument:
+		return domainerr.InvalidArgument("invalid product ID %q", productID)
+	case codes.Unavailable, codes.DeadlineExceeded:
+		return domainerr.Unavailable(err, "product service unavailable, please retry")
+	}
+	return domainerr.Internal(err, "failed to get product %s", productID)
+}
+
+// maxBulkCartItems bounds a single AddItemsToCart call, well under GetProductsByIds' limit of 100
+const maxBulkCartItems = 50
+
+// AddItemsToCart adds several products to the cart with a single product lookup and a
+// single cart write. Items that cannot be added (unknown or inactive product, invalid
+// quantity, cart limits) are reported as failures; the others are still added.
+// Repeated product IDs are merged into one item.
+func (s *CartService) AddItemsToCart(ctx context.Context, userID int64, inputs []models.CartItemInput) (*models.Cart, []models.CartItemFailure, error) {
+	if len(inputs) == 0 {
+		return nil, nil, domainerr.InvalidArgument("items are required")
+	}
+	if len(inputs) > maxBulkCartItems {
+		return nil, nil, domainerr.InvalidArgument("at most %d items must be added at once", maxBulkCartItems)
+	}
+
+	var failures []models.CartItemFailure
+	var productIDs []string
+	quantities := make(map[string]int32, len(inputs))
+	for _, input := range inputs {
+		productID := strings.TrimSpace(input.ProductID)
+		switch {
+		case productID == "":
+			failures = append(failures, models.CartItemFailure{ProductID: input.ProductID, Reason: "product_id is required"})
+			continue
+		case input.Quantity <= 0:
+			failures = append(failures, models.CartItemFailure{ProductID: productID, Reason: "quantity must be greater than 0"})
+			continue
+		}
+		// GetProductsByIds rejects the whole call for a malformed ID
+		if _, err := uuid.Parse(productID); err != nil {
+			failures = append(failures, models.CartItemFailure{ProductID: productID, Reason: "product not found"})
+			continue
+		}
+		if _, seen := quantities[productID]; !seen {
+			productIDs = append(productIDs, productID)
+		}
+		quantities[productID] += input.Quantity
+	}
+
+	if len(productIDs) == 0 {
+		cart, err := s.cartRepo.Get(ctx, userID)
+		if err != nil {
+			return nil, nil, err
+		}
+		return cart, failures, nil
+	}
+
+	products, notFound, err := s.productClient.GetProductsByIds(ctx, productIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, productID := range notFound {
+		failures = append(failures, models.CartItemFailure{ProductID: productID, Reason: "product not found"})
+	}
+
+	byID := make(map[string]*productpb.Product, len(products))
+	for _, product := range products {
+		byID[product.Id] = product
+	}
+
+	items := make([]models.CartItem, 0, len(productIDs))
+	for _, productID := range productIDs {
+		product, ok := byID[productID]
+		if !ok {
+			continue
+		}
+		if !product.IsActive {
+			failures = append(failures, models.CartItemFailure{ProductID: productID, Reason: "product is not available"})
+			continue
+		}
+		items = append(items, models.CartItem{
+			ProductID:   productID,
+			ProductName: product.Name,
+			Quantity:    quantities[productID],
+			Price:       product.Price,
+		})
+	}
+
+	cart, limitFailures, err := s.cartRepo.AddItems(ctx, userID, items, s.limits)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rejected := make(map[string]bool, len(limitFailures))
+	for _, failure := range limitFailures {
+		rejected[failure.ProductID] = true
+	}
+	interactions := make([]*productpb.ProductInteraction, 0, len(items))
+	for _, item := range items {
+		if rejected[item.ProductID] {
+			continue
+		}
+		interactions = append(interactions, &productpb.ProductInteraction{
+			ProductId: item.ProductID,
+			Type:      interactionAddToCart,
+			Quantity:  item.Quantity,
+			UserId:    userID,
+		})
+	}
+	recordInteractions(s.productClient, interactions)
+
+	return cart, append(failures, limitFailures...), nil
 }
 
 // UpdateCartItem updates item quantity in cart
 func (s *CartService) UpdateCartItem(ctx context.Context, userID int64, productID string, quantity int32) (*models.Cart, error) {
 	if quantity <= 0 {
-		return nil, fmt.Errorf("quantity must be greater than 0")
+		return nil, domainerr.InvalidArgument("quantity must be greater than 0")
 	}
 
 	// Check stock
 	hasStock, err := s.productClient.CheckStock(ctx, productID, quantity)
 	if err != nil || !hasStock {
-		return nil, fmt.Errorf("insufficient stock")
+		return nil, domainerr.Conflict("insufficient stock")
 	}
 
-	return s.cartRepo.UpdateItem(ctx, userID, productID, quantity)
+	return s.cartRepo.UpdateItem(ctx, userID, productID, quantity, s.limits)
 }
 
 // RemoveFromCart removes item from cart
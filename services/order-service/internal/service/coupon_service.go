@@ -0,0 +1,57 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/datngth03/ecommerce-go-app/services/order-service/internal/models"
+	"github.com/datngth03/ecommerce-go-app/services/order-service/internal/repository"
+	"github.com/datngth03/ecommerce-go-app/shared/pkg/domainerr"
+	"github.com/datngth03/ecommerce-go-app/shared/pkg/money"
+)
+
+type CouponService struct {
+	couponRepo repository.CouponRepository
+	rounding   money.Rounding
+}
+
+func NewCouponService(couponRepo repository.CouponRepository, rounding money.Rounding) *CouponService {
+	return &CouponService{
+		couponRepo: couponRepo,
+		rounding:   rounding,
+	}
+}
+
+// ApplyCoupon validates a coupon code against an order subtotal and returns the discount.
+// Usage is only checked here; it is recorded atomically when the order is created.
+func (s *CouponService) ApplyCoupon(ctx context.Context, code string, subtotal money.Amount) (*models.Coupon, money.Amount, error) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if code == "" {
+		return nil, 0, domainerr.InvalidArgument("invalid coupon: code is required")
+	}
+
+	coupon, err := s.couponRepo.GetByCode(ctx, code)
+	if err != nil {
+		if domainerr.Is(err, domainerr.KindNotFound) {
+			return nil, 0, domainerr.InvalidArgument("invalid coupon: %s does not exist", code)
+		}
+		return nil, 0, err
+	}
+
+	now := time.Now()
+	switch {
+	case !coupon.IsActive:
+		return nil, 0, domainerr.InvalidArgument("invalid coupon: %s is no longer active", code)
+	case coupon.StartsAt != nil && now.Before(*coupon.StartsAt):
+		return nil, 0, domainerr.InvalidArgument("invalid coupon: %s is not active yet", code)
+	case coupon.ExpiresAt != nil && !now.Before(*coupon.ExpiresAt):
+		return nil, 0, domainerr.InvalidArgument("invalid coupon: %s has expired", code)
+	case coupon.UsageLimit != nil && coupon.UsedCount >= *coupon.UsageLimit:
+		return nil, 0, domainerr.InvalidArgument("invalid coupon: %s has reached its usage limit", code)
+	case subtotal < s.rounding.FromFloat(coupon.MinOrderAmount):
+		return nil, 0, domainerr.InvalidArgument("invalid coupon: %s requires a minimum order of %.2f", code, coupon.MinOrderAmount)
+	}
+
+	return coupon, coupon.Discount(subtotal, s.rounding), nil
+}
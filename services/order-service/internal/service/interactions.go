@@ -0,0 +1,52 @@
+package service
+
+import (
+	"context"
+	"log"
+	"time"
+
+	productpb "github.com/datngth03/ecommerce-go-app/proto/product_service"
+	"github.com/datngth03/ecommerce-go-app/services/order-service/internal/client"
+	"github.com/datngth03/ecommerce-go-app/services/order-service/internal/models"
+)
+
+// Interaction types understood by the product service's statistics
+const (
+	interactionAddToCart         = "add_to_cart"
+	interactionPurchase          = "purchase"
+	interactionPurchaseCancelled = "purchase_cancelled"
+)
+
+// interactionRecordTimeout bounds the background call recording interactions
+const interactionRecordTimeout = 5 * time.Second
+
+// recordInteractions reports interactions to the product service in the background.
+// Statistics are best effort: a failure is logged and never fails the request.
+func recordInteractions(productClient *client.ProductClient, interactions []*productpb.ProductInteraction) {
+	if productClient == nil || len(interactions) == 0 {
+		return
+	}
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), interactionRecordTimeout)
+		defer cancel()
+
+		if err := productClient.RecordProductInteractions(ctx, interactions); err != nil {
+			log.Printf("Warning: failed to record %d product interactions: %v", len(interactions), err)
+		}
+	}()
+}
+
+// orderInteractions builds one interaction of the given type per order item
+func orderInteractions(order *models.Order, interactionType string) []*productpb.ProductInteraction {
+	interactions := make([]*productpb.ProductInteraction, 0, len(order.Items))
+	for _, item := range order.Items {
+		interactions = append(interactions, &productpb.ProductInteraction{
+			ProductId: item.ProductID,
+			Type:      interactionType,
+			Quantity:  item.Quantity,
+			UserId:    order.UserID,
+		})
+	}
+	return interactions
+}
@@ -0,0 +1,76 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/datngth03/ecommerce-go-app/services/order-service/internal/models"
+	"github.com/datngth03/ecommerce-go-app/services/order-service/internal/repository"
+	"github.com/datngth03/ecommerce-go-app/shared/pkg/domainerr"
+)
+
+// OrderNoteService manages support notes on orders. Notes never change the
+// order or its status history. Only staff add notes; the API gateway enforces that.
+type OrderNoteService struct {
+	noteRepo  repository.OrderNoteRepository
+	orderRepo repository.OrderRepository
+}
+
+func NewOrderNoteService(noteRepo repository.OrderNoteRepository, orderRepo repository.OrderRepository) *OrderNoteService {
+	return &OrderNoteService{
+		noteRepo:  noteRepo,
+		orderRepo: orderRepo,
+	}
+}
+
+// AddNote attaches a note written by authorID to an order
+func (s *OrderNoteService) AddNote(ctx context.Context, orderID string, authorID int64, body string, internal bool) (*models.OrderNote, error) {
+	body = strings.TrimSpace(body)
+	switch {
+	case orderID == "":
+		return nil, domainerr.InvalidArgument("order_id is required")
+	case authorID <= 0:
+		return nil, domainerr.InvalidArgument("author_id is required")
+	case body == "":
+		return nil, domainerr.InvalidArgument("invalid note: body is required")
+	case utf8.RuneCountInString(body) > models.MaxOrderNoteLength:
+		return nil, domainerr.InvalidArgument("invalid note: must not exceed %d characters", models.MaxOrderNoteLength)
+	}
+
+	if _, err := s.orderRepo.GetByID(ctx, orderID); err != nil {
+		return nil, err
+	}
+
+	note := &models.OrderNote{
+		OrderID:    orderID,
+		AuthorID:   authorID,
+		Body:       body,
+		IsInternal: internal,
+	}
+	if err := s.noteRepo.Create(ctx, note); err != nil {
+		return nil, err
+	}
+	return note, nil
+}
+
+// ListNotes returns the notes of an order, oldest first. With a userID the
+// order must belong to that user, who only sees the notes meant for customers.
+func (s *OrderNoteService) ListNotes(ctx context.Context, orderID string, userID int64, includeInternal bool) ([]models.OrderNote, error) {
+	if orderID == "" {
+		return nil, domainerr.InvalidArgument("order_id is required")
+	}
+
+	order, err := s.orderRepo.GetByID(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	if userID != 0 {
+		if order.UserID != userID {
+			return nil, domainerr.NotFound("order not found")
+		}
+		includeInternal = false
+	}
+
+	return s.noteRepo.ListByOrder(ctx, orderID, includeInternal)
+}
@@ -3,39 +3,75 @@ package service
 import (
 	"context"
 	"fmt"
+	"log"
+	"strings"
+	"time"
+	"unicode/utf8"
 
+	productpb "github.com/datngth03/ecommerce-go-app/proto/product_service"
 	"github.com/datngth03/ecommerce-go-app/services/order-service/internal/client"
 	"github.com/datngth03/ecommerce-go-app/services/order-service/internal/events"
 	"github.com/datngth03/ecommerce-go-app/services/order-service/internal/models"
 	"github.com/datngth03/ecommerce-go-app/services/order-service/internal/repository"
+	"github.com/datngth03/ecommerce-go-app/shared/pkg/domainerr"
+	"github.com/datngth03/ecommerce-go-app/shared/pkg/money"
+	"github.com/datngth03/ecommerce-go-app/shared/pkg/paging"
+)
+
+// paymentStatusRefunded is the payment service's status of a fully refunded payment
+const paymentStatusRefunded = "REFUNDED"
+
+// Page size bounds for order listings
+const (
+	defaultPageSize      = 10
+	maxPageSize          = 100
+	defaultAdminPageSize = 50
+	maxAdminPageSize     = 200
 )
 
 type OrderService struct {
 	orderRepo      repository.OrderRepository
 	cartRepo       repository.CartRepository
+	couponService  *CouponService
 	productClient  *client.ProductClient
 	userClient     *client.UserClient
+	paymentClient  *client.PaymentClient
 	eventPublisher *events.Publisher
+	rounding       money.Rounding
+	orderNumbers   models.OrderNumberFormat
 }
 
 func NewOrderService(
 	orderRepo repository.OrderRepository,
 	cartRepo repository.CartRepository,
+	couponService *CouponService,
 	productClient *client.ProductClient,
 	userClient *client.UserClient,
+	paymentClient *client.PaymentClient,
 	eventPublisher *events.Publisher,
+	rounding money.Rounding,
+	orderNumbers models.OrderNumberFormat,
 ) *OrderService {
 	return &OrderService{
 		orderRepo:      orderRepo,
 		cartRepo:       cartRepo,
+		couponService:  couponService,
 		productClient:  productClient,
 		userClient:     userClient,
+		paymentClient:  paymentClient,
 		eventPublisher: eventPublisher,
+		rounding:       rounding,
+		orderNumbers:   orderNumbers,
 	}
 }
 
 // CreateOrder creates a new order from cart or direct items
-func (s *OrderService) CreateOrder(ctx context.Context, userID int64, shippingAddress, paymentMethod string) (*models.Order, error) {
+func (s *OrderService) CreateOrder(ctx context.Context, userID int64, shippingAddress, paymentMethod string, opts models.CreateOrderOptions) (*models.Order, error) {
+	gift, err := validateGiftOptions(opts.Gift)
+	if err != nil {
+		return nil, err
+	}
+
 	// Validate user
 	if _, err := s.userClient.ValidateUser(ctx, userID); err != nil {
 		return nil, fmt.Errorf("invalid user: %w", err)
@@ -48,48 +84,91 @@ func (s *OrderService) CreateOrder(ctx context.Context, userID int64, shippingAd
 	}
 
 	if len(cart.Items) == 0 {
-		return nil, fmt.Errorf("cart is empty")
+		return nil, domainerr.Conflict("cart is empty")
+	}
+
+	// Products deleted or deactivated since they were added block the order
+	products, err := lookupCartProducts(ctx, s.productClient, cart)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check cart products: %w", err)
+	}
+	if unavailable := cart.MarkUnavailable(productPrices(products)); len(unavailable) > 0 {
+		names := make([]string, len(unavailable))
+		for i, item := range unavailable {
+			names[i] = item.ProductName
+		}
+		return nil, domainerr.Conflict("cart has unavailable products (%s); remove them from the cart to place the order", strings.Join(names, ", "))
+	}
+
+	// Items are charged the product service's current price. A cart price or a
+	// client-supplied price that differs is only logged as a stale cart.
+	for _, m := range cart.PriceMismatches(productPrices(products), opts.ExpectedPrices) {
+		log.Printf("WARNING: stale cart for user %d: %s price of product %s is %.2f, charging current price %.2f",
+			userID, m.Source, m.ProductID, m.Price, m.Current)
 	}
 
-	// Validate products and stock
-	var totalAmount float64
+	// Validate stock. Amounts are summed in minor units and converted back once.
+	var subtotalAmount money.Amount
 	orderItems := make([]models.OrderItem, 0, len(cart.Items))
 
 	for _, cartItem := range cart.Items {
-		// Get product details
-		product, err := s.productClient.GetProduct(ctx, cartItem.ProductID)
-		if err != nil {
-			return nil, fmt.Errorf("product %s not found: %w", cartItem.ProductID, err)
-		}
+		product := products[cartItem.ProductID]
 
 		// Check stock
 		hasStock, err := s.productClient.CheckStock(ctx, cartItem.ProductID, cartItem.Quantity)
 		if err != nil || !hasStock {
-			return nil, fmt.Errorf("insufficient stock for product %s", product.Name)
+			return nil, domainerr.Conflict("insufficient stock for product %s", product.Name)
 		}
 
 		// Create order item
-		subtotal := float64(cartItem.Quantity) * cartItem.Price
+		price := s.rounding.FromFloat(product.Price)
+		subtotal := price.Mul(cartItem.Quantity)
 		orderItems = append(orderItems, models.OrderItem{
 			ProductID:   cartItem.ProductID,
 			ProductName: product.Name,
 			Quantity:    cartItem.Quantity,
-			Price:       cartItem.Price,
-			Subtotal:    subtotal,
+			Price:       s.rounding.Float(price),
+			Subtotal:    s.rounding.Float(subtotal),
+			Components:  bundleComponents(product),
 		})
 
-		totalAmount += subtotal
+		subtotalAmount += subtotal
+	}
+
+	// Apply coupon
+	var discountAmount money.Amount
+	var coupon *models.Coupon
+	if strings.TrimSpace(opts.CouponCode) != "" {
+		coupon, discountAmount, err = s.couponService.ApplyCoupon(ctx, opts.CouponCode, subtotalAmount)
+		if err != nil {
+			return nil, err
+		}
 	}
+	totalAmount := subtotalAmount - discountAmount
 
 	// Create order
 	order := &models.Order{
 		UserID:          userID,
 		Status:          models.OrderStatusPending,
-		TotalAmount:     totalAmount,
+		SubtotalAmount:  s.rounding.Float(subtotalAmount),
+		DiscountAmount:  s.rounding.Float(discountAmount),
+		TotalAmount:     s.rounding.Float(totalAmount),
 		ShippingAddress: shippingAddress,
 		PaymentMethod:   paymentMethod,
+		IsGift:          gift.IsGift,
+		GiftMessage:     gift.Message,
 		Items:           orderItems,
 	}
+	if coupon != nil {
+		order.CouponID = coupon.ID
+		order.CouponCode = coupon.Code
+	}
+
+	seq, err := s.orderRepo.NextOrderSequence(ctx)
+	if err != nil {
+		return nil, err
+	}
+	order.OrderNumber = s.orderNumbers.Format(seq, time.Now())
 
 	createdOrder, err := s.orderRepo.Create(ctx, order)
 	if err != nil {
@@ -107,6 +186,21 @@ func (s *OrderService) CreateOrder(ctx context.Context, userID int64, shippingAd
 	return createdOrder, nil
 }
 
+// validateGiftOptions trims the gift message and checks it is only set on gift orders
+func validateGiftOptions(gift models.GiftOptions) (models.GiftOptions, error) {
+	gift.Message = strings.TrimSpace(gift.Message)
+	if gift.Message == "" {
+		return gift, nil
+	}
+	if !gift.IsGift {
+		return gift, domainerr.InvalidArgument("invalid gift_message: is_gift must be true to add a gift message")
+	}
+	if utf8.RuneCountInString(gift.Message) > models.MaxGiftMessageLength {
+		return gift, domainerr.InvalidArgument("invalid gift_message: must not exceed %d characters", models.MaxGiftMessageLength)
+	}
+	return gift, nil
+}
+
 // GetOrder retrieves order by ID
 func (s *OrderService) GetOrder(ctx context.Context, orderID string, userID int64) (*models.Order, error) {
 	order, err := s.orderRepo.GetByID(ctx, orderID)
@@ -116,81 +210,233 @@ func (s *OrderService) GetOrder(ctx context.Context, orderID string, userID int6
 
 	// Verify order belongs to user
 	if order.UserID != userID {
-		return nil, fmt.Errorf("order not found")
+		return nil, domainerr.NotFound("order not found")
 	}
 
 	return order, nil
 }
 
+// GetOrderByNumber retrieves an order by its human-friendly number, e.g. one a
+// customer reads to support. With a userID the order must belong to that user.
+func (s *OrderService) GetOrderByNumber(ctx context.Context, orderNumber string, userID int64) (*models.Order, error) {
+	orderNumber = strings.TrimSpace(orderNumber)
+	if orderNumber == "" {
+		return nil, domainerr.InvalidArgument("order_number is required")
+	}
+
+	order, err := s.orderRepo.GetByNumber(ctx, orderNumber)
+	if err != nil {
+		return nil, err
+	}
+	if userID != 0 && order.UserID != userID {
+		return nil, domainerr.NotFound("order not found")
+	}
+	return order, nil
+}
+
 // ListOrders retrieves user's orders with pagination
-func (s *OrderService) ListOrders(ctx context.Context, userID int64, page, pageSize int32, status string) ([]*models.Order, int64, error) {
-	return s.orderRepo.List(ctx, userID, page, pageSize, status)
+func (s *OrderService) ListOrders(ctx context.Context, userID int64, page, pageSize int32, status string) (*models.OrderList, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > maxPageSize {
+		pageSize = defaultPageSize
+	}
+	orders, total, err := s.orderRepo.List(ctx, userID, page, pageSize, status)
+	if err != nil {
+		return nil, err
+	}
+	return newOrderList(orders, total, page, pageSize), nil
 }
 
-// UpdateOrderStatus updates order status
-func (s *OrderService) UpdateOrderStatus(ctx context.Context, orderID, status string, userID int64) (*models.Order, error) {
-	// Validate status
-	validStatuses := map[string]bool{
-		models.OrderStatusPending:    true,
-		models.OrderStatusConfirmed:  true,
-		models.OrderStatusProcessing: true,
-		models.OrderStatusShipped:    true,
-		models.OrderStatusDelivered:  true,
-		models.OrderStatusCancelled:  true,
+// GetOrdersByStatus lists orders of all users in one status for admins,
+// e.g. orders confirmed more than a day ago that have not shipped
+func (s *OrderService) GetOrdersByStatus(ctx context.Context, filter models.OrderFilter, page, pageSize int32) (*models.OrderList, error) {
+	if !models.IsValidOrderStatus(filter.Status) {
+		return nil, domainerr.InvalidArgument("invalid order status: %q", filter.Status)
+	}
+	if (!filter.CreatedAfter.IsZero() && !filter.CreatedBefore.IsZero() && !filter.CreatedAfter.Before(filter.CreatedBefore)) ||
+		(!filter.UpdatedAfter.IsZero() && !filter.UpdatedBefore.IsZero() && !filter.UpdatedAfter.Before(filter.UpdatedBefore)) {
+		return nil, domainerr.InvalidArgument("invalid time range: the after bound must be earlier than the before bound")
+	}
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > maxAdminPageSize {
+		pageSize = defaultAdminPageSize
+	}
+	orders, total, err := s.orderRepo.ListByFilter(ctx, filter, page, pageSize)
+	if err != nil {
+		return nil, err
 	}
+	s.fillUserNames(ctx, orders)
+	return newOrderList(orders, total, page, pageSize), nil
+}
 
-	if !validStatuses[status] {
-		return nil, fmt.Errorf("invalid order status: %s", status)
+// fillUserNames sets the customer name on each order with one batch lookup.
+// Names are a convenience for admins, so a failed lookup only leaves them empty.
+func (s *OrderService) fillUserNames(ctx context.Context, orders []*models.Order) {
+	if s.userClient == nil || len(orders) == 0 {
+		return
 	}
 
-	// Get order
-	order, err := s.orderRepo.GetByID(ctx, orderID)
+	seen := make(map[int64]bool, len(orders))
+	var userIDs []int64
+	for _, order := range orders {
+		if !seen[order.UserID] {
+			seen[order.UserID] = true
+			userIDs = append(userIDs, order.UserID)
+		}
+	}
+
+	users, err := s.userClient.GetUsersByIds(ctx, userIDs)
 	if err != nil {
-		return nil, err
+		log.Printf("WARNING: failed to look up user names for admin order listing: %v", err)
+		return
 	}
 
-	// Verify order belongs to user (or allow admin to update any order)
-	if order.UserID != userID {
-		return nil, fmt.Errorf("order not found")
+	names := make(map[int64]string, len(users))
+	for _, user := range users {
+		names[user.Id] = user.Name
+	}
+	for _, order := range orders {
+		order.UserName = names[order.UserID]
+	}
+}
+
+// newOrderList wraps a page of orders with its paging metadata
+func newOrderList(orders []*models.Order, total int64, page, pageSize int32) *models.OrderList {
+	meta := paging.ForPage(int64(page), int64(pageSize), total)
+	return &models.OrderList{
+		Orders:     orders,
+		TotalCount: total,
+		Page:       page,
+		PageSize:   pageSize,
+		TotalPages: meta.TotalPages,
+		HasMore:    meta.HasMore,
 	}
+}
 
-	// Update status
-	updatedOrder, err := s.orderRepo.UpdateStatus(ctx, orderID, status)
+// UpdateOrderStatus updates order status
+func (s *OrderService) UpdateOrderStatus(ctx context.Context, orderID, status string, userID int64) (*models.Order, error) {
+	// Validate status
+	if !models.IsValidOrderStatus(status) {
+		return nil, domainerr.InvalidArgument("invalid order status: %s", status)
+	}
+
+	// Cancelling may need a refund, so it goes through the cancellation rules
+	if status == models.OrderStatusCancelled {
+		return s.cancelOrder(ctx, orderID, userID, "Cancelled by status update")
+	}
+
+	// The repository checks ownership and the transition with the order row locked
+	updatedOrder, oldStatus, err := s.orderRepo.UpdateStatus(ctx, orderID, userID, "", status)
 	if err != nil {
 		return nil, err
 	}
 
+	// A pending order is confirmed once it is paid; only then does it count as a purchase
+	if oldStatus == models.OrderStatusPending && status == models.OrderStatusConfirmed {
+		recordInteractions(s.productClient, orderInteractions(updatedOrder, interactionPurchase))
+	}
+
 	// Publish status change event
 	if s.eventPublisher != nil {
-		s.eventPublisher.PublishOrderStatusChanged(ctx, updatedOrder)
+		s.eventPublisher.PublishOrderStatusChanged(ctx, updatedOrder, oldStatus)
 	}
 
 	return updatedOrder, nil
 }
 
-// CancelOrder cancels an order
+// CancelOrder cancels an order.
+// Pending orders are only marked cancelled and inventory releases their reservation;
+// paid orders are refunded, which returns their items to stock; shipped orders
+// cannot be cancelled and must be returned.
 func (s *OrderService) CancelOrder(ctx context.Context, orderID string, userID int64) error {
-	// Get order
+	_, err := s.cancelOrder(ctx, orderID, userID, "User cancelled")
+	return err
+}
+
+func (s *OrderService) cancelOrder(ctx context.Context, orderID string, userID int64, reason string) (*models.Order, error) {
 	order, err := s.orderRepo.GetByID(ctx, orderID)
 	if err != nil {
-		return err
+		return nil, err
 	}
-
-	// Can only cancel pending orders
-	if order.Status != models.OrderStatusPending {
-		return fmt.Errorf("cannot cancel order with status: %s", order.Status)
+	if order.UserID != userID {
+		return nil, domainerr.NotFound("order not found")
 	}
-
-	// Cancel order
-	if err := s.orderRepo.Cancel(ctx, orderID, userID); err != nil {
-		return err
+	// Only paid orders were counted as purchases, so only they offset one
+	var purchased []*productpb.ProductInteraction
+
+	switch order.Status {
+	case models.OrderStatusPending:
+		// The status must still be pending under the row lock: a payment confirmed
+		// in the meantime wins and the caller retries against the paid order
+		order, _, err = s.orderRepo.UpdateStatus(ctx, orderID, userID, models.OrderStatusPending, models.OrderStatusCancelled)
+		if err != nil {
+			return nil, err
+		}
+	case models.OrderStatusConfirmed, models.OrderStatusProcessing:
+		purchased = orderInteractions(order, interactionPurchaseCancelled)
+		order, err = s.cancelPaidOrder(ctx, order, reason)
+		if err != nil {
+			return nil, err
+		}
+		reason += " (refunded)"
+	case models.OrderStatusShipped, models.OrderStatusDelivered:
+		return nil, domainerr.Conflict("invalid status transition from %s to %s: request a return instead", order.Status, models.OrderStatusCancelled)
+	default:
+		return nil, domainerr.Conflict("invalid status transition from %s to %s", order.Status, models.OrderStatusCancelled)
 	}
 
+	recordInteractions(s.productClient, purchased)
+
 	// Publish order cancelled event
 	if s.eventPublisher != nil {
-		order.Status = models.OrderStatusCancelled
-		s.eventPublisher.PublishOrderCancelled(ctx, order)
+		s.eventPublisher.PublishOrderCancelled(ctx, order, reason)
+	}
+
+	return order, nil
+}
+
+// cancelPaidOrder cancels a paid order and refunds what its payment has not refunded
+// yet; the payment service works out that remainder and the items still to return.
+// The order is cancelled first so it cannot ship while the refund runs;
+// if the refund fails the cancellation is undone.
+func (s *OrderService) cancelPaidOrder(ctx context.Context, order *models.Order, reason string) (*models.Order, error) {
+	if s.paymentClient == nil {
+		return nil, fmt.Errorf("cannot cancel paid order: payment service unavailable")
 	}
 
-	return nil
+	payment, err := s.paymentClient.GetPaymentByOrderID(ctx, order.ID)
+	if err != nil {
+		return nil, fmt.Errorf("cannot cancel paid order: %w", err)
+	}
+
+	cancelled, previous, err := s.orderRepo.UpdateStatus(ctx, order.ID, order.UserID, order.Status, models.OrderStatusCancelled)
+	if err != nil {
+		return nil, err
+	}
+
+	// A payment already refunded (e.g. by an earlier attempt) needs no second refund
+	if payment.Status != paymentStatusRefunded {
+		if err := s.paymentClient.RefundPayment(ctx, payment.Id, 0, reason, false); err != nil {
+			if !s.paymentRefunded(ctx, order.ID) {
+				if restoreErr := s.orderRepo.RestoreStatus(ctx, order.ID, models.OrderStatusCancelled, previous); restoreErr != nil {
+					log.Printf("ERROR: order %s is cancelled but its refund failed and it could not be restored to %s: %v",
+						order.ID, previous, restoreErr)
+				}
+				return nil, fmt.Errorf("cannot cancel paid order: %w", err)
+			}
+		}
+	}
+
+	return cancelled, nil
+}
+
+// paymentRefunded checks whether an order's payment is refunded, for refund
+// calls that failed after the payment service may have processed them
+func (s *OrderService) paymentRefunded(ctx context.Context, orderID string) bool {
+	payment, err := s.paymentClient.GetPaymentByOrderID(ctx, orderID)
+	return err == nil && payment.Status == paymentStatusRefunded
 }
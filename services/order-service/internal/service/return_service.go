@@ -0,0 +1,297 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/datngth03/ecommerce-go-app/services/order-service/internal/client"
+	"github.com/datngth03/ecommerce-go-app/services/order-service/internal/events"
+	"github.com/datngth03/ecommerce-go-app/services/order-service/internal/models"
+	"github.com/datngth03/ecommerce-go-app/services/order-service/internal/repository"
+	"github.com/datngth03/ecommerce-go-app/shared/pkg/domainerr"
+	"github.com/datngth03/ecommerce-go-app/shared/pkg/money"
+	"github.com/datngth03/ecommerce-go-app/shared/pkg/paging"
+)
+
+// ReturnService runs returns (RMAs) of delivered orders: a customer requests
+// one, staff approve or reject it, the items are received back into stock and
+// then refunded. Only staff change a return's status; the API gateway enforces that.
+type ReturnService struct {
+	returnRepo     repository.ReturnRepository
+	orderRepo      repository.OrderRepository
+	paymentClient  *client.PaymentClient
+	eventPublisher *events.Publisher
+	rounding       money.Rounding
+	window         time.Duration
+}
+
+func NewReturnService(
+	returnRepo repository.ReturnRepository,
+	orderRepo repository.OrderRepository,
+	paymentClient *client.PaymentClient,
+	eventPublisher *events.Publisher,
+	rounding money.Rounding,
+	window time.Duration,
+) *ReturnService {
+	return &ReturnService{
+		returnRepo:     returnRepo,
+		orderRepo:      orderRepo,
+		paymentClient:  paymentClient,
+		eventPublisher: eventPublisher,
+		rounding:       rounding,
+		window:         window,
+	}
+}
+
+// RequestReturn opens a return of items of a delivered order, within the return
+// window from delivery. Only the product and quantity of each item are read; the
+// rest is copied from the order. The refund is capped at what the order's payment
+// has left to refund.
+func (s *ReturnService) RequestReturn(ctx context.Context, orderID string, userID int64, items []models.ReturnItem, reason string) (*models.Return, error) {
+	reason = strings.TrimSpace(reason)
+	switch {
+	case orderID == "":
+		return nil, domainerr.InvalidArgument("order_id is required")
+	case reason == "":
+		return nil, domainerr.InvalidArgument("invalid return: reason is required")
+	case utf8.RuneCountInString(reason) > models.MaxReturnReasonLength:
+		return nil, domainerr.InvalidArgument("invalid return: reason must not exceed %d characters", models.MaxReturnReasonLength)
+	case len(items) == 0:
+		return nil, domainerr.InvalidArgument("invalid return: at least one item is required")
+	}
+
+	order, err := s.orderRepo.GetByID(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	if order.UserID != userID {
+		return nil, domainerr.NotFound("order not found")
+	}
+	if order.Status != models.OrderStatusDelivered {
+		return nil, domainerr.Conflict("only delivered orders can be returned, order is %s", order.Status)
+	}
+	if s.window > 0 {
+		deliveredAt, err := s.orderRepo.StatusChangedAt(ctx, order.ID, models.OrderStatusDelivered)
+		if err != nil {
+			return nil, err
+		}
+		if time.Since(deliveredAt) > s.window {
+			return nil, domainerr.Conflict("the return window of %d days has passed", int(s.window/(24*time.Hour)))
+		}
+	}
+
+	returned, err := returnItems(order, items)
+	if err != nil {
+		return nil, err
+	}
+
+	// The refund cannot exceed what the payment has left, e.g. after a goodwill refund
+	if s.paymentClient == nil {
+		return nil, fmt.Errorf("cannot request return: payment service unavailable")
+	}
+	payment, err := s.paymentClient.GetPaymentByOrderID(ctx, order.ID)
+	if err != nil {
+		return nil, fmt.Errorf("cannot request return: %w", err)
+	}
+	refundable := s.rounding.Float(s.rounding.FromFloat(payment.Amount) - s.rounding.FromFloat(payment.RefundedAmount))
+
+	ret := &models.Return{
+		OrderID:      order.ID,
+		UserID:       userID,
+		Reason:       reason,
+		RefundAmount: s.refundAmount(order, returned),
+		Items:        returned,
+	}
+	if err := s.returnRepo.Create(ctx, ret, refundable); err != nil {
+		return nil, err
+	}
+
+	s.publish(ctx, ret)
+	return ret, nil
+}
+
+// returnItems checks requested items against the order and copies the order
+// item details. Repeated products are merged.
+func returnItems(order *models.Order, items []models.ReturnItem) ([]models.ReturnItem, error) {
+	ordered := make(map[string]models.OrderItem, len(order.Items))
+	for _, item := range order.Items {
+		if existing, ok := ordered[item.ProductID]; ok {
+			item.Quantity += existing.Quantity
+		}
+		ordered[item.ProductID] = item
+	}
+
+	var returned []models.ReturnItem
+	index := make(map[string]int, len(items))
+	for _, item := range items {
+		orderItem, ok := ordered[item.ProductID]
+		switch {
+		case item.ProductID == "":
+			return nil, domainerr.InvalidArgument("product_id is required for return items")
+		case item.Quantity <= 0:
+			return nil, domainerr.InvalidArgument("quantity must be positive for product %s", item.ProductID)
+		case !ok:
+			return nil, domainerr.InvalidArgument("product %s is not part of order %s", item.ProductID, order.ID)
+		}
+
+		if i, ok := index[item.ProductID]; ok {
+			returned[i].Quantity += item.Quantity
+		} else {
+			index[item.ProductID] = len(returned)
+			returned = append(returned, models.ReturnItem{
+				ProductID:   orderItem.ProductID,
+				ProductName: orderItem.ProductName,
+				Quantity:    item.Quantity,
+				Price:       orderItem.Price,
+				Components:  orderItem.Components,
+			})
+		}
+		if q := returned[index[item.ProductID]].Quantity; q > orderItem.Quantity {
+			return nil, domainerr.InvalidArgument("return quantity for product %s exceeds ordered quantity %d",
+				item.ProductID, orderItem.Quantity)
+		}
+	}
+	return returned, nil
+}
+
+// refundAmount is what the returned items cost less their share of the order
+// discount, so returning everything refunds exactly the order total
+func (s *ReturnService) refundAmount(order *models.Order, items []models.ReturnItem) float64 {
+	var subtotal money.Amount
+	for _, item := range items {
+		subtotal += s.rounding.FromFloat(item.Price).Mul(item.Quantity)
+	}
+	discount := s.rounding.Share(s.rounding.FromFloat(order.DiscountAmount), subtotal, s.rounding.FromFloat(order.SubtotalAmount))
+	return s.rounding.Float(subtotal - discount)
+}
+
+// GetReturn returns a return. With a userID the return must belong to that user.
+func (s *ReturnService) GetReturn(ctx context.Context, returnID string, userID int64) (*models.Return, error) {
+	if returnID == "" {
+		return nil, domainerr.InvalidArgument("return_id is required")
+	}
+	ret, err := s.returnRepo.GetByID(ctx, returnID)
+	if err != nil {
+		return nil, err
+	}
+	if userID != 0 && ret.UserID != userID {
+		return nil, domainerr.NotFound("return not found")
+	}
+	return ret, nil
+}
+
+// ListReturns lists returns newest first. Customers pass their user ID in the
+// filter; admins may leave it zero to list the returns of all users.
+func (s *ReturnService) ListReturns(ctx context.Context, filter models.ReturnFilter, page, pageSize int32) (*models.ReturnList, error) {
+	if filter.Status != "" && !models.IsValidReturnStatus(filter.Status) {
+		return nil, domainerr.InvalidArgument("invalid return status: %q", filter.Status)
+	}
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > maxPageSize {
+		pageSize = defaultPageSize
+	}
+
+	returns, total, err := s.returnRepo.List(ctx, filter, page, pageSize)
+	if err != nil {
+		return nil, err
+	}
+	meta := paging.ForPage(int64(page), int64(pageSize), total)
+	return &models.ReturnList{
+		Returns:    returns,
+		TotalCount: total,
+		Page:       page,
+		PageSize:   pageSize,
+		TotalPages: meta.TotalPages,
+		HasMore:    meta.HasMore,
+	}, nil
+}
+
+// UpdateReturnStatus moves a return to status. Rejecting needs a reason shown
+// to the customer; received puts the items back in stock (inventory listens for
+// return.received) and refunded refunds the payment.
+func (s *ReturnService) UpdateReturnStatus(ctx context.Context, returnID, status, rejectionReason string) (*models.Return, error) {
+	if !models.IsValidReturnStatus(status) {
+		return nil, domainerr.InvalidArgument("invalid return status: %s", status)
+	}
+	rejectionReason = strings.TrimSpace(rejectionReason)
+	if status == models.ReturnStatusRejected {
+		if rejectionReason == "" {
+			return nil, domainerr.InvalidArgument("invalid return: rejection_reason is required to reject a return")
+		}
+		if utf8.RuneCountInString(rejectionReason) > models.MaxReturnReasonLength {
+			return nil, domainerr.InvalidArgument("invalid return: rejection_reason must not exceed %d characters", models.MaxReturnReasonLength)
+		}
+	} else {
+		rejectionReason = ""
+	}
+
+	ret, err := s.GetReturn(ctx, returnID, 0)
+	if err != nil {
+		return nil, err
+	}
+	if !models.CanTransitionReturn(ret.Status, status) {
+		return nil, domainerr.Conflict("invalid return status transition from %s to %s", ret.Status, status)
+	}
+
+	if status == models.ReturnStatusRefunded {
+		return s.refund(ctx, ret)
+	}
+
+	updated, err := s.returnRepo.UpdateStatus(ctx, ret.ID, ret.Status, status, rejectionReason)
+	if err != nil {
+		return nil, err
+	}
+	s.publish(ctx, updated)
+	return updated, nil
+}
+
+// refund marks a received return refunded and refunds its amount. The items are
+// already back in stock, so the payment service must not restock them again.
+// As with cancelled orders, the status changes first and is restored if the refund fails.
+func (s *ReturnService) refund(ctx context.Context, ret *models.Return) (*models.Return, error) {
+	if s.paymentClient == nil {
+		return nil, fmt.Errorf("cannot refund return: payment service unavailable")
+	}
+
+	payment, err := s.paymentClient.GetPaymentByOrderID(ctx, ret.OrderID)
+	if err != nil {
+		return nil, fmt.Errorf("cannot refund return: %w", err)
+	}
+
+	updated, err := s.returnRepo.UpdateStatus(ctx, ret.ID, models.ReturnStatusReceived, models.ReturnStatusRefunded, "")
+	if err != nil {
+		return nil, err
+	}
+
+	// A fully discounted return has nothing to refund
+	if ret.RefundAmount > 0 {
+		reason := fmt.Sprintf("Return %s: %s", ret.ID, ret.Reason)
+		if err := s.paymentClient.RefundPayment(ctx, payment.Id, ret.RefundAmount, reason, true); err != nil {
+			if _, restoreErr := s.returnRepo.UpdateStatus(ctx, ret.ID, models.ReturnStatusRefunded, models.ReturnStatusReceived, ""); restoreErr != nil {
+				log.Printf("ERROR: return %s is refunded but its refund failed and it could not be restored to %s: %v",
+					ret.ID, models.ReturnStatusReceived, restoreErr)
+			}
+			return nil, fmt.Errorf("cannot refund return: %w", err)
+		}
+	}
+
+	s.publish(ctx, updated)
+	return updated, nil
+}
+
+// publish emits the event of the status the return just entered. The change is
+// already stored, so a failed publish is only logged.
+func (s *ReturnService) publish(ctx context.Context, ret *models.Return) {
+	if s.eventPublisher == nil {
+		return
+	}
+	if err := s.eventPublisher.PublishReturnEvent(ctx, ret); err != nil {
+		log.Printf("WARNING: failed to publish %s event for return %s: %v", ret.Status, ret.ID, err)
+	}
+}
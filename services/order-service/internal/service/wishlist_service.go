@@ -0,0 +1,115 @@
+package service
+
+import (
+	"context"
+	"strings"
+
+	"github.com/datngth03/ecommerce-go-app/services/order-service/internal/client"
+	"github.com/datngth03/ecommerce-go-app/services/order-service/internal/models"
+	"github.com/datngth03/ecommerce-go-app/services/order-service/internal/repository"
+	"github.com/datngth03/ecommerce-go-app/shared/pkg/domainerr"
+)
+
+type WishlistService struct {
+	wishlistRepo  repository.WishlistRepository
+	cartRepo      repository.CartRepository
+	productClient *client.ProductClient
+	cartLimits    models.CartLimits
+}
+
+func NewWishlistService(
+	wishlistRepo repository.WishlistRepository,
+	cartRepo repository.CartRepository,
+	productClient *client.ProductClient,
+	cartLimits models.CartLimits,
+) *WishlistService {
+	return &WishlistService{
+		wishlistRepo:  wishlistRepo,
+		cartRepo:      cartRepo,
+		productClient: productClient,
+		cartLimits:    cartLimits,
+	}
+}
+
+// GetWishlist retrieves user's wishlist
+func (s *WishlistService) GetWishlist(ctx context.Context, userID int64) (*models.Wishlist, error) {
+	return s.wishlistRepo.Get(ctx, userID)
+}
+
+// AddToWishlist saves a product to the wishlist
+func (s *WishlistService) AddToWishlist(ctx context.Context, userID int64, productID string) (*models.Wishlist, error) {
+	if strings.TrimSpace(productID) == "" {
+		return nil, domainerr.InvalidArgument("product_id is required")
+	}
+
+	// Validate product exists
+	product, err := s.productClient.GetProduct(ctx, productID)
+	if err != nil {
+		return nil, productLookupError(err, productID)
+	}
+
+	item := &models.WishlistItem{
+		ProductID:   productID,
+		ProductName: product.Name,
+		Price:       product.Price,
+	}
+
+	return s.wishlistRepo.AddItem(ctx, userID, item)
+}
+
+// RemoveFromWishlist removes a product from the wishlist
+func (s *WishlistService) RemoveFromWishlist(ctx context.Context, userID int64, productID string) (*models.Wishlist, error) {
+	if strings.TrimSpace(productID) == "" {
+		return nil, domainerr.InvalidArgument("product_id is required")
+	}
+
+	return s.wishlistRepo.RemoveItem(ctx, userID, productID)
+}
+
+// MoveToCart moves a wishlist item into the cart at the current product price
+func (s *WishlistService) MoveToCart(ctx context.Context, userID int64, productID string, quantity int32) (*models.Cart, *models.Wishlist, error) {
+	if strings.TrimSpace(productID) == "" {
+		return nil, nil, domainerr.InvalidArgument("product_id is required")
+	}
+	if quantity < 0 {
+		return nil, nil, domainerr.InvalidArgument("quantity must be greater than 0")
+	}
+	if quantity == 0 {
+		quantity = 1
+	}
+
+	// Validate product exists
+	product, err := s.productClient.GetProduct(ctx, productID)
+	if err != nil {
+		return nil, nil, productLookupError(err, productID)
+	}
+
+	// Check stock
+	hasStock, err := s.productClient.CheckStock(ctx, productID, quantity)
+	if err != nil || !hasStock {
+		return nil, nil, domainerr.Conflict("insufficient stock for product: %s", product.Name)
+	}
+
+	item := &models.CartItem{
+		ProductID:   productID,
+		ProductName: product.Name,
+		Quantity:    quantity,
+		Price:       product.Price,
+	}
+
+	if err := s.wishlistRepo.MoveToCart(ctx, userID, item, s.cartLimits); err != nil {
+		return nil, nil, err
+	}
+
+	cart, err := s.cartRepo.Get(ctx, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	wishlist, err := s.wishlistRepo.Get(ctx, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return cart, wishlist, nil
+}
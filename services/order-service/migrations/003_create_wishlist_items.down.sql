@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_wishlist_items_user_created;
+DROP TABLE IF EXISTS wishlist_items CASCADE;
@@ -0,0 +1,15 @@
+-- Create wishlist_items table
+-- A wishlist is just the set of items saved by a user, so no parent table is needed
+CREATE TABLE IF NOT EXISTS wishlist_items (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id BIGINT NOT NULL,
+    product_id VARCHAR(255) NOT NULL,
+    product_name VARCHAR(255) NOT NULL,
+    price DECIMAL(12, 2) NOT NULL CHECK (price >= 0),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE(user_id, product_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_wishlist_items_user_created ON wishlist_items(user_id, created_at DESC);
+
+COMMENT ON TABLE wishlist_items IS 'Stores products saved by users for later (no quantity)';
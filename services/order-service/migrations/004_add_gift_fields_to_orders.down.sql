@@ -0,0 +1,2 @@
+ALTER TABLE orders DROP COLUMN IF EXISTS gift_message;
+ALTER TABLE orders DROP COLUMN IF EXISTS is_gift;
@@ -0,0 +1,6 @@
+-- Gift orders: packing slips for these orders must not show prices
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS is_gift BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS gift_message VARCHAR(250) NOT NULL DEFAULT '';
+
+COMMENT ON COLUMN orders.is_gift IS 'Gift order: packing slip omits prices';
+COMMENT ON COLUMN orders.gift_message IS 'Optional message printed on the packing slip of gift orders';
@@ -0,0 +1,11 @@
+ALTER TABLE orders DROP COLUMN IF EXISTS coupon_code;
+ALTER TABLE orders DROP COLUMN IF EXISTS discount_amount;
+ALTER TABLE orders DROP COLUMN IF EXISTS subtotal_amount;
+
+DROP TRIGGER IF EXISTS update_coupons_updated_at ON coupons;
+
+DROP INDEX IF EXISTS idx_coupon_usages_user_id;
+DROP INDEX IF EXISTS idx_coupon_usages_coupon_id;
+
+DROP TABLE IF EXISTS coupon_usages CASCADE;
+DROP TABLE IF EXISTS coupons CASCADE;
@@ -0,0 +1,45 @@
+-- Create coupons table
+CREATE TABLE IF NOT EXISTS coupons (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    code VARCHAR(50) NOT NULL UNIQUE, -- Stored upper-case
+    discount_type VARCHAR(20) NOT NULL CHECK (discount_type IN ('percentage', 'fixed')),
+    discount_value DECIMAL(12, 2) NOT NULL CHECK (discount_value > 0),
+    min_order_amount DECIMAL(12, 2) NOT NULL DEFAULT 0 CHECK (min_order_amount >= 0),
+    usage_limit INTEGER CHECK (usage_limit > 0), -- NULL means unlimited
+    used_count INTEGER NOT NULL DEFAULT 0 CHECK (used_count >= 0),
+    starts_at TIMESTAMP,
+    expires_at TIMESTAMP,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CHECK (discount_type <> 'percentage' OR discount_value <= 100),
+    CHECK (usage_limit IS NULL OR used_count <= usage_limit)
+);
+
+-- Create coupon_usages table (one row per order that redeemed a coupon)
+CREATE TABLE IF NOT EXISTS coupon_usages (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    coupon_id UUID NOT NULL REFERENCES coupons(id),
+    order_id UUID NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    user_id BIGINT NOT NULL,
+    discount_amount DECIMAL(12, 2) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE(order_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_coupon_usages_coupon_id ON coupon_usages(coupon_id);
+CREATE INDEX IF NOT EXISTS idx_coupon_usages_user_id ON coupon_usages(user_id);
+
+CREATE TRIGGER update_coupons_updated_at BEFORE UPDATE ON coupons
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+-- Discount breakdown on orders; total_amount stays the amount to pay
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS subtotal_amount DECIMAL(12, 2);
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS discount_amount DECIMAL(12, 2) NOT NULL DEFAULT 0;
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS coupon_code VARCHAR(50) NOT NULL DEFAULT '';
+
+UPDATE orders SET subtotal_amount = total_amount WHERE subtotal_amount IS NULL;
+ALTER TABLE orders ALTER COLUMN subtotal_amount SET NOT NULL;
+
+COMMENT ON TABLE coupons IS 'Discount codes (percentage or fixed amount)';
+COMMENT ON TABLE coupon_usages IS 'Records each coupon redemption, written in the same transaction as the order';
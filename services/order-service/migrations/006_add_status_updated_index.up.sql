@@ -0,0 +1,2 @@
+-- Admin queries for orders stuck in a status, e.g. confirmed but not shipped for a day
+CREATE INDEX IF NOT EXISTS idx_orders_status_updated ON orders(status, updated_at);
@@ -0,0 +1,5 @@
+-- Bundle order items: the bundle's components when the order was placed, as
+-- [{"product_id", "product_name", "quantity"}]. NULL for regular products.
+ALTER TABLE order_items ADD COLUMN IF NOT EXISTS bundle_components JSONB;
+
+COMMENT ON COLUMN order_items.bundle_components IS 'Bundle composition at order time; stock is reserved and committed per component';
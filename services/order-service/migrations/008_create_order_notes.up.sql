@@ -0,0 +1,13 @@
+-- Create order_notes table (support notes; they never change the order)
+CREATE TABLE IF NOT EXISTS order_notes (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    order_id UUID NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    author_id BIGINT NOT NULL,
+    body TEXT NOT NULL CHECK (length(body) > 0),
+    is_internal BOOLEAN NOT NULL DEFAULT TRUE, -- Internal notes are never shown to the customer
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_order_notes_order_id ON order_notes(order_id, created_at);
+
+COMMENT ON TABLE order_notes IS 'Notes staff attach to orders, internal or visible to the customer';
@@ -0,0 +1,8 @@
+DROP TRIGGER IF EXISTS update_returns_updated_at ON returns;
+
+DROP INDEX IF EXISTS idx_returns_status_updated;
+DROP INDEX IF EXISTS idx_returns_user_created;
+DROP INDEX IF EXISTS idx_returns_order_id;
+
+DROP TABLE IF EXISTS return_items CASCADE;
+DROP TABLE IF EXISTS returns CASCADE;
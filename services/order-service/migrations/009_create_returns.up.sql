@@ -0,0 +1,35 @@
+-- Create returns table (RMA: requested -> approved -> received -> refunded, or rejected)
+CREATE TABLE IF NOT EXISTS returns (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    order_id UUID NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    user_id BIGINT NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'requested'
+        CHECK (status IN ('requested', 'approved', 'received', 'refunded', 'rejected')),
+    reason TEXT NOT NULL,
+    rejection_reason TEXT NOT NULL DEFAULT '',
+    refund_amount DECIMAL(12, 2) NOT NULL CHECK (refund_amount >= 0),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create return_items table; prices and bundle compositions are copied from the order
+CREATE TABLE IF NOT EXISTS return_items (
+    return_id UUID NOT NULL REFERENCES returns(id) ON DELETE CASCADE,
+    product_id VARCHAR(255) NOT NULL,
+    product_name VARCHAR(255) NOT NULL,
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    price DECIMAL(12, 2) NOT NULL CHECK (price >= 0),
+    bundle_components JSONB,
+    PRIMARY KEY (return_id, product_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_returns_order_id ON returns(order_id);
+CREATE INDEX IF NOT EXISTS idx_returns_user_created ON returns(user_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_returns_status_updated ON returns(status, updated_at);
+
+CREATE TRIGGER update_returns_updated_at BEFORE UPDATE ON returns
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+COMMENT ON TABLE returns IS 'Return requests (RMAs) for delivered orders';
+COMMENT ON TABLE return_items IS 'Products and quantities being returned';
+COMMENT ON COLUMN returns.refund_amount IS 'Item prices less their share of the order discount, fixed when the return is requested';
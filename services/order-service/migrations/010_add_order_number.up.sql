@@ -0,0 +1,29 @@
+-- Human-friendly order numbers (e.g. ORD-2025-000123) alongside the UUID.
+-- The sequence makes them unique under concurrent checkouts; numbers of
+-- rolled-back orders are skipped, so there can be gaps.
+CREATE SEQUENCE IF NOT EXISTS order_number_seq;
+
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS order_number VARCHAR(50);
+
+-- Number existing orders in creation order with the default format. The
+-- trigger is disabled so updated_at keeps the time of the last status change.
+ALTER TABLE orders DISABLE TRIGGER update_orders_updated_at;
+
+WITH numbered AS (
+    SELECT id, created_at, row_number() OVER (ORDER BY created_at, id) AS seq
+    FROM orders WHERE order_number IS NULL
+)
+UPDATE orders o
+SET order_number = 'ORD-' || to_char(n.created_at, 'YYYY') || '-' || lpad(n.seq::text, 6, '0')
+FROM numbered n
+WHERE o.id = n.id;
+
+ALTER TABLE orders ENABLE TRIGGER update_orders_updated_at;
+
+SELECT setval('order_number_seq', GREATEST(COUNT(*), 1), COUNT(*) > 0) FROM orders;
+
+ALTER TABLE orders ALTER COLUMN order_number SET NOT NULL;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_orders_order_number ON orders(order_number);
+
+COMMENT ON COLUMN orders.order_number IS 'Human-friendly order number for support, from ORDER_NUMBER_FORMAT and order_number_seq';
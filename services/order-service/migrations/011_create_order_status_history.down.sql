@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_order_status_history_order;
+
+DROP TABLE IF EXISTS order_status_history;
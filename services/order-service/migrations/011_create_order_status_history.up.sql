@@ -0,0 +1,20 @@
+-- Every status an order entered and when. orders.updated_at also moves with
+-- unrelated edits, so times like delivery are read from here instead.
+CREATE TABLE IF NOT EXISTS order_status_history (
+    id BIGSERIAL PRIMARY KEY,
+    order_id UUID NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    from_status VARCHAR(50) NOT NULL,
+    to_status VARCHAR(50) NOT NULL,
+    changed_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_order_status_history_order ON order_status_history(order_id, to_status, changed_at);
+
+-- Orders delivered before this table existed: delivered is final, so updated_at
+-- is the best record of the delivery left
+INSERT INTO order_status_history (order_id, from_status, to_status, changed_at)
+SELECT id, 'shipped', status, updated_at
+FROM orders
+WHERE status = 'delivered';
+
+COMMENT ON TABLE order_status_history IS 'Order status changes, including ones undone by a failed follow-up step';
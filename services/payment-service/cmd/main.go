@@ -16,11 +16,17 @@ import (
 	"github.com/datngth03/ecommerce-go-app/proto/payment_service"
 	"github.com/datngth03/ecommerce-go-app/services/payment-service/internal/client"
 	"github.com/datngth03/ecommerce-go-app/services/payment-service/internal/config"
+	"github.com/datngth03/ecommerce-go-app/services/payment-service/internal/events"
+	"github.com/datngth03/ecommerce-go-app/services/payment-service/internal/handler"
 	"github.com/datngth03/ecommerce-go-app/services/payment-service/internal/metrics"
 	"github.com/datngth03/ecommerce-go-app/services/payment-service/internal/repository"
 	"github.com/datngth03/ecommerce-go-app/services/payment-service/internal/rpc"
 	"github.com/datngth03/ecommerce-go-app/services/payment-service/internal/service"
+	"github.com/datngth03/ecommerce-go-app/shared/pkg/domainerr"
+	"github.com/datngth03/ecommerce-go-app/shared/pkg/grpcsize"
+	sharedLogger "github.com/datngth03/ecommerce-go-app/shared/pkg/logger"
 	sharedMiddleware "github.com/datngth03/ecommerce-go-app/shared/pkg/middleware"
+	"github.com/datngth03/ecommerce-go-app/shared/pkg/outbox"
 	sharedTLS "github.com/datngth03/ecommerce-go-app/shared/pkg/tlsutil"
 	sharedTracing "github.com/datngth03/ecommerce-go-app/shared/pkg/tracing"
 	"github.com/gin-gonic/gin"
@@ -40,6 +46,9 @@ func main() {
 	if err != nil {
 		log.Fatalf("Failed to load config: %v", err)
 	}
+	if err := sharedLogger.Init(cfg.Logging); err != nil {
+		log.Fatalf("Failed to initialize logger: %v", err)
+	}
 
 	// Initialize distributed tracing
 	tracerCleanup, err := sharedTracing.InitTracer(sharedTracing.TracerConfig{
@@ -84,27 +93,67 @@ func main() {
 	// Initialize repository
 	repo := repository.NewPaymentRepository(db)
 
-	// Initialize service
-	svc := service.NewPaymentService(repo)
+	// Initialize event publisher. It connects on first use and reconnects after the
+	// broker goes away, so the service starts and keeps running while RabbitMQ is down.
+	publisher := events.NewPublisher(cfg.GetRabbitMQURL())
+	defer publisher.Close()
+
+	// Payment outcomes and refunds are stored in the outbox with the payment update and
+	// stay there until the relay publishes them, so a broker outage delays them but
+	// loses nothing
+	sqlDB, err := db.DB()
+	if err != nil {
+		log.Fatalf("Failed to get database handle: %v", err)
+	}
+	relayCtx, stopRelay := context.WithCancel(context.Background())
+	relayDone := make(chan struct{})
+	relay := outbox.NewRelay(sqlDB, publisher, outbox.RelayConfig{
+		PollInterval: cfg.Outbox.PollInterval,
+		BatchSize:    cfg.Outbox.BatchSize,
+		Retention:    cfg.Outbox.Retention,
+	})
+	go func() {
+		defer close(relayDone)
+		relay.Run(relayCtx)
+	}()
+	log.Println("✓ Outbox relay started")
+
+	// Initialize services
+	svc := service.NewPaymentService(repo, clients.Order)
+	subscriptionSvc := service.NewSubscriptionService(repo, publisher, cfg.Subscription)
 
-	// Initialize gRPC server with tracing interceptor and TLS
+	// Charge subscriptions as they fall due
+	schedulerCtx, stopScheduler := context.WithCancel(context.Background())
+	defer stopScheduler()
+	go subscriptionSvc.RunScheduler(schedulerCtx)
+	log.Printf("✓ Subscription scheduler started (interval: %v, max failed charges: %d)",
+		cfg.Subscription.SchedulerInterval, cfg.Subscription.MaxFailedCharges)
+
+	// Initialize gRPC server with tracing and error-mapping interceptors and TLS
 	var grpcServerOpts []grpc.ServerOption
-	grpcServerOpts = append(grpcServerOpts, grpc.UnaryInterceptor(sharedTracing.UnaryServerInterceptor()))
+	// Tracing runs outermost so spans record the mapped status codes
+	grpcServerOpts = append(grpcServerOpts, grpc.ChainUnaryInterceptor(
+		sharedTracing.UnaryServerInterceptor(),
+		domainerr.UnaryServerInterceptor(),
+	))
+
+	// Same message size limit as the other services and their clients
+	grpcServerOpts = append(grpcServerOpts, grpcsize.ServerOptions(cfg.Server.MaxMessageSize)...)
 
 	// Enable TLS if configured
 	if cfg.Server.TLS.Enabled {
-		tlsCreds, err := sharedTLS.ServerTLSConfig(cfg.Server.TLS.CertFile, cfg.Server.TLS.KeyFile)
+		tlsCreds, err := sharedTLS.ServerCredentials(cfg.Server.TLS)
 		if err != nil {
 			log.Fatalf("Failed to load TLS credentials: %v", err)
 		}
 		grpcServerOpts = append(grpcServerOpts, grpc.Creds(tlsCreds))
-		log.Printf("✓ TLS enabled for gRPC server (cert: %s)", cfg.Server.TLS.CertFile)
+		log.Printf("✓ TLS enabled for gRPC server (cert: %s, client certificates required: %v)", cfg.Server.TLS.CertFile, cfg.Server.TLS.ClientAuth)
 	} else {
 		log.Println("⚠️  TLS disabled - using insecure connection")
 	}
 
 	grpcServer := grpc.NewServer(grpcServerOpts...)
-	paymentServer := rpc.NewPaymentServer(svc)
+	paymentServer := rpc.NewPaymentServer(svc, subscriptionSvc)
 	payment_service.RegisterPaymentServiceServer(grpcServer, paymentServer)
 
 	// Register health check
@@ -197,6 +246,13 @@ func main() {
 		// Prometheus metrics endpoint
 		router.GET("/metrics", gin.WrapH(promhttp.Handler()))
 
+		// Payment gateway webhooks
+		webhookHandler := handler.NewWebhookHandler(svc, cfg.Payment.StripeWebhookSecret)
+		router.POST("/webhooks/stripe", webhookHandler.StripeWebhook)
+		if cfg.Payment.StripeWebhookSecret == "" {
+			log.Println("⚠️  STRIPE_WEBHOOK_SECRET not set - Stripe webhooks will be rejected")
+		}
+
 		log.Printf("✓ Payment HTTP server listening on port %s", cfg.Server.HTTPPort)
 		if err := router.Run(fmt.Sprintf(":%s", cfg.Server.HTTPPort)); err != nil {
 			log.Fatalf("Failed to start HTTP server: %v", err)
@@ -209,12 +265,13 @@ func main() {
 	<-quit
 
 	log.Println("Shutting down Payment Service...")
+	stopScheduler()
 	grpcServer.GracefulStop()
 
-	sqlDB, _ := db.DB()
-	if sqlDB != nil {
-		sqlDB.Close()
-	}
+	// Stop the relay before the publisher and database are closed
+	stopRelay()
+	<-relayDone
+	sqlDB.Close()
 
 	log.Println("Payment Service stopped")
 }
@@ -9,6 +9,7 @@ require (
 	github.com/datngth03/ecommerce-go-app/shared v0.0.0
 	github.com/gin-gonic/gin v1.11.0
 	github.com/prometheus/client_golang v1.23.2
+	github.com/rabbitmq/amqp091-go v1.9.0
 	golang.org/x/time v0.14.0
 	google.golang.org/grpc v1.76.0
 	gorm.io/driver/postgres v1.5.4
@@ -2,9 +2,11 @@ package client
 
 import (
 	"fmt"
+	"log"
 
 	"github.com/datngth03/ecommerce-go-app/services/payment-service/internal/config"
 	"github.com/datngth03/ecommerce-go-app/shared/pkg/grpcpool"
+	sharedTLS "github.com/datngth03/ecommerce-go-app/shared/pkg/tlsutil"
 )
 
 // Clients manages all gRPC clients with connection pooling
@@ -41,20 +43,41 @@ func NewClients(cfg *config.Config) (*Clients, error) {
 	return clients, nil
 }
 
-// createPools creates connection pools for all required services
+// createPools creates connection pools for all required services.
+// With TLS enabled (TLS_ENABLED), connections verify each service's certificate
+// against TLS_CA_FILE, using the service name as the expected server name.
 func (c *Clients) createPools() error {
-	services := map[string]string{
-		"order":        c.config.Services.OrderService.GRPCAddr,
-		"notification": c.config.Services.NotificationService.GRPCAddr,
+	services := []struct {
+		name       string
+		address    string
+		serverName string
+	}{
+		{"order", c.config.Services.OrderService.GRPCAddr, "order-service"},
+		{"notification", c.config.Services.NotificationService.GRPCAddr, "notification-service"},
 	}
 
-	for serviceName, address := range services {
-		poolConfig := grpcpool.DefaultPoolConfig(address)
-		if _, err := c.poolManager.GetOrCreate(serviceName, poolConfig); err != nil {
-			return fmt.Errorf("failed to create pool for %s service: %w", serviceName, err)
+	tlsCfg := c.config.Server.TLS
+	for _, svc := range services {
+		poolConfig := grpcpool.DefaultPoolConfig(svc.address)
+		poolConfig.MaxMessageSize = c.config.Server.MaxMessageSize
+		if tlsCfg.Enabled {
+			creds, err := sharedTLS.ClientCredentials(tlsCfg, svc.serverName)
+			if err != nil {
+				return fmt.Errorf("failed to create TLS credentials for %s service: %w", svc.name, err)
+			}
+			poolConfig.TLSEnabled = true
+			poolConfig.TLSCreds = creds
+		}
+		if _, err := c.poolManager.GetOrCreate(svc.name, poolConfig); err != nil {
+			return fmt.Errorf("failed to create pool for %s service: %w", svc.name, err)
 		}
 	}
 
+	if tlsCfg.Enabled {
+		log.Printf("✓ gRPC clients use TLS (CA: %s, client certificate: %v)", tlsCfg.CAFile, tlsCfg.ClientAuth)
+	} else {
+		log.Println("⚠️  gRPC clients use insecure connections")
+	}
 	return nil
 }
 
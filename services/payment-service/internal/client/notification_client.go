@@ -7,6 +7,7 @@ import (
 	pb "github.com/datngth03/ecommerce-go-app/proto/notification_service"
 	sharedConfig "github.com/datngth03/ecommerce-go-app/shared/pkg/config"
 	"github.com/datngth03/ecommerce-go-app/shared/pkg/grpcpool"
+	"github.com/datngth03/ecommerce-go-app/shared/pkg/grpcretry"
 	sharedTracing "github.com/datngth03/ecommerce-go-app/shared/pkg/tracing"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
@@ -16,6 +17,7 @@ type NotificationClient struct {
 	conn   *grpc.ClientConn
 	client pb.NotificationServiceClient
 	pool   *grpcpool.ConnectionPool // Connection pool support
+	policy grpcretry.Policy         // Timeout and retries of calls
 }
 
 func NewNotificationClient(endpoint sharedConfig.ServiceEndpoint) (*NotificationClient, error) {
@@ -32,6 +34,7 @@ func NewNotificationClient(endpoint sharedConfig.ServiceEndpoint) (*Notification
 	return &NotificationClient{
 		conn:   conn,
 		client: pb.NewNotificationServiceClient(conn),
+		policy: grpcretry.PolicyFor(endpoint),
 	}, nil
 }
 
@@ -48,7 +51,8 @@ func NewNotificationClientWithPool(endpoint sharedConfig.ServiceEndpoint, poolMa
 	}
 
 	return &NotificationClient{
-		pool: pool,
+		pool:   pool,
+		policy: grpcretry.PolicyFor(endpoint),
 	}, nil
 }
 
@@ -79,11 +83,13 @@ func (c *NotificationClient) SendEmail(ctx context.Context, userID, recipient, s
 		return err
 	}
 
-	resp, err := client.SendEmail(ctx, &pb.SendEmailRequest{
-		UserId:    userID,
-		Recipient: recipient,
-		Subject:   subject,
-		Body:      body,
+	resp, err := grpcretry.Call(ctx, c.policy, func(ctx context.Context) (*pb.SendEmailResponse, error) {
+		return client.SendEmail(ctx, &pb.SendEmailRequest{
+			UserId:    userID,
+			Recipient: recipient,
+			Subject:   subject,
+			Body:      body,
+		})
 	})
 	if err != nil {
 		return fmt.Errorf("failed to send email: %w", err)
@@ -103,10 +109,12 @@ func (c *NotificationClient) SendSMS(ctx context.Context, userID, recipient, mes
 		return err
 	}
 
-	resp, err := client.SendSMS(ctx, &pb.SendSMSRequest{
-		UserId:    userID,
-		Recipient: recipient,
-		Message:   message,
+	resp, err := grpcretry.Call(ctx, c.policy, func(ctx context.Context) (*pb.SendSMSResponse, error) {
+		return client.SendSMS(ctx, &pb.SendSMSRequest{
+			UserId:    userID,
+			Recipient: recipient,
+			Message:   message,
+		})
 	})
 	if err != nil {
 		return fmt.Errorf("failed to send SMS: %w", err)
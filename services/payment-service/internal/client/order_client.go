@@ -7,6 +7,7 @@ import (
 	pb "github.com/datngth03/ecommerce-go-app/proto/order_service"
 	sharedConfig "github.com/datngth03/ecommerce-go-app/shared/pkg/config"
 	"github.com/datngth03/ecommerce-go-app/shared/pkg/grpcpool"
+	"github.com/datngth03/ecommerce-go-app/shared/pkg/grpcretry"
 	sharedTracing "github.com/datngth03/ecommerce-go-app/shared/pkg/tracing"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
@@ -16,6 +17,7 @@ type OrderClient struct {
 	conn   *grpc.ClientConn
 	client pb.OrderServiceClient
 	pool   *grpcpool.ConnectionPool // Connection pool support
+	policy grpcretry.Policy         // Timeout and retries of calls
 }
 
 func NewOrderClient(endpoint sharedConfig.ServiceEndpoint) (*OrderClient, error) {
@@ -32,6 +34,7 @@ func NewOrderClient(endpoint sharedConfig.ServiceEndpoint) (*OrderClient, error)
 	return &OrderClient{
 		conn:   conn,
 		client: pb.NewOrderServiceClient(conn),
+		policy: grpcretry.PolicyFor(endpoint),
 	}, nil
 }
 
@@ -48,7 +51,8 @@ func NewOrderClientWithPool(endpoint sharedConfig.ServiceEndpoint, poolManager *
 	}
 
 	return &OrderClient{
-		pool: pool,
+		pool:   pool,
+		policy: grpcretry.PolicyFor(endpoint),
 	}, nil
 }
 
@@ -79,8 +83,10 @@ func (c *OrderClient) GetOrder(ctx context.Context, orderID string) (*pb.Order,
 		return nil, err
 	}
 
-	resp, err := client.GetOrder(ctx, &pb.GetOrderRequest{
-		Id: orderID,
+	resp, err := grpcretry.CallIdempotent(ctx, c.policy, func(ctx context.Context) (*pb.GetOrderResponse, error) {
+		return client.GetOrder(ctx, &pb.GetOrderRequest{
+			Id: orderID,
+		})
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to get order: %w", err)
@@ -96,9 +102,11 @@ func (c *OrderClient) UpdateOrderStatus(ctx context.Context, orderID, status str
 		return err
 	}
 
-	_, err = client.UpdateOrderStatus(ctx, &pb.UpdateOrderStatusRequest{
-		Id:     orderID,
-		Status: status,
+	_, err = grpcretry.Call(ctx, c.policy, func(ctx context.Context) (*pb.UpdateOrderStatusResponse, error) {
+		return client.UpdateOrderStatus(ctx, &pb.UpdateOrderStatusRequest{
+			Id:     orderID,
+			Status: status,
+		})
 	})
 	if err != nil {
 		return fmt.Errorf("failed to update order status: %w", err)
@@ -114,11 +122,13 @@ func (c *OrderClient) ListOrders(ctx context.Context, userID int64, page, pageSi
 		return nil, 0, err
 	}
 
-	resp, err := client.ListOrders(ctx, &pb.ListOrdersRequest{
-		UserId:   userID,
-		Page:     page,
-		PageSize: pageSize,
-		Status:   status,
+	resp, err := grpcretry.CallIdempotent(ctx, c.policy, func(ctx context.Context) (*pb.ListOrdersResponse, error) {
+		return client.ListOrders(ctx, &pb.ListOrdersRequest{
+			UserId:   userID,
+			Page:     page,
+			PageSize: pageSize,
+			Status:   status,
+		})
 	})
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to list orders: %w", err)
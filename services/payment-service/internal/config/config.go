@@ -30,14 +30,16 @@ type CORSConfig struct {
 
 // Config holds payment service specific configuration
 type Config struct {
-	Service  sharedConfig.ServiceInfo
-	Server   sharedConfig.ServerConfig
-	Database sharedConfig.DatabaseConfig
-	RabbitMQ sharedConfig.RabbitMQConfig
-	Services sharedConfig.ExternalServices
-	Logging  sharedConfig.LoggingConfig
-	Payment  PaymentConfig
-	Security SecurityConfig
+	Service      sharedConfig.ServiceInfo
+	Server       sharedConfig.ServerConfig
+	Database     sharedConfig.DatabaseConfig
+	RabbitMQ     sharedConfig.RabbitMQConfig
+	Services     sharedConfig.ExternalServices
+	Logging      sharedConfig.LoggingConfig
+	Payment      PaymentConfig
+	Subscription SubscriptionConfig
+	Outbox       OutboxConfig
+	Security     SecurityConfig
 }
 
 // PaymentConfig contains payment-specific settings
@@ -49,6 +51,22 @@ type PaymentConfig struct {
 	Currency            string
 }
 
+// SubscriptionConfig contains recurring billing settings
+type SubscriptionConfig struct {
+	SchedulerInterval time.Duration // How often due subscriptions are charged
+	BatchSize         int           // Max subscriptions charged per batch
+	ClaimLease        time.Duration // How long a claimed subscription is held before another run may retry it
+	MaxFailedCharges  int           // Failed charges in a row before a subscription is marked PAST_DUE
+	RetryBackoff      time.Duration // Delay before retrying a failed charge; doubles per failure
+}
+
+// OutboxConfig holds settings of the relay publishing payment events from the outbox table
+type OutboxConfig struct {
+	PollInterval time.Duration // Wait between polls once the outbox is drained
+	BatchSize    int           // Events published per transaction
+	Retention    time.Duration // How long published events are kept
+}
+
 // Load loads configuration from environment variables
 func Load() (*Config, error) {
 	cfg := &Config{
@@ -69,12 +87,52 @@ func Load() (*Config, error) {
 			PayPalClientSecret:  sharedConfig.GetEnv("PAYPAL_CLIENT_SECRET", ""),
 			Currency:            sharedConfig.GetEnv("PAYMENT_CURRENCY", "USD"),
 		},
+		Subscription: SubscriptionConfig{
+			SchedulerInterval: sharedConfig.GetEnvAsDuration("SUBSCRIPTION_SCHEDULER_INTERVAL", time.Minute), // seconds
+			BatchSize:         sharedConfig.GetEnvAsInt("SUBSCRIPTION_BATCH_SIZE", 100),
+			ClaimLease:        sharedConfig.GetEnvAsDurationMinutes("SUBSCRIPTION_CLAIM_LEASE_MINUTES", 10*time.Minute),
+			MaxFailedCharges:  sharedConfig.GetEnvAsInt("SUBSCRIPTION_MAX_FAILED_CHARGES", 4),
+			RetryBackoff:      sharedConfig.GetEnvAsDurationMinutes("SUBSCRIPTION_RETRY_BACKOFF_MINUTES", time.Hour),
+		},
+		Outbox:   LoadOutboxConfig(),
 		Security: LoadSecurityConfig(),
 	}
 
+	if cfg.Subscription.BatchSize <= 0 {
+		cfg.Subscription.BatchSize = 100
+	}
+
+	v := sharedConfig.NewValidator(cfg.Service)
+	v.CheckServer(cfg.Server)
+	v.CheckDatabase(cfg.Database)
+	v.CheckRabbitMQ(cfg.RabbitMQ)
+	v.Check(cfg.Subscription.SchedulerInterval > 0, "SUBSCRIPTION_SCHEDULER_INTERVAL must be positive")
+	v.Check(cfg.Subscription.ClaimLease > 0, "SUBSCRIPTION_CLAIM_LEASE_MINUTES must be positive")
+	v.Check(cfg.Subscription.MaxFailedCharges > 0, "SUBSCRIPTION_MAX_FAILED_CHARGES must be positive")
+	v.Check(cfg.Subscription.RetryBackoff > 0, "SUBSCRIPTION_RETRY_BACKOFF_MINUTES must be positive")
+	v.Check(cfg.Outbox.PollInterval > 0, "OUTBOX_POLL_INTERVAL must be positive")
+	v.Check(cfg.Outbox.BatchSize > 0, "OUTBOX_BATCH_SIZE must be positive")
+	if err := v.Err(); err != nil {
+		return nil, err
+	}
+
 	return cfg, nil
 }
 
+// LoadOutboxConfig loads outbox relay configuration from environment
+func LoadOutboxConfig() OutboxConfig {
+	pollInterval, err := time.ParseDuration(sharedConfig.GetEnv("OUTBOX_POLL_INTERVAL", "1s"))
+	if err != nil {
+		pollInterval = time.Second
+	}
+
+	return OutboxConfig{
+		PollInterval: pollInterval,
+		BatchSize:    sharedConfig.GetEnvAsInt("OUTBOX_BATCH_SIZE", 100),
+		Retention:    sharedConfig.GetEnvAsDurationHours("OUTBOX_RETENTION_HOURS", 7*24*time.Hour),
+	}
+}
+
 // LoadSecurityConfig loads security configuration from environment
 func LoadSecurityConfig() SecurityConfig {
 	// Parse rate limit RPS
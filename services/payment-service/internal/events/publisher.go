@@ -0,0 +1,285 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/datngth03/ecommerce-go-app/shared/pkg/outbox"
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// Event routing
+const (
+	PaymentExchange            = "payments"
+	EventPaymentCompleted      = "payment.completed"
+	EventPaymentFailed         = "payment.failed"
+	EventPaymentRefunded       = "payment.refunded"
+	EventSubscriptionRenewed   = "subscription.renewed"
+	EventSubscriptionPastDue   = "subscription.past_due"
+	EventSubscriptionCancelled = "subscription.cancelled"
+)
+
+// PaidItem is a line item of the order a payment settled
+type PaidItem struct {
+	ProductID string `json:"product_id"`
+	Quantity  int32  `json:"quantity"`
+}
+
+// PaymentCompletedEvent is published when a payment succeeds, so the order's
+// reserved stock can be committed. Items is empty when the order could not be
+// looked up; inventory then commits the order's reservations as they are.
+type PaymentCompletedEvent struct {
+	EventType   string     `json:"event_type"`
+	PaymentID   string     `json:"payment_id"`
+	OrderID     string     `json:"order_id"`
+	Amount      float64    `json:"amount"`
+	Currency    string     `json:"currency"`
+	Items       []PaidItem `json:"items"`
+	CompletedAt time.Time  `json:"completed_at"`
+}
+
+// PaymentFailedEvent is published when a payment fails for good, so the
+// order's reserved stock can be released
+type PaymentFailedEvent struct {
+	EventType string    `json:"event_type"`
+	PaymentID string    `json:"payment_id"`
+	OrderID   string    `json:"order_id"`
+	Reason    string    `json:"reason"`
+	FailedAt  time.Time `json:"failed_at"`
+}
+
+// RefundedItem is a line item returned to stock by a refund
+type RefundedItem struct {
+	ProductID string `json:"product_id"`
+	Quantity  int32  `json:"quantity"`
+}
+
+// PaymentRefundedEvent is published after a refund is completed.
+// Items lists what goes back to stock; it is empty for money-only refunds.
+type PaymentRefundedEvent struct {
+	EventType  string         `json:"event_type"`
+	RefundID   string         `json:"refund_id"`
+	PaymentID  string         `json:"payment_id"`
+	OrderID    string         `json:"order_id"`
+	Amount     float64        `json:"amount"`
+	Reason     string         `json:"reason"`
+	Items      []RefundedItem `json:"items"`
+	RefundedAt time.Time      `json:"refunded_at"`
+}
+
+// SubscriptionEvent is published when a subscription renews, falls past due or is cancelled.
+// PaymentID is the renewal charge; Reason is the last charge failure or the cancellation reason.
+type SubscriptionEvent struct {
+	EventType      string     `json:"event_type"`
+	SubscriptionID string     `json:"subscription_id"`
+	UserID         string     `json:"user_id"`
+	PlanID         string     `json:"plan_id"`
+	Amount         float64    `json:"amount"`
+	Currency       string     `json:"currency"`
+	Status         string     `json:"status"`
+	PaymentID      string     `json:"payment_id,omitempty"`
+	NextChargeAt   *time.Time `json:"next_charge_at,omitempty"`
+	Reason         string     `json:"reason,omitempty"`
+	OccurredAt     time.Time  `json:"occurred_at"`
+}
+
+// Reconnect backoff after the broker could not be reached
+const (
+	minDialBackoff = time.Second
+	maxDialBackoff = time.Minute
+)
+
+// Publisher publishes payment events to RabbitMQ. It connects on first use and
+// reconnects after the connection or channel is closed, waiting longer after each
+// failed dial. Publisher confirms are enabled, so a publish returns only once the
+// broker has taken responsibility for the message.
+type Publisher struct {
+	url string
+
+	mu       sync.Mutex
+	conn     *amqp.Connection
+	channel  *amqp.Channel
+	closed   chan *amqp.Error // Notified when the channel (or its connection) closes
+	backoff  time.Duration    // Wait after the last failed dial
+	nextDial time.Time        // No dial is attempted before this
+}
+
+var _ outbox.Publisher = (*Publisher)(nil)
+
+// NewPublisher creates a publisher for amqpURL. It does not connect until the
+// first event is published, so the service starts while the broker is down.
+func NewPublisher(amqpURL string) *Publisher {
+	return &Publisher{url: amqpURL}
+}
+
+// Publish sends an outbox event as it was stored, routed by its topic.
+// The outbox ID is the message ID, so consumers can drop redelivered duplicates.
+func (p *Publisher) Publish(ctx context.Context, event outbox.Event) error {
+	return p.send(ctx, event.Topic, event.Payload, strconv.FormatInt(event.ID, 10))
+}
+
+// PublishSubscriptionEvent publishes a subscription.* event
+func (p *Publisher) PublishSubscriptionEvent(ctx context.Context, eventType string, event *SubscriptionEvent) error {
+	event.EventType = eventType
+	return p.publish(ctx, eventType, event)
+}
+
+// publish sends an event to the payment exchange
+func (p *Publisher) publish(ctx context.Context, routingKey string, event interface{}) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event: %w", err)
+	}
+	return p.send(ctx, routingKey, body, "")
+}
+
+// send publishes body to the payment exchange and waits for the broker to confirm it
+func (p *Publisher) send(ctx context.Context, routingKey string, body []byte, messageID string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if err := p.connect(); err != nil {
+		return err
+	}
+
+	confirmation, err := p.channel.PublishWithDeferredConfirmWithContext(ctx,
+		PaymentExchange,
+		routingKey,
+		false, // mandatory
+		false, // immediate
+		amqp.Publishing{
+			ContentType:  "application/json",
+			Body:         body,
+			DeliveryMode: amqp.Persistent,
+			MessageId:    messageID,
+			Timestamp:    time.Now(),
+		},
+	)
+	if err != nil {
+		p.disconnect()
+		return fmt.Errorf("failed to publish event: %w", err)
+	}
+
+	acked, err := confirmation.WaitContext(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to confirm event: %w", err)
+	}
+	if !acked {
+		return fmt.Errorf("%s event was rejected by the broker", routingKey)
+	}
+
+	log.Printf("Published event: %s", routingKey)
+	return nil
+}
+
+// connect opens a connection and a confirming channel unless one is open already.
+// After a failed dial it returns an error without dialing until the backoff has passed.
+func (p *Publisher) connect() error {
+	if p.channel != nil {
+		select {
+		case err := <-p.closed:
+			log.Printf("RabbitMQ channel closed (%v), reconnecting", err)
+			p.disconnect()
+		default:
+			return nil
+		}
+	}
+
+	if wait := time.Until(p.nextDial); wait > 0 {
+		return fmt.Errorf("RabbitMQ unavailable, next connection attempt in %v", wait.Round(time.Second))
+	}
+
+	if err := p.dial(); err != nil {
+		p.backoff *= 2
+		if p.backoff < minDialBackoff {
+			p.backoff = minDialBackoff
+		}
+		if p.backoff > maxDialBackoff {
+			p.backoff = maxDialBackoff
+		}
+		p.nextDial = time.Now().Add(p.backoff)
+		return err
+	}
+
+	p.backoff = 0
+	p.nextDial = time.Time{}
+	log.Println("Connected to RabbitMQ for payment events")
+	return nil
+}
+
+// dial connects, declares the payment exchange and enables publisher confirms
+func (p *Publisher) dial() error {
+	conn, err := amqp.Dial(p.url)
+	if err != nil {
+		return fmt.Errorf("failed to connect to RabbitMQ: %w", err)
+	}
+
+	channel, err := conn.Channel()
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("failed to open channel: %w", err)
+	}
+
+	err = channel.ExchangeDeclare(
+		PaymentExchange,
+		"topic",
+		true,  // durable
+		false, // auto-deleted
+		false, // internal
+		false, // no-wait
+		nil,
+	)
+	if err != nil {
+		channel.Close()
+		conn.Close()
+		return fmt.Errorf("failed to declare exchange: %w", err)
+	}
+
+	if err := channel.Confirm(false); err != nil {
+		channel.Close()
+		conn.Close()
+		return fmt.Errorf("failed to enable publisher confirms: %w", err)
+	}
+
+	p.conn = conn
+	p.channel = channel
+	// A closed connection closes its channels, so one notification covers both
+	p.closed = channel.NotifyClose(make(chan *amqp.Error, 1))
+	return nil
+}
+
+// disconnect drops the current connection; the next publish dials again
+func (p *Publisher) disconnect() {
+	if p.channel != nil {
+		p.channel.Close()
+	}
+	if p.conn != nil {
+		p.conn.Close()
+	}
+	p.conn = nil
+	p.channel = nil
+	p.closed = nil
+}
+
+// Close closes the channel and connection
+func (p *Publisher) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.channel != nil {
+		p.channel.Close()
+	}
+	var err error
+	if p.conn != nil {
+		err = p.conn.Close()
+	}
+	p.conn = nil
+	p.channel = nil
+	p.closed = nil
+	return err
+}
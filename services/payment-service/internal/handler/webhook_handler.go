@@ -0,0 +1,63 @@
+package handler
+
+import (
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/datngth03/ecommerce-go-app/services/payment-service/internal/service"
+	"github.com/datngth03/ecommerce-go-app/services/payment-service/internal/webhook"
+	"github.com/gin-gonic/gin"
+)
+
+// WebhookHandler receives payment gateway webhook deliveries
+type WebhookHandler struct {
+	paymentService      *service.PaymentService
+	stripeWebhookSecret string
+}
+
+// NewWebhookHandler creates a new webhook handler
+func NewWebhookHandler(paymentService *service.PaymentService, stripeWebhookSecret string) *WebhookHandler {
+	return &WebhookHandler{
+		paymentService:      paymentService,
+		stripeWebhookSecret: stripeWebhookSecret,
+	}
+}
+
+// StripeWebhook verifies and applies a Stripe event.
+// Anything but a 2xx makes Stripe retry the delivery, so only processing
+// failures that may succeed later return 500.
+func (h *WebhookHandler) StripeWebhook(c *gin.Context) {
+	if h.stripeWebhookSecret == "" {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "stripe webhooks are not configured"})
+		return
+	}
+
+	payload, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "failed to read request body"})
+		return
+	}
+
+	signature := c.GetHeader(webhook.StripeSignatureHeader)
+	if err := webhook.VerifyStripeSignature(payload, signature, h.stripeWebhookSecret, webhook.DefaultTolerance, time.Now()); err != nil {
+		log.Printf("Rejected Stripe webhook from %s: %v", c.ClientIP(), err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	event, err := webhook.ParseStripeEvent(payload)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.paymentService.HandleStripeEvent(c.Request.Context(), event); err != nil {
+		log.Printf("Failed to process Stripe event %s (%s): %v", event.ID, event.Type, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to process event"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"received": true})
+}
@@ -8,12 +8,13 @@ import (
 
 // Payment statuses
 const (
-	PaymentStatusPending    = "PENDING"
-	PaymentStatusProcessing = "PROCESSING"
-	PaymentStatusCompleted  = "COMPLETED"
-	PaymentStatusFailed     = "FAILED"
-	PaymentStatusRefunded   = "REFUNDED"
-	PaymentStatusCancelled  = "CANCELLED"
+	PaymentStatusPending           = "PENDING"
+	PaymentStatusProcessing        = "PROCESSING"
+	PaymentStatusCompleted         = "COMPLETED"
+	PaymentStatusFailed            = "FAILED"
+	PaymentStatusRefunded          = "REFUNDED"
+	PaymentStatusPartiallyRefunded = "PARTIALLY_REFUNDED"
+	PaymentStatusCancelled         = "CANCELLED"
 )
 
 // Payment methods
@@ -51,7 +52,11 @@ type Payment struct {
 	Method            string         `gorm:"type:varchar(50);not null" json:"method"`
 	GatewayPaymentID  string         `gorm:"type:varchar(255);index" json:"gateway_payment_id"`
 	GatewayCustomerID string         `gorm:"type:varchar(255)" json:"gateway_customer_id"`
+	PaymentMethodID   *string        `gorm:"type:uuid" json:"payment_method_id,omitempty"`
+	SubscriptionID    *string        `gorm:"type:uuid" json:"subscription_id,omitempty"`
+	BillingPeriod     *time.Time     `json:"billing_period,omitempty"` // Start of the subscription period a renewal charged
 	FailureReason     string         `gorm:"type:text" json:"failure_reason,omitempty"`
+	RefundedAmount    float64        `gorm:"type:decimal(10,2);not null;default:0" json:"refunded_amount"` // Sum of completed refunds
 	Metadata          string         `gorm:"type:jsonb" json:"metadata,omitempty"`
 	CreatedAt         time.Time      `gorm:"autoCreateTime" json:"created_at"`
 	UpdatedAt         time.Time      `gorm:"autoUpdateTime" json:"updated_at"`
@@ -62,6 +67,14 @@ type Payment struct {
 	Refunds      []Refund      `gorm:"foreignKey:PaymentID" json:"refunds,omitempty"`
 }
 
+// PaymentHistory is one page of a user's payments. Total counts all of the user's payments.
+type PaymentHistory struct {
+	Payments   []*Payment
+	Total      int
+	TotalPages int32
+	HasMore    bool
+}
+
 // Transaction represents a payment transaction log
 type Transaction struct {
 	ID              string         `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
@@ -93,7 +106,46 @@ type Refund struct {
 	Payment Payment `gorm:"foreignKey:PaymentID" json:"-"`
 }
 
-// PaymentMethod represents a saved payment method
+// RefundItem is an order line item returned to stock by a refund
+type RefundItem struct {
+	ProductID string `json:"product_id"`
+	Quantity  int32  `json:"quantity"`
+}
+
+// RefundLine records how much of an order item a refund paid back, so that
+// later refunds of the same payment cannot pay for it again
+type RefundLine struct {
+	ID        string    `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	RefundID  string    `gorm:"type:uuid;not null;index" json:"refund_id"`
+	PaymentID string    `gorm:"type:uuid;not null;index" json:"payment_id"`
+	ProductID string    `gorm:"type:varchar(255);not null" json:"product_id"`
+	Quantity  int32     `gorm:"not null" json:"quantity"`
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
+
+// Subscription statuses
+const (
+	SubscriptionStatusActive    = "ACTIVE"
+	SubscriptionStatusPastDue   = "PAST_DUE"
+	SubscriptionStatusCancelled = "CANCELLED"
+)
+
+// Subscription billing intervals
+const (
+	IntervalDay   = "DAY"
+	IntervalWeek  = "WEEK"
+	IntervalMonth = "MONTH"
+	IntervalYear  = "YEAR"
+)
+
+// Saved payment method types
+const (
+	MethodTypeCard        = "CARD"
+	MethodTypeBankAccount = "BANK_ACCOUNT"
+)
+
+// PaymentMethod represents a saved payment method. Only the provider's token and
+// display metadata are stored; card numbers never reach the service.
 type PaymentMethod struct {
 	ID              string         `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
 	UserID          string         `gorm:"type:varchar(255);not null;index" json:"user_id"`
@@ -101,12 +153,48 @@ type PaymentMethod struct {
 	Last4           string         `gorm:"type:varchar(4)" json:"last4"`
 	Brand           string         `gorm:"type:varchar(50)" json:"brand"`
 	GatewayMethodID string         `gorm:"type:varchar(255);not null" json:"gateway_method_id"`
+	ExpMonth        int32          `gorm:"type:smallint" json:"exp_month,omitempty"`
+	ExpYear         int32          `gorm:"type:smallint" json:"exp_year,omitempty"`
 	IsDefault       bool           `gorm:"default:false" json:"is_default"`
 	CreatedAt       time.Time      `gorm:"autoCreateTime" json:"created_at"`
 	UpdatedAt       time.Time      `gorm:"autoUpdateTime" json:"updated_at"`
 	DeletedAt       gorm.DeletedAt `gorm:"index" json:"-"`
 }
 
+// WebhookEvent records a gateway webhook event that has been applied
+type WebhookEvent struct {
+	ID          string    `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	Gateway     string    `gorm:"type:varchar(50);not null;uniqueIndex:uq_webhook_events_gateway_event" json:"gateway"`
+	EventID     string    `gorm:"type:varchar(255);not null;uniqueIndex:uq_webhook_events_gateway_event" json:"event_id"`
+	EventType   string    `gorm:"type:varchar(100);not null" json:"event_type"`
+	ProcessedAt time.Time `gorm:"autoCreateTime" json:"processed_at"`
+}
+
+// Subscription charges a saved payment method every IntervalCount Intervals.
+// CurrentPeriodEnd is when the next billing period starts and its charge is due;
+// NextChargeAt is when the scheduler next tries to charge, which is later than
+// CurrentPeriodEnd while a failed charge is being retried.
+type Subscription struct {
+	ID                string         `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	UserID            string         `gorm:"type:varchar(255);not null;index" json:"user_id"`
+	PlanID            string         `gorm:"type:varchar(255);not null" json:"plan_id"`
+	Amount            float64        `gorm:"type:decimal(10,2);not null" json:"amount"`
+	Currency          string         `gorm:"type:varchar(3);not null;default:'USD'" json:"currency"`
+	Interval          string         `gorm:"column:billing_interval;type:varchar(10);not null" json:"interval"`
+	IntervalCount     int32          `gorm:"not null;default:1" json:"interval_count"`
+	PaymentMethodID   string         `gorm:"type:uuid;not null" json:"payment_method_id"`
+	Status            string         `gorm:"type:varchar(20);not null" json:"status"`
+	CurrentPeriodEnd  time.Time      `gorm:"not null" json:"current_period_end"`
+	NextChargeAt      time.Time      `gorm:"not null" json:"next_charge_at"`
+	FailedAttempts    int32          `gorm:"not null;default:0" json:"failed_attempts"`
+	LastFailureReason string         `gorm:"type:text" json:"last_failure_reason,omitempty"`
+	LastChargedAt     *time.Time     `json:"last_charged_at,omitempty"`
+	CancelledAt       *time.Time     `json:"cancelled_at,omitempty"`
+	CreatedAt         time.Time      `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt         time.Time      `gorm:"autoUpdateTime" json:"updated_at"`
+	DeletedAt         gorm.DeletedAt `gorm:"index" json:"-"`
+}
+
 // TableName specifies the table name for Payment
 func (Payment) TableName() string {
 	return "payments"
@@ -122,7 +210,22 @@ func (Refund) TableName() string {
 	return "refunds"
 }
 
+// TableName specifies the table name for RefundLine
+func (RefundLine) TableName() string {
+	return "refund_items"
+}
+
 // TableName specifies the table name for PaymentMethod
 func (PaymentMethod) TableName() string {
 	return "payment_methods"
 }
+
+// TableName specifies the table name for WebhookEvent
+func (WebhookEvent) TableName() string {
+	return "webhook_events"
+}
+
+// TableName specifies the table name for Subscription
+func (Subscription) TableName() string {
+	return "subscriptions"
+}
@@ -2,7 +2,9 @@ package repository
 
 import (
 	"context"
+	"time"
 
+	"github.com/datngth03/ecommerce-go-app/services/payment-service/internal/events"
 	"github.com/datngth03/ecommerce-go-app/services/payment-service/internal/models"
 )
 
@@ -12,7 +14,10 @@ type PaymentRepository interface {
 	CreatePayment(ctx context.Context, payment *models.Payment) error
 	GetPayment(ctx context.Context, paymentID string) (*models.Payment, error)
 	GetPaymentByOrder(ctx context.Context, orderID string) (*models.Payment, error)
+	GetPaymentByGatewayID(ctx context.Context, gatewayPaymentID string) (*models.Payment, error)
 	UpdatePayment(ctx context.Context, payment *models.Payment) error
+	CompletePayment(ctx context.Context, payment *models.Payment, event *events.PaymentCompletedEvent) (bool, error)
+	FailPayment(ctx context.Context, payment *models.Payment, event *events.PaymentFailedEvent) (bool, error)
 	GetPaymentHistory(ctx context.Context, userID string, limit, offset int) ([]*models.Payment, int, error)
 
 	// Transaction operations
@@ -21,6 +26,8 @@ type PaymentRepository interface {
 
 	// Refund operations
 	CreateRefund(ctx context.Context, refund *models.Refund) error
+	RecordRefund(ctx context.Context, payment *models.Payment, refund *models.Refund, lines []models.RefundLine, ordered map[string]int32, event *events.PaymentRefundedEvent) error
+	GetRefundedQuantities(ctx context.Context, paymentID string) (map[string]int32, error)
 	GetRefund(ctx context.Context, refundID string) (*models.Refund, error)
 	GetRefundsByPayment(ctx context.Context, paymentID string) ([]*models.Refund, error)
 	UpdateRefund(ctx context.Context, refund *models.Refund) error
@@ -29,5 +36,19 @@ type PaymentRepository interface {
 	SavePaymentMethod(ctx context.Context, method *models.PaymentMethod) error
 	GetPaymentMethods(ctx context.Context, userID string) ([]*models.PaymentMethod, error)
 	GetPaymentMethod(ctx context.Context, methodID string) (*models.PaymentMethod, error)
+	GetPaymentMethodByGatewayID(ctx context.Context, userID, gatewayMethodID string) (*models.PaymentMethod, error)
+	SetDefaultPaymentMethod(ctx context.Context, userID, methodID string) error
 	DeletePaymentMethod(ctx context.Context, methodID string) error
+
+	// Subscription operations
+	CreateSubscription(ctx context.Context, subscription *models.Subscription) error
+	GetSubscription(ctx context.Context, subscriptionID string) (*models.Subscription, error)
+	CancelSubscription(ctx context.Context, subscriptionID string, cancelledAt time.Time) error
+	ClaimDueSubscriptions(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*models.Subscription, error)
+	UpdateSubscriptionBilling(ctx context.Context, subscription *models.Subscription) error
+	RecordSubscriptionCharge(ctx context.Context, payment *models.Payment, transaction *models.Transaction, subscription *models.Subscription) (bool, error)
+
+	// Webhook event operations
+	IsWebhookEventProcessed(ctx context.Context, gateway, eventID string) (bool, error)
+	MarkWebhookEventProcessed(ctx context.Context, event *models.WebhookEvent) error
 }
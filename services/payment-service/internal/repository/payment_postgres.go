@@ -3,9 +3,14 @@ package repository
 import (
 	"context"
 	"fmt"
+	"time"
 
+	"github.com/datngth03/ecommerce-go-app/services/payment-service/internal/events"
 	"github.com/datngth03/ecommerce-go-app/services/payment-service/internal/models"
+	"github.com/datngth03/ecommerce-go-app/shared/pkg/domainerr"
+	"github.com/datngth03/ecommerce-go-app/shared/pkg/outbox"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type paymentRepository struct {
@@ -52,11 +57,78 @@ func (r *paymentRepository) GetPaymentByOrder(ctx context.Context, orderID strin
 	return &payment, nil
 }
 
+// GetPaymentByGatewayID retrieves a payment by the gateway's payment ID
+func (r *paymentRepository) GetPaymentByGatewayID(ctx context.Context, gatewayPaymentID string) (*models.Payment, error) {
+	var payment models.Payment
+	err := r.db.WithContext(ctx).
+		Where("gateway_payment_id = ?", gatewayPaymentID).
+		First(&payment).Error
+	if err != nil {
+		return nil, err
+	}
+	return &payment, nil
+}
+
 // UpdatePayment updates a payment
 func (r *paymentRepository) UpdatePayment(ctx context.Context, payment *models.Payment) error {
 	return r.db.WithContext(ctx).Save(payment).Error
 }
 
+// settledStatuses are final: a late or repeated outcome must not change them
+var settledStatuses = []string{
+	models.PaymentStatusCompleted,
+	models.PaymentStatusPartiallyRefunded,
+	models.PaymentStatusRefunded,
+}
+
+// refundableStatuses can take another refund, up to the amount not yet refunded
+var refundableStatuses = []string{models.PaymentStatusCompleted, models.PaymentStatusPartiallyRefunded}
+
+// CompletePayment marks a payment completed and stores its payment.completed event in
+// the outbox, in one transaction. A payment that is already settled is left alone and
+// no event is stored; completed reports whether the payment was updated.
+func (r *paymentRepository) CompletePayment(ctx context.Context, payment *models.Payment, event *events.PaymentCompletedEvent) (bool, error) {
+	event.EventType = events.EventPaymentCompleted
+	return r.settlePayment(ctx, payment, models.PaymentStatusCompleted, event.EventType, event)
+}
+
+// FailPayment marks a payment failed and stores its payment.failed event in the outbox,
+// in one transaction. A payment that is already settled is left alone and no event is
+// stored; failed reports whether the payment was updated.
+func (r *paymentRepository) FailPayment(ctx context.Context, payment *models.Payment, event *events.PaymentFailedEvent) (bool, error) {
+	event.EventType = events.EventPaymentFailed
+	return r.settlePayment(ctx, payment, models.PaymentStatusFailed, event.EventType, event)
+}
+
+// settlePayment moves an unsettled payment to status and enqueues event with it
+func (r *paymentRepository) settlePayment(ctx context.Context, payment *models.Payment, status, topic string, event interface{}) (bool, error) {
+	updated := false
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.Payment{}).
+			Where("id = ? AND status NOT IN ?", payment.ID, settledStatuses).
+			Updates(map[string]interface{}{
+				"status":         status,
+				"failure_reason": payment.FailureReason,
+			})
+		if result.Error != nil {
+			return fmt.Errorf("failed to update payment: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+
+		updated = true
+		return outbox.Enqueue(ctx, tx.Statement.ConnPool, topic, payment.ID, event)
+	})
+	if err != nil {
+		return false, err
+	}
+	if updated {
+		payment.Status = status
+	}
+	return updated, nil
+}
+
 // GetPaymentHistory retrieves payment history for a user
 func (r *paymentRepository) GetPaymentHistory(ctx context.Context, userID string, limit, offset int) ([]*models.Payment, int, error) {
 	var payments []*models.Payment
@@ -101,6 +173,105 @@ func (r *paymentRepository) GetTransactionsByPayment(ctx context.Context, paymen
 	return transactions, nil
 }
 
+// RecordRefund stores a completed refund with the order items it pays back, adds its
+// amount to the payment and stores payment.refunded in the outbox, in one transaction.
+// The payment row stays locked until commit, so concurrent refunds are checked one
+// after the other: a refund above the amount not yet refunded, or paying back more of
+// an item than ordered (by product ID) minus what earlier refunds paid back, is
+// rejected as a conflict. payment gets the new refunded amount and status.
+func (r *paymentRepository) RecordRefund(ctx context.Context, payment *models.Payment, refund *models.Refund, lines []models.RefundLine, ordered map[string]int32, event *events.PaymentRefundedEvent) error {
+	var updated models.Payment
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		amount := gorm.Expr("CAST(? AS DECIMAL(10,2))", refund.Amount)
+		result := tx.Model(&models.Payment{}).
+			Where("id = ? AND status IN ? AND refunded_amount + ? <= amount", payment.ID, refundableStatuses, amount).
+			Updates(map[string]interface{}{
+				"refunded_amount": gorm.Expr("refunded_amount + ?", amount),
+				"status": gorm.Expr("CASE WHEN refunded_amount + ? >= amount THEN ? ELSE ? END",
+					amount, models.PaymentStatusRefunded, models.PaymentStatusPartiallyRefunded),
+			})
+		if result.Error != nil {
+			return fmt.Errorf("failed to update payment: %w", result.Error)
+		}
+		if err := tx.Select("amount", "refunded_amount", "status").Where("id = ?", payment.ID).First(&updated).Error; err != nil {
+			return err
+		}
+		if result.RowsAffected == 0 {
+			if updated.Status != models.PaymentStatusCompleted && updated.Status != models.PaymentStatusPartiallyRefunded {
+				return domainerr.Conflict("can only refund completed payments")
+			}
+			return domainerr.Conflict("refund amount %.2f exceeds the refundable %.2f",
+				refund.Amount, updated.Amount-updated.RefundedAmount)
+		}
+
+		if len(lines) > 0 {
+			refunded, err := refundedQuantities(tx, payment.ID)
+			if err != nil {
+				return err
+			}
+			for _, line := range lines {
+				if refunded[line.ProductID]+line.Quantity > ordered[line.ProductID] {
+					return domainerr.Conflict("refund quantity for product %s exceeds the %d still refundable",
+						line.ProductID, ordered[line.ProductID]-refunded[line.ProductID])
+				}
+				refunded[line.ProductID] += line.Quantity
+			}
+		}
+
+		refund.PaymentID = payment.ID
+		if err := tx.Create(refund).Error; err != nil {
+			return fmt.Errorf("failed to create refund: %w", err)
+		}
+		for i := range lines {
+			lines[i].RefundID = refund.ID
+			lines[i].PaymentID = payment.ID
+		}
+		if len(lines) > 0 {
+			if err := tx.Create(&lines).Error; err != nil {
+				return fmt.Errorf("failed to record refund items: %w", err)
+			}
+		}
+
+		event.EventType = events.EventPaymentRefunded
+		event.RefundID = refund.ID
+		event.RefundedAt = refund.CreatedAt
+		return outbox.Enqueue(ctx, tx.Statement.ConnPool, event.EventType, payment.ID, event)
+	})
+	if err != nil {
+		return err
+	}
+
+	payment.RefundedAmount = updated.RefundedAmount
+	payment.Status = updated.Status
+	return nil
+}
+
+// GetRefundedQuantities sums the quantities the refunds of a payment paid back, by product ID
+func (r *paymentRepository) GetRefundedQuantities(ctx context.Context, paymentID string) (map[string]int32, error) {
+	return refundedQuantities(r.db.WithContext(ctx), paymentID)
+}
+
+func refundedQuantities(db *gorm.DB, paymentID string) (map[string]int32, error) {
+	var rows []struct {
+		ProductID string
+		Quantity  int32
+	}
+	err := db.Model(&models.RefundLine{}).
+		Select("product_id, SUM(quantity) AS quantity").
+		Where("payment_id = ?", paymentID).
+		Group("product_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	refunded := make(map[string]int32, len(rows))
+	for _, row := range rows {
+		refunded[row.ProductID] = row.Quantity
+	}
+	return refunded, nil
+}
+
 // CreateRefund creates a new refund
 func (r *paymentRepository) CreateRefund(ctx context.Context, refund *models.Refund) error {
 	return r.db.WithContext(ctx).Create(refund).Error
@@ -176,7 +347,165 @@ func (r *paymentRepository) GetPaymentMethod(ctx context.Context, methodID strin
 	return &method, nil
 }
 
+// GetPaymentMethodByGatewayID retrieves a user's payment method by its provider token
+func (r *paymentRepository) GetPaymentMethodByGatewayID(ctx context.Context, userID, gatewayMethodID string) (*models.PaymentMethod, error) {
+	var method models.PaymentMethod
+	err := r.db.WithContext(ctx).
+		Where("user_id = ? AND gateway_method_id = ?", userID, gatewayMethodID).
+		First(&method).Error
+	if err != nil {
+		return nil, err
+	}
+	return &method, nil
+}
+
+// SetDefaultPaymentMethod makes methodID the user's only default payment method
+func (r *paymentRepository) SetDefaultPaymentMethod(ctx context.Context, userID, methodID string) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.PaymentMethod{}).
+			Where("user_id = ? AND id != ?", userID, methodID).
+			Update("is_default", false).Error; err != nil {
+			return fmt.Errorf("failed to unset other defaults: %w", err)
+		}
+		return tx.Model(&models.PaymentMethod{}).
+			Where("user_id = ? AND id = ?", userID, methodID).
+			Update("is_default", true).Error
+	})
+}
+
 // DeletePaymentMethod deletes a payment method
 func (r *paymentRepository) DeletePaymentMethod(ctx context.Context, methodID string) error {
 	return r.db.WithContext(ctx).Delete(&models.PaymentMethod{}, "id = ?", methodID).Error
 }
+
+// CreateSubscription creates a new subscription
+func (r *paymentRepository) CreateSubscription(ctx context.Context, subscription *models.Subscription) error {
+	return r.db.WithContext(ctx).Create(subscription).Error
+}
+
+// GetSubscription retrieves a subscription by ID
+func (r *paymentRepository) GetSubscription(ctx context.Context, subscriptionID string) (*models.Subscription, error) {
+	var subscription models.Subscription
+	err := r.db.WithContext(ctx).Where("id = ?", subscriptionID).First(&subscription).Error
+	if err != nil {
+		return nil, err
+	}
+	return &subscription, nil
+}
+
+// CancelSubscription marks a subscription cancelled so the scheduler no longer charges it
+func (r *paymentRepository) CancelSubscription(ctx context.Context, subscriptionID string, cancelledAt time.Time) error {
+	return r.db.WithContext(ctx).Model(&models.Subscription{}).
+		Where("id = ? AND status <> ?", subscriptionID, models.SubscriptionStatusCancelled).
+		Updates(map[string]interface{}{
+			"status":       models.SubscriptionStatusCancelled,
+			"cancelled_at": cancelledAt,
+		}).Error
+}
+
+// ClaimDueSubscriptions returns up to limit active subscriptions due at now and pushes
+// their next_charge_at out by lease, so concurrent schedulers (or a crash mid-charge)
+// cannot charge the same period twice before the lease runs out. The returned rows
+// carry the values read before the lease was applied.
+func (r *paymentRepository) ClaimDueSubscriptions(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*models.Subscription, error) {
+	var subscriptions []*models.Subscription
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND next_charge_at <= ?", models.SubscriptionStatusActive, now).
+			Order("next_charge_at").
+			Limit(limit).
+			Find(&subscriptions).Error; err != nil {
+			return err
+		}
+		if len(subscriptions) == 0 {
+			return nil
+		}
+
+		ids := make([]string, len(subscriptions))
+		for i, subscription := range subscriptions {
+			ids[i] = subscription.ID
+		}
+		return tx.Model(&models.Subscription{}).
+			Where("id IN ?", ids).
+			Update("next_charge_at", now.Add(lease)).Error
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim due subscriptions: %w", err)
+	}
+	return subscriptions, nil
+}
+
+// UpdateSubscriptionBilling stores the outcome of a charge attempt. A subscription
+// cancelled while it was being charged stays cancelled.
+func (r *paymentRepository) UpdateSubscriptionBilling(ctx context.Context, subscription *models.Subscription) error {
+	return updateSubscriptionBilling(r.db.WithContext(ctx), subscription)
+}
+
+// RecordSubscriptionCharge stores a successful renewal charge, its transaction and the
+// advanced subscription in one transaction, so a charged period is never left due.
+// Payments are unique per (subscription_id, billing_period): when the period was
+// already charged no payment is created, the subscription is still advanced and
+// created is false.
+func (r *paymentRepository) RecordSubscriptionCharge(ctx context.Context, payment *models.Payment, transaction *models.Transaction, subscription *models.Subscription) (bool, error) {
+	created := false
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Clauses(clause.OnConflict{
+			Columns:     []clause.Column{{Name: "subscription_id"}, {Name: "billing_period"}},
+			TargetWhere: clause.Where{Exprs: []clause.Expression{clause.Expr{SQL: "billing_period IS NOT NULL"}}},
+			DoNothing:   true,
+		}).Create(payment)
+		if result.Error != nil {
+			return fmt.Errorf("failed to create payment: %w", result.Error)
+		}
+
+		created = result.RowsAffected > 0
+		if created {
+			transaction.PaymentID = payment.ID
+			if err := tx.Create(transaction).Error; err != nil {
+				return fmt.Errorf("failed to create transaction: %w", err)
+			}
+		}
+
+		if err := updateSubscriptionBilling(tx, subscription); err != nil {
+			return fmt.Errorf("failed to update subscription: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+	return created, nil
+}
+
+func updateSubscriptionBilling(db *gorm.DB, subscription *models.Subscription) error {
+	return db.Model(&models.Subscription{}).
+		Where("id = ? AND status <> ?", subscription.ID, models.SubscriptionStatusCancelled).
+		Updates(map[string]interface{}{
+			"status":              subscription.Status,
+			"current_period_end":  subscription.CurrentPeriodEnd,
+			"next_charge_at":      subscription.NextChargeAt,
+			"failed_attempts":     subscription.FailedAttempts,
+			"last_failure_reason": subscription.LastFailureReason,
+			"last_charged_at":     subscription.LastChargedAt,
+		}).Error
+}
+
+// IsWebhookEventProcessed reports whether a gateway event has already been applied
+func (r *paymentRepository) IsWebhookEventProcessed(ctx context.Context, gateway, eventID string) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&models.WebhookEvent{}).
+		Where("gateway = ? AND event_id = ?", gateway, eventID).
+		Count(&count).Error
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// MarkWebhookEventProcessed records a gateway event as applied; recording it twice is a no-op
+func (r *paymentRepository) MarkWebhookEventProcessed(ctx context.Context, event *models.WebhookEvent) error {
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "gateway"}, {Name: "event_id"}},
+		DoNothing: true,
+	}).Create(event).Error
+}
@@ -6,21 +6,24 @@ import (
 
 	pb "github.com/datngth03/ecommerce-go-app/proto/payment_service"
 	"github.com/datngth03/ecommerce-go-app/services/payment-service/internal/metrics"
+	"github.com/datngth03/ecommerce-go-app/services/payment-service/internal/models"
 	"github.com/datngth03/ecommerce-go-app/services/payment-service/internal/service"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
+	"github.com/datngth03/ecommerce-go-app/shared/pkg/domainerr"
+	"github.com/datngth03/ecommerce-go-app/shared/pkg/validator"
 )
 
 // PaymentServer implements the gRPC payment service
 type PaymentServer struct {
 	pb.UnimplementedPaymentServiceServer
-	service *service.PaymentService
+	service       *service.PaymentService
+	subscriptions *service.SubscriptionService
 }
 
 // NewPaymentServer creates a new gRPC payment server
-func NewPaymentServer(svc *service.PaymentService) *PaymentServer {
+func NewPaymentServer(svc *service.PaymentService, subscriptions *service.SubscriptionService) *PaymentServer {
 	return &PaymentServer{
-		service: svc,
+		service:       svc,
+		subscriptions: subscriptions,
 	}
 }
 
@@ -35,6 +38,7 @@ func (s *PaymentServer) ProcessPayment(ctx context.Context, req *pb.ProcessPayme
 		req.Amount,
 		req.Currency,
 		req.Method,
+		req.PaymentMethodId,
 		req.Metadata,
 	)
 
@@ -47,7 +51,7 @@ func (s *PaymentServer) ProcessPayment(ctx context.Context, req *pb.ProcessPayme
 		paymentStatus = "failed"
 		metrics.RecordGRPCRequest("ProcessPayment", grpcStatus, duration)
 		metrics.RecordPayment(req.Method, paymentStatus, req.Amount, req.Currency, duration)
-		return nil, status.Error(codes.Internal, err.Error())
+		return nil, err
 	}
 
 	// Record successful payment
@@ -66,9 +70,11 @@ func (s *PaymentServer) ProcessPayment(ctx context.Context, req *pb.ProcessPayme
 			GatewayPaymentId:  payment.GatewayPaymentID,
 			GatewayCustomerId: payment.GatewayCustomerID,
 			FailureReason:     payment.FailureReason,
+			RefundedAmount:    payment.RefundedAmount,
 			Metadata:          payment.Metadata,
 			CreatedAt:         payment.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
 			UpdatedAt:         payment.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
+			PaymentMethodId:   stringValue(payment.PaymentMethodID),
 		},
 		Success:      true,
 		Message:      "Payment processed successfully",
@@ -78,6 +84,10 @@ func (s *PaymentServer) ProcessPayment(ctx context.Context, req *pb.ProcessPayme
 
 // ConfirmPayment confirms a pending payment
 func (s *PaymentServer) ConfirmPayment(ctx context.Context, req *pb.ConfirmPaymentRequest) (*pb.ConfirmPaymentResponse, error) {
+	if err := validator.ValidateUUIDArg(req.PaymentId, "payment_id"); err != nil {
+		return nil, err
+	}
+
 	start := time.Now()
 
 	payment, err := s.service.ConfirmPayment(ctx, req.PaymentId, req.PaymentIntentId)
@@ -86,7 +96,7 @@ func (s *PaymentServer) ConfirmPayment(ctx context.Context, req *pb.ConfirmPayme
 	if err != nil {
 		grpcStatus = "error"
 		metrics.RecordGRPCRequest("ConfirmPayment", grpcStatus, time.Since(start))
-		return nil, status.Error(codes.Internal, err.Error())
+		return nil, err
 	}
 
 	metrics.RecordGRPCRequest("ConfirmPayment", grpcStatus, time.Since(start))
@@ -108,9 +118,18 @@ func (s *PaymentServer) ConfirmPayment(ctx context.Context, req *pb.ConfirmPayme
 
 // RefundPayment processes a refund
 func (s *PaymentServer) RefundPayment(ctx context.Context, req *pb.RefundPaymentRequest) (*pb.RefundPaymentResponse, error) {
+	if err := validator.ValidateUUIDArg(req.PaymentId, "payment_id"); err != nil {
+		return nil, err
+	}
+
 	start := time.Now()
 
-	refund, err := s.service.RefundPayment(ctx, req.PaymentId, req.Amount, req.Reason)
+	items := make([]models.RefundItem, len(req.Items))
+	for i, item := range req.Items {
+		items[i] = models.RefundItem{ProductID: item.ProductId, Quantity: item.Quantity}
+	}
+
+	refund, err := s.service.RefundPayment(ctx, req.PaymentId, req.Amount, req.Reason, items, req.SkipRestock)
 
 	grpcStatus := "success"
 	refundStatus := "success"
@@ -119,7 +138,7 @@ func (s *PaymentServer) RefundPayment(ctx context.Context, req *pb.RefundPayment
 		refundStatus = "failed"
 		metrics.RecordGRPCRequest("RefundPayment", grpcStatus, time.Since(start))
 		metrics.RecordRefund(refundStatus)
-		return nil, status.Error(codes.Internal, err.Error())
+		return nil, err
 	}
 
 	metrics.RecordGRPCRequest("RefundPayment", grpcStatus, time.Since(start))
@@ -143,6 +162,10 @@ func (s *PaymentServer) RefundPayment(ctx context.Context, req *pb.RefundPayment
 
 // GetPayment retrieves payment details
 func (s *PaymentServer) GetPayment(ctx context.Context, req *pb.GetPaymentRequest) (*pb.GetPaymentResponse, error) {
+	if err := validator.ValidateUUIDArg(req.PaymentId, "payment_id"); err != nil {
+		return nil, err
+	}
+
 	start := time.Now()
 
 	payment, err := s.service.GetPayment(ctx, req.PaymentId)
@@ -151,7 +174,7 @@ func (s *PaymentServer) GetPayment(ctx context.Context, req *pb.GetPaymentReques
 	if err != nil {
 		grpcStatus = "error"
 		metrics.RecordGRPCRequest("GetPayment", grpcStatus, time.Since(start))
-		return nil, status.Error(codes.NotFound, err.Error())
+		return nil, err
 	}
 
 	metrics.RecordGRPCRequest("GetPayment", grpcStatus, time.Since(start))
@@ -197,9 +220,11 @@ func (s *PaymentServer) GetPayment(ctx context.Context, req *pb.GetPaymentReques
 			GatewayPaymentId:  payment.GatewayPaymentID,
 			GatewayCustomerId: payment.GatewayCustomerID,
 			FailureReason:     payment.FailureReason,
+			RefundedAmount:    payment.RefundedAmount,
 			Metadata:          payment.Metadata,
 			CreatedAt:         payment.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
 			UpdatedAt:         payment.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
+			PaymentMethodId:   stringValue(payment.PaymentMethodID),
 		},
 		Transactions: transactions,
 		Refunds:      refunds,
@@ -210,7 +235,7 @@ func (s *PaymentServer) GetPayment(ctx context.Context, req *pb.GetPaymentReques
 func (s *PaymentServer) GetPaymentByOrder(ctx context.Context, req *pb.GetPaymentByOrderRequest) (*pb.GetPaymentByOrderResponse, error) {
 	payment, err := s.service.GetPaymentByOrder(ctx, req.OrderId)
 	if err != nil {
-		return nil, status.Error(codes.NotFound, err.Error())
+		return nil, err
 	}
 
 	return &pb.GetPaymentByOrderResponse{
@@ -222,19 +247,21 @@ func (s *PaymentServer) GetPaymentByOrder(ctx context.Context, req *pb.GetPaymen
 			Currency: payment.Currency,
 			Status:   payment.Status,
 			Method:   payment.Method,
+
+			RefundedAmount: payment.RefundedAmount,
 		},
 	}, nil
 }
 
 // GetPaymentHistory retrieves user payment history
 func (s *PaymentServer) GetPaymentHistory(ctx context.Context, req *pb.GetPaymentHistoryRequest) (*pb.GetPaymentHistoryResponse, error) {
-	payments, total, err := s.service.GetPaymentHistory(ctx, req.UserId, int(req.Limit), int(req.Offset))
+	history, err := s.service.GetPaymentHistory(ctx, req.UserId, int(req.Limit), int(req.Offset))
 	if err != nil {
-		return nil, status.Error(codes.Internal, err.Error())
+		return nil, err
 	}
 
 	var pbPayments []*pb.Payment
-	for _, p := range payments {
+	for _, p := range history.Payments {
 		pbPayments = append(pbPayments, &pb.Payment{
 			Id:        p.ID,
 			OrderId:   p.OrderID,
@@ -248,16 +275,27 @@ func (s *PaymentServer) GetPaymentHistory(ctx context.Context, req *pb.GetPaymen
 	}
 
 	return &pb.GetPaymentHistoryResponse{
-		Payments: pbPayments,
-		Total:    int32(total),
+		Payments:   pbPayments,
+		Total:      int32(history.Total),
+		TotalPages: history.TotalPages,
+		HasMore:    history.HasMore,
 	}, nil
 }
 
 // SavePaymentMethod saves a payment method
 func (s *PaymentServer) SavePaymentMethod(ctx context.Context, req *pb.SavePaymentMethodRequest) (*pb.SavePaymentMethodResponse, error) {
-	method, err := s.service.SavePaymentMethod(ctx, req.UserId, req.MethodType, req.GatewayMethodId, req.IsDefault)
+	method, err := s.service.SavePaymentMethod(ctx, &models.PaymentMethod{
+		UserID:          req.UserId,
+		MethodType:      req.MethodType,
+		GatewayMethodID: req.GatewayMethodId,
+		Last4:           req.Last4,
+		Brand:           req.Brand,
+		ExpMonth:        req.ExpMonth,
+		ExpYear:         req.ExpYear,
+		IsDefault:       req.IsDefault,
+	})
 	if err != nil {
-		return nil, status.Error(codes.Internal, err.Error())
+		return nil, err
 	}
 
 	return &pb.SavePaymentMethodResponse{
@@ -270,6 +308,8 @@ func (s *PaymentServer) SavePaymentMethod(ctx context.Context, req *pb.SavePayme
 			GatewayMethodId: method.GatewayMethodID,
 			IsDefault:       method.IsDefault,
 			CreatedAt:       method.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+			ExpMonth:        method.ExpMonth,
+			ExpYear:         method.ExpYear,
 		},
 		Success: true,
 		Message: "Payment method saved successfully",
@@ -280,7 +320,7 @@ func (s *PaymentServer) SavePaymentMethod(ctx context.Context, req *pb.SavePayme
 func (s *PaymentServer) GetPaymentMethods(ctx context.Context, req *pb.GetPaymentMethodsRequest) (*pb.GetPaymentMethodsResponse, error) {
 	methods, err := s.service.GetPaymentMethods(ctx, req.UserId)
 	if err != nil {
-		return nil, status.Error(codes.Internal, err.Error())
+		return nil, err
 	}
 
 	var pbMethods []*pb.PaymentMethod
@@ -294,6 +334,8 @@ func (s *PaymentServer) GetPaymentMethods(ctx context.Context, req *pb.GetPaymen
 			GatewayMethodId: m.GatewayMethodID,
 			IsDefault:       m.IsDefault,
 			CreatedAt:       m.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+			ExpMonth:        m.ExpMonth,
+			ExpYear:         m.ExpYear,
 		})
 	}
 
@@ -302,11 +344,77 @@ func (s *PaymentServer) GetPaymentMethods(ctx context.Context, req *pb.GetPaymen
 	}, nil
 }
 
+// DeletePaymentMethod removes a user's saved payment method
+func (s *PaymentServer) DeletePaymentMethod(ctx context.Context, req *pb.DeletePaymentMethodRequest) (*pb.DeletePaymentMethodResponse, error) {
+	if err := validator.ValidateUUIDArg(req.PaymentMethodId, "payment_method_id"); err != nil {
+		return nil, err
+	}
+
+	err := s.service.DeletePaymentMethod(ctx, req.UserId, req.PaymentMethodId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.DeletePaymentMethodResponse{
+		Success: true,
+		Message: "Payment method deleted successfully",
+	}, nil
+}
+
+// CreateSubscription starts recurring charges on a saved payment method
+func (s *PaymentServer) CreateSubscription(ctx context.Context, req *pb.CreateSubscriptionRequest) (*pb.CreateSubscriptionResponse, error) {
+	var startAt time.Time
+	if req.StartAt != "" {
+		var err error
+		startAt, err = time.Parse(time.RFC3339, req.StartAt)
+		if err != nil {
+			return nil, domainerr.InvalidArgument("start_at must be an RFC3339 timestamp")
+		}
+	}
+
+	subscription, err := s.subscriptions.CreateSubscription(ctx, &models.Subscription{
+		UserID:          req.UserId,
+		PlanID:          req.PlanId,
+		Amount:          req.Amount,
+		Currency:        req.Currency,
+		Interval:        req.Interval,
+		IntervalCount:   req.IntervalCount,
+		PaymentMethodID: req.PaymentMethodId,
+	}, startAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.CreateSubscriptionResponse{
+		Subscription: convertSubscription(subscription),
+		Success:      true,
+		Message:      "Subscription created successfully",
+	}, nil
+}
+
+// CancelSubscription stops a subscription's future charges
+func (s *PaymentServer) CancelSubscription(ctx context.Context, req *pb.CancelSubscriptionRequest) (*pb.CancelSubscriptionResponse, error) {
+	if err := validator.ValidateUUIDArg(req.SubscriptionId, "subscription_id"); err != nil {
+		return nil, err
+	}
+
+	subscription, err := s.subscriptions.CancelSubscription(ctx, req.UserId, req.SubscriptionId, req.Reason)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.CancelSubscriptionResponse{
+		Subscription: convertSubscription(subscription),
+		Success:      true,
+		Message:      "Subscription cancelled successfully",
+	}, nil
+}
+
 // HandleWebhook handles payment gateway webhooks
 func (s *PaymentServer) HandleWebhook(ctx context.Context, req *pb.WebhookEventRequest) (*pb.WebhookEventResponse, error) {
 	err := s.service.HandleWebhook(ctx, req.Gateway, req.EventType, req.EventData)
 	if err != nil {
-		return nil, status.Error(codes.Internal, err.Error())
+		return nil, err
 	}
 
 	return &pb.WebhookEventResponse{
@@ -314,3 +422,35 @@ func (s *PaymentServer) HandleWebhook(ctx context.Context, req *pb.WebhookEventR
 		Message: "Webhook processed successfully",
 	}, nil
 }
+
+// stringValue dereferences an optional string column
+func stringValue(v *string) string {
+	if v == nil {
+		return ""
+	}
+	return *v
+}
+
+// convertSubscription converts a subscription model to protobuf
+func convertSubscription(subscription *models.Subscription) *pb.Subscription {
+	pbSubscription := &pb.Subscription{
+		Id:                subscription.ID,
+		UserId:            subscription.UserID,
+		PlanId:            subscription.PlanID,
+		Amount:            subscription.Amount,
+		Currency:          subscription.Currency,
+		Interval:          subscription.Interval,
+		IntervalCount:     subscription.IntervalCount,
+		PaymentMethodId:   subscription.PaymentMethodID,
+		Status:            subscription.Status,
+		CurrentPeriodEnd:  subscription.CurrentPeriodEnd.Format("2006-01-02T15:04:05Z07:00"),
+		NextChargeAt:      subscription.NextChargeAt.Format("2006-01-02T15:04:05Z07:00"),
+		FailedAttempts:    subscription.FailedAttempts,
+		LastFailureReason: subscription.LastFailureReason,
+		CreatedAt:         subscription.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+	if subscription.CancelledAt != nil {
+		pbSubscription.CancelledAt = subscription.CancelledAt.Format("2006-01-02T15:04:05Z07:00")
+	}
+	return pbSubscription
+}
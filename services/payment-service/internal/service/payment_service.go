@@ -3,32 +3,60 @@ package service
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"log"
+	"math"
+	"strings"
+	"time"
 
+	orderpb "github.com/datngth03/ecommerce-go-app/proto/order_service"
+	"github.com/datngth03/ecommerce-go-app/services/payment-service/internal/events"
 	"github.com/datngth03/ecommerce-go-app/services/payment-service/internal/models"
 	"github.com/datngth03/ecommerce-go-app/services/payment-service/internal/repository"
+	"github.com/datngth03/ecommerce-go-app/services/payment-service/internal/webhook"
+	"github.com/datngth03/ecommerce-go-app/shared/pkg/domainerr"
+	"github.com/datngth03/ecommerce-go-app/shared/pkg/paging"
+	"gorm.io/gorm"
 )
 
+// OrderLookup provides order details from order service
+type OrderLookup interface {
+	GetOrder(ctx context.Context, orderID string) (*orderpb.Order, error)
+}
+
 // PaymentService handles payment business logic
 type PaymentService struct {
-	repo repository.PaymentRepository
+	repo   repository.PaymentRepository
+	orders OrderLookup
 }
 
 // NewPaymentService creates a new payment service
-func NewPaymentService(repo repository.PaymentRepository) *PaymentService {
+func NewPaymentService(repo repository.PaymentRepository, orders OrderLookup) *PaymentService {
 	return &PaymentService{
-		repo: repo,
+		repo:   repo,
+		orders: orders,
 	}
 }
 
-// ProcessPayment processes a new payment
-func (s *PaymentService) ProcessPayment(ctx context.Context, orderID, userID string, amount float64, currency, method string, metadata map[string]string) (*models.Payment, string, error) {
+// ProcessPayment processes a new payment, charging the user's saved payment method
+// when paymentMethodID is set
+func (s *PaymentService) ProcessPayment(ctx context.Context, orderID, userID string, amount float64, currency, method, paymentMethodID string, metadata map[string]string) (*models.Payment, string, error) {
 	// Validate input
 	if orderID == "" || userID == "" {
-		return nil, "", fmt.Errorf("order_id and user_id are required")
+		return nil, "", domainerr.InvalidArgument("order_id and user_id are required")
 	}
 	if amount <= 0 {
-		return nil, "", fmt.Errorf("amount must be positive")
+		return nil, "", domainerr.InvalidArgument("amount must be positive")
+	}
+
+	var savedMethod *models.PaymentMethod
+	if paymentMethodID != "" {
+		var err error
+		savedMethod, err = userPaymentMethod(ctx, s.repo, userID, paymentMethodID)
+		if err != nil {
+			return nil, "", err
+		}
 	}
 
 	// Convert metadata to JSON
@@ -44,6 +72,10 @@ func (s *PaymentService) ProcessPayment(ctx context.Context, orderID, userID str
 		Method:   method,
 		Metadata: string(metadataJSON),
 	}
+	if savedMethod != nil {
+		// The gateway charges the stored token (savedMethod.GatewayMethodID)
+		payment.PaymentMethodID = &savedMethod.ID
+	}
 
 	// TODO: Integrate with payment gateway (Stripe/PayPal)
 	// For now, we'll simulate payment processing
@@ -66,38 +98,77 @@ func (s *PaymentService) ProcessPayment(ctx context.Context, orderID, userID str
 	s.repo.CreateTransaction(ctx, transaction)
 
 	// Simulate successful payment (in production, this would be async via webhook)
-	payment.Status = models.PaymentStatusCompleted
-	s.repo.UpdatePayment(ctx, payment)
+	if _, err := s.repo.CompletePayment(ctx, payment, s.completedEvent(ctx, payment)); err != nil {
+		return nil, "", fmt.Errorf("failed to complete payment: %w", err)
+	}
 
 	return payment, "", nil // client_secret for 3D Secure (not implemented)
 }
 
 // ConfirmPayment confirms a pending payment (for 3D Secure)
 func (s *PaymentService) ConfirmPayment(ctx context.Context, paymentID, paymentIntentID string) (*models.Payment, error) {
-	payment, err := s.repo.GetPayment(ctx, paymentID)
+	payment, err := s.getPayment(ctx, paymentID)
 	if err != nil {
-		return nil, fmt.Errorf("payment not found: %w", err)
+		return nil, err
 	}
 
 	// TODO: Confirm with payment gateway
-	payment.Status = models.PaymentStatusCompleted
-	err = s.repo.UpdatePayment(ctx, payment)
+	if payment.Status == models.PaymentStatusCompleted {
+		return payment, nil
+	}
+	completed, err := s.repo.CompletePayment(ctx, payment, s.completedEvent(ctx, payment))
 	if err != nil {
 		return nil, fmt.Errorf("failed to update payment: %w", err)
 	}
+	if !completed {
+		// Settled meanwhile (or refunded already); report its current state
+		return s.getPayment(ctx, paymentID)
+	}
 
 	return payment, nil
 }
 
-// RefundPayment processes a refund
-func (s *PaymentService) RefundPayment(ctx context.Context, paymentID string, amount float64, reason string) (*models.Refund, error) {
-	payment, err := s.repo.GetPayment(ctx, paymentID)
+// RefundPayment processes a refund and publishes payment.refunded through the outbox.
+// amount 0 refunds whatever is still refundable; a payment is never refunded beyond
+// its amount in total. items are the order items going back to stock; the refund that
+// settles the payment in full returns, without items, every item not yet returned, and
+// a partial refund without items returns none. An item is returned at most as many
+// times as it was ordered. skipRestock refunds money only, for stock that came back
+// another way.
+func (s *PaymentService) RefundPayment(ctx context.Context, paymentID string, amount float64, reason string, items []models.RefundItem, skipRestock bool) (*models.Refund, error) {
+	if skipRestock && len(items) > 0 {
+		return nil, domainerr.InvalidArgument("items cannot be combined with skip_restock")
+	}
+	if amount < 0 {
+		return nil, domainerr.InvalidArgument("refund amount cannot be negative")
+	}
+
+	payment, err := s.getPayment(ctx, paymentID)
 	if err != nil {
-		return nil, fmt.Errorf("payment not found: %w", err)
+		return nil, err
 	}
 
-	if payment.Status != models.PaymentStatusCompleted {
-		return nil, fmt.Errorf("can only refund completed payments")
+	if payment.Status != models.PaymentStatusCompleted && payment.Status != models.PaymentStatusPartiallyRefunded {
+		return nil, domainerr.Conflict("can only refund completed payments")
+	}
+
+	refundable := roundCents(payment.Amount - payment.RefundedAmount)
+	amount = roundCents(amount)
+	if amount == 0 {
+		amount = refundable
+	}
+	if amount > refundable {
+		return nil, domainerr.InvalidArgument("refund amount %.2f exceeds the refundable %.2f", amount, refundable)
+	}
+
+	// Subscription charges have no order, so nothing goes back to stock
+	var order *orderpb.Order
+	var returned []models.RefundItem
+	if payment.SubscriptionID == nil && !skipRestock {
+		order, returned, err = s.returnedItems(ctx, payment, items, amount == refundable)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	// Create refund record
@@ -112,55 +183,261 @@ func (s *PaymentService) RefundPayment(ctx context.Context, paymentID string, am
 	refund.Status = models.RefundStatusCompleted
 	refund.GatewayRefundID = fmt.Sprintf("rfnd_%s", payment.ID)
 
-	err = s.repo.CreateRefund(ctx, refund)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create refund: %w", err)
+	lines := make([]models.RefundLine, len(returned))
+	for i, item := range returned {
+		lines[i] = models.RefundLine{ProductID: item.ProductID, Quantity: item.Quantity}
+	}
+	event := &events.PaymentRefundedEvent{
+		PaymentID: payment.ID,
+		OrderID:   payment.OrderID,
+		Amount:    refund.Amount,
+		Reason:    refund.Reason,
+		Items:     []events.RefundedItem{},
+	}
+	if order != nil {
+		for _, item := range stockItems(order, returned) {
+			event.Items = append(event.Items, events.RefundedItem{ProductID: item.ProductID, Quantity: item.Quantity})
+		}
 	}
 
-	// Update payment status if fully refunded
-	if amount >= payment.Amount {
-		payment.Status = models.PaymentStatusRefunded
-		s.repo.UpdatePayment(ctx, payment)
+	if err := s.repo.RecordRefund(ctx, payment, refund, lines, orderedQuantities(order), event); err != nil {
+		return nil, fmt.Errorf("failed to create refund: %w", err)
 	}
 
 	return refund, nil
 }
 
+// returnedItems resolves and validates the order items a refund puts back in stock,
+// against what the order has not returned yet. The order is nil when nothing goes back.
+func (s *PaymentService) returnedItems(ctx context.Context, payment *models.Payment, items []models.RefundItem, finalRefund bool) (*orderpb.Order, []models.RefundItem, error) {
+	if len(items) == 0 && !finalRefund {
+		return nil, nil, nil
+	}
+
+	order, err := s.orders.GetOrder(ctx, payment.OrderID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get order %s: %w", payment.OrderID, err)
+	}
+	refunded, err := s.repo.GetRefundedQuantities(ctx, payment.ID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get refunded items: %w", err)
+	}
+	ordered := orderedQuantities(order)
+
+	if len(items) == 0 {
+		var returned []models.RefundItem
+		for _, item := range order.Items {
+			if remaining := ordered[item.ProductId] - refunded[item.ProductId]; remaining > 0 {
+				returned = append(returned, models.RefundItem{ProductID: item.ProductId, Quantity: remaining})
+				refunded[item.ProductId] += remaining
+			}
+		}
+		return order, returned, nil
+	}
+
+	for _, item := range items {
+		if item.ProductID == "" {
+			return nil, nil, domainerr.InvalidArgument("product_id is required for refund items")
+		}
+		if item.Quantity <= 0 {
+			return nil, nil, domainerr.InvalidArgument("quantity must be positive for product %s", item.ProductID)
+		}
+		if _, ok := ordered[item.ProductID]; !ok {
+			return nil, nil, domainerr.InvalidArgument("product %s is not part of order %s", item.ProductID, payment.OrderID)
+		}
+		if refunded[item.ProductID]+item.Quantity > ordered[item.ProductID] {
+			return nil, nil, domainerr.InvalidArgument("refund quantity for product %s exceeds the %d still refundable",
+				item.ProductID, ordered[item.ProductID]-refunded[item.ProductID])
+		}
+		refunded[item.ProductID] += item.Quantity
+	}
+
+	return order, items, nil
+}
+
+// orderedQuantities sums the quantities of an order's items by product ID
+func orderedQuantities(order *orderpb.Order) map[string]int32 {
+	ordered := make(map[string]int32)
+	if order == nil {
+		return ordered
+	}
+	for _, item := range order.Items {
+		ordered[item.ProductId] += item.Quantity
+	}
+	return ordered
+}
+
+// roundCents rounds an amount to whole cents, so amounts left over after
+// subtracting refunds compare exactly
+func roundCents(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
+
+// stockItems replaces the bundles among items with their components, using the
+// composition recorded on the order, since stock is held per component.
+// Quantities of the same product are merged in first-seen order.
+func stockItems(order *orderpb.Order, items []models.RefundItem) []models.RefundItem {
+	components := make(map[string][]*orderpb.OrderItemComponent)
+	for _, item := range order.Items {
+		if len(item.Components) > 0 {
+			components[item.ProductId] = item.Components
+		}
+	}
+
+	var stock []models.RefundItem
+	index := make(map[string]int, len(items))
+	add := func(productID string, quantity int32) {
+		if i, ok := index[productID]; ok {
+			stock[i].Quantity += quantity
+			return
+		}
+		index[productID] = len(stock)
+		stock = append(stock, models.RefundItem{ProductID: productID, Quantity: quantity})
+	}
+	for _, item := range items {
+		if parts, ok := components[item.ProductID]; ok {
+			for _, part := range parts {
+				add(part.ProductId, part.Quantity*item.Quantity)
+			}
+			continue
+		}
+		add(item.ProductID, item.Quantity)
+	}
+	return stock
+}
+
+// completedEvent builds the payment.completed event with the items of the paid order.
+// Without items inventory commits the order's reservations as they are.
+func (s *PaymentService) completedEvent(ctx context.Context, payment *models.Payment) *events.PaymentCompletedEvent {
+	event := &events.PaymentCompletedEvent{
+		PaymentID:   payment.ID,
+		OrderID:     payment.OrderID,
+		Amount:      payment.Amount,
+		Currency:    payment.Currency,
+		Items:       []events.PaidItem{},
+		CompletedAt: time.Now(),
+	}
+	order, err := s.orders.GetOrder(ctx, payment.OrderID)
+	if err != nil {
+		log.Printf("Warning: failed to get order %s for payment.completed: %v", payment.OrderID, err)
+		return event
+	}
+	ordered := make([]models.RefundItem, 0, len(order.Items))
+	for _, item := range order.Items {
+		ordered = append(ordered, models.RefundItem{ProductID: item.ProductId, Quantity: item.Quantity})
+	}
+	for _, item := range stockItems(order, ordered) {
+		event.Items = append(event.Items, events.PaidItem{ProductID: item.ProductID, Quantity: item.Quantity})
+	}
+	return event
+}
+
 // GetPayment retrieves payment details
 func (s *PaymentService) GetPayment(ctx context.Context, paymentID string) (*models.Payment, error) {
-	return s.repo.GetPayment(ctx, paymentID)
+	return s.getPayment(ctx, paymentID)
 }
 
 // GetPaymentByOrder retrieves payment by order ID
 func (s *PaymentService) GetPaymentByOrder(ctx context.Context, orderID string) (*models.Payment, error) {
-	return s.repo.GetPaymentByOrder(ctx, orderID)
+	payment, err := s.repo.GetPaymentByOrder(ctx, orderID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, domainerr.NotFound("payment not found for order %s", orderID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get payment: %w", err)
+	}
+	return payment, nil
+}
+
+// getPayment loads a payment, reporting a missing one as not found
+func (s *PaymentService) getPayment(ctx context.Context, paymentID string) (*models.Payment, error) {
+	payment, err := s.repo.GetPayment(ctx, paymentID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, domainerr.NotFound("payment not found")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get payment: %w", err)
+	}
+	return payment, nil
 }
 
 // GetPaymentHistory retrieves user payment history
-func (s *PaymentService) GetPaymentHistory(ctx context.Context, userID string, limit, offset int) ([]*models.Payment, int, error) {
+func (s *PaymentService) GetPaymentHistory(ctx context.Context, userID string, limit, offset int) (*models.PaymentHistory, error) {
 	if limit <= 0 {
 		limit = 10
 	}
 	if offset < 0 {
 		offset = 0
 	}
-	return s.repo.GetPaymentHistory(ctx, userID, limit, offset)
+	payments, total, err := s.repo.GetPaymentHistory(ctx, userID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	meta := paging.ForOffset(int64(offset), int64(limit), int64(total))
+	return &models.PaymentHistory{
+		Payments:   payments,
+		Total:      total,
+		TotalPages: meta.TotalPages,
+		HasMore:    meta.HasMore,
+	}, nil
 }
 
-// SavePaymentMethod saves a payment method
-func (s *PaymentService) SavePaymentMethod(ctx context.Context, userID, methodType, gatewayMethodID string, isDefault bool) (*models.PaymentMethod, error) {
-	// TODO: Validate with payment gateway and get card details
-	method := &models.PaymentMethod{
-		UserID:          userID,
-		MethodType:      methodType,
-		GatewayMethodID: gatewayMethodID,
-		IsDefault:       isDefault,
-		Last4:           "4242", // Would come from gateway
-		Brand:           "VISA", // Would come from gateway
+// SavePaymentMethod saves a tokenized payment method. Clients tokenize card details with
+// the provider (e.g. Stripe.js) and send only the token plus the display metadata the
+// provider returned, so card numbers never reach this service. Saving a token the user
+// already has returns the existing method; a user's first method becomes the default.
+func (s *PaymentService) SavePaymentMethod(ctx context.Context, method *models.PaymentMethod) (*models.PaymentMethod, error) {
+	method.UserID = strings.TrimSpace(method.UserID)
+	method.GatewayMethodID = strings.TrimSpace(method.GatewayMethodID)
+	method.MethodType = strings.ToUpper(strings.TrimSpace(method.MethodType))
+	method.Brand = strings.ToUpper(strings.TrimSpace(method.Brand))
+	method.Last4 = strings.TrimSpace(method.Last4)
+
+	if method.UserID == "" || method.GatewayMethodID == "" {
+		return nil, domainerr.InvalidArgument("user_id and gateway_method_id are required")
+	}
+	if looksLikeCardNumber(method.GatewayMethodID) {
+		return nil, domainerr.InvalidArgument("gateway_method_id must be a provider token, not a card number")
 	}
 
-	err := s.repo.SavePaymentMethod(ctx, method)
+	switch method.MethodType {
+	case models.MethodTypeCard:
+		if err := validateCardMetadata(method, time.Now()); err != nil {
+			return nil, err
+		}
+	case models.MethodTypeBankAccount:
+		if method.Last4 != "" && !isDigits(method.Last4, 4) {
+			return nil, domainerr.InvalidArgument("last4 must be 4 digits")
+		}
+		method.ExpMonth, method.ExpYear = 0, 0
+	default:
+		return nil, domainerr.InvalidArgument("method_type must be %s or %s", models.MethodTypeCard, models.MethodTypeBankAccount)
+	}
+
+	existing, err := s.repo.GetPaymentMethodByGatewayID(ctx, method.UserID, method.GatewayMethodID)
+	if err == nil {
+		if method.IsDefault && !existing.IsDefault {
+			if err := s.repo.SetDefaultPaymentMethod(ctx, existing.UserID, existing.ID); err != nil {
+				return nil, fmt.Errorf("failed to set default payment method: %w", err)
+			}
+			existing.IsDefault = true
+		}
+		return existing, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("failed to check payment method: %w", err)
+	}
+
+	methods, err := s.repo.GetPaymentMethods(ctx, method.UserID)
 	if err != nil {
+		return nil, fmt.Errorf("failed to get payment methods: %w", err)
+	}
+	if len(methods) == 0 {
+		method.IsDefault = true
+	}
+
+	if err := s.repo.SavePaymentMethod(ctx, method); err != nil {
 		return nil, fmt.Errorf("failed to save payment method: %w", err)
 	}
 
@@ -172,9 +449,224 @@ func (s *PaymentService) GetPaymentMethods(ctx context.Context, userID string) (
 	return s.repo.GetPaymentMethods(ctx, userID)
 }
 
-// HandleWebhook handles payment gateway webhooks
+// DeletePaymentMethod removes a user's saved payment method. If it was the default,
+// the most recently added remaining method becomes the default.
+func (s *PaymentService) DeletePaymentMethod(ctx context.Context, userID, methodID string) error {
+	if userID == "" || methodID == "" {
+		return domainerr.InvalidArgument("user_id and payment_method_id are required")
+	}
+
+	method, err := userPaymentMethod(ctx, s.repo, userID, methodID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repo.DeletePaymentMethod(ctx, method.ID); err != nil {
+		return fmt.Errorf("failed to delete payment method: %w", err)
+	}
+
+	if method.IsDefault {
+		remaining, err := s.repo.GetPaymentMethods(ctx, userID)
+		if err != nil {
+			log.Printf("Warning: failed to promote default payment method for user %s: %v", userID, err)
+			return nil
+		}
+		if len(remaining) > 0 {
+			if err := s.repo.SetDefaultPaymentMethod(ctx, userID, remaining[0].ID); err != nil {
+				log.Printf("Warning: failed to promote default payment method for user %s: %v", userID, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// userPaymentMethod loads a saved payment method, treating other users' methods as missing
+func userPaymentMethod(ctx context.Context, repo repository.PaymentRepository, userID, methodID string) (*models.PaymentMethod, error) {
+	method, err := repo.GetPaymentMethod(ctx, methodID)
+	if errors.Is(err, gorm.ErrRecordNotFound) || (err == nil && method.UserID != userID) {
+		return nil, domainerr.NotFound("payment method not found")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get payment method: %w", err)
+	}
+	return method, nil
+}
+
+// validateCardMetadata checks the display metadata of a tokenized card
+func validateCardMetadata(method *models.PaymentMethod, now time.Time) error {
+	if !isDigits(method.Last4, 4) {
+		return domainerr.InvalidArgument("last4 must be 4 digits")
+	}
+	if method.Brand == "" {
+		return domainerr.InvalidArgument("brand is required for cards")
+	}
+	if method.ExpMonth < 1 || method.ExpMonth > 12 {
+		return domainerr.InvalidArgument("exp_month must be between 1 and 12")
+	}
+	if method.ExpYear < 2000 || method.ExpYear > 9999 {
+		return domainerr.InvalidArgument("exp_year must be a 4-digit year")
+	}
+	if cardExpired(method, now) {
+		return domainerr.InvalidArgument("card has expired")
+	}
+	return nil
+}
+
+// cardExpired reports whether a card is past its expiry; cards are valid through
+// the end of their expiry month
+func cardExpired(method *models.PaymentMethod, now time.Time) bool {
+	if method.MethodType != models.MethodTypeCard {
+		return false
+	}
+	return int(method.ExpYear) < now.Year() ||
+		(int(method.ExpYear) == now.Year() && time.Month(method.ExpMonth) < now.Month())
+}
+
+// looksLikeCardNumber reports whether value is a bare card number (PAN),
+// ignoring the spaces and dashes it is commonly written with
+func looksLikeCardNumber(value string) bool {
+	digits := strings.NewReplacer(" ", "", "-", "").Replace(value)
+	return len(digits) >= 12 && len(digits) <= 19 && isDigits(digits, len(digits))
+}
+
+// isDigits reports whether value is exactly n ASCII digits
+func isDigits(value string, n int) bool {
+	if len(value) != n {
+		return false
+	}
+	for _, r := range value {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// HandleWebhook applies a payment gateway webhook event whose signature the caller
+// has already verified
 func (s *PaymentService) HandleWebhook(ctx context.Context, gateway, eventType, eventData string) error {
-	// TODO: Implement webhook handling for Stripe/PayPal
-	// Parse event, verify signature, update payment status
+	switch strings.ToUpper(gateway) {
+	case models.PaymentMethodStripe:
+		event, err := webhook.ParseStripeEvent([]byte(eventData))
+		if err != nil {
+			return domainerr.InvalidArgument("%v", err)
+		}
+		if eventType != "" && eventType != event.Type {
+			return domainerr.InvalidArgument("event_type %s does not match event payload type %s", eventType, event.Type)
+		}
+		return s.HandleStripeEvent(ctx, event)
+	default:
+		return domainerr.InvalidArgument("unsupported payment gateway: %s", gateway)
+	}
+}
+
+// HandleStripeEvent applies a Stripe event to its payment. Stripe retries deliveries,
+// so an event ID is applied only once; event types we don't handle are acknowledged
+// and ignored.
+func (s *PaymentService) HandleStripeEvent(ctx context.Context, event *webhook.StripeEvent) error {
+	gateway := models.PaymentMethodStripe
+
+	processed, err := s.repo.IsWebhookEventProcessed(ctx, gateway, event.ID)
+	if err != nil {
+		return fmt.Errorf("failed to check webhook event %s: %w", event.ID, err)
+	}
+	if processed {
+		log.Printf("Stripe event %s already processed, skipping", event.ID)
+		return nil
+	}
+
+	switch event.Type {
+	case webhook.StripePaymentIntentSucceeded, webhook.StripePaymentIntentFailed:
+		if err := s.applyStripePaymentIntent(ctx, event); err != nil {
+			return err
+		}
+	default:
+		log.Printf("Ignoring Stripe event %s of type %s", event.ID, event.Type)
+		return nil
+	}
+
+	err = s.repo.MarkWebhookEventProcessed(ctx, &models.WebhookEvent{
+		Gateway:   gateway,
+		EventID:   event.ID,
+		EventType: event.Type,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to record webhook event %s: %w", event.ID, err)
+	}
 	return nil
 }
+
+// applyStripePaymentIntent moves the payment behind a payment intent to its final status
+func (s *PaymentService) applyStripePaymentIntent(ctx context.Context, event *webhook.StripeEvent) error {
+	intent, err := event.PaymentIntent()
+	if err != nil {
+		return domainerr.InvalidArgument("%v", err)
+	}
+
+	payment, err := s.paymentForIntent(ctx, intent)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		// Not one of ours (or already deleted); retrying will not change that
+		log.Printf("Warning: no payment for Stripe payment intent %s (event %s)", intent.ID, event.ID)
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get payment for intent %s: %w", intent.ID, err)
+	}
+
+	// A late or out-of-order event must not undo a settled payment
+	if payment.Status == models.PaymentStatusCompleted || payment.Status == models.PaymentStatusPartiallyRefunded ||
+		payment.Status == models.PaymentStatusRefunded {
+		log.Printf("Payment %s already %s, ignoring Stripe event %s", payment.ID, payment.Status, event.Type)
+		return nil
+	}
+
+	transaction := &models.Transaction{
+		PaymentID:       payment.ID,
+		TransactionType: models.TransactionTypeCharge,
+		Amount:          payment.Amount,
+		GatewayResponse: string(event.Data.Object),
+	}
+
+	if event.Type == webhook.StripePaymentIntentSucceeded {
+		if _, err := s.ConfirmPayment(ctx, payment.ID, intent.ID); err != nil {
+			return err
+		}
+		transaction.Status = models.PaymentStatusCompleted
+	} else {
+		payment.Status = models.PaymentStatusFailed
+		payment.FailureReason = "payment failed"
+		if intent.LastPaymentError != nil && intent.LastPaymentError.Message != "" {
+			payment.FailureReason = intent.LastPaymentError.Message
+		}
+		// The payment.failed event releases the order's reserved stock
+		failed, err := s.repo.FailPayment(ctx, payment, &events.PaymentFailedEvent{
+			PaymentID: payment.ID,
+			OrderID:   payment.OrderID,
+			Reason:    payment.FailureReason,
+			FailedAt:  time.Now(),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to update payment: %w", err)
+		}
+		if !failed {
+			log.Printf("Payment %s settled meanwhile, ignoring Stripe event %s", payment.ID, event.Type)
+			return nil
+		}
+		transaction.Status = models.PaymentStatusFailed
+	}
+
+	if err := s.repo.CreateTransaction(ctx, transaction); err != nil {
+		log.Printf("Warning: failed to log transaction for payment %s: %v", payment.ID, err)
+	}
+	return nil
+}
+
+// paymentForIntent finds the payment for a payment intent, preferring the payment_id
+// metadata set when the intent was created
+func (s *PaymentService) paymentForIntent(ctx context.Context, intent *webhook.StripePaymentIntent) (*models.Payment, error) {
+	if paymentID := intent.Metadata["payment_id"]; paymentID != "" {
+		return s.repo.GetPayment(ctx, paymentID)
+	}
+	return s.repo.GetPaymentByGatewayID(ctx, intent.ID)
+}
@@ -0,0 +1,148 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	orderpb "github.com/datngth03/ecommerce-go-app/proto/order_service"
+	"github.com/datngth03/ecommerce-go-app/services/payment-service/internal/events"
+	"github.com/datngth03/ecommerce-go-app/services/payment-service/internal/models"
+	"github.com/datngth03/ecommerce-go-app/services/payment-service/internal/repository"
+	"github.com/datngth03/ecommerce-go-app/shared/pkg/domainerr"
+)
+
+// refundRepo keeps one payment and its refunds in memory and applies RecordRefund
+// the way the PostgreSQL repository does
+type refundRepo struct {
+	repository.PaymentRepository
+	payment *models.Payment
+	refunds []*models.Refund
+	lines   []models.RefundLine
+	events  []*events.PaymentRefundedEvent
+}
+
+func (r *refundRepo) GetPayment(ctx context.Context, paymentID string) (*models.Payment, error) {
+	payment := *r.payment
+	return &payment, nil
+}
+
+func (r *refundRepo) GetRefundedQuantities(ctx context.Context, paymentID string) (map[string]int32, error) {
+	refunded := make(map[string]int32)
+	for _, line := range r.lines {
+		refunded[line.ProductID] += line.Quantity
+	}
+	return refunded, nil
+}
+
+func (r *refundRepo) RecordRefund(ctx context.Context, payment *models.Payment, refund *models.Refund, lines []models.RefundLine, ordered map[string]int32, event *events.PaymentRefundedEvent) error {
+	if roundCents(r.payment.RefundedAmount+refund.Amount) > r.payment.Amount {
+		return domainerr.Conflict("refund amount %.2f exceeds the refundable %.2f", refund.Amount, r.payment.Amount-r.payment.RefundedAmount)
+	}
+	refunded, _ := r.GetRefundedQuantities(ctx, payment.ID)
+	for _, line := range lines {
+		if refunded[line.ProductID]+line.Quantity > ordered[line.ProductID] {
+			return domainerr.Conflict("refund quantity for product %s exceeds ordered quantity", line.ProductID)
+		}
+		refunded[line.ProductID] += line.Quantity
+	}
+
+	r.payment.RefundedAmount = roundCents(r.payment.RefundedAmount + refund.Amount)
+	r.payment.Status = models.PaymentStatusPartiallyRefunded
+	if r.payment.RefundedAmount == r.payment.Amount {
+		r.payment.Status = models.PaymentStatusRefunded
+	}
+	r.refunds = append(r.refunds, refund)
+	r.lines = append(r.lines, lines...)
+	r.events = append(r.events, event)
+	payment.RefundedAmount, payment.Status = r.payment.RefundedAmount, r.payment.Status
+	return nil
+}
+
+type staticOrders struct {
+	order *orderpb.Order
+}
+
+func (o staticOrders) GetOrder(ctx context.Context, orderID string) (*orderpb.Order, error) {
+	return o.order, nil
+}
+
+func newRefundTest() (*PaymentService, *refundRepo) {
+	repo := &refundRepo{payment: &models.Payment{
+		ID:      "pay-1",
+		OrderID: "order-1",
+		Amount:  100,
+		Status:  models.PaymentStatusCompleted,
+	}}
+	orders := staticOrders{order: &orderpb.Order{Id: "order-1", Items: []*orderpb.OrderItem{
+		{ProductId: "p1", Quantity: 2},
+		{ProductId: "p2", Quantity: 1},
+	}}}
+	return NewPaymentService(repo, orders), repo
+}
+
+func TestRefundPaymentTracksRemainder(t *testing.T) {
+	svc, repo := newRefundTest()
+	ctx := context.Background()
+
+	if _, err := svc.RefundPayment(ctx, "pay-1", 30, "damaged", []models.RefundItem{{ProductID: "p1", Quantity: 1}}, false); err != nil {
+		t.Fatalf("first partial refund: %v", err)
+	}
+	if repo.payment.Status != models.PaymentStatusPartiallyRefunded || repo.payment.RefundedAmount != 30 {
+		t.Fatalf("after 30: status %s, refunded %v, want PARTIALLY_REFUNDED, 30", repo.payment.Status, repo.payment.RefundedAmount)
+	}
+
+	if _, err := svc.RefundPayment(ctx, "pay-1", 80, "", nil, false); !domainerr.Is(err, domainerr.KindInvalidArgument) {
+		t.Errorf("refund above the remainder: error = %v, want InvalidArgument", err)
+	}
+	if _, err := svc.RefundPayment(ctx, "pay-1", 10, "", []models.RefundItem{{ProductID: "p1", Quantity: 2}}, false); !domainerr.Is(err, domainerr.KindInvalidArgument) {
+		t.Errorf("item returned beyond its ordered quantity: error = %v, want InvalidArgument", err)
+	}
+
+	// Amount 0 refunds the rest and returns only what was not returned before
+	refund, err := svc.RefundPayment(ctx, "pay-1", 0, "cancelled", nil, false)
+	if err != nil {
+		t.Fatalf("final refund: %v", err)
+	}
+	if refund.Amount != 70 {
+		t.Errorf("final refund amount = %v, want 70", refund.Amount)
+	}
+	if repo.payment.Status != models.PaymentStatusRefunded || repo.payment.RefundedAmount != 100 {
+		t.Errorf("after the final refund: status %s, refunded %v, want REFUNDED, 100", repo.payment.Status, repo.payment.RefundedAmount)
+	}
+	want := map[string]int32{"p1": 1, "p2": 1}
+	got := make(map[string]int32)
+	for _, item := range repo.events[1].Items {
+		got[item.ProductID] += item.Quantity
+	}
+	if len(got) != len(want) || got["p1"] != want["p1"] || got["p2"] != want["p2"] {
+		t.Errorf("final refund returned %v, want %v", got, want)
+	}
+
+	if _, err := svc.RefundPayment(ctx, "pay-1", 1, "", nil, false); !domainerr.Is(err, domainerr.KindConflict) {
+		t.Errorf("refund of a refunded payment: error = %v, want Conflict", err)
+	}
+}
+
+func TestRefundPaymentMoneyOnly(t *testing.T) {
+	svc, repo := newRefundTest()
+	ctx := context.Background()
+
+	if _, err := svc.RefundPayment(ctx, "pay-1", 0, "restocked on receipt", nil, true); err != nil {
+		t.Fatalf("money-only refund: %v", err)
+	}
+	if len(repo.lines) != 0 || len(repo.events[0].Items) != 0 {
+		t.Errorf("money-only refund returned %d items, want none", len(repo.events[0].Items))
+	}
+	if _, err := svc.RefundPayment(ctx, "pay-1", 5, "", []models.RefundItem{{ProductID: "p1", Quantity: 1}}, true); !domainerr.Is(err, domainerr.KindInvalidArgument) {
+		t.Errorf("items with skip_restock: error = %v, want InvalidArgument", err)
+	}
+}
+
+func TestRoundCents(t *testing.T) {
+	if got := roundCents(19.99 - 9.99); got != 10 {
+		t.Errorf("roundCents(19.99 - 9.99) = %v, want 10", got)
+	}
+	if got := roundCents(0.1 + 0.2); got != 0.3 {
+		t.Errorf("roundCents(0.1 + 0.2) = %v, want 0.3", got)
+	}
+}
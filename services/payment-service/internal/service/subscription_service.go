@@ -0,0 +1,320 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/datngth03/ecommerce-go-app/services/payment-service/internal/config"
+	"github.com/datngth03/ecommerce-go-app/services/payment-service/internal/events"
+	"github.com/datngth03/ecommerce-go-app/services/payment-service/internal/models"
+	"github.com/datngth03/ecommerce-go-app/services/payment-service/internal/repository"
+	"github.com/datngth03/ecommerce-go-app/shared/pkg/domainerr"
+	"gorm.io/gorm"
+)
+
+// maxIntervalCount bounds how many intervals one billing period may span
+const maxIntervalCount = 12
+
+// SubscriptionPublisher publishes subscription lifecycle events for notifications
+type SubscriptionPublisher interface {
+	PublishSubscriptionEvent(ctx context.Context, eventType string, event *events.SubscriptionEvent) error
+}
+
+// SubscriptionService manages subscriptions and charges them when they are due
+type SubscriptionService struct {
+	repo      repository.PaymentRepository
+	publisher SubscriptionPublisher // Optional, nil disables subscription events
+	cfg       config.SubscriptionConfig
+}
+
+// NewSubscriptionService creates a new subscription service
+func NewSubscriptionService(repo repository.PaymentRepository, publisher SubscriptionPublisher, cfg config.SubscriptionConfig) *SubscriptionService {
+	return &SubscriptionService{
+		repo:      repo,
+		publisher: publisher,
+		cfg:       cfg,
+	}
+}
+
+// CreateSubscription starts a subscription on one of the user's saved payment methods.
+// The first charge is due at startAt (now when zero or in the past) and is made by the scheduler.
+func (s *SubscriptionService) CreateSubscription(ctx context.Context, subscription *models.Subscription, startAt time.Time) (*models.Subscription, error) {
+	subscription.UserID = strings.TrimSpace(subscription.UserID)
+	subscription.PlanID = strings.TrimSpace(subscription.PlanID)
+	subscription.Interval = strings.ToUpper(strings.TrimSpace(subscription.Interval))
+	subscription.Currency = strings.ToUpper(strings.TrimSpace(subscription.Currency))
+
+	if subscription.UserID == "" || subscription.PlanID == "" || subscription.PaymentMethodID == "" {
+		return nil, domainerr.InvalidArgument("user_id, plan_id and payment_method_id are required")
+	}
+	if subscription.Amount <= 0 {
+		return nil, domainerr.InvalidArgument("amount must be positive")
+	}
+	switch subscription.Interval {
+	case models.IntervalDay, models.IntervalWeek, models.IntervalMonth, models.IntervalYear:
+	default:
+		return nil, domainerr.InvalidArgument("interval must be one of %s, %s, %s, %s",
+			models.IntervalDay, models.IntervalWeek, models.IntervalMonth, models.IntervalYear)
+	}
+	if subscription.IntervalCount == 0 {
+		subscription.IntervalCount = 1
+	}
+	if subscription.IntervalCount < 0 || subscription.IntervalCount > maxIntervalCount {
+		return nil, domainerr.InvalidArgument("interval_count must be between 1 and %d", maxIntervalCount)
+	}
+	if subscription.Currency == "" {
+		subscription.Currency = "USD"
+	}
+	if len(subscription.Currency) != 3 {
+		return nil, domainerr.InvalidArgument("currency must be a 3-letter code")
+	}
+
+	method, err := userPaymentMethod(ctx, s.repo, subscription.UserID, subscription.PaymentMethodID)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	if cardExpired(method, now) {
+		return nil, domainerr.InvalidArgument("card has expired")
+	}
+
+	if startAt.Before(now) {
+		startAt = now
+	}
+	subscription.Status = models.SubscriptionStatusActive
+	subscription.CurrentPeriodEnd = startAt
+	subscription.NextChargeAt = startAt
+
+	if err := s.repo.CreateSubscription(ctx, subscription); err != nil {
+		return nil, fmt.Errorf("failed to create subscription: %w", err)
+	}
+	return subscription, nil
+}
+
+// CancelSubscription stops future charges. Cancelling an already cancelled subscription is a no-op.
+func (s *SubscriptionService) CancelSubscription(ctx context.Context, userID, subscriptionID, reason string) (*models.Subscription, error) {
+	if userID == "" || subscriptionID == "" {
+		return nil, domainerr.InvalidArgument("user_id and subscription_id are required")
+	}
+
+	subscription, err := s.repo.GetSubscription(ctx, subscriptionID)
+	if errors.Is(err, gorm.ErrRecordNotFound) || (err == nil && subscription.UserID != userID) {
+		return nil, domainerr.NotFound("subscription not found")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get subscription: %w", err)
+	}
+	if subscription.Status == models.SubscriptionStatusCancelled {
+		return subscription, nil
+	}
+
+	now := time.Now()
+	if err := s.repo.CancelSubscription(ctx, subscription.ID, now); err != nil {
+		return nil, fmt.Errorf("failed to cancel subscription: %w", err)
+	}
+	subscription.Status = models.SubscriptionStatusCancelled
+	subscription.CancelledAt = &now
+
+	s.publish(ctx, events.EventSubscriptionCancelled, subscription, "", reason)
+	return subscription, nil
+}
+
+// RunScheduler periodically charges subscriptions that are due
+func (s *SubscriptionService) RunScheduler(ctx context.Context) {
+	ticker := time.NewTicker(s.cfg.SchedulerInterval)
+	defer ticker.Stop()
+
+	for {
+		for {
+			due, err := s.repo.ClaimDueSubscriptions(ctx, time.Now(), s.cfg.ClaimLease, s.cfg.BatchSize)
+			if err != nil {
+				if ctx.Err() == nil {
+					log.Printf("Failed to get due subscriptions: %v", err)
+				}
+				break
+			}
+			for _, subscription := range due {
+				s.charge(ctx, subscription)
+			}
+			// A short batch means the backlog is drained
+			if len(due) < s.cfg.BatchSize {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// charge bills the current period of a claimed subscription and schedules the next attempt
+func (s *SubscriptionService) charge(ctx context.Context, subscription *models.Subscription) {
+	now := time.Now()
+
+	method, err := userPaymentMethod(ctx, s.repo, subscription.UserID, subscription.PaymentMethodID)
+	if err != nil && !domainerr.Is(err, domainerr.KindNotFound) {
+		// Not a charge failure; the claim lease runs out and the next run retries
+		log.Printf("Failed to load payment method for subscription %s: %v", subscription.ID, err)
+		return
+	}
+	if err == nil && cardExpired(method, now) {
+		err = fmt.Errorf("card has expired")
+	}
+	if err != nil {
+		var methodID *string
+		if method != nil {
+			methodID = &method.ID
+		}
+		s.chargeFailed(ctx, subscription, methodID, now, err.Error())
+		return
+	}
+
+	// TODO: Charge method.GatewayMethodID off-session with the payment gateway, using
+	// GatewayPaymentID as the idempotency key so a retried period is not charged twice
+	periodStart := subscription.CurrentPeriodEnd
+	payment := &models.Payment{
+		OrderID:          "subscription:" + subscription.ID,
+		UserID:           subscription.UserID,
+		Amount:           subscription.Amount,
+		Currency:         subscription.Currency,
+		Status:           models.PaymentStatusCompleted,
+		Method:           models.PaymentMethodStripe,
+		GatewayPaymentID: fmt.Sprintf("sim_sub_%s_%d", subscription.ID, periodStart.Unix()),
+		PaymentMethodID:  &method.ID,
+		SubscriptionID:   &subscription.ID,
+		BillingPeriod:    &periodStart,
+		Metadata:         chargeMetadata(subscription),
+	}
+	transaction := &models.Transaction{
+		TransactionType: models.TransactionTypeCharge,
+		Amount:          payment.Amount,
+		Status:          payment.Status,
+		GatewayResponse: `{"simulated": true}`,
+	}
+
+	next := addInterval(periodStart, subscription.Interval, subscription.IntervalCount)
+	if !next.After(now) {
+		// Long outage: bill from now instead of charging every missed period
+		next = addInterval(now, subscription.Interval, subscription.IntervalCount)
+	}
+	subscription.Status = models.SubscriptionStatusActive
+	subscription.CurrentPeriodEnd = next
+	subscription.NextChargeAt = next
+	subscription.FailedAttempts = 0
+	subscription.LastFailureReason = ""
+	subscription.LastChargedAt = &now
+
+	// Nothing is stored on failure; the claim lease runs out and the next run retries the period
+	created, err := s.repo.RecordSubscriptionCharge(ctx, payment, transaction, subscription)
+	if err != nil {
+		log.Printf("Failed to record charge for subscription %s: %v", subscription.ID, err)
+		return
+	}
+	if !created {
+		log.Printf("Period starting %s of subscription %s was already charged", periodStart.Format(time.RFC3339), subscription.ID)
+		return
+	}
+	s.publish(ctx, events.EventSubscriptionRenewed, subscription, payment.ID, "")
+}
+
+// chargeFailed records a failed charge and schedules a retry with exponential backoff;
+// after MaxFailedCharges failures in a row the subscription is marked PAST_DUE
+func (s *SubscriptionService) chargeFailed(ctx context.Context, subscription *models.Subscription, methodID *string, now time.Time, reason string) {
+	failed := &models.Payment{
+		OrderID:         "subscription:" + subscription.ID,
+		UserID:          subscription.UserID,
+		Amount:          subscription.Amount,
+		Currency:        subscription.Currency,
+		Status:          models.PaymentStatusFailed,
+		Method:          models.PaymentMethodStripe,
+		FailureReason:   reason,
+		PaymentMethodID: methodID,
+		SubscriptionID:  &subscription.ID,
+		Metadata:        chargeMetadata(subscription),
+	}
+	if err := s.repo.CreatePayment(ctx, failed); err != nil {
+		log.Printf("Failed to record failed charge for subscription %s: %v", subscription.ID, err)
+	}
+
+	subscription.FailedAttempts++
+	subscription.LastFailureReason = reason
+	subscription.NextChargeAt = now.Add(retryDelay(s.cfg.RetryBackoff, subscription.FailedAttempts))
+	if int(subscription.FailedAttempts) >= s.cfg.MaxFailedCharges {
+		subscription.Status = models.SubscriptionStatusPastDue
+	}
+
+	if err := s.repo.UpdateSubscriptionBilling(ctx, subscription); err != nil {
+		log.Printf("Failed to update subscription %s after failed charge: %v", subscription.ID, err)
+		return
+	}
+
+	log.Printf("Charge for subscription %s failed (attempt %d): %s", subscription.ID, subscription.FailedAttempts, reason)
+	if subscription.Status == models.SubscriptionStatusPastDue {
+		s.publish(ctx, events.EventSubscriptionPastDue, subscription, "", reason)
+	}
+}
+
+// publish emits a subscription event. State is already stored, so failures are only logged.
+func (s *SubscriptionService) publish(ctx context.Context, eventType string, subscription *models.Subscription, paymentID, reason string) {
+	if s.publisher == nil {
+		log.Printf("Warning: event publisher unavailable, %s not sent for subscription %s", eventType, subscription.ID)
+		return
+	}
+
+	event := &events.SubscriptionEvent{
+		SubscriptionID: subscription.ID,
+		UserID:         subscription.UserID,
+		PlanID:         subscription.PlanID,
+		Amount:         subscription.Amount,
+		Currency:       subscription.Currency,
+		Status:         subscription.Status,
+		PaymentID:      paymentID,
+		Reason:         reason,
+		OccurredAt:     time.Now(),
+	}
+	if subscription.Status == models.SubscriptionStatusActive {
+		event.NextChargeAt = &subscription.NextChargeAt
+	}
+
+	if err := s.publisher.PublishSubscriptionEvent(ctx, eventType, event); err != nil {
+		log.Printf("Warning: failed to publish %s for subscription %s: %v", eventType, subscription.ID, err)
+	}
+}
+
+// chargeMetadata is the payment metadata of a subscription charge
+func chargeMetadata(subscription *models.Subscription) string {
+	metadataJSON, _ := json.Marshal(map[string]string{
+		"plan_id":      subscription.PlanID,
+		"period_start": subscription.CurrentPeriodEnd.Format(time.RFC3339),
+	})
+	return string(metadataJSON)
+}
+
+// retryDelay is the wait before retrying after failedAttempts failed charges in a row:
+// backoff, doubling per failure and capped at 1024 times backoff
+func retryDelay(backoff time.Duration, failedAttempts int32) time.Duration {
+	return backoff << min(failedAttempts-1, 10)
+}
+
+// addInterval advances t by count billing intervals
+func addInterval(t time.Time, interval string, count int32) time.Time {
+	n := int(count)
+	switch interval {
+	case models.IntervalDay:
+		return t.AddDate(0, 0, n)
+	case models.IntervalWeek:
+		return t.AddDate(0, 0, 7*n)
+	case models.IntervalYear:
+		return t.AddDate(n, 0, 0)
+	default:
+		return t.AddDate(0, n, 0)
+	}
+}
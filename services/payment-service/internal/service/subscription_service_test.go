@@ -0,0 +1,114 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/datngth03/ecommerce-go-app/services/payment-service/internal/config"
+	"github.com/datngth03/ecommerce-go-app/services/payment-service/internal/models"
+	"github.com/datngth03/ecommerce-go-app/services/payment-service/internal/repository"
+)
+
+// billingRepo records the payments and subscription updates of chargeFailed
+type billingRepo struct {
+	repository.PaymentRepository
+	payments []*models.Payment
+	updates  []models.Subscription
+}
+
+func (r *billingRepo) CreatePayment(ctx context.Context, payment *models.Payment) error {
+	r.payments = append(r.payments, payment)
+	return nil
+}
+
+func (r *billingRepo) UpdateSubscriptionBilling(ctx context.Context, subscription *models.Subscription) error {
+	r.updates = append(r.updates, *subscription)
+	return nil
+}
+
+func TestAddInterval(t *testing.T) {
+	start := time.Date(2026, time.January, 15, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		interval string
+		count    int32
+		want     time.Time
+	}{
+		{"day", models.IntervalDay, 1, time.Date(2026, time.January, 16, 10, 0, 0, 0, time.UTC)},
+		{"days", models.IntervalDay, 3, time.Date(2026, time.January, 18, 10, 0, 0, 0, time.UTC)},
+		{"week", models.IntervalWeek, 1, time.Date(2026, time.January, 22, 10, 0, 0, 0, time.UTC)},
+		{"weeks", models.IntervalWeek, 2, time.Date(2026, time.January, 29, 10, 0, 0, 0, time.UTC)},
+		{"month", models.IntervalMonth, 1, time.Date(2026, time.February, 15, 10, 0, 0, 0, time.UTC)},
+		{"months across year", models.IntervalMonth, 12, time.Date(2027, time.January, 15, 10, 0, 0, 0, time.UTC)},
+		{"year", models.IntervalYear, 1, time.Date(2027, time.January, 15, 10, 0, 0, 0, time.UTC)},
+		{"unknown interval is monthly", "FORTNIGHT", 1, time.Date(2026, time.February, 15, 10, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := addInterval(start, tt.interval, tt.count); !got.Equal(tt.want) {
+				t.Errorf("addInterval(%s, %d) = %v, want %v", tt.interval, tt.count, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	tests := []struct {
+		failedAttempts int32
+		want           time.Duration
+	}{
+		{1, time.Hour},
+		{2, 2 * time.Hour},
+		{3, 4 * time.Hour},
+		{11, 1024 * time.Hour},
+		{20, 1024 * time.Hour},
+	}
+
+	for _, tt := range tests {
+		if got := retryDelay(time.Hour, tt.failedAttempts); got != tt.want {
+			t.Errorf("retryDelay(1h, %d) = %v, want %v", tt.failedAttempts, got, tt.want)
+		}
+	}
+}
+
+func TestChargeFailedMarksPastDue(t *testing.T) {
+	repo := &billingRepo{}
+	svc := NewSubscriptionService(repo, nil, config.SubscriptionConfig{
+		MaxFailedCharges: 3,
+		RetryBackoff:     time.Hour,
+	})
+	subscription := &models.Subscription{ID: "sub_1", UserID: "user_1", Status: models.SubscriptionStatusActive}
+	now := time.Date(2026, time.March, 1, 0, 0, 0, 0, time.UTC)
+
+	wantStatus := []string{
+		models.SubscriptionStatusActive,
+		models.SubscriptionStatusActive,
+		models.SubscriptionStatusPastDue,
+	}
+	for i, want := range wantStatus {
+		svc.chargeFailed(context.Background(), subscription, nil, now, "card declined")
+
+		update := repo.updates[i]
+		if update.FailedAttempts != int32(i+1) {
+			t.Errorf("attempt %d: failed_attempts = %d, want %d", i+1, update.FailedAttempts, i+1)
+		}
+		if update.Status != want {
+			t.Errorf("attempt %d: status = %s, want %s", i+1, update.Status, want)
+		}
+		if wantNext := now.Add(retryDelay(time.Hour, int32(i+1))); !update.NextChargeAt.Equal(wantNext) {
+			t.Errorf("attempt %d: next_charge_at = %v, want %v", i+1, update.NextChargeAt, wantNext)
+		}
+	}
+
+	if len(repo.payments) != len(wantStatus) {
+		t.Fatalf("recorded %d failed payments, want %d", len(repo.payments), len(wantStatus))
+	}
+	for _, payment := range repo.payments {
+		if payment.Status != models.PaymentStatusFailed || payment.BillingPeriod != nil {
+			t.Errorf("failed charge stored as status %s with billing period %v", payment.Status, payment.BillingPeriod)
+		}
+	}
+}
@@ -0,0 +1,127 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// StripeSignatureHeader is the header Stripe signs webhook deliveries with
+const StripeSignatureHeader = "Stripe-Signature"
+
+// DefaultTolerance is the maximum age of a signed Stripe delivery, as in Stripe's own libraries
+const DefaultTolerance = 5 * time.Minute
+
+// Stripe event types handled by the payment service
+const (
+	StripePaymentIntentSucceeded = "payment_intent.succeeded"
+	StripePaymentIntentFailed    = "payment_intent.payment_failed"
+)
+
+var (
+	ErrMissingSignature = errors.New("missing stripe signature")
+	ErrInvalidSignature = errors.New("invalid stripe signature")
+	ErrExpiredSignature = errors.New("stripe signature timestamp outside tolerance")
+)
+
+// StripeEvent is the envelope of a Stripe webhook delivery
+type StripeEvent struct {
+	ID   string `json:"id"`
+	Type string `json:"type"`
+	Data struct {
+		Object json.RawMessage `json:"object"`
+	} `json:"data"`
+}
+
+// StripePaymentIntent holds the payment intent fields the payment service uses
+type StripePaymentIntent struct {
+	ID               string            `json:"id"`
+	Status           string            `json:"status"`
+	Metadata         map[string]string `json:"metadata"`
+	LastPaymentError *struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	} `json:"last_payment_error"`
+}
+
+// VerifyStripeSignature checks a Stripe-Signature header ("t=<unix>,v1=<hex>,...")
+// against payload. The signed content is "<t>.<payload>" under HMAC-SHA256 with the
+// endpoint secret; any v1 entry may match, which covers secret rotation.
+func VerifyStripeSignature(payload []byte, header, secret string, tolerance time.Duration, now time.Time) error {
+	if header == "" {
+		return ErrMissingSignature
+	}
+
+	var timestamp string
+	var signatures []string
+	for _, part := range strings.Split(header, ",") {
+		key, value, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok {
+			continue
+		}
+		switch key {
+		case "t":
+			timestamp = value
+		case "v1":
+			signatures = append(signatures, value)
+		}
+	}
+	if timestamp == "" || len(signatures) == 0 {
+		return ErrMissingSignature
+	}
+
+	unix, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+	if tolerance > 0 && now.Sub(time.Unix(unix, 0)).Abs() > tolerance {
+		return ErrExpiredSignature
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	expected := mac.Sum(nil)
+
+	for _, signature := range signatures {
+		decoded, err := hex.DecodeString(signature)
+		if err != nil {
+			continue
+		}
+		if hmac.Equal(decoded, expected) {
+			return nil
+		}
+	}
+	return ErrInvalidSignature
+}
+
+// ParseStripeEvent decodes a Stripe webhook payload
+func ParseStripeEvent(payload []byte) (*StripeEvent, error) {
+	var event StripeEvent
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return nil, fmt.Errorf("invalid stripe event: %w", err)
+	}
+	if event.ID == "" || event.Type == "" {
+		return nil, fmt.Errorf("invalid stripe event: id and type are required")
+	}
+	return &event, nil
+}
+
+// PaymentIntent decodes the event's object as a payment intent
+func (e *StripeEvent) PaymentIntent() (*StripePaymentIntent, error) {
+	var intent StripePaymentIntent
+	if err := json.Unmarshal(e.Data.Object, &intent); err != nil {
+		return nil, fmt.Errorf("invalid payment intent in event %s: %w", e.ID, err)
+	}
+	if intent.ID == "" {
+		return nil, fmt.Errorf("payment intent id is required in event %s", e.ID)
+	}
+	return &intent, nil
+}
@@ -0,0 +1,49 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func sign(payload []byte, secret string, ts time.Time) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	fmt.Fprintf(mac, "%d.%s", ts.Unix(), payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestVerifyStripeSignature(t *testing.T) {
+	payload := []byte(`{"id":"evt_1","type":"payment_intent.succeeded"}`)
+	secret := "whsec_test"
+	now := time.Unix(1700000000, 0)
+	valid := fmt.Sprintf("t=%d,v1=%s", now.Unix(), sign(payload, secret, now))
+
+	tests := []struct {
+		name    string
+		payload []byte
+		header  string
+		now     time.Time
+		wantErr error
+	}{
+		{"valid", payload, valid, now, nil},
+		{"valid with rotated secret", payload, fmt.Sprintf("t=%d,v1=%s,v1=%s", now.Unix(), sign(payload, "old", now), sign(payload, secret, now)), now, nil},
+		{"missing header", payload, "", now, ErrMissingSignature},
+		{"no v1 entry", payload, fmt.Sprintf("t=%d,v0=abc", now.Unix()), now, ErrMissingSignature},
+		{"tampered payload", []byte(`{"id":"evt_2"}`), valid, now, ErrInvalidSignature},
+		{"wrong secret", payload, fmt.Sprintf("t=%d,v1=%s", now.Unix(), sign(payload, "other", now)), now, ErrInvalidSignature},
+		{"too old", payload, valid, now.Add(DefaultTolerance + time.Second), ErrExpiredSignature},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := VerifyStripeSignature(tt.payload, tt.header, secret, DefaultTolerance, tt.now)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("VerifyStripeSignature() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
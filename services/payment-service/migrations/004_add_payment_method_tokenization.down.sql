@@ -0,0 +1,4 @@
+ALTER TABLE payments DROP COLUMN IF EXISTS payment_method_id;
+DROP INDEX IF EXISTS uq_payment_methods_user_gateway_method;
+ALTER TABLE payment_methods DROP COLUMN IF EXISTS exp_year;
+ALTER TABLE payment_methods DROP COLUMN IF EXISTS exp_month;
@@ -0,0 +1,10 @@
+-- Saved payment methods keep only the provider token and display metadata, never card numbers
+ALTER TABLE payment_methods ADD COLUMN IF NOT EXISTS exp_month SMALLINT;
+ALTER TABLE payment_methods ADD COLUMN IF NOT EXISTS exp_year SMALLINT;
+
+-- A provider token is saved once per user
+CREATE UNIQUE INDEX IF NOT EXISTS uq_payment_methods_user_gateway_method ON payment_methods(user_id, gateway_method_id)
+WHERE deleted_at IS NULL;
+
+-- Saved method charged by a payment
+ALTER TABLE payments ADD COLUMN IF NOT EXISTS payment_method_id UUID REFERENCES payment_methods(id) ON DELETE SET NULL;
@@ -0,0 +1,33 @@
+-- Recurring charges against a saved payment method
+CREATE TABLE IF NOT EXISTS subscriptions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id VARCHAR(255) NOT NULL,
+    plan_id VARCHAR(255) NOT NULL,
+    amount DECIMAL(10,2) NOT NULL,
+    currency VARCHAR(3) NOT NULL DEFAULT 'USD',
+    billing_interval VARCHAR(10) NOT NULL,
+    interval_count INTEGER NOT NULL DEFAULT 1,
+    payment_method_id UUID NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    current_period_end TIMESTAMP WITH TIME ZONE NOT NULL,
+    next_charge_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    failed_attempts INTEGER NOT NULL DEFAULT 0,
+    last_failure_reason TEXT,
+    last_charged_at TIMESTAMP WITH TIME ZONE,
+    cancelled_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP WITH TIME ZONE,
+
+    CONSTRAINT subscription_amount_positive CHECK (amount > 0),
+    CONSTRAINT subscription_interval_count_positive CHECK (interval_count > 0)
+);
+
+CREATE INDEX IF NOT EXISTS idx_subscriptions_user_id ON subscriptions(user_id);
+
+-- The scheduler polls active subscriptions that are due
+CREATE INDEX IF NOT EXISTS idx_subscriptions_due ON subscriptions(next_charge_at)
+WHERE status = 'ACTIVE' AND deleted_at IS NULL;
+
+-- Charges created by a subscription renewal
+ALTER TABLE payments ADD COLUMN IF NOT EXISTS subscription_id UUID REFERENCES subscriptions(id) ON DELETE SET NULL;
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS uq_payments_subscription_period;
+ALTER TABLE payments DROP COLUMN IF EXISTS billing_period;
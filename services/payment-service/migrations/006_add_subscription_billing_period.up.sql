@@ -0,0 +1,9 @@
+-- Migration: 006_add_subscription_billing_period.up.sql
+-- Description: Charge each subscription billing period at most once
+
+-- Period billed by a successful renewal charge; NULL for failed attempts and non-subscription payments
+ALTER TABLE payments ADD COLUMN IF NOT EXISTS billing_period TIMESTAMP WITH TIME ZONE;
+
+-- A subscription period is charged once: a retried period conflicts instead of charging again
+CREATE UNIQUE INDEX IF NOT EXISTS uq_payments_subscription_period ON payments(subscription_id, billing_period)
+WHERE billing_period IS NOT NULL;
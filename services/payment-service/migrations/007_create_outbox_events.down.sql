@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_outbox_events_unsent;
+DROP TABLE IF EXISTS outbox_events;
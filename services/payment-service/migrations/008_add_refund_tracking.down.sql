@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS refund_items;
+
+UPDATE payments SET status = 'COMPLETED' WHERE status = 'PARTIALLY_REFUNDED';
+
+ALTER TABLE payments DROP CONSTRAINT IF EXISTS payment_refunded_amount_range;
+ALTER TABLE payments DROP COLUMN IF EXISTS refunded_amount;
@@ -0,0 +1,36 @@
+-- Migration: 008_add_refund_tracking.up.sql
+-- Description: Track what each payment has refunded so refunds cannot exceed the charge
+
+-- Sum of completed refunds; a refund is accepted only while amount - refunded_amount covers it
+ALTER TABLE payments ADD COLUMN IF NOT EXISTS refunded_amount DECIMAL(10,2) NOT NULL DEFAULT 0;
+
+UPDATE payments p SET refunded_amount = LEAST(p.amount, r.total)
+FROM (
+    SELECT payment_id, SUM(amount) AS total
+    FROM refunds
+    WHERE status = 'COMPLETED' AND deleted_at IS NULL
+    GROUP BY payment_id
+) r
+WHERE r.payment_id = p.id;
+
+UPDATE payments SET status = 'PARTIALLY_REFUNDED'
+WHERE status = 'COMPLETED' AND refunded_amount > 0;
+
+ALTER TABLE payments ADD CONSTRAINT payment_refunded_amount_range
+CHECK (refunded_amount >= 0 AND refunded_amount <= amount);
+
+-- Order items paid back by each refund. Refunds made before this migration have no rows,
+-- so their items stay refundable by quantity (the amount limit still applies).
+CREATE TABLE IF NOT EXISTS refund_items (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    refund_id UUID NOT NULL REFERENCES refunds(id) ON DELETE CASCADE,
+    payment_id UUID NOT NULL REFERENCES payments(id) ON DELETE CASCADE,
+    product_id VARCHAR(255) NOT NULL,
+    quantity INTEGER NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT refund_item_quantity_positive CHECK (quantity > 0)
+);
+
+CREATE INDEX IF NOT EXISTS idx_refund_items_refund_id ON refund_items(refund_id);
+CREATE INDEX IF NOT EXISTS idx_refund_items_payment_product ON refund_items(payment_id, product_id);
@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"log"
 	"net"
@@ -15,13 +16,21 @@ import (
 	"golang.org/x/time/rate"
 
 	pb "github.com/datngth03/ecommerce-go-app/proto/product_service"
+	"github.com/datngth03/ecommerce-go-app/services/product-service/internal/client"
 	"github.com/datngth03/ecommerce-go-app/services/product-service/internal/config"
+	"github.com/datngth03/ecommerce-go-app/services/product-service/internal/currency"
+	"github.com/datngth03/ecommerce-go-app/services/product-service/internal/events"
 	"github.com/datngth03/ecommerce-go-app/services/product-service/internal/metrics"
+	"github.com/datngth03/ecommerce-go-app/services/product-service/internal/models"
 	"github.com/datngth03/ecommerce-go-app/services/product-service/internal/repository"
 	"github.com/datngth03/ecommerce-go-app/services/product-service/internal/rpc"
 	"github.com/datngth03/ecommerce-go-app/services/product-service/internal/service"
 	sharedCache "github.com/datngth03/ecommerce-go-app/shared/pkg/cache"
+	"github.com/datngth03/ecommerce-go-app/shared/pkg/eventbus"
+	"github.com/datngth03/ecommerce-go-app/shared/pkg/grpcsize"
+	sharedLogger "github.com/datngth03/ecommerce-go-app/shared/pkg/logger"
 	sharedMiddleware "github.com/datngth03/ecommerce-go-app/shared/pkg/middleware"
+	"github.com/datngth03/ecommerce-go-app/shared/pkg/outbox"
 	sharedTLS "github.com/datngth03/ecommerce-go-app/shared/pkg/tlsutil"
 	sharedTracing "github.com/datngth03/ecommerce-go-app/shared/pkg/tracing"
 
@@ -39,6 +48,9 @@ func main() {
 	if err != nil {
 		log.Fatalf("Failed to load configuration: %v", err)
 	}
+	if err := sharedLogger.Init(cfg.Logging); err != nil {
+		log.Fatalf("Failed to initialize logger: %v", err)
+	}
 	log.Printf("Product Service v%s starting in %s mode...", cfg.Service.Version, cfg.Service.Environment)
 
 	// 2. Initialize Distributed Tracing
@@ -80,6 +92,28 @@ func main() {
 		}
 	}()
 
+	// 3.1. Connect to the read replica (optional). Catalog reads use it while it answers
+	// health pings; without it, or while it is down, they use the primary.
+	var replicaDB *sql.DB
+	if cfg.Database.ReadReplicaDSN != "" {
+		replicaDB, err = repository.ConnectPostgres(
+			cfg.Database.ReadReplicaDSN,
+			cfg.Database.MaxOpenConns,
+			cfg.Database.MaxIdleConns,
+		)
+		if err != nil {
+			log.Printf("Warning: Failed to connect to read replica: %v (reads will use the primary)", err)
+			replicaDB = nil
+		} else {
+			log.Println("✓ Read replica connection established")
+			defer replicaDB.Close()
+		}
+	}
+	readRouter := repository.NewReplicaRouter(db, replicaDB)
+	watchCtx, stopReplicaWatch := context.WithCancel(context.Background())
+	defer stopReplicaWatch()
+	go readRouter.Watch(watchCtx, repository.ReplicaCheckInterval)
+
 	// 3.5. Initialize Redis Cache
 	redisHost := os.Getenv("REDIS_HOST")
 	if redisHost == "" {
@@ -117,6 +151,7 @@ func main() {
 		Database:     db,
 		MaxOpenConns: cfg.Database.MaxOpenConns,
 		MaxIdleConns: cfg.Database.MaxIdleConns,
+		ReadRouter:   readRouter,
 	})
 	if err != nil {
 		log.Fatalf("Failed to initialize repositories: %v", err)
@@ -126,28 +161,131 @@ func main() {
 	if redisCache != nil {
 		repos.Product = repository.NewCachedProductRepository(repos.Product, redisCache)
 		repos.Category = repository.NewCachedCategoryRepository(repos.Category, redisCache)
+		repos.Interaction = repository.NewCachedInteractionRepository(repos.Interaction, redisCache)
 		log.Println("✓ Repositories initialized with caching")
 	} else {
 		log.Println("✓ Repositories initialized (without caching)")
 	}
 
+	// 4.5. Initialize Inventory Client (optional, used for availability enrichment)
+	var stockLookup service.StockLookup
+	if cfg.Services.InventoryService.Enabled {
+		inventoryClient, err := client.NewInventoryClient(cfg.Services.InventoryService, cfg.Server)
+		if err != nil {
+			log.Printf("Warning: Failed to create inventory client: %v (availability will be unknown)", err)
+		} else {
+			stockLookup = inventoryClient
+			defer inventoryClient.Close()
+			log.Println("✓ Inventory client initialized")
+		}
+	}
+
+	// 4.6. Initialize Currency Converter (optional, rates are fetched lazily)
+	var priceConverter *currency.Converter
+	if cfg.Currency.Enabled {
+		rateProvider := currency.NewCachedRateProvider(
+			currency.NewHTTPRateProvider(cfg.Currency.RatesURL, cfg.Currency.Timeout),
+			redisCache,
+		)
+		priceConverter, err = currency.NewConverter(rateProvider, cfg.Currency.BaseCurrency)
+		if err != nil {
+			log.Fatalf("Invalid currency configuration: %v", err)
+		}
+		log.Printf("✓ Currency conversion enabled (base: %s)", priceConverter.Base())
+	}
+
 	// 5. Initialize Services
-	productService := service.NewProductService(repos)
-	categoryService := service.NewCategoryService(repos)
+	slugPolicy := service.SlugPolicy{RegenerateOnRename: cfg.Catalog.RegenerateSlugOnRename}
+	imagePolicy := service.ImagePolicy{
+		MaxURLLength: cfg.Catalog.ImageURLMaxLength,
+		AllowedHosts: cfg.Catalog.ImageAllowedHosts,
+	}
+	productService := service.NewProductService(repos, stockLookup, slugPolicy, imagePolicy, priceConverter)
+	categoryService := service.NewCategoryService(repos, slugPolicy)
+
+	// Saved searches notify their users through the notification service, so the
+	// matcher only runs when a client to it could be created
+	var savedSearchNotifier service.SavedSearchNotifier
+	if cfg.SavedSearch.MatcherEnabled && cfg.Services.NotificationService.Enabled {
+		notificationClient, err := client.NewNotificationClient(cfg.Services.NotificationService, cfg.Server)
+		if err != nil {
+			log.Printf("Warning: Failed to create notification client: %v (saved searches will not be matched)", err)
+		} else {
+			savedSearchNotifier = notificationClient
+			defer notificationClient.Close()
+		}
+	}
+	savedSearchService := service.NewSavedSearchService(repos, savedSearchNotifier, service.SavedSearchConfig{
+		Interval:      cfg.SavedSearch.Interval,
+		BatchSize:     cfg.SavedSearch.BatchSize,
+		RatePerSecond: cfg.SavedSearch.RatePerSecond,
+	})
 	log.Println("✓ Services initialized")
 
+	// 5.2. Start the saved search matcher
+	matcherCtx, stopMatcher := context.WithCancel(context.Background())
+	defer stopMatcher()
+	if savedSearchNotifier != nil {
+		go savedSearchService.RunMatcher(matcherCtx)
+		log.Printf("✓ Saved search matcher started (every %v, %.1f searches/s)",
+			cfg.SavedSearch.Interval, cfg.SavedSearch.RatePerSecond)
+	}
+
+	// 5.1. Warm the product cache in the background so startup isn't delayed
+	warmupCtx, stopWarmup := context.WithTimeout(context.Background(), cfg.Warmup.Timeout)
+	defer stopWarmup()
+	if redisCache != nil && cfg.Warmup.Enabled {
+		go func() {
+			start := time.Now()
+			warmed := productService.WarmCache(warmupCtx, cfg.Warmup.ProductIDs, cfg.Warmup.Count)
+			log.Printf("✓ Product cache warmed: %d products in %v", warmed, time.Since(start).Round(time.Millisecond))
+		}()
+	}
+
+	// 5.5. Start Outbox Relay. Product events are stored in the outbox with each change
+	// and stay there until a relay publishes them, so running without one loses nothing.
+	// The publisher connects on first use and reconnects with backoff, so the relay also
+	// starts while the broker is down and catches up once it is back.
+	relayCtx, stopRelay := context.WithCancel(context.Background())
+	relayDone := make(chan struct{})
+	close(relayDone)
+	if cfg.RabbitMQ.Enabled && cfg.Outbox.RelayEnabled {
+		publisher, err := events.NewBrokerPublisher(cfg.Outbox.Broker, cfg.GetRabbitMQURL())
+		if err != nil {
+			log.Fatalf("Failed to create event publisher: %v", err)
+		}
+		defer publisher.Close()
+		// Events go out in the shared envelope, whichever broker carries them
+		relay := outbox.NewRelay(db, eventbus.NewOutboxPublisher(publisher, models.ProductEventVersion), outbox.RelayConfig{
+			PollInterval:     cfg.Outbox.PollInterval,
+			BatchSize:        cfg.Outbox.BatchSize,
+			Retention:        cfg.Outbox.Retention,
+			ObservePublished: metrics.RecordOutboxPublish,
+			ObserveLag:       metrics.UpdateOutboxRelayLag,
+		})
+		relayDone = make(chan struct{})
+		go func() {
+			defer close(relayDone)
+			relay.Run(relayCtx)
+		}()
+		log.Println("✓ Outbox relay started")
+	}
+
 	// 5. Initialize gRPC Server with Tracing Interceptor and TLS
 	var grpcServerOpts []grpc.ServerOption
 	grpcServerOpts = append(grpcServerOpts, grpc.UnaryInterceptor(sharedTracing.UnaryServerInterceptor()))
 
+	// Same message size limit as the other services and their clients
+	grpcServerOpts = append(grpcServerOpts, grpcsize.ServerOptions(cfg.Server.MaxMessageSize)...)
+
 	// Enable TLS if configured
 	if cfg.Server.TLS.Enabled {
-		tlsCreds, err := sharedTLS.ServerTLSConfig(cfg.Server.TLS.CertFile, cfg.Server.TLS.KeyFile)
+		tlsCreds, err := sharedTLS.ServerCredentials(cfg.Server.TLS)
 		if err != nil {
 			log.Fatalf("Failed to load TLS credentials: %v", err)
 		}
 		grpcServerOpts = append(grpcServerOpts, grpc.Creds(tlsCreds))
-		log.Printf("✓ TLS enabled for gRPC server (cert: %s)", cfg.Server.TLS.CertFile)
+		log.Printf("✓ TLS enabled for gRPC server (cert: %s, client certificates required: %v)", cfg.Server.TLS.CertFile, cfg.Server.TLS.ClientAuth)
 	} else {
 		log.Println("⚠️  TLS disabled - using insecure connection")
 	}
@@ -155,7 +293,7 @@ func main() {
 	grpcServer := grpc.NewServer(grpcServerOpts...)
 
 	// Register Product Service
-	productGRPCServer := rpc.NewProductGRPCServer(productService, categoryService)
+	productGRPCServer := rpc.NewProductGRPCServer(productService, categoryService, savedSearchService)
 	pb.RegisterProductServiceServer(grpcServer, productGRPCServer)
 
 	// Register Health Check Service
@@ -266,5 +404,10 @@ func main() {
 	grpcServer.GracefulStop()
 	log.Println(" gRPC server stopped")
 
+	// Stop the relay before the publisher and database are closed
+	stopRelay()
+	<-relayDone
+	stopMatcher()
+
 	log.Println(" Product Service shutdown completed")
 }
@@ -0,0 +1,98 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	pb "github.com/datngth03/ecommerce-go-app/proto/inventory_service"
+	sharedConfig "github.com/datngth03/ecommerce-go-app/shared/pkg/config"
+	"github.com/datngth03/ecommerce-go-app/shared/pkg/grpcretry"
+	"github.com/datngth03/ecommerce-go-app/shared/pkg/grpcsize"
+	sharedTLS "github.com/datngth03/ecommerce-go-app/shared/pkg/tlsutil"
+	sharedTracing "github.com/datngth03/ecommerce-go-app/shared/pkg/tracing"
+	"google.golang.org/grpc"
+)
+
+// InventoryClient looks up stock levels from inventory service
+type InventoryClient struct {
+	conn   *grpc.ClientConn
+	client pb.InventoryServiceClient
+	policy grpcretry.Policy // Timeout and retries of calls
+}
+
+// NewInventoryClient creates a new inventory service gRPC client.
+// The connection is established lazily so product-service can start
+// (and serve products without availability) while inventory is down.
+// With TLS enabled the inventory certificate is verified against the CA file.
+// Messages are limited to the server's MaxMessageSize, like every gRPC hop.
+func NewInventoryClient(endpoint sharedConfig.ServiceEndpoint, server sharedConfig.ServerConfig) (*InventoryClient, error) {
+	tlsCfg := server.TLS
+	addr := endpoint.GRPCAddr
+	if addr == "" {
+		return nil, fmt.Errorf("inventory service address is required")
+	}
+
+	creds, err := sharedTLS.ClientCredentials(tlsCfg, "inventory-service")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create inventory service credentials: %w", err)
+	}
+
+	opts := []grpc.DialOption{
+		grpc.WithUnaryInterceptor(sharedTracing.UnaryClientInterceptor()),
+		grpc.WithTransportCredentials(creds),
+		grpcsize.DialOption(server.MaxMessageSize),
+	}
+
+	conn, err := grpc.Dial(addr, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to inventory service at %s: %w", addr, err)
+	}
+
+	log.Printf("Inventory service client configured for %s (TLS: %v)", addr, tlsCfg.Enabled)
+
+	return &InventoryClient{
+		conn:   conn,
+		client: pb.NewInventoryServiceClient(conn),
+		policy: grpcretry.PolicyFor(endpoint),
+	}, nil
+}
+
+// maxStocksPerRequest is the most products inventory's GetStocks accepts per call
+const maxStocksPerRequest = 100
+
+// GetAvailableQuantities returns the available quantity for each product, in calls of
+// at most maxStocksPerRequest products. Products without a stock record in inventory
+// are omitted from the map.
+func (c *InventoryClient) GetAvailableQuantities(ctx context.Context, productIDs []string) (map[string]int32, error) {
+	quantities := make(map[string]int32, len(productIDs))
+
+	for start := 0; start < len(productIDs); start += maxStocksPerRequest {
+		end := start + maxStocksPerRequest
+		if end > len(productIDs) {
+			end = len(productIDs)
+		}
+		chunk := productIDs[start:end]
+
+		resp, err := grpcretry.CallIdempotent(ctx, c.policy, func(ctx context.Context) (*pb.GetStocksResponse, error) {
+			return c.client.GetStocks(ctx, &pb.GetStocksRequest{ProductIds: chunk})
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get stocks: %w", err)
+		}
+
+		for _, stock := range resp.Stocks {
+			quantities[stock.ProductId] = stock.Available
+		}
+	}
+
+	return quantities, nil
+}
+
+// Close closes the gRPC connection
+func (c *InventoryClient) Close() error {
+	if c.conn != nil {
+		return c.conn.Close()
+	}
+	return nil
+}
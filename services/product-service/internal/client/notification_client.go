@@ -0,0 +1,105 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	pb "github.com/datngth03/ecommerce-go-app/proto/notification_service"
+	"github.com/datngth03/ecommerce-go-app/services/product-service/internal/models"
+	sharedConfig "github.com/datngth03/ecommerce-go-app/shared/pkg/config"
+	"github.com/datngth03/ecommerce-go-app/shared/pkg/grpcretry"
+	"github.com/datngth03/ecommerce-go-app/shared/pkg/grpcsize"
+	sharedTLS "github.com/datngth03/ecommerce-go-app/shared/pkg/tlsutil"
+	sharedTracing "github.com/datngth03/ecommerce-go-app/shared/pkg/tracing"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// NotificationClient sends saved search notifications through the notification service
+type NotificationClient struct {
+	conn   *grpc.ClientConn
+	client pb.NotificationServiceClient
+	policy grpcretry.Policy // Timeout and retries of calls
+}
+
+// NewNotificationClient creates a new notification service gRPC client. Like the
+// inventory client it connects lazily, verifies the server certificate when TLS is
+// enabled and uses the server's MaxMessageSize.
+func NewNotificationClient(endpoint sharedConfig.ServiceEndpoint, server sharedConfig.ServerConfig) (*NotificationClient, error) {
+	tlsCfg := server.TLS
+	addr := endpoint.GRPCAddr
+	if addr == "" {
+		return nil, fmt.Errorf("notification service address is required")
+	}
+
+	creds, err := sharedTLS.ClientCredentials(tlsCfg, "notification-service")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create notification service credentials: %w", err)
+	}
+
+	opts := []grpc.DialOption{
+		grpc.WithUnaryInterceptor(sharedTracing.UnaryClientInterceptor()),
+		grpc.WithTransportCredentials(creds),
+		grpcsize.DialOption(server.MaxMessageSize),
+	}
+
+	conn, err := grpc.Dial(addr, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to notification service at %s: %w", addr, err)
+	}
+
+	log.Printf("Notification service client configured for %s (TLS: %v)", addr, tlsCfg.Enabled)
+
+	return &NotificationClient{
+		conn:   conn,
+		client: pb.NewNotificationServiceClient(conn),
+		policy: grpcretry.PolicyFor(endpoint),
+	}, nil
+}
+
+// NotifySavedSearchMatches sends a push notification to every device of the search's
+// user listing the new products, the newest of total matches. A user without registered devices is not an error:
+// there is nothing to deliver, now or on a retry.
+func (c *NotificationClient) NotifySavedSearchMatches(ctx context.Context, search models.SavedSearch, products []models.Product, total int) error {
+	ids := make([]string, len(products))
+	for i, product := range products {
+		ids[i] = product.ID
+	}
+
+	body := fmt.Sprintf("%s is now available", products[0].Name)
+	if total > 1 {
+		body = fmt.Sprintf("%s and %d more new products match your search", products[0].Name, total-1)
+	}
+
+	_, err := grpcretry.Call(ctx, c.policy, func(ctx context.Context) (*pb.SendPushNotificationResponse, error) {
+		return c.client.SendPushNotification(ctx, &pb.SendPushNotificationRequest{
+			UserId: strconv.FormatInt(search.UserID, 10),
+			Title:  fmt.Sprintf("New products for %q", search.Name),
+			Body:   body,
+			Data: map[string]string{
+				"type":            "saved_search_matches",
+				"saved_search_id": search.ID,
+				"product_ids":     strings.Join(ids, ","),
+			},
+		})
+	})
+	if status.Code(err) == codes.NotFound {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to send saved search notification: %w", err)
+	}
+	return nil
+}
+
+// Close closes the gRPC connection
+func (c *NotificationClient) Close() error {
+	if c.conn != nil {
+		return c.conn.Close()
+	}
+	return nil
+}
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	sharedConfig "github.com/datngth03/ecommerce-go-app/shared/pkg/config"
+	"github.com/datngth03/ecommerce-go-app/shared/pkg/eventbus"
 )
 
 // SecurityConfig holds security-related configuration
@@ -29,13 +30,63 @@ type CORSConfig struct {
 	Enabled        bool
 }
 
+// CatalogConfig holds catalog behaviour configuration
+type CatalogConfig struct {
+	// RegenerateSlugOnRename regenerates product/category slugs when renamed.
+	// Off by default so existing links keep working.
+	RegenerateSlugOnRename bool
+
+	ImageURLMaxLength int      // Longest accepted product image URL
+	ImageAllowedHosts []string // Hosts product images may be served from; empty allows any
+}
+
+// CurrencyConfig holds price conversion configuration
+type CurrencyConfig struct {
+	Enabled      bool
+	BaseCurrency string        // Currency product prices are stored in
+	RatesURL     string        // Exchange rate API, {base} is replaced by BaseCurrency; no default
+	Timeout      time.Duration // Timeout for calls to the rate provider
+}
+
+// OutboxConfig holds settings of the relay publishing product events from the outbox table
+type OutboxConfig struct {
+	RelayEnabled bool
+	Broker       string        // Broker the relay publishes to
+	PollInterval time.Duration // Wait between polls once the outbox is drained
+	BatchSize    int           // Events published per transaction
+	Retention    time.Duration // How long published events are kept
+}
+
+// CacheWarmupConfig holds settings of the product cache warm-up run at startup
+type CacheWarmupConfig struct {
+	Enabled    bool
+	Count      int           // Products to preload
+	ProductIDs []string      // Hot products preloaded first; the newest products fill the rest
+	Timeout    time.Duration // Bound on the whole warm-up
+}
+
+// SavedSearchConfig holds settings of the job notifying users of new products matching their saved searches
+type SavedSearchConfig struct {
+	MatcherEnabled bool
+	Interval       time.Duration // How often each saved search runs
+	BatchSize      int           // Searches claimed at a time
+	RatePerSecond  float64       // Searches run per second
+}
+
 // Config holds product service specific configuration
 type Config struct {
-	Service  sharedConfig.ServiceInfo
-	Server   sharedConfig.ServerConfig
-	Database sharedConfig.DatabaseConfig
-	Logging  sharedConfig.LoggingConfig
-	Security SecurityConfig
+	Service     sharedConfig.ServiceInfo
+	Server      sharedConfig.ServerConfig
+	Database    sharedConfig.DatabaseConfig
+	RabbitMQ    sharedConfig.RabbitMQConfig
+	Logging     sharedConfig.LoggingConfig
+	Security    SecurityConfig
+	Services    sharedConfig.ExternalServices
+	Catalog     CatalogConfig
+	Currency    CurrencyConfig
+	Outbox      OutboxConfig
+	Warmup      CacheWarmupConfig
+	SavedSearch SavedSearchConfig
 }
 
 // Load loads configuration from environment variables
@@ -46,10 +97,40 @@ func Load() (*Config, error) {
 			Version:     sharedConfig.GetEnv("SERVICE_VERSION", "1.0.0"),
 			Environment: sharedConfig.GetEnv("ENVIRONMENT", "development"),
 		},
-		Server:   sharedConfig.LoadServerConfig("product-service", "8002", "9002"),
-		Database: sharedConfig.LoadDatabaseConfig("product_db"),
-		Logging:  sharedConfig.LoadLoggingConfig(),
-		Security: LoadSecurityConfig(),
+		Server:      sharedConfig.LoadServerConfig("product-service", "8002", "9002"),
+		Database:    sharedConfig.LoadDatabaseConfig("product_db"),
+		RabbitMQ:    sharedConfig.LoadRabbitMQConfig(),
+		Logging:     sharedConfig.LoadLoggingConfig(),
+		Security:    LoadSecurityConfig(),
+		Services:    sharedConfig.LoadExternalServices(),
+		Catalog:     LoadCatalogConfig(),
+		Currency:    LoadCurrencyConfig(),
+		Outbox:      LoadOutboxConfig(),
+		Warmup:      LoadCacheWarmupConfig(),
+		SavedSearch: LoadSavedSearchConfig(),
+	}
+
+	v := sharedConfig.NewValidator(cfg.Service)
+	v.CheckServer(cfg.Server)
+	v.CheckDatabase(cfg.Database)
+	v.CheckRabbitMQ(cfg.RabbitMQ)
+	if broker, err := eventbus.ParseBroker(cfg.Outbox.Broker); err != nil {
+		v.Addf("EVENT_BROKER: %v", err)
+	} else {
+		cfg.Outbox.Broker = broker
+	}
+	v.Check(cfg.Outbox.PollInterval > 0, "OUTBOX_POLL_INTERVAL must be positive")
+	v.Check(cfg.Outbox.BatchSize > 0, "OUTBOX_BATCH_SIZE must be positive")
+	if cfg.Catalog.ImageURLMaxLength <= 0 || cfg.Catalog.ImageURLMaxLength > maxImageURLLength {
+		v.Addf("PRODUCT_IMAGE_URL_MAX_LENGTH must be between 1 and %d", maxImageURLLength)
+	}
+	v.Check(!cfg.Currency.Enabled || cfg.Currency.RatesURL != "", "EXCHANGE_RATES_URL is required when CURRENCY_CONVERSION_ENABLED is true")
+	v.Check(!cfg.Warmup.Enabled || cfg.Warmup.Count > 0, "CACHE_WARMUP_COUNT must be positive")
+	v.Check(cfg.SavedSearch.Interval > 0, "SAVED_SEARCH_INTERVAL_MINUTES must be positive")
+	v.Check(cfg.SavedSearch.BatchSize > 0, "SAVED_SEARCH_BATCH_SIZE must be positive")
+	v.Check(cfg.SavedSearch.RatePerSecond > 0, "SAVED_SEARCH_RATE must be positive")
+	if err := v.Err(); err != nil {
+		return nil, err
 	}
 
 	return cfg, nil
@@ -99,17 +180,108 @@ func LoadSecurityConfig() SecurityConfig {
 	}
 }
 
+// maxImageURLLength is the width of the products.image_url column
+const maxImageURLLength = 500
+
+// LoadCatalogConfig loads catalog behaviour configuration from environment
+func LoadCatalogConfig() CatalogConfig {
+	var hosts []string
+	for _, host := range strings.Split(sharedConfig.GetEnv("PRODUCT_IMAGE_ALLOWED_HOSTS", ""), ",") {
+		if host = strings.ToLower(strings.TrimSpace(host)); host != "" {
+			hosts = append(hosts, host)
+		}
+	}
+
+	return CatalogConfig{
+		RegenerateSlugOnRename: sharedConfig.GetEnvAsBool("SLUG_REGENERATE_ON_RENAME", false),
+		ImageURLMaxLength:      sharedConfig.GetEnvAsInt("PRODUCT_IMAGE_URL_MAX_LENGTH", maxImageURLLength),
+		ImageAllowedHosts:      hosts,
+	}
+}
+
+// LoadCurrencyConfig loads price conversion configuration from environment
+func LoadCurrencyConfig() CurrencyConfig {
+	timeout, err := time.ParseDuration(sharedConfig.GetEnv("EXCHANGE_RATES_TIMEOUT", "5s"))
+	if err != nil {
+		timeout = 5 * time.Second
+	}
+
+	return CurrencyConfig{
+		Enabled:      sharedConfig.GetEnvAsBool("CURRENCY_CONVERSION_ENABLED", false),
+		BaseCurrency: strings.ToUpper(sharedConfig.GetEnv("BASE_CURRENCY", "USD")),
+		RatesURL:     sharedConfig.GetEnv("EXCHANGE_RATES_URL", ""),
+		Timeout:      timeout,
+	}
+}
+
+// LoadOutboxConfig loads outbox relay configuration from environment
+func LoadOutboxConfig() OutboxConfig {
+	pollInterval, err := time.ParseDuration(sharedConfig.GetEnv("OUTBOX_POLL_INTERVAL", "1s"))
+	if err != nil {
+		pollInterval = time.Second
+	}
+
+	return OutboxConfig{
+		RelayEnabled: sharedConfig.GetEnvAsBool("OUTBOX_RELAY_ENABLED", true),
+		Broker:       sharedConfig.GetEnv("EVENT_BROKER", eventbus.BrokerRabbitMQ),
+		PollInterval: pollInterval,
+		BatchSize:    sharedConfig.GetEnvAsInt("OUTBOX_BATCH_SIZE", 100),
+		Retention:    sharedConfig.GetEnvAsDurationHours("OUTBOX_RETENTION_HOURS", 7*24*time.Hour),
+	}
+}
+
+// LoadCacheWarmupConfig loads cache warm-up configuration from environment
+func LoadCacheWarmupConfig() CacheWarmupConfig {
+	var ids []string
+	for _, id := range strings.Split(sharedConfig.GetEnv("CACHE_WARMUP_PRODUCT_IDS", ""), ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			ids = append(ids, id)
+		}
+	}
+
+	return CacheWarmupConfig{
+		Enabled:    sharedConfig.GetEnvAsBool("CACHE_WARMUP_ENABLED", true),
+		Count:      sharedConfig.GetEnvAsInt("CACHE_WARMUP_COUNT", 100),
+		ProductIDs: ids,
+		Timeout:    sharedConfig.GetEnvAsDuration("CACHE_WARMUP_TIMEOUT", time.Minute), // seconds
+	}
+}
+
+// LoadSavedSearchConfig loads saved search matcher configuration from environment
+func LoadSavedSearchConfig() SavedSearchConfig {
+	ratePerSecond, err := strconv.ParseFloat(sharedConfig.GetEnv("SAVED_SEARCH_RATE", "5"), 64)
+	if err != nil {
+		ratePerSecond = 5
+	}
+
+	return SavedSearchConfig{
+		MatcherEnabled: sharedConfig.GetEnvAsBool("SAVED_SEARCH_MATCHER_ENABLED", true),
+		Interval:       sharedConfig.GetEnvAsDurationMinutes("SAVED_SEARCH_INTERVAL_MINUTES", time.Hour),
+		BatchSize:      sharedConfig.GetEnvAsInt("SAVED_SEARCH_BATCH_SIZE", 100),
+		RatePerSecond:  ratePerSecond,
+	}
+}
+
 // GetDatabaseDSN returns PostgreSQL connection string
 func (c *Config) GetDatabaseDSN() string {
 	return c.Database.GetDSN()
 }
 
+// GetRabbitMQURL returns RabbitMQ connection URL
+func (c *Config) GetRabbitMQURL() string {
+	baseConfig := sharedConfig.Config{
+		RabbitMQ: c.RabbitMQ,
+	}
+	return baseConfig.GetRabbitMQURL()
+}
+
 // PrintConfig prints the configuration
 func (c *Config) PrintConfig() {
 	baseConfig := sharedConfig.Config{
 		Service:  c.Service,
 		Server:   c.Server,
 		Database: c.Database,
+		RabbitMQ: c.RabbitMQ,
 		Logging:  c.Logging,
 	}
 	baseConfig.PrintConfig()
@@ -0,0 +1,132 @@
+package currency
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/datngth03/ecommerce-go-app/shared/pkg/cache"
+)
+
+// Cache TTL constants for exchange rates
+const (
+	RatesCacheTTL     = 24 * time.Hour      // Rates are published daily
+	RatesRetryTTL     = 10 * time.Minute    // How long fallback rates are served before retrying the provider
+	LastKnownRatesTTL = 30 * 24 * time.Hour // Fallback when the provider is down
+)
+
+// CachedRateProvider wraps a RateProvider with Redis caching.
+// When the provider fails, the last known rates are served instead.
+type CachedRateProvider struct {
+	provider RateProvider
+	cache    *cache.RedisCache // Optional, nil keeps rates in memory only
+
+	mu        sync.RWMutex
+	lastKnown map[string]fetchedRates
+}
+
+// fetchedRates remembers until when rates may be served without refreshing
+type fetchedRates struct {
+	rates     *Rates
+	expiresAt time.Time
+}
+
+// NewCachedRateProvider creates a cached rate provider
+func NewCachedRateProvider(provider RateProvider, cache *cache.RedisCache) *CachedRateProvider {
+	return &CachedRateProvider{
+		provider:  provider,
+		cache:     cache,
+		lastKnown: make(map[string]fetchedRates),
+	}
+}
+
+// GetRates returns cached rates (memory, then Redis), refreshing them from the provider once a day
+func (p *CachedRateProvider) GetRates(ctx context.Context, base string) (*Rates, error) {
+	if rates := p.memoryRates(base, true); rates != nil {
+		return rates, nil
+	}
+
+	cacheKey := fmt.Sprintf("fx:rates:%s", base)
+	if p.cache != nil {
+		var rates Rates
+		err := p.cache.Get(ctx, cacheKey, &rates)
+		if err == nil {
+			return &rates, nil
+		}
+		if !cache.IsCacheMiss(err) {
+			log.Printf("Cache error for exchange rates %s: %v", base, err)
+		}
+	}
+
+	rates, err := p.provider.GetRates(ctx, base)
+	if err != nil {
+		last := p.lastKnownRates(ctx, base)
+		if last == nil {
+			return nil, fmt.Errorf("%w: %w", ErrRatesUnavailable, err)
+		}
+
+		log.Printf("Warning: exchange rate provider failed (%v), using rates from %s", err, last.UpdatedAt.Format(time.RFC3339))
+		// Serve the fallback for a while so every request does not wait on a failing provider
+		p.store(ctx, cacheKey, last, RatesRetryTTL)
+		return last, nil
+	}
+
+	p.store(ctx, cacheKey, rates, RatesCacheTTL)
+	if p.cache != nil {
+		if err := p.cache.Set(ctx, cacheKey+":last", rates, LastKnownRatesTTL); err != nil {
+			log.Printf("Warning: failed to cache last known exchange rates: %v", err)
+		}
+	}
+
+	return rates, nil
+}
+
+// store keeps rates in memory and in Redis for ttl
+func (p *CachedRateProvider) store(ctx context.Context, cacheKey string, rates *Rates, ttl time.Duration) {
+	p.mu.Lock()
+	p.lastKnown[rates.Base] = fetchedRates{rates: rates, expiresAt: time.Now().Add(ttl)}
+	p.mu.Unlock()
+
+	if p.cache != nil {
+		if err := p.cache.Set(ctx, cacheKey, rates, ttl); err != nil {
+			log.Printf("Warning: failed to cache exchange rates: %v", err)
+		}
+	}
+}
+
+// lastKnownRates looks up the most recent rates in memory, then in Redis
+func (p *CachedRateProvider) lastKnownRates(ctx context.Context, base string) *Rates {
+	if rates := p.memoryRates(base, false); rates != nil {
+		return rates
+	}
+
+	if p.cache == nil {
+		return nil
+	}
+
+	var rates Rates
+	if err := p.cache.Get(ctx, fmt.Sprintf("fx:rates:%s:last", base), &rates); err != nil {
+		if !cache.IsCacheMiss(err) {
+			log.Printf("Cache error for last known exchange rates %s: %v", base, err)
+		}
+		return nil
+	}
+	return &rates
+}
+
+// memoryRates returns in-memory rates for base; with freshOnly, expired rates are ignored
+func (p *CachedRateProvider) memoryRates(base string, freshOnly bool) *Rates {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	entry, ok := p.lastKnown[base]
+	if !ok {
+		return nil
+	}
+	if freshOnly && time.Now().After(entry.expiresAt) {
+		return nil
+	}
+	return entry.rates
+}
@@ -0,0 +1,118 @@
+package currency
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// RateProvider fetches exchange rates relative to a base currency
+type RateProvider interface {
+	GetRates(ctx context.Context, base string) (*Rates, error)
+}
+
+// Rates holds exchange rates for one base currency.
+// Rates[code] is how many units of code one unit of Base buys.
+type Rates struct {
+	Base      string             `json:"base"`
+	Rates     map[string]float64 `json:"rates"`
+	UpdatedAt time.Time          `json:"updated_at"`
+}
+
+// Errors returned by conversions; match them with errors.Is
+var (
+	ErrInvalidCurrency     = errors.New("invalid currency")           // Not an ISO 4217 code
+	ErrUnsupportedCurrency = errors.New("unsupported currency")       // No rate for the currency
+	ErrRatesUnavailable    = errors.New("exchange rates unavailable") // Rates could not be fetched, or conversion is disabled
+)
+
+var codePattern = regexp.MustCompile(`^[A-Z]{3}$`)
+
+// zeroDecimalCurrencies are currencies without minor units
+var zeroDecimalCurrencies = map[string]bool{
+	"VND": true,
+	"JPY": true,
+	"KRW": true,
+}
+
+// NormalizeCode upper-cases an ISO 4217 currency code and validates its format
+func NormalizeCode(code string) (string, error) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if !codePattern.MatchString(code) {
+		return "", fmt.Errorf("%w: '%s' is not an ISO 4217 code", ErrInvalidCurrency, code)
+	}
+	return code, nil
+}
+
+// Convert converts amount from one currency to another using rates.
+// Either currency may be the base of rates; other pairs are crossed via the base.
+// The result is rounded to the minor unit of the target currency.
+func Convert(amount float64, from, to string, rates *Rates) (float64, error) {
+	if from == to {
+		return amount, nil
+	}
+
+	fromRate, err := rates.rate(from)
+	if err != nil {
+		return 0, err
+	}
+	toRate, err := rates.rate(to)
+	if err != nil {
+		return 0, err
+	}
+
+	return Round(amount/fromRate*toRate, to), nil
+}
+
+// Round rounds amount to the minor unit of the given currency
+func Round(amount float64, code string) float64 {
+	if zeroDecimalCurrencies[code] {
+		return math.Round(amount)
+	}
+	return math.Round(amount*100) / 100
+}
+
+func (r *Rates) rate(code string) (float64, error) {
+	if code == r.Base {
+		return 1, nil
+	}
+	rate, ok := r.Rates[code]
+	if !ok || rate <= 0 {
+		return 0, fmt.Errorf("%w: %s", ErrUnsupportedCurrency, code)
+	}
+	return rate, nil
+}
+
+// Converter converts prices stored in the base currency into other currencies
+type Converter struct {
+	provider RateProvider
+	base     string
+}
+
+// NewConverter creates a converter for prices stored in base
+func NewConverter(provider RateProvider, base string) (*Converter, error) {
+	base, err := NormalizeCode(base)
+	if err != nil {
+		return nil, err
+	}
+	return &Converter{provider: provider, base: base}, nil
+}
+
+// Base returns the currency prices are stored in
+func (c *Converter) Base() string {
+	return c.base
+}
+
+// Rates returns the current rates for the base currency. Any provider failure
+// is reported as ErrRatesUnavailable.
+func (c *Converter) Rates(ctx context.Context) (*Rates, error) {
+	rates, err := c.provider.GetRates(ctx, c.base)
+	if err != nil && !errors.Is(err, ErrRatesUnavailable) {
+		return nil, fmt.Errorf("%w: %w", ErrRatesUnavailable, err)
+	}
+	return rates, err
+}
@@ -0,0 +1,191 @@
+package currency
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// fakeProvider returns fixed rates, or err, and counts its calls
+type fakeProvider struct {
+	rates *Rates
+	err   error
+	calls int
+}
+
+func (p *fakeProvider) GetRates(ctx context.Context, base string) (*Rates, error) {
+	p.calls++
+	if p.err != nil {
+		return nil, p.err
+	}
+	return p.rates, nil
+}
+
+func usdRates() *Rates {
+	return &Rates{
+		Base:      "USD",
+		Rates:     map[string]float64{"EUR": 0.9, "VND": 25400, "JPY": 150},
+		UpdatedAt: time.Date(2026, time.October, 1, 0, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestNormalizeCode(t *testing.T) {
+	tests := []struct {
+		code    string
+		want    string
+		wantErr bool
+	}{
+		{"usd", "USD", false},
+		{" vnd ", "VND", false},
+		{"EUR", "EUR", false},
+		{"US", "", true},
+		{"USDT", "", true},
+		{"U5D", "", true},
+		{"", "", true},
+	}
+
+	for _, tt := range tests {
+		got, err := NormalizeCode(tt.code)
+		if tt.wantErr {
+			if !errors.Is(err, ErrInvalidCurrency) {
+				t.Errorf("NormalizeCode(%q) error = %v, want ErrInvalidCurrency", tt.code, err)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("NormalizeCode(%q) = %q, %v, want %q", tt.code, got, err, tt.want)
+		}
+	}
+}
+
+func TestConvert(t *testing.T) {
+	rates := usdRates()
+
+	tests := []struct {
+		name     string
+		amount   float64
+		from, to string
+		want     float64
+	}{
+		{"same currency", 19.99, "USD", "USD", 19.99},
+		{"from base", 199.99, "USD", "EUR", 179.99},
+		{"to base", 90, "EUR", "USD", 100},
+		{"cross rate", 9, "EUR", "JPY", 1500},
+		{"zero decimal target", 199.99, "USD", "VND", 5079746},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Convert(tt.amount, tt.from, tt.to, rates)
+			if err != nil {
+				t.Fatalf("Convert: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Convert(%v %s -> %s) = %v, want %v", tt.amount, tt.from, tt.to, got, tt.want)
+			}
+		})
+	}
+
+	if _, err := Convert(10, "USD", "GBP", rates); !errors.Is(err, ErrUnsupportedCurrency) {
+		t.Errorf("Convert to a currency without a rate: error = %v, want ErrUnsupportedCurrency", err)
+	}
+}
+
+func TestCachedRateProvider(t *testing.T) {
+	ctx := context.Background()
+	provider := &fakeProvider{rates: usdRates()}
+	cached := NewCachedRateProvider(provider, nil)
+
+	for i := 0; i < 3; i++ {
+		rates, err := cached.GetRates(ctx, "USD")
+		if err != nil {
+			t.Fatalf("GetRates: %v", err)
+		}
+		if rates.Rates["EUR"] != 0.9 {
+			t.Fatalf("EUR rate = %v, want 0.9", rates.Rates["EUR"])
+		}
+	}
+	if provider.calls != 1 {
+		t.Errorf("provider called %d times, want 1", provider.calls)
+	}
+
+	// Once the rates expire a failing provider falls back to the last known rates
+	cached.lastKnown["USD"] = fetchedRates{rates: provider.rates, expiresAt: time.Now().Add(-time.Second)}
+	provider.err = errors.New("connection refused")
+	rates, err := cached.GetRates(ctx, "USD")
+	if err != nil {
+		t.Fatalf("GetRates with a failing provider: %v", err)
+	}
+	if rates.Rates["EUR"] != 0.9 {
+		t.Errorf("fallback EUR rate = %v, want 0.9", rates.Rates["EUR"])
+	}
+	if provider.calls != 2 {
+		t.Errorf("provider called %d times, want 2", provider.calls)
+	}
+
+	// The fallback is served for a while without calling the provider again
+	if _, err := cached.GetRates(ctx, "USD"); err != nil {
+		t.Fatalf("GetRates during the retry window: %v", err)
+	}
+	if provider.calls != 2 {
+		t.Errorf("provider called %d times during the retry window, want 2", provider.calls)
+	}
+
+	// Without any known rates the failure is reported as ErrRatesUnavailable
+	if _, err := cached.GetRates(ctx, "EUR"); !errors.Is(err, ErrRatesUnavailable) {
+		t.Errorf("GetRates without known rates: error = %v, want ErrRatesUnavailable", err)
+	}
+}
+
+func TestConverterRatesUnavailable(t *testing.T) {
+	converter, err := NewConverter(&fakeProvider{err: errors.New("timeout")}, "usd")
+	if err != nil {
+		t.Fatalf("NewConverter: %v", err)
+	}
+	if converter.Base() != "USD" {
+		t.Errorf("Base() = %s, want USD", converter.Base())
+	}
+	if _, err := converter.Rates(context.Background()); !errors.Is(err, ErrRatesUnavailable) {
+		t.Errorf("Rates error = %v, want ErrRatesUnavailable", err)
+	}
+
+	if _, err := NewConverter(&fakeProvider{}, "dollar"); !errors.Is(err, ErrInvalidCurrency) {
+		t.Errorf("NewConverter with an invalid base: error = %v, want ErrInvalidCurrency", err)
+	}
+}
+
+func TestHTTPRateProvider(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/latest/USD":
+			w.Write([]byte(`{"result":"success","base_code":"USD","time_last_update_unix":1790812800,"rates":{"USD":1,"EUR":0.9}}`))
+		case "/latest/XXX":
+			w.Write([]byte(`{"result":"error","error-type":"unsupported-code"}`))
+		default:
+			http.Error(w, "boom", http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	provider := NewHTTPRateProvider(server.URL+"/latest/{base}", time.Second)
+
+	rates, err := provider.GetRates(context.Background(), "USD")
+	if err != nil {
+		t.Fatalf("GetRates: %v", err)
+	}
+	if rates.Base != "USD" || rates.Rates["EUR"] != 0.9 {
+		t.Errorf("rates = %+v, want base USD with EUR 0.9", rates)
+	}
+	if want := time.Unix(1790812800, 0).UTC(); !rates.UpdatedAt.Equal(want) {
+		t.Errorf("updated_at = %v, want %v", rates.UpdatedAt, want)
+	}
+
+	for _, base := range []string{"XXX", "GBP"} {
+		if _, err := provider.GetRates(context.Background(), base); err == nil {
+			t.Errorf("GetRates(%s) succeeded, want an error", base)
+		}
+	}
+}
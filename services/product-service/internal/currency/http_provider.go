@@ -0,0 +1,72 @@
+package currency
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// HTTPRateProvider fetches daily rates from an open.er-api.com compatible endpoint
+type HTTPRateProvider struct {
+	url    string
+	client *http.Client
+}
+
+// NewHTTPRateProvider creates a provider for urlTemplate, in which {base} is replaced
+// by the base currency (e.g. https://rates.example.com/latest/{base})
+func NewHTTPRateProvider(urlTemplate string, timeout time.Duration) *HTTPRateProvider {
+	return &HTTPRateProvider{
+		url:    urlTemplate,
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+// ratesResponse is the payload returned by the rates API
+type ratesResponse struct {
+	Result             string             `json:"result"`
+	ErrorType          string             `json:"error-type"`
+	BaseCode           string             `json:"base_code"`
+	TimeLastUpdateUnix int64              `json:"time_last_update_unix"`
+	Rates              map[string]float64 `json:"rates"`
+}
+
+// GetRates fetches the latest rates for base
+func (p *HTTPRateProvider) GetRates(ctx context.Context, base string) (*Rates, error) {
+	url := strings.ReplaceAll(p.url, "{base}", base)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build exchange rate request: %w", err)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch exchange rates: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("exchange rate provider returned status %d", resp.StatusCode)
+	}
+
+	var payload ratesResponse
+	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+		return nil, fmt.Errorf("failed to decode exchange rates: %w", err)
+	}
+
+	if payload.Result != "success" {
+		return nil, fmt.Errorf("exchange rate provider error: %s", payload.ErrorType)
+	}
+	if len(payload.Rates) == 0 {
+		return nil, fmt.Errorf("exchange rate provider returned no rates for %s", base)
+	}
+
+	return &Rates{
+		Base:      base,
+		Rates:     payload.Rates,
+		UpdatedAt: time.Unix(payload.TimeLastUpdateUnix, 0).UTC(),
+	}, nil
+}
@@ -0,0 +1,203 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/datngth03/ecommerce-go-app/shared/pkg/eventbus"
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// ProductExchange receives product events, routed by event type (e.g. "product.updated")
+const ProductExchange = "products"
+
+// Reconnect backoff after the broker could not be reached
+const (
+	minDialBackoff = time.Second
+	maxDialBackoff = time.Minute
+)
+
+// Publisher publishes event envelopes to RabbitMQ. It connects on first use and
+// reconnects after the connection or channel is closed, waiting longer after each
+// failed dial. Publisher confirms are enabled, so Publish returns only once the
+// broker has taken responsibility for the message.
+type Publisher struct {
+	url string
+
+	mu       sync.Mutex
+	conn     *amqp.Connection
+	channel  *amqp.Channel
+	closed   chan *amqp.Error // Notified when the channel (or its connection) closes
+	backoff  time.Duration    // Wait after the last failed dial
+	nextDial time.Time        // No dial is attempted before this
+}
+
+var _ eventbus.Publisher = (*Publisher)(nil)
+
+// NewBrokerPublisher creates a publisher for the broker chosen by EVENT_BROKER
+func NewBrokerPublisher(broker, rabbitmqURL string) (eventbus.Publisher, error) {
+	switch broker {
+	case eventbus.BrokerRabbitMQ:
+		return NewPublisher(rabbitmqURL), nil
+	default:
+		return nil, fmt.Errorf("unsupported event broker %q", broker)
+	}
+}
+
+// NewPublisher creates a publisher for rabbitmqURL. It does not connect until the
+// first event is published, so the service starts while the broker is down.
+func NewPublisher(rabbitmqURL string) *Publisher {
+	return &Publisher{url: rabbitmqURL}
+}
+
+// Publish sends an envelope, routed by its type, and waits for the broker to confirm
+// it. The envelope ID is also the message ID, so consumers can drop redelivered duplicates.
+func (p *Publisher) Publish(ctx context.Context, env eventbus.Envelope) error {
+	body, err := json.Marshal(env)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s event: %w", env.Type, err)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if err := p.connect(); err != nil {
+		return err
+	}
+
+	confirmation, err := p.channel.PublishWithDeferredConfirmWithContext(
+		ctx,
+		ProductExchange,
+		env.Type,
+		false, // mandatory
+		false, // immediate
+		amqp.Publishing{
+			ContentType:  "application/json",
+			Body:         body,
+			DeliveryMode: amqp.Persistent,
+			MessageId:    env.ID,
+			Timestamp:    env.Timestamp,
+			Type:         env.Type,
+		},
+	)
+	if err != nil {
+		p.disconnect()
+		return fmt.Errorf("failed to publish event: %w", err)
+	}
+
+	acked, err := confirmation.WaitContext(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to confirm event: %w", err)
+	}
+	if !acked {
+		return fmt.Errorf("event %s was rejected by the broker", env.ID)
+	}
+
+	log.Printf("Published event: %s (id: %s, key: %s)", env.Type, env.ID, env.Key)
+	return nil
+}
+
+// connect opens a connection and a confirming channel unless one is open already.
+// After a failed dial it returns an error without dialing until the backoff has passed.
+func (p *Publisher) connect() error {
+	if p.channel != nil {
+		select {
+		case err := <-p.closed:
+			log.Printf("RabbitMQ channel closed (%v), reconnecting", err)
+			p.disconnect()
+		default:
+			return nil
+		}
+	}
+
+	if wait := time.Until(p.nextDial); wait > 0 {
+		return fmt.Errorf("RabbitMQ unavailable, next connection attempt in %v", wait.Round(time.Second))
+	}
+
+	if err := p.dial(); err != nil {
+		p.backoff = min(max(2*p.backoff, minDialBackoff), maxDialBackoff)
+		p.nextDial = time.Now().Add(p.backoff)
+		return err
+	}
+
+	p.backoff = 0
+	p.nextDial = time.Time{}
+	log.Println("Connected to RabbitMQ for product events")
+	return nil
+}
+
+// dial connects, declares the products exchange and enables publisher confirms
+func (p *Publisher) dial() error {
+	conn, err := amqp.Dial(p.url)
+	if err != nil {
+		return fmt.Errorf("failed to connect to RabbitMQ: %w", err)
+	}
+
+	channel, err := conn.Channel()
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("failed to open channel: %w", err)
+	}
+
+	err = channel.ExchangeDeclare(
+		ProductExchange,
+		"topic",
+		true,  // durable
+		false, // auto-deleted
+		false, // internal
+		false, // no-wait
+		nil,   // arguments
+	)
+	if err != nil {
+		channel.Close()
+		conn.Close()
+		return fmt.Errorf("failed to declare exchange: %w", err)
+	}
+
+	if err := channel.Confirm(false); err != nil {
+		channel.Close()
+		conn.Close()
+		return fmt.Errorf("failed to enable publisher confirms: %w", err)
+	}
+
+	p.conn = conn
+	p.channel = channel
+	// A closed connection closes its channels, so one notification covers both
+	p.closed = channel.NotifyClose(make(chan *amqp.Error, 1))
+	return nil
+}
+
+// disconnect drops the current connection; the next publish dials again
+func (p *Publisher) disconnect() {
+	if p.channel != nil {
+		p.channel.Close()
+	}
+	if p.conn != nil {
+		p.conn.Close()
+	}
+	p.conn = nil
+	p.channel = nil
+	p.closed = nil
+}
+
+// Close closes the channel and connection
+func (p *Publisher) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.channel != nil {
+		p.channel.Close()
+	}
+	var err error
+	if p.conn != nil {
+		err = p.conn.Close()
+	}
+	p.conn = nil
+	p.channel = nil
+	p.closed = nil
+	return err
+}
@@ -115,7 +115,10 @@ func (h *CategoryHandler) CreateCategory(c *gin.Context) {
 
 	category, err := h.service.CreateCategory(c.Request.Context(), &req)
 	if err != nil {
-		if strings.Contains(err.Error(), "already exists") {
+		if respondAlreadyExists(c, err) {
+			return
+		}
+		if strings.Contains(err.Error(), "already exists") || strings.Contains(err.Error(), "invalid slug") {
 			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 			return
 		}
@@ -175,6 +178,10 @@ func (h *CategoryHandler) GetCategoryBySlug(c *gin.Context) {
 			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
 			return
 		}
+		if strings.Contains(err.Error(), "required") {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get category by slug: " + err.Error()})
 		return
 	}
@@ -210,7 +217,7 @@ func (h *CategoryHandler) UpdateCategory(c *gin.Context) {
 			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
 			return
 		}
-		if strings.Contains(err.Error(), "already exists") {
+		if strings.Contains(err.Error(), "already exists") || strings.Contains(err.Error(), "invalid slug") {
 			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 			return
 		}
@@ -1,11 +1,13 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 	"strings"
 
 	"github.com/datngth03/ecommerce-go-app/services/product-service/internal/client"
+	"github.com/datngth03/ecommerce-go-app/services/product-service/internal/currency"
 	"github.com/datngth03/ecommerce-go-app/services/product-service/internal/models"
 	"github.com/datngth03/ecommerce-go-app/services/product-service/internal/service"
 	"github.com/datngth03/ecommerce-go-app/shared/pkg/validator"
@@ -161,7 +163,12 @@ func (h *ProductHandler) CreateProduct(c *gin.Context) {
 
 	product, err := h.service.CreateProduct(c.Request.Context(), &req)
 	if err != nil {
-		if strings.Contains(err.Error(), "not found") || strings.Contains(err.Error(), "already exists") {
+		if respondAlreadyExists(c, err) {
+			return
+		}
+		if strings.Contains(err.Error(), "not found") || strings.Contains(err.Error(), "already exists") ||
+			strings.Contains(err.Error(), "invalid slug") || strings.Contains(err.Error(), "invalid bundle") ||
+			strings.Contains(err.Error(), "invalid variants") || strings.Contains(err.Error(), "invalid image URL") {
 			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 			return
 		}
@@ -181,21 +188,31 @@ func (h *ProductHandler) CreateProduct(c *gin.Context) {
 // @Description  Get a product's details by its ID
 // @Tags         Products
 // @Produce      json
-// @Param        id   path      string  true  "Product ID"
-// @Success      200  {object}  models.ProductResponse
-// @Failure      400  {object}  map[string]string
-// @Failure      404  {object}  map[string]string
-// @Failure      500  {object}  map[string]string
+// @Param        id                   path      string  true   "Product ID"
+// @Param        includeAvailability  query     bool    false  "Include stock status from inventory"
+// @Param        currency             query     string  false  "ISO 4217 code to convert the price into (e.g. VND)"
+// @Success      200                  {object}  models.ProductResponse
+// @Failure      400                  {object}  map[string]string
+// @Failure      404                  {object}  map[string]string
+// @Failure      500                  {object}  map[string]string
 // @Router       /products/{id} [get]
 func (h *ProductHandler) GetProduct(c *gin.Context) {
 	id := c.Param("id")
+	includeAvailability, _ := strconv.ParseBool(c.Query("includeAvailability"))
 
-	product, err := h.service.GetProduct(c.Request.Context(), id)
+	product, err := h.service.GetProduct(c.Request.Context(), id, models.GetProductOptions{
+		IncludeAvailability: includeAvailability,
+		Currency:            c.Query("currency"),
+	})
 	if err != nil {
 		if strings.Contains(err.Error(), "not found") {
 			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
 			return
 		}
+		if code, ok := currencyErrorHTTPStatus(err); ok {
+			c.JSON(code, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get product: " + err.Error()})
 		return
 	}
@@ -222,6 +239,10 @@ func (h *ProductHandler) GetProductBySlug(c *gin.Context) {
 			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
 			return
 		}
+		if strings.Contains(err.Error(), "required") {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get product: " + err.Error()})
 		return
 	}
@@ -259,11 +280,16 @@ func (h *ProductHandler) UpdateProduct(c *gin.Context) {
 
 	product, err := h.service.UpdateProduct(c.Request.Context(), id, &req)
 	if err != nil {
+		if strings.Contains(err.Error(), "invalid bundle") || strings.Contains(err.Error(), "invalid variants") ||
+			strings.Contains(err.Error(), "invalid image URL") {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		if strings.Contains(err.Error(), "not found") {
 			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
 			return
 		}
-		if strings.Contains(err.Error(), "already exists") {
+		if strings.Contains(err.Error(), "already exists") || strings.Contains(err.Error(), "invalid slug") {
 			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 			return
 		}
@@ -285,6 +311,7 @@ func (h *ProductHandler) UpdateProduct(c *gin.Context) {
 // @Param        id   path      string  true  "Product ID"
 // @Success      204  "No Content"
 // @Failure      404  {object}  map[string]string
+// @Failure      409  {object}  map[string]string
 // @Failure      500  {object}  map[string]string
 // @Router       /products/{id} [delete]
 func (h *ProductHandler) DeleteProduct(c *gin.Context) {
@@ -296,6 +323,10 @@ func (h *ProductHandler) DeleteProduct(c *gin.Context) {
 			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
 			return
 		}
+		if strings.Contains(err.Error(), "component of a bundle") {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete product: " + err.Error()})
 		return
 	}
@@ -311,6 +342,14 @@ func (h *ProductHandler) DeleteProduct(c *gin.Context) {
 // @Param        page        query     int     false  "Page number"
 // @Param        pageSize    query     int     false  "Number of items per page"
 // @Param        categoryId  query     string  false  "Filter by Category ID"
+// @Param        brandId     query     string  false  "Filter by Brand ID"
+// @Param        tagIds      query     string  false  "Comma-separated Tag IDs (products must have all of them)"
+// @Param        includeAvailability  query  bool  false  "Include stock status from inventory"
+// @Param        currency    query     string  false  "ISO 4217 code to convert prices into (e.g. VND)"
+// @Param        sort        query     string  false  "Sort order: newest (default), price_asc, price_desc, name"
+// @Param        minPrice    query     number  false  "Minimum price, inclusive, in the base currency"
+// @Param        maxPrice    query     number  false  "Maximum price, inclusive, in the base currency"
+// @Param        inStockOnly query     bool    false  "Only products with stock to sell"
 // @Success      200         {object}  models.ListProductsResponse
 // @Failure      400         {object}  map[string]string
 // @Failure      500         {object}  map[string]string
@@ -320,13 +359,28 @@ func (h *ProductHandler) ListProducts(c *gin.Context) {
 	req.Page, _ = strconv.Atoi(c.DefaultQuery("page", "1"))
 	req.PageSize, _ = strconv.Atoi(c.DefaultQuery("pageSize", "10"))
 	req.CategoryID = c.Query("categoryId")
+	req.BrandID = c.Query("brandId")
+	if tagIDs := c.Query("tagIds"); tagIDs != "" {
+		req.TagIDs = strings.Split(tagIDs, ",")
+	}
+	req.IncludeAvailability, _ = strconv.ParseBool(c.Query("includeAvailability"))
+	req.Currency = c.Query("currency")
+	req.Sort = c.Query("sort")
+	req.MinPrice, _ = strconv.ParseFloat(c.Query("minPrice"), 64)
+	req.MaxPrice, _ = strconv.ParseFloat(c.Query("maxPrice"), 64)
+	req.InStockOnly, _ = strconv.ParseBool(c.Query("inStockOnly"))
 
 	response, err := h.service.ListProducts(c.Request.Context(), &req)
 	if err != nil {
-		if strings.Contains(err.Error(), "category not found") {
+		if strings.Contains(err.Error(), "category not found") ||
+			strings.Contains(err.Error(), "invalid sort") || strings.Contains(err.Error(), "invalid price range") {
 			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 			return
 		}
+		if code, ok := currencyErrorHTTPStatus(err); ok {
+			c.JSON(code, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list products: " + err.Error()})
 		return
 	}
@@ -422,3 +476,24 @@ func (h *ProductHandler) DeactivateProduct(c *gin.Context) {
 	}
 	c.JSON(http.StatusOK, gin.H{"message": "Product deactivated successfully"})
 }
+
+// currencyErrorHTTPStatus maps price conversion errors to HTTP status codes
+func currencyErrorHTTPStatus(err error) (int, bool) {
+	switch {
+	case errors.Is(err, currency.ErrInvalidCurrency), errors.Is(err, currency.ErrUnsupportedCurrency):
+		return http.StatusBadRequest, true
+	case errors.Is(err, currency.ErrRatesUnavailable):
+		return http.StatusServiceUnavailable, true
+	}
+	return 0, false
+}
+
+// respondAlreadyExists writes 409 with the ID of the existing product or category
+func respondAlreadyExists(c *gin.Context, err error) bool {
+	var exists *models.AlreadyExistsError
+	if !errors.As(err, &exists) {
+		return false
+	}
+	c.JSON(http.StatusConflict, gin.H{"error": exists.Error(), "existing_id": exists.ExistingID})
+	return true
+}
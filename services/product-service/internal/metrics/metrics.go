@@ -17,6 +17,9 @@ var (
 	dbQueriesTotal  *prometheus.CounterVec
 	dbQueryDuration *prometheus.HistogramVec
 
+	// Per-operation repository query latency
+	dbOperationDuration *prometheus.HistogramVec
+
 	// Product-specific metrics
 	productsTotal   prometheus.Gauge
 	categoriesTotal prometheus.Gauge
@@ -28,6 +31,10 @@ var (
 	// Active connections
 	activeConnections prometheus.Gauge
 
+	// Outbox relay metrics
+	outboxEventsPublished *prometheus.CounterVec
+	outboxRelayLag        prometheus.Gauge
+
 	// Ensure metrics are initialized only once
 	metricsOnce sync.Once
 )
@@ -71,6 +78,15 @@ func initMetrics() {
 			[]string{"operation", "table"},
 		)
 
+		dbOperationDuration = prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Name:    "product_service_db_operation_duration_seconds",
+				Help:    "Repository query duration in seconds by named operation",
+				Buckets: prometheus.DefBuckets,
+			},
+			[]string{"operation", "status"},
+		)
+
 		// Product-specific metrics
 		productsTotal = prometheus.NewGauge(
 			prometheus.GaugeOpts{
@@ -112,17 +128,36 @@ func initMetrics() {
 			},
 		)
 
+		// Outbox relay metrics
+		outboxEventsPublished = prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "product_service_outbox_events_published_total",
+				Help: "Total number of outbox events the relay tried to publish",
+			},
+			[]string{"topic", "status"},
+		)
+
+		outboxRelayLag = prometheus.NewGauge(
+			prometheus.GaugeOpts{
+				Name: "product_service_outbox_relay_lag_seconds",
+				Help: "Age of the oldest unpublished outbox event in seconds, 0 when all events are published",
+			},
+		)
+
 		// Register all metrics (with duplicate check)
 		metrics := []prometheus.Collector{
 			httpRequestsTotal,
 			httpRequestDuration,
 			dbQueriesTotal,
 			dbQueryDuration,
+			dbOperationDuration,
 			productsTotal,
 			categoriesTotal,
 			grpcRequestsTotal,
 			grpcRequestDuration,
 			activeConnections,
+			outboxEventsPublished,
+			outboxRelayLag,
 		}
 
 		for _, metric := range metrics {
@@ -187,6 +222,16 @@ func RecordDBQuery(operation, table, status string, duration time.Duration) {
 	dbQueryDuration.WithLabelValues(operation, table).Observe(duration.Seconds())
 }
 
+// RecordDBOperation records the duration of a named repository query; it matches dbquery.Observer
+func RecordDBOperation(operation string, err error, duration time.Duration) {
+	initMetrics() // Ensure metrics are initialized
+	status := "success"
+	if err != nil {
+		status = "error"
+	}
+	dbOperationDuration.WithLabelValues(operation, status).Observe(duration.Seconds())
+}
+
 // RecordGRPCRequest records a gRPC request metric
 func RecordGRPCRequest(method, status string, duration time.Duration) {
 	initMetrics() // Ensure metrics are initialized
@@ -205,3 +250,19 @@ func UpdateCategoriesTotal(count float64) {
 	initMetrics() // Ensure metrics are initialized
 	categoriesTotal.Set(count)
 }
+
+// RecordOutboxPublish records an attempt by the outbox relay to publish an event
+func RecordOutboxPublish(topic string, err error) {
+	initMetrics() // Ensure metrics are initialized
+	status := "success"
+	if err != nil {
+		status = "error"
+	}
+	outboxEventsPublished.WithLabelValues(topic, status).Inc()
+}
+
+// UpdateOutboxRelayLag sets the age of the oldest unpublished outbox event
+func UpdateOutboxRelayLag(lag time.Duration) {
+	initMetrics() // Ensure metrics are initialized
+	outboxRelayLag.Set(lag.Seconds())
+}
@@ -17,11 +17,13 @@ type Category struct {
 // CreateCategoryRequest represents the request to create a new category
 type CreateCategoryRequest struct {
 	Name string `json:"name" validate:"required,min=1,max=100"`
+	Slug string `json:"slug"` // Optional, generated from name when empty
 }
 
 // UpdateCategoryRequest represents the request to update a category
 type UpdateCategoryRequest struct {
 	Name string `json:"name" validate:"required,min=1,max=100"`
+	Slug string `json:"slug"` // Optional, overrides the current slug
 }
 
 // CategoryResponse represents the response for category operations
@@ -41,15 +43,10 @@ type ListCategoriesResponse struct {
 
 // GenerateSlug creates a URL-friendly slug from the category name
 func (c *Category) GenerateSlug() {
-	slug := strings.ToLower(c.Name)
-	slug = strings.ReplaceAll(slug, " ", "-")
-	// Remove special characters (basic implementation)
-	slug = strings.Map(func(r rune) rune {
-		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '-' {
-			return r
-		}
-		return -1
-	}, slug)
+	slug := Slugify(c.Name)
+	if len(slug) > MaxCategorySlugLength {
+		slug = strings.TrimRight(slug[:MaxCategorySlugLength], "-")
+	}
 	c.Slug = slug
 }
 
@@ -0,0 +1,19 @@
+package models
+
+import "fmt"
+
+// AlreadyExistsError reports a create or update that collides with an existing
+// product or category on a unique field
+type AlreadyExistsError struct {
+	Entity     string // "product" or "category"
+	Field      string // "name" or "slug"
+	Value      string
+	ExistingID string // ID of the entity holding Value; empty if it could not be looked up
+}
+
+func (e *AlreadyExistsError) Error() string {
+	if e.Value == "" {
+		return fmt.Sprintf("%s with %s already exists", e.Entity, e.Field)
+	}
+	return fmt.Sprintf("%s with %s '%s' already exists", e.Entity, e.Field, e.Value)
+}
@@ -0,0 +1,34 @@
+package models
+
+import "time"
+
+// Product event topics, used as routing keys on the products exchange
+const (
+	EventProductCreated = "product.created"
+	EventProductUpdated = "product.updated"
+	EventProductDeleted = "product.deleted"
+)
+
+// ProductEventVersion is the schema version of ProductEvent, sent in the event envelope
+const ProductEventVersion = 1
+
+// ProductEvent is the payload of product events. Product is omitted for deletions.
+type ProductEvent struct {
+	EventType  string    `json:"event_type"`
+	ProductID  string    `json:"product_id"`
+	Product    *Product  `json:"product,omitempty"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+// NewProductEvent creates the event for a change to product
+func NewProductEvent(eventType string, product *Product) ProductEvent {
+	event := ProductEvent{
+		EventType:  eventType,
+		ProductID:  product.ID,
+		OccurredAt: time.Now(),
+	}
+	if eventType != EventProductDeleted {
+		event.Product = product
+	}
+	return event
+}
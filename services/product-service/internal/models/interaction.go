@@ -0,0 +1,36 @@
+package models
+
+import "time"
+
+// Product interaction types
+const (
+	InteractionView              = "view"
+	InteractionAddToCart         = "add_to_cart"
+	InteractionPurchase          = "purchase"
+	InteractionPurchaseCancelled = "purchase_cancelled" // Offsets the purchase of a cancelled order
+)
+
+// MaxInteractionsPerRecord bounds one RecordProductInteractions call
+const MaxInteractionsPerRecord = 100
+
+// ProductInteraction is one view, add-to-cart or purchase of a product
+type ProductInteraction struct {
+	ProductID string
+	Type      string
+	Quantity  int32 // Units; always 1 for views
+	UserID    int64 // 0 for anonymous visitors
+}
+
+// ProductStats are the interaction counts of a product over a date range
+type ProductStats struct {
+	ProductID      string `json:"product_id"`
+	ViewCount      int64  `json:"view_count"`
+	AddToCartCount int64  `json:"add_to_cart_count"` // Units added to carts
+	PurchaseCount  int64  `json:"purchase_count"`    // Units bought, net of cancelled orders
+}
+
+// StatsRange limits stats to interactions in [From, To); nil bounds are open
+type StatsRange struct {
+	From *time.Time
+	To   *time.Time
+}
@@ -20,6 +20,28 @@ type Product struct {
 
 	// Relation (not stored in DB, populated when needed)
 	Category *Category `json:"category,omitempty"`
+
+	// Components of a bundle, stored in product_bundle_components. On create and
+	// update nil leaves them unchanged and an empty slice removes them.
+	Components []BundleComponent `json:"components,omitempty" db:"-"`
+
+	// Variants, stored in product_variants. On create and update nil leaves them
+	// unchanged and an empty slice removes them.
+	Variants []ProductVariant `json:"variants,omitempty" db:"-"`
+}
+
+// Bundle limits
+const (
+	MaxBundleComponents        = 20
+	MaxBundleComponentQuantity = 100
+)
+
+// BundleComponent is a product contained in a bundle. Quantity is per bundle unit,
+// so selling n bundles takes n*Quantity of the component from stock.
+type BundleComponent struct {
+	ProductID string `json:"product_id" validate:"required"`
+	Name      string `json:"name,omitempty"` // Set on reads
+	Quantity  int32  `json:"quantity" validate:"required,gt=0"`
 }
 
 // CreateProductRequest represents the request to create a new product
@@ -29,6 +51,13 @@ type CreateProductRequest struct {
 	Price       float64 `json:"price" validate:"required,gt=0"`
 	CategoryID  string  `json:"category_id" validate:"required"`
 	ImageURL    string  `json:"image_url"`
+	Slug        string  `json:"slug"` // Optional, generated from name when empty
+
+	// Components make the product a bundle (optional)
+	Components []BundleComponent `json:"components"`
+
+	// Variants such as sizes or colors, each with its own SKU (optional)
+	Variants []ProductVariant `json:"variants"`
 }
 
 // UpdateProductRequest represents the request to update a product
@@ -39,6 +68,17 @@ type UpdateProductRequest struct {
 	CategoryID  string  `json:"category_id" validate:"required"`
 	ImageURL    string  `json:"image_url"`
 	IsActive    bool    `json:"is_active"`
+	Slug        string  `json:"slug"` // Optional, overrides the current slug
+
+	// Components replace the bundle's components when set; ClearComponents turns
+	// a bundle back into a regular product
+	Components      []BundleComponent `json:"components"`
+	ClearComponents bool              `json:"clear_components"`
+
+	// Variants replace the product's variants when set, keeping the IDs of SKUs
+	// that remain; ClearVariants removes them all
+	Variants      []ProductVariant `json:"variants"`
+	ClearVariants bool             `json:"clear_variants"`
 }
 
 // ProductResponse represents the response for product operations
@@ -54,13 +94,77 @@ type ProductResponse struct {
 	CreatedAt   time.Time         `json:"created_at"`
 	UpdatedAt   time.Time         `json:"updated_at"`
 	Category    *CategoryResponse `json:"category,omitempty"`
+	IsBundle    bool              `json:"is_bundle"`
+	Components  []BundleComponent `json:"components,omitempty"`
+	Variants    []ProductVariant  `json:"variants,omitempty"`
+
+	// Availability is only populated when explicitly requested
+	Availability *ProductAvailability `json:"availability,omitempty"`
+
+	// Currency and BasePrice are only populated when a target currency is
+	// requested; Price is then expressed in Currency.
+	Currency  string  `json:"currency,omitempty"`
+	BasePrice float64 `json:"base_price,omitempty"`
+}
+
+// Availability statuses
+const (
+	AvailabilityInStock    = "in_stock"
+	AvailabilityOutOfStock = "out_of_stock"
+	AvailabilityUnknown    = "unknown" // Inventory service could not be reached
+)
+
+// ProductAvailability represents the stock status of a product
+type ProductAvailability struct {
+	Status            string `json:"status"`
+	InStock           bool   `json:"in_stock"`
+	AvailableQuantity int32  `json:"available_quantity"`
 }
 
 // ListProductsRequest represents the request for listing products
 type ListProductsRequest struct {
-	Page       int    `json:"page" form:"page" validate:"min=1"`
-	PageSize   int    `json:"page_size" form:"page_size" validate:"min=1,max=100"`
-	CategoryID string `json:"category_id" form:"category_id"`
+	Page                int      `json:"page" form:"page" validate:"min=1"`
+	PageSize            int      `json:"page_size" form:"page_size" validate:"min=1,max=100"`
+	CategoryID          string   `json:"category_id" form:"category_id"`
+	BrandID             string   `json:"brand_id" form:"brand_id"`
+	TagIDs              []string `json:"tag_ids" form:"tag_ids"` // Products must carry all of these tags
+	IncludeAvailability bool     `json:"include_availability" form:"include_availability"`
+	Currency            string   `json:"currency" form:"currency"`   // Optional ISO 4217 code to convert prices into
+	Sort                string   `json:"sort" form:"sort"`           // One of the ProductSort values; empty means ProductSortNewest
+	MinPrice            float64  `json:"min_price" form:"min_price"` // Inclusive, in the base currency; 0 means no bound
+	MaxPrice            float64  `json:"max_price" form:"max_price"` // Inclusive, in the base currency; 0 means no bound
+	InStockOnly         bool     `json:"in_stock_only" form:"in_stock_only"`
+}
+
+// Product list sort orders
+const (
+	ProductSortNewest    = "newest"     // created_at descending
+	ProductSortPriceAsc  = "price_asc"  // cheapest first
+	ProductSortPriceDesc = "price_desc" // most expensive first
+	ProductSortName      = "name"       // name A-Z
+)
+
+// IsValidProductSort reports whether sort is a supported product list order
+func IsValidProductSort(sort string) bool {
+	switch sort {
+	case ProductSortNewest, ProductSortPriceAsc, ProductSortPriceDesc, ProductSortName:
+		return true
+	}
+	return false
+}
+
+// StreamProductsRequest represents the request for streaming all products matching filters
+type StreamProductsRequest struct {
+	CategoryID string
+	BrandID    string
+	TagIDs     []string // Products must carry all of these tags
+	BatchSize  int      // Products per batch
+}
+
+// GetProductOptions controls optional enrichment of a single product read
+type GetProductOptions struct {
+	IncludeAvailability bool
+	Currency            string // Optional ISO 4217 code to convert the price into
 }
 
 // ListProductsResponse represents the response for listing products
@@ -70,19 +174,18 @@ type ListProductsResponse struct {
 	Page       int               `json:"page"`
 	PageSize   int               `json:"page_size"`
 	TotalPages int               `json:"total_pages"`
+	HasMore    bool              `json:"has_more"`
+	// StockFilterSkipped is set when InStockOnly could not be applied because
+	// inventory was unreachable; the products are then not filtered by stock
+	StockFilterSkipped bool `json:"stock_filter_skipped,omitempty"`
 }
 
 // GenerateSlug creates a URL-friendly slug from the product name
 func (p *Product) GenerateSlug() {
-	slug := strings.ToLower(p.Name)
-	slug = strings.ReplaceAll(slug, " ", "-")
-	// Remove special characters (basic implementation)
-	slug = strings.Map(func(r rune) rune {
-		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '-' {
-			return r
-		}
-		return -1
-	}, slug)
+	slug := Slugify(p.Name)
+	if len(slug) > MaxProductSlugLength {
+		slug = strings.TrimRight(slug[:MaxProductSlugLength], "-")
+	}
 	p.Slug = slug
 }
 
@@ -99,6 +202,9 @@ func (p *Product) ToResponse() ProductResponse {
 		IsActive:    p.IsActive,
 		CreatedAt:   p.CreatedAt,
 		UpdatedAt:   p.UpdatedAt,
+		IsBundle:    len(p.Components) > 0,
+		Components:  p.Components,
+		Variants:    p.Variants,
 	}
 
 	if p.Category != nil {
@@ -0,0 +1,47 @@
+package models
+
+import "time"
+
+// Saved search limits
+const (
+	MaxSavedSearchesPerUser   = 20
+	MaxSavedSearchNameLength  = 100 // Width of the saved_searches.name column
+	MaxSavedSearchMatchesSent = 20  // New products listed in one notification
+)
+
+// SavedSearch is a product list filter saved by a user. The matcher reruns it
+// periodically and notifies the user of products created since LastRunAt.
+type SavedSearch struct {
+	ID         string    `json:"id"`
+	UserID     int64     `json:"user_id"`
+	Name       string    `json:"name"`
+	CategoryID string    `json:"category_id,omitempty"`
+	BrandID    string    `json:"brand_id,omitempty"`
+	TagIDs     []string  `json:"tag_ids,omitempty"`
+	MinPrice   float64   `json:"min_price,omitempty"` // 0 means no bound
+	MaxPrice   float64   `json:"max_price,omitempty"` // 0 means no bound
+	LastRunAt  time.Time `json:"last_run_at"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// ListRequest returns the product list filters of the saved search
+func (s *SavedSearch) ListRequest() *ListProductsRequest {
+	return &ListProductsRequest{
+		CategoryID: s.CategoryID,
+		BrandID:    s.BrandID,
+		TagIDs:     s.TagIDs,
+		MinPrice:   s.MinPrice,
+		MaxPrice:   s.MaxPrice,
+	}
+}
+
+// SaveSearchRequest is the input of SaveSearch
+type SaveSearchRequest struct {
+	UserID     int64
+	Name       string
+	CategoryID string
+	BrandID    string
+	TagIDs     []string
+	MinPrice   float64
+	MaxPrice   float64
+}
@@ -0,0 +1,73 @@
+package models
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// Slug length limits (match the column sizes in migrations)
+const (
+	MaxProductSlugLength  = 280
+	MaxCategorySlugLength = 120
+)
+
+var slugPattern = regexp.MustCompile(`^[a-z0-9]+(?:-[a-z0-9]+)*$`)
+
+// Slugify converts a name into a URL-safe slug: lowercase ASCII letters and
+// digits separated by single hyphens. Diacritics are stripped so that e.g.
+// "Điện thoại" becomes "dien-thoai".
+func Slugify(name string) string {
+	// Tách dấu ra khỏi ký tự gốc rồi bỏ dấu (NFD -> remove Mn -> NFC)
+	t := transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+	stripped, _, err := transform.String(t, name)
+	if err != nil {
+		stripped = name
+	}
+
+	var b strings.Builder
+	pendingHyphen := false
+	for _, r := range strings.ToLower(stripped) {
+		switch {
+		case r == 'đ':
+			r = 'd'
+		case (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9'):
+		default:
+			pendingHyphen = b.Len() > 0
+			continue
+		}
+		if pendingHyphen {
+			b.WriteByte('-')
+			pendingHyphen = false
+		}
+		b.WriteRune(r)
+	}
+
+	return b.String()
+}
+
+// ValidateSlug checks that a manually supplied slug is URL-safe
+func ValidateSlug(slug string, maxLength int) error {
+	if len(slug) > maxLength {
+		return fmt.Errorf("slug must not exceed %d characters", maxLength)
+	}
+	if !slugPattern.MatchString(slug) {
+		return fmt.Errorf("slug must contain only lowercase letters, digits and single hyphens")
+	}
+	return nil
+}
+
+// SlugWithSuffix appends a numeric suffix to a slug, truncating the base so
+// the result stays within maxLength.
+func SlugWithSuffix(base string, n, maxLength int) string {
+	suffix := fmt.Sprintf("-%d", n)
+	if len(base)+len(suffix) > maxLength {
+		base = strings.TrimRight(base[:maxLength-len(suffix)], "-")
+	}
+	return base + suffix
+}
@@ -0,0 +1,28 @@
+package models
+
+// Variant limits
+const (
+	MaxProductVariants        = 100
+	MaxVariantAttributes      = 5
+	MaxVariantSKULength       = 64
+	MaxVariantAttributeLength = 50
+)
+
+// ProductVariant is a sellable version of a product, such as size M in red, with
+// its own SKU and stock. Inventory keys the variant's stock on its ID, which stays
+// the same as long as the SKU is kept when the variants are replaced.
+type ProductVariant struct {
+	ID         string             `json:"id,omitempty"` // Set on reads
+	SKU        string             `json:"sku" validate:"required"`
+	Attributes []VariantAttribute `json:"attributes"`
+	Price      float64            `json:"price,omitempty"` // Overrides the product price when set
+
+	// Availability is only populated when explicitly requested
+	Availability *ProductAvailability `json:"availability,omitempty"`
+}
+
+// VariantAttribute is one property telling variants apart, e.g. size=M
+type VariantAttribute struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
@@ -3,6 +3,8 @@ package repository
 import (
 	"context"
 	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/datngth03/ecommerce-go-app/services/product-service/internal/models"
@@ -21,6 +23,11 @@ const (
 	ProductListCacheTTL  = 3 * time.Minute  // Product list cache
 	CategoryCacheTTL     = 10 * time.Minute // Categories change less frequently
 	SearchResultCacheTTL = 2 * time.Minute  // Search results cache
+	AutocompleteCacheTTL = 1 * time.Minute  // Autocomplete suggestions cache
+	ProductStatsCacheTTL = 1 * time.Minute  // Product stats; new interactions show up after it expires
+
+	// Only short prefixes are cached; they are the common ones and keep the key space small
+	AutocompleteCacheMaxPrefix = 10
 )
 
 // NewCachedProductRepository creates a cached product repository
@@ -106,6 +113,12 @@ func (r *CachedProductRepository) GetBySlug(ctx context.Context, slug string) (*
 
 // Update updates a product and invalidates its caches
 func (r *CachedProductRepository) Update(ctx context.Context, product *models.Product) error {
+	// Get current product first so a changed slug doesn't leave a stale entry
+	existing, err := r.repo.GetByID(ctx, product.ID)
+	if err != nil {
+		return err
+	}
+
 	if err := r.repo.Update(ctx, product); err != nil {
 		return err
 	}
@@ -113,6 +126,7 @@ func (r *CachedProductRepository) Update(ctx context.Context, product *models.Pr
 	// Invalidate product caches
 	r.cache.Delete(ctx,
 		fmt.Sprintf("product:id:%s", product.ID),
+		fmt.Sprintf("product:slug:%s", existing.Slug),
 		fmt.Sprintf("product:slug:%s", product.Slug),
 	)
 
@@ -148,8 +162,8 @@ func (r *CachedProductRepository) Delete(ctx context.Context, id string) error {
 
 // List retrieves products with caching
 func (r *CachedProductRepository) List(ctx context.Context, req *models.ListProductsRequest) ([]models.Product, int64, error) {
-	cacheKey := fmt.Sprintf("products:list:page:%d:pagesize:%d:category:%s",
-		req.Page, req.PageSize, req.CategoryID)
+	cacheKey := fmt.Sprintf("products:list:page:%d:pagesize:%d:category:%s:brand:%s:tags:%s:sort:%s:price:%g-%g",
+		req.Page, req.PageSize, req.CategoryID, req.BrandID, strings.Join(req.TagIDs, ","), req.Sort, req.MinPrice, req.MaxPrice)
 
 	var cachedResult struct {
 		Products []models.Product
@@ -188,10 +202,15 @@ func (r *CachedProductRepository) List(ctx context.Context, req *models.ListProd
 	return products, total, nil
 }
 
+// ListIDs is not cached; it backs the in-stock filter, whose result depends on live stock
+func (r *CachedProductRepository) ListIDs(ctx context.Context, req *models.ListProductsRequest) ([]string, error) {
+	return r.repo.ListIDs(ctx, req)
+}
+
 // ListByCategoryID retrieves products by category with caching
 func (r *CachedProductRepository) ListByCategoryID(ctx context.Context, categoryID string, req *models.ListProductsRequest) ([]models.Product, int64, error) {
-	cacheKey := fmt.Sprintf("products:category:%s:page:%d:pagesize:%d",
-		categoryID, req.Page, req.PageSize)
+	cacheKey := fmt.Sprintf("products:category:%s:page:%d:pagesize:%d:sort:%s",
+		categoryID, req.Page, req.PageSize, req.Sort)
 
 	var cachedResult struct {
 		Products []models.Product
@@ -235,6 +254,81 @@ func (r *CachedProductRepository) ExistsByName(ctx context.Context, name string,
 	return r.repo.ExistsByName(ctx, name, excludeID...)
 }
 
+// ListCreatedBetween returns new products matching the filters (no caching: saved searches need every new product)
+func (r *CachedProductRepository) ListCreatedBetween(ctx context.Context, req *models.ListProductsRequest, after, until time.Time, limit int) ([]models.Product, int, error) {
+	return r.repo.ListCreatedBetween(ctx, req, after, until, limit)
+}
+
+// IDByName returns the ID of the product with the given name (no caching for existence checks)
+func (r *CachedProductRepository) IDByName(ctx context.Context, name string) (string, error) {
+	return r.repo.IDByName(ctx, name)
+}
+
+// ExistsBySlug checks if product exists by slug (no caching for existence checks)
+func (r *CachedProductRepository) ExistsBySlug(ctx context.Context, slug string, excludeID ...string) (bool, error) {
+	return r.repo.ExistsBySlug(ctx, slug, excludeID...)
+}
+
+// GetByIDs retrieves several products in one query (no caching: callers need current prices)
+func (r *CachedProductRepository) GetByIDs(ctx context.Context, ids []string) ([]models.Product, error) {
+	return r.repo.GetByIDs(ctx, ids)
+}
+
+// GetBundleComponents returns bundle compositions (no caching: a renamed or
+// deleted component would otherwise leave every bundle containing it stale)
+func (r *CachedProductRepository) GetBundleComponents(ctx context.Context, productIDs []string) (map[string][]models.BundleComponent, error) {
+	return r.repo.GetBundleComponents(ctx, productIDs)
+}
+
+// IsBundleComponent checks bundle membership (no caching for existence checks)
+func (r *CachedProductRepository) IsBundleComponent(ctx context.Context, productID string) (bool, error) {
+	return r.repo.IsBundleComponent(ctx, productID)
+}
+
+// GetVariants returns product variants (no caching: callers need current prices and SKUs)
+func (r *CachedProductRepository) GetVariants(ctx context.Context, productIDs []string) (map[string][]models.ProductVariant, error) {
+	return r.repo.GetVariants(ctx, productIDs)
+}
+
+// Stream scans products in batches (no caching: exports read everything once)
+func (r *CachedProductRepository) Stream(ctx context.Context, req *models.ListProductsRequest, batchSize int, fn func([]models.Product) error) error {
+	return r.repo.Stream(ctx, req, batchSize, fn)
+}
+
+// SuggestNames returns product name suggestions, caching results for short prefixes
+func (r *CachedProductRepository) SuggestNames(ctx context.Context, prefix string, limit int) ([]string, error) {
+	if len([]rune(prefix)) > AutocompleteCacheMaxPrefix {
+		return r.repo.SuggestNames(ctx, prefix, limit)
+	}
+
+	cacheKey := fmt.Sprintf("products:autocomplete:%d:%s", limit, prefix)
+
+	var names []string
+
+	// Try cache first
+	err := r.cache.Get(ctx, cacheKey, &names)
+	if err == nil {
+		return names, nil
+	}
+
+	if !cache.IsCacheMiss(err) {
+		fmt.Printf("Cache error for autocomplete prefix %q: %v\n", prefix, err)
+	}
+
+	// Fetch from DB
+	names, err = r.repo.SuggestNames(ctx, prefix, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	// Cache the result
+	if err := r.cache.Set(ctx, cacheKey, names, AutocompleteCacheTTL); err != nil {
+		fmt.Printf("Warning: failed to cache autocomplete prefix %q: %v\n", prefix, err)
+	}
+
+	return names, nil
+}
+
 // CountByCategory counts products by category (cached)
 func (r *CachedProductRepository) CountByCategory(ctx context.Context, categoryID string) (int64, error) {
 	cacheKey := fmt.Sprintf("products:category:%s:count", categoryID)
@@ -266,6 +360,7 @@ func (r *CachedProductRepository) invalidateProductCaches(ctx context.Context, c
 	// Delete product list patterns
 	patterns := []string{
 		"products:list:*",
+		"products:autocomplete:*",
 		fmt.Sprintf("products:category:%s:*", categoryID),
 	}
 
@@ -364,6 +459,12 @@ func (r *CachedCategoryRepository) GetBySlug(ctx context.Context, slug string) (
 
 // Update updates a category and invalidates its caches
 func (r *CachedCategoryRepository) Update(ctx context.Context, category *models.Category) error {
+	// Get current category first so a changed slug doesn't leave a stale entry
+	existing, err := r.repo.GetByID(ctx, category.ID)
+	if err != nil {
+		return err
+	}
+
 	if err := r.repo.Update(ctx, category); err != nil {
 		return err
 	}
@@ -371,6 +472,7 @@ func (r *CachedCategoryRepository) Update(ctx context.Context, category *models.
 	// Invalidate category caches
 	r.cache.Delete(ctx,
 		fmt.Sprintf("category:id:%s", category.ID),
+		fmt.Sprintf("category:slug:%s", existing.Slug),
 		fmt.Sprintf("category:slug:%s", category.Slug),
 	)
 	r.cache.DeletePattern(ctx, "categories:*")
@@ -435,7 +537,71 @@ func (r *CachedCategoryRepository) ExistsByName(ctx context.Context, name string
 	return r.repo.ExistsByName(ctx, name, excludeID...)
 }
 
+// IDByName returns the ID of the category with the given name (no caching)
+func (r *CachedCategoryRepository) IDByName(ctx context.Context, name string) (string, error) {
+	return r.repo.IDByName(ctx, name)
+}
+
+// ExistsBySlug checks if category exists by slug (no caching)
+func (r *CachedCategoryRepository) ExistsBySlug(ctx context.Context, slug string, excludeID ...string) (bool, error) {
+	return r.repo.ExistsBySlug(ctx, slug, excludeID...)
+}
+
 // ExistsByID checks if category exists by ID (no caching)
 func (r *CachedCategoryRepository) ExistsByID(ctx context.Context, id string) (bool, error) {
 	return r.repo.ExistsByID(ctx, id)
 }
+
+// CachedInteractionRepository wraps InteractionRepository, caching the stats of
+// products the dashboard keeps asking for
+type CachedInteractionRepository struct {
+	repo  InteractionRepository
+	cache *cache.RedisCache
+}
+
+// NewCachedInteractionRepository creates a cached interaction repository
+func NewCachedInteractionRepository(repo InteractionRepository, cache *cache.RedisCache) *CachedInteractionRepository {
+	return &CachedInteractionRepository{
+		repo:  repo,
+		cache: cache,
+	}
+}
+
+// Record stores interactions (no caching; cached stats expire on their own)
+func (r *CachedInteractionRepository) Record(ctx context.Context, interactions []models.ProductInteraction) error {
+	return r.repo.Record(ctx, interactions)
+}
+
+// GetStats retrieves product stats with caching
+func (r *CachedInteractionRepository) GetStats(ctx context.Context, productID string, within models.StatsRange) (*models.ProductStats, error) {
+	cacheKey := fmt.Sprintf("product:stats:%s:from:%s:to:%s", productID, statsBound(within.From), statsBound(within.To))
+
+	var stats models.ProductStats
+	err := r.cache.Get(ctx, cacheKey, &stats)
+	if err == nil {
+		return &stats, nil
+	}
+
+	if !cache.IsCacheMiss(err) {
+		fmt.Printf("Cache error for product stats %s: %v\n", productID, err)
+	}
+
+	dbStats, err := r.repo.GetStats(ctx, productID, within)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.cache.Set(ctx, cacheKey, dbStats, ProductStatsCacheTTL); err != nil {
+		fmt.Printf("Warning: failed to cache product stats %s: %v\n", productID, err)
+	}
+
+	return dbStats, nil
+}
+
+// statsBound formats a range bound for a cache key
+func statsBound(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return strconv.FormatInt(t.Unix(), 10)
+}
@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/lib/pq"
+
+	"github.com/datngth03/ecommerce-go-app/services/product-service/internal/metrics"
+	"github.com/datngth03/ecommerce-go-app/services/product-service/internal/models"
+	"github.com/datngth03/ecommerce-go-app/shared/pkg/dbquery"
+)
+
+// InteractionPostgresRepository implements InteractionRepository for PostgreSQL.
+// Stats are aggregated on reads, so they can be served by a read replica.
+type InteractionPostgresRepository struct {
+	db    *sql.DB
+	reads *ReplicaRouter
+	q     *dbquery.Instrumenter
+}
+
+// NewInteractionRepository creates a new PostgreSQL interaction repository
+func NewInteractionRepository(db *sql.DB, reads *ReplicaRouter) InteractionRepository {
+	return &InteractionPostgresRepository{db: db, reads: reads, q: dbquery.New(metrics.RecordDBOperation)}
+}
+
+// Record inserts interactions in one statement. Interactions with products that
+// don't exist are skipped rather than failing the batch.
+func (r *InteractionPostgresRepository) Record(ctx context.Context, interactions []models.ProductInteraction) error {
+	if len(interactions) == 0 {
+		return nil
+	}
+
+	productIDs := make([]string, len(interactions))
+	types := make([]string, len(interactions))
+	quantities := make([]int64, len(interactions))
+	userIDs := make([]int64, len(interactions))
+	for i, interaction := range interactions {
+		productIDs[i] = interaction.ProductID
+		types[i] = interaction.Type
+		quantities[i] = int64(interaction.Quantity)
+		userIDs[i] = interaction.UserID
+	}
+
+	query := `
+		INSERT INTO product_interactions (product_id, type, quantity, user_id)
+		SELECT i.product_id, i.type, i.quantity, NULLIF(i.user_id, 0)
+		FROM unnest($1::uuid[], $2::varchar[], $3::int[], $4::bigint[]) AS i(product_id, type, quantity, user_id)
+		JOIN products p ON p.id = i.product_id
+	`
+	err := r.q.Do("product_interactions.record", func() error {
+		stmt, err := r.q.Prepare(ctx, r.db, query)
+		if err != nil {
+			return err
+		}
+		_, err = stmt.ExecContext(ctx, pq.Array(productIDs), pq.Array(types), pq.Array(quantities), pq.Array(userIDs))
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to record product interactions: %w", err)
+	}
+	return nil
+}
+
+// GetStats aggregates the interactions of a product within the range. A cancellation
+// is counted when it happens, so one in the range can offset a purchase made before
+// it; the purchase count is clamped at 0 rather than going negative.
+func (r *InteractionPostgresRepository) GetStats(ctx context.Context, productID string, within models.StatsRange) (*models.ProductStats, error) {
+	query := `
+		SELECT
+			COUNT(*) FILTER (WHERE type = 'view'),
+			COALESCE(SUM(quantity) FILTER (WHERE type = 'add_to_cart'), 0),
+			GREATEST(COALESCE(SUM(quantity) FILTER (WHERE type = 'purchase'), 0)
+				- COALESCE(SUM(quantity) FILTER (WHERE type = 'purchase_cancelled'), 0), 0)
+		FROM product_interactions
+		WHERE product_id = $1
+			AND occurred_at >= COALESCE($2::timestamptz, '-infinity')
+			AND occurred_at < COALESCE($3::timestamptz, 'infinity')
+	`
+
+	stats := &models.ProductStats{ProductID: productID}
+	err := r.q.Do("product_interactions.stats", func() error {
+		stmt, err := r.q.Prepare(ctx, r.reads.Reader(ctx), query)
+		if err != nil {
+			return err
+		}
+		return stmt.QueryRowContext(ctx, productID, within.From, within.To).Scan(
+			&stats.ViewCount, &stats.AddToCartCount, &stats.PurchaseCount,
+		)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get product stats: %w", err)
+	}
+	return stats, nil
+}
@@ -4,6 +4,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/datngth03/ecommerce-go-app/services/product-service/internal/models"
 )
@@ -12,13 +13,26 @@ import (
 type ProductRepository interface {
 	Create(ctx context.Context, product *models.Product) error
 	GetByID(ctx context.Context, id string) (*models.Product, error)
+	GetByIDs(ctx context.Context, ids []string) ([]models.Product, error)
 	GetBySlug(ctx context.Context, slug string) (*models.Product, error)
 	Update(ctx context.Context, product *models.Product) error
 	Delete(ctx context.Context, id string) error
 	List(ctx context.Context, req *models.ListProductsRequest) ([]models.Product, int64, error)
+	// ListIDs returns the IDs of all products matching the list filters, in list order
+	ListIDs(ctx context.Context, req *models.ListProductsRequest) ([]string, error)
 	ListByCategoryID(ctx context.Context, categoryID string, req *models.ListProductsRequest) ([]models.Product, int64, error)
+	// ListCreatedBetween returns up to limit active products matching the list filters created in (after, until], and how many match in all
+	ListCreatedBetween(ctx context.Context, req *models.ListProductsRequest, after, until time.Time, limit int) ([]models.Product, int, error)
 	ExistsByName(ctx context.Context, name string, excludeID ...string) (bool, error)
+	// IDByName returns the ID of the product with the given name, or "" if there is none
+	IDByName(ctx context.Context, name string) (string, error)
+	ExistsBySlug(ctx context.Context, slug string, excludeID ...string) (bool, error)
 	CountByCategory(ctx context.Context, categoryID string) (int64, error)
+	SuggestNames(ctx context.Context, prefix string, limit int) ([]string, error)
+	Stream(ctx context.Context, req *models.ListProductsRequest, batchSize int, fn func([]models.Product) error) error
+	GetBundleComponents(ctx context.Context, productIDs []string) (map[string][]models.BundleComponent, error)
+	IsBundleComponent(ctx context.Context, productID string) (bool, error)
+	GetVariants(ctx context.Context, productIDs []string) (map[string][]models.ProductVariant, error)
 }
 
 // CategoryRepository defines the interface for category data operations
@@ -30,20 +44,44 @@ type CategoryRepository interface {
 	Delete(ctx context.Context, id string) error
 	List(ctx context.Context) ([]models.Category, error)
 	ExistsByName(ctx context.Context, name string, excludeID ...string) (bool, error)
+	// IDByName returns the ID of the category with the given name, or "" if there is none
+	IDByName(ctx context.Context, name string) (string, error)
+	ExistsBySlug(ctx context.Context, slug string, excludeID ...string) (bool, error)
 	ExistsByID(ctx context.Context, id string) (bool, error)
 }
 
+// InteractionRepository stores product interactions and aggregates them into stats
+type InteractionRepository interface {
+	Record(ctx context.Context, interactions []models.ProductInteraction) error
+	GetStats(ctx context.Context, productID string, within models.StatsRange) (*models.ProductStats, error)
+}
+
+// SavedSearchRepository stores saved searches and the progress of their periodic runs
+type SavedSearchRepository interface {
+	// Create stores a search unless the user already has limit of them (ErrSavedSearchLimitReached)
+	Create(ctx context.Context, search *models.SavedSearch, limit int) error
+	ListByUser(ctx context.Context, userID int64) ([]models.SavedSearch, error)
+	Delete(ctx context.Context, id string, userID int64) error
+	// ClaimDue claims up to limit searches last run at least interval ago, for lease, and returns the database time of the claim
+	ClaimDue(ctx context.Context, interval, lease time.Duration, limit int) ([]models.SavedSearch, time.Time, error)
+	// MarkRun records that a search reported every match created up to runAt and releases its claim
+	MarkRun(ctx context.Context, id string, runAt time.Time) error
+}
+
 // Repository aggregates all repository interfaces
 type Repository struct {
-	Product  ProductRepository
-	Category CategoryRepository
+	Product     ProductRepository
+	Category    CategoryRepository
+	Interaction InteractionRepository
+	SavedSearch SavedSearchRepository
 }
 
 // RepositoryOptions contains options for repository initialization
 type RepositoryOptions struct {
-	Database     interface{} // Can be *sql.DB, *gorm.DB, etc.
-	MaxOpenConns int         // Maximum number of open database connections
-	MaxIdleConns int         // Maximum number of idle database connections
+	Database     interface{}    // Can be *sql.DB, *gorm.DB, etc.
+	MaxOpenConns int            // Maximum number of open database connections
+	MaxIdleConns int            // Maximum number of idle database connections
+	ReadRouter   *ReplicaRouter // Optional; catalog reads use the primary when nil
 }
 
 // NewRepository creates a new repository instance
@@ -5,7 +5,9 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"log"
 	"strings"
 	"time"
 
@@ -14,11 +16,21 @@ import (
 
 	"github.com/datngth03/ecommerce-go-app/services/product-service/internal/metrics"
 	"github.com/datngth03/ecommerce-go-app/services/product-service/internal/models"
+	"github.com/datngth03/ecommerce-go-app/shared/pkg/dbquery"
+	"github.com/datngth03/ecommerce-go-app/shared/pkg/outbox"
 )
 
-// ProductPostgresRepository implements ProductRepository for PostgreSQL
+// ErrProductNotFound is returned when the product to read, update or delete does not exist
+var ErrProductNotFound = errors.New("product not found")
+
+// ProductPostgresRepository implements ProductRepository for PostgreSQL.
+// Catalog reads (get, list, stream, autocomplete) use reads, so they can be served
+// by a read replica. Writes and the existence checks that guard them use the primary.
+// Hot queries are timed per operation by q and run as cached prepared statements.
 type ProductPostgresRepository struct {
-	db *sql.DB
+	db    *sql.DB
+	reads *ReplicaRouter
+	q     *dbquery.Instrumenter
 }
 
 // CategoryPostgresRepository implements CategoryRepository for PostgreSQL
@@ -27,8 +39,8 @@ type CategoryPostgresRepository struct {
 }
 
 // NewProductRepository creates a new PostgreSQL product repository
-func NewProductRepository(db *sql.DB) ProductRepository {
-	return &ProductPostgresRepository{db: db}
+func NewProductRepository(db *sql.DB, reads *ReplicaRouter) ProductRepository {
+	return &ProductPostgresRepository{db: db, reads: reads, q: dbquery.New(metrics.RecordDBOperation)}
 }
 
 // NewCategoryRepository creates a new PostgreSQL category repository
@@ -46,7 +58,9 @@ func (r *ProductPostgresRepository) Create(ctx context.Context, product *models.
 	}()
 
 	product.ID = uuid.New().String()
-	product.GenerateSlug()
+	if product.Slug == "" {
+		product.GenerateSlug()
+	}
 	now := time.Now()
 	product.CreatedAt = now
 	product.UpdatedAt = now
@@ -57,19 +71,28 @@ func (r *ProductPostgresRepository) Create(ctx context.Context, product *models.
 		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
 	`
 
-	_, err := r.db.ExecContext(ctx, query,
-		product.ID, product.Name, product.Slug, product.Description,
-		product.Price, product.CategoryID, product.ImageURL, product.IsActive,
-		product.CreatedAt, product.UpdatedAt,
-	)
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	err = r.q.Do("products.create", func() error {
+		_, err := tx.ExecContext(ctx, query,
+			product.ID, product.Name, product.Slug, product.Description,
+			product.Price, product.CategoryID, product.ImageURL, product.IsActive,
+			product.CreatedAt, product.UpdatedAt,
+		)
+		return err
+	})
 
 	if err != nil {
 		metrics.RecordDBQuery("INSERT", "products", "error", time.Since(start))
 		if pqErr, ok := err.(*pq.Error); ok {
 			switch pqErr.Code {
 			case "23505": // unique violation
-				if strings.Contains(pqErr.Message, "slug") {
-					return fmt.Errorf("product with slug already exists")
+				if err := uniqueViolation(ctx, r.db, "product", "products", pqErr, product.Name, product.Slug); err != nil {
+					return err
 				}
 				return fmt.Errorf("product already exists")
 			case "23503": // foreign key violation
@@ -79,6 +102,24 @@ func (r *ProductPostgresRepository) Create(ctx context.Context, product *models.
 		return fmt.Errorf("failed to create product: %w", err)
 	}
 
+	if product.Components != nil {
+		if err := r.replaceBundleComponents(ctx, tx, product.ID, product.Components); err != nil {
+			return err
+		}
+	}
+	if product.Variants != nil {
+		if err := r.replaceVariants(ctx, tx, product.ID, product.Variants); err != nil {
+			return err
+		}
+	}
+
+	if err := enqueueProductEvent(ctx, tx, models.EventProductCreated, product); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to create product: %w", err)
+	}
+
 	return nil
 }
 
@@ -103,17 +144,23 @@ func (r *ProductPostgresRepository) GetByID(ctx context.Context, id string) (*mo
 	var categoryID, categoryName, categorySlug sql.NullString
 	var categoryCreatedAt, categoryUpdatedAt sql.NullTime
 
-	err := r.db.QueryRowContext(ctx, query, id).Scan(
-		&product.ID, &product.Name, &product.Slug, &product.Description,
-		&product.Price, &product.CategoryID, &product.ImageURL, &product.IsActive,
-		&product.CreatedAt, &product.UpdatedAt,
-		&categoryID, &categoryName, &categorySlug, &categoryCreatedAt, &categoryUpdatedAt,
-	)
+	err := r.q.Do("products.get_by_id", func() error {
+		stmt, err := r.q.Prepare(ctx, r.reads.Reader(ctx), query)
+		if err != nil {
+			return err
+		}
+		return stmt.QueryRowContext(ctx, id).Scan(
+			&product.ID, &product.Name, &product.Slug, &product.Description,
+			&product.Price, &product.CategoryID, &product.ImageURL, &product.IsActive,
+			&product.CreatedAt, &product.UpdatedAt,
+			&categoryID, &categoryName, &categorySlug, &categoryCreatedAt, &categoryUpdatedAt,
+		)
+	})
 
 	if err != nil {
 		metrics.RecordDBQuery("SELECT", "products", "error", time.Since(start))
 		if err == sql.ErrNoRows {
-			return nil, fmt.Errorf("product not found")
+			return nil, ErrProductNotFound
 		}
 		return nil, fmt.Errorf("failed to get product: %w", err)
 	}
@@ -131,6 +178,43 @@ func (r *ProductPostgresRepository) GetByID(ctx context.Context, id string) (*mo
 	return product, nil
 }
 
+// GetByIDs retrieves the products with the given IDs in one query; missing IDs are skipped.
+// ids must be valid UUIDs.
+func (r *ProductPostgresRepository) GetByIDs(ctx context.Context, ids []string) ([]models.Product, error) {
+	start := time.Now()
+	query := `
+		SELECT p.id, p.name, p.slug, p.description, p.price, p.category_id,
+		       p.image_url, p.is_active, p.created_at, p.updated_at,
+		       c.id, c.name, c.slug, c.created_at, c.updated_at
+		FROM products p
+		LEFT JOIN categories c ON p.category_id = c.id
+		WHERE p.id = ANY($1::uuid[])
+	`
+
+	var products []models.Product
+	err := r.q.Do("products.get_by_ids", func() error {
+		stmt, err := r.q.Prepare(ctx, r.reads.Reader(ctx), query)
+		if err != nil {
+			return fmt.Errorf("failed to get products: %w", err)
+		}
+		rows, err := stmt.QueryContext(ctx, pq.Array(ids))
+		if err != nil {
+			return fmt.Errorf("failed to get products: %w", err)
+		}
+		defer rows.Close()
+
+		products, err = scanProductsWithCategory(rows)
+		return err
+	})
+	if err != nil {
+		metrics.RecordDBQuery("SELECT", "products", "error", time.Since(start))
+		return nil, err
+	}
+
+	metrics.RecordDBQuery("SELECT", "products", "success", time.Since(start))
+	return products, nil
+}
+
 // GetBySlug retrieves a product by slug
 func (r *ProductPostgresRepository) GetBySlug(ctx context.Context, slug string) (*models.Product, error) {
 	query := `
@@ -147,16 +231,22 @@ func (r *ProductPostgresRepository) GetBySlug(ctx context.Context, slug string)
 	var categoryID, categoryName, categorySlug sql.NullString
 	var categoryCreatedAt, categoryUpdatedAt sql.NullTime
 
-	err := r.db.QueryRowContext(ctx, query, slug).Scan(
-		&product.ID, &product.Name, &product.Slug, &product.Description,
-		&product.Price, &product.CategoryID, &product.ImageURL, &product.IsActive,
-		&product.CreatedAt, &product.UpdatedAt,
-		&categoryID, &categoryName, &categorySlug, &categoryCreatedAt, &categoryUpdatedAt,
-	)
+	err := r.q.Do("products.get_by_slug", func() error {
+		stmt, err := r.q.Prepare(ctx, r.reads.Reader(ctx), query)
+		if err != nil {
+			return err
+		}
+		return stmt.QueryRowContext(ctx, slug).Scan(
+			&product.ID, &product.Name, &product.Slug, &product.Description,
+			&product.Price, &product.CategoryID, &product.ImageURL, &product.IsActive,
+			&product.CreatedAt, &product.UpdatedAt,
+			&categoryID, &categoryName, &categorySlug, &categoryCreatedAt, &categoryUpdatedAt,
+		)
+	})
 
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, fmt.Errorf("product not found")
+			return nil, ErrProductNotFound
 		}
 		return nil, fmt.Errorf("failed to get product: %w", err)
 	}
@@ -176,7 +266,9 @@ func (r *ProductPostgresRepository) GetBySlug(ctx context.Context, slug string)
 
 // Update updates an existing product
 func (r *ProductPostgresRepository) Update(ctx context.Context, product *models.Product) error {
-	product.GenerateSlug()
+	if product.Slug == "" {
+		product.GenerateSlug()
+	}
 	product.UpdatedAt = time.Now()
 
 	query := `
@@ -186,16 +278,29 @@ func (r *ProductPostgresRepository) Update(ctx context.Context, product *models.
 		WHERE id = $1
 	`
 
-	result, err := r.db.ExecContext(ctx, query,
-		product.ID, product.Name, product.Slug, product.Description,
-		product.Price, product.CategoryID, product.ImageURL, product.IsActive,
-		product.UpdatedAt,
-	)
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var result sql.Result
+	err = r.q.Do("products.update", func() error {
+		result, err = tx.ExecContext(ctx, query,
+			product.ID, product.Name, product.Slug, product.Description,
+			product.Price, product.CategoryID, product.ImageURL, product.IsActive,
+			product.UpdatedAt,
+		)
+		return err
+	})
 
 	if err != nil {
 		if pqErr, ok := err.(*pq.Error); ok {
 			switch pqErr.Code {
 			case "23505": // unique violation
+				if err := uniqueViolation(ctx, r.db, "product", "products", pqErr, product.Name, product.Slug); err != nil {
+					return err
+				}
 				return fmt.Errorf("product with slug already exists")
 			case "23503": // foreign key violation
 				return fmt.Errorf("category not found")
@@ -210,7 +315,25 @@ func (r *ProductPostgresRepository) Update(ctx context.Context, product *models.
 	}
 
 	if rowsAffected == 0 {
-		return fmt.Errorf("product not found")
+		return ErrProductNotFound
+	}
+
+	if product.Components != nil {
+		if err := r.replaceBundleComponents(ctx, tx, product.ID, product.Components); err != nil {
+			return err
+		}
+	}
+	if product.Variants != nil {
+		if err := r.replaceVariants(ctx, tx, product.ID, product.Variants); err != nil {
+			return err
+		}
+	}
+
+	if err := enqueueProductEvent(ctx, tx, models.EventProductUpdated, product); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to update product: %w", err)
 	}
 
 	return nil
@@ -220,8 +343,17 @@ func (r *ProductPostgresRepository) Update(ctx context.Context, product *models.
 func (r *ProductPostgresRepository) Delete(ctx context.Context, id string) error {
 	query := `DELETE FROM products WHERE id = $1`
 
-	result, err := r.db.ExecContext(ctx, query, id)
+	tx, err := r.db.BeginTx(ctx, nil)
 	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, query, id)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23503" {
+			return fmt.Errorf("product is a component of a bundle; remove it from the bundle first")
+		}
 		return fmt.Errorf("failed to delete product: %w", err)
 	}
 
@@ -231,27 +363,174 @@ func (r *ProductPostgresRepository) Delete(ctx context.Context, id string) error
 	}
 
 	if rowsAffected == 0 {
-		return fmt.Errorf("product not found")
+		return ErrProductNotFound
+	}
+
+	if err := enqueueProductEvent(ctx, tx, models.EventProductDeleted, &models.Product{ID: id}); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to delete product: %w", err)
 	}
 
 	return nil
 }
 
-// List retrieves a paginated list of products
-func (r *ProductPostgresRepository) List(ctx context.Context, req *models.ListProductsRequest) ([]models.Product, int64, error) {
-	// Convert empty category_id to nil for proper SQL handling
-	var categoryIDParam interface{}
-	if req.CategoryID == "" {
-		categoryIDParam = nil
-	} else {
+// replaceBundleComponents sets the components of a bundle within tx; an empty
+// list turns the bundle back into a regular product
+func (r *ProductPostgresRepository) replaceBundleComponents(ctx context.Context, tx *sql.Tx, bundleID string, components []models.BundleComponent) error {
+	if _, err := tx.ExecContext(ctx, `DELETE FROM product_bundle_components WHERE bundle_id = $1`, bundleID); err != nil {
+		return fmt.Errorf("failed to update bundle components: %w", err)
+	}
+
+	for _, component := range components {
+		_, err := tx.ExecContext(ctx,
+			`INSERT INTO product_bundle_components (bundle_id, component_id, quantity) VALUES ($1, $2, $3)`,
+			bundleID, component.ProductID, component.Quantity)
+		if err != nil {
+			if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23503" {
+				return fmt.Errorf("invalid bundle: component product %s not found", component.ProductID)
+			}
+			return fmt.Errorf("failed to update bundle components: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// GetBundleComponents returns the components of the given products keyed by bundle ID,
+// in component name order. Products that are not bundles are absent from the map.
+func (r *ProductPostgresRepository) GetBundleComponents(ctx context.Context, productIDs []string) (map[string][]models.BundleComponent, error) {
+	start := time.Now()
+	query := `
+		SELECT bc.bundle_id, bc.component_id, p.name, bc.quantity
+		FROM product_bundle_components bc
+		JOIN products p ON p.id = bc.component_id
+		WHERE bc.bundle_id = ANY($1::uuid[])
+		ORDER BY bc.bundle_id, p.name
+	`
+
+	components := make(map[string][]models.BundleComponent)
+	err := r.q.Do("products.bundle_components", func() error {
+		stmt, err := r.q.Prepare(ctx, r.reads.Reader(ctx), query)
+		if err != nil {
+			return fmt.Errorf("failed to get bundle components: %w", err)
+		}
+		rows, err := stmt.QueryContext(ctx, pq.Array(productIDs))
+		if err != nil {
+			return fmt.Errorf("failed to get bundle components: %w", err)
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			var bundleID string
+			var component models.BundleComponent
+			if err := rows.Scan(&bundleID, &component.ProductID, &component.Name, &component.Quantity); err != nil {
+				return fmt.Errorf("failed to scan bundle component: %w", err)
+			}
+			components[bundleID] = append(components[bundleID], component)
+		}
+		return rows.Err()
+	})
+	if err != nil {
+		metrics.RecordDBQuery("SELECT", "product_bundle_components", "error", time.Since(start))
+		return nil, err
+	}
+
+	metrics.RecordDBQuery("SELECT", "product_bundle_components", "success", time.Since(start))
+	return components, nil
+}
+
+// IsBundleComponent reports whether any bundle contains the product
+func (r *ProductPostgresRepository) IsBundleComponent(ctx context.Context, productID string) (bool, error) {
+	var exists bool
+	err := r.db.QueryRowContext(ctx,
+		`SELECT EXISTS(SELECT 1 FROM product_bundle_components WHERE component_id = $1)`,
+		productID).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("failed to check bundle components: %w", err)
+	}
+	return exists, nil
+}
+
+// enqueueProductEvent stores a product event in the outbox within tx, so it is
+// published by the outbox relay if and only if the change commits
+func enqueueProductEvent(ctx context.Context, tx *sql.Tx, eventType string, product *models.Product) error {
+	return outbox.Enqueue(ctx, tx, eventType, product.ID, models.NewProductEvent(eventType, product))
+}
+
+// productListFilter is the WHERE clause shared by the product list and count
+// queries. $1 = category ID, $2 = brand ID, $3 = tag IDs, $4/$5 = minimum and
+// maximum price (inclusive); NULL disables a filter.
+// A product matches the tag filter only when it carries every requested tag.
+const productListFilter = `
+		($1::uuid IS NULL OR p.category_id = $1::uuid)
+		AND ($2::uuid IS NULL OR p.brand_id = $2::uuid)
+		AND ($3::uuid[] IS NULL OR p.id IN (
+			SELECT pt.product_id FROM product_tags pt
+			WHERE pt.tag_id = ANY($3::uuid[])
+			GROUP BY pt.product_id
+			HAVING COUNT(DISTINCT pt.tag_id) = cardinality($3::uuid[])
+		))
+		AND ($4::numeric IS NULL OR p.price >= $4::numeric)
+		AND ($5::numeric IS NULL OR p.price <= $5::numeric)
+`
+
+// listFilterArgs converts the list filters into SQL parameters for productListFilter
+func listFilterArgs(req *models.ListProductsRequest) []interface{} {
+	// Convert empty filters to nil for proper SQL handling
+	var categoryIDParam, brandIDParam, tagIDsParam, minPriceParam, maxPriceParam interface{}
+	if req.CategoryID != "" {
 		categoryIDParam = req.CategoryID
 	}
+	if req.BrandID != "" {
+		brandIDParam = req.BrandID
+	}
+	if len(req.TagIDs) > 0 {
+		tagIDsParam = pq.Array(req.TagIDs)
+	}
+	if req.MinPrice > 0 {
+		minPriceParam = req.MinPrice
+	}
+	if req.MaxPrice > 0 {
+		maxPriceParam = req.MaxPrice
+	}
+	return []interface{}{categoryIDParam, brandIDParam, tagIDsParam, minPriceParam, maxPriceParam}
+}
+
+// productListOrders maps each sort option to its ORDER BY clause. Only these
+// clauses ever reach the query. p.id breaks ties so pages never overlap.
+var productListOrders = map[string]string{
+	models.ProductSortNewest:    "p.created_at DESC, p.id",
+	models.ProductSortPriceAsc:  "p.price ASC, p.id",
+	models.ProductSortPriceDesc: "p.price DESC, p.id",
+	models.ProductSortName:      "p.name ASC, p.id",
+}
+
+// listOrderBy returns the ORDER BY clause of sort, defaulting to newest first
+func listOrderBy(sort string) string {
+	if orderBy, ok := productListOrders[sort]; ok {
+		return orderBy
+	}
+	return productListOrders[models.ProductSortNewest]
+}
+
+// List retrieves a paginated list of products
+func (r *ProductPostgresRepository) List(ctx context.Context, req *models.ListProductsRequest) ([]models.Product, int64, error) {
+	filterArgs := listFilterArgs(req)
+	db := r.reads.Reader(ctx)
 
-	// Count total products
-	countQuery := `SELECT COUNT(*) FROM products WHERE ($1::uuid IS NULL OR category_id = $1::uuid)`
+	// Count total products matching the filters
+	countQuery := `SELECT COUNT(*) FROM products p WHERE` + productListFilter
 
 	var total int64
-	err := r.db.QueryRowContext(ctx, countQuery, categoryIDParam).Scan(&total)
+	err := r.q.Do("products.count", func() error {
+		stmt, err := r.q.Prepare(ctx, db, countQuery)
+		if err != nil {
+			return err
+		}
+		return stmt.QueryRowContext(ctx, filterArgs...).Scan(&total)
+	})
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to count products: %w", err)
 	}
@@ -266,17 +545,35 @@ func (r *ProductPostgresRepository) List(ctx context.Context, req *models.ListPr
 		       c.id, c.name, c.slug, c.created_at, c.updated_at
 		FROM products p
 		LEFT JOIN categories c ON p.category_id = c.id
-		WHERE ($1::uuid IS NULL OR p.category_id = $1::uuid)
-		ORDER BY p.created_at DESC
-		LIMIT $2 OFFSET $3
+		WHERE` + productListFilter + `
+		ORDER BY ` + listOrderBy(req.Sort) + `
+		LIMIT $6 OFFSET $7
 	`
 
-	rows, err := r.db.QueryContext(ctx, query, categoryIDParam, req.PageSize, offset)
+	var products []models.Product
+	err = r.q.Do("products.list", func() error {
+		stmt, err := r.q.Prepare(ctx, db, query)
+		if err != nil {
+			return fmt.Errorf("failed to list products: %w", err)
+		}
+		rows, err := stmt.QueryContext(ctx, append(filterArgs, req.PageSize, offset)...)
+		if err != nil {
+			return fmt.Errorf("failed to list products: %w", err)
+		}
+		defer rows.Close()
+
+		products, err = scanProductsWithCategory(rows)
+		return err
+	})
 	if err != nil {
-		return nil, 0, fmt.Errorf("failed to list products: %w", err)
+		return nil, 0, err
 	}
-	defer rows.Close()
 
+	return products, total, nil
+}
+
+// scanProductsWithCategory reads rows of the product list columns joined with their category
+func scanProductsWithCategory(rows *sql.Rows) ([]models.Product, error) {
 	var products []models.Product
 
 	for rows.Next() {
@@ -292,7 +589,7 @@ func (r *ProductPostgresRepository) List(ctx context.Context, req *models.ListPr
 			&categoryID, &categoryName, &categorySlug, &categoryCreatedAt, &categoryUpdatedAt,
 		)
 		if err != nil {
-			return nil, 0, fmt.Errorf("failed to scan product: %w", err)
+			return nil, fmt.Errorf("failed to scan product: %w", err)
 		}
 
 		// Populate category if exists
@@ -309,9 +606,149 @@ func (r *ProductPostgresRepository) List(ctx context.Context, req *models.ListPr
 	}
 
 	if err := rows.Err(); err != nil {
-		return nil, 0, fmt.Errorf("failed to iterate products: %w", err)
+		return nil, fmt.Errorf("failed to iterate products: %w", err)
+	}
+
+	return products, nil
+}
+
+// Stream calls fn with every product matching the list filters, batchSize at a time, ordered
+// by ID. Rows are read through a server-side cursor in one read-only transaction, so only one
+// batch is held in memory and the export sees a consistent snapshot. Cancelling ctx or an error
+// from fn stops the scan and closes the cursor.
+func (r *ProductPostgresRepository) Stream(ctx context.Context, req *models.ListProductsRequest, batchSize int, fn func([]models.Product) error) error {
+	start := time.Now()
+	result := "success"
+	defer func() {
+		metrics.RecordDBQuery("STREAM", "products", result, time.Since(start))
+	}()
+
+	tx, err := r.reads.Reader(ctx).BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		result = "error"
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	declare := `
+		DECLARE product_stream NO SCROLL CURSOR FOR
+		SELECT p.id, p.name, p.slug, p.description, p.price, p.category_id,
+		       p.image_url, p.is_active, p.created_at, p.updated_at,
+		       c.id, c.name, c.slug, c.created_at, c.updated_at
+		FROM products p
+		LEFT JOIN categories c ON p.category_id = c.id
+		WHERE` + productListFilter + `
+		ORDER BY p.id
+	`
+	if _, err := tx.ExecContext(ctx, declare, listFilterArgs(req)...); err != nil {
+		result = "error"
+		return fmt.Errorf("failed to open product cursor: %w", err)
+	}
+
+	fetch := fmt.Sprintf("FETCH FORWARD %d FROM product_stream", batchSize)
+	for {
+		if err := ctx.Err(); err != nil {
+			result = "cancelled"
+			return err
+		}
+
+		rows, err := tx.QueryContext(ctx, fetch)
+		if err != nil {
+			result = "error"
+			return fmt.Errorf("failed to fetch products: %w", err)
+		}
+		batch, err := scanProductsWithCategory(rows)
+		rows.Close()
+		if err != nil {
+			result = "error"
+			return err
+		}
+
+		if len(batch) == 0 {
+			return nil
+		}
+		if err := fn(batch); err != nil {
+			result = "error"
+			return err
+		}
+		if len(batch) < batchSize {
+			return nil
+		}
 	}
+}
+
+// ListIDs returns the IDs of every product matching the list filters, in list order
+func (r *ProductPostgresRepository) ListIDs(ctx context.Context, req *models.ListProductsRequest) ([]string, error) {
+	query := `SELECT p.id FROM products p WHERE` + productListFilter + `ORDER BY ` + listOrderBy(req.Sort)
 
+	var ids []string
+	err := r.q.Do("products.list_ids", func() error {
+		stmt, err := r.q.Prepare(ctx, r.reads.Reader(ctx), query)
+		if err != nil {
+			return fmt.Errorf("failed to list product IDs: %w", err)
+		}
+		rows, err := stmt.QueryContext(ctx, listFilterArgs(req)...)
+		if err != nil {
+			return fmt.Errorf("failed to list product IDs: %w", err)
+		}
+		defer rows.Close()
+
+		ids = ids[:0]
+		for rows.Next() {
+			var id string
+			if err := rows.Scan(&id); err != nil {
+				return fmt.Errorf("failed to scan product ID: %w", err)
+			}
+			ids = append(ids, id)
+		}
+		return rows.Err()
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// ListCreatedBetween returns up to limit active products matching the list filters that
+// were created in (after, until], newest first, and how many match in all. It reads the
+// primary: a lagging replica could miss products created just before until, and saved
+// searches never look back.
+func (r *ProductPostgresRepository) ListCreatedBetween(ctx context.Context, req *models.ListProductsRequest, after, until time.Time, limit int) ([]models.Product, int, error) {
+	query := `
+		SELECT p.id, p.name, p.slug, p.price, p.created_at, COUNT(*) OVER ()
+		FROM products p
+		WHERE` + productListFilter + `
+			AND p.is_active = true AND p.created_at > $6 AND p.created_at <= $7
+		ORDER BY p.created_at DESC, p.id
+		LIMIT $8
+	`
+
+	var products []models.Product
+	var total int
+	err := r.q.Do("products.list_created_between", func() error {
+		stmt, err := r.q.Prepare(ctx, r.db, query)
+		if err != nil {
+			return err
+		}
+		rows, err := stmt.QueryContext(ctx, append(listFilterArgs(req), after, until, limit)...)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		products, total = products[:0], 0
+		for rows.Next() {
+			var product models.Product
+			if err := rows.Scan(&product.ID, &product.Name, &product.Slug, &product.Price, &product.CreatedAt, &total); err != nil {
+				return err
+			}
+			products = append(products, product)
+		}
+		return rows.Err()
+	})
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list new products: %w", err)
+	}
 	return products, total, nil
 }
 
@@ -341,6 +778,31 @@ func (r *ProductPostgresRepository) ExistsByName(ctx context.Context, name strin
 	return exists, nil
 }
 
+// IDByName returns the ID of the product with the given name, or "" if there is none
+func (r *ProductPostgresRepository) IDByName(ctx context.Context, name string) (string, error) {
+	return idByField(ctx, r.db, "products", "name", name)
+}
+
+// ExistsBySlug checks if a product exists by slug
+func (r *ProductPostgresRepository) ExistsBySlug(ctx context.Context, slug string, excludeID ...string) (bool, error) {
+	query := `SELECT EXISTS(SELECT 1 FROM products WHERE slug = $1`
+	args := []interface{}{slug}
+
+	if len(excludeID) > 0 && excludeID[0] != "" {
+		query += ` AND id != $2`
+		args = append(args, excludeID[0])
+	}
+	query += `)`
+
+	var exists bool
+	err := r.db.QueryRowContext(ctx, query, args...).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("failed to check product slug: %w", err)
+	}
+
+	return exists, nil
+}
+
 // CountByCategory counts products in a category
 func (r *ProductPostgresRepository) CountByCategory(ctx context.Context, categoryID string) (int64, error) {
 	query := `SELECT COUNT(*) FROM products WHERE category_id = $1`
@@ -354,12 +816,52 @@ func (r *ProductPostgresRepository) CountByCategory(ctx context.Context, categor
 	return count, nil
 }
 
+// SuggestNames returns names of active products starting with prefix (case-insensitive),
+// shortest first. prefix must already be lower-cased.
+func (r *ProductPostgresRepository) SuggestNames(ctx context.Context, prefix string, limit int) ([]string, error) {
+	// Matches the idx_products_name_prefix expression index
+	query := `
+		SELECT name FROM products
+		WHERE is_active = true AND lower(name) LIKE $1 ESCAPE '\'
+		ORDER BY length(name), name
+		LIMIT $2
+	`
+
+	rows, err := r.reads.Reader(ctx).QueryContext(ctx, query, escapeLikePattern(prefix)+"%", limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to suggest product names: %w", err)
+	}
+	defer rows.Close()
+
+	names := make([]string, 0, limit)
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, fmt.Errorf("failed to scan product name: %w", err)
+		}
+		names = append(names, name)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate product names: %w", err)
+	}
+
+	return names, nil
+}
+
+// escapeLikePattern escapes LIKE wildcards so user input matches literally
+func escapeLikePattern(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
+
 // =================== CATEGORY REPOSITORY IMPLEMENTATION ===================
 
 // Create creates a new category in the database
 func (r *CategoryPostgresRepository) Create(ctx context.Context, category *models.Category) error {
 	category.ID = uuid.New().String()
-	category.GenerateSlug()
+	if category.Slug == "" {
+		category.GenerateSlug()
+	}
 	now := time.Now()
 	category.CreatedAt = now
 	category.UpdatedAt = now
@@ -377,11 +879,8 @@ func (r *CategoryPostgresRepository) Create(ctx context.Context, category *model
 		if pqErr, ok := err.(*pq.Error); ok {
 			switch pqErr.Code {
 			case "23505": // unique violation
-				if strings.Contains(pqErr.Message, "name") {
-					return fmt.Errorf("category with name already exists")
-				}
-				if strings.Contains(pqErr.Message, "slug") {
-					return fmt.Errorf("category with slug already exists")
+				if err := uniqueViolation(ctx, r.db, "category", "categories", pqErr, category.Name, category.Slug); err != nil {
+					return err
 				}
 				return fmt.Errorf("category already exists")
 			}
@@ -434,7 +933,9 @@ func (r *CategoryPostgresRepository) GetBySlug(ctx context.Context, slug string)
 
 // Update updates an existing category
 func (r *CategoryPostgresRepository) Update(ctx context.Context, category *models.Category) error {
-	category.GenerateSlug()
+	if category.Slug == "" {
+		category.GenerateSlug()
+	}
 	category.UpdatedAt = time.Now()
 
 	query := `
@@ -451,8 +952,8 @@ func (r *CategoryPostgresRepository) Update(ctx context.Context, category *model
 		if pqErr, ok := err.(*pq.Error); ok {
 			switch pqErr.Code {
 			case "23505": // unique violation
-				if strings.Contains(pqErr.Message, "name") {
-					return fmt.Errorf("category with name already exists")
+				if err := uniqueViolation(ctx, r.db, "category", "categories", pqErr, category.Name, category.Slug); err != nil {
+					return err
 				}
 				return fmt.Errorf("category with slug already exists")
 			}
@@ -560,6 +1061,31 @@ func (r *CategoryPostgresRepository) ExistsByName(ctx context.Context, name stri
 	return exists, nil
 }
 
+// IDByName returns the ID of the category with the given name, or "" if there is none
+func (r *CategoryPostgresRepository) IDByName(ctx context.Context, name string) (string, error) {
+	return idByField(ctx, r.db, "categories", "name", name)
+}
+
+// ExistsBySlug checks if a category exists by slug
+func (r *CategoryPostgresRepository) ExistsBySlug(ctx context.Context, slug string, excludeID ...string) (bool, error) {
+	query := `SELECT EXISTS(SELECT 1 FROM categories WHERE slug = $1`
+	args := []interface{}{slug}
+
+	if len(excludeID) > 0 && excludeID[0] != "" {
+		query += ` AND id != $2`
+		args = append(args, excludeID[0])
+	}
+	query += `)`
+
+	var exists bool
+	err := r.db.QueryRowContext(ctx, query, args...).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("failed to check category slug: %w", err)
+	}
+
+	return exists, nil
+}
+
 // ExistsByID checks if a category exists by ID
 func (r *CategoryPostgresRepository) ExistsByID(ctx context.Context, id string) (bool, error) {
 	query := `SELECT EXISTS(SELECT 1 FROM categories WHERE id = $1)`
@@ -572,3 +1098,43 @@ func (r *CategoryPostgresRepository) ExistsByID(ctx context.Context, id string)
 
 	return exists, nil
 }
+
+// =================== UNIQUE VIOLATIONS ===================
+
+// uniqueViolation translates a unique violation on the name or slug of table into a
+// models.AlreadyExistsError carrying the ID of the row that holds the value. It returns
+// nil for other constraints. The lookup runs on db, outside the aborted transaction;
+// if it fails the error is returned without the ID.
+func uniqueViolation(ctx context.Context, db *sql.DB, entity, table string, pqErr *pq.Error, name, slug string) error {
+	var field, value string
+	switch {
+	case strings.Contains(pqErr.Constraint, "name"):
+		field, value = "name", name
+	case strings.Contains(pqErr.Constraint, "slug"):
+		field, value = "slug", slug
+	default:
+		return nil
+	}
+
+	existingID, err := idByField(ctx, db, table, field, value)
+	if err != nil {
+		log.Printf("WARNING: failed to look up the %s holding %s %q: %v", entity, field, value, err)
+	}
+	return &models.AlreadyExistsError{Entity: entity, Field: field, Value: value, ExistingID: existingID}
+}
+
+// idByField returns the ID of the row of table whose field equals value, or "" if there
+// is none. table and field are never user input.
+func idByField(ctx context.Context, db *sql.DB, table, field, value string) (string, error) {
+	query := fmt.Sprintf(`SELECT id FROM %s WHERE %s = $1`, table, field)
+
+	var id string
+	err := db.QueryRowContext(ctx, query, value).Scan(&id)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to look up %s by %s: %w", table, field, err)
+	}
+	return id, nil
+}
@@ -23,9 +23,16 @@ func NewPostgresRepository(opts *RepositoryOptions) (*Repository, error) {
 		return nil, fmt.Errorf("failed to ping database: %w", err)
 	}
 
+	reads := opts.ReadRouter
+	if reads == nil {
+		reads = NewReplicaRouter(db, nil)
+	}
+
 	return &Repository{
-		Product:  NewProductRepository(db),
-		Category: NewCategoryRepository(db),
+		Product:     NewProductRepository(db, reads),
+		Category:    NewCategoryRepository(db),
+		Interaction: NewInteractionRepository(db, reads),
+		SavedSearch: NewSavedSearchRepository(db),
 	}, nil
 }
 
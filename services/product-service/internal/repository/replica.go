@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"log"
+	"sync/atomic"
+	"time"
+)
+
+// ReplicaCheckInterval is how often the read replica is pinged to decide whether reads can use it
+const ReplicaCheckInterval = 5 * time.Second
+
+type primaryKey struct{}
+
+// WithPrimary marks ctx so product reads made with it go to the primary. Use it for
+// reads that must see the caller's own writes, such as read-modify-write updates.
+func WithPrimary(ctx context.Context) context.Context {
+	return context.WithValue(ctx, primaryKey{}, true)
+}
+
+func usePrimary(ctx context.Context) bool {
+	forced, _ := ctx.Value(primaryKey{}).(bool)
+	return forced
+}
+
+// ReplicaRouter picks the connection pool for read-only queries. Reads go to the
+// replica while it answers pings and to the primary otherwise; writes always use the
+// primary. The replica lags behind the primary, so a read right after a write may
+// not see it unless the context is marked with WithPrimary.
+type ReplicaRouter struct {
+	primary   *sql.DB
+	replica   *sql.DB
+	replicaUp atomic.Bool
+}
+
+// NewReplicaRouter creates a router. With a nil replica every read uses the primary.
+func NewReplicaRouter(primary, replica *sql.DB) *ReplicaRouter {
+	r := &ReplicaRouter{primary: primary, replica: replica}
+	r.replicaUp.Store(replica != nil)
+	return r
+}
+
+// Reader returns the pool a read-only query should use
+func (r *ReplicaRouter) Reader(ctx context.Context) *sql.DB {
+	if !r.replicaUp.Load() || usePrimary(ctx) {
+		return r.primary
+	}
+	return r.replica
+}
+
+// Watch pings the replica every interval until ctx is done, sending reads to the
+// primary while the replica is down
+func (r *ReplicaRouter) Watch(ctx context.Context, interval time.Duration) {
+	if r.replica == nil {
+		return
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			pingCtx, cancel := context.WithTimeout(ctx, interval)
+			err := r.replica.PingContext(pingCtx)
+			cancel()
+
+			up := err == nil
+			if r.replicaUp.Swap(up) != up {
+				if up {
+					log.Println("Read replica is back, routing reads to it")
+				} else {
+					log.Printf("Read replica unavailable, routing reads to the primary: %v", err)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,190 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+
+	"github.com/datngth03/ecommerce-go-app/services/product-service/internal/models"
+)
+
+// SavedSearchPostgresRepository implements SavedSearchRepository for PostgreSQL.
+// Every query uses the primary: the matcher must see the last_run_at it just wrote.
+type SavedSearchPostgresRepository struct {
+	db *sql.DB
+}
+
+// NewSavedSearchRepository creates a new PostgreSQL saved search repository
+func NewSavedSearchRepository(db *sql.DB) SavedSearchRepository {
+	return &SavedSearchPostgresRepository{db: db}
+}
+
+const savedSearchColumns = `id, user_id, name, category_id, brand_id, tag_ids, min_price, max_price, last_run_at, created_at`
+
+// ErrSavedSearchLimitReached is returned by Create when the user already has the maximum number of saved searches
+var ErrSavedSearchLimitReached = errors.New("saved search limit reached")
+
+// ErrSavedSearchNotFound is returned by Delete when the user has no saved search with the ID
+var ErrSavedSearchNotFound = errors.New("saved search not found")
+
+// Create stores a saved search unless the user already has limit of them; its first run
+// reports products created from now on. The user's searches are counted under a
+// transaction-scoped advisory lock on the user, so concurrent saves cannot both pass
+// the limit.
+func (r *SavedSearchPostgresRepository) Create(ctx context.Context, search *models.SavedSearch, limit int) error {
+	query := `
+		INSERT INTO saved_searches (user_id, name, category_id, brand_id, tag_ids, min_price, max_price)
+		SELECT $1, $2, $3, $4, $5, $6, $7
+		WHERE (SELECT COUNT(*) FROM saved_searches WHERE user_id = $1) < $8
+		RETURNING id, last_run_at, created_at
+	`
+
+	tagIDs := search.TagIDs
+	if tagIDs == nil {
+		tagIDs = []string{}
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `SELECT pg_advisory_xact_lock(hashtext('saved_searches:' || $1::text))`, search.UserID); err != nil {
+		return fmt.Errorf("failed to lock saved searches of user: %w", err)
+	}
+
+	err = tx.QueryRowContext(ctx, query,
+		search.UserID, search.Name, nullIfEmpty(search.CategoryID), nullIfEmpty(search.BrandID),
+		pq.Array(tagIDs), nullIfZero(search.MinPrice), nullIfZero(search.MaxPrice), limit,
+	).Scan(&search.ID, &search.LastRunAt, &search.CreatedAt)
+	if err == sql.ErrNoRows {
+		return ErrSavedSearchLimitReached
+	}
+	if err != nil {
+		return fmt.Errorf("failed to create saved search: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit saved search: %w", err)
+	}
+	return nil
+}
+
+// ListByUser returns the saved searches of a user, newest first
+func (r *SavedSearchPostgresRepository) ListByUser(ctx context.Context, userID int64) ([]models.SavedSearch, error) {
+	query := `SELECT ` + savedSearchColumns + ` FROM saved_searches WHERE user_id = $1 ORDER BY created_at DESC, id`
+
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list saved searches: %w", err)
+	}
+	defer rows.Close()
+
+	return scanSavedSearches(rows)
+}
+
+// Delete removes a saved search of a user. Searches of other users are not found.
+func (r *SavedSearchPostgresRepository) Delete(ctx context.Context, id string, userID int64) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM saved_searches WHERE id = $1 AND user_id = $2`, id, userID)
+	if err != nil {
+		return fmt.Errorf("failed to delete saved search: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return ErrSavedSearchNotFound
+	}
+	return nil
+}
+
+// ClaimDue claims up to limit searches last run at least interval ago, oldest first,
+// for lease. Claimed searches are skipped by other matchers until MarkRun or the lease
+// runs out, so a matcher that dies mid-run only delays its searches. It also returns
+// the database time of the claim, so runs do not depend on the matcher's clock.
+func (r *SavedSearchPostgresRepository) ClaimDue(ctx context.Context, interval, lease time.Duration, limit int) ([]models.SavedSearch, time.Time, error) {
+	query := `
+		UPDATE saved_searches SET claimed_until = NOW() + $2 * INTERVAL '1 millisecond'
+		WHERE id IN (
+			SELECT id FROM saved_searches
+			WHERE last_run_at <= NOW() - $1 * INTERVAL '1 millisecond'
+				AND (claimed_until IS NULL OR claimed_until < NOW())
+			ORDER BY last_run_at
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + savedSearchColumns + `, NOW()`
+
+	rows, err := r.db.QueryContext(ctx, query, interval.Milliseconds(), lease.Milliseconds(), limit)
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("failed to claim due saved searches: %w", err)
+	}
+	defer rows.Close()
+
+	// NOW() is the transaction start time, the same on every row
+	var claimedAt time.Time
+	searches, err := scanSavedSearches(rows, &claimedAt)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	return searches, claimedAt, nil
+}
+
+// MarkRun records that a search reported every match created up to runAt and releases its claim
+func (r *SavedSearchPostgresRepository) MarkRun(ctx context.Context, id string, runAt time.Time) error {
+	_, err := r.db.ExecContext(ctx,
+		`UPDATE saved_searches SET last_run_at = $2, claimed_until = NULL WHERE id = $1`, id, runAt)
+	if err != nil {
+		return fmt.Errorf("failed to mark saved search run: %w", err)
+	}
+	return nil
+}
+
+// scanSavedSearches scans rows of savedSearchColumns followed by the extra columns,
+// which are scanned into the same destinations on every row
+func scanSavedSearches(rows *sql.Rows, extra ...interface{}) ([]models.SavedSearch, error) {
+	searches := []models.SavedSearch{}
+	for rows.Next() {
+		var search models.SavedSearch
+		var categoryID, brandID sql.NullString
+		var minPrice, maxPrice sql.NullFloat64
+		dest := []interface{}{
+			&search.ID, &search.UserID, &search.Name, &categoryID, &brandID,
+			pq.Array(&search.TagIDs), &minPrice, &maxPrice, &search.LastRunAt, &search.CreatedAt,
+		}
+		if err := rows.Scan(append(dest, extra...)...); err != nil {
+			return nil, fmt.Errorf("failed to scan saved search: %w", err)
+		}
+		search.CategoryID = categoryID.String
+		search.BrandID = brandID.String
+		search.MinPrice = minPrice.Float64
+		search.MaxPrice = maxPrice.Float64
+		searches = append(searches, search)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate saved searches: %w", err)
+	}
+	return searches, nil
+}
+
+func nullIfEmpty(s string) interface{} {
+	if s == "" {
+		return nil
+	}
+	return s
+}
+
+func nullIfZero(f float64) interface{} {
+	if f == 0 {
+		return nil
+	}
+	return f
+}
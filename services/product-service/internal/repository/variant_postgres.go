@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+
+	"github.com/datngth03/ecommerce-go-app/services/product-service/internal/metrics"
+	"github.com/datngth03/ecommerce-go-app/services/product-service/internal/models"
+)
+
+// replaceVariants sets the variants of a product within tx, in the given order.
+// Variants whose SKU is kept are updated in place so their ID, which inventory
+// keys their stock on, does not change; the others are deleted. The IDs are set
+// on variants.
+func (r *ProductPostgresRepository) replaceVariants(ctx context.Context, tx *sql.Tx, productID string, variants []models.ProductVariant) error {
+	skus := make([]string, len(variants))
+	for i, variant := range variants {
+		skus[i] = variant.SKU
+	}
+	if _, err := tx.ExecContext(ctx,
+		`DELETE FROM product_variants WHERE product_id = $1 AND NOT (sku = ANY($2))`,
+		productID, pq.Array(skus)); err != nil {
+		return fmt.Errorf("failed to update product variants: %w", err)
+	}
+
+	// The WHERE clause leaves a SKU of another product untouched, so no row is returned
+	query := `
+		INSERT INTO product_variants (product_id, sku, attributes, price, position)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (sku) DO UPDATE
+		SET attributes = EXCLUDED.attributes, price = EXCLUDED.price, position = EXCLUDED.position
+		WHERE product_variants.product_id = EXCLUDED.product_id
+		RETURNING id
+	`
+	for i := range variants {
+		attributes, err := json.Marshal(variants[i].Attributes)
+		if err != nil {
+			return fmt.Errorf("failed to encode variant attributes: %w", err)
+		}
+		price := sql.NullFloat64{Float64: variants[i].Price, Valid: variants[i].Price > 0}
+
+		err = tx.QueryRowContext(ctx, query, productID, variants[i].SKU, attributes, price, i).Scan(&variants[i].ID)
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("invalid variants: SKU %s is used by another product", variants[i].SKU)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to update product variants: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// GetVariants returns the variants of the given products keyed by product ID, in
+// display order. Products without variants are absent from the map.
+func (r *ProductPostgresRepository) GetVariants(ctx context.Context, productIDs []string) (map[string][]models.ProductVariant, error) {
+	start := time.Now()
+	query := `
+		SELECT product_id, id, sku, attributes, price
+		FROM product_variants
+		WHERE product_id = ANY($1::uuid[])
+		ORDER BY product_id, position
+	`
+
+	variants := make(map[string][]models.ProductVariant)
+	err := r.q.Do("products.variants", func() error {
+		stmt, err := r.q.Prepare(ctx, r.reads.Reader(ctx), query)
+		if err != nil {
+			return fmt.Errorf("failed to get product variants: %w", err)
+		}
+		rows, err := stmt.QueryContext(ctx, pq.Array(productIDs))
+		if err != nil {
+			return fmt.Errorf("failed to get product variants: %w", err)
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			var productID string
+			var variant models.ProductVariant
+			var attributes []byte
+			var price sql.NullFloat64
+			if err := rows.Scan(&productID, &variant.ID, &variant.SKU, &attributes, &price); err != nil {
+				return fmt.Errorf("failed to scan product variant: %w", err)
+			}
+			if err := json.Unmarshal(attributes, &variant.Attributes); err != nil {
+				return fmt.Errorf("failed to decode variant attributes: %w", err)
+			}
+			variant.Price = price.Float64
+			variants[productID] = append(variants[productID], variant)
+		}
+		return rows.Err()
+	})
+	if err != nil {
+		metrics.RecordDBQuery("SELECT", "product_variants", "error", time.Since(start))
+		return nil, err
+	}
+
+	metrics.RecordDBQuery("SELECT", "product_variants", "success", time.Since(start))
+	return variants, nil
+}
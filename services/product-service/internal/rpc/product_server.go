@@ -2,14 +2,20 @@ package rpc
 
 import (
 	"context"
+	"errors"
 	"strings"
 	"time"
 
 	pb "github.com/datngth03/ecommerce-go-app/proto/product_service"
+	"github.com/datngth03/ecommerce-go-app/services/product-service/internal/currency"
 	"github.com/datngth03/ecommerce-go-app/services/product-service/internal/metrics"
 	"github.com/datngth03/ecommerce-go-app/services/product-service/internal/models"
 	"github.com/datngth03/ecommerce-go-app/services/product-service/internal/service"
+	"github.com/datngth03/ecommerce-go-app/shared/pkg/domainerr"
+	"github.com/datngth03/ecommerce-go-app/shared/pkg/validator"
 
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
@@ -19,8 +25,9 @@ import (
 // ProductGRPCServer implements pb.ProductServiceServer
 type ProductGRPCServer struct {
 	pb.UnimplementedProductServiceServer
-	productService  *service.ProductService
-	categoryService *service.CategoryService
+	productService     *service.ProductService
+	categoryService    *service.CategoryService
+	savedSearchService *service.SavedSearchService
 }
 
 // CategoryGRPCServer implements pb.CategoryServiceServer
@@ -30,10 +37,11 @@ type CategoryGRPCServer struct {
 }
 
 // NewProductGRPCServer creates a new gRPC server for products
-func NewProductGRPCServer(productService *service.ProductService, categoryService *service.CategoryService) *ProductGRPCServer {
+func NewProductGRPCServer(productService *service.ProductService, categoryService *service.CategoryService, savedSearchService *service.SavedSearchService) *ProductGRPCServer {
 	return &ProductGRPCServer{
-		productService:  productService,
-		categoryService: categoryService,
+		productService:     productService,
+		categoryService:    categoryService,
+		savedSearchService: savedSearchService,
 	}
 }
 
@@ -55,6 +63,9 @@ func (s *ProductGRPCServer) CreateProduct(ctx context.Context, req *pb.CreatePro
 		Price:       req.Price,
 		CategoryID:  req.CategoryId,
 		ImageURL:    req.ImageUrl,
+		Slug:        req.Slug,
+		Components:  bundleComponentsFromProto(req.Components),
+		Variants:    productVariantsFromProto(req.Variants),
 	}
 
 	product, err := s.productService.CreateProduct(ctx, createReq)
@@ -63,6 +74,13 @@ func (s *ProductGRPCServer) CreateProduct(ctx context.Context, req *pb.CreatePro
 	if err != nil {
 		metricStatus = "error"
 		metrics.RecordGRPCRequest("CreateProduct", metricStatus, time.Since(start))
+		if strings.Contains(err.Error(), "invalid slug") || strings.Contains(err.Error(), "invalid bundle") ||
+			strings.Contains(err.Error(), "invalid variants") || strings.Contains(err.Error(), "invalid image URL") {
+			return nil, status.Errorf(codes.InvalidArgument, "%s", err.Error())
+		}
+		if st, ok := alreadyExistsStatus(err); ok {
+			return nil, st.Err()
+		}
 		if strings.Contains(err.Error(), "already exists") {
 			return nil, status.Errorf(codes.AlreadyExists, "%s", err.Error())
 		}
@@ -79,9 +97,16 @@ func (s *ProductGRPCServer) CreateProduct(ctx context.Context, req *pb.CreatePro
 }
 
 func (s *ProductGRPCServer) GetProduct(ctx context.Context, req *pb.GetProductRequest) (*pb.GetProductResponse, error) {
+	if err := validator.ValidateUUIDArg(req.Id, "id"); err != nil {
+		return nil, err
+	}
+
 	start := time.Now()
 
-	product, err := s.productService.GetProduct(ctx, req.Id)
+	product, err := s.productService.GetProduct(ctx, req.Id, models.GetProductOptions{
+		IncludeAvailability: req.IncludeAvailability,
+		Currency:            req.Currency,
+	})
 
 	metricStatus := "success"
 	if err != nil {
@@ -90,6 +115,9 @@ func (s *ProductGRPCServer) GetProduct(ctx context.Context, req *pb.GetProductRe
 		if strings.Contains(err.Error(), "not found") {
 			return nil, status.Errorf(codes.NotFound, "product with id %s not found", req.Id)
 		}
+		if st, ok := currencyErrorStatus(err); ok {
+			return nil, st.Err()
+		}
 		return nil, status.Error(codes.Internal, "failed to get product")
 	}
 
@@ -97,7 +125,45 @@ func (s *ProductGRPCServer) GetProduct(ctx context.Context, req *pb.GetProductRe
 	return &pb.GetProductResponse{Product: productResponseToProto(product)}, nil
 }
 
+// GetProductsByIds returns several products in one call; unknown IDs are listed in not_found_ids
+func (s *ProductGRPCServer) GetProductsByIds(ctx context.Context, req *pb.GetProductsByIdsRequest) (*pb.GetProductsByIdsResponse, error) {
+	for _, id := range req.Ids {
+		if err := validator.ValidateUUIDArg(strings.TrimSpace(id), "ids"); err != nil {
+			return nil, err
+		}
+	}
+
+	start := time.Now()
+
+	products, notFound, err := s.productService.GetProductsByIDs(ctx, req.Ids)
+
+	metricStatus := "success"
+	if err != nil {
+		metricStatus = "error"
+		metrics.RecordGRPCRequest("GetProductsByIds", metricStatus, time.Since(start))
+		if strings.Contains(err.Error(), "required") || strings.Contains(err.Error(), "must be") {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return nil, status.Error(codes.Internal, "failed to get products")
+	}
+
+	metrics.RecordGRPCRequest("GetProductsByIds", metricStatus, time.Since(start))
+
+	resp := &pb.GetProductsByIdsResponse{
+		Products:    make([]*pb.Product, len(products)),
+		NotFoundIds: notFound,
+	}
+	for i := range products {
+		resp.Products[i] = productResponseToProto(&products[i])
+	}
+	return resp, nil
+}
+
 func (s *ProductGRPCServer) UpdateProduct(ctx context.Context, req *pb.UpdateProductRequest) (*pb.UpdateProductResponse, error) {
+	if err := validator.ValidateUUIDArg(req.Id, "id"); err != nil {
+		return nil, err
+	}
+
 	updateReq := &models.UpdateProductRequest{
 		Name:        req.Name,
 		Description: req.Description,
@@ -105,13 +171,27 @@ func (s *ProductGRPCServer) UpdateProduct(ctx context.Context, req *pb.UpdatePro
 		CategoryID:  req.CategoryId,
 		ImageURL:    req.ImageUrl,
 		IsActive:    req.IsActive,
+		Slug:        req.Slug,
+
+		Components:      bundleComponentsFromProto(req.Components),
+		ClearComponents: req.ClearComponents,
+
+		Variants:      productVariantsFromProto(req.Variants),
+		ClearVariants: req.ClearVariants,
 	}
 
 	product, err := s.productService.UpdateProduct(ctx, req.Id, updateReq)
 	if err != nil {
+		if strings.Contains(err.Error(), "invalid slug") || strings.Contains(err.Error(), "invalid bundle") ||
+			strings.Contains(err.Error(), "invalid variants") || strings.Contains(err.Error(), "invalid image URL") {
+			return nil, status.Errorf(codes.InvalidArgument, "%s", err.Error())
+		}
 		if strings.Contains(err.Error(), "not found") {
 			return nil, status.Errorf(codes.NotFound, "%s", err.Error())
 		}
+		if st, ok := alreadyExistsStatus(err); ok {
+			return nil, st.Err()
+		}
 		if strings.Contains(err.Error(), "already exists") {
 			return nil, status.Errorf(codes.AlreadyExists, "%s", err.Error())
 		}
@@ -124,10 +204,17 @@ func (s *ProductGRPCServer) UpdateProduct(ctx context.Context, req *pb.UpdatePro
 }
 
 func (s *ProductGRPCServer) DeleteProduct(ctx context.Context, req *pb.DeleteProductRequest) (*emptypb.Empty, error) {
+	if err := validator.ValidateUUIDArg(req.Id, "id"); err != nil {
+		return nil, err
+	}
+
 	if err := s.productService.DeleteProduct(ctx, req.Id); err != nil {
 		if strings.Contains(err.Error(), "not found") {
 			return nil, status.Errorf(codes.NotFound, "%s", err.Error())
 		}
+		if strings.Contains(err.Error(), "component of a bundle") {
+			return nil, status.Errorf(codes.FailedPrecondition, "%s", err.Error())
+		}
 		return nil, status.Errorf(codes.Internal, "failed to delete product: %v", err)
 	}
 	return &emptypb.Empty{}, nil
@@ -136,28 +223,228 @@ func (s *ProductGRPCServer) DeleteProduct(ctx context.Context, req *pb.DeletePro
 // ListProducts được triển khai đầy đủ vì các service khác (ví dụ: Search) có thể cần nó.
 func (s *ProductGRPCServer) ListProducts(ctx context.Context, req *pb.ListProductsRequest) (*pb.ListProductsResponse, error) {
 	serviceReq := &models.ListProductsRequest{
-		Page:       int(req.Page),
-		PageSize:   int(req.PageSize),
-		CategoryID: req.CategoryId,
+		Page:                int(req.Page),
+		PageSize:            int(req.PageSize),
+		CategoryID:          req.CategoryId,
+		BrandID:             req.BrandId,
+		TagIDs:              req.TagIds,
+		IncludeAvailability: req.IncludeAvailability,
+		Currency:            req.Currency,
+		Sort:                req.Sort,
+		MinPrice:            req.MinPrice,
+		MaxPrice:            req.MaxPrice,
+		InStockOnly:         req.InStockOnly,
 	}
 
 	listResponse, err := s.productService.ListProducts(ctx, serviceReq)
 	if err != nil {
+		if st, ok := currencyErrorStatus(err); ok {
+			return nil, st.Err()
+		}
+		if strings.Contains(err.Error(), "invalid sort") || strings.Contains(err.Error(), "invalid price range") {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
 		return nil, status.Error(codes.Internal, "failed to list products")
 	}
 
 	return listProductsResponseToProto(listResponse), nil
 }
 
+// Autocomplete trả về gợi ý tên sản phẩm theo tiền tố; rỗng thay vì lỗi khi tra cứu quá chậm.
+func (s *ProductGRPCServer) Autocomplete(ctx context.Context, req *pb.AutocompleteRequest) (*pb.AutocompleteResponse, error) {
+	suggestions, err := s.productService.Autocomplete(ctx, req.Prefix, int(req.Limit))
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	return &pb.AutocompleteResponse{Suggestions: suggestions}, nil
+}
+
+// StreamProducts gửi toàn bộ sản phẩm khớp bộ lọc theo từng lô; dừng quét DB khi client hủy.
+func (s *ProductGRPCServer) StreamProducts(req *pb.StreamProductsRequest, stream grpc.ServerStreamingServer[pb.StreamProductsResponse]) error {
+	ctx := stream.Context()
+	streamReq := &models.StreamProductsRequest{
+		CategoryID: req.CategoryId,
+		BrandID:    req.BrandId,
+		TagIDs:     req.TagIds,
+		BatchSize:  int(req.BatchSize),
+	}
+
+	err := s.productService.StreamProducts(ctx, streamReq, func(products []models.ProductResponse) error {
+		resp := &pb.StreamProductsResponse{Products: make([]*pb.Product, len(products))}
+		for i := range products {
+			resp.Products[i] = productResponseToProto(&products[i])
+		}
+		return stream.Send(resp)
+	})
+	if err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return status.FromContextError(ctxErr).Err()
+		}
+		if strings.Contains(err.Error(), "not found") {
+			return status.Errorf(codes.NotFound, "%s", err.Error())
+		}
+		return status.Error(codes.Internal, "failed to stream products")
+	}
+
+	return nil
+}
+
+// RecordProductInteractions ghi nhận lượt xem, thêm vào giỏ và mua hàng
+func (s *ProductGRPCServer) RecordProductInteractions(ctx context.Context, req *pb.RecordProductInteractionsRequest) (*emptypb.Empty, error) {
+	start := time.Now()
+
+	interactions := make([]models.ProductInteraction, len(req.Interactions))
+	for i, interaction := range req.Interactions {
+		interactions[i] = models.ProductInteraction{
+			ProductID: interaction.ProductId,
+			Type:      interaction.Type,
+			Quantity:  interaction.Quantity,
+			UserID:    interaction.UserId,
+		}
+	}
+
+	err := s.productService.RecordInteractions(ctx, interactions)
+
+	metricStatus := "success"
+	if err != nil {
+		metricStatus = "error"
+		metrics.RecordGRPCRequest("RecordProductInteractions", metricStatus, time.Since(start))
+		if domainerr.Is(err, domainerr.KindInvalidArgument) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return nil, status.Error(codes.Internal, "failed to record product interactions")
+	}
+
+	metrics.RecordGRPCRequest("RecordProductInteractions", metricStatus, time.Since(start))
+	return &emptypb.Empty{}, nil
+}
+
+// GetProductStats trả về số lượt xem, thêm vào giỏ và mua của một sản phẩm
+func (s *ProductGRPCServer) GetProductStats(ctx context.Context, req *pb.GetProductStatsRequest) (*pb.GetProductStatsResponse, error) {
+	start := time.Now()
+
+	var within models.StatsRange
+	if req.From != nil {
+		from := req.From.AsTime()
+		within.From = &from
+	}
+	if req.To != nil {
+		to := req.To.AsTime()
+		within.To = &to
+	}
+
+	stats, err := s.productService.GetProductStats(ctx, req.ProductId, within)
+
+	metricStatus := "success"
+	if err != nil {
+		metricStatus = "error"
+		metrics.RecordGRPCRequest("GetProductStats", metricStatus, time.Since(start))
+		switch domainerr.KindOf(err) {
+		case domainerr.KindInvalidArgument:
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		case domainerr.KindNotFound:
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		return nil, status.Error(codes.Internal, "failed to get product stats")
+	}
+
+	metrics.RecordGRPCRequest("GetProductStats", metricStatus, time.Since(start))
+	return &pb.GetProductStatsResponse{
+		ProductId:      stats.ProductID,
+		ViewCount:      stats.ViewCount,
+		AddToCartCount: stats.AddToCartCount,
+		PurchaseCount:  stats.PurchaseCount,
+	}, nil
+}
+
+// SaveSearch lưu bộ lọc sản phẩm của người dùng để báo sản phẩm mới khớp bộ lọc
+func (s *ProductGRPCServer) SaveSearch(ctx context.Context, req *pb.SaveSearchRequest) (*pb.SaveSearchResponse, error) {
+	start := time.Now()
+
+	search, err := s.savedSearchService.SaveSearch(ctx, &models.SaveSearchRequest{
+		UserID:     req.UserId,
+		Name:       req.Name,
+		CategoryID: req.CategoryId,
+		BrandID:    req.BrandId,
+		TagIDs:     req.TagIds,
+		MinPrice:   req.MinPrice,
+		MaxPrice:   req.MaxPrice,
+	})
+	if err != nil {
+		metrics.RecordGRPCRequest("SaveSearch", "error", time.Since(start))
+		switch domainerr.KindOf(err) {
+		case domainerr.KindInvalidArgument:
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		case domainerr.KindNotFound:
+			return nil, status.Error(codes.NotFound, err.Error())
+		case domainerr.KindConflict:
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+		return nil, status.Error(codes.Internal, "failed to save search")
+	}
+
+	metrics.RecordGRPCRequest("SaveSearch", "success", time.Since(start))
+	return &pb.SaveSearchResponse{SavedSearch: savedSearchToProto(search)}, nil
+}
+
+// ListSavedSearches trả về các bộ lọc đã lưu của người dùng
+func (s *ProductGRPCServer) ListSavedSearches(ctx context.Context, req *pb.ListSavedSearchesRequest) (*pb.ListSavedSearchesResponse, error) {
+	start := time.Now()
+
+	searches, err := s.savedSearchService.ListSavedSearches(ctx, req.UserId)
+	if err != nil {
+		metrics.RecordGRPCRequest("ListSavedSearches", "error", time.Since(start))
+		if domainerr.Is(err, domainerr.KindInvalidArgument) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return nil, status.Error(codes.Internal, "failed to list saved searches")
+	}
+
+	resp := &pb.ListSavedSearchesResponse{SavedSearches: make([]*pb.SavedSearch, len(searches))}
+	for i := range searches {
+		resp.SavedSearches[i] = savedSearchToProto(&searches[i])
+	}
+
+	metrics.RecordGRPCRequest("ListSavedSearches", "success", time.Since(start))
+	return resp, nil
+}
+
+// DeleteSavedSearch xóa một bộ lọc đã lưu của người dùng
+func (s *ProductGRPCServer) DeleteSavedSearch(ctx context.Context, req *pb.DeleteSavedSearchRequest) (*emptypb.Empty, error) {
+	start := time.Now()
+
+	if err := s.savedSearchService.DeleteSavedSearch(ctx, req.Id, req.UserId); err != nil {
+		metrics.RecordGRPCRequest("DeleteSavedSearch", "error", time.Since(start))
+		switch domainerr.KindOf(err) {
+		case domainerr.KindInvalidArgument:
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		case domainerr.KindNotFound:
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		return nil, status.Error(codes.Internal, "failed to delete saved search")
+	}
+
+	metrics.RecordGRPCRequest("DeleteSavedSearch", "success", time.Since(start))
+	return &emptypb.Empty{}, nil
+}
+
 // ==================== CATEGORY SERVICE METHODS ====================
 
 func (s *CategoryGRPCServer) CreateCategory(ctx context.Context, req *pb.CreateCategoryRequest) (*pb.CreateCategoryResponse, error) {
 	createReq := &models.CreateCategoryRequest{
 		Name: req.Name,
+		Slug: req.Slug,
 	}
 
 	category, err := s.categoryService.CreateCategory(ctx, createReq)
 	if err != nil {
+		if strings.Contains(err.Error(), "invalid slug") {
+			return nil, status.Errorf(codes.InvalidArgument, "%s", err.Error())
+		}
+		if st, ok := alreadyExistsStatus(err); ok {
+			return nil, st.Err()
+		}
 		if strings.Contains(err.Error(), "already exists") {
 			return nil, status.Errorf(codes.AlreadyExists, "%s", err.Error())
 		}
@@ -170,6 +457,10 @@ func (s *CategoryGRPCServer) CreateCategory(ctx context.Context, req *pb.CreateC
 }
 
 func (s *CategoryGRPCServer) GetCategory(ctx context.Context, req *pb.GetCategoryRequest) (*pb.GetCategoryResponse, error) {
+	if err := validator.ValidateUUIDArg(req.Id, "id"); err != nil {
+		return nil, err
+	}
+
 	category, err := s.categoryService.GetCategory(ctx, req.Id)
 	if err != nil {
 		if strings.Contains(err.Error(), "not found") {
@@ -181,15 +472,26 @@ func (s *CategoryGRPCServer) GetCategory(ctx context.Context, req *pb.GetCategor
 }
 
 func (s *CategoryGRPCServer) UpdateCategory(ctx context.Context, req *pb.UpdateCategoryRequest) (*pb.UpdateCategoryResponse, error) {
+	if err := validator.ValidateUUIDArg(req.Id, "id"); err != nil {
+		return nil, err
+	}
+
 	updateReq := &models.UpdateCategoryRequest{
 		Name: req.Name,
+		Slug: req.Slug,
 	}
 
 	category, err := s.categoryService.UpdateCategory(ctx, req.Id, updateReq)
 	if err != nil {
+		if strings.Contains(err.Error(), "invalid slug") {
+			return nil, status.Errorf(codes.InvalidArgument, "%s", err.Error())
+		}
 		if strings.Contains(err.Error(), "not found") {
 			return nil, status.Errorf(codes.NotFound, "%s", err.Error())
 		}
+		if st, ok := alreadyExistsStatus(err); ok {
+			return nil, st.Err()
+		}
 		if strings.Contains(err.Error(), "already exists") {
 			return nil, status.Errorf(codes.AlreadyExists, "%s", err.Error())
 		}
@@ -202,6 +504,10 @@ func (s *CategoryGRPCServer) UpdateCategory(ctx context.Context, req *pb.UpdateC
 }
 
 func (s *CategoryGRPCServer) DeleteCategory(ctx context.Context, req *pb.DeleteCategoryRequest) (*emptypb.Empty, error) {
+	if err := validator.ValidateUUIDArg(req.Id, "id"); err != nil {
+		return nil, err
+	}
+
 	if err := s.categoryService.DeleteCategory(ctx, req.Id); err != nil {
 		if strings.Contains(err.Error(), "not found") {
 			return nil, status.Errorf(codes.NotFound, "%s", err.Error())
@@ -223,24 +529,164 @@ func (s *CategoryGRPCServer) ListCategories(ctx context.Context, req *pb.ListCat
 	return listCategoriesResponseToProto(listResponse), nil
 }
 
+// currencyErrorStatus maps price conversion errors to gRPC statuses
+func currencyErrorStatus(err error) (*status.Status, bool) {
+	switch {
+	case errors.Is(err, currency.ErrInvalidCurrency), errors.Is(err, currency.ErrUnsupportedCurrency):
+		return status.New(codes.InvalidArgument, err.Error()), true
+	case errors.Is(err, currency.ErrRatesUnavailable):
+		return status.New(codes.Unavailable, "exchange rates unavailable"), true
+	}
+	return nil, false
+}
+
+// alreadyExistsStatus maps a duplicate name or slug to AlreadyExists, with the existing
+// entity in a ResourceInfo detail so clients can use it instead of searching for it
+func alreadyExistsStatus(err error) (*status.Status, bool) {
+	var exists *models.AlreadyExistsError
+	if !errors.As(err, &exists) {
+		return nil, false
+	}
+	st := status.New(codes.AlreadyExists, exists.Error())
+	if exists.ExistingID == "" {
+		return st, true
+	}
+	withDetails, detailErr := st.WithDetails(&errdetails.ResourceInfo{
+		ResourceType: exists.Entity,
+		ResourceName: exists.ExistingID,
+		Description:  exists.Field + " is already taken",
+	})
+	if detailErr != nil {
+		return st, true
+	}
+	return withDetails, true
+}
+
 // ==================== HELPER CONVERTERS ====================
 
+// Helper: convert models.SavedSearch -> pb.SavedSearch
+func savedSearchToProto(search *models.SavedSearch) *pb.SavedSearch {
+	return &pb.SavedSearch{
+		Id:         search.ID,
+		UserId:     search.UserID,
+		Name:       search.Name,
+		CategoryId: search.CategoryID,
+		BrandId:    search.BrandID,
+		TagIds:     search.TagIDs,
+		MinPrice:   search.MinPrice,
+		MaxPrice:   search.MaxPrice,
+		LastRunAt:  timestamppb.New(search.LastRunAt),
+		CreatedAt:  timestamppb.New(search.CreatedAt),
+	}
+}
+
 // Helper: convert models.ProductResponse -> pb.Product
 func productResponseToProto(p *models.ProductResponse) *pb.Product {
 	if p == nil {
 		return nil
 	}
 	return &pb.Product{
-		Id:          p.ID,
-		Name:        p.Name,
-		Slug:        p.Slug,
-		Description: p.Description,
-		Price:       p.Price,
-		CategoryId:  p.Category.ID,
-		ImageUrl:    p.ImageURL,
-		IsActive:    p.IsActive,
-		CreatedAt:   timestamppb.New(p.CreatedAt),
-		UpdatedAt:   timestamppb.New(p.UpdatedAt),
+		Id:           p.ID,
+		Name:         p.Name,
+		Slug:         p.Slug,
+		Description:  p.Description,
+		Price:        p.Price,
+		CategoryId:   p.Category.ID,
+		ImageUrl:     p.ImageURL,
+		IsActive:     p.IsActive,
+		CreatedAt:    timestamppb.New(p.CreatedAt),
+		UpdatedAt:    timestamppb.New(p.UpdatedAt),
+		Availability: productAvailabilityToProto(p.Availability),
+		Currency:     p.Currency,
+		BasePrice:    p.BasePrice,
+		IsBundle:     p.IsBundle,
+		Components:   bundleComponentsToProto(p.Components),
+		Variants:     productVariantsToProto(p.Variants),
+	}
+}
+
+// Helper: convert []models.BundleComponent -> []*pb.BundleComponent
+func bundleComponentsToProto(components []models.BundleComponent) []*pb.BundleComponent {
+	if len(components) == 0 {
+		return nil
+	}
+	protoComponents := make([]*pb.BundleComponent, len(components))
+	for i, c := range components {
+		protoComponents[i] = &pb.BundleComponent{
+			ProductId: c.ProductID,
+			Name:      c.Name,
+			Quantity:  c.Quantity,
+		}
+	}
+	return protoComponents
+}
+
+// Helper: convert []*pb.BundleComponent -> []models.BundleComponent
+func bundleComponentsFromProto(components []*pb.BundleComponent) []models.BundleComponent {
+	if len(components) == 0 {
+		return nil
+	}
+	modelComponents := make([]models.BundleComponent, len(components))
+	for i, c := range components {
+		modelComponents[i] = models.BundleComponent{
+			ProductID: c.ProductId,
+			Quantity:  c.Quantity,
+		}
+	}
+	return modelComponents
+}
+
+// Helper: convert []models.ProductVariant -> []*pb.ProductVariant
+func productVariantsToProto(variants []models.ProductVariant) []*pb.ProductVariant {
+	if len(variants) == 0 {
+		return nil
+	}
+	protoVariants := make([]*pb.ProductVariant, len(variants))
+	for i, v := range variants {
+		attributes := make([]*pb.VariantAttribute, len(v.Attributes))
+		for j, a := range v.Attributes {
+			attributes[j] = &pb.VariantAttribute{Name: a.Name, Value: a.Value}
+		}
+		protoVariants[i] = &pb.ProductVariant{
+			Id:           v.ID,
+			Sku:          v.SKU,
+			Attributes:   attributes,
+			Price:        v.Price,
+			Availability: productAvailabilityToProto(v.Availability),
+		}
+	}
+	return protoVariants
+}
+
+// Helper: convert []*pb.ProductVariant -> []models.ProductVariant
+func productVariantsFromProto(variants []*pb.ProductVariant) []models.ProductVariant {
+	if len(variants) == 0 {
+		return nil
+	}
+	modelVariants := make([]models.ProductVariant, len(variants))
+	for i, v := range variants {
+		attributes := make([]models.VariantAttribute, len(v.Attributes))
+		for j, a := range v.Attributes {
+			attributes[j] = models.VariantAttribute{Name: a.Name, Value: a.Value}
+		}
+		modelVariants[i] = models.ProductVariant{
+			SKU:        v.Sku,
+			Attributes: attributes,
+			Price:      v.Price,
+		}
+	}
+	return modelVariants
+}
+
+// Helper: convert models.ProductAvailability -> pb.ProductAvailability
+func productAvailabilityToProto(a *models.ProductAvailability) *pb.ProductAvailability {
+	if a == nil {
+		return nil
+	}
+	return &pb.ProductAvailability{
+		Status:            a.Status,
+		InStock:           a.InStock,
+		AvailableQuantity: a.AvailableQuantity,
 	}
 }
 
@@ -257,8 +703,11 @@ func listProductsResponseToProto(resp *models.ListProductsResponse) *pb.ListProd
 	}
 
 	return &pb.ListProductsResponse{
-		Products:   protoProducts,
-		TotalCount: resp.Total,
+		Products:           protoProducts,
+		TotalCount:         resp.Total,
+		TotalPages:         int32(resp.TotalPages),
+		HasMore:            resp.HasMore,
+		StockFilterSkipped: resp.StockFilterSkipped,
 	}
 }
 
@@ -0,0 +1,48 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	autocompleteTimeout      = 50 * time.Millisecond // Suggestions are dropped rather than slowing down typing
+	defaultAutocompleteLimit = 5
+	maxAutocompleteLimit     = 10
+	maxAutocompletePrefix    = 100
+)
+
+// Autocomplete returns product names starting with prefix for search-as-you-type.
+// Lookups slower than autocompleteTimeout or failing degrade to an empty list; only
+// invalid input is returned as an error.
+func (s *ProductService) Autocomplete(ctx context.Context, prefix string, limit int) ([]string, error) {
+	prefix = strings.ToLower(strings.Join(strings.Fields(prefix), " "))
+	if prefix == "" {
+		return nil, fmt.Errorf("prefix is required")
+	}
+	if utf8.RuneCountInString(prefix) > maxAutocompletePrefix {
+		return nil, fmt.Errorf("prefix must be at most %d characters", maxAutocompletePrefix)
+	}
+
+	if limit <= 0 {
+		limit = defaultAutocompleteLimit
+	}
+	if limit > maxAutocompleteLimit {
+		limit = maxAutocompleteLimit
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, autocompleteTimeout)
+	defer cancel()
+
+	names, err := s.repo.Product.SuggestNames(ctx, prefix, limit)
+	if err != nil {
+		log.Printf("Autocomplete for %q degraded to no suggestions: %v", prefix, err)
+		return []string{}, nil
+	}
+
+	return names, nil
+}
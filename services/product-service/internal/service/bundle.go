@@ -0,0 +1,132 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/datngth03/ecommerce-go-app/services/product-service/internal/models"
+	"github.com/google/uuid"
+)
+
+// resolveComponents validates the components of a bundle. bundleID is empty for a new
+// product. Bundles are one level deep: a component cannot be a bundle, and a product
+// contained in a bundle cannot become one.
+func (s *ProductService) resolveComponents(ctx context.Context, bundleID string, components []models.BundleComponent) ([]models.BundleComponent, error) {
+	if len(components) > models.MaxBundleComponents {
+		return nil, fmt.Errorf("invalid bundle: at most %d components are allowed", models.MaxBundleComponents)
+	}
+
+	resolved := make([]models.BundleComponent, 0, len(components))
+	ids := make([]string, 0, len(components))
+	seen := make(map[string]bool, len(components))
+	for _, component := range components {
+		id := strings.TrimSpace(component.ProductID)
+		if _, err := uuid.Parse(id); err != nil {
+			return nil, fmt.Errorf("invalid bundle: invalid component product ID %q", component.ProductID)
+		}
+		if id == bundleID {
+			return nil, fmt.Errorf("invalid bundle: a bundle cannot contain itself")
+		}
+		if seen[id] {
+			return nil, fmt.Errorf("invalid bundle: component product %s is listed more than once", id)
+		}
+		if component.Quantity <= 0 || component.Quantity > models.MaxBundleComponentQuantity {
+			return nil, fmt.Errorf("invalid bundle: component quantity must be between 1 and %d", models.MaxBundleComponentQuantity)
+		}
+		seen[id] = true
+		ids = append(ids, id)
+		resolved = append(resolved, models.BundleComponent{ProductID: id, Quantity: component.Quantity})
+	}
+
+	if bundleID != "" {
+		contained, err := s.repo.Product.IsBundleComponent(ctx, bundleID)
+		if err != nil {
+			return nil, err
+		}
+		if contained {
+			return nil, fmt.Errorf("invalid bundle: product is a component of another bundle")
+		}
+	}
+
+	products, err := s.repo.Product.GetByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[string]models.Product, len(products))
+	for _, product := range products {
+		byID[product.ID] = product
+	}
+	for i, component := range resolved {
+		product, ok := byID[component.ProductID]
+		if !ok {
+			return nil, fmt.Errorf("invalid bundle: component product %s not found", component.ProductID)
+		}
+		if !product.IsActive {
+			return nil, fmt.Errorf("invalid bundle: component product %s is inactive", component.ProductID)
+		}
+		resolved[i].Name = product.Name
+	}
+
+	nested, err := s.repo.Product.GetBundleComponents(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range ids {
+		if len(nested[id]) > 0 {
+			return nil, fmt.Errorf("invalid bundle: component product %s is itself a bundle", id)
+		}
+	}
+
+	// Components are stocked per product, which products with variants are not
+	variants, err := s.repo.Product.GetVariants(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range ids {
+		if len(variants[id]) > 0 {
+			return nil, fmt.Errorf("invalid bundle: component product %s has variants", id)
+		}
+	}
+
+	return resolved, nil
+}
+
+// attachComponents fills in the composition of the bundles among products with one lookup
+func (s *ProductService) attachComponents(ctx context.Context, products []models.ProductResponse) error {
+	if len(products) == 0 {
+		return nil
+	}
+
+	ids := make([]string, len(products))
+	for i := range products {
+		ids[i] = products[i].ID
+	}
+
+	components, err := s.repo.Product.GetBundleComponents(ctx, ids)
+	if err != nil {
+		return err
+	}
+
+	for i := range products {
+		products[i].Components = components[products[i].ID]
+		products[i].IsBundle = len(products[i].Components) > 0
+	}
+	return nil
+}
+
+// bundleAvailableQuantity is how many whole bundles the component stock makes up;
+// one component out of stock makes the bundle unavailable
+func bundleAvailableQuantity(components []models.BundleComponent, quantities map[string]int32) int32 {
+	var available int32
+	for i, component := range components {
+		units := quantities[component.ProductID] / component.Quantity
+		if i == 0 || units < available {
+			available = units
+		}
+	}
+	if available < 0 {
+		return 0
+	}
+	return available
+}
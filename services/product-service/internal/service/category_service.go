@@ -10,12 +10,14 @@ import (
 )
 
 type CategoryService struct {
-	repo *repository.Repository
+	repo  *repository.Repository
+	slugs SlugPolicy
 }
 
-func NewCategoryService(repo *repository.Repository) *CategoryService {
+func NewCategoryService(repo *repository.Repository, slugs SlugPolicy) *CategoryService {
 	return &CategoryService{
-		repo: repo,
+		repo:  repo,
+		slugs: slugs,
 	}
 }
 
@@ -25,18 +27,36 @@ func (s *CategoryService) CreateCategory(ctx context.Context, req *models.Create
 		return nil, err
 	}
 
-	// Check if category name already exists
-	nameExists, err := s.repo.Category.ExistsByName(ctx, req.Name)
+	// Check if category name already exists; the unique constraint catches concurrent creates
+	name := strings.TrimSpace(req.Name)
+	existingID, err := s.repo.Category.IDByName(ctx, name)
 	if err != nil {
 		return nil, fmt.Errorf("failed to check category name: %w", err)
 	}
-	if nameExists {
-		return nil, fmt.Errorf("category with name '%s' already exists", req.Name)
+	if existingID != "" {
+		return nil, &models.AlreadyExistsError{Entity: "category", Field: "name", Value: name, ExistingID: existingID}
+	}
+
+	// Resolve slug: manual override or generated from name
+	slug := strings.TrimSpace(req.Slug)
+	slugExists := func(ctx context.Context, slug string) (bool, error) {
+		return s.repo.Category.ExistsBySlug(ctx, slug)
+	}
+	if slug != "" {
+		if err := checkManualSlug(ctx, slug, models.MaxCategorySlugLength, slugExists); err != nil {
+			return nil, err
+		}
+	} else {
+		slug, err = generateUniqueSlug(ctx, req.Name, models.MaxCategorySlugLength, slugExists)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	// Create category
 	category := &models.Category{
-		Name: strings.TrimSpace(req.Name),
+		Name: name,
+		Slug: slug,
 	}
 
 	if err := s.repo.Category.Create(ctx, category); err != nil {
@@ -68,10 +88,16 @@ func (s *CategoryService) GetCategory(ctx context.Context, id string) (*models.C
 }
 
 func (s *CategoryService) GetCategoryBySlug(ctx context.Context, slug string) (*models.CategoryResponse, error) {
-	if strings.TrimSpace(slug) == "" {
+	slug = strings.TrimSpace(slug)
+	if slug == "" {
 		return nil, fmt.Errorf("category slug is required")
 	}
 
+	// A malformed slug can never match, skip the lookup
+	if models.ValidateSlug(slug, models.MaxCategorySlugLength) != nil {
+		return nil, fmt.Errorf("category not found")
+	}
+
 	category, err := s.repo.Category.GetBySlug(ctx, slug)
 	if err != nil {
 		return nil, err
@@ -99,12 +125,30 @@ func (s *CategoryService) UpdateCategory(ctx context.Context, id string, req *mo
 
 	// Check if category name already exists (excluding current category)
 	if req.Name != existingCategory.Name {
-		nameExists, err := s.repo.Category.ExistsByName(ctx, req.Name, id)
+		existingID, err := s.repo.Category.IDByName(ctx, req.Name)
 		if err != nil {
 			return nil, fmt.Errorf("failed to check category name: %w", err)
 		}
-		if nameExists {
-			return nil, fmt.Errorf("category with name '%s' already exists", req.Name)
+		if existingID != "" && existingID != id {
+			return nil, &models.AlreadyExistsError{Entity: "category", Field: "name", Value: req.Name, ExistingID: existingID}
+		}
+	}
+
+	// Resolve slug: manual override, regenerate on rename (if enabled) or keep
+	slugExists := func(ctx context.Context, slug string) (bool, error) {
+		return s.repo.Category.ExistsBySlug(ctx, slug, id)
+	}
+	newSlug := strings.TrimSpace(req.Slug)
+	switch {
+	case newSlug != "" && newSlug != existingCategory.Slug:
+		if err := checkManualSlug(ctx, newSlug, models.MaxCategorySlugLength, slugExists); err != nil {
+			return nil, err
+		}
+		existingCategory.Slug = newSlug
+	case newSlug == "" && s.slugs.RegenerateOnRename && strings.TrimSpace(req.Name) != existingCategory.Name:
+		existingCategory.Slug, err = generateUniqueSlug(ctx, req.Name, models.MaxCategorySlugLength, slugExists)
+		if err != nil {
+			return nil, err
 		}
 	}
 
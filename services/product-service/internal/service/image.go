@@ -0,0 +1,57 @@
+package service
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// ImagePolicy controls which product image URLs are accepted
+type ImagePolicy struct {
+	MaxURLLength int // At most the width of the image_url column
+	// AllowedHosts restricts images to these hosts and their subdomains, e.g. a CDN.
+	// Empty allows any host.
+	AllowedHosts []string
+}
+
+// checkImageURL validates a product image URL. An empty URL means no image.
+func (p ImagePolicy) checkImageURL(raw string) error {
+	if raw == "" {
+		return nil
+	}
+	if len(raw) > p.MaxURLLength {
+		return fmt.Errorf("invalid image URL %q: must not exceed %d characters", raw, p.MaxURLLength)
+	}
+
+	u, err := url.Parse(raw)
+	if err != nil {
+		return fmt.Errorf("invalid image URL %q: malformed URL", raw)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("invalid image URL %q: scheme must be http or https", raw)
+	}
+	if u.Hostname() == "" {
+		return fmt.Errorf("invalid image URL %q: host is required", raw)
+	}
+	if u.User != nil {
+		return fmt.Errorf("invalid image URL %q: credentials are not allowed", raw)
+	}
+	if !p.hostAllowed(u.Hostname()) {
+		return fmt.Errorf("invalid image URL %q: host %s is not allowed", raw, u.Hostname())
+	}
+	return nil
+}
+
+// hostAllowed reports whether host is one of the allowed hosts or a subdomain of one
+func (p ImagePolicy) hostAllowed(host string) bool {
+	if len(p.AllowedHosts) == 0 {
+		return true
+	}
+	host = strings.ToLower(host)
+	for _, allowed := range p.AllowedHosts {
+		if host == allowed || strings.HasSuffix(host, "."+allowed) {
+			return true
+		}
+	}
+	return false
+}
@@ -2,25 +2,50 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"math"
+	"log"
+	"sort"
 	"strings"
+	"time"
 
+	"github.com/datngth03/ecommerce-go-app/services/product-service/internal/currency"
 	"github.com/datngth03/ecommerce-go-app/services/product-service/internal/models"
 	"github.com/datngth03/ecommerce-go-app/services/product-service/internal/repository"
+	"github.com/datngth03/ecommerce-go-app/shared/pkg/paging"
+	"github.com/google/uuid"
 )
 
+// StockLookup provides available stock quantities from inventory service
+type StockLookup interface {
+	GetAvailableQuantities(ctx context.Context, productIDs []string) (map[string]int32, error)
+}
+
+// availabilityTimeout bounds how long a product read waits on inventory
+const availabilityTimeout = 2 * time.Second
+
 type ProductService struct {
-	repo *repository.Repository
+	repo   *repository.Repository
+	stock  StockLookup // Optional, nil disables availability enrichment
+	slugs  SlugPolicy
+	images ImagePolicy
+	prices *currency.Converter // Optional, nil disables price conversion
 }
 
-func NewProductService(repo *repository.Repository) *ProductService {
+func NewProductService(repo *repository.Repository, stock StockLookup, slugs SlugPolicy, images ImagePolicy, prices *currency.Converter) *ProductService {
 	return &ProductService{
-		repo: repo,
+		repo:   repo,
+		stock:  stock,
+		slugs:  slugs,
+		images: images,
+		prices: prices,
 	}
 }
 
 func (s *ProductService) CreateProduct(ctx context.Context, req *models.CreateProductRequest) (*models.ProductResponse, error) {
+	// Reads in write flows must see this request's own writes, which the read replica may not have yet
+	ctx = repository.WithPrimary(ctx)
+
 	// Validate input
 	if err := s.validateCreateProductRequest(req); err != nil {
 		return nil, err
@@ -35,23 +60,59 @@ func (s *ProductService) CreateProduct(ctx context.Context, req *models.CreatePr
 		return nil, fmt.Errorf("category not found")
 	}
 
-	// Check if product name already exists
-	nameExists, err := s.repo.Product.ExistsByName(ctx, req.Name)
+	// Check if product name already exists; the unique index catches concurrent creates
+	name := strings.TrimSpace(req.Name)
+	existingID, err := s.repo.Product.IDByName(ctx, name)
 	if err != nil {
 		return nil, fmt.Errorf("failed to check product name: %w", err)
 	}
-	if nameExists {
-		return nil, fmt.Errorf("product with name '%s' already exists", req.Name)
+	if existingID != "" {
+		return nil, &models.AlreadyExistsError{Entity: "product", Field: "name", Value: name, ExistingID: existingID}
+	}
+
+	// Resolve slug: manual override or generated from name
+	slug := strings.TrimSpace(req.Slug)
+	slugExists := func(ctx context.Context, slug string) (bool, error) {
+		return s.repo.Product.ExistsBySlug(ctx, slug)
+	}
+	if slug != "" {
+		if err := checkManualSlug(ctx, slug, models.MaxProductSlugLength, slugExists); err != nil {
+			return nil, err
+		}
+	} else {
+		slug, err = generateUniqueSlug(ctx, req.Name, models.MaxProductSlugLength, slugExists)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var components []models.BundleComponent
+	if len(req.Components) > 0 {
+		components, err = s.resolveComponents(ctx, "", req.Components)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var variants []models.ProductVariant
+	if len(req.Variants) > 0 {
+		variants, err = s.resolveVariants(ctx, "", len(components) > 0, req.Variants)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	// Create product
 	product := &models.Product{
-		Name:        strings.TrimSpace(req.Name),
+		Name:        name,
+		Slug:        slug,
 		Description: strings.TrimSpace(req.Description),
 		Price:       req.Price,
 		CategoryID:  req.CategoryID,
 		ImageURL:    strings.TrimSpace(req.ImageURL),
 		IsActive:    true,
+		Components:  components,
+		Variants:    variants,
 	}
 
 	if err := s.repo.Product.Create(ctx, product); err != nil {
@@ -64,39 +125,133 @@ func (s *ProductService) CreateProduct(ctx context.Context, req *models.CreatePr
 		return nil, fmt.Errorf("failed to get created product: %w", err)
 	}
 
-	response := createdProduct.ToResponse()
-	return &response, nil
+	responses := []models.ProductResponse{createdProduct.ToResponse()}
+	if err := s.attachComponents(ctx, responses); err != nil {
+		return nil, fmt.Errorf("failed to get bundle components: %w", err)
+	}
+	if err := s.attachVariants(ctx, responses); err != nil {
+		return nil, fmt.Errorf("failed to get product variants: %w", err)
+	}
+	return &responses[0], nil
 }
 
-func (s *ProductService) GetProduct(ctx context.Context, id string) (*models.ProductResponse, error) {
+func (s *ProductService) GetProduct(ctx context.Context, id string, opts models.GetProductOptions) (*models.ProductResponse, error) {
 	if strings.TrimSpace(id) == "" {
 		return nil, fmt.Errorf("product ID is required")
 	}
 
+	target, err := normalizeCurrency(opts.Currency)
+	if err != nil {
+		return nil, err
+	}
+
 	product, err := s.repo.Product.GetByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
 
-	response := product.ToResponse()
-	return &response, nil
+	responses := []models.ProductResponse{product.ToResponse()}
+	if err := s.attachComponents(ctx, responses); err != nil {
+		return nil, fmt.Errorf("failed to get bundle components: %w", err)
+	}
+	if err := s.attachVariants(ctx, responses); err != nil {
+		return nil, fmt.Errorf("failed to get product variants: %w", err)
+	}
+	if err := s.convertPrices(ctx, responses, target); err != nil {
+		return nil, err
+	}
+	if opts.IncludeAvailability {
+		s.attachAvailability(ctx, responses)
+	}
+	return &responses[0], nil
+}
+
+// maxProductsByIDs bounds a single GetProductsByIDs call
+const maxProductsByIDs = 100
+
+// GetProductsByIDs returns the products with the given IDs in request order, plus the IDs
+// that do not exist. IDs are trimmed and must be UUIDs. Duplicate IDs are returned once.
+func (s *ProductService) GetProductsByIDs(ctx context.Context, ids []string) ([]models.ProductResponse, []string, error) {
+	if len(ids) == 0 {
+		return nil, nil, fmt.Errorf("product IDs are required")
+	}
+	if len(ids) > maxProductsByIDs {
+		return nil, nil, fmt.Errorf("at most %d product IDs must be requested at once", maxProductsByIDs)
+	}
+
+	var lookup, notFound []string
+	seen := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		id = strings.TrimSpace(id)
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		if _, err := uuid.Parse(id); err != nil {
+			return nil, nil, fmt.Errorf("product ID %q must be a valid UUID", id)
+		}
+		lookup = append(lookup, id)
+	}
+
+	byID := make(map[string]models.Product, len(lookup))
+	if len(lookup) > 0 {
+		products, err := s.repo.Product.GetByIDs(ctx, lookup)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, product := range products {
+			byID[product.ID] = product
+		}
+	}
+
+	responses := make([]models.ProductResponse, 0, len(lookup))
+	for _, id := range lookup {
+		product, ok := byID[id]
+		if !ok {
+			notFound = append(notFound, id)
+			continue
+		}
+		responses = append(responses, product.ToResponse())
+	}
+
+	if err := s.attachComponents(ctx, responses); err != nil {
+		return nil, nil, fmt.Errorf("failed to get bundle components: %w", err)
+	}
+	if err := s.attachVariants(ctx, responses); err != nil {
+		return nil, nil, fmt.Errorf("failed to get product variants: %w", err)
+	}
+
+	return responses, notFound, nil
 }
 
 func (s *ProductService) GetProductBySlug(ctx context.Context, slug string) (*models.ProductResponse, error) {
-	if strings.TrimSpace(slug) == "" {
+	slug = strings.TrimSpace(slug)
+	if slug == "" {
 		return nil, fmt.Errorf("product slug is required")
 	}
 
+	// A malformed slug can never match, skip the lookup
+	if models.ValidateSlug(slug, models.MaxProductSlugLength) != nil {
+		return nil, fmt.Errorf("product not found")
+	}
+
 	product, err := s.repo.Product.GetBySlug(ctx, slug)
 	if err != nil {
 		return nil, err
 	}
 
-	response := product.ToResponse()
-	return &response, nil
+	responses := []models.ProductResponse{product.ToResponse()}
+	if err := s.attachComponents(ctx, responses); err != nil {
+		return nil, fmt.Errorf("failed to get bundle components: %w", err)
+	}
+	if err := s.attachVariants(ctx, responses); err != nil {
+		return nil, fmt.Errorf("failed to get product variants: %w", err)
+	}
+	return &responses[0], nil
 }
 
 func (s *ProductService) UpdateProduct(ctx context.Context, id string, req *models.UpdateProductRequest) (*models.ProductResponse, error) {
+	ctx = repository.WithPrimary(ctx)
 	if strings.TrimSpace(id) == "" {
 		return nil, fmt.Errorf("product ID is required")
 	}
@@ -125,12 +280,69 @@ func (s *ProductService) UpdateProduct(ctx context.Context, id string, req *mode
 
 	// Check if product name already exists (excluding current product)
 	if req.Name != existingProduct.Name {
-		nameExists, err := s.repo.Product.ExistsByName(ctx, req.Name, id)
+		existingID, err := s.repo.Product.IDByName(ctx, req.Name)
 		if err != nil {
 			return nil, fmt.Errorf("failed to check product name: %w", err)
 		}
-		if nameExists {
-			return nil, fmt.Errorf("product with name '%s' already exists", req.Name)
+		if existingID != "" && existingID != id {
+			return nil, &models.AlreadyExistsError{Entity: "product", Field: "name", Value: req.Name, ExistingID: existingID}
+		}
+	}
+
+	// Resolve slug: manual override, regenerate on rename (if enabled) or keep
+	slugExists := func(ctx context.Context, slug string) (bool, error) {
+		return s.repo.Product.ExistsBySlug(ctx, slug, id)
+	}
+	newSlug := strings.TrimSpace(req.Slug)
+	switch {
+	case newSlug != "" && newSlug != existingProduct.Slug:
+		if err := checkManualSlug(ctx, newSlug, models.MaxProductSlugLength, slugExists); err != nil {
+			return nil, err
+		}
+		existingProduct.Slug = newSlug
+	case newSlug == "" && s.slugs.RegenerateOnRename && strings.TrimSpace(req.Name) != existingProduct.Name:
+		existingProduct.Slug, err = generateUniqueSlug(ctx, req.Name, models.MaxProductSlugLength, slugExists)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Replace or remove bundle components; without either they stay as they are
+	switch {
+	case req.ClearComponents && len(req.Components) > 0:
+		return nil, fmt.Errorf("invalid bundle: components and clear_components cannot both be set")
+	case req.ClearComponents:
+		existingProduct.Components = []models.BundleComponent{}
+	case len(req.Components) > 0:
+		existingProduct.Components, err = s.resolveComponents(ctx, id, req.Components)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Replace or remove variants the same way
+	switch {
+	case req.ClearVariants && len(req.Variants) > 0:
+		return nil, fmt.Errorf("invalid variants: variants and clear_variants cannot both be set")
+	case req.ClearVariants:
+		existingProduct.Variants = []models.ProductVariant{}
+	case len(req.Variants) > 0:
+		isBundle, err := s.isBundleAfterUpdate(ctx, existingProduct)
+		if err != nil {
+			return nil, err
+		}
+		existingProduct.Variants, err = s.resolveVariants(ctx, id, isBundle, req.Variants)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if len(existingProduct.Components) > 0 && existingProduct.Variants == nil {
+		current, err := s.repo.Product.GetVariants(ctx, []string{id})
+		if err != nil {
+			return nil, err
+		}
+		if len(current[id]) > 0 {
+			return nil, fmt.Errorf("invalid bundle: a product with variants cannot become a bundle")
 		}
 	}
 
@@ -152,11 +364,18 @@ func (s *ProductService) UpdateProduct(ctx context.Context, id string, req *mode
 		return nil, fmt.Errorf("failed to get updated product: %w", err)
 	}
 
-	response := updatedProduct.ToResponse()
-	return &response, nil
+	responses := []models.ProductResponse{updatedProduct.ToResponse()}
+	if err := s.attachComponents(ctx, responses); err != nil {
+		return nil, fmt.Errorf("failed to get bundle components: %w", err)
+	}
+	if err := s.attachVariants(ctx, responses); err != nil {
+		return nil, fmt.Errorf("failed to get product variants: %w", err)
+	}
+	return &responses[0], nil
 }
 
 func (s *ProductService) DeleteProduct(ctx context.Context, id string) error {
+	ctx = repository.WithPrimary(ctx)
 	if strings.TrimSpace(id) == "" {
 		return fmt.Errorf("product ID is required")
 	}
@@ -191,10 +410,28 @@ func (s *ProductService) ListProducts(ctx context.Context, req *models.ListProdu
 		}
 	}
 
-	// Get products
-	products, total, err := s.repo.Product.List(ctx, req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to list products: %w", err)
+	// Get products. The in-stock filter falls back to an unfiltered listing
+	// when inventory cannot be reached, rather than failing the read.
+	var products []models.Product
+	var total int64
+	var quantities map[string]int32
+	stockFilterSkipped := false
+	if req.InStockOnly {
+		var err error
+		products, total, quantities, err = s.listInStock(ctx, req)
+		if errors.Is(err, errStockUnavailable) {
+			log.Printf("Warning: in_stock_only ignored: %v", err)
+			stockFilterSkipped = true
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to list products: %w", err)
+		}
+	}
+	if !req.InStockOnly || stockFilterSkipped {
+		var err error
+		products, total, err = s.repo.Product.List(ctx, req)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list products: %w", err)
+		}
 	}
 
 	// Convert to response
@@ -203,18 +440,177 @@ func (s *ProductService) ListProducts(ctx context.Context, req *models.ListProdu
 		productResponses[i] = product.ToResponse()
 	}
 
-	// Calculate total pages
-	totalPages := int(math.Ceil(float64(total) / float64(req.PageSize)))
+	if err := s.attachComponents(ctx, productResponses); err != nil {
+		return nil, fmt.Errorf("failed to get bundle components: %w", err)
+	}
+	if err := s.attachVariants(ctx, productResponses); err != nil {
+		return nil, fmt.Errorf("failed to get product variants: %w", err)
+	}
+
+	if err := s.convertPrices(ctx, productResponses, req.Currency); err != nil {
+		return nil, err
+	}
+
+	if req.IncludeAvailability {
+		// The in-stock filter already fetched the quantities of these products
+		if quantities != nil {
+			setAvailability(productResponses, quantities)
+		} else {
+			s.attachAvailability(ctx, productResponses)
+		}
+	}
+
+	// total counts the same filters as the page, so the metadata matches it
+	meta := paging.ForPage(int64(req.Page), int64(req.PageSize), total)
 
 	return &models.ListProductsResponse{
-		Products:   productResponses,
-		Total:      total,
-		Page:       req.Page,
-		PageSize:   req.PageSize,
-		TotalPages: totalPages,
+		Products:           productResponses,
+		Total:              total,
+		Page:               req.Page,
+		PageSize:           req.PageSize,
+		TotalPages:         int(meta.TotalPages),
+		HasMore:            meta.HasMore,
+		StockFilterSkipped: stockFilterSkipped,
 	}, nil
 }
 
+// attachAvailability fills in stock status using a single batch lookup. A bundle is
+// available as many times as its components allow, ignoring any stock of its own;
+// a product with variants is available as the sum of its variants.
+// Inventory failures never fail the read: products are marked "unknown" instead.
+func (s *ProductService) attachAvailability(ctx context.Context, products []models.ProductResponse) {
+	if len(products) == 0 {
+		return
+	}
+
+	// Components are looked up once even when several bundles share them
+	ids := make([]string, 0, len(products))
+	seen := make(map[string]bool, len(products))
+	for i := range products {
+		lookup := []string{products[i].ID}
+		for _, component := range products[i].Components {
+			lookup = append(lookup, component.ProductID)
+		}
+		for _, variant := range products[i].Variants {
+			lookup = append(lookup, variant.ID)
+		}
+		for _, id := range lookup {
+			if !seen[id] {
+				seen[id] = true
+				ids = append(ids, id)
+			}
+		}
+	}
+
+	quantities, err := s.availableQuantities(ctx, ids)
+	if err != nil {
+		log.Printf("Warning: failed to get product availability: %v", err)
+		for i := range products {
+			products[i].Availability = &models.ProductAvailability{Status: models.AvailabilityUnknown}
+		}
+		return
+	}
+
+	setAvailability(products, quantities)
+}
+
+// availableQuantities asks inventory for the available stock of productIDs
+func (s *ProductService) availableQuantities(ctx context.Context, productIDs []string) (map[string]int32, error) {
+	if s.stock == nil {
+		return nil, fmt.Errorf("inventory client not configured")
+	}
+	lookupCtx, cancel := context.WithTimeout(ctx, availabilityTimeout)
+	defer cancel()
+	return s.stock.GetAvailableQuantities(lookupCtx, productIDs)
+}
+
+// setAvailability sets the stock status of products from looked up quantities
+func setAvailability(products []models.ProductResponse, quantities map[string]int32) {
+	for i := range products {
+		// Products without a stock record have nothing available to sell
+		quantity := quantities[products[i].ID]
+		switch {
+		case products[i].IsBundle:
+			quantity = bundleAvailableQuantity(products[i].Components, quantities)
+		case len(products[i].Variants) > 0:
+			quantity = variantsAvailableQuantity(products[i].Variants, quantities)
+			for j := range products[i].Variants {
+				products[i].Variants[j].Availability = newAvailability(quantities[products[i].Variants[j].ID])
+			}
+		}
+		products[i].Availability = newAvailability(quantity)
+	}
+}
+
+// newAvailability is the stock status of an available quantity
+func newAvailability(quantity int32) *models.ProductAvailability {
+	availability := &models.ProductAvailability{
+		Status:            models.AvailabilityOutOfStock,
+		AvailableQuantity: quantity,
+	}
+	if quantity > 0 {
+		availability.Status = models.AvailabilityInStock
+		availability.InStock = true
+	}
+	return availability
+}
+
+// convertPrices expresses prices in the target currency using one rate lookup.
+// An empty target leaves prices in the base currency.
+func (s *ProductService) convertPrices(ctx context.Context, products []models.ProductResponse, target string) error {
+	if target == "" || len(products) == 0 {
+		return nil
+	}
+	if s.prices == nil {
+		return fmt.Errorf("%w: currency conversion is disabled", currency.ErrRatesUnavailable)
+	}
+
+	base := s.prices.Base()
+	if target == base {
+		for i := range products {
+			products[i].Currency = base
+			products[i].BasePrice = products[i].Price
+		}
+		return nil
+	}
+
+	rates, err := s.prices.Rates(ctx)
+	if err != nil {
+		return err
+	}
+
+	for i := range products {
+		converted, err := currency.Convert(products[i].Price, base, target, rates)
+		if err != nil {
+			return err
+		}
+		products[i].BasePrice = products[i].Price
+		products[i].Price = converted
+		products[i].Currency = target
+
+		// Variant price overrides are in the base currency too
+		for j := range products[i].Variants {
+			if products[i].Variants[j].Price == 0 {
+				continue
+			}
+			products[i].Variants[j].Price, err = currency.Convert(products[i].Variants[j].Price, base, target, rates)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// normalizeCurrency validates an optional currency code
+func normalizeCurrency(code string) (string, error) {
+	if strings.TrimSpace(code) == "" {
+		return "", nil
+	}
+	return currency.NormalizeCode(code)
+}
+
 func (s *ProductService) ListProductsByCategory(ctx context.Context, categoryID string, req *models.ListProductsRequest) (*models.ListProductsResponse, error) {
 	if strings.TrimSpace(categoryID) == "" {
 		return nil, fmt.Errorf("category ID is required")
@@ -249,19 +645,32 @@ func (s *ProductService) ListProductsByCategory(ctx context.Context, categoryID
 		productResponses[i] = product.ToResponse()
 	}
 
-	// Calculate total pages
-	totalPages := int(math.Ceil(float64(total) / float64(req.PageSize)))
+	if err := s.attachComponents(ctx, productResponses); err != nil {
+		return nil, fmt.Errorf("failed to get bundle components: %w", err)
+	}
+	if err := s.attachVariants(ctx, productResponses); err != nil {
+		return nil, fmt.Errorf("failed to get product variants: %w", err)
+	}
+
+	if err := s.convertPrices(ctx, productResponses, req.Currency); err != nil {
+		return nil, err
+	}
+
+	// total counts the same filters as the page, so the metadata matches it
+	meta := paging.ForPage(int64(req.Page), int64(req.PageSize), total)
 
 	return &models.ListProductsResponse{
 		Products:   productResponses,
 		Total:      total,
 		Page:       req.Page,
 		PageSize:   req.PageSize,
-		TotalPages: totalPages,
+		TotalPages: int(meta.TotalPages),
+		HasMore:    meta.HasMore,
 	}, nil
 }
 
 func (s *ProductService) ActivateProduct(ctx context.Context, id string) error {
+	ctx = repository.WithPrimary(ctx)
 	if strings.TrimSpace(id) == "" {
 		return fmt.Errorf("product ID is required")
 	}
@@ -284,6 +693,7 @@ func (s *ProductService) ActivateProduct(ctx context.Context, id string) error {
 }
 
 func (s *ProductService) DeactivateProduct(ctx context.Context, id string) error {
+	ctx = repository.WithPrimary(ctx)
 	if strings.TrimSpace(id) == "" {
 		return fmt.Errorf("product ID is required")
 	}
@@ -335,8 +745,8 @@ func (s *ProductService) validateCreateProductRequest(req *models.CreateProductR
 		return fmt.Errorf("product description must be less than 5000 characters")
 	}
 
-	if req.ImageURL != "" && len(req.ImageURL) > 500 {
-		return fmt.Errorf("image URL must be less than 500 characters")
+	if err := s.images.checkImageURL(strings.TrimSpace(req.ImageURL)); err != nil {
+		return err
 	}
 
 	return nil
@@ -371,8 +781,8 @@ func (s *ProductService) validateUpdateProductRequest(req *models.UpdateProductR
 		return fmt.Errorf("product description must be less than 5000 characters")
 	}
 
-	if req.ImageURL != "" && len(req.ImageURL) > 500 {
-		return fmt.Errorf("image URL must be less than 500 characters")
+	if err := s.images.checkImageURL(strings.TrimSpace(req.ImageURL)); err != nil {
+		return err
 	}
 
 	return nil
@@ -396,5 +806,55 @@ func (s *ProductService) validateListProductsRequest(req *models.ListProductsReq
 		req.PageSize = 100
 	}
 
+	// Normalize filters so equivalent requests share the same cache entry
+	req.CategoryID = strings.TrimSpace(req.CategoryID)
+	req.BrandID = strings.TrimSpace(req.BrandID)
+	req.TagIDs = normalizeIDs(req.TagIDs)
+
+	currencyCode, err := normalizeCurrency(req.Currency)
+	if err != nil {
+		return err
+	}
+	req.Currency = currencyCode
+
+	if req.MinPrice < 0 || req.MaxPrice < 0 {
+		return fmt.Errorf("invalid price range: prices must not be negative")
+	}
+	if req.MaxPrice > 0 && req.MinPrice > req.MaxPrice {
+		return fmt.Errorf("invalid price range: min_price must not exceed max_price")
+	}
+
+	req.Sort = strings.ToLower(strings.TrimSpace(req.Sort))
+	if req.Sort == "" {
+		req.Sort = models.ProductSortNewest
+	}
+	if !models.IsValidProductSort(req.Sort) {
+		return fmt.Errorf("invalid sort %q: must be one of newest, price_asc, price_desc, name", req.Sort)
+	}
+
 	return nil
 }
+
+// normalizeIDs trims, de-duplicates and sorts a list of IDs, dropping empty values
+func normalizeIDs(ids []string) []string {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	seen := make(map[string]bool, len(ids))
+	normalized := make([]string, 0, len(ids))
+	for _, id := range ids {
+		id = strings.TrimSpace(id)
+		if id == "" || seen[id] {
+			continue
+		}
+		seen[id] = true
+		normalized = append(normalized, id)
+	}
+	if len(normalized) == 0 {
+		return nil
+	}
+
+	sort.Strings(normalized)
+	return normalized
+}
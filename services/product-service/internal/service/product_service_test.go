@@ -0,0 +1,75 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/datngth03/ecommerce-go-app/services/product-service/internal/currency"
+	"github.com/datngth03/ecommerce-go-app/services/product-service/internal/models"
+)
+
+// fixedRates serves the same rates for every base
+type fixedRates struct {
+	rates *currency.Rates
+	err   error
+}
+
+func (p fixedRates) GetRates(ctx context.Context, base string) (*currency.Rates, error) {
+	return p.rates, p.err
+}
+
+func TestConvertPrices(t *testing.T) {
+	converter, err := currency.NewConverter(fixedRates{rates: &currency.Rates{
+		Base:  "USD",
+		Rates: map[string]float64{"EUR": 0.5},
+	}}, "USD")
+	if err != nil {
+		t.Fatalf("NewConverter: %v", err)
+	}
+	svc := &ProductService{prices: converter}
+
+	products := []models.ProductResponse{{
+		Price:    10,
+		Variants: []models.ProductVariant{{Price: 0}, {Price: 12}},
+	}}
+	if err := svc.convertPrices(context.Background(), products, "EUR"); err != nil {
+		t.Fatalf("convertPrices: %v", err)
+	}
+
+	got := products[0]
+	if got.Price != 5 || got.BasePrice != 10 || got.Currency != "EUR" {
+		t.Errorf("price = %v %s (base %v), want 5 EUR (base 10)", got.Price, got.Currency, got.BasePrice)
+	}
+	if got.Variants[0].Price != 0 || got.Variants[1].Price != 6 {
+		t.Errorf("variant prices = %v, %v, want 0 (inherited) and 6", got.Variants[0].Price, got.Variants[1].Price)
+	}
+
+	if err := svc.convertPrices(context.Background(), products, "GBP"); !errors.Is(err, currency.ErrUnsupportedCurrency) {
+		t.Errorf("convert to GBP: error = %v, want ErrUnsupportedCurrency", err)
+	}
+}
+
+func TestConvertPricesUnavailable(t *testing.T) {
+	products := []models.ProductResponse{{Price: 10}}
+
+	disabled := &ProductService{}
+	if err := disabled.convertPrices(context.Background(), products, "EUR"); !errors.Is(err, currency.ErrRatesUnavailable) {
+		t.Errorf("conversion disabled: error = %v, want ErrRatesUnavailable", err)
+	}
+	if err := disabled.convertPrices(context.Background(), products, ""); err != nil {
+		t.Errorf("no target currency with conversion disabled: %v", err)
+	}
+
+	converter, err := currency.NewConverter(fixedRates{err: errors.New("provider down")}, "USD")
+	if err != nil {
+		t.Fatalf("NewConverter: %v", err)
+	}
+	failing := &ProductService{prices: converter}
+	if err := failing.convertPrices(context.Background(), products, "EUR"); !errors.Is(err, currency.ErrRatesUnavailable) {
+		t.Errorf("provider down: error = %v, want ErrRatesUnavailable", err)
+	}
+	if products[0].Price != 10 {
+		t.Errorf("price = %v after a failed conversion, want 10", products[0].Price)
+	}
+}
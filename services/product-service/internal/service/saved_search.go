@@ -0,0 +1,244 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"golang.org/x/time/rate"
+
+	"github.com/datngth03/ecommerce-go-app/services/product-service/internal/models"
+	"github.com/datngth03/ecommerce-go-app/services/product-service/internal/repository"
+	"github.com/datngth03/ecommerce-go-app/shared/pkg/domainerr"
+)
+
+// savedSearchPollInterval is how often the matcher looks for due searches
+const savedSearchPollInterval = time.Minute
+
+// maxSavedSearchTags bounds the tag filter of a saved search
+const maxSavedSearchTags = 20
+
+// savedSearchVisibilityLag is how far a run stays behind the database clock. A
+// product's created_at is the start of its inserting transaction, so a product
+// committed after a run could carry a created_at before it; lagging gives such
+// transactions time to commit before their products' window is passed.
+const savedSearchVisibilityLag = 30 * time.Second
+
+// SavedSearchNotifier tells a user about new products matching one of their saved searches
+type SavedSearchNotifier interface {
+	// NotifySavedSearchMatches lists products, the newest of total new matches
+	NotifySavedSearchMatches(ctx context.Context, search models.SavedSearch, products []models.Product, total int) error
+}
+
+// SavedSearchConfig controls the saved search matcher
+type SavedSearchConfig struct {
+	Interval      time.Duration // How often each saved search runs
+	BatchSize     int           // Searches claimed at a time
+	RatePerSecond float64       // Searches run per second across a batch
+}
+
+// SavedSearchService stores users' saved searches and periodically notifies them of new matches
+type SavedSearchService struct {
+	repo     *repository.Repository
+	notifier SavedSearchNotifier
+	cfg      SavedSearchConfig
+	limiter  *rate.Limiter
+}
+
+// NewSavedSearchService creates a saved search service. notifier may be nil when the
+// matcher does not run in this process.
+func NewSavedSearchService(repo *repository.Repository, notifier SavedSearchNotifier, cfg SavedSearchConfig) *SavedSearchService {
+	return &SavedSearchService{
+		repo:     repo,
+		notifier: notifier,
+		cfg:      cfg,
+		limiter:  rate.NewLimiter(rate.Limit(cfg.RatePerSecond), 1),
+	}
+}
+
+// SaveSearch stores a user's product filters. Only products created from now on are
+// reported, so saving a broad search does not flood the user with the existing catalog.
+func (s *SavedSearchService) SaveSearch(ctx context.Context, req *models.SaveSearchRequest) (*models.SavedSearch, error) {
+	search, err := validateSaveSearchRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if search.CategoryID != "" {
+		exists, err := s.repo.Category.ExistsByID(ctx, search.CategoryID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check category existence: %w", err)
+		}
+		if !exists {
+			return nil, domainerr.NotFound("category not found")
+		}
+	}
+
+	if err := s.repo.SavedSearch.Create(ctx, search, models.MaxSavedSearchesPerUser); err != nil {
+		if errors.Is(err, repository.ErrSavedSearchLimitReached) {
+			return nil, domainerr.Conflict("saved search limit reached: at most %d per user", models.MaxSavedSearchesPerUser)
+		}
+		return nil, err
+	}
+	return search, nil
+}
+
+// ListSavedSearches returns the saved searches of a user, newest first
+func (s *SavedSearchService) ListSavedSearches(ctx context.Context, userID int64) ([]models.SavedSearch, error) {
+	if userID <= 0 {
+		return nil, domainerr.InvalidArgument("invalid saved search: user ID is required")
+	}
+	return s.repo.SavedSearch.ListByUser(ctx, userID)
+}
+
+// DeleteSavedSearch deletes a saved search of the user
+func (s *SavedSearchService) DeleteSavedSearch(ctx context.Context, id string, userID int64) error {
+	if userID <= 0 {
+		return domainerr.InvalidArgument("invalid saved search: user ID is required")
+	}
+	if _, err := uuid.Parse(id); err != nil {
+		return domainerr.InvalidArgument("invalid saved search: invalid ID %q", id)
+	}
+	if err := s.repo.SavedSearch.Delete(ctx, id, userID); err != nil {
+		if errors.Is(err, repository.ErrSavedSearchNotFound) {
+			return domainerr.NotFound("saved search not found")
+		}
+		return err
+	}
+	return nil
+}
+
+// RunMatcher periodically runs the saved searches that are due and notifies their
+// users of the products created since the previous run. Searches are run at most
+// RatePerSecond at a time, so a large backlog is spread out instead of loading the
+// catalog and notification service at once.
+func (s *SavedSearchService) RunMatcher(ctx context.Context) {
+	pollInterval := savedSearchPollInterval
+	if s.cfg.Interval < pollInterval {
+		pollInterval = s.cfg.Interval
+	}
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	// Long enough for a whole batch at the configured rate
+	lease := time.Duration(float64(s.cfg.BatchSize)/s.cfg.RatePerSecond*float64(time.Second)) + time.Minute
+
+	for {
+		for {
+			due, claimedAt, err := s.repo.SavedSearch.ClaimDue(ctx, s.cfg.Interval, lease, s.cfg.BatchSize)
+			if err != nil {
+				if ctx.Err() == nil {
+					log.Printf("Failed to get due saved searches: %v", err)
+				}
+				break
+			}
+			for _, search := range due {
+				if err := s.limiter.Wait(ctx); err != nil {
+					return
+				}
+				s.runSearch(ctx, search, claimedAt.Add(-savedSearchVisibilityLag))
+			}
+			// A short batch means the backlog is drained
+			if len(due) < s.cfg.BatchSize {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// runSearch notifies the user of products created since the search last ran, up to
+// runAt. When the notification fails the run is not recorded: the claim runs out and
+// the next run reports the same products.
+func (s *SavedSearchService) runSearch(ctx context.Context, search models.SavedSearch, runAt time.Time) {
+	// Never move last_run_at back, which would report products again
+	if runAt.Before(search.LastRunAt) {
+		runAt = search.LastRunAt
+	}
+
+	products, total, err := s.repo.Product.ListCreatedBetween(ctx, search.ListRequest(), search.LastRunAt, runAt, models.MaxSavedSearchMatchesSent)
+	if err != nil {
+		log.Printf("Failed to run saved search %s: %v", search.ID, err)
+		return
+	}
+	if len(products) > 0 {
+		if err := s.notifier.NotifySavedSearchMatches(ctx, search, products, total); err != nil {
+			log.Printf("Failed to notify user %d of saved search %s matches: %v", search.UserID, search.ID, err)
+			return
+		}
+	}
+
+	if err := s.repo.SavedSearch.MarkRun(ctx, search.ID, runAt); err != nil {
+		log.Printf("Failed to record run of saved search %s: %v", search.ID, err)
+	}
+}
+
+func validateSaveSearchRequest(req *models.SaveSearchRequest) (*models.SavedSearch, error) {
+	if req == nil {
+		return nil, domainerr.InvalidArgument("invalid saved search: request is required")
+	}
+	if req.UserID <= 0 {
+		return nil, domainerr.InvalidArgument("invalid saved search: user ID is required")
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, domainerr.InvalidArgument("invalid saved search: name is required")
+	}
+	if utf8.RuneCountInString(name) > models.MaxSavedSearchNameLength {
+		return nil, domainerr.InvalidArgument("invalid saved search: name must be at most %d characters", models.MaxSavedSearchNameLength)
+	}
+
+	search := &models.SavedSearch{
+		UserID:     req.UserID,
+		Name:       name,
+		CategoryID: strings.TrimSpace(req.CategoryID),
+		BrandID:    strings.TrimSpace(req.BrandID),
+		MinPrice:   req.MinPrice,
+		MaxPrice:   req.MaxPrice,
+	}
+	for _, id := range []string{search.CategoryID, search.BrandID} {
+		if id == "" {
+			continue
+		}
+		if _, err := uuid.Parse(id); err != nil {
+			return nil, domainerr.InvalidArgument("invalid saved search: invalid ID %q", id)
+		}
+	}
+
+	if len(req.TagIDs) > maxSavedSearchTags {
+		return nil, domainerr.InvalidArgument("invalid saved search: at most %d tags are allowed", maxSavedSearchTags)
+	}
+	for _, id := range req.TagIDs {
+		id = strings.TrimSpace(id)
+		if _, err := uuid.Parse(id); err != nil {
+			return nil, domainerr.InvalidArgument("invalid saved search: invalid tag ID %q", id)
+		}
+		search.TagIDs = append(search.TagIDs, id)
+	}
+
+	if search.MinPrice < 0 || search.MaxPrice < 0 {
+		return nil, domainerr.InvalidArgument("invalid saved search: prices cannot be negative")
+	}
+	if search.MaxPrice > 0 && search.MinPrice > search.MaxPrice {
+		return nil, domainerr.InvalidArgument("invalid saved search: min_price cannot exceed max_price")
+	}
+
+	// Without filters every new product would match
+	if search.CategoryID == "" && search.BrandID == "" && len(search.TagIDs) == 0 &&
+		search.MinPrice == 0 && search.MaxPrice == 0 {
+		return nil, domainerr.InvalidArgument("invalid saved search: at least one filter is required")
+	}
+
+	return search, nil
+}
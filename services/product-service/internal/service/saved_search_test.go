@@ -0,0 +1,99 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/datngth03/ecommerce-go-app/services/product-service/internal/models"
+	"github.com/datngth03/ecommerce-go-app/services/product-service/internal/repository"
+	"github.com/datngth03/ecommerce-go-app/shared/pkg/domainerr"
+)
+
+// savedSearchRepo enforces the per-user limit like the PostgreSQL repository and records runs
+type savedSearchRepo struct {
+	repository.SavedSearchRepository
+	count int
+	runAt time.Time
+}
+
+func (r *savedSearchRepo) Create(ctx context.Context, search *models.SavedSearch, limit int) error {
+	if r.count >= limit {
+		return repository.ErrSavedSearchLimitReached
+	}
+	r.count++
+	return nil
+}
+
+func (r *savedSearchRepo) MarkRun(ctx context.Context, id string, runAt time.Time) error {
+	r.runAt = runAt
+	return nil
+}
+
+// newProductsRepo returns the newest limit of total new products
+type newProductsRepo struct {
+	repository.ProductRepository
+	total int
+	after time.Time
+	until time.Time
+}
+
+func (r *newProductsRepo) ListCreatedBetween(ctx context.Context, req *models.ListProductsRequest, after, until time.Time, limit int) ([]models.Product, int, error) {
+	r.after, r.until = after, until
+	products := make([]models.Product, min(r.total, limit))
+	for i := range products {
+		products[i] = models.Product{ID: "p", Name: "Product"}
+	}
+	return products, r.total, nil
+}
+
+type recordingNotifier struct {
+	sent  int
+	total int
+}
+
+func (n *recordingNotifier) NotifySavedSearchMatches(ctx context.Context, search models.SavedSearch, products []models.Product, total int) error {
+	n.sent, n.total = len(products), total
+	return nil
+}
+
+func TestSaveSearchLimitReached(t *testing.T) {
+	repo := &savedSearchRepo{count: models.MaxSavedSearchesPerUser}
+	svc := NewSavedSearchService(&repository.Repository{SavedSearch: repo}, nil, SavedSearchConfig{RatePerSecond: 1})
+
+	_, err := svc.SaveSearch(context.Background(), &models.SaveSearchRequest{UserID: 1, Name: "Cheap", MaxPrice: 10})
+	if !domainerr.Is(err, domainerr.KindConflict) {
+		t.Errorf("error = %v, want Conflict", err)
+	}
+}
+
+func TestRunSearchReportsTotalMatches(t *testing.T) {
+	searches := &savedSearchRepo{}
+	products := &newProductsRepo{total: models.MaxSavedSearchMatchesSent + 5}
+	notifier := &recordingNotifier{}
+	svc := NewSavedSearchService(&repository.Repository{SavedSearch: searches, Product: products}, notifier, SavedSearchConfig{RatePerSecond: 1})
+
+	lastRun := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	runAt := lastRun.Add(time.Hour)
+	svc.runSearch(context.Background(), models.SavedSearch{ID: "s1", LastRunAt: lastRun}, runAt)
+
+	if notifier.sent != models.MaxSavedSearchMatchesSent || notifier.total != products.total {
+		t.Errorf("notified %d of %d products, want %d of %d", notifier.sent, notifier.total, models.MaxSavedSearchMatchesSent, products.total)
+	}
+	if !products.until.Equal(runAt) || !searches.runAt.Equal(runAt) {
+		t.Errorf("listed until %v and marked run at %v, want %v", products.until, searches.runAt, runAt)
+	}
+}
+
+func TestRunSearchNeverMovesLastRunBack(t *testing.T) {
+	searches := &savedSearchRepo{}
+	products := &newProductsRepo{}
+	svc := NewSavedSearchService(&repository.Repository{SavedSearch: searches, Product: products}, &recordingNotifier{}, SavedSearchConfig{RatePerSecond: 1})
+
+	lastRun := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	svc.runSearch(context.Background(), models.SavedSearch{ID: "s1", LastRunAt: lastRun}, lastRun.Add(-savedSearchVisibilityLag))
+
+	if !searches.runAt.Equal(lastRun) {
+		t.Errorf("marked run at %v, want the previous run %v", searches.runAt, lastRun)
+	}
+}
@@ -0,0 +1,65 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/datngth03/ecommerce-go-app/services/product-service/internal/models"
+)
+
+// SlugPolicy controls how slugs are maintained after creation
+type SlugPolicy struct {
+	// RegenerateOnRename regenerates the slug when the name changes.
+	// Disabled by default so existing links keep working.
+	RegenerateOnRename bool
+}
+
+// maxSlugSuffix bounds the number of "-N" candidates tried on collision
+const maxSlugSuffix = 100
+
+// slugExistsFunc reports whether a slug is already taken
+type slugExistsFunc func(ctx context.Context, slug string) (bool, error)
+
+// generateUniqueSlug derives a slug from name and appends -2, -3, ... until it is free
+func generateUniqueSlug(ctx context.Context, name string, maxLength int, exists slugExistsFunc) (string, error) {
+	base := models.Slugify(name)
+	if len(base) > maxLength {
+		base = strings.TrimRight(base[:maxLength], "-")
+	}
+	if base == "" {
+		return "", fmt.Errorf("invalid slug: cannot generate slug from name, please provide one")
+	}
+
+	candidate := base
+	for n := 2; ; n++ {
+		taken, err := exists(ctx, candidate)
+		if err != nil {
+			return "", err
+		}
+		if !taken {
+			return candidate, nil
+		}
+		if n > maxSlugSuffix {
+			return "", fmt.Errorf("failed to generate unique slug for '%s'", name)
+		}
+		candidate = models.SlugWithSuffix(base, n, maxLength)
+	}
+}
+
+// checkManualSlug validates a client supplied slug and ensures it is free
+func checkManualSlug(ctx context.Context, slug string, maxLength int, exists slugExistsFunc) error {
+	if err := models.ValidateSlug(slug, maxLength); err != nil {
+		return fmt.Errorf("invalid slug: %w", err)
+	}
+
+	taken, err := exists(ctx, slug)
+	if err != nil {
+		return err
+	}
+	if taken {
+		return fmt.Errorf("slug '%s' already exists", slug)
+	}
+
+	return nil
+}
@@ -0,0 +1,75 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/datngth03/ecommerce-go-app/services/product-service/internal/models"
+	"github.com/datngth03/ecommerce-go-app/services/product-service/internal/repository"
+	"github.com/datngth03/ecommerce-go-app/shared/pkg/domainerr"
+	"github.com/google/uuid"
+)
+
+// maxInteractionQuantity bounds the units of one interaction
+const maxInteractionQuantity = 10000
+
+// RecordInteractions stores product views, add-to-cart and purchase events. The
+// whole batch is rejected if any entry is invalid; entries for unknown products
+// are dropped.
+func (s *ProductService) RecordInteractions(ctx context.Context, interactions []models.ProductInteraction) error {
+	if len(interactions) == 0 {
+		return domainerr.InvalidArgument("invalid interactions: at least one is required")
+	}
+	if len(interactions) > models.MaxInteractionsPerRecord {
+		return domainerr.InvalidArgument("invalid interactions: at most %d are allowed per call", models.MaxInteractionsPerRecord)
+	}
+
+	valid := make([]models.ProductInteraction, len(interactions))
+	for i, interaction := range interactions {
+		interaction.ProductID = strings.TrimSpace(interaction.ProductID)
+		if _, err := uuid.Parse(interaction.ProductID); err != nil {
+			return domainerr.InvalidArgument("invalid interactions: invalid product ID %q", interaction.ProductID)
+		}
+		switch interaction.Type {
+		case models.InteractionView:
+			interaction.Quantity = 1
+		case models.InteractionAddToCart, models.InteractionPurchase, models.InteractionPurchaseCancelled:
+			if interaction.Quantity == 0 {
+				interaction.Quantity = 1
+			}
+			if interaction.Quantity < 0 || interaction.Quantity > maxInteractionQuantity {
+				return domainerr.InvalidArgument("invalid interactions: quantity must be between 1 and %d", maxInteractionQuantity)
+			}
+		default:
+			return domainerr.InvalidArgument("invalid interactions: unknown type %q", interaction.Type)
+		}
+		if interaction.UserID < 0 {
+			return domainerr.InvalidArgument("invalid interactions: invalid user ID %d", interaction.UserID)
+		}
+		valid[i] = interaction
+	}
+
+	return s.repo.Interaction.Record(ctx, valid)
+}
+
+// GetProductStats returns the view, add-to-cart and purchase counts of a product
+// within the range
+func (s *ProductService) GetProductStats(ctx context.Context, productID string, within models.StatsRange) (*models.ProductStats, error) {
+	if _, err := uuid.Parse(productID); err != nil {
+		return nil, domainerr.InvalidArgument("invalid product ID %q", productID)
+	}
+	if within.From != nil && within.To != nil && !within.From.Before(*within.To) {
+		return nil, domainerr.InvalidArgument("invalid range: from must be before to")
+	}
+
+	// Stats of a deleted or unknown product would read as zeros; report it instead
+	if _, err := s.repo.Product.GetByID(ctx, productID); err != nil {
+		if errors.Is(err, repository.ErrProductNotFound) {
+			return nil, domainerr.NotFound("product with id %s not found", productID)
+		}
+		return nil, err
+	}
+
+	return s.repo.Interaction.GetStats(ctx, productID, within)
+}
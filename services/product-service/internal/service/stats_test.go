@@ -0,0 +1,42 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/datngth03/ecommerce-go-app/services/product-service/internal/models"
+	"github.com/datngth03/ecommerce-go-app/shared/pkg/domainerr"
+)
+
+func TestRecordInteractionsRejectsInvalidBatches(t *testing.T) {
+	const productID = "3f8b6c2e-1d4a-4e7b-9c0d-5a6b7c8d9e0f"
+	svc := &ProductService{}
+
+	tests := []struct {
+		name         string
+		interactions []models.ProductInteraction
+	}{
+		{"empty batch", nil},
+		{"invalid product ID", []models.ProductInteraction{{ProductID: "p1", Type: models.InteractionView}}},
+		{"unknown type", []models.ProductInteraction{{ProductID: productID, Type: "like"}}},
+		{"negative quantity", []models.ProductInteraction{{ProductID: productID, Type: models.InteractionPurchase, Quantity: -1}}},
+		{"negative user ID", []models.ProductInteraction{{ProductID: productID, Type: models.InteractionView, UserID: -1}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := svc.RecordInteractions(context.Background(), tt.interactions)
+			if !domainerr.Is(err, domainerr.KindInvalidArgument) {
+				t.Errorf("error = %v, want InvalidArgument", err)
+			}
+		})
+	}
+}
+
+func TestGetProductStatsRejectsInvalidRequests(t *testing.T) {
+	svc := &ProductService{}
+
+	if _, err := svc.GetProductStats(context.Background(), "not-a-uuid", models.StatsRange{}); !domainerr.Is(err, domainerr.KindInvalidArgument) {
+		t.Errorf("invalid product ID: error = %v, want InvalidArgument", err)
+	}
+}
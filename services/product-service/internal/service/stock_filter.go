@@ -0,0 +1,110 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/datngth03/ecommerce-go-app/services/product-service/internal/models"
+)
+
+// errStockUnavailable means inventory could not be asked, so stock cannot filter a listing
+var errStockUnavailable = errors.New("inventory unavailable")
+
+// listInStock returns the page of products matching req that have stock to sell.
+// Stock lives in the inventory service, so the IDs of all matching products are
+// checked in one batch lookup before paging; total counts in-stock products only.
+// The quantities are returned so availability can be filled in without another lookup.
+func (s *ProductService) listInStock(ctx context.Context, req *models.ListProductsRequest) ([]models.Product, int64, map[string]int32, error) {
+	ids, err := s.repo.Product.ListIDs(ctx, req)
+	if err != nil {
+		return nil, 0, nil, err
+	}
+	if len(ids) == 0 {
+		return []models.Product{}, 0, map[string]int32{}, nil
+	}
+
+	components, err := s.repo.Product.GetBundleComponents(ctx, ids)
+	if err != nil {
+		return nil, 0, nil, fmt.Errorf("failed to get bundle components: %w", err)
+	}
+	variants, err := s.repo.Product.GetVariants(ctx, ids)
+	if err != nil {
+		return nil, 0, nil, fmt.Errorf("failed to get product variants: %w", err)
+	}
+
+	lookup := make([]string, 0, len(ids))
+	seen := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			lookup = append(lookup, id)
+		}
+		for _, component := range components[id] {
+			if !seen[component.ProductID] {
+				seen[component.ProductID] = true
+				lookup = append(lookup, component.ProductID)
+			}
+		}
+		for _, variant := range variants[id] {
+			lookup = append(lookup, variant.ID)
+		}
+	}
+
+	quantities, err := s.availableQuantities(ctx, lookup)
+	if err != nil {
+		return nil, 0, nil, fmt.Errorf("%w: %v", errStockUnavailable, err)
+	}
+
+	inStock := make([]string, 0, len(ids))
+	for _, id := range ids {
+		quantity := quantities[id]
+		switch {
+		case len(components[id]) > 0:
+			quantity = bundleAvailableQuantity(components[id], quantities)
+		case len(variants[id]) > 0:
+			quantity = variantsAvailableQuantity(variants[id], quantities)
+		}
+		if quantity > 0 {
+			inStock = append(inStock, id)
+		}
+	}
+
+	total := int64(len(inStock))
+	start := (req.Page - 1) * req.PageSize
+	if start >= len(inStock) {
+		return []models.Product{}, total, quantities, nil
+	}
+	end := min(start+req.PageSize, len(inStock))
+	page := inStock[start:end]
+
+	products, err := s.repo.Product.GetByIDs(ctx, page)
+	if err != nil {
+		return nil, 0, nil, err
+	}
+
+	// GetByIDs does not keep the list order
+	position := make(map[string]int, len(page))
+	for i, id := range page {
+		position[id] = i
+	}
+	ordered := make([]models.Product, len(page))
+	found := 0
+	for _, product := range products {
+		if i, ok := position[product.ID]; ok {
+			ordered[i] = product
+			found++
+		}
+	}
+	if found < len(page) {
+		// A product was deleted between the two queries; drop the gaps
+		kept := ordered[:0]
+		for _, product := range ordered {
+			if product.ID != "" {
+				kept = append(kept, product)
+			}
+		}
+		ordered = kept
+	}
+	return ordered, total, quantities, nil
+}
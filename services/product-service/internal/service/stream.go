@@ -0,0 +1,61 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/datngth03/ecommerce-go-app/services/product-service/internal/models"
+)
+
+const (
+	defaultStreamBatchSize = 500
+	maxStreamBatchSize     = 1000
+)
+
+// StreamProducts sends every product matching the filters to send in batches, without
+// pagination, for exports and search reindexing. Only one batch is held in memory; the scan
+// stops when ctx is cancelled or send fails.
+func (s *ProductService) StreamProducts(ctx context.Context, req *models.StreamProductsRequest, send func([]models.ProductResponse) error) error {
+	if req == nil {
+		return fmt.Errorf("request is required")
+	}
+
+	batchSize := req.BatchSize
+	if batchSize <= 0 {
+		batchSize = defaultStreamBatchSize
+	}
+	if batchSize > maxStreamBatchSize {
+		batchSize = maxStreamBatchSize
+	}
+
+	filter := &models.ListProductsRequest{
+		CategoryID: strings.TrimSpace(req.CategoryID),
+		BrandID:    strings.TrimSpace(req.BrandID),
+		TagIDs:     normalizeIDs(req.TagIDs),
+	}
+
+	if filter.CategoryID != "" {
+		exists, err := s.repo.Category.ExistsByID(ctx, filter.CategoryID)
+		if err != nil {
+			return fmt.Errorf("failed to check category existence: %w", err)
+		}
+		if !exists {
+			return fmt.Errorf("category not found")
+		}
+	}
+
+	return s.repo.Product.Stream(ctx, filter, batchSize, func(products []models.Product) error {
+		batch := make([]models.ProductResponse, len(products))
+		for i, product := range products {
+			batch[i] = product.ToResponse()
+		}
+		if err := s.attachComponents(ctx, batch); err != nil {
+			return fmt.Errorf("failed to get bundle components: %w", err)
+		}
+		if err := s.attachVariants(ctx, batch); err != nil {
+			return fmt.Errorf("failed to get product variants: %w", err)
+		}
+		return send(batch)
+	})
+}
@@ -0,0 +1,146 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/datngth03/ecommerce-go-app/services/product-service/internal/models"
+)
+
+// variantSKUPattern restricts SKUs to characters safe in URLs, labels and exports
+var variantSKUPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+// resolveVariants validates the variants of a product. productID is empty for a new
+// product. Bundles are stocked as their components, so they cannot have variants,
+// and neither can a product contained in a bundle.
+func (s *ProductService) resolveVariants(ctx context.Context, productID string, isBundle bool, variants []models.ProductVariant) ([]models.ProductVariant, error) {
+	if len(variants) > models.MaxProductVariants {
+		return nil, fmt.Errorf("invalid variants: at most %d variants are allowed", models.MaxProductVariants)
+	}
+	if isBundle {
+		return nil, fmt.Errorf("invalid variants: a bundle cannot have variants")
+	}
+
+	resolved := make([]models.ProductVariant, 0, len(variants))
+	skus := make(map[string]bool, len(variants))
+	combinations := make(map[string]bool, len(variants))
+	for _, variant := range variants {
+		sku := strings.TrimSpace(variant.SKU)
+		if sku == "" {
+			return nil, fmt.Errorf("invalid variants: SKU is required")
+		}
+		if len(sku) > models.MaxVariantSKULength || !variantSKUPattern.MatchString(sku) {
+			return nil, fmt.Errorf("invalid variants: SKU %q must be at most %d letters, digits, '.', '_' or '-'", sku, models.MaxVariantSKULength)
+		}
+		if skus[sku] {
+			return nil, fmt.Errorf("invalid variants: SKU %s is listed more than once", sku)
+		}
+		if variant.Price < 0 || variant.Price > 999999.99 {
+			return nil, fmt.Errorf("invalid variants: price of SKU %s must be between 0 and 999999.99", sku)
+		}
+
+		attributes, key, err := normalizeVariantAttributes(sku, variant.Attributes)
+		if err != nil {
+			return nil, err
+		}
+		if combinations[key] {
+			return nil, fmt.Errorf("invalid variants: SKU %s has the same attributes as another variant", sku)
+		}
+
+		skus[sku] = true
+		combinations[key] = true
+		resolved = append(resolved, models.ProductVariant{SKU: sku, Attributes: attributes, Price: variant.Price})
+	}
+
+	if productID != "" && len(resolved) > 0 {
+		contained, err := s.repo.Product.IsBundleComponent(ctx, productID)
+		if err != nil {
+			return nil, err
+		}
+		if contained {
+			return nil, fmt.Errorf("invalid variants: product is a component of a bundle")
+		}
+	}
+
+	return resolved, nil
+}
+
+// isBundleAfterUpdate reports whether product is a bundle once the update applies:
+// components set by the update decide, otherwise the stored ones do
+func (s *ProductService) isBundleAfterUpdate(ctx context.Context, product *models.Product) (bool, error) {
+	if product.Components != nil {
+		return len(product.Components) > 0, nil
+	}
+	components, err := s.repo.Product.GetBundleComponents(ctx, []string{product.ID})
+	if err != nil {
+		return false, err
+	}
+	return len(components[product.ID]) > 0, nil
+}
+
+// normalizeVariantAttributes trims and checks the attributes of one variant. It also
+// returns a key identifying the combination regardless of attribute order and case.
+func normalizeVariantAttributes(sku string, attributes []models.VariantAttribute) ([]models.VariantAttribute, string, error) {
+	if len(attributes) == 0 || len(attributes) > models.MaxVariantAttributes {
+		return nil, "", fmt.Errorf("invalid variants: SKU %s must have between 1 and %d attributes", sku, models.MaxVariantAttributes)
+	}
+
+	normalized := make([]models.VariantAttribute, len(attributes))
+	pairs := make([]string, len(attributes))
+	names := make(map[string]bool, len(attributes))
+	for i, attribute := range attributes {
+		name := strings.TrimSpace(attribute.Name)
+		value := strings.TrimSpace(attribute.Value)
+		if name == "" || value == "" {
+			return nil, "", fmt.Errorf("invalid variants: attributes of SKU %s need a name and a value", sku)
+		}
+		if len(name) > models.MaxVariantAttributeLength || len(value) > models.MaxVariantAttributeLength {
+			return nil, "", fmt.Errorf("invalid variants: attribute names and values of SKU %s must be at most %d characters", sku, models.MaxVariantAttributeLength)
+		}
+		lowerName := strings.ToLower(name)
+		if names[lowerName] {
+			return nil, "", fmt.Errorf("invalid variants: SKU %s has attribute %s more than once", sku, name)
+		}
+		names[lowerName] = true
+		normalized[i] = models.VariantAttribute{Name: name, Value: value}
+		pairs[i] = lowerName + "=" + strings.ToLower(value)
+	}
+
+	sort.Strings(pairs)
+	return normalized, strings.Join(pairs, "\x00"), nil
+}
+
+// attachVariants fills in the variants of products with one lookup
+func (s *ProductService) attachVariants(ctx context.Context, products []models.ProductResponse) error {
+	if len(products) == 0 {
+		return nil
+	}
+
+	ids := make([]string, len(products))
+	for i := range products {
+		ids[i] = products[i].ID
+	}
+
+	variants, err := s.repo.Product.GetVariants(ctx, ids)
+	if err != nil {
+		return err
+	}
+
+	for i := range products {
+		products[i].Variants = variants[products[i].ID]
+	}
+	return nil
+}
+
+// variantsAvailableQuantity is the stock of all variants together; the stock of
+// the product itself is not sold once it has variants
+func variantsAvailableQuantity(variants []models.ProductVariant, quantities map[string]int32) int32 {
+	var total int32
+	for _, variant := range variants {
+		total += quantities[variant.ID]
+	}
+	return total
+}
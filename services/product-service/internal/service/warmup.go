@@ -0,0 +1,63 @@
+package service
+
+import (
+	"context"
+	"log"
+	"strings"
+
+	"github.com/datngth03/ecommerce-go-app/services/product-service/internal/models"
+)
+
+// WarmCache loads up to limit products through the cached repository so the
+// first requests after a deploy don't all reach PostgreSQL. The configured
+// productIDs come first, in order; the newest products fill the rest. There
+// is no popularity data in the catalog yet, so the list is the hot-product
+// signal. Products that fail to load are skipped. It returns how many were warmed.
+func (s *ProductService) WarmCache(ctx context.Context, productIDs []string, limit int) int {
+	if limit <= 0 {
+		return 0
+	}
+
+	seen := make(map[string]bool, limit)
+	ids := make([]string, 0, limit)
+	for _, id := range productIDs {
+		id = strings.TrimSpace(id)
+		if id != "" && !seen[id] && len(ids) < limit {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+
+	if len(ids) < limit {
+		newest, _, err := s.repo.Product.List(ctx, &models.ListProductsRequest{Page: 1, PageSize: limit})
+		if err != nil {
+			log.Printf("Warning: cache warm-up could not list newest products: %v", err)
+		}
+		for _, product := range newest {
+			if !seen[product.ID] && len(ids) < limit {
+				seen[product.ID] = true
+				ids = append(ids, product.ID)
+			}
+		}
+	}
+
+	warmed := 0
+	for _, id := range ids {
+		if ctx.Err() != nil {
+			break
+		}
+		product, err := s.repo.Product.GetByID(ctx, id)
+		if err != nil {
+			log.Printf("Warning: cache warm-up skipped product %s: %v", id, err)
+			continue
+		}
+		// Product pages are also opened by slug
+		if product.Slug != "" {
+			if _, err := s.repo.Product.GetBySlug(ctx, product.Slug); err != nil {
+				log.Printf("Warning: cache warm-up could not cache slug of product %s: %v", id, err)
+			}
+		}
+		warmed++
+	}
+	return warmed
+}
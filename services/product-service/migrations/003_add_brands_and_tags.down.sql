@@ -0,0 +1,13 @@
+-- Migration: 003_add_brands_and_tags.down.sql
+-- Description: Rollback brands and tags
+
+DROP TRIGGER IF EXISTS update_brands_updated_at ON brands;
+
+DROP INDEX IF EXISTS idx_product_tags_tag_id;
+DROP INDEX IF EXISTS idx_products_category_brand;
+DROP INDEX IF EXISTS idx_products_brand_id;
+
+DROP TABLE IF EXISTS product_tags;
+ALTER TABLE products DROP COLUMN IF EXISTS brand_id;
+DROP TABLE IF EXISTS tags;
+DROP TABLE IF EXISTS brands;
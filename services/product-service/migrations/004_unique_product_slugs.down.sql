@@ -0,0 +1,5 @@
+-- Migration: 004_unique_product_slugs.down.sql
+-- Description: Rollback unique product slugs
+
+DROP INDEX IF EXISTS idx_products_slug_unique;
+CREATE INDEX IF NOT EXISTS idx_products_slug ON products(slug);
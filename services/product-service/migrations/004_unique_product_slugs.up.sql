@@ -0,0 +1,32 @@
+-- Migration: 004_unique_product_slugs.sql
+-- Description: Enforce unique product slugs so GetProductBySlug is unambiguous
+
+-- De-duplicate existing slugs: keep the oldest product, suffix the rest with the first
+-- free -2, -3, ... (a plain -n could collide with an existing slug such as "foo-2")
+DO $$
+DECLARE
+    dup RECORD;
+    n INTEGER;
+    candidate TEXT;
+BEGIN
+    FOR dup IN
+        SELECT id, slug FROM (
+            SELECT id, slug, ROW_NUMBER() OVER (PARTITION BY slug ORDER BY created_at, id) AS rn
+            FROM products
+        ) ranked
+        WHERE rn > 1
+        ORDER BY slug, rn
+    LOOP
+        n := 2;
+        LOOP
+            candidate := dup.slug || '-' || n;
+            EXIT WHEN NOT EXISTS (SELECT 1 FROM products WHERE slug = candidate);
+            n := n + 1;
+        END LOOP;
+        UPDATE products SET slug = candidate WHERE id = dup.id;
+    END LOOP;
+END $$;
+
+-- Replace the plain index with a unique one
+DROP INDEX IF EXISTS idx_products_slug;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_products_slug_unique ON products(slug);
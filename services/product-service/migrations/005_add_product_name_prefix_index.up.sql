@@ -0,0 +1,7 @@
+-- Migration: 005_add_product_name_prefix_index.sql
+-- Description: Index for autocomplete prefix lookups (lower(name) LIKE 'prefix%') on active products
+
+CREATE INDEX IF NOT EXISTS idx_products_name_prefix ON products (lower(name) text_pattern_ops)
+WHERE is_active = true;
+
+COMMENT ON INDEX idx_products_name_prefix IS 'Optimizes autocomplete prefix matching on product names';
@@ -0,0 +1,15 @@
+-- Migration: 006_create_outbox_events_table.sql
+-- Description: Transactional outbox for product events, published to RabbitMQ by the outbox relay
+
+CREATE TABLE IF NOT EXISTS outbox_events (
+    id         BIGSERIAL PRIMARY KEY,
+    topic      VARCHAR(255) NOT NULL,
+    event_key  VARCHAR(255) NOT NULL DEFAULT '',
+    payload    JSONB NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    sent_at    TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_outbox_events_unsent ON outbox_events (id) WHERE sent_at IS NULL;
+
+COMMENT ON TABLE outbox_events IS 'Events written with the product change that caused them, relayed to the broker after commit';
@@ -0,0 +1,5 @@
+-- Migration: 007_add_product_bundles.down.sql
+-- Description: Rollback product bundles
+
+DROP INDEX IF EXISTS idx_product_bundle_components_component_id;
+DROP TABLE IF EXISTS product_bundle_components;
@@ -0,0 +1,17 @@
+-- Migration: 007_add_product_bundles.up.sql
+-- Description: Bundles (kits) sold as one product but stocked as their components.
+-- A product is a bundle when it has component rows.
+
+CREATE TABLE IF NOT EXISTS product_bundle_components (
+    bundle_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    -- A product cannot be deleted while a bundle contains it
+    component_id UUID NOT NULL REFERENCES products(id) ON DELETE RESTRICT,
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    PRIMARY KEY (bundle_id, component_id),
+    CHECK (bundle_id <> component_id)
+);
+
+-- Finds the bundles containing a product
+CREATE INDEX IF NOT EXISTS idx_product_bundle_components_component_id ON product_bundle_components(component_id);
+
+COMMENT ON TABLE product_bundle_components IS 'Components of bundle products; quantity is per bundle unit';
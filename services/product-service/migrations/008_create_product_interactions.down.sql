@@ -0,0 +1,5 @@
+-- Migration: 008_create_product_interactions.down.sql
+-- Description: Rollback product interactions
+
+DROP INDEX IF EXISTS idx_product_interactions_product_time;
+DROP TABLE IF EXISTS product_interactions;
@@ -0,0 +1,16 @@
+-- Migration: 008_create_product_interactions.up.sql
+-- Description: Views, add-to-cart and purchase events per product, aggregated into product stats
+
+CREATE TABLE IF NOT EXISTS product_interactions (
+    id          BIGSERIAL PRIMARY KEY,
+    product_id  UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    type        VARCHAR(32) NOT NULL CHECK (type IN ('view', 'add_to_cart', 'purchase', 'purchase_cancelled')),
+    quantity    INTEGER NOT NULL DEFAULT 1 CHECK (quantity > 0),
+    user_id     BIGINT,
+    occurred_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Stats of one product over a date range
+CREATE INDEX IF NOT EXISTS idx_product_interactions_product_time ON product_interactions (product_id, occurred_at);
+
+COMMENT ON TABLE product_interactions IS 'Append-only interaction log; purchase_cancelled rows offset purchases of cancelled orders';
@@ -0,0 +1,25 @@
+-- Migration: 009_add_product_variants.up.sql
+-- Description: Variants (size, color...) of a product, each with its own SKU and stock.
+-- A product has variants when it has variant rows; inventory keys their stock on the variant ID.
+
+CREATE TABLE IF NOT EXISTS product_variants (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    product_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    sku VARCHAR(64) NOT NULL UNIQUE,
+    -- Ordered [{"name": "size", "value": "M"}, ...]
+    attributes JSONB NOT NULL DEFAULT '[]',
+    -- NULL means the variant sells at the product price
+    price DECIMAL(10, 2) CHECK (price IS NULL OR price > 0),
+    position INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Variants of a product in display order
+CREATE INDEX IF NOT EXISTS idx_product_variants_product_id ON product_variants(product_id, position);
+
+CREATE TRIGGER update_product_variants_updated_at
+    BEFORE UPDATE ON product_variants
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+COMMENT ON TABLE product_variants IS 'Variants of a product; the id is the stock key in inventory and is kept while the SKU is';
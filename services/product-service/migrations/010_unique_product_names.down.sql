@@ -0,0 +1,4 @@
+-- Migration: 010_unique_product_names.down.sql
+-- Description: Rollback unique product names
+
+DROP INDEX IF EXISTS idx_products_name_unique;
@@ -0,0 +1,30 @@
+-- Migration: 010_unique_product_names.up.sql
+-- Description: Enforce unique product names so concurrent creates cannot both pass the service check
+
+-- De-duplicate existing names: keep the oldest product, suffix the rest with the first
+-- free (2), (3), ... (a plain (n) could collide with an existing name such as "Foo (2)")
+DO $$
+DECLARE
+    dup RECORD;
+    n INTEGER;
+    candidate TEXT;
+BEGIN
+    FOR dup IN
+        SELECT id, name FROM (
+            SELECT id, name, ROW_NUMBER() OVER (PARTITION BY name ORDER BY created_at, id) AS rn
+            FROM products
+        ) ranked
+        WHERE rn > 1
+        ORDER BY name, rn
+    LOOP
+        n := 2;
+        LOOP
+            candidate := LEFT(dup.name, 240) || ' (' || n || ')';
+            EXIT WHEN NOT EXISTS (SELECT 1 FROM products WHERE name = candidate);
+            n := n + 1;
+        END LOOP;
+        UPDATE products SET name = candidate WHERE id = dup.id;
+    END LOOP;
+END $$;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_products_name_unique ON products(name);
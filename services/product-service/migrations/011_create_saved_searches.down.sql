@@ -0,0 +1,6 @@
+-- Migration: 011_create_saved_searches.down.sql
+-- Description: Rollback saved searches
+
+DROP INDEX IF EXISTS idx_saved_searches_last_run;
+DROP INDEX IF EXISTS idx_saved_searches_user;
+DROP TABLE IF EXISTS saved_searches;
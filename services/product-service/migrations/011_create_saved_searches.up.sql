@@ -0,0 +1,26 @@
+-- Migration: 011_create_saved_searches.up.sql
+-- Description: Product list filters saved by users, rerun periodically to notify them of new matches
+
+CREATE TABLE IF NOT EXISTS saved_searches (
+    id            UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id       BIGINT NOT NULL,
+    name          VARCHAR(100) NOT NULL,
+    category_id   UUID,
+    brand_id      UUID,
+    tag_ids       UUID[] NOT NULL DEFAULT '{}',
+    min_price     DECIMAL(10, 2),
+    max_price     DECIMAL(10, 2),
+    -- Products created after this are reported by the next run
+    last_run_at   TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    -- Set while a matcher instance runs the search, so replicas don't notify twice
+    claimed_until TIMESTAMP WITH TIME ZONE,
+    created_at    TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- A user's saved searches, newest first
+CREATE INDEX IF NOT EXISTS idx_saved_searches_user ON saved_searches (user_id, created_at DESC);
+
+-- Searches due for a run
+CREATE INDEX IF NOT EXISTS idx_saved_searches_last_run ON saved_searches (last_run_at);
+
+COMMENT ON TABLE saved_searches IS 'Saved product filters; the matcher notifies users of products created since last_run_at';
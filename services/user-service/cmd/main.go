@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"flag"
 	"fmt"
 	"log"
 	"net"
@@ -20,8 +21,11 @@ import (
 	"github.com/datngth03/ecommerce-go-app/services/user-service/internal/repository"
 	"github.com/datngth03/ecommerce-go-app/services/user-service/internal/rpc"
 	"github.com/datngth03/ecommerce-go-app/services/user-service/internal/service"
+	"github.com/datngth03/ecommerce-go-app/services/user-service/pkg/utils"
 
 	sharedCache "github.com/datngth03/ecommerce-go-app/shared/pkg/cache"
+	"github.com/datngth03/ecommerce-go-app/shared/pkg/grpcsize"
+	sharedLogger "github.com/datngth03/ecommerce-go-app/shared/pkg/logger"
 	sharedMiddleware "github.com/datngth03/ecommerce-go-app/shared/pkg/middleware"
 	sharedTLS "github.com/datngth03/ecommerce-go-app/shared/pkg/tlsutil"
 	sharedTracing "github.com/datngth03/ecommerce-go-app/shared/pkg/tracing"
@@ -39,11 +43,18 @@ import (
 )
 
 func main() {
+	bootstrapAdmin := flag.Bool("bootstrap-admin", false,
+		"create the admin account from ADMIN_BOOTSTRAP_* if it does not exist, then exit")
+	flag.Parse()
+
 	// 1. Load Configuration
 	cfg, err := config.Load()
 	if err != nil {
 		log.Fatalf("Failed to load configuration: %v", err)
 	}
+	if err := sharedLogger.Init(cfg.Logging); err != nil {
+		log.Fatalf("Failed to initialize logger: %v", err)
+	}
 	log.Printf("User Service v%s starting in %s mode...", cfg.Service.Version, cfg.Service.Environment)
 
 	// 2. Initialize Distributed Tracing
@@ -163,19 +174,39 @@ func main() {
 	}
 
 	tokenRepo := repository.NewRedisTokenRepository(redisClient)
+	auditRepo := repository.NewSQLAuditRepository(sqlDB)
+	twoFactorRepo := repository.NewSQLTwoFactorRepository(sqlDB)
 
 	// 6. Initialize Services
+	jwtKeys, err := utils.LoadJWTKeys(cfg.Auth.JWTSecret, cfg.Signing.PrivateKeyFile, cfg.Signing.PreviousPublicKeyFiles)
+	if err != nil {
+		log.Fatalf("Failed to load JWT signing keys: %v", err)
+	}
+	if jwtKeys.PrivateKey != nil {
+		log.Printf("✓ Signing access tokens with RS256 (kid %s)", jwtKeys.KeyID)
+	}
+
 	authService := service.NewAuthService(
 		finalUserRepo,
 		tokenRepo,
-		cfg.Auth.JWTSecret,
+		jwtKeys,
 		cfg.Auth.AccessTokenTTL,
 		cfg.Auth.RefreshTokenTTL,
 		cfg.Auth.ResetTokenTTL,
 	)
 	userService := service.NewUserService(finalUserRepo, authService)
+	auditService := service.NewAuditService(auditRepo)
+	twoFactorService := service.NewTwoFactorService(finalUserRepo, twoFactorRepo, cfg.TwoFactor.Issuer, cfg.TwoFactor.EncryptionKey)
 	log.Println("✓ Services initialized")
 
+	// 6.1. One-shot admin bootstrap for fresh deployments
+	if *bootstrapAdmin {
+		if err := runBootstrapAdmin(ctx, userService, cfg.Bootstrap); err != nil {
+			log.Fatalf("Admin bootstrap failed: %v", err)
+		}
+		return
+	}
+
 	// Initialize metrics middleware
 	// middleware.InitMetrics()
 
@@ -183,14 +214,17 @@ func main() {
 	var grpcServerOpts []grpc.ServerOption
 	grpcServerOpts = append(grpcServerOpts, grpc.UnaryInterceptor(sharedTracing.UnaryServerInterceptor()))
 
+	// Same message size limit as the other services and their clients
+	grpcServerOpts = append(grpcServerOpts, grpcsize.ServerOptions(cfg.Server.MaxMessageSize)...)
+
 	// Enable TLS if configured
 	if cfg.Server.TLS.Enabled {
-		tlsCreds, err := sharedTLS.ServerTLSConfig(cfg.Server.TLS.CertFile, cfg.Server.TLS.KeyFile)
+		tlsCreds, err := sharedTLS.ServerCredentials(cfg.Server.TLS)
 		if err != nil {
 			log.Fatalf("Failed to load TLS credentials: %v", err)
 		}
 		grpcServerOpts = append(grpcServerOpts, grpc.Creds(tlsCreds))
-		log.Printf("✓ TLS enabled for gRPC server (cert: %s)", cfg.Server.TLS.CertFile)
+		log.Printf("✓ TLS enabled for gRPC server (cert: %s, client certificates required: %v)", cfg.Server.TLS.CertFile, cfg.Server.TLS.ClientAuth)
 	} else {
 		log.Println("⚠️  TLS disabled - using insecure connection")
 	}
@@ -198,7 +232,7 @@ func main() {
 	grpcServer := grpc.NewServer(grpcServerOpts...)
 
 	// Register User Service
-	userGRPCServer := rpc.NewGRPCServer(userService, authService)
+	userGRPCServer := rpc.NewGRPCServer(userService, authService, auditService, twoFactorService)
 	pb.RegisterUserServiceServer(grpcServer, userGRPCServer)
 
 	// Register Health Check Service
@@ -280,6 +314,12 @@ func main() {
 	// Prometheus metrics endpoint
 	router.GET("/metrics", gin.WrapH(promhttp.Handler()))
 
+	// Public keys for verifying RS256 access tokens without calling this service
+	router.GET("/.well-known/jwks.json", func(c *gin.Context) {
+		c.Header("Cache-Control", "public, max-age=300")
+		c.JSON(http.StatusOK, jwtKeys.JWKS())
+	})
+
 	httpServer := &http.Server{
 		Addr:    fmt.Sprintf(":%s", cfg.Server.HTTPPort),
 		Handler: router,
@@ -315,3 +355,22 @@ func main() {
 
 	log.Println("✓ User Service shutdown completed")
 }
+
+// runBootstrapAdmin creates the admin account unless it already exists
+func runBootstrapAdmin(ctx context.Context, users service.UserServiceInterface, cfg config.BootstrapConfig) error {
+	password, err := cfg.Password()
+	if err != nil {
+		return err
+	}
+
+	admin, created, err := users.BootstrapAdmin(ctx, cfg.AdminName, password)
+	if err != nil {
+		return err
+	}
+	if created {
+		log.Printf("✓ Admin account %s created (ID: %d)", admin.Email, admin.ID)
+	} else {
+		log.Printf("✓ Admin account %s already exists (ID: %d), nothing to do", admin.Email, admin.ID)
+	}
+	return nil
+}
@@ -1,7 +1,9 @@
 package config
 
 import (
+	"encoding/base64"
 	"fmt"
+	"os"
 	"strconv"
 	"strings"
 	"time"
@@ -11,15 +13,57 @@ import (
 
 // Config holds user service specific configuration
 type Config struct {
-	Service  sharedConfig.ServiceInfo
-	Server   sharedConfig.ServerConfig
-	Database sharedConfig.DatabaseConfig
-	Redis    sharedConfig.RedisConfig
-	Auth     sharedConfig.AuthConfig
-	Logging  sharedConfig.LoggingConfig
-	Security SecurityConfig
+	Service   sharedConfig.ServiceInfo
+	Server    sharedConfig.ServerConfig
+	Database  sharedConfig.DatabaseConfig
+	Redis     sharedConfig.RedisConfig
+	Auth      sharedConfig.AuthConfig
+	Logging   sharedConfig.LoggingConfig
+	Security  SecurityConfig
+	TwoFactor TwoFactorConfig
+	Bootstrap BootstrapConfig
+	Signing   SigningConfig
 }
 
+// SigningConfig holds the RSA keys access tokens are signed with. Without a
+// private key, tokens are signed HS256 with JWT_SECRET.
+type SigningConfig struct {
+	PrivateKeyFile         string   // PEM RSA private key signing RS256 tokens
+	PreviousPublicKeyFiles []string // PEM public keys of rotated-out signing keys, still verified and published
+}
+
+// BootstrapConfig holds the credentials of the admin account created by
+// user-service -bootstrap-admin
+type BootstrapConfig struct {
+	AdminName         string
+	AdminPassword     string
+	AdminPasswordFile string // File holding the password, e.g. a mounted secret; used when AdminPassword is empty
+}
+
+// Password returns the admin password from the environment or the password file
+func (b BootstrapConfig) Password() (string, error) {
+	if b.AdminPassword != "" {
+		return b.AdminPassword, nil
+	}
+	if b.AdminPasswordFile == "" {
+		return "", fmt.Errorf("ADMIN_BOOTSTRAP_PASSWORD or ADMIN_BOOTSTRAP_PASSWORD_FILE is required")
+	}
+	data, err := os.ReadFile(b.AdminPasswordFile)
+	if err != nil {
+		return "", fmt.Errorf("failed to read ADMIN_BOOTSTRAP_PASSWORD_FILE: %w", err)
+	}
+	return strings.TrimRight(string(data), "\r\n"), nil
+}
+
+// TwoFactorConfig contains TOTP two-factor authentication settings
+type TwoFactorConfig struct {
+	Issuer        string // Account issuer shown in authenticator apps
+	EncryptionKey []byte // AES-256 key encrypting the stored TOTP secrets
+}
+
+// defaultTwoFactorKey is the development-only TWO_FACTOR_ENCRYPTION_KEY (base64 of 32 bytes)
+const defaultTwoFactorKey = "ZGV2LW9ubHktdHdvLWZhY3Rvci1lbmNyeXB0aW9uLWs="
+
 // SecurityConfig contains security middleware settings
 type SecurityConfig struct {
 	RateLimit      RateLimitConfig
@@ -54,6 +98,32 @@ func Load() (*Config, error) {
 		Auth:     sharedConfig.LoadAuthConfig(),
 		Logging:  sharedConfig.LoadLoggingConfig(),
 		Security: LoadSecurityConfig(),
+		TwoFactor: TwoFactorConfig{
+			Issuer: sharedConfig.GetEnv("TWO_FACTOR_ISSUER", "E-commerce"),
+		},
+		Bootstrap: BootstrapConfig{
+			AdminName:         sharedConfig.GetEnv("ADMIN_BOOTSTRAP_NAME", "Administrator"),
+			AdminPassword:     sharedConfig.GetEnv("ADMIN_BOOTSTRAP_PASSWORD", ""),
+			AdminPasswordFile: sharedConfig.GetEnv("ADMIN_BOOTSTRAP_PASSWORD_FILE", ""),
+		},
+		Signing: SigningConfig{
+			PrivateKeyFile:         sharedConfig.GetEnv("JWT_PRIVATE_KEY_FILE", ""),
+			PreviousPublicKeyFiles: splitList(sharedConfig.GetEnv("JWT_PREVIOUS_PUBLIC_KEY_FILES", "")),
+		},
+	}
+
+	twoFactorKey, keyErr := base64.StdEncoding.DecodeString(sharedConfig.GetEnv("TWO_FACTOR_ENCRYPTION_KEY", defaultTwoFactorKey))
+	cfg.TwoFactor.EncryptionKey = twoFactorKey
+
+	v := sharedConfig.NewValidator(cfg.Service)
+	v.CheckServer(cfg.Server)
+	v.CheckDatabase(cfg.Database)
+	v.CheckRedis(cfg.Redis)
+	v.CheckAuth(cfg.Auth)
+	v.Check(keyErr == nil && len(twoFactorKey) == 32, "TWO_FACTOR_ENCRYPTION_KEY must be 32 bytes, base64 encoded")
+	v.RequireInProduction("TWO_FACTOR_ENCRYPTION_KEY")
+	if err := v.Err(); err != nil {
+		return nil, err
 	}
 
 	return cfg, nil
@@ -91,6 +161,14 @@ func (c *Config) PrintConfig() {
 	fmt.Printf("    Enabled: %v\n", c.Security.CORS.Enabled)
 	fmt.Printf("    Allowed Origins: %v\n", c.Security.CORS.AllowedOrigins)
 	fmt.Printf("  Request Timeout: %v\n", c.Security.RequestTimeout)
+
+	fmt.Printf("Token Signing:\n")
+	if c.Signing.PrivateKeyFile != "" {
+		fmt.Printf("  Algorithm: RS256 (%s)\n", c.Signing.PrivateKeyFile)
+	} else {
+		fmt.Printf("  Algorithm: HS256\n")
+	}
+	fmt.Printf("  Previous Public Keys: %d\n", len(c.Signing.PreviousPublicKeyFiles))
 }
 
 // LoadSecurityConfig loads security middleware configuration
@@ -105,13 +183,7 @@ func LoadSecurityConfig() SecurityConfig {
 	// Load CORS origins from environment
 	corsOrigins := []string{"http://localhost:3000", "http://localhost:8080"}
 	if corsEnv := sharedConfig.GetEnv("CORS_ALLOWED_ORIGINS", ""); corsEnv != "" {
-		origins := strings.Split(corsEnv, ",")
-		corsOrigins = make([]string, 0, len(origins))
-		for _, origin := range origins {
-			if trimmed := strings.TrimSpace(origin); trimmed != "" {
-				corsOrigins = append(corsOrigins, trimmed)
-			}
-		}
+		corsOrigins = splitList(corsEnv)
 	}
 
 	return SecurityConfig{
@@ -127,3 +199,18 @@ func LoadSecurityConfig() SecurityConfig {
 		RequestTimeout: sharedConfig.GetEnvAsDuration("REQUEST_TIMEOUT", 30*time.Second),
 	}
 }
+
+// splitList splits a comma-separated list, dropping blank entries
+func splitList(value string) []string {
+	if value == "" {
+		return nil
+	}
+	parts := strings.Split(value, ",")
+	items := make([]string, 0, len(parts))
+	for _, part := range parts {
+		if trimmed := strings.TrimSpace(part); trimmed != "" {
+			items = append(items, trimmed)
+		}
+	}
+	return items
+}
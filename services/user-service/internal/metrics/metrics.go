@@ -65,6 +65,17 @@ var (
 		[]string{"operation", "table"},
 	)
 
+	// UserCacheLookupsTotal counts user cache reads by key kind (id, email, profile)
+	// and result (hit, miss, error). Hit ratio:
+	// sum(rate(user_cache_lookups_total{result="hit"}[5m])) / sum(rate(user_cache_lookups_total[5m]))
+	UserCacheLookupsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "user_cache_lookups_total",
+			Help: "Total number of user cache lookups by result",
+		},
+		[]string{"cache", "result"},
+	)
+
 	// gRPC metrics
 	userGrpcRequestsTotal = promauto.NewCounterVec(
 		prometheus.CounterOpts{
@@ -100,6 +111,11 @@ func RecordDatabaseQuery(operation, table string, duration time.Duration) {
 	UserDatabaseQueryDuration.WithLabelValues(operation, table).Observe(duration.Seconds())
 }
 
+// RecordCacheLookup records a user cache read; result is hit, miss or error
+func RecordCacheLookup(cache, result string) {
+	UserCacheLookupsTotal.WithLabelValues(cache, result).Inc()
+}
+
 // RecordGRPCRequest records gRPC request metrics
 func RecordGRPCRequest(method, status string, duration time.Duration) {
 	userGrpcRequestsTotal.WithLabelValues(method, status).Inc()
@@ -56,3 +56,36 @@ type ResetPasswordRequest struct {
 	ResetToken  string `json:"reset_token" validate:"required"`
 	NewPassword string `json:"new_password" validate:"required,min=8"`
 }
+
+// TwoFactor is a user's TOTP two-factor state. EncryptedSecret is set from
+// EnableTwoFactor on, Enabled only once the first code is confirmed.
+type TwoFactor struct {
+	UserID          int64
+	EncryptedSecret string
+	Enabled         bool
+	LastStep        int64 // Last accepted TOTP time step
+}
+
+// AuditEntry records one mutating admin action
+type AuditEntry struct {
+	ID          int64     `json:"id"`
+	ActorUserID int64     `json:"actor_user_id"`
+	Action      string    `json:"action"`
+	TargetID    string    `json:"target_id"`
+	Method      string    `json:"method"`
+	Path        string    `json:"path"`
+	StatusCode  int32     `json:"status_code"`
+	SourceIP    string    `json:"source_ip"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// AuditFilter selects audit entries; zero fields do not filter
+type AuditFilter struct {
+	ActorUserID int64
+	Action      string
+	TargetID    string
+	From        time.Time
+	To          time.Time
+	Limit       int
+	Offset      int
+}
@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/datngth03/ecommerce-go-app/services/user-service/internal/metrics"
+	"github.com/datngth03/ecommerce-go-app/services/user-service/internal/models"
+)
+
+// AuditRepositoryInterface stores the audit trail of admin actions
+type AuditRepositoryInterface interface {
+	Create(ctx context.Context, entry *models.AuditEntry) error
+	List(ctx context.Context, filter models.AuditFilter) ([]*models.AuditEntry, int64, error)
+}
+
+type sqlAuditRepository struct {
+	db *sql.DB
+}
+
+// NewSQLAuditRepository creates an audit repository on PostgreSQL
+func NewSQLAuditRepository(db *sql.DB) AuditRepositoryInterface {
+	return &sqlAuditRepository{db: db}
+}
+
+func (r *sqlAuditRepository) Create(ctx context.Context, entry *models.AuditEntry) error {
+	start := time.Now()
+	defer func() {
+		metrics.RecordDatabaseQuery("INSERT", "audit_logs", time.Since(start))
+	}()
+
+	query := `
+		INSERT INTO audit_logs (actor_user_id, action, target_id, method, path, status_code, source_ip, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id`
+
+	return r.db.QueryRowContext(
+		ctx, query,
+		entry.ActorUserID, entry.Action, entry.TargetID, entry.Method,
+		entry.Path, entry.StatusCode, entry.SourceIP, entry.CreatedAt,
+	).Scan(&entry.ID)
+}
+
+// List returns one page of matching entries, newest first, and the total match count
+func (r *sqlAuditRepository) List(ctx context.Context, filter models.AuditFilter) ([]*models.AuditEntry, int64, error) {
+	start := time.Now()
+	defer func() {
+		metrics.RecordDatabaseQuery("SELECT", "audit_logs", time.Since(start))
+	}()
+
+	var conditions []string
+	var args []interface{}
+	where := func(condition string, arg interface{}) {
+		args = append(args, arg)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+	if filter.ActorUserID != 0 {
+		where("actor_user_id = $%d", filter.ActorUserID)
+	}
+	if filter.Action != "" {
+		where("action = $%d", filter.Action)
+	}
+	if filter.TargetID != "" {
+		where("target_id = $%d", filter.TargetID)
+	}
+	if !filter.From.IsZero() {
+		where("created_at >= $%d", filter.From)
+	}
+	if !filter.To.IsZero() {
+		where("created_at < $%d", filter.To)
+	}
+	whereClause := ""
+	if len(conditions) > 0 {
+		whereClause = " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	var total int64
+	if err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM audit_logs"+whereClause, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	query := fmt.Sprintf(`
+		SELECT id, actor_user_id, action, target_id, method, path, status_code, source_ip, created_at
+		FROM audit_logs%s
+		ORDER BY created_at DESC, id DESC
+		LIMIT $%d OFFSET $%d`, whereClause, len(args)+1, len(args)+2)
+	rows, err := r.db.QueryContext(ctx, query, append(args, filter.Limit, filter.Offset)...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var entries []*models.AuditEntry
+	for rows.Next() {
+		var entry models.AuditEntry
+		if err := rows.Scan(
+			&entry.ID, &entry.ActorUserID, &entry.Action, &entry.TargetID, &entry.Method,
+			&entry.Path, &entry.StatusCode, &entry.SourceIP, &entry.CreatedAt,
+		); err != nil {
+			return nil, 0, err
+		}
+		entries = append(entries, &entry)
+	}
+	return entries, total, rows.Err()
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/datngth03/ecommerce-go-app/services/user-service/internal/metrics"
 	"github.com/datngth03/ecommerce-go-app/services/user-service/internal/models"
 	"github.com/datngth03/ecommerce-go-app/shared/pkg/cache"
 )
@@ -22,6 +23,11 @@ const (
 	EmailLookupTTL  = 5 * time.Minute  // Email to user mapping
 )
 
+// invalidateAgainAfter is when a mutation deletes the user's keys a second time.
+// A read that missed the cache before the write may still store the old row once
+// the first delete has run; the second delete removes it.
+const invalidateAgainAfter = 500 * time.Millisecond
+
 // NewCachedUserRepository creates a cached user repository
 func NewCachedUserRepository(repo UserRepositoryInterface, cache *cache.RedisCache) *CachedUserRepository {
 	return &CachedUserRepository{
@@ -63,6 +69,7 @@ func (r *CachedUserRepository) GetByID(ctx context.Context, id int64) (*models.U
 
 	// Try cache first
 	err := r.cache.Get(ctx, cacheKey, &user)
+	recordLookup("id", err)
 	if err == nil {
 		return &user, nil
 	}
@@ -93,6 +100,7 @@ func (r *CachedUserRepository) GetByEmail(ctx context.Context, email string) (*m
 
 	// Try cache first
 	err := r.cache.Get(ctx, cacheKey, &user)
+	recordLookup("email", err)
 	if err == nil {
 		return &user, nil
 	}
@@ -121,47 +129,16 @@ func (r *CachedUserRepository) GetByEmail(ctx context.Context, email string) (*m
 	return dbUser, nil
 }
 
-// Update updates a user and invalidates its caches
+// Update updates a user and invalidates its caches. The updated user is not
+// written back: the next read loads it, so concurrent updates cannot leave an
+// older version in the cache.
 func (r *CachedUserRepository) Update(ctx context.Context, updateData *models.UserUpdateData) (*models.User, error) {
-	// Get current user data for cache invalidation
-	currentUser, err := r.repo.GetByID(ctx, updateData.ID)
-	if err != nil {
-		return nil, err
-	}
-
-	// Update in database
 	updatedUser, err := r.repo.Update(ctx, updateData)
 	if err != nil {
 		return nil, err
 	}
 
-	// Invalidate caches
-	keysToDelete := []string{
-		fmt.Sprintf("user:id:%d", updatedUser.ID),
-		fmt.Sprintf("user:email:%s", currentUser.Email),
-	}
-
-	// If email changed, also invalidate new email cache
-	if updatedUser.Email != currentUser.Email {
-		keysToDelete = append(keysToDelete, fmt.Sprintf("user:email:%s", updatedUser.Email))
-	}
-
-	if err := r.cache.Delete(ctx, keysToDelete...); err != nil {
-		fmt.Printf("Warning: failed to invalidate user caches: %v\n", err)
-	}
-
-	// Cache the updated user
-	cacheKeyID := fmt.Sprintf("user:id:%d", updatedUser.ID)
-	cacheKeyEmail := fmt.Sprintf("user:email:%s", updatedUser.Email)
-
-	if err := r.cache.Set(ctx, cacheKeyID, updatedUser, UserCacheTTL); err != nil {
-		fmt.Printf("Warning: failed to cache updated user: %v\n", err)
-	}
-
-	if err := r.cache.Set(ctx, cacheKeyEmail, updatedUser, EmailLookupTTL); err != nil {
-		fmt.Printf("Warning: failed to cache updated user email: %v\n", err)
-	}
-
+	r.invalidate(ctx, updatedUser)
 	return updatedUser, nil
 }
 
@@ -178,34 +155,74 @@ func (r *CachedUserRepository) Delete(ctx context.Context, id int64) error {
 		return err
 	}
 
-	// Invalidate caches
-	keysToDelete := []string{
-		fmt.Sprintf("user:id:%d", id),
-		fmt.Sprintf("user:email:%s", user.Email),
-	}
-
-	if err := r.cache.Delete(ctx, keysToDelete...); err != nil {
-		fmt.Printf("Warning: failed to invalidate deleted user caches: %v\n", err)
-	}
-
+	r.invalidate(ctx, user)
 	return nil
 }
 
-// UpdatePassword updates a user's password and invalidates caches
+// UpdatePassword updates a user's password and invalidates caches. The cached
+// user carries the password hash, so the email entry used by login must go too,
+// or the old password would keep working until it expires.
 func (r *CachedUserRepository) UpdatePassword(ctx context.Context, userID int64, hashedPassword string) error {
 	if err := r.repo.UpdatePassword(ctx, userID, hashedPassword); err != nil {
 		return err
 	}
 
-	// Invalidate user cache (password change might affect user data)
-	cacheKey := fmt.Sprintf("user:id:%d", userID)
-	if err := r.cache.Delete(ctx, cacheKey); err != nil {
-		fmt.Printf("Warning: failed to invalidate user cache after password update: %v\n", err)
+	user, err := r.repo.GetByID(ctx, userID)
+	if err != nil {
+		// Without the email only the ID keys can be dropped; the email entry expires with its TTL
+		fmt.Printf("Warning: failed to load user %d for cache invalidation: %v\n", userID, err)
+		user = &models.User{ID: userID}
 	}
-
+	r.invalidate(ctx, user)
 	return nil
 }
 
+// userKeys returns every cache key holding user
+func userKeys(user *models.User) []string {
+	keys := []string{
+		fmt.Sprintf("user:id:%d", user.ID),
+		fmt.Sprintf("user:profile:%d", user.ID),
+	}
+	if user.Email != "" {
+		keys = append(keys, fmt.Sprintf("user:email:%s", user.Email))
+	}
+	return keys
+}
+
+// invalidate deletes the cached copies of user after a write, then once more
+// after invalidateAgainAfter for reads that were already in flight
+func (r *CachedUserRepository) invalidate(ctx context.Context, user *models.User) {
+	keys := userKeys(user)
+	if err := r.cache.Delete(ctx, keys...); err != nil {
+		fmt.Printf("Warning: failed to invalidate caches of user %d: %v\n", user.ID, err)
+	}
+
+	time.AfterFunc(invalidateAgainAfter, func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+		if err := r.cache.Delete(ctx, keys...); err != nil {
+			fmt.Printf("Warning: failed to invalidate caches of user %d again: %v\n", user.ID, err)
+		}
+	})
+}
+
+// recordLookup counts a cache read as a hit, a miss or an error
+func recordLookup(kind string, err error) {
+	switch {
+	case err == nil:
+		metrics.RecordCacheLookup(kind, "hit")
+	case cache.IsCacheMiss(err):
+		metrics.RecordCacheLookup(kind, "miss")
+	default:
+		metrics.RecordCacheLookup(kind, "error")
+	}
+}
+
+// GetByIDs retrieves several users in one query (no caching - a batch rarely repeats)
+func (r *CachedUserRepository) GetByIDs(ctx context.Context, ids []int64) ([]*models.User, error) {
+	return r.repo.GetByIDs(ctx, ids)
+}
+
 // ExistsByEmail checks if user exists by email (no caching - security sensitive)
 func (r *CachedUserRepository) ExistsByEmail(ctx context.Context, email string) (bool, error) {
 	// Don't cache existence checks - they're security-sensitive
@@ -221,6 +238,7 @@ func (r *CachedUserRepository) GetProfile(ctx context.Context, id int64) (*model
 
 	// Try cache first
 	err := r.cache.Get(ctx, cacheKey, &user)
+	recordLookup("profile", err)
 	if err == nil {
 		return &user, nil
 	}
@@ -251,13 +269,7 @@ func (r *CachedUserRepository) InvalidateUserCache(ctx context.Context, userID i
 		return err
 	}
 
-	keysToDelete := []string{
-		fmt.Sprintf("user:id:%d", userID),
-		fmt.Sprintf("user:email:%s", user.Email),
-		fmt.Sprintf("user:profile:%d", userID),
-	}
-
-	return r.cache.Delete(ctx, keysToDelete...)
+	return r.cache.Delete(ctx, userKeys(user)...)
 }
 
 // WarmupCache pre-populates cache with frequently accessed users
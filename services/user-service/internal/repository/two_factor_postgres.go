@@ -0,0 +1,192 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/datngth03/ecommerce-go-app/services/user-service/internal/metrics"
+	"github.com/datngth03/ecommerce-go-app/services/user-service/internal/models"
+)
+
+// ErrTwoFactorState is returned when two-factor was enabled or disabled
+// concurrently, so the requested transition no longer applies
+var ErrTwoFactorState = errors.New("two-factor state changed")
+
+// TwoFactorRepositoryInterface stores TOTP secrets and recovery codes
+type TwoFactorRepositoryInterface interface {
+	Get(ctx context.Context, userID int64) (*models.TwoFactor, error)
+	// SetPendingSecret stores a secret that is not used until Enable
+	SetPendingSecret(ctx context.Context, userID int64, encryptedSecret string) error
+	// Enable activates the pending secret, records step as used and replaces the recovery codes
+	Enable(ctx context.Context, userID int64, step int64, recoveryCodeHashes []string) error
+	Disable(ctx context.Context, userID int64) error
+	// ClaimStep records step as used; false when it, or a later step, was used already
+	ClaimStep(ctx context.Context, userID int64, step int64) (bool, error)
+	// UseRecoveryCode marks an unused code as used; false when there is none
+	UseRecoveryCode(ctx context.Context, userID int64, codeHash string) (bool, error)
+}
+
+type sqlTwoFactorRepository struct {
+	db *sql.DB
+}
+
+// NewSQLTwoFactorRepository creates a two-factor repository on PostgreSQL
+func NewSQLTwoFactorRepository(db *sql.DB) TwoFactorRepositoryInterface {
+	return &sqlTwoFactorRepository{db: db}
+}
+
+func (r *sqlTwoFactorRepository) Get(ctx context.Context, userID int64) (*models.TwoFactor, error) {
+	start := time.Now()
+	defer func() {
+		metrics.RecordDatabaseQuery("SELECT", "users", time.Since(start))
+	}()
+
+	tf := models.TwoFactor{UserID: userID}
+	query := `
+		SELECT COALESCE(two_factor_secret, ''), two_factor_enabled, two_factor_last_step
+		FROM users
+		WHERE id = $1`
+
+	err := r.db.QueryRowContext(ctx, query, userID).Scan(&tf.EncryptedSecret, &tf.Enabled, &tf.LastStep)
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &tf, nil
+}
+
+func (r *sqlTwoFactorRepository) SetPendingSecret(ctx context.Context, userID int64, encryptedSecret string) error {
+	start := time.Now()
+	defer func() {
+		metrics.RecordDatabaseQuery("UPDATE", "users", time.Since(start))
+	}()
+
+	query := `
+		UPDATE users
+		SET two_factor_secret = $2, two_factor_last_step = 0, updated_at = NOW()
+		WHERE id = $1 AND NOT two_factor_enabled`
+
+	result, err := r.db.ExecContext(ctx, query, userID, encryptedSecret)
+	if err != nil {
+		return err
+	}
+	return expectOneRow(result, ErrTwoFactorState)
+}
+
+func (r *sqlTwoFactorRepository) Enable(ctx context.Context, userID int64, step int64, recoveryCodeHashes []string) error {
+	start := time.Now()
+	defer func() {
+		metrics.RecordDatabaseQuery("UPDATE", "users", time.Since(start))
+	}()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `
+		UPDATE users
+		SET two_factor_enabled = TRUE, two_factor_last_step = $2, updated_at = NOW()
+		WHERE id = $1 AND NOT two_factor_enabled AND two_factor_secret IS NOT NULL`,
+		userID, step)
+	if err != nil {
+		return err
+	}
+	if err := expectOneRow(result, ErrTwoFactorState); err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, "DELETE FROM two_factor_recovery_codes WHERE user_id = $1", userID); err != nil {
+		return err
+	}
+	for _, hash := range recoveryCodeHashes {
+		if _, err := tx.ExecContext(ctx,
+			"INSERT INTO two_factor_recovery_codes (user_id, code_hash) VALUES ($1, $2)",
+			userID, hash); err != nil {
+			return fmt.Errorf("failed to store recovery code: %w", err)
+		}
+	}
+	return tx.Commit()
+}
+
+func (r *sqlTwoFactorRepository) Disable(ctx context.Context, userID int64) error {
+	start := time.Now()
+	defer func() {
+		metrics.RecordDatabaseQuery("UPDATE", "users", time.Since(start))
+	}()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `
+		UPDATE users
+		SET two_factor_secret = NULL, two_factor_enabled = FALSE, two_factor_last_step = 0, updated_at = NOW()
+		WHERE id = $1 AND two_factor_enabled`,
+		userID)
+	if err != nil {
+		return err
+	}
+	if err := expectOneRow(result, ErrTwoFactorState); err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, "DELETE FROM two_factor_recovery_codes WHERE user_id = $1", userID); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (r *sqlTwoFactorRepository) ClaimStep(ctx context.Context, userID int64, step int64) (bool, error) {
+	start := time.Now()
+	defer func() {
+		metrics.RecordDatabaseQuery("UPDATE", "users", time.Since(start))
+	}()
+
+	result, err := r.db.ExecContext(ctx,
+		"UPDATE users SET two_factor_last_step = $2 WHERE id = $1 AND two_factor_last_step < $2",
+		userID, step)
+	if err != nil {
+		return false, err
+	}
+	rows, err := result.RowsAffected()
+	return rows == 1, err
+}
+
+func (r *sqlTwoFactorRepository) UseRecoveryCode(ctx context.Context, userID int64, codeHash string) (bool, error) {
+	start := time.Now()
+	defer func() {
+		metrics.RecordDatabaseQuery("UPDATE", "two_factor_recovery_codes", time.Since(start))
+	}()
+
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE two_factor_recovery_codes
+		SET used_at = NOW()
+		WHERE user_id = $1 AND code_hash = $2 AND used_at IS NULL`,
+		userID, codeHash)
+	if err != nil {
+		return false, err
+	}
+	rows, err := result.RowsAffected()
+	return rows == 1, err
+}
+
+// expectOneRow returns errNone when result changed no row
+func expectOneRow(result sql.Result, errNone error) error {
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return errNone
+	}
+	return nil
+}
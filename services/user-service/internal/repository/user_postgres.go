@@ -5,14 +5,21 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
+	"strings"
 	"time"
 
+	"github.com/jackc/pgx/v5/pgconn"
+
 	"github.com/datngth03/ecommerce-go-app/services/user-service/internal/metrics"
 	"github.com/datngth03/ecommerce-go-app/services/user-service/internal/models"
 )
 
 var ErrNotFound = errors.New("record not found")
 
+// ErrEmailTaken is returned when another user already has the (normalized) email
+var ErrEmailTaken = errors.New("email already taken")
+
 type sqlUserRepository struct {
 	db *sql.DB
 }
@@ -40,6 +47,10 @@ func (r *sqlUserRepository) Create(ctx context.Context, user *models.User) (*mod
 	).Scan(&user.ID, &user.CreatedAt, &user.UpdatedAt)
 
 	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" { // unique violation
+			return nil, ErrEmailTaken
+		}
 		return nil, err
 	}
 	return user, nil
@@ -97,6 +108,50 @@ func (r *sqlUserRepository) GetByEmail(ctx context.Context, email string) (*mode
 	return &user, nil
 }
 
+// GetByIDs loads several users in one query; IDs without a user are skipped.
+// Password hashes are not loaded.
+func (r *sqlUserRepository) GetByIDs(ctx context.Context, ids []int64) ([]*models.User, error) {
+	start := time.Now()
+	defer func() {
+		metrics.RecordDatabaseQuery("SELECT", "users", time.Since(start))
+	}()
+
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	placeholders := make([]string, len(ids))
+	args := make([]interface{}, len(ids))
+	for i, id := range ids {
+		placeholders[i] = fmt.Sprintf("$%d", i+1)
+		args[i] = id
+	}
+
+	query := `
+		SELECT id, email, name, phone, is_active, created_at, updated_at
+		FROM users
+		WHERE id IN (` + strings.Join(placeholders, ", ") + `)`
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var users []*models.User
+	for rows.Next() {
+		var user models.User
+		if err := rows.Scan(
+			&user.ID, &user.Email, &user.Name,
+			&user.Phone, &user.IsActive, &user.CreatedAt, &user.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		users = append(users, &user)
+	}
+	return users, rows.Err()
+}
+
 func (r *sqlUserRepository) Update(ctx context.Context, updateData *models.UserUpdateData) (*models.User, error) {
 	start := time.Now()
 	defer func() {
@@ -13,6 +13,7 @@ type UserRepositoryInterface interface {
 	Create(ctx context.Context, user *models.User) (*models.User, error)
 	GetByID(ctx context.Context, id int64) (*models.User, error)
 	GetByEmail(ctx context.Context, email string) (*models.User, error)
+	GetByIDs(ctx context.Context, ids []int64) ([]*models.User, error)
 	Update(ctx context.Context, updateData *models.UserUpdateData) (*models.User, error)
 	Delete(ctx context.Context, id int64) error
 
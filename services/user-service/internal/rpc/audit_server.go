@@ -0,0 +1,100 @@
+package rpc
+
+import (
+	"context"
+	"log"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "github.com/datngth03/ecommerce-go-app/proto/user_service"
+	"github.com/datngth03/ecommerce-go-app/services/user-service/internal/models"
+	"github.com/datngth03/ecommerce-go-app/services/user-service/internal/service"
+)
+
+// AuditServer implements the audit log RPC methods
+type AuditServer struct {
+	pb.UnimplementedUserServiceServer
+	auditService service.AuditServiceInterface
+}
+
+// NewAuditServer creates a new AuditServer instance
+func NewAuditServer(auditService service.AuditServiceInterface) *AuditServer {
+	return &AuditServer{auditService: auditService}
+}
+
+// RecordAuditEntry stores one admin action reported by the API gateway
+func (s *AuditServer) RecordAuditEntry(ctx context.Context, req *pb.RecordAuditEntryRequest) (*pb.RecordAuditEntryResponse, error) {
+	entry := &models.AuditEntry{
+		ActorUserID: req.ActorUserId,
+		Action:      req.Action,
+		TargetID:    req.TargetId,
+		Method:      req.Method,
+		Path:        req.Path,
+		StatusCode:  req.StatusCode,
+		SourceIP:    req.SourceIp,
+	}
+	if req.OccurredAt != nil {
+		entry.CreatedAt = req.OccurredAt.AsTime()
+	}
+
+	recorded, err := s.auditService.RecordEntry(ctx, entry)
+	if err != nil {
+		if service.IsValidationError(err) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		log.Printf("RecordAuditEntry service error: %v", err)
+		return nil, status.Errorf(codes.Internal, "Failed to record audit entry: %v", err)
+	}
+
+	return &pb.RecordAuditEntryResponse{
+		Success: true,
+		Message: "Audit entry recorded",
+		Id:      recorded.ID,
+	}, nil
+}
+
+// GetAuditLog lists audit entries, newest first
+func (s *AuditServer) GetAuditLog(ctx context.Context, req *pb.GetAuditLogRequest) (*pb.GetAuditLogResponse, error) {
+	filter := models.AuditFilter{
+		ActorUserID: req.ActorUserId,
+		Action:      req.Action,
+		TargetID:    req.TargetId,
+	}
+	if req.From != nil {
+		filter.From = req.From.AsTime()
+	}
+	if req.To != nil {
+		filter.To = req.To.AsTime()
+	}
+
+	entries, total, err := s.auditService.ListEntries(ctx, filter, int(req.Page), int(req.PageSize))
+	if err != nil {
+		if service.IsValidationError(err) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		log.Printf("GetAuditLog service error: %v", err)
+		return nil, status.Errorf(codes.Internal, "Failed to get audit log: %v", err)
+	}
+
+	pbEntries := make([]*pb.AuditEntry, 0, len(entries))
+	for _, entry := range entries {
+		pbEntries = append(pbEntries, &pb.AuditEntry{
+			Id:          entry.ID,
+			ActorUserId: entry.ActorUserID,
+			Action:      entry.Action,
+			TargetId:    entry.TargetID,
+			Method:      entry.Method,
+			Path:        entry.Path,
+			StatusCode:  entry.StatusCode,
+			SourceIp:    entry.SourceIP,
+			CreatedAt:   timestamppb.New(entry.CreatedAt),
+		})
+	}
+
+	return &pb.GetAuditLogResponse{
+		Entries:    pbEntries,
+		TotalCount: total,
+	}, nil
+}
@@ -18,15 +18,17 @@ import (
 // AuthServer implements the authentication-related RPC methods
 type AuthServer struct {
 	pb.UnimplementedUserServiceServer
-	userService service.UserServiceInterface
-	authService service.AuthServiceInterface
+	userService      service.UserServiceInterface
+	authService      service.AuthServiceInterface
+	twoFactorService service.TwoFactorServiceInterface
 }
 
 // NewAuthServer creates a new AuthServer instance
-func NewAuthServer(userService service.UserServiceInterface, authService service.AuthServiceInterface) *AuthServer {
+func NewAuthServer(userService service.UserServiceInterface, authService service.AuthServiceInterface, twoFactorService service.TwoFactorServiceInterface) *AuthServer {
 	return &AuthServer{
-		userService: userService,
-		authService: authService,
+		userService:      userService,
+		authService:      authService,
+		twoFactorService: twoFactorService,
 	}
 }
 
@@ -56,6 +58,33 @@ func (s *AuthServer) Login(ctx context.Context, req *pb.LoginRequest) (*pb.Login
 		}, nil
 	}
 
+	// Second factor, when the user has enabled it
+	twoFactorEnabled, err := s.twoFactorService.IsEnabled(ctx, user.ID)
+	if err != nil {
+		log.Printf("Failed to check two-factor state for user %d: %v", user.ID, err)
+		return nil, status.Errorf(codes.Internal, "Failed to complete login process")
+	}
+	if twoFactorEnabled {
+		if req.TwoFactorCode == "" {
+			return &pb.LoginResponse{
+				Success:           false,
+				Message:           "Two-factor code required",
+				TwoFactorRequired: true,
+			}, nil
+		}
+		if err := s.twoFactorService.Verify(ctx, user.ID, req.TwoFactorCode); err != nil {
+			log.Printf("Two-factor check failed for user %d: %v", user.ID, err)
+			if !service.IsUnauthorizedError(err) && !service.IsValidationError(err) {
+				return nil, status.Errorf(codes.Internal, "Failed to complete login process")
+			}
+			return &pb.LoginResponse{
+				Success:           false,
+				Message:           "Invalid two-factor code",
+				TwoFactorRequired: true,
+			}, nil
+		}
+	}
+
 	// Generate tokens
 	tokenPair, err := s.authService.GenerateTokenPair(ctx, user.ID, user.Email)
 	if err != nil {
@@ -241,26 +270,22 @@ func (s *AuthServer) Logout(ctx context.Context, req *pb.LogoutRequest) (*pb.Log
 // Password Management Methods
 // =================================
 
-// ChangePassword changes user password
+// ChangePassword changes the password of the authenticated user after checking
+// the current one. Other sessions are signed out; the session whose refresh
+// token is passed in the request stays signed in.
 func (s *AuthServer) ChangePassword(ctx context.Context, req *pb.ChangePasswordRequest) (*pb.ChangePasswordResponse, error) {
 	log.Printf("ChangePassword RPC called")
 
 	// Validate request
 	if req.OldPassword == "" || req.NewPassword == "" {
-		return &pb.ChangePasswordResponse{
-			Success: false,
-			Message: "Old password and new password are required",
-		}, nil
+		return nil, status.Error(codes.InvalidArgument, "current password and new password are required")
 	}
 
 	// Extract user ID from authentication context (from JWT token in metadata)
 	userID, err := s.getUserIDFromContext(ctx)
 	if err != nil {
 		log.Printf("Failed to get user ID from context: %v", err)
-		return &pb.ChangePasswordResponse{
-			Success: false,
-			Message: "Authentication required",
-		}, nil
+		return nil, status.Error(codes.Unauthenticated, "authentication required")
 	}
 
 	// Change password
@@ -268,29 +293,31 @@ func (s *AuthServer) ChangePassword(ctx context.Context, req *pb.ChangePasswordR
 	if err != nil {
 		log.Printf("Failed to change password for user %d: %v", userID, err)
 
-		if err.Error() == "invalid old password" {
-			return &pb.ChangePasswordResponse{
-				Success: false,
-				Message: "Current password is incorrect",
-			}, nil
+		switch {
+		case service.IsValidationError(err):
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		case service.IsUnauthorizedError(err):
+			return nil, status.Error(codes.Unauthenticated, err.Error())
+		case service.IsNotFoundError(err):
+			return nil, status.Error(codes.NotFound, err.Error())
 		}
-
-		return &pb.ChangePasswordResponse{
-			Success: false,
-			Message: "Failed to change password",
-		}, nil
+		return nil, status.Error(codes.Internal, "failed to change password")
 	}
 
-	// Optionally invalidate all existing tokens to force re-login
-	err = s.authService.InvalidateAllUserTokens(ctx, userID)
+	// Revoke the refresh tokens of every other session
+	kept, err := s.authService.InvalidateOtherUserTokens(ctx, userID, req.RefreshToken)
 	if err != nil {
 		log.Printf("Warning: Failed to invalidate tokens after password change for user %d: %v", userID, err)
 	}
 
 	log.Printf("Password changed successfully for user %d", userID)
+	message := "Password changed successfully. Please login again."
+	if kept {
+		message = "Password changed successfully. Other sessions have been signed out."
+	}
 	return &pb.ChangePasswordResponse{
 		Success: true,
-		Message: "Password changed successfully. Please login again.",
+		Message: message,
 	}, nil
 }
 
@@ -367,6 +394,9 @@ func (s *AuthServer) ResetPassword(ctx context.Context, req *pb.ResetPasswordReq
 	err = s.userService.UpdatePasswordByEmail(ctx, req.Email, req.NewPassword)
 	if err != nil {
 		log.Printf("Failed to update password for email %s: %v", req.Email, err)
+		if service.IsValidationError(err) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
 		return nil, status.Errorf(codes.Internal, "Failed to reset password")
 	}
 
@@ -394,6 +424,12 @@ func (s *AuthServer) ResetPassword(ctx context.Context, req *pb.ResetPasswordReq
 
 // getUserIDFromContext extracts user ID from gRPC metadata (JWT token)
 func (s *AuthServer) getUserIDFromContext(ctx context.Context) (int64, error) {
+	return userIDFromContext(ctx, s.authService)
+}
+
+// userIDFromContext returns the user of the access token in the call's
+// authorization metadata
+func userIDFromContext(ctx context.Context, authService service.AuthServiceInterface) (int64, error) {
 	md, ok := metadata.FromIncomingContext(ctx)
 	if !ok {
 		return 0, status.Errorf(codes.Unauthenticated, "missing metadata")
@@ -412,7 +448,7 @@ func (s *AuthServer) getUserIDFromContext(ctx context.Context) (int64, error) {
 	}
 
 	// Validate token and extract claims
-	claims, err := s.authService.ValidateAccessToken(ctx, token)
+	claims, err := authService.ValidateAccessToken(ctx, token)
 	if err != nil {
 		return 0, status.Errorf(codes.Unauthenticated, "invalid token: %v", err)
 	}
@@ -15,13 +15,17 @@ type GRPCServer struct {
 	pb.UnimplementedUserServiceServer // Nhúng để đảm bảo tương thích
 	*UserServer                       // Nhúng UserServer
 	*AuthServer                       // Nhúng AuthServer
+	*AuditServer
+	*TwoFactorServer
 }
 
 // NewServer tạo một instance của server tổng hợp.
-func NewGRPCServer(userService service.UserServiceInterface, authService service.AuthServiceInterface) *GRPCServer {
+func NewGRPCServer(userService service.UserServiceInterface, authService service.AuthServiceInterface, auditService service.AuditServiceInterface, twoFactorService service.TwoFactorServiceInterface) *GRPCServer {
 	return &GRPCServer{
-		UserServer: NewUserServer(userService),
-		AuthServer: NewAuthServer(userService, authService),
+		UserServer:      NewUserServer(userService),
+		AuthServer:      NewAuthServer(userService, authService, twoFactorService),
+		AuditServer:     NewAuditServer(auditService),
+		TwoFactorServer: NewTwoFactorServer(authService, twoFactorService),
 	}
 }
 func (s *GRPCServer) CreateUser(ctx context.Context, req *pb.CreateUserRequest) (*pb.UserResponse, error) {
@@ -32,6 +36,9 @@ func (s *GRPCServer) DeleteUser(ctx context.Context, req *pb.DeleteUserRequest)
 
 	return s.UserServer.DeleteUser(ctx, req)
 }
+func (s *GRPCServer) GetUsersByIds(ctx context.Context, req *pb.GetUsersByIdsRequest) (*pb.GetUsersByIdsResponse, error) {
+	return s.UserServer.GetUsersByIds(ctx, req)
+}
 func (s *GRPCServer) GetUser(ctx context.Context, req *pb.GetUserRequest) (*pb.UserResponse, error) {
 	return s.UserServer.GetUser(ctx, req)
 }
@@ -70,3 +77,20 @@ func (s *GRPCServer) Logout(ctx context.Context, req *pb.LogoutRequest) (*pb.Log
 func (s *GRPCServer) RefreshToken(ctx context.Context, req *pb.RefreshTokenRequest) (*pb.LoginResponse, error) {
 	return s.AuthServer.RefreshToken(ctx, req)
 }
+
+func (s *GRPCServer) EnableTwoFactor(ctx context.Context, req *pb.EnableTwoFactorRequest) (*pb.EnableTwoFactorResponse, error) {
+	return s.TwoFactorServer.EnableTwoFactor(ctx, req)
+}
+func (s *GRPCServer) ConfirmTwoFactor(ctx context.Context, req *pb.ConfirmTwoFactorRequest) (*pb.ConfirmTwoFactorResponse, error) {
+	return s.TwoFactorServer.ConfirmTwoFactor(ctx, req)
+}
+func (s *GRPCServer) DisableTwoFactor(ctx context.Context, req *pb.DisableTwoFactorRequest) (*pb.DisableTwoFactorResponse, error) {
+	return s.TwoFactorServer.DisableTwoFactor(ctx, req)
+}
+
+func (s *GRPCServer) RecordAuditEntry(ctx context.Context, req *pb.RecordAuditEntryRequest) (*pb.RecordAuditEntryResponse, error) {
+	return s.AuditServer.RecordAuditEntry(ctx, req)
+}
+func (s *GRPCServer) GetAuditLog(ctx context.Context, req *pb.GetAuditLogRequest) (*pb.GetAuditLogResponse, error) {
+	return s.AuditServer.GetAuditLog(ctx, req)
+}
@@ -0,0 +1,102 @@
+package rpc
+
+import (
+	"context"
+	"log"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/datngth03/ecommerce-go-app/proto/user_service"
+	"github.com/datngth03/ecommerce-go-app/services/user-service/internal/service"
+)
+
+// TwoFactorServer implements the two-factor authentication RPC methods
+type TwoFactorServer struct {
+	pb.UnimplementedUserServiceServer
+	authService      service.AuthServiceInterface
+	twoFactorService service.TwoFactorServiceInterface
+}
+
+// NewTwoFactorServer creates a new TwoFactorServer instance
+func NewTwoFactorServer(authService service.AuthServiceInterface, twoFactorService service.TwoFactorServiceInterface) *TwoFactorServer {
+	return &TwoFactorServer{
+		authService:      authService,
+		twoFactorService: twoFactorService,
+	}
+}
+
+// EnableTwoFactor generates a TOTP secret for the authenticated user
+func (s *TwoFactorServer) EnableTwoFactor(ctx context.Context, req *pb.EnableTwoFactorRequest) (*pb.EnableTwoFactorResponse, error) {
+	userID, err := userIDFromContext(ctx, s.authService)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "authentication required")
+	}
+
+	setup, err := s.twoFactorService.Enable(ctx, userID)
+	if err != nil {
+		log.Printf("EnableTwoFactor failed for user %d: %v", userID, err)
+		return nil, twoFactorError(err)
+	}
+
+	return &pb.EnableTwoFactorResponse{
+		Secret:          setup.Secret,
+		ProvisioningUri: setup.ProvisioningURI,
+	}, nil
+}
+
+// ConfirmTwoFactor activates two-factor authentication with the first code
+func (s *TwoFactorServer) ConfirmTwoFactor(ctx context.Context, req *pb.ConfirmTwoFactorRequest) (*pb.ConfirmTwoFactorResponse, error) {
+	userID, err := userIDFromContext(ctx, s.authService)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "authentication required")
+	}
+	if req.Code == "" {
+		return nil, status.Error(codes.InvalidArgument, "code is required")
+	}
+
+	recoveryCodes, err := s.twoFactorService.Confirm(ctx, userID, req.Code)
+	if err != nil {
+		log.Printf("ConfirmTwoFactor failed for user %d: %v", userID, err)
+		return nil, twoFactorError(err)
+	}
+
+	return &pb.ConfirmTwoFactorResponse{RecoveryCodes: recoveryCodes}, nil
+}
+
+// DisableTwoFactor turns two-factor authentication off after checking the
+// password and a current code
+func (s *TwoFactorServer) DisableTwoFactor(ctx context.Context, req *pb.DisableTwoFactorRequest) (*pb.DisableTwoFactorResponse, error) {
+	userID, err := userIDFromContext(ctx, s.authService)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "authentication required")
+	}
+	if req.Password == "" || req.Code == "" {
+		return nil, status.Error(codes.InvalidArgument, "password and code are required")
+	}
+
+	if err := s.twoFactorService.Disable(ctx, userID, req.Password, req.Code); err != nil {
+		log.Printf("DisableTwoFactor failed for user %d: %v", userID, err)
+		return nil, twoFactorError(err)
+	}
+
+	return &pb.DisableTwoFactorResponse{
+		Success: true,
+		Message: "Two-factor authentication disabled",
+	}, nil
+}
+
+// twoFactorError maps a two-factor service error to a gRPC status
+func twoFactorError(err error) error {
+	switch {
+	case service.IsValidationError(err):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case service.IsUnauthorizedError(err):
+		return status.Error(codes.Unauthenticated, err.Error())
+	case service.IsNotFoundError(err):
+		return status.Error(codes.NotFound, err.Error())
+	case service.IsConflictError(err):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+	return status.Error(codes.Internal, "two-factor operation failed")
+}
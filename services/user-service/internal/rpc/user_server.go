@@ -67,6 +67,10 @@ func (s *UserServer) CreateUser(ctx context.Context, req *pb.CreateUserRequest)
 		statusCode = "error"
 
 		// Handle business logic errors
+		if service.IsValidationError(err) {
+			statusCode = "validation_error"
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
 		if err.Error() == "user already exists" {
 			return &pb.UserResponse{
 				Success: false,
@@ -219,6 +223,41 @@ func (s *UserServer) DeleteUser(ctx context.Context, req *pb.DeleteUserRequest)
 	}, nil
 }
 
+// GetUsersByIds returns the public profiles of several users in one call
+func (s *UserServer) GetUsersByIds(ctx context.Context, req *pb.GetUsersByIdsRequest) (*pb.GetUsersByIdsResponse, error) {
+	start := time.Now()
+	var statusCode string
+	defer func() {
+		metrics.RecordGRPCRequest("GetUsersByIds", statusCode, time.Since(start))
+	}()
+
+	users, err := s.userService.GetUsersByIDs(ctx, req.Ids)
+	if err != nil {
+		log.Printf("GetUsersByIds service error: %v", err)
+		if service.IsValidationError(err) {
+			statusCode = "validation_error"
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		statusCode = "error"
+		return nil, status.Error(codes.Internal, "failed to get users")
+	}
+
+	statusCode = "success"
+	profiles := make([]*pb.UserProfile, 0, len(users))
+	for _, user := range users {
+		profile := &pb.UserProfile{
+			Id:   user.ID,
+			Name: user.Name,
+		}
+		if !user.CreatedAt.IsZero() {
+			profile.CreatedAt = timestamppb.New(user.CreatedAt)
+		}
+		profiles = append(profiles, profile)
+	}
+
+	return &pb.GetUsersByIdsResponse{Users: profiles}, nil
+}
+
 // =================================
 // Helper Methods
 // =================================
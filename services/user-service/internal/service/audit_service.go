@@ -0,0 +1,76 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/datngth03/ecommerce-go-app/services/user-service/internal/models"
+	"github.com/datngth03/ecommerce-go-app/services/user-service/internal/repository"
+)
+
+const (
+	defaultAuditPageSize = 50
+	maxAuditPageSize     = 200
+)
+
+// AuditServiceInterface defines the audit log contract
+type AuditServiceInterface interface {
+	RecordEntry(ctx context.Context, entry *models.AuditEntry) (*models.AuditEntry, error)
+	ListEntries(ctx context.Context, filter models.AuditFilter, page, pageSize int) ([]*models.AuditEntry, int64, error)
+}
+
+// AuditService records and lists admin actions
+type AuditService struct {
+	auditRepo repository.AuditRepositoryInterface
+}
+
+// NewAuditService creates a new AuditService instance
+func NewAuditService(auditRepo repository.AuditRepositoryInterface) AuditServiceInterface {
+	return &AuditService{auditRepo: auditRepo}
+}
+
+// RecordEntry stores an audit entry; CreatedAt defaults to now
+func (s *AuditService) RecordEntry(ctx context.Context, entry *models.AuditEntry) (*models.AuditEntry, error) {
+	entry.Action = strings.TrimSpace(entry.Action)
+	entry.Method = strings.ToUpper(strings.TrimSpace(entry.Method))
+	if entry.ActorUserID <= 0 {
+		return nil, NewValidationError("actor_user_id is required")
+	}
+	if entry.Action == "" || entry.Method == "" || entry.Path == "" {
+		return nil, NewValidationError("action, method and path are required")
+	}
+	if entry.CreatedAt.IsZero() {
+		entry.CreatedAt = time.Now()
+	}
+
+	if err := s.auditRepo.Create(ctx, entry); err != nil {
+		return nil, fmt.Errorf("failed to record audit entry: %w", err)
+	}
+	return entry, nil
+}
+
+// ListEntries returns one page of entries matching filter, newest first, and the total count
+func (s *AuditService) ListEntries(ctx context.Context, filter models.AuditFilter, page, pageSize int) ([]*models.AuditEntry, int64, error) {
+	if !filter.From.IsZero() && !filter.To.IsZero() && !filter.From.Before(filter.To) {
+		return nil, 0, NewValidationError("from must be before to")
+	}
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = defaultAuditPageSize
+	}
+	if pageSize > maxAuditPageSize {
+		pageSize = maxAuditPageSize
+	}
+	filter.Limit = pageSize
+	filter.Offset = (page - 1) * pageSize
+
+	entries, total, err := s.auditRepo.List(ctx, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list audit entries: %w", err)
+	}
+	return entries, total, nil
+}
@@ -24,6 +24,7 @@ type AuthServiceInterface interface {
 	StoreRefreshToken(ctx context.Context, userID int64, refreshToken string, expiresAt time.Time) error
 	InvalidateUserTokens(ctx context.Context, accessToken string, refreshToken *string) error
 	InvalidateAllUserTokens(ctx context.Context, userID int64) error
+	InvalidateOtherUserTokens(ctx context.Context, userID int64, keepRefreshToken string) (bool, error)
 
 	// Password reset
 	GeneratePasswordResetToken(ctx context.Context, userID int64) (string, time.Time, error)
@@ -36,7 +37,7 @@ type AuthServiceInterface interface {
 type AuthService struct {
 	userRepo        repository.UserRepositoryInterface
 	tokenRepo       repository.TokenRepositoryInterface
-	jwtSecret       string
+	jwtKeys         *utils.JWTKeys
 	accessTokenTTL  time.Duration
 	refreshTokenTTL time.Duration
 	resetTokenTTL   time.Duration
@@ -46,13 +47,13 @@ type AuthService struct {
 func NewAuthService(
 	userRepo repository.UserRepositoryInterface,
 	tokenRepo repository.TokenRepositoryInterface,
-	jwtSecret string,
+	jwtKeys *utils.JWTKeys,
 	accessTokenTTL, refreshTokenTTL, resetTokenTTL time.Duration,
 ) AuthServiceInterface {
 	return &AuthService{
 		userRepo:        userRepo,
 		tokenRepo:       tokenRepo,
-		jwtSecret:       jwtSecret,
+		jwtKeys:         jwtKeys,
 		accessTokenTTL:  accessTokenTTL,
 		refreshTokenTTL: refreshTokenTTL,
 		resetTokenTTL:   resetTokenTTL,
@@ -67,7 +68,7 @@ func NewAuthService(
 func (s *AuthService) GenerateTokenPair(ctx context.Context, userID int64, email string) (*utils.TokenPair, error) {
 	log.Printf("AuthService: Generating token pair for user %d", userID)
 
-	tokenPair, err := utils.GenerateTokenPair(userID, email, s.jwtSecret, s.accessTokenTTL, s.refreshTokenTTL)
+	tokenPair, err := utils.GenerateTokenPair(userID, email, s.jwtKeys, s.accessTokenTTL, s.refreshTokenTTL)
 	if err != nil {
 		log.Printf("AuthService: Failed to generate token pair for user %d: %v", userID, err)
 		return nil, status.Error(codes.Internal, "could not generate token pair")
@@ -80,7 +81,7 @@ func (s *AuthService) GenerateTokenPair(ctx context.Context, userID int64, email
 func (s *AuthService) ValidateAccessToken(ctx context.Context, token string) (*utils.JWTClaims, error) {
 	log.Printf("AuthService: Validating access token")
 
-	claims, err := utils.ValidateJWT(token, s.jwtSecret)
+	claims, err := utils.ValidateJWT(token, s.jwtKeys)
 	if err != nil {
 		log.Printf("AuthService: Access token validation failed: %v", err)
 		return nil, status.Error(codes.Unauthenticated, "invalid or expired access token")
@@ -162,7 +163,7 @@ func (s *AuthService) InvalidateUserTokens(ctx context.Context, accessToken stri
 	log.Printf("AuthService: Invalidating tokens")
 
 	// Blacklist the access token until it expires naturally.
-	claims, err := utils.ValidateJWT(accessToken, s.jwtSecret)
+	claims, err := utils.ValidateJWT(accessToken, s.jwtKeys)
 	if err == nil {
 		// CORRECTED LINE: Access .ExpiresAt.Time from the embedded RegisteredClaims
 		if err_blacklist := s.tokenRepo.BlacklistToken(ctx, accessToken, claims.ExpiresAt.Time); err_blacklist != nil {
@@ -191,6 +192,33 @@ func (s *AuthService) InvalidateAllUserTokens(ctx context.Context, userID int64)
 	return nil
 }
 
+// InvalidateOtherUserTokens invalidates all refresh tokens for a user except
+// keepRefreshToken, so the session changing the password stays signed in.
+// It reports whether that token was kept; a token that is unknown, expired or
+// issued to another user is not, and every session is then signed out.
+func (s *AuthService) InvalidateOtherUserTokens(ctx context.Context, userID int64, keepRefreshToken string) (bool, error) {
+	var keep *utils.RefreshTokenData
+	if keepRefreshToken != "" {
+		tokenData, err := s.tokenRepo.GetRefreshToken(ctx, keepRefreshToken)
+		if err == nil && tokenData.UserID == userID && time.Now().Before(tokenData.ExpiresAt) {
+			keep = tokenData
+		}
+	}
+
+	if err := s.InvalidateAllUserTokens(ctx, userID); err != nil {
+		return false, err
+	}
+	if keep == nil {
+		return false, nil
+	}
+
+	if err := s.tokenRepo.StoreRefreshToken(ctx, userID, keepRefreshToken, keep.ExpiresAt); err != nil {
+		log.Printf("AuthService: Failed to restore current refresh token for user %d: %v", userID, err)
+		return false, nil
+	}
+	return true, nil
+}
+
 // =================================
 // Password Reset Implementation
 // =================================
@@ -0,0 +1,47 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/datngth03/ecommerce-go-app/services/user-service/internal/models"
+	"github.com/datngth03/ecommerce-go-app/services/user-service/internal/repository"
+	"github.com/datngth03/ecommerce-go-app/services/user-service/pkg/utils"
+)
+
+// AdminEmail is the account the API gateway grants admin access to
+const AdminEmail = "admin@example.com"
+
+// seedAdminPassword is the password migration 001 gives the admin account
+const seedAdminPassword = "Admin123!"
+
+// BootstrapAdmin creates the admin account of a fresh deployment with the given
+// name and password. It is a no-op if the account already exists, so it can run
+// on every deploy; the password of an existing admin is never changed. It
+// reports whether the account was created.
+func (s *UserService) BootstrapAdmin(ctx context.Context, name, password string) (*models.User, bool, error) {
+	existing, err := s.userRepo.GetByEmail(ctx, AdminEmail)
+	if err == nil {
+		if utils.CheckPasswordHash(seedAdminPassword, existing.Password) {
+			log.Printf("WARNING: admin account %s still has the default password from the seed migration; change it", AdminEmail)
+		}
+		return existing, false, nil
+	}
+	if !errors.Is(err, repository.ErrNotFound) {
+		return nil, false, fmt.Errorf("failed to look up admin account: %w", err)
+	}
+
+	admin, err := s.CreateUser(ctx, &models.User{Email: AdminEmail, Name: name, Password: password})
+	if err != nil {
+		// Another instance bootstrapping at the same time created it first
+		if existing, getErr := s.userRepo.GetByEmail(ctx, AdminEmail); getErr == nil {
+			return existing, false, nil
+		}
+		return nil, false, fmt.Errorf("failed to create admin account: %w", err)
+	}
+
+	log.Printf("UserService: Admin account %s created with ID: %d", AdminEmail, admin.ID)
+	return admin, true, nil
+}
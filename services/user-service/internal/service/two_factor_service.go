@@ -0,0 +1,217 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/datngth03/ecommerce-go-app/services/user-service/internal/repository"
+	"github.com/datngth03/ecommerce-go-app/services/user-service/pkg/utils"
+)
+
+// recoveryCodeCount is how many recovery codes ConfirmTwoFactor hands out
+const recoveryCodeCount = 10
+
+// TwoFactorSetup is what a user needs to add the account to an authenticator app
+type TwoFactorSetup struct {
+	Secret          string
+	ProvisioningURI string
+}
+
+// TwoFactorServiceInterface defines the TOTP two-factor contract
+type TwoFactorServiceInterface interface {
+	Enable(ctx context.Context, userID int64) (*TwoFactorSetup, error)
+	Confirm(ctx context.Context, userID int64, code string) ([]string, error)
+	Disable(ctx context.Context, userID int64, password, code string) error
+	IsEnabled(ctx context.Context, userID int64) (bool, error)
+	// Verify checks a login code: a TOTP code or an unused recovery code
+	Verify(ctx context.Context, userID int64, code string) error
+}
+
+// TwoFactorService manages TOTP secrets and recovery codes
+type TwoFactorService struct {
+	userRepo      repository.UserRepositoryInterface
+	twoFactorRepo repository.TwoFactorRepositoryInterface
+	issuer        string
+	encryptionKey []byte
+}
+
+// NewTwoFactorService creates a new TwoFactorService instance
+func NewTwoFactorService(
+	userRepo repository.UserRepositoryInterface,
+	twoFactorRepo repository.TwoFactorRepositoryInterface,
+	issuer string,
+	encryptionKey []byte,
+) TwoFactorServiceInterface {
+	return &TwoFactorService{
+		userRepo:      userRepo,
+		twoFactorRepo: twoFactorRepo,
+		issuer:        issuer,
+		encryptionKey: encryptionKey,
+	}
+}
+
+// Enable generates a new secret for the user. It has no effect on login until
+// Confirm; calling Enable again replaces a secret that was not confirmed.
+func (s *TwoFactorService) Enable(ctx context.Context, userID int64) (*TwoFactorSetup, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, NewNotFoundError("user not found")
+	}
+	tf, err := s.twoFactorRepo.Get(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get two-factor state: %w", err)
+	}
+	if tf.Enabled {
+		return nil, NewConflictError("two-factor authentication is already enabled")
+	}
+
+	secret, err := utils.GenerateTOTPSecret()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate secret: %w", err)
+	}
+	encrypted, err := utils.EncryptString(s.encryptionKey, secret)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt secret: %w", err)
+	}
+	if err := s.twoFactorRepo.SetPendingSecret(ctx, userID, encrypted); err != nil {
+		if errors.Is(err, repository.ErrTwoFactorState) {
+			return nil, NewConflictError("two-factor authentication is already enabled")
+		}
+		return nil, fmt.Errorf("failed to store secret: %w", err)
+	}
+
+	log.Printf("TwoFactorService: Secret generated for user %d", userID)
+	return &TwoFactorSetup{
+		Secret:          secret,
+		ProvisioningURI: utils.TOTPProvisioningURI(s.issuer, user.Email, secret),
+	}, nil
+}
+
+// Confirm activates two-factor authentication with the first code from the
+// app and returns the recovery codes. They are only shown this once.
+func (s *TwoFactorService) Confirm(ctx context.Context, userID int64, code string) ([]string, error) {
+	tf, err := s.twoFactorRepo.Get(ctx, userID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, NewNotFoundError("user not found")
+		}
+		return nil, fmt.Errorf("failed to get two-factor state: %w", err)
+	}
+	if tf.Enabled {
+		return nil, NewConflictError("two-factor authentication is already enabled")
+	}
+	if tf.EncryptedSecret == "" {
+		return nil, NewConflictError("two-factor authentication has not been set up; call EnableTwoFactor first")
+	}
+
+	secret, err := utils.DecryptString(s.encryptionKey, tf.EncryptedSecret)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt secret: %w", err)
+	}
+	step, ok := utils.ValidateTOTP(secret, code, time.Now())
+	if !ok {
+		return nil, NewUnauthorizedError("invalid two-factor code")
+	}
+
+	recoveryCodes, err := utils.GenerateRecoveryCodes(recoveryCodeCount)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate recovery codes: %w", err)
+	}
+	hashes := make([]string, len(recoveryCodes))
+	for i, rc := range recoveryCodes {
+		hashes[i] = utils.HashRecoveryCode(rc)
+	}
+	if err := s.twoFactorRepo.Enable(ctx, userID, step, hashes); err != nil {
+		if errors.Is(err, repository.ErrTwoFactorState) {
+			return nil, NewConflictError("two-factor authentication is already enabled")
+		}
+		return nil, fmt.Errorf("failed to enable two-factor authentication: %w", err)
+	}
+
+	log.Printf("TwoFactorService: Two-factor authentication enabled for user %d", userID)
+	return recoveryCodes, nil
+}
+
+// Disable turns two-factor authentication off. It takes the password and a
+// current code (or a recovery code), so a stolen session alone cannot do it.
+func (s *TwoFactorService) Disable(ctx context.Context, userID int64, password, code string) error {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return NewNotFoundError("user not found")
+	}
+	if !utils.CheckPasswordHash(password, user.Password) {
+		return NewUnauthorizedError("password is incorrect")
+	}
+	if err := s.Verify(ctx, userID, code); err != nil {
+		return err
+	}
+
+	if err := s.twoFactorRepo.Disable(ctx, userID); err != nil {
+		if errors.Is(err, repository.ErrTwoFactorState) {
+			return NewConflictError("two-factor authentication is not enabled")
+		}
+		return fmt.Errorf("failed to disable two-factor authentication: %w", err)
+	}
+
+	log.Printf("TwoFactorService: Two-factor authentication disabled for user %d", userID)
+	return nil
+}
+
+// IsEnabled reports whether login requires a second factor
+func (s *TwoFactorService) IsEnabled(ctx context.Context, userID int64) (bool, error) {
+	tf, err := s.twoFactorRepo.Get(ctx, userID)
+	if err != nil {
+		return false, fmt.Errorf("failed to get two-factor state: %w", err)
+	}
+	return tf.Enabled, nil
+}
+
+// Verify accepts a TOTP code within the allowed clock skew that has not been
+// used before, or an unused recovery code, which is then spent
+func (s *TwoFactorService) Verify(ctx context.Context, userID int64, code string) error {
+	code = strings.TrimSpace(code)
+	if code == "" {
+		return NewValidationError("two-factor code is required")
+	}
+
+	tf, err := s.twoFactorRepo.Get(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get two-factor state: %w", err)
+	}
+	if !tf.Enabled {
+		return NewConflictError("two-factor authentication is not enabled")
+	}
+
+	if len(code) != utils.TOTPDigits {
+		used, err := s.twoFactorRepo.UseRecoveryCode(ctx, userID, utils.HashRecoveryCode(code))
+		if err != nil {
+			return fmt.Errorf("failed to check recovery code: %w", err)
+		}
+		if !used {
+			return NewUnauthorizedError("invalid two-factor code")
+		}
+		log.Printf("TwoFactorService: Recovery code used by user %d", userID)
+		return nil
+	}
+
+	secret, err := utils.DecryptString(s.encryptionKey, tf.EncryptedSecret)
+	if err != nil {
+		return fmt.Errorf("failed to decrypt secret: %w", err)
+	}
+	step, ok := utils.ValidateTOTP(secret, code, time.Now())
+	if !ok {
+		return NewUnauthorizedError("invalid two-factor code")
+	}
+	claimed, err := s.twoFactorRepo.ClaimStep(ctx, userID, step)
+	if err != nil {
+		return fmt.Errorf("failed to record two-factor code: %w", err)
+	}
+	if !claimed {
+		return NewUnauthorizedError("two-factor code was already used")
+	}
+	return nil
+}
@@ -0,0 +1,118 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/datngth03/ecommerce-go-app/services/user-service/internal/models"
+	"github.com/datngth03/ecommerce-go-app/services/user-service/internal/repository"
+	"github.com/datngth03/ecommerce-go-app/services/user-service/pkg/utils"
+)
+
+// memoryTwoFactorRepo keeps one user's two-factor state the way the PostgreSQL
+// repository does: a step is claimed only past the last one, a recovery code once
+type memoryTwoFactorRepo struct {
+	repository.TwoFactorRepositoryInterface
+	tf            models.TwoFactor
+	recoveryCodes map[string]bool // Hash to used
+}
+
+func (r *memoryTwoFactorRepo) Get(ctx context.Context, userID int64) (*models.TwoFactor, error) {
+	tf := r.tf
+	return &tf, nil
+}
+
+func (r *memoryTwoFactorRepo) ClaimStep(ctx context.Context, userID int64, step int64) (bool, error) {
+	if step <= r.tf.LastStep {
+		return false, nil
+	}
+	r.tf.LastStep = step
+	return true, nil
+}
+
+func (r *memoryTwoFactorRepo) UseRecoveryCode(ctx context.Context, userID int64, codeHash string) (bool, error) {
+	used, ok := r.recoveryCodes[codeHash]
+	if !ok || used {
+		return false, nil
+	}
+	r.recoveryCodes[codeHash] = true
+	return true, nil
+}
+
+func newTwoFactorTest(t *testing.T) (*TwoFactorService, *memoryTwoFactorRepo, string) {
+	t.Helper()
+	key := bytes.Repeat([]byte{7}, utils.EncryptionKeySize)
+	secret, err := utils.GenerateTOTPSecret()
+	if err != nil {
+		t.Fatalf("GenerateTOTPSecret: %v", err)
+	}
+	encrypted, err := utils.EncryptString(key, secret)
+	if err != nil {
+		t.Fatalf("EncryptString: %v", err)
+	}
+
+	repo := &memoryTwoFactorRepo{
+		tf:            models.TwoFactor{UserID: 1, EncryptedSecret: encrypted, Enabled: true},
+		recoveryCodes: map[string]bool{utils.HashRecoveryCode("a1b2c-3d4e5"): false},
+	}
+	svc := &TwoFactorService{twoFactorRepo: repo, issuer: "ecommerce", encryptionKey: key}
+	return svc, repo, secret
+}
+
+func TestVerifyRejectsReplayedCode(t *testing.T) {
+	svc, repo, secret := newTwoFactorTest(t)
+	ctx := context.Background()
+
+	code, err := utils.TOTPCode(secret, utils.TOTPStep(time.Now()))
+	if err != nil {
+		t.Fatalf("TOTPCode: %v", err)
+	}
+	if err := svc.Verify(ctx, 1, code); err != nil {
+		t.Fatalf("first use: %v", err)
+	}
+	if err := svc.Verify(ctx, 1, code); !IsUnauthorizedError(err) {
+		t.Errorf("replayed code: error = %v, want unauthorized", err)
+	}
+
+	// An older code still within the skew window is rejected once a later step was used
+	previous, _ := utils.TOTPCode(secret, repo.tf.LastStep-1)
+	if err := svc.Verify(ctx, 1, previous); !IsUnauthorizedError(err) {
+		t.Errorf("code of an earlier step: error = %v, want unauthorized", err)
+	}
+}
+
+func TestVerifyRecoveryCodeSingleUse(t *testing.T) {
+	svc, repo, _ := newTwoFactorTest(t)
+	ctx := context.Background()
+
+	// Typed in upper case with spaces around it, as users do
+	if err := svc.Verify(ctx, 1, " A1B2C-3D4E5 "); err != nil {
+		t.Fatalf("first use: %v", err)
+	}
+	if !repo.recoveryCodes[utils.HashRecoveryCode("a1b2c-3d4e5")] {
+		t.Error("recovery code was not marked used")
+	}
+	if err := svc.Verify(ctx, 1, "a1b2c-3d4e5"); !IsUnauthorizedError(err) {
+		t.Errorf("second use: error = %v, want unauthorized", err)
+	}
+	if err := svc.Verify(ctx, 1, "ffff0-00000"); !IsUnauthorizedError(err) {
+		t.Errorf("unknown recovery code: error = %v, want unauthorized", err)
+	}
+}
+
+func TestVerifyRequiresEnabledTwoFactor(t *testing.T) {
+	svc, repo, secret := newTwoFactorTest(t)
+	ctx := context.Background()
+
+	if err := svc.Verify(ctx, 1, " "); !IsValidationError(err) {
+		t.Errorf("empty code: error = %v, want validation", err)
+	}
+
+	repo.tf.Enabled = false
+	code, _ := utils.TOTPCode(secret, utils.TOTPStep(time.Now()))
+	if err := svc.Verify(ctx, 1, code); !IsConflictError(err) {
+		t.Errorf("two-factor disabled: error = %v, want conflict", err)
+	}
+}
@@ -4,12 +4,14 @@ package service
 import (
 	"context"
 	"errors"
+	"fmt"
 	"log"
 
 	"github.com/datngth03/ecommerce-go-app/services/user-service/internal/metrics"
 	"github.com/datngth03/ecommerce-go-app/services/user-service/internal/models"
 	"github.com/datngth03/ecommerce-go-app/services/user-service/internal/repository"
 	"github.com/datngth03/ecommerce-go-app/services/user-service/pkg/utils"
+	"github.com/datngth03/ecommerce-go-app/shared/pkg/validator"
 )
 
 // UserServiceInterface defines the user service contract
@@ -20,11 +22,15 @@ type UserServiceInterface interface {
 	GetUserByEmail(ctx context.Context, email string) (*models.User, error)
 	UpdateUser(ctx context.Context, updateData *models.UserUpdateData) (*models.User, error)
 	DeleteUser(ctx context.Context, id int64) error
+	GetUsersByIDs(ctx context.Context, ids []int64) ([]*models.User, error)
 
 	// Auth operations (will be used by auth_server later)
 	ValidateUserCredentials(ctx context.Context, email, password string) (*models.User, error)
 	ChangePassword(ctx context.Context, userID int64, oldPassword, newPassword string) error
 	UpdatePasswordByEmail(ctx context.Context, email, newPassword string) error
+
+	// Setup
+	BootstrapAdmin(ctx context.Context, name, password string) (*models.User, bool, error)
 }
 
 // UserService implements the UserServiceInterface
@@ -47,8 +53,13 @@ func NewUserService(userRepo repository.UserRepositoryInterface, authService Aut
 
 // CreateUser creates a new user
 func (s *UserService) CreateUser(ctx context.Context, user *models.User) (*models.User, error) {
+	user.Email = utils.NormalizeEmail(user.Email)
 	log.Printf("UserService: Creating user with email: %s", user.Email)
 
+	if err := validator.ValidatePassword(user.Password); err != nil {
+		return nil, NewValidationError(err.Error())
+	}
+
 	// Check if user already exists
 	existingUser, err := s.userRepo.GetByEmail(ctx, user.Email)
 	if err == nil && existingUser != nil {
@@ -68,6 +79,10 @@ func (s *UserService) CreateUser(ctx context.Context, user *models.User) (*model
 
 	// Create user
 	createdUser, err := s.userRepo.Create(ctx, user)
+	if errors.Is(err, repository.ErrEmailTaken) {
+		// Lost a race with a concurrent registration of the same email
+		return nil, errors.New("user already exists")
+	}
 	if err != nil {
 		log.Printf("UserService: Failed to create user: %v", err)
 		return nil, errors.New("failed to create user")
@@ -95,6 +110,7 @@ func (s *UserService) GetUserByID(ctx context.Context, id int64) (*models.User,
 
 // GetUserByEmail retrieves a user by email
 func (s *UserService) GetUserByEmail(ctx context.Context, email string) (*models.User, error) {
+	email = utils.NormalizeEmail(email)
 	log.Printf("UserService: Getting user by email: %s", email)
 
 	user, err := s.userRepo.GetByEmail(ctx, email)
@@ -150,12 +166,51 @@ func (s *UserService) DeleteUser(ctx context.Context, id int64) error {
 	return nil
 }
 
+// maxUsersByIDs caps how many users one GetUsersByIDs call may request
+const maxUsersByIDs = 100
+
+// GetUsersByIDs retrieves several users in one query. Unknown and deleted
+// (inactive) users are omitted, so the result may be shorter than ids.
+func (s *UserService) GetUsersByIDs(ctx context.Context, ids []int64) ([]*models.User, error) {
+	if len(ids) > maxUsersByIDs {
+		return nil, NewValidationError(fmt.Sprintf("at most %d user IDs can be requested at once", maxUsersByIDs))
+	}
+
+	seen := make(map[int64]bool, len(ids))
+	unique := make([]int64, 0, len(ids))
+	for _, id := range ids {
+		if id <= 0 || seen[id] {
+			continue
+		}
+		seen[id] = true
+		unique = append(unique, id)
+	}
+	if len(unique) == 0 {
+		return nil, nil
+	}
+
+	users, err := s.userRepo.GetByIDs(ctx, unique)
+	if err != nil {
+		log.Printf("UserService: Failed to get users by IDs: %v", err)
+		return nil, errors.New("failed to get users")
+	}
+
+	active := users[:0]
+	for _, user := range users {
+		if user.IsActive {
+			active = append(active, user)
+		}
+	}
+	return active, nil
+}
+
 // =================================
 // Auth Operations Implementation
 // =================================
 
 // ValidateUserCredentials validates user login credentials
 func (s *UserService) ValidateUserCredentials(ctx context.Context, email, password string) (*models.User, error) {
+	email = utils.NormalizeEmail(email)
 	log.Printf("UserService: Validating credentials for email: %s", email)
 
 	// Get user by email
@@ -188,12 +243,19 @@ func (s *UserService) ChangePassword(ctx context.Context, userID int64, oldPassw
 	// Get user
 	user, err := s.userRepo.GetByID(ctx, userID)
 	if err != nil {
-		return errors.New("user not found")
+		return NewNotFoundError("user not found")
 	}
 
 	// Verify old password
 	if !utils.CheckPasswordHash(oldPassword, user.Password) {
-		return errors.New("invalid old password")
+		return NewUnauthorizedError("current password is incorrect")
+	}
+
+	if err := validator.ValidatePassword(newPassword); err != nil {
+		return NewValidationError("new password: " + err.Error())
+	}
+	if newPassword == oldPassword {
+		return NewValidationError("new password must be different from the current password")
 	}
 
 	// Hash new password
@@ -215,8 +277,13 @@ func (s *UserService) ChangePassword(ctx context.Context, userID int64, oldPassw
 
 // UpdatePasswordByEmail updates password by email (for reset password)
 func (s *UserService) UpdatePasswordByEmail(ctx context.Context, email, newPassword string) error {
+	email = utils.NormalizeEmail(email)
 	log.Printf("UserService: Updating password by email: %s", email)
 
+	if err := validator.ValidatePassword(newPassword); err != nil {
+		return NewValidationError("new password: " + err.Error())
+	}
+
 	// Get user by email
 	user, err := s.userRepo.GetByEmail(ctx, email)
 	if err != nil {
@@ -0,0 +1,18 @@
+-- Audit trail of admin mutations, written by the API gateway after a successful response
+CREATE TABLE IF NOT EXISTS audit_logs (
+    id            BIGSERIAL PRIMARY KEY,
+    actor_user_id BIGINT NOT NULL,
+    action        VARCHAR(100) NOT NULL,
+    target_id     VARCHAR(255) NOT NULL DEFAULT '',
+    method        VARCHAR(10) NOT NULL,
+    path          VARCHAR(500) NOT NULL,
+    status_code   INTEGER NOT NULL,
+    source_ip     VARCHAR(45) NOT NULL DEFAULT '',
+    created_at    TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- Entries are listed newest first, optionally by actor, action or target
+CREATE INDEX IF NOT EXISTS idx_audit_logs_created_at ON audit_logs(created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_audit_logs_actor_created ON audit_logs(actor_user_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_audit_logs_action_created ON audit_logs(action, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_audit_logs_target ON audit_logs(target_id);
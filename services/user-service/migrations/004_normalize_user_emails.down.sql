@@ -0,0 +1,4 @@
+-- Rollback normalized email uniqueness
+-- Emails stay lower-cased, and accounts renamed as duplicates keep their suffix.
+
+DROP INDEX IF EXISTS idx_users_email_normalized;
@@ -0,0 +1,29 @@
+-- Make emails unique regardless of case and surrounding spaces.
+-- The service stores and looks up emails trimmed and lower-cased.
+
+-- Existing accounts that differ only in case or spacing: the active account
+-- with the lowest id keeps the address. The others are deactivated and their
+-- email is suffixed with their id, so they stay unique and can be merged by
+-- hand if needed.
+WITH ranked AS (
+    SELECT id,
+           ROW_NUMBER() OVER (
+               PARTITION BY LOWER(TRIM(email))
+               ORDER BY is_active DESC, id
+           ) AS rn
+    FROM users
+)
+UPDATE users u
+SET email      = LEFT(LOWER(TRIM(u.email)), 230) || '.duplicate-' || u.id,
+    is_active  = FALSE,
+    updated_at = NOW()
+FROM ranked r
+WHERE u.id = r.id AND r.rn > 1;
+
+UPDATE users
+SET email = LOWER(TRIM(email))
+WHERE email <> LOWER(TRIM(email));
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_email_normalized ON users (LOWER(TRIM(email)));
+
+COMMENT ON INDEX idx_users_email_normalized IS 'One account per email, ignoring case and surrounding spaces';
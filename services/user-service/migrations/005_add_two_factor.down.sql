@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS two_factor_recovery_codes;
+
+ALTER TABLE users
+    DROP COLUMN IF EXISTS two_factor_last_step,
+    DROP COLUMN IF EXISTS two_factor_enabled,
+    DROP COLUMN IF EXISTS two_factor_secret;
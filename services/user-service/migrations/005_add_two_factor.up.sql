@@ -0,0 +1,19 @@
+-- Optional TOTP two-factor authentication.
+-- The secret is AES-GCM encrypted by the service (TWO_FACTOR_ENCRYPTION_KEY).
+-- It is stored on EnableTwoFactor and only takes effect once ConfirmTwoFactor
+-- sets two_factor_enabled. two_factor_last_step is the last accepted time
+-- step, so a code cannot be used twice.
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS two_factor_secret    TEXT,
+    ADD COLUMN IF NOT EXISTS two_factor_enabled   BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN IF NOT EXISTS two_factor_last_step BIGINT NOT NULL DEFAULT 0;
+
+-- Single-use recovery codes, stored as SHA-256 hashes
+CREATE TABLE IF NOT EXISTS two_factor_recovery_codes (
+    id         BIGSERIAL PRIMARY KEY,
+    user_id    BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    code_hash  CHAR(64) NOT NULL,
+    used_at    TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    UNIQUE (user_id, code_hash)
+);
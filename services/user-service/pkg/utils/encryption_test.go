@@ -0,0 +1,88 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/base64"
+	"testing"
+)
+
+func testKey(b byte) []byte {
+	return bytes.Repeat([]byte{b}, EncryptionKeySize)
+}
+
+func TestEncryptStringRoundTrip(t *testing.T) {
+	key := testKey(1)
+
+	for _, plaintext := range []string{"JBSWY3DPEHPK3PXP", "", "ünïcödé"} {
+		encrypted, err := EncryptString(key, plaintext)
+		if err != nil {
+			t.Fatalf("EncryptString(%q): %v", plaintext, err)
+		}
+		got, err := DecryptString(key, encrypted)
+		if err != nil {
+			t.Fatalf("DecryptString: %v", err)
+		}
+		if got != plaintext {
+			t.Errorf("round trip = %q, want %q", got, plaintext)
+		}
+	}
+
+	// A fresh nonce each time: the same plaintext never encrypts the same way twice
+	first, _ := EncryptString(key, "secret")
+	second, _ := EncryptString(key, "secret")
+	if first == second {
+		t.Error("encrypting the same plaintext twice gave the same ciphertext")
+	}
+}
+
+func TestDecryptStringWrongKey(t *testing.T) {
+	encrypted, err := EncryptString(testKey(1), "secret")
+	if err != nil {
+		t.Fatalf("EncryptString: %v", err)
+	}
+	if _, err := DecryptString(testKey(2), encrypted); err == nil {
+		t.Error("DecryptString with the wrong key succeeded")
+	}
+}
+
+func TestDecryptStringTampered(t *testing.T) {
+	key := testKey(1)
+	encrypted, err := EncryptString(key, "secret")
+	if err != nil {
+		t.Fatalf("EncryptString: %v", err)
+	}
+	sealed, err := base64.StdEncoding.DecodeString(encrypted)
+	if err != nil {
+		t.Fatalf("ciphertext is not base64: %v", err)
+	}
+
+	for _, i := range []int{0, len(sealed) / 2, len(sealed) - 1} {
+		tampered := append([]byte(nil), sealed...)
+		tampered[i] ^= 0x01
+		if _, err := DecryptString(key, base64.StdEncoding.EncodeToString(tampered)); err == nil {
+			t.Errorf("DecryptString accepted a ciphertext with byte %d flipped", i)
+		}
+	}
+
+	tests := map[string]string{
+		"not base64": "%%%",
+		"too short":  base64.StdEncoding.EncodeToString(sealed[:4]),
+		"truncated":  base64.StdEncoding.EncodeToString(sealed[:len(sealed)-1]),
+	}
+	for name, encoded := range tests {
+		if _, err := DecryptString(key, encoded); err == nil {
+			t.Errorf("%s: DecryptString succeeded", name)
+		}
+	}
+}
+
+func TestEncryptionKeySize(t *testing.T) {
+	for _, key := range [][]byte{nil, make([]byte, 16), make([]byte, 33)} {
+		if _, err := EncryptString(key, "secret"); err == nil {
+			t.Errorf("EncryptString with a %d-byte key succeeded", len(key))
+		}
+		if _, err := DecryptString(key, "c2VjcmV0"); err == nil {
+			t.Errorf("DecryptString with a %d-byte key succeeded", len(key))
+		}
+	}
+}
@@ -3,6 +3,7 @@ package utils
 
 import (
 	"log"
+	"strings"
 
 	"golang.org/x/crypto/bcrypt"
 )
@@ -16,6 +17,13 @@ func HashPassword(password string) (string, error) {
 	return string(hashedBytes), nil
 }
 
+// NormalizeEmail returns the form emails are stored and looked up in:
+// surrounding spaces trimmed and lower-cased, so addresses differing only
+// in case belong to the same account
+func NormalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
 // CheckPasswordHash verifies a password against its hash
 func CheckPasswordHash(password, hash string) bool {
 	// Debug logging
@@ -0,0 +1,156 @@
+package utils
+
+import (
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"os"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// JWTKeys holds the keys access tokens are signed and verified with. With a
+// PrivateKey tokens are signed RS256 and the public keys are published as a
+// JWKS; without one they are signed HS256 with Secret.
+type JWTKeys struct {
+	Secret     string
+	PrivateKey *rsa.PrivateKey // Optional RS256 signing key
+	KeyID      string          // kid of PrivateKey
+
+	// Public keys by kid that verify tokens: the signing key's and, while keys
+	// are rotated, those of earlier signing keys whose tokens may not have expired
+	PublicKeys map[string]*rsa.PublicKey
+}
+
+// JWK is one RSA public key of a JSON Web Key Set (RFC 7517)
+type JWK struct {
+	KeyType   string `json:"kty"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	KeyID     string `json:"kid"`
+	Modulus   string `json:"n"`
+	Exponent  string `json:"e"`
+}
+
+// JWKSet is the JWKS document served to token verifiers
+type JWKSet struct {
+	Keys []JWK `json:"keys"`
+}
+
+// LoadJWTKeys builds the token keys from the shared secret, an optional PEM
+// private key file (PKCS#1 or PKCS#8) and PEM public key files of earlier keys
+func LoadJWTKeys(secret, privateKeyFile string, previousPublicKeyFiles []string) (*JWTKeys, error) {
+	keys := &JWTKeys{Secret: secret, PublicKeys: make(map[string]*rsa.PublicKey)}
+
+	if privateKeyFile != "" {
+		privateKey, err := readRSAPrivateKey(privateKeyFile)
+		if err != nil {
+			return nil, err
+		}
+		keys.PrivateKey = privateKey
+		keys.KeyID = KeyID(&privateKey.PublicKey)
+		keys.PublicKeys[keys.KeyID] = &privateKey.PublicKey
+	}
+
+	for _, file := range previousPublicKeyFiles {
+		publicKey, err := readRSAPublicKey(file)
+		if err != nil {
+			return nil, err
+		}
+		keys.PublicKeys[KeyID(publicKey)] = publicKey
+	}
+
+	return keys, nil
+}
+
+// KeyID derives a stable kid from a public key: a truncated SHA-256 of its DER encoding
+func KeyID(key *rsa.PublicKey) string {
+	sum := sha256.Sum256(x509.MarshalPKCS1PublicKey(key))
+	return base64.RawURLEncoding.EncodeToString(sum[:12])
+}
+
+// JWKS returns the public verification keys. It is empty while tokens are signed HS256.
+func (k *JWTKeys) JWKS() JWKSet {
+	set := JWKSet{Keys: make([]JWK, 0, len(k.PublicKeys))}
+	for kid, key := range k.PublicKeys {
+		set.Keys = append(set.Keys, JWK{
+			KeyType:   "RSA",
+			Use:       "sig",
+			Algorithm: jwt.SigningMethodRS256.Alg(),
+			KeyID:     kid,
+			Modulus:   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			Exponent:  base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		})
+	}
+	return set
+}
+
+// verificationKey picks the key verifying token from its algorithm and kid
+func (k *JWTKeys) verificationKey(token *jwt.Token) (interface{}, error) {
+	switch token.Method.(type) {
+	case *jwt.SigningMethodRSA:
+		kid, _ := token.Header["kid"].(string)
+		key, ok := k.PublicKeys[kid]
+		if !ok {
+			return nil, fmt.Errorf("unknown signing key %q", kid)
+		}
+		return key, nil
+	case *jwt.SigningMethodHMAC:
+		return []byte(k.Secret), nil
+	}
+	return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+}
+
+func readRSAPrivateKey(file string) (*rsa.PrivateKey, error) {
+	block, err := readPEM(file)
+	if err != nil {
+		return nil, err
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse private key %s: %w", file, err)
+	}
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("private key %s is not an RSA key", file)
+	}
+	return key, nil
+}
+
+func readRSAPublicKey(file string) (*rsa.PublicKey, error) {
+	block, err := readPEM(file)
+	if err != nil {
+		return nil, err
+	}
+	if key, err := x509.ParsePKCS1PublicKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	parsed, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse public key %s: %w", file, err)
+	}
+	key, ok := parsed.(*rsa.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("public key %s is not an RSA key", file)
+	}
+	return key, nil
+}
+
+func readPEM(file string) (*pem.Block, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read key file: %w", err)
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("key file %s is not PEM encoded", file)
+	}
+	return block, nil
+}
@@ -45,7 +45,7 @@ type PasswordResetTokenData struct {
 }
 
 // GenerateJWT generates a JWT access token
-func GenerateJWT(userID int64, email string, expiresAt time.Time, secret string) (string, error) {
+func GenerateJWT(userID int64, email string, expiresAt time.Time, keys *JWTKeys) (string, error) {
 	now := time.Now()
 
 	// Sử dụng các trường chuẩn từ jwt.RegisteredClaims
@@ -59,11 +59,18 @@ func GenerateJWT(userID int64, email string, expiresAt time.Time, secret string)
 		},
 	}
 
-	// Tạo token với claims và phương thức ký HS256
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-
-	// Ký token với secret key và trả về chuỗi token
-	signedToken, err := token.SignedString([]byte(secret))
+	// RS256 when a private key is configured, so holders of the JWKS can verify
+	// tokens without the secret; HS256 with the shared secret otherwise
+	var signedToken string
+	var err error
+	if keys.PrivateKey != nil {
+		token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+		token.Header["kid"] = keys.KeyID
+		signedToken, err = token.SignedString(keys.PrivateKey)
+	} else {
+		token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+		signedToken, err = token.SignedString([]byte(keys.Secret))
+	}
 	if err != nil {
 		return "", fmt.Errorf("could not sign token: %w", err)
 	}
@@ -71,15 +78,12 @@ func GenerateJWT(userID int64, email string, expiresAt time.Time, secret string)
 	return signedToken, nil
 }
 
-// ValidateJWT validates a JWT token and returns its claims if valid
-func ValidateJWT(tokenString string, secret string) (*JWTClaims, error) {
-	token, err := jwt.ParseWithClaims(tokenString, &JWTClaims{}, func(token *jwt.Token) (interface{}, error) {
-		// Kiểm tra phương thức ký, đảm bảo là HMAC
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-		}
-		return []byte(secret), nil
-	})
+// ValidateJWT validates a JWT token and returns its claims if valid. HS256
+// tokens are accepted alongside RS256 ones, so tokens issued before switching
+// to RS256 stay valid until they expire.
+func ValidateJWT(tokenString string, keys *JWTKeys) (*JWTClaims, error) {
+	token, err := jwt.ParseWithClaims(tokenString, &JWTClaims{}, keys.verificationKey,
+		jwt.WithValidMethods([]string{jwt.SigningMethodRS256.Alg(), jwt.SigningMethodHS256.Alg()}))
 
 	if err != nil {
 		return nil, fmt.Errorf("could not parse token: %w", err)
@@ -104,12 +108,12 @@ func GenerateRefreshToken() (string, error) {
 }
 
 // GenerateTokenPair creates a new access and refresh token pair
-func GenerateTokenPair(userID int64, email, secret string, accessDuration, refreshDuration time.Duration) (*TokenPair, error) {
+func GenerateTokenPair(userID int64, email string, keys *JWTKeys, accessDuration, refreshDuration time.Duration) (*TokenPair, error) {
 	accessExpiresAt := time.Now().Add(accessDuration)
 	refreshExpiresAt := time.Now().Add(refreshDuration)
 
 	// Tạo access token
-	accessToken, err := GenerateJWT(userID, email, accessExpiresAt, secret)
+	accessToken, err := GenerateJWT(userID, email, accessExpiresAt, keys)
 	if err != nil {
 		return nil, fmt.Errorf("could not generate access token: %w", err)
 	}
@@ -35,6 +35,7 @@ type ServerConfig struct {
 	WriteTimeout    time.Duration
 	ShutdownTimeout time.Duration
 	TLS             TLSConfig
+	MaxMessageSize  int // Largest gRPC message sent or received, in bytes, by the server and its clients
 }
 
 // TLSConfig contains TLS/SSL certificate settings
@@ -43,6 +44,9 @@ type TLSConfig struct {
 	CertFile string // Path to server certificate (.pem)
 	KeyFile  string // Path to server private key (.pem)
 	CAFile   string // Path to CA certificate for client verification (.pem)
+	// ClientAuth requires and verifies client certificates (mTLS); the service's
+	// own certificate is then also presented when it calls other services
+	ClientAuth bool
 }
 
 // DatabaseConfig contains PostgreSQL connection settings
@@ -56,6 +60,7 @@ type DatabaseConfig struct {
 	MaxOpenConns    int
 	MaxIdleConns    int
 	ConnMaxLifetime time.Duration
+	ReadReplicaDSN  string // Optional read replica for services that route reads to it
 }
 
 // RedisConfig contains Redis connection settings
@@ -91,10 +96,11 @@ type ExternalServices struct {
 
 // ServiceEndpoint represents a microservice endpoint
 type ServiceEndpoint struct {
-	GRPCAddr string
-	HTTPAddr string
-	Timeout  time.Duration
-	Enabled  bool
+	GRPCAddr   string
+	HTTPAddr   string
+	Timeout    time.Duration // Per call attempt
+	MaxRetries int           // Retries of idempotent calls on transient errors
+	Enabled    bool
 }
 
 // AuthConfig contains authentication settings
@@ -109,7 +115,7 @@ type AuthConfig struct {
 // LoggingConfig contains logging settings
 type LoggingConfig struct {
 	Level    string // debug, info, warn, error
-	Format   string // json, text
+	Format   string // json, console (text)
 	Output   string // stdout, file
 	FilePath string
 }
@@ -232,6 +238,9 @@ func (c *Config) PrintConfig() {
 	fmt.Printf("  User: %s\n", c.Database.User)
 	fmt.Printf("  Password: %s\n", maskPassword(c.Database.Password))
 	fmt.Printf("  SSL Mode: %s\n", c.Database.SSLMode)
+	if c.Database.ReadReplicaDSN != "" {
+		fmt.Printf("  Read Replica: configured\n")
+	}
 
 	// Redis
 	if c.Redis.Enabled {
@@ -273,7 +282,7 @@ func (c *Config) PrintConfig() {
 	fmt.Printf("  Format: %s\n", c.Logging.Format)
 	fmt.Printf("  Output: %s\n", c.Logging.Output)
 
-	fmt.Println("===========================\n")
+	fmt.Printf("===========================\n\n")
 }
 
 // Helper function to mask passwords
@@ -307,6 +316,7 @@ func LoadServerConfig(serviceName, defaultHTTPPort, defaultGRPCPort string) Serv
 		WriteTimeout:    GetEnvAsDuration("WRITE_TIMEOUT", 30*time.Second),
 		ShutdownTimeout: GetEnvAsDuration("SHUTDOWN_TIMEOUT", 15*time.Second),
 		TLS:             LoadTLSConfig(serviceName),
+		MaxMessageSize:  GetEnvAsInt("GRPC_MAX_MESSAGE_SIZE_MB", 10) * 1024 * 1024,
 	}
 }
 
@@ -324,6 +334,8 @@ func LoadTLSConfig(serviceName string) TLSConfig {
 		CertFile: GetEnv("TLS_CERT_FILE", defaultCertPath),
 		KeyFile:  GetEnv("TLS_KEY_FILE", defaultKeyPath),
 		CAFile:   GetEnv("TLS_CA_FILE", defaultCAPath),
+		// mTLS is only meaningful on top of TLS
+		ClientAuth: enabled && GetEnvAsBool("TLS_CLIENT_AUTH", false),
 	}
 }
 
@@ -339,6 +351,7 @@ func LoadDatabaseConfig(defaultDBName string) DatabaseConfig {
 		MaxOpenConns:    GetEnvAsInt("DB_MAX_OPEN_CONNS", 100), // Increased from 25 for load testing
 		MaxIdleConns:    GetEnvAsInt("DB_MAX_IDLE_CONNS", 25),  // Increased from 5 for load testing
 		ConnMaxLifetime: GetEnvAsDurationMinutes("DB_CONN_MAX_LIFETIME", 5*time.Minute),
+		ReadReplicaDSN:  GetEnv("DB_READ_REPLICA_DSN", ""),
 	}
 }
 
@@ -370,7 +383,7 @@ func LoadRabbitMQConfig() RabbitMQConfig {
 // LoadAuthConfig loads common auth configuration
 func LoadAuthConfig() AuthConfig {
 	return AuthConfig{
-		JWTSecret:       GetEnv("JWT_SECRET", "your-secret-key"),
+		JWTSecret:       GetEnv("JWT_SECRET", DefaultJWTSecret),
 		AccessTokenTTL:  GetEnvAsDurationMinutes("JWT_ACCESS_TOKEN_TTL", 15*time.Minute),
 		RefreshTokenTTL: GetEnvAsDurationHours("JWT_REFRESH_TOKEN_TTL", 168*time.Hour),
 		ResetTokenTTL:   GetEnvAsDurationMinutes("JWT_RESET_TOKEN_TTL", 30*time.Minute),
@@ -378,11 +391,16 @@ func LoadAuthConfig() AuthConfig {
 	}
 }
 
-// LoadLoggingConfig loads common logging configuration
+// LoadLoggingConfig loads common logging configuration. Development defaults to
+// debug console output, other environments to info JSON.
 func LoadLoggingConfig() LoggingConfig {
+	level, format := "info", "json"
+	if GetEnv("ENVIRONMENT", "development") == "development" {
+		level, format = "debug", "console"
+	}
 	return LoggingConfig{
-		Level:    GetEnv("LOG_LEVEL", "info"),
-		Format:   GetEnv("LOG_FORMAT", "json"),
+		Level:    GetEnv("LOG_LEVEL", level),
+		Format:   GetEnv("LOG_FORMAT", format),
 		Output:   GetEnv("LOG_OUTPUT", "stdout"),
 		FilePath: GetEnv("LOG_FILE_PATH", "/var/log/service.log"),
 	}
@@ -391,10 +409,11 @@ func LoadLoggingConfig() LoggingConfig {
 // LoadServiceEndpoint loads configuration for an external service
 func LoadServiceEndpoint(prefix string, defaultGRPC, defaultHTTP string, defaultTimeout time.Duration) ServiceEndpoint {
 	return ServiceEndpoint{
-		GRPCAddr: GetEnv(prefix+"_GRPC", defaultGRPC),
-		HTTPAddr: GetEnv(prefix+"_HTTP", defaultHTTP),
-		Timeout:  GetEnvAsDuration(prefix+"_TIMEOUT", defaultTimeout),
-		Enabled:  GetEnvAsBool(prefix+"_ENABLED", true),
+		GRPCAddr:   GetEnv(prefix+"_GRPC", defaultGRPC),
+		HTTPAddr:   GetEnv(prefix+"_HTTP", defaultHTTP),
+		Timeout:    GetEnvAsDuration(prefix+"_TIMEOUT", defaultTimeout),
+		MaxRetries: GetEnvAsInt(prefix+"_MAX_RETRIES", 2),
+		Enabled:    GetEnvAsBool(prefix+"_ENABLED", true),
 	}
 }
 
@@ -0,0 +1,154 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// DefaultJWTSecret is the development fallback for JWT_SECRET; it is refused in production
+const DefaultJWTSecret = "your-secret-key"
+
+// minProductionJWTSecretLength is the shortest JWT secret accepted in production (256 bits for HS256)
+const minProductionJWTSecretLength = 32
+
+// Environments a service can run in
+const (
+	EnvironmentDevelopment = "development"
+	EnvironmentStaging     = "staging"
+	EnvironmentProduction  = "production"
+)
+
+// IsProduction reports whether the service runs in production
+func (s ServiceInfo) IsProduction() bool {
+	return s.Environment == EnvironmentProduction
+}
+
+// Validator collects configuration problems so a service can report all of
+// them at startup instead of failing on the first one. Malformed values are
+// always errors; in production, variables that have a development default
+// must also be set explicitly.
+type Validator struct {
+	production bool
+	problems   []string
+}
+
+// NewValidator creates a validator for a service; an unknown environment is itself a problem
+func NewValidator(service ServiceInfo) *Validator {
+	v := &Validator{production: service.IsProduction()}
+	switch service.Environment {
+	case EnvironmentDevelopment, EnvironmentStaging, EnvironmentProduction:
+	default:
+		v.Addf("ENVIRONMENT must be one of %s, %s, %s (got %q)",
+			EnvironmentDevelopment, EnvironmentStaging, EnvironmentProduction, service.Environment)
+	}
+	return v
+}
+
+// Addf records a problem
+func (v *Validator) Addf(format string, args ...interface{}) {
+	v.problems = append(v.problems, fmt.Sprintf(format, args...))
+}
+
+// Check records msg when ok is false
+func (v *Validator) Check(ok bool, msg string) {
+	if !ok {
+		v.problems = append(v.problems, msg)
+	}
+}
+
+// RequireInProduction records each variable that is unset in production,
+// where its development default must not be used
+func (v *Validator) RequireInProduction(keys ...string) {
+	if !v.production {
+		return
+	}
+	for _, key := range keys {
+		if os.Getenv(key) == "" {
+			v.Addf("%s is required in production", key)
+		}
+	}
+}
+
+// CheckServer validates listen ports and TLS files (the CA too with mTLS); an empty gRPC port means no gRPC server
+func (v *Validator) CheckServer(server ServerConfig) {
+	v.checkPort("HTTP_PORT", server.HTTPPort)
+	if server.GRPCPort != "" {
+		v.checkPort("GRPC_PORT", server.GRPCPort)
+	}
+	v.Check(server.MaxMessageSize >= 0, "GRPC_MAX_MESSAGE_SIZE_MB must not be negative")
+	if server.TLS.Enabled {
+		v.checkFile("TLS_CERT_FILE", server.TLS.CertFile)
+		v.checkFile("TLS_KEY_FILE", server.TLS.KeyFile)
+		if server.TLS.ClientAuth {
+			v.checkFile("TLS_CA_FILE", server.TLS.CAFile)
+		}
+	}
+}
+
+// CheckDatabase validates PostgreSQL settings; production needs explicit host and credentials
+func (v *Validator) CheckDatabase(db DatabaseConfig) {
+	v.checkPort("DB_PORT", db.Port)
+	v.Check(db.MaxOpenConns >= 0 && db.MaxIdleConns >= 0, "DB_MAX_OPEN_CONNS and DB_MAX_IDLE_CONNS must not be negative")
+	v.RequireInProduction("DB_HOST", "DB_USER", "DB_PASSWORD", "DB_NAME")
+}
+
+// CheckRedis validates Redis settings when Redis is enabled
+func (v *Validator) CheckRedis(redis RedisConfig) {
+	if !redis.Enabled {
+		return
+	}
+	v.checkPort("REDIS_PORT", redis.Port)
+	v.RequireInProduction("REDIS_HOST")
+}
+
+// CheckRabbitMQ validates RabbitMQ settings when RabbitMQ is enabled;
+// the broker's guest account is refused in production
+func (v *Validator) CheckRabbitMQ(rabbit RabbitMQConfig) {
+	if !rabbit.Enabled {
+		return
+	}
+	v.checkPort("RABBITMQ_PORT", rabbit.Port)
+	v.RequireInProduction("RABBITMQ_HOST", "RABBITMQ_USER", "RABBITMQ_PASSWORD")
+	if v.production && rabbit.User == "guest" {
+		v.Addf("RABBITMQ_USER must not be guest in production")
+	}
+}
+
+// CheckAuth validates JWT settings; the default secret and short secrets are refused in production
+func (v *Validator) CheckAuth(auth AuthConfig) {
+	v.Check(auth.AccessTokenTTL > 0, "JWT_ACCESS_TOKEN_TTL must be positive")
+	v.Check(auth.RefreshTokenTTL > 0, "JWT_REFRESH_TOKEN_TTL must be positive")
+	if !v.production {
+		return
+	}
+	switch {
+	case os.Getenv("JWT_SECRET") == "":
+		v.Addf("JWT_SECRET is required in production")
+	case auth.JWTSecret == DefaultJWTSecret:
+		v.Addf("JWT_SECRET must not be the development default in production")
+	case len(auth.JWTSecret) < minProductionJWTSecretLength:
+		v.Addf("JWT_SECRET must be at least %d characters in production", minProductionJWTSecretLength)
+	}
+}
+
+// Err returns every recorded problem as one error, or nil
+func (v *Validator) Err() error {
+	if len(v.problems) == 0 {
+		return nil
+	}
+	return fmt.Errorf("invalid configuration:\n  - %s", strings.Join(v.problems, "\n  - "))
+}
+
+func (v *Validator) checkPort(key, port string) {
+	if n, err := strconv.Atoi(port); err != nil || n < 1 || n > 65535 {
+		v.Addf("%s must be a port number (got %q)", key, port)
+	}
+}
+
+func (v *Validator) checkFile(key, path string) {
+	if _, err := os.Stat(path); err != nil {
+		v.Addf("%s must name a readable file when TLS_ENABLED is true (%s: %v)", key, path, err)
+	}
+}
@@ -0,0 +1,50 @@
+package config
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestValidatorProduction(t *testing.T) {
+	t.Setenv("DB_HOST", "")
+	t.Setenv("DB_USER", "app")
+	t.Setenv("DB_PASSWORD", "")
+	t.Setenv("DB_NAME", "orders_db")
+	t.Setenv("JWT_SECRET", DefaultJWTSecret)
+
+	v := NewValidator(ServiceInfo{Environment: EnvironmentProduction})
+	v.CheckDatabase(DatabaseConfig{Port: "5432"})
+	v.CheckAuth(AuthConfig{JWTSecret: DefaultJWTSecret, AccessTokenTTL: time.Minute, RefreshTokenTTL: time.Hour})
+
+	err := v.Err()
+	if err == nil {
+		t.Fatal("expected production validation to fail")
+	}
+	for _, want := range []string{"DB_HOST is required", "DB_PASSWORD is required", "JWT_SECRET must not be the development default"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q does not mention %q", err, want)
+		}
+	}
+	if strings.Contains(err.Error(), "DB_USER") {
+		t.Errorf("error %q mentions DB_USER, which is set", err)
+	}
+}
+
+func TestValidatorDevelopmentDefaults(t *testing.T) {
+	t.Setenv("JWT_SECRET", "")
+
+	v := NewValidator(ServiceInfo{Environment: EnvironmentDevelopment})
+	v.CheckServer(ServerConfig{HTTPPort: "8001", GRPCPort: "9001"})
+	v.CheckDatabase(DatabaseConfig{Port: "5432"})
+	v.CheckAuth(AuthConfig{JWTSecret: DefaultJWTSecret, AccessTokenTTL: time.Minute, RefreshTokenTTL: time.Hour})
+	if err := v.Err(); err != nil {
+		t.Fatalf("development defaults should be accepted: %v", err)
+	}
+
+	v = NewValidator(ServiceInfo{Environment: "prod"})
+	v.CheckServer(ServerConfig{HTTPPort: "http"})
+	if err := v.Err(); err == nil || !strings.Contains(err.Error(), "ENVIRONMENT") || !strings.Contains(err.Error(), "HTTP_PORT") {
+		t.Fatalf("expected environment and port errors, got %v", err)
+	}
+}
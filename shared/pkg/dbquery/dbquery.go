@@ -0,0 +1,90 @@
+// Package dbquery times database/sql queries by operation name and reuses
+// prepared statements for hot queries.
+package dbquery
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sync"
+	"time"
+)
+
+// Observer records the duration and outcome of one named operation
+type Observer func(operation string, err error, duration time.Duration)
+
+type stmtKey struct {
+	db    *sql.DB
+	query string
+}
+
+// Instrumenter times repository operations and caches prepared statements.
+// Statements are cached per connection pool, so one instrumenter can serve a
+// primary and a read replica.
+type Instrumenter struct {
+	observe Observer
+
+	mu    sync.RWMutex
+	stmts map[stmtKey]*sql.Stmt
+}
+
+// New creates an instrumenter reporting to observe; a nil observe only caches statements
+func New(observe Observer) *Instrumenter {
+	return &Instrumenter{observe: observe, stmts: make(map[stmtKey]*sql.Stmt)}
+}
+
+// Do runs fn as operation and records how long it took. sql.ErrNoRows counts as
+// success: a missing row is an answer, not a failed query.
+func (i *Instrumenter) Do(operation string, fn func() error) error {
+	start := time.Now()
+	err := fn()
+	if i.observe != nil {
+		observed := err
+		if errors.Is(observed, sql.ErrNoRows) {
+			observed = nil
+		}
+		i.observe(operation, observed, time.Since(start))
+	}
+	return err
+}
+
+// Prepare returns a prepared statement for query on db. The statement is prepared
+// on first use and reused afterwards; database/sql re-prepares it on other
+// connections of the pool as needed. Use tx.StmtContext to run it in a transaction.
+func (i *Instrumenter) Prepare(ctx context.Context, db *sql.DB, query string) (*sql.Stmt, error) {
+	key := stmtKey{db: db, query: query}
+
+	i.mu.RLock()
+	stmt, ok := i.stmts[key]
+	i.mu.RUnlock()
+	if ok {
+		return stmt, nil
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if stmt, ok := i.stmts[key]; ok {
+		return stmt, nil
+	}
+	stmt, err := db.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	i.stmts[key] = stmt
+	return stmt, nil
+}
+
+// Close closes every cached statement
+func (i *Instrumenter) Close() error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	var errs []error
+	for key, stmt := range i.stmts {
+		if err := stmt.Close(); err != nil {
+			errs = append(errs, err)
+		}
+		delete(i.stmts, key)
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,63 @@
+package dbquery
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+type observation struct {
+	operation string
+	err       error
+}
+
+func TestDoRecordsOperationAndOutcome(t *testing.T) {
+	var got []observation
+	inst := New(func(operation string, err error, d time.Duration) {
+		if d < 0 {
+			t.Errorf("negative duration %v", d)
+		}
+		got = append(got, observation{operation, err})
+	})
+
+	failure := errors.New("connection reset")
+	tests := []struct {
+		name     string
+		err      error
+		observed error
+	}{
+		{"success", nil, nil},
+		{"failure", failure, failure},
+		{"no rows", sql.ErrNoRows, nil},
+		{"wrapped no rows", fmt.Errorf("get: %w", sql.ErrNoRows), nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got = nil
+			err := inst.Do("products.get_by_id", func() error { return tt.err })
+			if err != tt.err {
+				t.Errorf("Do returned %v, want %v", err, tt.err)
+			}
+			if len(got) != 1 {
+				t.Fatalf("got %d observations, want 1", len(got))
+			}
+			if got[0].operation != "products.get_by_id" || got[0].err != tt.observed {
+				t.Errorf("observed %+v, want operation products.get_by_id with error %v", got[0], tt.observed)
+			}
+		})
+	}
+}
+
+func TestDoWithoutObserver(t *testing.T) {
+	inst := New(nil)
+	called := false
+	if err := inst.Do("noop", func() error { called = true; return nil }); err != nil {
+		t.Fatalf("Do returned %v", err)
+	}
+	if !called {
+		t.Error("fn was not called")
+	}
+}
@@ -0,0 +1,161 @@
+// Package domainerr defines the errors application services return for expected
+// failures and maps them to gRPC status codes with client-safe messages.
+package domainerr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Kind classifies a domain error
+type Kind int
+
+const (
+	KindInternal        Kind = iota // Unexpected failure; details stay server-side
+	KindNotFound                    // The requested entity does not exist
+	KindAlreadyExists               // The entity to create exists already
+	KindInvalidArgument             // The request itself is invalid
+	KindConflict                    // The request conflicts with the entity's current state
+	KindAborted                     // A concurrent operation got in the way; the client may retry
+	KindUnavailable                 // A dependency is down or timed out; the client may retry later
+)
+
+// internalMessage is sent to clients in place of internal error details
+const internalMessage = "internal error"
+
+// Error is a domain error. Message is shown to clients; Err, if set, is the
+// underlying cause and is only logged.
+type Error struct {
+	Kind    Kind
+	Message string
+	Err     error
+}
+
+func (e *Error) Error() string {
+	if e.Err != nil {
+		return e.Message + ": " + e.Err.Error()
+	}
+	return e.Message
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// NotFound reports a missing entity
+func NotFound(format string, args ...any) *Error {
+	return &Error{Kind: KindNotFound, Message: fmt.Sprintf(format, args...)}
+}
+
+// AlreadyExists reports an entity that cannot be created twice
+func AlreadyExists(format string, args ...any) *Error {
+	return &Error{Kind: KindAlreadyExists, Message: fmt.Sprintf(format, args...)}
+}
+
+// InvalidArgument reports an invalid request
+func InvalidArgument(format string, args ...any) *Error {
+	return &Error{Kind: KindInvalidArgument, Message: fmt.Sprintf(format, args...)}
+}
+
+// Conflict reports a request the entity's current state does not allow
+func Conflict(format string, args ...any) *Error {
+	return &Error{Kind: KindConflict, Message: fmt.Sprintf(format, args...)}
+}
+
+// Aborted reports an operation that lost a race and can be retried
+func Aborted(format string, args ...any) *Error {
+	return &Error{Kind: KindAborted, Message: fmt.Sprintf(format, args...)}
+}
+
+// Unavailable wraps the failure of a dependency (e.g. another service timing out).
+// Clients see the message; the cause is only logged.
+func Unavailable(err error, format string, args ...any) *Error {
+	return &Error{Kind: KindUnavailable, Message: fmt.Sprintf(format, args...), Err: err}
+}
+
+// Internal wraps an unexpected failure; clients only see that an internal error occurred
+func Internal(err error, format string, args ...any) *Error {
+	return &Error{Kind: KindInternal, Message: fmt.Sprintf(format, args...), Err: err}
+}
+
+// KindOf returns the kind of the first domain error in err's chain,
+// or KindInternal if there is none
+func KindOf(err error) Kind {
+	var de *Error
+	if errors.As(err, &de) {
+		return de.Kind
+	}
+	return KindInternal
+}
+
+// Is reports whether err's chain holds a domain error of kind
+func Is(err error, kind Kind) bool {
+	var de *Error
+	return errors.As(err, &de) && de.Kind == kind
+}
+
+var kindCodes = map[Kind]codes.Code{
+	KindInternal:        codes.Internal,
+	KindNotFound:        codes.NotFound,
+	KindAlreadyExists:   codes.AlreadyExists,
+	KindInvalidArgument: codes.InvalidArgument,
+	KindConflict:        codes.FailedPrecondition,
+	KindAborted:         codes.Aborted,
+	KindUnavailable:     codes.Unavailable,
+}
+
+// ToStatus converts err to a gRPC status error. Status errors pass through unchanged,
+// domain errors take their kind's code and message, context errors keep their
+// meaning, and anything else becomes a sanitized Internal error.
+func ToStatus(err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := err.(interface{ GRPCStatus() *status.Status }); ok {
+		return err
+	}
+
+	var de *Error
+	if errors.As(err, &de) {
+		if de.Kind == KindInternal {
+			return status.Error(codes.Internal, internalMessage)
+		}
+		return status.Error(kindCodes[de.Kind], de.Message)
+	}
+
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, context.DeadlineExceeded.Error())
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, context.Canceled.Error())
+	}
+	return status.Error(codes.Internal, internalMessage)
+}
+
+// UnaryServerInterceptor maps handler errors with ToStatus. Errors that become
+// Internal or Unavailable are logged in full, since clients no longer see their details.
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		resp, err := handler(ctx, req)
+		if err == nil {
+			return resp, nil
+		}
+
+		mapped := ToStatus(err)
+		if code := status.Code(mapped); mapped != err && (code == codes.Internal || code == codes.Unavailable) {
+			log.Printf("ERROR: %s: %v", info.FullMethod, err)
+		}
+		return resp, mapped
+	}
+}
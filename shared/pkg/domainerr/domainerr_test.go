@@ -0,0 +1,95 @@
+package domainerr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestToStatus(t *testing.T) {
+	dbErr := errors.New(`pq: relation "orders" does not exist`)
+	passthrough := status.Error(codes.Unavailable, "downstream unavailable")
+
+	tests := []struct {
+		name    string
+		err     error
+		code    codes.Code
+		message string
+	}{
+		{"not found", NotFound("order not found"), codes.NotFound, "order not found"},
+		{"already exists", AlreadyExists("coupon %s already exists", "SAVE10"), codes.AlreadyExists, "coupon SAVE10 already exists"},
+		{"invalid argument", InvalidArgument("amount must be positive"), codes.InvalidArgument, "amount must be positive"},
+		{"conflict", Conflict("can only refund completed payments"), codes.FailedPrecondition, "can only refund completed payments"},
+		{"aborted", Aborted("cart is busy, please retry"), codes.Aborted, "cart is busy, please retry"},
+		{"wrapped domain error", fmt.Errorf("failed to cancel order: %w", NotFound("order not found")), codes.NotFound, "order not found"},
+		{"unavailable hides cause", Unavailable(errors.New("connection refused"), "product service unavailable"), codes.Unavailable, "product service unavailable"},
+		{"internal hides cause", Internal(dbErr, "failed to create order"), codes.Internal, "internal error"},
+		{"raw error is sanitized", dbErr, codes.Internal, "internal error"},
+		{"wrapped raw error is sanitized", fmt.Errorf("failed to get order: %w", dbErr), codes.Internal, "internal error"},
+		{"deadline", fmt.Errorf("query: %w", context.DeadlineExceeded), codes.DeadlineExceeded, context.DeadlineExceeded.Error()},
+		{"canceled", context.Canceled, codes.Canceled, context.Canceled.Error()},
+		{"status passes through", passthrough, codes.Unavailable, "downstream unavailable"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st, ok := status.FromError(ToStatus(tt.err))
+			if !ok {
+				t.Fatalf("ToStatus(%v) is not a status error", tt.err)
+			}
+			if st.Code() != tt.code || st.Message() != tt.message {
+				t.Errorf("got %s %q, want %s %q", st.Code(), st.Message(), tt.code, tt.message)
+			}
+		})
+	}
+
+	if ToStatus(nil) != nil {
+		t.Error("ToStatus(nil) should be nil")
+	}
+}
+
+func TestKindOf(t *testing.T) {
+	if got := KindOf(fmt.Errorf("wrap: %w", Conflict("busy"))); got != KindConflict {
+		t.Errorf("KindOf wrapped conflict = %v, want KindConflict", got)
+	}
+	if got := KindOf(errors.New("boom")); got != KindInternal {
+		t.Errorf("KindOf plain error = %v, want KindInternal", got)
+	}
+	if !Is(NotFound("x"), KindNotFound) || Is(NotFound("x"), KindConflict) {
+		t.Error("Is does not match the error kind")
+	}
+}
+
+func TestErrorMessageKeepsCause(t *testing.T) {
+	err := Internal(errors.New("connection refused"), "failed to save payment")
+	if err.Error() != "failed to save payment: connection refused" {
+		t.Errorf("Error() = %q", err.Error())
+	}
+	if !errors.Is(err, err.Err) {
+		t.Error("Internal error should unwrap to its cause")
+	}
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	interceptor := UnaryServerInterceptor()
+	info := &grpc.UnaryServerInfo{FullMethod: "/order.OrderService/GetOrder"}
+
+	_, err := interceptor(context.Background(), nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, NotFound("order not found")
+	})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("got code %s, want NotFound", status.Code(err))
+	}
+
+	resp, err := interceptor(context.Background(), nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return "ok", nil
+	})
+	if err != nil || resp != "ok" {
+		t.Errorf("got (%v, %v), want (ok, nil)", resp, err)
+	}
+}
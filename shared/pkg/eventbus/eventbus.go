@@ -0,0 +1,87 @@
+// Package eventbus is the broker-neutral side of event publishing: the envelope every
+// event travels in, and the interface a broker's publisher implements. Producers build
+// envelopes and consumers decode them without knowing which broker carried them, so
+// the broker is a config choice.
+package eventbus
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/datngth03/ecommerce-go-app/shared/pkg/outbox"
+)
+
+// Brokers that can carry events
+const (
+	BrokerRabbitMQ = "rabbitmq"
+)
+
+// ParseBroker normalizes a broker name and checks it is supported
+func ParseBroker(name string) (string, error) {
+	switch broker := strings.ToLower(strings.TrimSpace(name)); broker {
+	case BrokerRabbitMQ:
+		return broker, nil
+	default:
+		return "", fmt.Errorf("unsupported event broker %q (supported: %s)", name, BrokerRabbitMQ)
+	}
+}
+
+// Envelope wraps every event on the wire
+type Envelope struct {
+	Type      string          `json:"type"`          // e.g. "product.updated"; the routing key or topic
+	ID        string          `json:"id"`            // Unique per event; consumers drop IDs they have seen
+	Version   int             `json:"version"`       // Schema version of Payload
+	Timestamp time.Time       `json:"timestamp"`     // When the event occurred
+	Key       string          `json:"key,omitempty"` // ID of the entity the event is about
+	Payload   json.RawMessage `json:"payload"`
+}
+
+// Decode reads an envelope from a message body
+func Decode(body []byte) (Envelope, error) {
+	var env Envelope
+	if err := json.Unmarshal(body, &env); err != nil {
+		return Envelope{}, fmt.Errorf("failed to decode event envelope: %w", err)
+	}
+	if env.Type == "" || env.ID == "" {
+		return Envelope{}, fmt.Errorf("event envelope is missing its type or id")
+	}
+	return env, nil
+}
+
+// Publisher delivers envelopes to a broker. Publish must return only once the broker
+// has accepted the event. Delivery is at least once.
+type Publisher interface {
+	Publish(ctx context.Context, env Envelope) error
+	Close() error
+}
+
+// OutboxPublisher lets the outbox relay publish through any broker. Each outbox event
+// is wrapped in an envelope with its outbox ID as the event ID.
+type OutboxPublisher struct {
+	publisher Publisher
+	version   int
+}
+
+var _ outbox.Publisher = (*OutboxPublisher)(nil)
+
+// NewOutboxPublisher wraps publisher for the outbox relay; version is the schema
+// version of the outbox payloads
+func NewOutboxPublisher(publisher Publisher, version int) *OutboxPublisher {
+	return &OutboxPublisher{publisher: publisher, version: version}
+}
+
+// Publish sends one outbox event
+func (p *OutboxPublisher) Publish(ctx context.Context, event outbox.Event) error {
+	return p.publisher.Publish(ctx, Envelope{
+		Type:      event.Topic,
+		ID:        strconv.FormatInt(event.ID, 10),
+		Version:   p.version,
+		Timestamp: event.CreatedAt,
+		Key:       event.Key,
+		Payload:   event.Payload,
+	})
+}
@@ -0,0 +1,70 @@
+package eventbus
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/datngth03/ecommerce-go-app/shared/pkg/outbox"
+)
+
+type recordingPublisher struct {
+	published []Envelope
+}
+
+func (p *recordingPublisher) Publish(_ context.Context, env Envelope) error {
+	p.published = append(p.published, env)
+	return nil
+}
+
+func (p *recordingPublisher) Close() error { return nil }
+
+func TestOutboxPublisherWrapsEvents(t *testing.T) {
+	broker := &recordingPublisher{}
+	createdAt := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	err := NewOutboxPublisher(broker, 1).Publish(context.Background(), outbox.Event{
+		ID:        42,
+		Topic:     "product.updated",
+		Key:       "p-1",
+		Payload:   []byte(`{"product_id":"p-1"}`),
+		CreatedAt: createdAt,
+	})
+	if err != nil {
+		t.Fatalf("Publish: %v", err)
+	}
+	if len(broker.published) != 1 {
+		t.Fatalf("published %d envelopes, want 1", len(broker.published))
+	}
+
+	body, err := json.Marshal(broker.published[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	env, err := Decode(body)
+	if err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+	if env.Type != "product.updated" || env.ID != "42" || env.Version != 1 || env.Key != "p-1" ||
+		!env.Timestamp.Equal(createdAt) || string(env.Payload) != `{"product_id":"p-1"}` {
+		t.Errorf("envelope = %+v", env)
+	}
+}
+
+func TestDecodeRejectsIncompleteEnvelopes(t *testing.T) {
+	for _, body := range []string{`not json`, `{"id":"1","payload":{}}`, `{"type":"product.updated","payload":{}}`} {
+		if _, err := Decode([]byte(body)); err == nil {
+			t.Errorf("Decode(%s) should fail", body)
+		}
+	}
+}
+
+func TestParseBroker(t *testing.T) {
+	if broker, err := ParseBroker(" RabbitMQ "); err != nil || broker != BrokerRabbitMQ {
+		t.Errorf("ParseBroker(RabbitMQ) = %q, %v", broker, err)
+	}
+	if _, err := ParseBroker("kafka"); err == nil {
+		t.Error("kafka has no publisher and should be rejected")
+	}
+}
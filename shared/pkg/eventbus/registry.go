@@ -0,0 +1,117 @@
+package eventbus
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+)
+
+// LegacyVersion is the schema version of messages published before the envelope:
+// the body is the bare payload, and the type comes from the routing key
+const LegacyVersion = 0
+
+// ErrUnknownEventType is returned for events no decoder is registered for
+var ErrUnknownEventType = errors.New("unknown event type")
+
+// DecodeFunc turns the payload of one schema version into the consumer's current type
+type DecodeFunc func(payload json.RawMessage) (interface{}, error)
+
+// JSON decodes payloads into T. Unknown fields are ignored, so producers can add
+// fields without a new version.
+func JSON[T any]() DecodeFunc {
+	return func(payload json.RawMessage) (interface{}, error) {
+		var v T
+		if err := json.Unmarshal(payload, &v); err != nil {
+			return nil, err
+		}
+		return v, nil
+	}
+}
+
+// Upgrade decodes payloads of an older schema into From and converts them to the current type
+func Upgrade[From, To any](convert func(From) To) DecodeFunc {
+	return func(payload json.RawMessage) (interface{}, error) {
+		var v From
+		if err := json.Unmarshal(payload, &v); err != nil {
+			return nil, err
+		}
+		return convert(v), nil
+	}
+}
+
+// Registry routes events to a decoder by type and schema version. Older versions are
+// decoded by their own decoder and logged once per type and version, so a lagging
+// producer shows up in the logs. Versions newer than any registered one are decoded
+// by the newest decoder: a producer deployed first may only have added fields.
+type Registry struct {
+	decoders map[string]map[int]DecodeFunc
+	latest   map[string]int
+	warned   sync.Map // "type@version" of old or unknown versions already logged
+}
+
+// NewRegistry creates an empty registry
+func NewRegistry() *Registry {
+	return &Registry{
+		decoders: make(map[string]map[int]DecodeFunc),
+		latest:   make(map[string]int),
+	}
+}
+
+// Register adds the decoder of one version of an event type. Register every version
+// before decoding; the highest one is the current schema.
+func (r *Registry) Register(eventType string, version int, decode DecodeFunc) {
+	if r.decoders[eventType] == nil {
+		r.decoders[eventType] = make(map[int]DecodeFunc)
+		r.latest[eventType] = version
+	}
+	r.decoders[eventType][version] = decode
+	if version > r.latest[eventType] {
+		r.latest[eventType] = version
+	}
+}
+
+// Decode decodes the payload of env with the decoder for its type and version
+func (r *Registry) Decode(env Envelope) (interface{}, error) {
+	versions, ok := r.decoders[env.Type]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownEventType, env.Type)
+	}
+
+	latest := r.latest[env.Type]
+	decode, ok := versions[env.Version]
+	switch {
+	case !ok && env.Version > latest:
+		r.warnOnce(env, fmt.Sprintf("newer than the latest known version %d; decoding it as that version", latest))
+		decode = versions[latest]
+	case !ok:
+		return nil, fmt.Errorf("unsupported schema version %d of %s event %s", env.Version, env.Type, env.ID)
+	case env.Version < latest:
+		r.warnOnce(env, fmt.Sprintf("older than the current version %d", latest))
+	}
+
+	v, err := decode(env.Payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode %s event %s (version %d): %w", env.Type, env.ID, env.Version, err)
+	}
+	return v, nil
+}
+
+// DecodeMessage decodes a message body. Bodies that are not an envelope are taken as
+// LegacyVersion payloads of routingKey's event type.
+func (r *Registry) DecodeMessage(body []byte, routingKey string) (Envelope, interface{}, error) {
+	env, err := Decode(body)
+	if err != nil || len(env.Payload) == 0 {
+		env = Envelope{Type: routingKey, Version: LegacyVersion, Payload: body}
+	}
+	v, err := r.Decode(env)
+	return env, v, err
+}
+
+func (r *Registry) warnOnce(env Envelope, detail string) {
+	if _, seen := r.warned.LoadOrStore(fmt.Sprintf("%s@%d", env.Type, env.Version), true); seen {
+		return
+	}
+	log.Printf("WARNING: received %s event with schema version %d, %s", env.Type, env.Version, detail)
+}
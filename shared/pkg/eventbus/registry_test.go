@@ -0,0 +1,82 @@
+package eventbus
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+// productV2 is the current schema; v1 had a single price field in cents
+type productV2 struct {
+	ProductID string  `json:"product_id"`
+	Price     float64 `json:"price"`
+}
+
+type productV1 struct {
+	ProductID  string `json:"product_id"`
+	PriceCents int64  `json:"price_cents"`
+}
+
+func newProductRegistry() *Registry {
+	r := NewRegistry()
+	r.Register("product.updated", 2, JSON[productV2]())
+	r.Register("product.updated", 1, Upgrade(func(v productV1) productV2 {
+		return productV2{ProductID: v.ProductID, Price: float64(v.PriceCents) / 100}
+	}))
+	r.Register("product.updated", LegacyVersion, Upgrade(func(v productV1) productV2 {
+		return productV2{ProductID: v.ProductID, Price: float64(v.PriceCents) / 100}
+	}))
+	return r
+}
+
+func TestRegistryRoutesByVersion(t *testing.T) {
+	r := newProductRegistry()
+	tests := []struct {
+		name    string
+		version int
+		payload string
+	}{
+		{"current", 2, `{"product_id":"p-1","price":12.5}`},
+		{"current with unknown fields", 2, `{"product_id":"p-1","price":12.5,"color":"red"}`},
+		{"prior version", 1, `{"product_id":"p-1","price_cents":1250}`},
+		{"newer version", 3, `{"product_id":"p-1","price":12.5,"currency":"EUR"}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v, err := r.Decode(Envelope{Type: "product.updated", ID: "1", Version: tt.version, Payload: json.RawMessage(tt.payload)})
+			if err != nil {
+				t.Fatalf("Decode: %v", err)
+			}
+			if got := v.(productV2); got != (productV2{ProductID: "p-1", Price: 12.5}) {
+				t.Errorf("decoded %+v", got)
+			}
+		})
+	}
+}
+
+func TestRegistryRejectsUnknownEvents(t *testing.T) {
+	r := NewRegistry()
+	r.Register("product.updated", 2, JSON[productV2]())
+
+	if _, err := r.Decode(Envelope{Type: "product.created", ID: "1", Version: 2, Payload: json.RawMessage(`{}`)}); !errors.Is(err, ErrUnknownEventType) {
+		t.Errorf("unknown type: got %v", err)
+	}
+	if _, err := r.Decode(Envelope{Type: "product.updated", ID: "1", Version: 1, Payload: json.RawMessage(`{}`)}); err == nil {
+		t.Error("an old version without a decoder should fail")
+	}
+}
+
+func TestRegistryDecodeMessage(t *testing.T) {
+	r := newProductRegistry()
+
+	env, v, err := r.DecodeMessage([]byte(`{"type":"product.updated","id":"7","version":2,"payload":{"product_id":"p-1","price":12.5}}`), "product.updated")
+	if err != nil || env.ID != "7" || v.(productV2).Price != 12.5 {
+		t.Fatalf("envelope: %+v, %+v, %v", env, v, err)
+	}
+
+	// Published before the envelope: the body is the payload
+	env, v, err = r.DecodeMessage([]byte(`{"product_id":"p-1","price_cents":1250}`), "product.updated")
+	if err != nil || env.Version != LegacyVersion || v.(productV2).Price != 12.5 {
+		t.Fatalf("legacy: %+v, %+v, %v", env, v, err)
+	}
+}
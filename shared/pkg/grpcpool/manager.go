@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"sync"
 
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
 )
 
@@ -118,6 +119,10 @@ type ServicePoolConfig struct {
 
 	DefaultPoolSize int
 	TLSEnabled      bool
+	MaxMessageSize  int // Largest message sent or received, in bytes; zero uses the default
+
+	// Dial options added to every pool, e.g. client interceptors
+	DialOptions []grpc.DialOption
 }
 
 // CreateCommonPools creates connection pools for all common services
@@ -148,6 +153,10 @@ func (m *Manager) CreateCommonPools(config *ServicePoolConfig) error {
 		poolConfig.PoolSize = config.DefaultPoolSize
 		poolConfig.TLSEnabled = config.TLSEnabled
 		poolConfig.TLSCreds = svc.tlsCreds // Mỗi service có TLS credentials riêng
+		poolConfig.DialOptions = config.DialOptions
+		if config.MaxMessageSize > 0 {
+			poolConfig.MaxMessageSize = config.MaxMessageSize
+		}
 
 		if _, err := m.GetOrCreate(name, poolConfig); err != nil {
 			return fmt.Errorf("failed to create pool for %s: %w", name, err)
@@ -6,6 +6,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/datngth03/ecommerce-go-app/shared/pkg/grpcsize"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/connectivity"
 	"google.golang.org/grpc/credentials"
@@ -41,6 +42,9 @@ type PoolConfig struct {
 	MaxConnectionAge      time.Duration // Max connection lifetime (default: 30min)
 	MaxConnectionAgeGrace time.Duration // Grace period for connection close (default: 5min)
 
+	// Largest message sent or received, in bytes (default: grpcsize.DefaultMaxMessageSize)
+	MaxMessageSize int
+
 	// Additional dial options
 	DialOptions []grpc.DialOption
 }
@@ -55,6 +59,7 @@ func DefaultPoolConfig(target string) *PoolConfig {
 		MaxConnectionIdle:     5 * time.Minute,
 		MaxConnectionAge:      30 * time.Minute,
 		MaxConnectionAgeGrace: 5 * time.Minute,
+		MaxMessageSize:        grpcsize.DefaultMaxMessageSize,
 	}
 }
 
@@ -71,10 +76,7 @@ func NewConnectionPool(config *PoolConfig) (*ConnectionPool, error) {
 			Timeout:             config.KeepaliveTimeout,
 			PermitWithoutStream: true,
 		}),
-		grpc.WithDefaultCallOptions(
-			grpc.MaxCallRecvMsgSize(10*1024*1024), // 10MB
-			grpc.MaxCallSendMsgSize(10*1024*1024), // 10MB
-		),
+		grpcsize.DialOption(config.MaxMessageSize),
 	}
 
 	// Add TLS or insecure credentials
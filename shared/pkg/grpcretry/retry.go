@@ -0,0 +1,86 @@
+// Package grpcretry applies per-call timeouts and retries to outbound gRPC calls
+package grpcretry
+
+import (
+	"context"
+	"math/rand"
+	"time"
+
+	"github.com/datngth03/ecommerce-go-app/shared/pkg/config"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Backoff between retries: doubled after each attempt, capped, with jitter
+const (
+	initialBackoff = 100 * time.Millisecond
+	maxBackoff     = 2 * time.Second
+)
+
+// Policy controls how calls to one downstream service are made
+type Policy struct {
+	Timeout    time.Duration // Per attempt; zero leaves only the caller's deadline
+	MaxRetries int           // Extra attempts for idempotent calls
+}
+
+// PolicyFor builds the call policy of an external service endpoint
+func PolicyFor(endpoint config.ServiceEndpoint) Policy {
+	return Policy{
+		Timeout:    endpoint.Timeout,
+		MaxRetries: endpoint.MaxRetries,
+	}
+}
+
+// Call makes a single attempt of fn under the policy timeout.
+// Use it for calls that are not safe to repeat, such as creating or mutating data.
+func Call[T any](ctx context.Context, p Policy, fn func(ctx context.Context) (T, error)) (T, error) {
+	return attempt(ctx, p.Timeout, fn)
+}
+
+// CallIdempotent makes an attempt of fn under the policy timeout and retries it with
+// backoff on Unavailable or DeadlineExceeded, up to MaxRetries times. Use it only for
+// reads and other calls that are safe to repeat.
+func CallIdempotent[T any](ctx context.Context, p Policy, fn func(ctx context.Context) (T, error)) (T, error) {
+	backoff := initialBackoff
+	for retry := 0; ; retry++ {
+		resp, err := attempt(ctx, p.Timeout, fn)
+		if err == nil || retry >= p.MaxRetries || !Retryable(ctx, err) {
+			return resp, err
+		}
+
+		delay := backoff/2 + time.Duration(rand.Int63n(int64(backoff/2)+1))
+		select {
+		case <-ctx.Done():
+			return resp, err
+		case <-time.After(delay):
+		}
+
+		backoff *= 2
+		if backoff > maxBackoff {
+			backoff = maxBackoff
+		}
+	}
+}
+
+// Retryable reports whether err is a transient failure worth another attempt.
+// Deadline errors only count when they come from the attempt, not from ctx itself.
+func Retryable(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded:
+		return true
+	}
+	return false
+}
+
+// attempt runs fn once, bounded by timeout when it is set
+func attempt[T any](ctx context.Context, timeout time.Duration, fn func(ctx context.Context) (T, error)) (T, error) {
+	if timeout <= 0 {
+		return fn(ctx)
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	return fn(ctx)
+}
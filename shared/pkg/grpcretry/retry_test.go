@@ -0,0 +1,89 @@
+package grpcretry
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestCallIdempotentRetriesTransientErrors(t *testing.T) {
+	policy := Policy{Timeout: time.Second, MaxRetries: 2}
+
+	tests := []struct {
+		name      string
+		err       error
+		wantCalls int
+	}{
+		{"Unavailable", status.Error(codes.Unavailable, "connection refused"), 3},
+		{"DeadlineExceeded", status.Error(codes.DeadlineExceeded, "timeout"), 3},
+		{"NotFound", status.Error(codes.NotFound, "no such product"), 1},
+		{"Internal", status.Error(codes.Internal, "boom"), 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			_, err := CallIdempotent(context.Background(), policy, func(ctx context.Context) (int, error) {
+				calls++
+				return 0, tt.err
+			})
+			if status.Code(err) != status.Code(tt.err) {
+				t.Errorf("error = %v, want %v", err, tt.err)
+			}
+			if calls != tt.wantCalls {
+				t.Errorf("calls = %d, want %d", calls, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestCallIdempotentSucceedsAfterBlip(t *testing.T) {
+	calls := 0
+	got, err := CallIdempotent(context.Background(), Policy{MaxRetries: 2}, func(ctx context.Context) (string, error) {
+		calls++
+		if calls == 1 {
+			return "", status.Error(codes.Unavailable, "connection reset")
+		}
+		return "ok", nil
+	})
+	if err != nil || got != "ok" || calls != 2 {
+		t.Fatalf("got %q, %v after %d calls; want ok after 2", got, err, calls)
+	}
+}
+
+func TestCallDoesNotRetry(t *testing.T) {
+	calls := 0
+	_, err := Call(context.Background(), Policy{MaxRetries: 3}, func(ctx context.Context) (int, error) {
+		calls++
+		return 0, status.Error(codes.Unavailable, "connection refused")
+	})
+	if err == nil || calls != 1 {
+		t.Fatalf("calls = %d, err = %v; want a single failed attempt", calls, err)
+	}
+}
+
+func TestCallAppliesTimeout(t *testing.T) {
+	_, err := Call(context.Background(), Policy{Timeout: 10 * time.Millisecond}, func(ctx context.Context) (int, error) {
+		<-ctx.Done()
+		return 0, status.FromContextError(ctx.Err()).Err()
+	})
+	if status.Code(err) != codes.DeadlineExceeded {
+		t.Fatalf("error = %v, want DeadlineExceeded", err)
+	}
+}
+
+func TestCallIdempotentStopsWhenCallerIsDone(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	calls := 0
+	_, err := CallIdempotent(ctx, Policy{MaxRetries: 5}, func(ctx context.Context) (int, error) {
+		calls++
+		cancel()
+		return 0, status.Error(codes.Unavailable, "connection refused")
+	})
+	if err == nil || calls != 1 {
+		t.Fatalf("calls = %d, err = %v; want no retry after the caller gave up", calls, err)
+	}
+}
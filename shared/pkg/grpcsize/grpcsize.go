@@ -0,0 +1,64 @@
+// Package grpcsize applies one gRPC message size limit to servers and clients.
+// gRPC defaults to 4MB for received messages, so without a shared limit a large
+// response can pass one hop and fail the next.
+package grpcsize
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// DefaultMaxMessageSize is used when no limit is configured. It is large enough
+// for a page of 100 products with descriptions and images.
+const DefaultMaxMessageSize = 10 * 1024 * 1024
+
+// orDefault returns limit, or DefaultMaxMessageSize if it is not positive
+func orDefault(limit int) int {
+	if limit <= 0 {
+		return DefaultMaxMessageSize
+	}
+	return limit
+}
+
+// ServerOptions limits the messages a server receives and sends to limit bytes.
+// A response over the limit fails with ResourceExhausted and a message asking
+// for a smaller page, instead of the transport's generic size error.
+func ServerOptions(limit int) []grpc.ServerOption {
+	limit = orDefault(limit)
+	return []grpc.ServerOption{
+		grpc.MaxRecvMsgSize(limit),
+		grpc.MaxSendMsgSize(limit),
+		grpc.ChainUnaryInterceptor(UnaryServerInterceptor(limit)),
+	}
+}
+
+// DialOption limits the messages a client sends and receives to limit bytes
+func DialOption(limit int) grpc.DialOption {
+	limit = orDefault(limit)
+	return grpc.WithDefaultCallOptions(
+		grpc.MaxCallRecvMsgSize(limit),
+		grpc.MaxCallSendMsgSize(limit),
+	)
+}
+
+// UnaryServerInterceptor rejects responses larger than limit bytes
+func UnaryServerInterceptor(limit int) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		resp, err := handler(ctx, req)
+		if err != nil {
+			return resp, err
+		}
+		if msg, ok := resp.(proto.Message); ok {
+			if size := proto.Size(msg); size > limit {
+				return nil, status.Errorf(codes.ResourceExhausted,
+					"response of %s is %d bytes, over the %d byte message limit; request a smaller page",
+					info.FullMethod, size, limit)
+			}
+		}
+		return resp, nil
+	}
+}
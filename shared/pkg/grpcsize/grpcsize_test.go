@@ -0,0 +1,43 @@
+package grpcsize
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	intercept := UnaryServerInterceptor(64)
+	info := &grpc.UnaryServerInfo{FullMethod: "/product_service.ProductService/ListProducts"}
+	respond := func(value string) grpc.UnaryHandler {
+		return func(context.Context, interface{}) (interface{}, error) {
+			return wrapperspb.String(value), nil
+		}
+	}
+
+	if _, err := intercept(context.Background(), nil, info, respond("small")); err != nil {
+		t.Fatalf("small response rejected: %v", err)
+	}
+
+	_, err := intercept(context.Background(), nil, info, respond(strings.Repeat("x", 100)))
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("code = %v, want ResourceExhausted", status.Code(err))
+	}
+	if !strings.Contains(err.Error(), "smaller page") {
+		t.Errorf("error %q does not suggest paging", err)
+	}
+}
+
+func TestOrDefault(t *testing.T) {
+	if got := orDefault(0); got != DefaultMaxMessageSize {
+		t.Errorf("orDefault(0) = %d, want %d", got, DefaultMaxMessageSize)
+	}
+	if got := orDefault(1024); got != 1024 {
+		t.Errorf("orDefault(1024) = %d, want 1024", got)
+	}
+}
@@ -0,0 +1,116 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"google.golang.org/grpc"
+)
+
+// Component is a part of a service that must be stopped on shutdown.
+// *http.Server implements it as is.
+type Component interface {
+	Shutdown(ctx context.Context) error
+}
+
+// ComponentFunc adapts a function to Component
+type ComponentFunc func(ctx context.Context) error
+
+// Shutdown calls f
+func (f ComponentFunc) Shutdown(ctx context.Context) error {
+	return f(ctx)
+}
+
+// Closer adapts a Close method (database, Redis, broker connections) to Component
+func Closer(close func() error) Component {
+	return ComponentFunc(func(context.Context) error {
+		return close()
+	})
+}
+
+// GRPCServer stops a gRPC server gracefully, letting in-flight RPCs finish;
+// when ctx expires first the remaining RPCs are cancelled
+func GRPCServer(server *grpc.Server) Component {
+	return ComponentFunc(func(ctx context.Context) error {
+		done := make(chan struct{})
+		go func() {
+			server.GracefulStop()
+			close(done)
+		}()
+
+		select {
+		case <-done:
+			return nil
+		case <-ctx.Done():
+			server.Stop()
+			<-done
+			return fmt.Errorf("graceful stop timed out, in-flight RPCs cancelled")
+		}
+	})
+}
+
+type namedComponent struct {
+	name      string
+	component Component
+}
+
+// Manager stops a service's components in registration order when the service
+// shuts down. Register servers first so they stop taking requests before the
+// background workers and connections they depend on are closed.
+type Manager struct {
+	service    string
+	timeout    time.Duration
+	components []namedComponent
+}
+
+// NewManager creates a manager; timeout bounds the whole shutdown
+func NewManager(service string, timeout time.Duration) *Manager {
+	return &Manager{service: service, timeout: timeout}
+}
+
+// Register adds a component to be stopped after the ones registered before it
+func (m *Manager) Register(name string, component Component) {
+	m.components = append(m.components, namedComponent{name: name, component: component})
+}
+
+// Wait blocks until SIGINT or SIGTERM, then shuts the service down
+func (m *Manager) Wait() error {
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(quit)
+
+	log.Printf("%s is running. Press Ctrl+C to exit...", m.service)
+	sig := <-quit
+	log.Printf("Shutting down %s (%v)...", m.service, sig)
+
+	return m.Shutdown()
+}
+
+// Shutdown stops every component in registration order within the total timeout.
+// A failing component does not stop the rest; all errors are returned together.
+func (m *Manager) Shutdown() error {
+	ctx, cancel := context.WithTimeout(context.Background(), m.timeout)
+	defer cancel()
+
+	var errs []error
+	for _, c := range m.components {
+		if err := c.component.Shutdown(ctx); err != nil {
+			log.Printf("Error stopping %s: %v", c.name, err)
+			errs = append(errs, fmt.Errorf("%s: %w", c.name, err))
+			continue
+		}
+		log.Printf("✓ %s stopped", c.name)
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return err
+	}
+	log.Printf("%s stopped", m.service)
+	return nil
+}
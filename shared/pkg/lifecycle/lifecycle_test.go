@@ -0,0 +1,58 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestManagerShutdownOrder(t *testing.T) {
+	var stopped []string
+	record := func(name string, err error) Component {
+		return ComponentFunc(func(context.Context) error {
+			stopped = append(stopped, name)
+			return err
+		})
+	}
+
+	m := NewManager("test-service", time.Second)
+	m.Register("http server", record("http", nil))
+	m.Register("grpc server", record("grpc", errors.New("boom")))
+	m.Register("database", record("db", nil))
+
+	err := m.Shutdown()
+	if want := []string{"http", "grpc", "db"}; !reflect.DeepEqual(stopped, want) {
+		t.Errorf("stopped %v, want %v", stopped, want)
+	}
+	if err == nil || !strings.Contains(err.Error(), "grpc server: boom") {
+		t.Errorf("Shutdown() error = %v, want the grpc server failure", err)
+	}
+}
+
+func TestManagerShutdownTimeout(t *testing.T) {
+	var deadlineExceeded bool
+	m := NewManager("test-service", 20*time.Millisecond)
+	m.Register("slow", ComponentFunc(func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}))
+	// The timeout covers the whole shutdown, so later components see the expired context
+	m.Register("after", ComponentFunc(func(ctx context.Context) error {
+		deadlineExceeded = ctx.Err() != nil
+		return nil
+	}))
+
+	start := time.Now()
+	if err := m.Shutdown(); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Shutdown() error = %v, want deadline exceeded", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Shutdown() took %v", elapsed)
+	}
+	if !deadlineExceeded {
+		t.Error("component after the timeout got a live context")
+	}
+}
@@ -0,0 +1,92 @@
+package logger
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/datngth03/ecommerce-go-app/shared/pkg/config"
+)
+
+// level is shared by the installed handler so it can be changed at runtime
+var level = new(slog.LevelVar)
+
+// Init installs the process-wide logger from LOG_LEVEL, LOG_FORMAT and LOG_OUTPUT.
+// Output of the standard log package goes through it too, at info level.
+func Init(cfg config.LoggingConfig) error {
+	lvl, err := ParseLevel(cfg.Level)
+	if err != nil {
+		return err
+	}
+
+	out, err := openOutput(cfg)
+	if err != nil {
+		return err
+	}
+	handler, err := newHandler(out, cfg.Format)
+	if err != nil {
+		return err
+	}
+
+	level.Set(lvl)
+	slog.SetDefault(slog.New(handler))
+	// The handler adds its own timestamp
+	log.SetFlags(0)
+	return nil
+}
+
+// Level returns the current level name, e.g. "info"
+func Level() string {
+	return strings.ToLower(level.Level().String())
+}
+
+// SetLevel changes the level of the installed logger
+func SetLevel(name string) error {
+	lvl, err := ParseLevel(name)
+	if err != nil {
+		return err
+	}
+	level.Set(lvl)
+	return nil
+}
+
+// ParseLevel parses debug, info, warn or error
+func ParseLevel(name string) (slog.Level, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "debug":
+		return slog.LevelDebug, nil
+	case "info", "":
+		return slog.LevelInfo, nil
+	case "warn", "warning":
+		return slog.LevelWarn, nil
+	case "error":
+		return slog.LevelError, nil
+	}
+	return 0, fmt.Errorf("unknown log level %q (want debug, info, warn or error)", name)
+}
+
+// newHandler writes JSON, or key=value lines for the console format
+func newHandler(w io.Writer, format string) (slog.Handler, error) {
+	opts := &slog.HandlerOptions{Level: level}
+	switch strings.ToLower(format) {
+	case "json":
+		return slog.NewJSONHandler(w, opts), nil
+	case "console", "text":
+		return slog.NewTextHandler(w, opts), nil
+	}
+	return nil, fmt.Errorf("unknown log format %q (want json or console)", format)
+}
+
+func openOutput(cfg config.LoggingConfig) (io.Writer, error) {
+	if cfg.Output != "file" {
+		return os.Stdout, nil
+	}
+	file, err := os.OpenFile(cfg.FilePath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open log file: %w", err)
+	}
+	return file, nil
+}
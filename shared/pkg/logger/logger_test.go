@@ -0,0 +1,65 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestSetLevelAtRuntime(t *testing.T) {
+	var buf bytes.Buffer
+	handler, err := newHandler(&buf, "json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	logger := slog.New(handler)
+	if err := SetLevel("info"); err != nil {
+		t.Fatal(err)
+	}
+
+	logger.Debug("hidden")
+	if buf.Len() != 0 {
+		t.Fatalf("debug logged at info level: %s", buf.String())
+	}
+
+	if err := SetLevel("DEBUG"); err != nil {
+		t.Fatal(err)
+	}
+	logger.Debug("shown", "order_id", "o-1")
+	var entry map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("output is not JSON: %q", buf.String())
+	}
+	if entry["msg"] != "shown" || entry["order_id"] != "o-1" || entry["level"] != "DEBUG" {
+		t.Errorf("unexpected entry %v", entry)
+	}
+	if Level() != "debug" {
+		t.Errorf("Level() = %q, want debug", Level())
+	}
+
+	if err := SetLevel("verbose"); err == nil {
+		t.Error("SetLevel accepted an unknown level")
+	}
+	if Level() != "debug" {
+		t.Errorf("a rejected level changed the level to %q", Level())
+	}
+}
+
+func TestConsoleFormat(t *testing.T) {
+	var buf bytes.Buffer
+	handler, err := newHandler(&buf, "console")
+	if err != nil {
+		t.Fatal(err)
+	}
+	SetLevel("info")
+	slog.New(handler).Info("started", "port", 8000)
+	if out := buf.String(); !strings.Contains(out, "msg=started") || !strings.Contains(out, "port=8000") {
+		t.Errorf("console output = %q", out)
+	}
+
+	if _, err := newHandler(&buf, "xml"); err == nil {
+		t.Error("newHandler accepted an unknown format")
+	}
+}
@@ -0,0 +1,161 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CORSConfig configures CORSWithConfig
+type CORSConfig struct {
+	AllowedOrigins   []string // "*" allows any origin, but never with credentials
+	AllowedMethods   []string
+	AllowedHeaders   []string
+	ExposeHeaders    []string // Response headers readable by the browser
+	AllowCredentials bool     // Cookies and Authorization on cross-origin requests
+	MaxAge           time.Duration
+	// OriginPolicies overrides the allowed methods and headers for single origins,
+	// e.g. an admin UI that may use DELETE. Listed origins are allowed.
+	OriginPolicies map[string]CORSOriginPolicy
+}
+
+// CORSOriginPolicy holds the methods and headers allowed for one origin;
+// empty lists fall back to the config's
+type CORSOriginPolicy struct {
+	AllowedMethods []string
+	AllowedHeaders []string
+}
+
+// DefaultCORSConfig is the configuration CORSMiddleware uses for a list of origins
+func DefaultCORSConfig(allowedOrigins []string) CORSConfig {
+	return CORSConfig{
+		AllowedOrigins: allowedOrigins,
+		AllowedMethods: []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodDelete, http.MethodOptions},
+		AllowedHeaders: []string{"Content-Type", "Authorization"},
+		MaxAge:         24 * time.Hour,
+	}
+}
+
+// Validate rejects configurations browsers would refuse or that are unsafe.
+// A wildcard origin with credentials is disallowed by the CORS spec.
+func (cfg CORSConfig) Validate() error {
+	if cfg.AllowCredentials {
+		for _, origin := range cfg.AllowedOrigins {
+			if origin == "*" {
+				return fmt.Errorf("CORS credentials cannot be allowed for the wildcard origin")
+			}
+		}
+	}
+	if cfg.MaxAge < 0 {
+		return fmt.Errorf("CORS max age must not be negative")
+	}
+	return nil
+}
+
+// CORSWithConfig handles CORS. Preflight requests (OPTIONS with
+// Access-Control-Request-Method) are answered with 204 and not passed on; the
+// allow headers are only sent when the origin, method and headers are all allowed.
+// Origins matched by "*" get "Access-Control-Allow-Origin: *" and never credentials.
+func CORSWithConfig(cfg CORSConfig) gin.HandlerFunc {
+	wildcard := false
+	origins := make(map[string]bool, len(cfg.AllowedOrigins)+len(cfg.OriginPolicies))
+	for _, origin := range cfg.AllowedOrigins {
+		if origin == "*" {
+			wildcard = true
+			continue
+		}
+		origins[origin] = true
+	}
+	for origin := range cfg.OriginPolicies {
+		origins[origin] = true
+	}
+	exposeHeaders := strings.Join(cfg.ExposeHeaders, ", ")
+	maxAge := strconv.Itoa(int(cfg.MaxAge.Seconds()))
+
+	return func(c *gin.Context) {
+		origin := c.Request.Header.Get("Origin")
+		preflight := c.Request.Method == http.MethodOptions && c.Request.Header.Get("Access-Control-Request-Method") != ""
+
+		// The response depends on Origin unless every origin gets the same answer
+		if !wildcard || len(origins) > 0 {
+			c.Writer.Header().Add("Vary", "Origin")
+		}
+
+		explicit := origins[origin]
+		if origin == "" || (!explicit && !wildcard) {
+			if preflight {
+				c.AbortWithStatus(http.StatusNoContent)
+				return
+			}
+			c.Next()
+			return
+		}
+
+		methods, headers := cfg.AllowedMethods, cfg.AllowedHeaders
+		if policy, ok := cfg.OriginPolicies[origin]; ok {
+			if len(policy.AllowedMethods) > 0 {
+				methods = policy.AllowedMethods
+			}
+			if len(policy.AllowedHeaders) > 0 {
+				headers = policy.AllowedHeaders
+			}
+		}
+
+		if preflight {
+			if !containsFold(methods, c.Request.Header.Get("Access-Control-Request-Method")) ||
+				!allHeadersAllowed(headers, c.Request.Header.Get("Access-Control-Request-Headers")) {
+				c.AbortWithStatus(http.StatusNoContent)
+				return
+			}
+		}
+
+		if explicit {
+			c.Header("Access-Control-Allow-Origin", origin)
+			if cfg.AllowCredentials {
+				c.Header("Access-Control-Allow-Credentials", "true")
+			}
+		} else {
+			c.Header("Access-Control-Allow-Origin", "*")
+		}
+
+		if preflight {
+			c.Header("Access-Control-Allow-Methods", strings.Join(methods, ", "))
+			c.Header("Access-Control-Allow-Headers", strings.Join(headers, ", "))
+			if cfg.MaxAge > 0 {
+				c.Header("Access-Control-Max-Age", maxAge)
+			}
+			c.AbortWithStatus(http.StatusNoContent)
+			return
+		}
+
+		if exposeHeaders != "" {
+			c.Header("Access-Control-Expose-Headers", exposeHeaders)
+		}
+		c.Next()
+	}
+}
+
+// allHeadersAllowed reports whether every header in a comma-separated
+// Access-Control-Request-Headers value is allowed
+func allHeadersAllowed(allowed []string, requested string) bool {
+	for _, header := range strings.Split(requested, ",") {
+		header = strings.TrimSpace(header)
+		if header != "" && !containsFold(allowed, header) {
+			return false
+		}
+	}
+	return true
+}
+
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,121 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func corsRouter(cfg CORSConfig) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(CORSWithConfig(cfg))
+	router.Any("/products", func(c *gin.Context) { c.Status(http.StatusOK) })
+	return router
+}
+
+func corsRequest(router *gin.Engine, method, origin string, headers map[string]string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, "/products", nil)
+	if origin != "" {
+		req.Header.Set("Origin", origin)
+	}
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestCORSPreflight(t *testing.T) {
+	cfg := DefaultCORSConfig([]string{"https://shop.example.com"})
+	cfg.AllowCredentials = true
+	cfg.MaxAge = 10 * time.Minute
+	cfg.OriginPolicies = map[string]CORSOriginPolicy{
+		"https://admin.example.com": {AllowedMethods: []string{http.MethodGet, http.MethodPatch}},
+	}
+	router := corsRouter(cfg)
+
+	tests := []struct {
+		name        string
+		origin      string
+		method      string
+		headers     string
+		wantAllowed bool
+	}{
+		{"allowed origin and method", "https://shop.example.com", http.MethodPut, "content-type, authorization", true},
+		{"method not allowed", "https://shop.example.com", http.MethodPatch, "", false},
+		{"header not allowed", "https://shop.example.com", http.MethodPost, "X-Custom", false},
+		{"unknown origin", "https://evil.example.com", http.MethodGet, "", false},
+		{"per-origin method", "https://admin.example.com", http.MethodPatch, "", true},
+		{"per-origin policy replaces methods", "https://admin.example.com", http.MethodPut, "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := corsRequest(router, http.MethodOptions, tt.origin, map[string]string{
+				"Access-Control-Request-Method":  tt.method,
+				"Access-Control-Request-Headers": tt.headers,
+			})
+			if w.Code != http.StatusNoContent {
+				t.Fatalf("status = %d, want 204", w.Code)
+			}
+			gotOrigin := w.Header().Get("Access-Control-Allow-Origin")
+			if tt.wantAllowed {
+				if gotOrigin != tt.origin || w.Header().Get("Access-Control-Allow-Credentials") != "true" ||
+					w.Header().Get("Access-Control-Max-Age") != "600" || w.Header().Get("Access-Control-Allow-Methods") == "" {
+					t.Errorf("preflight not allowed: headers %v", w.Header())
+				}
+			} else if gotOrigin != "" {
+				t.Errorf("Access-Control-Allow-Origin = %q, want none", gotOrigin)
+			}
+		})
+	}
+}
+
+func TestCORSSimpleRequest(t *testing.T) {
+	cfg := DefaultCORSConfig([]string{"https://shop.example.com"})
+	cfg.ExposeHeaders = []string{"X-Request-ID"}
+	router := corsRouter(cfg)
+
+	w := corsRequest(router, http.MethodGet, "https://shop.example.com", nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", w.Code)
+	}
+	if got := w.Header().Get("Access-Control-Allow-Origin"); got != "https://shop.example.com" {
+		t.Errorf("Access-Control-Allow-Origin = %q", got)
+	}
+	if got := w.Header().Get("Access-Control-Expose-Headers"); got != "X-Request-ID" {
+		t.Errorf("Access-Control-Expose-Headers = %q", got)
+	}
+	if got := w.Header().Get("Access-Control-Allow-Credentials"); got != "" {
+		t.Errorf("credentials allowed without AllowCredentials")
+	}
+	if got := w.Header().Get("Vary"); got != "Origin" {
+		t.Errorf("Vary = %q, want Origin", got)
+	}
+
+	// Requests from other origins are served without CORS headers
+	w = corsRequest(router, http.MethodGet, "https://evil.example.com", nil)
+	if w.Code != http.StatusOK || w.Header().Get("Access-Control-Allow-Origin") != "" {
+		t.Errorf("unknown origin: status %d, headers %v", w.Code, w.Header())
+	}
+}
+
+func TestCORSWildcardNeverAllowsCredentials(t *testing.T) {
+	cfg := DefaultCORSConfig([]string{"*"})
+	cfg.AllowCredentials = true
+	if err := cfg.Validate(); err == nil {
+		t.Error("Validate() accepted a wildcard origin with credentials")
+	}
+
+	w := corsRequest(corsRouter(cfg), http.MethodGet, "https://any.example.com", nil)
+	if got := w.Header().Get("Access-Control-Allow-Origin"); got != "*" {
+		t.Errorf("Access-Control-Allow-Origin = %q, want *", got)
+	}
+	if got := w.Header().Get("Access-Control-Allow-Credentials"); got != "" {
+		t.Errorf("Access-Control-Allow-Credentials = %q for a wildcard origin", got)
+	}
+}
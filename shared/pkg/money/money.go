@@ -0,0 +1,135 @@
+// Package money does price arithmetic in integer minor units, so that totals,
+// discounts and taxes are rounded once, the same way everywhere, and never end
+// in fractions of a cent
+package money
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Mode decides which way a value exactly halfway between two minor units goes
+type Mode int
+
+const (
+	HalfUp   Mode = iota // Ties away from zero: 0.125 -> 0.13
+	HalfEven             // Banker's rounding, ties to the even unit: 0.125 -> 0.12
+)
+
+// MaxPrecision bounds the decimal places so minor units fit in an int64
+const MaxPrecision = 6
+
+// ParseMode parses a rounding mode name: half_up or half_even (alias bankers)
+func ParseMode(s string) (Mode, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "", "half_up":
+		return HalfUp, nil
+	case "half_even", "bankers":
+		return HalfEven, nil
+	}
+	return HalfUp, fmt.Errorf("unknown rounding mode %q (want half_up or half_even)", s)
+}
+
+func (m Mode) String() string {
+	if m == HalfEven {
+		return "half_even"
+	}
+	return "half_up"
+}
+
+// Amount is a money amount in minor units of the currency, e.g. cents
+type Amount int64
+
+// Mul returns the amount times quantity; it is exact
+func (a Amount) Mul(quantity int32) Amount {
+	return a * Amount(quantity)
+}
+
+// Rounding converts float64 prices to and from Amounts with a fixed number of decimal places
+type Rounding struct {
+	Mode      Mode
+	Precision int // Decimal places of the currency, 2 for cents
+}
+
+// NewRounding returns a Rounding, clamping precision to [0, MaxPrecision]
+func NewRounding(mode Mode, precision int) Rounding {
+	if precision < 0 {
+		precision = 0
+	}
+	if precision > MaxPrecision {
+		precision = MaxPrecision
+	}
+	return Rounding{Mode: mode, Precision: precision}
+}
+
+// FromFloat rounds v to minor units. v is read as the shortest decimal that
+// prints as it, so 2.675 is treated as 2.675 and not as the binary value just below.
+// NaN and infinities are zero.
+func (r Rounding) FromFloat(v float64) Amount {
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return 0
+	}
+	x, _ := new(big.Rat).SetString(strconv.FormatFloat(v, 'f', -1, 64))
+	return r.round(x)
+}
+
+// Float returns a as a float64 in major units, e.g. 1999 cents as 19.99
+func (r Rounding) Float(a Amount) float64 {
+	return float64(a) / math.Pow10(r.Precision)
+}
+
+// Round rounds v to the currency precision
+func (r Rounding) Round(v float64) float64 {
+	return r.Float(r.FromFloat(v))
+}
+
+// Percent returns percent of a, rounded once. It serves percentage discounts and tax rates.
+func (r Rounding) Percent(a Amount, percent float64) Amount {
+	if math.IsNaN(percent) || math.IsInf(percent, 0) {
+		return 0
+	}
+	p, _ := new(big.Rat).SetString(strconv.FormatFloat(percent, 'f', -1, 64))
+	x := new(big.Rat).Mul(new(big.Rat).SetInt64(int64(a)), p)
+	x.Quo(x, big.NewRat(100, 1))
+	// x is already in minor units, so it is rounded at precision 0
+	return Rounding{Mode: r.Mode}.round(x)
+}
+
+// Share returns the part/whole share of a, rounded once, e.g. the part of an order
+// discount that falls on some of its items. A zero whole gives zero.
+func (r Rounding) Share(a, part, whole Amount) Amount {
+	if whole == 0 {
+		return 0
+	}
+	x := new(big.Rat).SetFrac(new(big.Int).Mul(big.NewInt(int64(a)), big.NewInt(int64(part))), big.NewInt(int64(whole)))
+	// x is already in minor units, so it is rounded at precision 0
+	return Rounding{Mode: r.Mode}.round(x)
+}
+
+// round rounds x, in major units, to minor units
+func (r Rounding) round(x *big.Rat) Amount {
+	scaled := new(big.Rat).Mul(x, new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(r.Precision)), nil)))
+	// QuoRem truncates toward zero; rem has the sign of the numerator
+	q, rem := new(big.Int).QuoRem(scaled.Num(), scaled.Denom(), new(big.Int))
+
+	twice := new(big.Int).Abs(rem)
+	twice.Lsh(twice, 1)
+	awayFromZero := false
+	switch twice.Cmp(scaled.Denom()) {
+	case 1:
+		awayFromZero = true
+	case 0:
+		awayFromZero = r.Mode == HalfUp || q.Bit(0) == 1
+	}
+	if awayFromZero {
+		if scaled.Sign() < 0 {
+			q.Sub(q, big.NewInt(1))
+		} else {
+			q.Add(q, big.NewInt(1))
+		}
+	}
+	return Amount(q.Int64())
+}
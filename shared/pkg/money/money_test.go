@@ -0,0 +1,141 @@
+package money
+
+import "testing"
+
+func TestFromFloat(t *testing.T) {
+	halfUp := NewRounding(HalfUp, 2)
+	halfEven := NewRounding(HalfEven, 2)
+
+	tests := []struct {
+		name           string
+		v              float64
+		halfUp, banker Amount
+	}{
+		{"exact", 19.99, 1999, 1999},
+		{"binary just below half", 2.675, 268, 268},
+		{"tie to even down", 0.125, 13, 12},
+		{"tie to even up", 0.135, 14, 14},
+		{"classic 1.005", 1.005, 101, 100},
+		{"below half", 1.004, 100, 100},
+		{"above half", 1.0051, 101, 101},
+		{"negative tie", -0.125, -13, -12},
+		{"negative above half", -2.346, -235, -235},
+		{"zero", 0, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := halfUp.FromFloat(tt.v); got != tt.halfUp {
+				t.Errorf("half up FromFloat(%v) = %d, want %d", tt.v, got, tt.halfUp)
+			}
+			if got := halfEven.FromFloat(tt.v); got != tt.banker {
+				t.Errorf("half even FromFloat(%v) = %d, want %d", tt.v, got, tt.banker)
+			}
+		})
+	}
+}
+
+func TestSumHasNoDrift(t *testing.T) {
+	r := NewRounding(HalfUp, 2)
+
+	// 0.1 + 0.2 and 19.99 * 3 drift in float64
+	var total Amount
+	for _, price := range []float64{0.1, 0.2} {
+		total += r.FromFloat(price)
+	}
+	if got := r.Float(total); got != 0.3 {
+		t.Errorf("0.1 + 0.2 = %v, want 0.3", got)
+	}
+	if got := r.Float(r.FromFloat(19.99).Mul(3)); got != 59.97 {
+		t.Errorf("19.99 * 3 = %v, want 59.97", got)
+	}
+}
+
+func TestPercent(t *testing.T) {
+	halfUp := NewRounding(HalfUp, 2)
+	halfEven := NewRounding(HalfEven, 2)
+
+	tests := []struct {
+		name           string
+		amount         Amount
+		percent        float64
+		halfUp, banker Amount
+	}{
+		{"whole", 10000, 10, 1000, 1000},
+		{"tie", 25, 10, 3, 2},                    // 2.5 cents
+		{"odd tie", 35, 10, 4, 4},                // 3.5 cents
+		{"fractional rate", 1999, 7.5, 150, 150}, // 149.925 cents
+		{"repeating", 1000, 33.333333, 333, 333},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := halfUp.Percent(tt.amount, tt.percent); got != tt.halfUp {
+				t.Errorf("half up Percent(%d, %v) = %d, want %d", tt.amount, tt.percent, got, tt.halfUp)
+			}
+			if got := halfEven.Percent(tt.amount, tt.percent); got != tt.banker {
+				t.Errorf("half even Percent(%d, %v) = %d, want %d", tt.amount, tt.percent, got, tt.banker)
+			}
+		})
+	}
+}
+
+func TestShare(t *testing.T) {
+	halfUp := NewRounding(HalfUp, 2)
+	halfEven := NewRounding(HalfEven, 2)
+
+	// A 10.00 discount on a 30.00 order, 10.00 of which is returned: 3.33
+	if got := halfUp.Share(1000, 1000, 3000); got != 333 {
+		t.Errorf("Share(1000, 1000, 3000) = %d, want 333", got)
+	}
+	// 5 cents split in half ties
+	if got := halfUp.Share(5, 1, 2); got != 3 {
+		t.Errorf("half up Share(5, 1, 2) = %d, want 3", got)
+	}
+	if got := halfEven.Share(5, 1, 2); got != 2 {
+		t.Errorf("half even Share(5, 1, 2) = %d, want 2", got)
+	}
+	if got := halfUp.Share(1000, 1, 0); got != 0 {
+		t.Errorf("Share with zero whole = %d, want 0", got)
+	}
+}
+
+func TestPrecision(t *testing.T) {
+	vnd := NewRounding(HalfUp, 0)
+	if got := vnd.FromFloat(5079745.5); got != 5079746 {
+		t.Errorf("precision 0: got %d, want 5079746", got)
+	}
+	if got := vnd.Float(5079746); got != 5079746 {
+		t.Errorf("precision 0 Float: got %v", got)
+	}
+
+	three := NewRounding(HalfEven, 3)
+	if got := three.Round(1.2345); got != 1.234 {
+		t.Errorf("precision 3 Round(1.2345) = %v, want 1.234", got)
+	}
+
+	if r := NewRounding(HalfUp, 12); r.Precision != MaxPrecision {
+		t.Errorf("precision not clamped: %d", r.Precision)
+	}
+}
+
+func TestParseMode(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    Mode
+		wantErr bool
+	}{
+		{"", HalfUp, false},
+		{"half_up", HalfUp, false},
+		{" HALF_EVEN ", HalfEven, false},
+		{"bankers", HalfEven, false},
+		{"truncate", HalfUp, true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseMode(tt.in)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseMode(%q) = %v, %v; want %v, error %v", tt.in, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
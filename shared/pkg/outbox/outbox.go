@@ -0,0 +1,58 @@
+package outbox
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Event is a message waiting in the outbox table to be published.
+// The table is created by each adopting service's migrations:
+//
+//	CREATE TABLE outbox_events (
+//	    id         BIGSERIAL PRIMARY KEY,
+//	    topic      VARCHAR(255) NOT NULL,
+//	    event_key  VARCHAR(255) NOT NULL DEFAULT '',
+//	    payload    JSONB NOT NULL,
+//	    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+//	    sent_at    TIMESTAMP WITH TIME ZONE
+//	);
+//	CREATE INDEX idx_outbox_events_unsent ON outbox_events (id) WHERE sent_at IS NULL;
+type Event struct {
+	ID        int64
+	Topic     string // Routing key, e.g. "product.updated"
+	Key       string // ID of the entity the event is about
+	Payload   []byte // JSON
+	CreatedAt time.Time
+}
+
+// Publisher delivers events to a message broker. Publish must return only once the
+// broker has accepted the event; the relay marks it sent after that.
+// Delivery is at least once, so consumers should ignore event IDs they have seen.
+type Publisher interface {
+	Publish(ctx context.Context, event Event) error
+}
+
+// Execer is satisfied by *sql.Tx (and *sql.DB)
+type Execer interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+}
+
+// Enqueue stores an event with its JSON payload. Call it with the transaction that
+// changes the entity: the event is then published if and only if the change commits.
+func Enqueue(ctx context.Context, tx Execer, topic, key string, payload interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s event: %w", topic, err)
+	}
+	_, err = tx.ExecContext(ctx,
+		`INSERT INTO outbox_events (topic, event_key, payload) VALUES ($1, $2, $3)`,
+		topic, key, body,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to store %s event: %w", topic, err)
+	}
+	return nil
+}
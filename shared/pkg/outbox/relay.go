@@ -0,0 +1,120 @@
+package outbox
+
+import (
+	"context"
+	"database/sql"
+	"log"
+	"time"
+)
+
+// cleanupInterval is how often sent events older than the retention are deleted
+const cleanupInterval = time.Hour
+
+// RelayConfig contains relay settings
+type RelayConfig struct {
+	PollInterval time.Duration // Wait between polls once the outbox is drained
+	BatchSize    int           // Events published per transaction
+	Retention    time.Duration // How long sent events are kept; 0 keeps them
+
+	// Optional hooks for metrics. ObserveLag receives the age of the oldest
+	// unsent event after each poll, 0 when the outbox is drained.
+	ObservePublished func(topic string, err error)
+	ObserveLag       func(lag time.Duration)
+}
+
+// Relay publishes committed outbox events in order and marks them sent. An event is
+// marked only after the publisher accepted it, so a crash in between publishes it
+// again: delivery is at least once. A failed publish stops the batch, and the event
+// is retried on the next poll. With relays in several replicas events can be
+// delivered out of order, so consumers should not rely on ordering across events.
+type Relay struct {
+	store     store
+	publisher Publisher
+	cfg       RelayConfig
+}
+
+// NewRelay creates a relay for the outbox_events table in db
+func NewRelay(db *sql.DB, publisher Publisher, cfg RelayConfig) *Relay {
+	return newRelay(&postgresStore{db: db}, publisher, cfg)
+}
+
+func newRelay(s store, publisher Publisher, cfg RelayConfig) *Relay {
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = time.Second
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = 100
+	}
+	return &Relay{store: s, publisher: publisher, cfg: cfg}
+}
+
+// Run relays events until ctx is done
+func (r *Relay) Run(ctx context.Context) {
+	log.Printf("Outbox relay started (poll interval: %v, batch size: %d)", r.cfg.PollInterval, r.cfg.BatchSize)
+	lastCleanup := time.Now()
+
+	for {
+		r.drain(ctx)
+		r.observeLag(ctx)
+
+		if r.cfg.Retention > 0 && time.Since(lastCleanup) >= cleanupInterval {
+			if deleted, err := r.store.deleteSent(ctx, time.Now().Add(-r.cfg.Retention)); err != nil {
+				log.Printf("Outbox cleanup failed: %v", err)
+			} else if deleted > 0 {
+				log.Printf("Deleted %d sent outbox events", deleted)
+			}
+			lastCleanup = time.Now()
+		}
+
+		select {
+		case <-ctx.Done():
+			log.Println("Outbox relay stopped")
+			return
+		case <-time.After(r.cfg.PollInterval):
+		}
+	}
+}
+
+// drain publishes full batches until the outbox is empty or a publish fails
+func (r *Relay) drain(ctx context.Context) {
+	for ctx.Err() == nil {
+		failed := false
+		sent, err := r.store.publishBatch(ctx, r.cfg.BatchSize, func(events []Event) int {
+			for i, event := range events {
+				err := r.publisher.Publish(ctx, event)
+				if r.cfg.ObservePublished != nil {
+					r.cfg.ObservePublished(event.Topic, err)
+				}
+				if err != nil {
+					log.Printf("Failed to publish outbox event %d (%s): %v", event.ID, event.Topic, err)
+					failed = true
+					return i
+				}
+			}
+			return len(events)
+		})
+		if err != nil {
+			log.Printf("Outbox relay error: %v", err)
+			return
+		}
+		if failed || sent < r.cfg.BatchSize {
+			return
+		}
+	}
+}
+
+func (r *Relay) observeLag(ctx context.Context) {
+	if r.cfg.ObserveLag == nil {
+		return
+	}
+	oldest, ok, err := r.store.oldestUnsent(ctx)
+	if err != nil {
+		log.Printf("Outbox relay error: %v", err)
+		return
+	}
+	if !ok {
+		r.cfg.ObserveLag(0)
+		return
+	}
+	r.cfg.ObserveLag(time.Since(oldest))
+}
@@ -0,0 +1,149 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+// memoryStore is an outbox held in memory
+type memoryStore struct {
+	mu     sync.Mutex
+	events []Event
+	sent   map[int64]time.Time
+}
+
+func (s *memoryStore) publishBatch(_ context.Context, limit int, publish func([]Event) int) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var batch []Event
+	for _, e := range s.events {
+		if _, ok := s.sent[e.ID]; !ok && len(batch) < limit {
+			batch = append(batch, e)
+		}
+	}
+	if len(batch) == 0 {
+		return 0, nil
+	}
+	n := publish(batch)
+	for _, e := range batch[:n] {
+		s.sent[e.ID] = time.Now()
+	}
+	return n, nil
+}
+
+func (s *memoryStore) oldestUnsent(context.Context) (time.Time, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, e := range s.events {
+		if _, ok := s.sent[e.ID]; !ok {
+			return e.CreatedAt, true, nil
+		}
+	}
+	return time.Time{}, false, nil
+}
+
+func (s *memoryStore) deleteSent(_ context.Context, before time.Time) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var kept []Event
+	var deleted int64
+	for _, e := range s.events {
+		if sentAt, ok := s.sent[e.ID]; ok && sentAt.Before(before) {
+			deleted++
+			continue
+		}
+		kept = append(kept, e)
+	}
+	s.events = kept
+	return deleted, nil
+}
+
+// flakyPublisher fails the events listed in failOnce the first time they are published
+type flakyPublisher struct {
+	published []int64
+	failOnce  map[int64]bool
+}
+
+func (p *flakyPublisher) Publish(_ context.Context, event Event) error {
+	if p.failOnce[event.ID] {
+		delete(p.failOnce, event.ID)
+		return errors.New("broker unavailable")
+	}
+	p.published = append(p.published, event.ID)
+	return nil
+}
+
+func TestRelayPublishesInOrderAndRetries(t *testing.T) {
+	created := time.Now().Add(-time.Minute)
+	store := &memoryStore{sent: map[int64]time.Time{}}
+	for id := int64(1); id <= 5; id++ {
+		store.events = append(store.events, Event{ID: id, Topic: "product.updated", CreatedAt: created})
+	}
+	publisher := &flakyPublisher{failOnce: map[int64]bool{3: true}}
+
+	var lags []time.Duration
+	var failures int
+	relay := newRelay(store, publisher, RelayConfig{
+		BatchSize: 2,
+		ObservePublished: func(_ string, err error) {
+			if err != nil {
+				failures++
+			}
+		},
+		ObserveLag: func(lag time.Duration) { lags = append(lags, lag) },
+	})
+
+	// The failure stops the relay at event 3 so later events are not published ahead of it
+	relay.drain(context.Background())
+	relay.observeLag(context.Background())
+	if want := []int64{1, 2}; !reflect.DeepEqual(publisher.published, want) {
+		t.Fatalf("published %v, want %v", publisher.published, want)
+	}
+	if failures != 1 || lags[0] < time.Minute {
+		t.Errorf("failures = %d, lag = %v; want 1 failure and the age of event 3", failures, lags[0])
+	}
+
+	// The next poll retries it and drains the outbox
+	relay.drain(context.Background())
+	relay.observeLag(context.Background())
+	if want := []int64{1, 2, 3, 4, 5}; !reflect.DeepEqual(publisher.published, want) {
+		t.Fatalf("published %v, want %v", publisher.published, want)
+	}
+	if lags[1] != 0 {
+		t.Errorf("lag = %v after draining, want 0", lags[1])
+	}
+
+	// Sent events are removed once past the retention
+	if deleted, _ := store.deleteSent(context.Background(), time.Now().Add(time.Second)); deleted != 5 {
+		t.Errorf("deleted %d events, want 5", deleted)
+	}
+}
+
+func TestRelayRunStopsWithContext(t *testing.T) {
+	store := &memoryStore{sent: map[int64]time.Time{}}
+	store.events = []Event{{ID: 1, Topic: "product.created", CreatedAt: time.Now()}}
+	publisher := &flakyPublisher{}
+	relay := newRelay(store, publisher, RelayConfig{PollInterval: 10 * time.Millisecond})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		relay.Run(ctx)
+		close(done)
+	}()
+	time.Sleep(30 * time.Millisecond)
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Run did not return after cancel")
+	}
+	if !reflect.DeepEqual(publisher.published, []int64{1}) {
+		t.Errorf("published %v, want [1]", publisher.published)
+	}
+}
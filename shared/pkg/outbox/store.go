@@ -0,0 +1,101 @@
+package outbox
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// store is the outbox table as seen by the relay
+type store interface {
+	// publishBatch locks up to limit unsent events in ID order, passes them to
+	// publish and marks the first n it reports as sent, all in one transaction
+	publishBatch(ctx context.Context, limit int, publish func([]Event) int) (int, error)
+	// oldestUnsent returns when the oldest unsent event was stored
+	oldestUnsent(ctx context.Context) (time.Time, bool, error)
+	// deleteSent removes events sent before the given time
+	deleteSent(ctx context.Context, before time.Time) (int64, error)
+}
+
+// postgresStore keeps the outbox in PostgreSQL. Rows are locked with SKIP LOCKED,
+// so relays in several replicas of a service share the work instead of repeating it.
+type postgresStore struct {
+	db *sql.DB
+}
+
+func (s *postgresStore) publishBatch(ctx context.Context, limit int, publish func([]Event) int) (int, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.QueryContext(ctx, `
+		SELECT id, topic, event_key, payload, created_at
+		FROM outbox_events
+		WHERE sent_at IS NULL
+		ORDER BY id
+		LIMIT $1
+		FOR UPDATE SKIP LOCKED`, limit)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read outbox: %w", err)
+	}
+	var events []Event
+	for rows.Next() {
+		var e Event
+		if err := rows.Scan(&e.ID, &e.Topic, &e.Key, &e.Payload, &e.CreatedAt); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("failed to scan outbox event: %w", err)
+		}
+		events = append(events, e)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("failed to read outbox: %w", err)
+	}
+	if len(events) == 0 {
+		return 0, nil
+	}
+
+	sent := publish(events)
+	if sent == 0 {
+		return 0, nil
+	}
+	// List the IDs: a range could cover rows another relay has locked
+	placeholders := make([]string, sent)
+	ids := make([]interface{}, sent)
+	for i, e := range events[:sent] {
+		placeholders[i] = fmt.Sprintf("$%d", i+1)
+		ids[i] = e.ID
+	}
+	_, err = tx.ExecContext(ctx,
+		`UPDATE outbox_events SET sent_at = NOW() WHERE id IN (`+strings.Join(placeholders, ", ")+`)`,
+		ids...,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("failed to mark events sent: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to mark events sent: %w", err)
+	}
+	return sent, nil
+}
+
+func (s *postgresStore) oldestUnsent(ctx context.Context) (time.Time, bool, error) {
+	var oldest sql.NullTime
+	err := s.db.QueryRowContext(ctx, `SELECT MIN(created_at) FROM outbox_events WHERE sent_at IS NULL`).Scan(&oldest)
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("failed to read outbox lag: %w", err)
+	}
+	return oldest.Time, oldest.Valid, nil
+}
+
+func (s *postgresStore) deleteSent(ctx context.Context, before time.Time) (int64, error) {
+	result, err := s.db.ExecContext(ctx, `DELETE FROM outbox_events WHERE sent_at < $1`, before)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete sent events: %w", err)
+	}
+	return result.RowsAffected()
+}
@@ -0,0 +1,27 @@
+// Package paging computes the paging metadata returned with list responses
+package paging
+
+// Meta tells clients how many pages a listing has and whether another page follows
+type Meta struct {
+	TotalPages int32
+	HasMore    bool
+}
+
+// ForPage returns the metadata of the 1-based page of pageSize items out of total
+func ForPage(page, pageSize, total int64) Meta {
+	if page < 1 {
+		page = 1
+	}
+	return ForOffset((page-1)*pageSize, pageSize, total)
+}
+
+// ForOffset returns the metadata of the limit items starting at offset out of total
+func ForOffset(offset, limit, total int64) Meta {
+	if limit <= 0 {
+		return Meta{}
+	}
+	return Meta{
+		TotalPages: int32((total + limit - 1) / limit),
+		HasMore:    offset+limit < total,
+	}
+}
@@ -0,0 +1,36 @@
+package paging
+
+import "testing"
+
+func TestForPage(t *testing.T) {
+	tests := []struct {
+		name                  string
+		page, pageSize, total int64
+		want                  Meta
+	}{
+		{"first of several", 1, 10, 25, Meta{TotalPages: 3, HasMore: true}},
+		{"last partial page", 3, 10, 25, Meta{TotalPages: 3, HasMore: false}},
+		{"exact fit", 2, 10, 20, Meta{TotalPages: 2, HasMore: false}},
+		{"past the end", 5, 10, 20, Meta{TotalPages: 2, HasMore: false}},
+		{"empty", 1, 10, 0, Meta{TotalPages: 0, HasMore: false}},
+		{"page zero treated as first", 0, 10, 15, Meta{TotalPages: 2, HasMore: true}},
+		{"no page size", 1, 0, 15, Meta{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ForPage(tt.page, tt.pageSize, tt.total); got != tt.want {
+				t.Errorf("ForPage(%d, %d, %d) = %+v, want %+v", tt.page, tt.pageSize, tt.total, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestForOffset(t *testing.T) {
+	if got := ForOffset(5, 10, 16); got != (Meta{TotalPages: 2, HasMore: true}) {
+		t.Errorf("ForOffset(5, 10, 16) = %+v", got)
+	}
+	if got := ForOffset(10, 10, 20); got.HasMore {
+		t.Errorf("ForOffset(10, 10, 20) = %+v, want no more", got)
+	}
+}
@@ -6,7 +6,9 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/datngth03/ecommerce-go-app/shared/pkg/config"
 	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
 )
 
 // ServerTLSConfig creates TLS configuration for gRPC servers
@@ -49,6 +51,49 @@ func ClientTLSConfig(caFile, serverName string) (credentials.TransportCredential
 	return credentials.NewTLS(tlsConfig), nil
 }
 
+// MutualServerTLSConfig creates mTLS configuration for gRPC servers: clients must
+// present a certificate signed by the CA in caFile or the handshake fails
+func MutualServerTLSConfig(certFile, keyFile, caFile string) (credentials.TransportCredentials, error) {
+	tlsConfig, err := mutualServerConfig(certFile, keyFile, caFile)
+	if err != nil {
+		return nil, err
+	}
+	return credentials.NewTLS(tlsConfig), nil
+}
+
+// MutualClientTLSConfig creates mTLS configuration for gRPC clients: the server is
+// verified against caFile and the certificate in certFile/keyFile is presented to it
+func MutualClientTLSConfig(caFile, certFile, keyFile, serverName string) (credentials.TransportCredentials, error) {
+	tlsConfig, err := mutualClientConfig(caFile, certFile, keyFile, serverName)
+	if err != nil {
+		return nil, err
+	}
+	return credentials.NewTLS(tlsConfig), nil
+}
+
+// ServerCredentials returns the gRPC server credentials for a service's TLS settings:
+// mTLS when ClientAuth is set, server-only TLS otherwise. TLS must be enabled.
+func ServerCredentials(cfg config.TLSConfig) (credentials.TransportCredentials, error) {
+	if cfg.ClientAuth {
+		return MutualServerTLSConfig(cfg.CertFile, cfg.KeyFile, cfg.CAFile)
+	}
+	return ServerTLSConfig(cfg.CertFile, cfg.KeyFile)
+}
+
+// ClientCredentials returns the transport credentials for calling another service:
+// insecure when TLS is disabled (local development), otherwise TLS verified against
+// the CA for serverName, presenting the service's own certificate when ClientAuth is set
+func ClientCredentials(cfg config.TLSConfig, serverName string) (credentials.TransportCredentials, error) {
+	switch {
+	case !cfg.Enabled:
+		return insecure.NewCredentials(), nil
+	case cfg.ClientAuth:
+		return MutualClientTLSConfig(cfg.CAFile, cfg.CertFile, cfg.KeyFile, serverName)
+	default:
+		return ClientTLSConfig(cfg.CAFile, serverName)
+	}
+}
+
 // HTTPServerTLSConfig creates TLS configuration for HTTP servers (Gin, etc.)
 // This provides a secure TLS config with modern cipher suites
 func HTTPServerTLSConfig() *tls.Config {
@@ -98,3 +143,52 @@ func IsTLSEnabled(certFile, keyFile string) bool {
 
 	return true
 }
+
+func mutualServerConfig(certFile, keyFile, caFile string) (*tls.Config, error) {
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load server certificate: %w", err)
+	}
+	certPool, err := loadCertPool(caFile)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		ClientCAs:    certPool,
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		MinVersion:   tls.VersionTLS12,
+	}, nil
+}
+
+func mutualClientConfig(caFile, certFile, keyFile, serverName string) (*tls.Config, error) {
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load client certificate: %w", err)
+	}
+	certPool, err := loadCertPool(caFile)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		RootCAs:      certPool,
+		ServerName:   serverName,
+		MinVersion:   tls.VersionTLS12,
+	}, nil
+}
+
+// loadCertPool reads the CA certificates in caFile into a pool
+func loadCertPool(caFile string) (*x509.CertPool, error) {
+	caCert, err := os.ReadFile(caFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CA certificate: %w", err)
+	}
+	certPool := x509.NewCertPool()
+	if !certPool.AppendCertsFromPEM(caCert) {
+		return nil, fmt.Errorf("failed to append CA certificate to pool")
+	}
+	return certPool, nil
+}
@@ -0,0 +1,166 @@
+package tlsutil
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// writeCert issues a certificate for name signed by parent (self-signed when nil)
+// and writes it and its key as PEM files in dir
+func writeCert(t *testing.T, dir, name string, isCA bool, parent *x509.Certificate, parentKey *ecdsa.PrivateKey) (*x509.Certificate, *ecdsa.PrivateKey) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: name},
+		DNSNames:     []string{name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	if isCA {
+		template.IsCA = true
+		template.BasicConstraintsValid = true
+		template.KeyUsage |= x509.KeyUsageCertSign
+	}
+	if parent == nil {
+		parent, parentKey = template, key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	writePEM(t, filepath.Join(dir, name+"-cert.pem"), "CERTIFICATE", der)
+	writePEM(t, filepath.Join(dir, name+"-key.pem"), "EC PRIVATE KEY", keyDER)
+
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cert, key
+}
+
+func writePEM(t *testing.T, path, blockType string, der []byte) {
+	t.Helper()
+	if err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestMutualTLSRequiresClientCertificate(t *testing.T) {
+	dir := t.TempDir()
+	ca, caKey := writeCert(t, dir, "ca", true, nil, nil)
+	writeCert(t, dir, "order-service", false, ca, caKey)
+	writeCert(t, dir, "payment-service", false, ca, caKey)
+	caFile := filepath.Join(dir, "ca-cert.pem")
+
+	serverConfig, err := mutualServerConfig(
+		filepath.Join(dir, "order-service-cert.pem"), filepath.Join(dir, "order-service-key.pem"), caFile)
+	if err != nil {
+		t.Fatalf("mutualServerConfig() error = %v", err)
+	}
+	listener, err := tls.Listen("tcp", "127.0.0.1:0", serverConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+
+	// The server reports each handshake's outcome
+	handshakes := make(chan error)
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			err = conn.(*tls.Conn).Handshake()
+			if err == nil {
+				_, err = conn.Write([]byte("ok"))
+			}
+			conn.Close()
+			handshakes <- err
+		}
+	}()
+
+	dial := func(config *tls.Config) error {
+		conn, err := tls.Dial("tcp", listener.Addr().String(), config)
+		if err != nil {
+			<-handshakes
+			return err
+		}
+		defer conn.Close()
+		// With TLS 1.3 the client finishes its handshake before the server checks
+		// the client certificate, so a rejection only shows on the first read
+		_, readErr := io.ReadAll(conn)
+		if err := <-handshakes; err != nil {
+			return err
+		}
+		return readErr
+	}
+
+	certPool, err := loadCertPool(caFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := dial(&tls.Config{RootCAs: certPool, ServerName: "order-service"}); err == nil {
+		t.Error("client without a certificate was accepted")
+	}
+
+	clientConfig, err := mutualClientConfig(caFile,
+		filepath.Join(dir, "payment-service-cert.pem"), filepath.Join(dir, "payment-service-key.pem"), "order-service")
+	if err != nil {
+		t.Fatalf("mutualClientConfig() error = %v", err)
+	}
+	if err := dial(clientConfig); err != nil {
+		t.Errorf("client with a CA-signed certificate was rejected: %v", err)
+	}
+
+	// A certificate from another CA is rejected as well
+	otherDir := t.TempDir()
+	otherCA, otherKey := writeCert(t, otherDir, "ca", true, nil, nil)
+	writeCert(t, otherDir, "payment-service", false, otherCA, otherKey)
+	strangerConfig, err := mutualClientConfig(caFile,
+		filepath.Join(otherDir, "payment-service-cert.pem"), filepath.Join(otherDir, "payment-service-key.pem"), "order-service")
+	if err != nil {
+		t.Fatalf("mutualClientConfig() error = %v", err)
+	}
+	if err := dial(strangerConfig); err == nil {
+		t.Error("client with a certificate from an unknown CA was accepted")
+	}
+}
+
+func TestMutualTLSLoadErrors(t *testing.T) {
+	dir := t.TempDir()
+	ca, caKey := writeCert(t, dir, "ca", true, nil, nil)
+	writeCert(t, dir, "order-service", false, ca, caKey)
+	cert, key := filepath.Join(dir, "order-service-cert.pem"), filepath.Join(dir, "order-service-key.pem")
+
+	if _, err := MutualServerTLSConfig(cert, key, filepath.Join(dir, "missing.pem")); err == nil {
+		t.Error("expected an error for a missing CA file")
+	}
+	// A key is not a CA certificate
+	if _, err := MutualClientTLSConfig(key, cert, key, "order-service"); err == nil {
+		t.Error("expected an error for a CA file without certificates")
+	}
+}
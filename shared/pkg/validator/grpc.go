@@ -0,0 +1,16 @@
+package validator
+
+import (
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ValidateUUIDArg checks an ID taken from an RPC request with ValidateUUID and reports
+// a bad one as an InvalidArgument status. Handlers call it before the ID reaches SQL,
+// where a malformed UUID would fail as an internal database error.
+func ValidateUUIDArg(id, fieldName string) error {
+	if err := ValidateUUID(id, fieldName); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	return nil
+}
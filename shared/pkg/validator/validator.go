@@ -18,8 +18,8 @@ var (
 	// Alphanumeric with spaces, hyphens, and underscores
 	alphanumericRegex = regexp.MustCompile(`^[a-zA-Z0-9\s\-_]+$`)
 
-	// UUID v4 pattern
-	uuidRegex = regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+	// Canonical UUID pattern, any version (PostgreSQL's UUID type accepts them all)
+	uuidRegex = regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$`)
 )
 
 // Common validation errors
@@ -144,7 +144,7 @@ func ValidateAlphanumeric(value, fieldName string) error {
 	return nil
 }
 
-// ValidateUUID validates UUID v4 format
+// ValidateUUID validates canonical UUID format
 func ValidateUUID(uuid, fieldName string) error {
 	if uuid == "" {
 		return fmt.Errorf("%s %w", fieldName, ErrRequired)
@@ -2,6 +2,9 @@ package validator
 
 import (
 	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 func TestValidateEmail(t *testing.T) {
@@ -104,6 +107,42 @@ func TestValidateRequired(t *testing.T) {
 	}
 }
 
+func TestValidateUUID(t *testing.T) {
+	tests := []struct {
+		name    string
+		id      string
+		wantErr bool
+	}{
+		{"Valid v4", "3f2504e0-4f89-41d3-9a0c-0305e82c3301", false},
+		{"Valid v1", "6ba7b810-9dad-11d1-80b4-00c04fd430c8", false},
+		{"Upper case", "3F2504E0-4F89-41D3-9A0C-0305E82C3301", false},
+		{"Empty", "", true},
+		{"Not a UUID", "abc", true},
+		{"Numeric ID", "42", true},
+		{"Missing hyphens", "3f2504e04f8941d39a0c0305e82c3301", true},
+		{"Trailing characters", "3f2504e0-4f89-41d3-9a0c-0305e82c3301'", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateUUID(tt.id, "id")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateUUID() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateUUIDArg(t *testing.T) {
+	if err := ValidateUUIDArg("3f2504e0-4f89-41d3-9a0c-0305e82c3301", "order_id"); err != nil {
+		t.Fatalf("valid ID: %v", err)
+	}
+	err := ValidateUUIDArg("abc", "order_id")
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("got %v, want InvalidArgument", err)
+	}
+}
+
 func TestValidateLength(t *testing.T) {
 	tests := []struct {
 		name      string
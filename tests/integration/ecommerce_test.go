@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"sync"
 	"testing"
 	"time"
 
@@ -361,6 +362,66 @@ func TestInventoryManagement(t *testing.T) {
 	})
 }
 
+// TestConcurrentCartUpdates hammers a single cart from many goroutines and checks no update is lost
+func TestConcurrentCartUpdates(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test")
+	}
+
+	token := getTestToken(t)
+	productIDs := []string{createTestProduct(t, token), createTestProduct(t, token)}
+
+	const addsPerProduct = 15
+
+	var wg sync.WaitGroup
+	statuses := make(chan int, addsPerProduct*len(productIDs))
+	for i := 0; i < addsPerProduct; i++ {
+		for _, productID := range productIDs {
+			wg.Add(1)
+			go func(productID string) {
+				defer wg.Done()
+
+				payload := map[string]interface{}{
+					"product_id": productID,
+					"quantity":   1,
+				}
+				resp, err := makeRequest("POST", "/cart", payload, token)
+				if err != nil {
+					statuses <- 0
+					return
+				}
+				resp.Body.Close()
+				statuses <- resp.StatusCode
+			}(productID)
+		}
+	}
+	wg.Wait()
+	close(statuses)
+
+	for code := range statuses {
+		require.Equal(t, http.StatusOK, code)
+	}
+
+	resp, err := makeRequest("GET", "/cart", nil, token)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var result map[string]interface{}
+	err = json.NewDecoder(resp.Body).Decode(&result)
+	require.NoError(t, err)
+
+	data := result["data"].(map[string]interface{})
+	items := data["items"].([]interface{})
+	require.Len(t, items, len(productIDs))
+
+	for _, raw := range items {
+		item := raw.(map[string]interface{})
+		assert.Contains(t, productIDs, item["product_id"])
+		assert.Equal(t, float64(addsPerProduct), item["quantity"])
+	}
+}
+
 // Helper Functions
 
 func makeRequest(method, endpoint string, payload interface{}, token string) (*http.Response, error) {